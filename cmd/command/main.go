@@ -1,6 +1,9 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"runtime/debug"
 
 	"github.com/iota-uz/iota-sdk/modules"
@@ -13,11 +16,46 @@ func panicWithStack(err error) {
 }
 
 func main() {
-	// TODO: Add more commands here like so:
+	// Usage:
 	// go run cmd/command/main.go check_tr_keys
-	// go run cmd/command/main.go some_other_command
+	// go run cmd/command/main.go check_rls
+	// go run cmd/command/main.go export_tenant <tenant-id> <file.zip>
+	// go run cmd/command/main.go import_tenant <file.zip> [name] [subdomain]
 
-	if err := commands.CheckTrKeys(modules.BuiltInModules...); err != nil {
+	command := "check_tr_keys"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	var err error
+	switch command {
+	case "check_tr_keys":
+		err = commands.CheckTrKeys(modules.BuiltInModules...)
+	case "check_rls":
+		err = commands.CheckRLS()
+	case "export_tenant":
+		if len(os.Args) < 4 {
+			err = errors.New("usage: export_tenant <tenant-id> <file.zip>")
+			break
+		}
+		err = commands.ExportTenant(os.Args[2], os.Args[3], modules.BuiltInModules...)
+	case "import_tenant":
+		if len(os.Args) < 3 {
+			err = errors.New("usage: import_tenant <file.zip> [name] [subdomain]")
+			break
+		}
+		var name, subdomain string
+		if len(os.Args) > 3 {
+			name = os.Args[3]
+		}
+		if len(os.Args) > 4 {
+			subdomain = os.Args[4]
+		}
+		err = commands.ImportTenant(os.Args[2], name, subdomain, modules.BuiltInModules...)
+	default:
+		err = fmt.Errorf("unknown command: %s", command)
+	}
+	if err != nil {
 		panicWithStack(err)
 	}
 }
@@ -13,10 +13,14 @@ import (
 	"github.com/iota-uz/iota-sdk/modules"
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	loggingservices "github.com/iota-uz/iota-sdk/modules/logging/services"
+	webhookservices "github.com/iota-uz/iota-sdk/modules/webhooks/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 	"github.com/iota-uz/iota-sdk/pkg/logging"
+	"github.com/iota-uz/iota-sdk/pkg/rls"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
@@ -49,7 +53,14 @@ func main() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
-	pool, err := pgxpool.New(ctx, conf.Database.Opts)
+	poolConfig, err := pgxpool.ParseConfig(conf.Database.Opts)
+	if err != nil {
+		panic(err)
+	}
+	if conf.Database.RLS {
+		rls.Configure(poolConfig)
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		panic(err)
 	}
@@ -78,6 +89,28 @@ func main() {
 		controllers.NewStaticFilesController(app.HashFsAssets()),
 		controllers.NewGraphQLController(app),
 	)
+	jobWorker := services.NewJobWorker(
+		app,
+		persistence.NewUserRepository(persistence.NewUploadRepository()),
+		services.DefaultJobWorkerOptions(),
+	)
+	go func() {
+		if err := jobWorker.Run(context.Background()); err != nil {
+			logger.WithError(err).Error("job worker stopped")
+		}
+	}()
+	retentionWorker := loggingservices.NewRetentionWorker(app)
+	go func() {
+		if err := retentionWorker.Run(context.Background()); err != nil {
+			logger.WithError(err).Error("action log retention worker stopped")
+		}
+	}()
+	deliveryWorker := webhookservices.NewDeliveryWorker(app, webhookservices.DefaultDeliveryWorkerOptions())
+	go func() {
+		if err := deliveryWorker.Run(context.Background()); err != nil {
+			logger.WithError(err).Error("webhook delivery worker stopped")
+		}
+	}()
 	options := &server.DefaultOptions{
 		Logger:        logger,
 		Configuration: conf,
@@ -12,7 +12,9 @@ import (
 	icons "github.com/iota-uz/icons/phosphor"
 	"github.com/iota-uz/iota-sdk/components/base/button"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/excel"
 	"net/http"
+	"net/url"
 )
 
 type ExportFormat int
@@ -22,6 +24,8 @@ const (
 	ExportFormatCSV
 	ExportFormatJSON
 	ExportFormatTXT
+	ExportFormatODS
+	ExportFormatPDF
 )
 
 var formatDetails = map[ExportFormat]struct {
@@ -49,6 +53,46 @@ var formatDetails = map[ExportFormat]struct {
 		LabelKey: "Export.ToTXT",
 		Param:    "txt",
 	},
+	ExportFormatODS: {
+		Icon:     icons.FileXls(icons.Props{Size: "16"}),
+		LabelKey: "Export.ToODS",
+		Param:    "ods",
+	},
+	ExportFormatPDF: {
+		Icon:     icons.FilePdf(icons.Props{Size: "16"}),
+		LabelKey: "Export.ToPDF",
+		Param:    "pdf",
+	},
+}
+
+// exportPreset is an additional dropdown entry of a format that sends extra query parameters
+type exportPreset struct {
+	LabelKey string
+	Params   url.Values
+}
+
+// formatPresets are listed right after their format. The CSV ones are what spreadsheet
+// applications with a Russian or Uzbek locale open without the import dialog
+var formatPresets = map[ExportFormat][]exportPreset{
+	ExportFormatCSV: {
+		{
+			LabelKey: "Export.ToCSVExcel1251",
+			Params:   url.Values{"delimiter": {";"}, "encoding": {"windows-1251"}},
+		},
+		{
+			LabelKey: "Export.ToCSVExcelUTF8",
+			Params:   url.Values{"delimiter": {";"}, "encoding": {"utf-8-bom"}},
+		},
+	},
+}
+
+// exportURL adds the format and the preset parameters to the export URL
+func exportURL(base string, format string, params url.Values) string {
+	query := url.Values{"format": {format}}
+	for k, v := range params {
+		query[k] = v
+	}
+	return base + "?" + query.Encode()
 }
 
 var formatParamMap = map[string]ExportFormat{
@@ -56,6 +100,21 @@ var formatParamMap = map[string]ExportFormat{
 	"csv":   ExportFormatCSV,
 	"json":  ExportFormatJSON,
 	"txt":   ExportFormatTXT,
+	"ods":   ExportFormatODS,
+	"pdf":   ExportFormatPDF,
+}
+
+var fileFormats = map[ExportFormat]excel.Format{
+	ExportFormatExcel: excel.FormatXLSX,
+	ExportFormatCSV:   excel.FormatCSV,
+	ExportFormatODS:   excel.FormatODS,
+	ExportFormatPDF:   excel.FormatPDF,
+}
+
+var csvEncodings = map[string]excel.CSVEncoding{
+	"utf-8":        excel.CSVEncodingUTF8,
+	"utf-8-bom":    excel.CSVEncodingUTF8BOM,
+	"windows-1251": excel.CSVEncodingWindows1251,
 }
 
 // GetExportFormat extracts and validates the export format from an HTTP request
@@ -77,6 +136,28 @@ func GetExportFormatString(format ExportFormat) string {
 	return ""
 }
 
+// GetFileFormat returns the file format produced by the excel package for an ExportFormat
+func GetFileFormat(format ExportFormat) (excel.Format, bool) {
+	f, exists := fileFormats[format]
+	return f, exists
+}
+
+// GetCSVOptions reads optional "delimiter" and "encoding" query parameters of a CSV export request
+func GetCSVOptions(r *http.Request) *excel.CSVOptions {
+	opts := excel.DefaultCSVOptions()
+	query := r.URL.Query()
+	switch delimiter := query.Get("delimiter"); delimiter {
+	case "tab":
+		opts.Delimiter = '\t'
+	case ",", ";", "|":
+		opts.Delimiter = rune(delimiter[0])
+	}
+	if encoding, exists := csvEncodings[query.Get("encoding")]; exists {
+		opts.Encoding = encoding
+	}
+	return opts
+}
+
 // IsValidExportFormat checks if a string is a valid export format
 func IsValidExportFormat(formatStr string) bool {
 	_, exists := formatParamMap[formatStr]
@@ -126,7 +207,7 @@ func ExportDropdown(props ExportDropdownProps) templ.Component {
 			var templ_7745c5c3_Var2 string
 			templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(props.Label)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 94, Col: 18}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 175, Col: 18}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 			if templ_7745c5c3_Err != nil {
@@ -136,7 +217,7 @@ func ExportDropdown(props ExportDropdownProps) templ.Component {
 			var templ_7745c5c3_Var3 string
 			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(pageCtx.T("Export"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 96, Col: 26}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 177, Col: 26}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
@@ -147,7 +228,7 @@ func ExportDropdown(props ExportDropdownProps) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</summary><ul class=\"flex flex-col gap-1 mt-1 absolute bg-surface-300 right-0 text-sm rounded-md w-44 overflow-hidden shadow-sm border border-secondary p-1\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</summary><ul class=\"flex flex-col gap-1 mt-1 absolute bg-surface-300 right-0 text-sm rounded-md w-60 overflow-hidden shadow-sm border border-secondary p-1\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -158,9 +239,9 @@ func ExportDropdown(props ExportDropdownProps) templ.Component {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var4 string
-			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(props.ExportURL + "?format=" + details.Param)
+			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(exportURL(props.ExportURL, details.Param, nil))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 106, Col: 61}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 187, Col: 63}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 			if templ_7745c5c3_Err != nil {
@@ -185,7 +266,7 @@ func ExportDropdown(props ExportDropdownProps) templ.Component {
 			var templ_7745c5c3_Var5 string
 			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(pageCtx.T(details.LabelKey))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 113, Col: 36}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 194, Col: 36}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 			if templ_7745c5c3_Err != nil {
@@ -195,8 +276,52 @@ func ExportDropdown(props ExportDropdownProps) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
+			for _, preset := range formatPresets[format] {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "<li><button class=\"flex items-center gap-2 w-full text-left p-2 duration-200 hover:bg-surface-400 rounded-md\" hx-post=\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var6 string
+				templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(exportURL(props.ExportURL, details.Param, preset.Params))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 201, Col: 74}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\" hx-target=\"body\" hx-swap=\"none\" hx-on::after-request=\"this.closest(&#39;details&#39;).removeAttribute(&#39;open&#39;)\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templ.RenderAttributes(ctx, templ_7745c5c3_Buffer, props.Attrs)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, ">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = details.Icon.Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var7 string
+				templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(pageCtx.T(preset.LabelKey))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/export/export_dropdown.templ`, Line: 208, Col: 36}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</button></li>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</ul></details> <details class=\"hidden peer-open:block\" name=\"export-dropdown\"><summary class=\"fixed w-full h-full left-0 top-0\"></summary></details></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "</ul></details> <details class=\"hidden peer-open:block\" name=\"export-dropdown\"><summary class=\"fixed w-full h-full left-0 top-0\"></summary></details></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
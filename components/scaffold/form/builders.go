@@ -687,10 +687,10 @@ func (b *ColorFieldBuilder) Build() ColorField {
 
 // SearchSelectFieldBuilder for async search select fields
 type SearchSelectFieldBuilder struct {
-	key, label, defaultVal, endpoint, placeholder string
-	required                                      bool
-	attrs                                         templ.Attributes
-	validators                                    []Validator
+	key, label, defaultVal, valueLabel, endpoint, placeholder string
+	required                                                  bool
+	attrs                                                     templ.Attributes
+	validators                                                []Validator
 }
 
 func SearchSelect() *SearchSelectFieldBuilder {
@@ -733,7 +733,13 @@ func (b *SearchSelectFieldBuilder) WithRequired(required bool) *SearchSelectFiel
 }
 
 func (b *SearchSelectFieldBuilder) WithValue(value string) *SearchSelectFieldBuilder {
-	// This is handled in the field itself
+	b.defaultVal = value
+	return b
+}
+
+// ValueLabel sets the text shown for the selected value until options are searched
+func (b *SearchSelectFieldBuilder) ValueLabel(label string) *SearchSelectFieldBuilder {
+	b.valueLabel = label
 	return b
 }
 
@@ -752,6 +758,7 @@ func (b *SearchSelectFieldBuilder) Build() SearchSelectField {
 		key:         b.key,
 		label:       b.label,
 		defaultVal:  b.defaultVal,
+		valueLabel:  b.valueLabel,
 		endpoint:    b.endpoint,
 		placeholder: b.placeholder,
 		required:    b.required,
@@ -793,6 +793,7 @@ type searchSelectField struct {
 	label       string
 	value       string
 	defaultVal  string
+	valueLabel  string
 	endpoint    string
 	placeholder string
 	required    bool
@@ -801,21 +802,7 @@ type searchSelectField struct {
 }
 
 func (f *searchSelectField) Component() templ.Component {
-	attrs := templ.Attributes{}
-	if f.required {
-		attrs["required"] = true
-	}
-	for k, v := range f.attrs {
-		attrs[k] = v
-	}
-
-	// For now, return a placeholder component
-	// TODO: Integrate with actual selects.SearchSelect component
-	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
-		// Render search select component placeholder
-		_, err := io.WriteString(w, `<div class="search-select-wrapper" data-name="`+html.EscapeString(f.key)+`" data-endpoint="`+html.EscapeString(f.endpoint)+`" data-placeholder="`+html.EscapeString(f.placeholder)+`" data-value="`+html.EscapeString(f.value)+`">SearchSelect: `+html.EscapeString(f.label)+`</div>`)
-		return err
-	})
+	return searchSelect(f)
 }
 
 func (f *searchSelectField) Key() string             { return f.key }
@@ -828,6 +815,7 @@ func (f *searchSelectField) Validators() []Validator { return f.validators }
 func (f *searchSelectField) Default() string         { return f.defaultVal }
 func (f *searchSelectField) Endpoint() string        { return f.endpoint }
 func (f *searchSelectField) Placeholder() string     { return f.placeholder }
+func (f *searchSelectField) ValueLabel() string      { return f.valueLabel }
 func (f *searchSelectField) WithValue(value string) GenericField[string] {
 	newField := *f // Create a copy
 	newField.value = value
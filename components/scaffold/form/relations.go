@@ -0,0 +1,266 @@
+package form
+
+import (
+	"github.com/a-h/templ"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+)
+
+const (
+	FieldTypeHidden     FieldType = "hidden"
+	FieldTypeCollection FieldType = "collection"
+	FieldTypeUpload     FieldType = "upload"
+)
+
+// CollectionIndexPlaceholder is replaced with the index of a row added to a collection in the browser
+const CollectionIndexPlaceholder = "__index__"
+
+// HiddenField carries a value the user does not edit
+type HiddenField interface {
+	GenericField[string]
+}
+
+// CollectionField edits the rows of a one-to-many relation inline.
+// Row fields are keyed "<key>[<index>].<name>" so rows can be told apart when the form is parsed.
+type CollectionField interface {
+	Field
+	Columns() []string
+	Rows() [][]Field
+	Template() []Field
+}
+
+// UploadField uploads a file and submits its ID
+type UploadField interface {
+	Field
+	Accept() string
+	Uploads() []*viewmodels.Upload
+}
+
+type hiddenField struct {
+	key        string
+	value      string
+	defaultVal string
+	attrs      templ.Attributes
+}
+
+func (f *hiddenField) Component() templ.Component { return hiddenInput(f) }
+func (f *hiddenField) Key() string                { return f.key }
+func (f *hiddenField) Label() string              { return "" }
+func (f *hiddenField) Type() FieldType            { return FieldTypeHidden }
+func (f *hiddenField) Value() string              { return f.value }
+func (f *hiddenField) Required() bool             { return false }
+func (f *hiddenField) Attrs() templ.Attributes    { return f.attrs }
+func (f *hiddenField) Validators() []Validator    { return nil }
+func (f *hiddenField) Default() string            { return f.defaultVal }
+func (f *hiddenField) WithValue(value string) GenericField[string] {
+	newField := *f // Create a copy
+	newField.value = value
+	return &newField
+}
+
+type collectionField struct {
+	key         string
+	label       string
+	addLabel    string
+	removeLabel string
+	columns     []string
+	rows        [][]Field
+	template    []Field
+	attrs       templ.Attributes
+	validators  []Validator
+}
+
+func (f *collectionField) Component() templ.Component { return collection(f) }
+func (f *collectionField) Key() string                { return f.key }
+func (f *collectionField) Label() string              { return f.label }
+func (f *collectionField) Type() FieldType            { return FieldTypeCollection }
+func (f *collectionField) Required() bool             { return false }
+func (f *collectionField) Attrs() templ.Attributes    { return f.attrs }
+func (f *collectionField) Validators() []Validator    { return f.validators }
+func (f *collectionField) Columns() []string          { return f.columns }
+func (f *collectionField) Rows() [][]Field            { return f.rows }
+func (f *collectionField) Template() []Field          { return f.template }
+
+type uploadField struct {
+	key         string
+	label       string
+	buttonLabel string
+	placeholder string
+	accept      string
+	uploads     []*viewmodels.Upload
+	required    bool
+	attrs       templ.Attributes
+	validators  []Validator
+}
+
+func (f *uploadField) Component() templ.Component    { return upload(f) }
+func (f *uploadField) Key() string                   { return f.key }
+func (f *uploadField) Label() string                 { return f.label }
+func (f *uploadField) Type() FieldType               { return FieldTypeUpload }
+func (f *uploadField) Required() bool                { return f.required }
+func (f *uploadField) Attrs() templ.Attributes       { return f.attrs }
+func (f *uploadField) Validators() []Validator       { return f.validators }
+func (f *uploadField) Accept() string                { return f.accept }
+func (f *uploadField) Uploads() []*viewmodels.Upload { return f.uploads }
+
+// HiddenFieldBuilder builds a HiddenField
+type HiddenFieldBuilder struct {
+	key, defaultVal string
+	attrs           templ.Attributes
+}
+
+func Hidden(key string) *HiddenFieldBuilder {
+	return &HiddenFieldBuilder{key: key, attrs: templ.Attributes{}}
+}
+
+func (b *HiddenFieldBuilder) Default(val string) *HiddenFieldBuilder {
+	b.defaultVal = val
+	return b
+}
+
+func (b *HiddenFieldBuilder) Attrs(a templ.Attributes) *HiddenFieldBuilder {
+	b.attrs = a
+	return b
+}
+
+func (b *HiddenFieldBuilder) Build() HiddenField {
+	return &hiddenField{
+		key:        b.key,
+		defaultVal: b.defaultVal,
+		attrs:      b.attrs,
+	}
+}
+
+// CollectionFieldBuilder builds a CollectionField
+type CollectionFieldBuilder struct {
+	key, label, addLabel, removeLabel string
+	columns                           []string
+	rows                              [][]Field
+	template                          []Field
+	attrs                             templ.Attributes
+	validators                        []Validator
+}
+
+func Collection(key, label string) *CollectionFieldBuilder {
+	return &CollectionFieldBuilder{
+		key:         key,
+		label:       label,
+		addLabel:    "Add",
+		removeLabel: "Remove",
+		attrs:       templ.Attributes{},
+	}
+}
+
+// Columns sets the column headers, one per field of a row
+func (b *CollectionFieldBuilder) Columns(columns ...string) *CollectionFieldBuilder {
+	b.columns = columns
+	return b
+}
+
+// Row appends an existing row
+func (b *CollectionFieldBuilder) Row(fields ...Field) *CollectionFieldBuilder {
+	b.rows = append(b.rows, fields)
+	return b
+}
+
+// Template sets the fields of a new row. Their keys contain CollectionIndexPlaceholder.
+func (b *CollectionFieldBuilder) Template(fields ...Field) *CollectionFieldBuilder {
+	b.template = fields
+	return b
+}
+
+func (b *CollectionFieldBuilder) AddLabel(label string) *CollectionFieldBuilder {
+	b.addLabel = label
+	return b
+}
+
+func (b *CollectionFieldBuilder) RemoveLabel(label string) *CollectionFieldBuilder {
+	b.removeLabel = label
+	return b
+}
+
+func (b *CollectionFieldBuilder) Attrs(a templ.Attributes) *CollectionFieldBuilder {
+	b.attrs = a
+	return b
+}
+
+func (b *CollectionFieldBuilder) Validators(v []Validator) *CollectionFieldBuilder {
+	b.validators = v
+	return b
+}
+
+func (b *CollectionFieldBuilder) Build() CollectionField {
+	return &collectionField{
+		key:         b.key,
+		label:       b.label,
+		addLabel:    b.addLabel,
+		removeLabel: b.removeLabel,
+		columns:     b.columns,
+		rows:        b.rows,
+		template:    b.template,
+		attrs:       b.attrs,
+		validators:  b.validators,
+	}
+}
+
+// UploadFieldBuilder builds an UploadField
+type UploadFieldBuilder struct {
+	key, label, buttonLabel, placeholder, accept string
+	uploads                                      []*viewmodels.Upload
+	required                                     bool
+	attrs                                        templ.Attributes
+	validators                                   []Validator
+}
+
+func Upload(key, label string) *UploadFieldBuilder {
+	return &UploadFieldBuilder{key: key, label: label, buttonLabel: label, attrs: templ.Attributes{}}
+}
+
+func (b *UploadFieldBuilder) ButtonLabel(label string) *UploadFieldBuilder {
+	b.buttonLabel = label
+	return b
+}
+
+func (b *UploadFieldBuilder) Placeholder(placeholder string) *UploadFieldBuilder {
+	b.placeholder = placeholder
+	return b
+}
+
+func (b *UploadFieldBuilder) Accept(accept string) *UploadFieldBuilder {
+	b.accept = accept
+	return b
+}
+
+// Default sets the already uploaded files
+func (b *UploadFieldBuilder) Default(uploads ...*viewmodels.Upload) *UploadFieldBuilder {
+	b.uploads = uploads
+	return b
+}
+
+func (b *UploadFieldBuilder) Required() *UploadFieldBuilder {
+	b.required = true
+	return b
+}
+
+func (b *UploadFieldBuilder) Attrs(a templ.Attributes) *UploadFieldBuilder {
+	b.attrs = a
+	return b
+}
+
+func (b *UploadFieldBuilder) Validators(v []Validator) *UploadFieldBuilder {
+	b.validators = v
+	return b
+}
+
+func (b *UploadFieldBuilder) Build() UploadField {
+	return &uploadField{
+		key:         b.key,
+		label:       b.label,
+		buttonLabel: b.buttonLabel,
+		placeholder: b.placeholder,
+		accept:      b.accept,
+		uploads:     b.uploads,
+		required:    b.required,
+		attrs:       b.attrs,
+		validators:  b.validators,
+	}
+}
@@ -39,6 +39,9 @@ func Default(options *DefaultOptions) (*server.HTTPServer, error) {
 
 		middleware.TracedMiddleware("requestParams"),
 		middleware.RequestParams(),
+
+		middleware.TracedMiddleware("resolveTenant"),
+		middleware.ResolveTenant(app),
 	)
 
 	serverInstance := server.NewHTTPServer(
@@ -0,0 +1,45 @@
+-- +migrate Up
+-- Change CREATE_TABLE: jobs
+CREATE TABLE jobs (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    user_id int REFERENCES users (id) ON DELETE CASCADE,
+    type varchar(50) NOT NULL,
+    title varchar(255) NOT NULL DEFAULT '',
+    status varchar(20) NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed', 'canceled')),
+    payload jsonb NOT NULL DEFAULT '{}',
+    progress int NOT NULL DEFAULT 0,
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL DEFAULT 3,
+    result_upload_id int REFERENCES uploads (id) ON DELETE SET NULL,
+    error_upload_id int REFERENCES uploads (id) ON DELETE SET NULL,
+    error text NOT NULL DEFAULT '',
+    run_at timestamp with time zone NOT NULL DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Change CREATE_INDEX: jobs_tenant_id_idx
+CREATE INDEX jobs_tenant_id_idx ON jobs (tenant_id);
+
+-- Change CREATE_INDEX: jobs_user_id_idx
+CREATE INDEX jobs_user_id_idx ON jobs (user_id);
+
+-- Change CREATE_INDEX: jobs_status_run_at_idx
+CREATE INDEX jobs_status_run_at_idx ON jobs (status, run_at);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: jobs_status_run_at_idx
+DROP INDEX IF EXISTS jobs_status_run_at_idx;
+
+-- Undo CREATE_INDEX: jobs_user_id_idx
+DROP INDEX IF EXISTS jobs_user_id_idx;
+
+-- Undo CREATE_INDEX: jobs_tenant_id_idx
+DROP INDEX IF EXISTS jobs_tenant_id_idx;
+
+-- Undo CREATE_TABLE: jobs
+DROP TABLE IF EXISTS jobs CASCADE;
+
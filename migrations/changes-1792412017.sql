@@ -0,0 +1,55 @@
+-- +migrate Up
+-- Change ADD_COLUMN: action_logs.action
+ALTER TABLE action_logs ADD COLUMN action varchar(20) NOT NULL DEFAULT '';
+
+-- Change ADD_COLUMN: action_logs.entity
+ALTER TABLE action_logs ADD COLUMN entity varchar(255) NOT NULL DEFAULT '';
+
+-- Change ADD_COLUMN: action_logs.entity_id
+ALTER TABLE action_logs ADD COLUMN entity_id varchar(255) NOT NULL DEFAULT '';
+
+-- Change ALTER_COLUMN: action_logs.method
+ALTER TABLE action_logs ALTER COLUMN method DROP NOT NULL;
+
+-- Change ALTER_COLUMN: action_logs.path
+ALTER TABLE action_logs ALTER COLUMN path DROP NOT NULL;
+
+-- Change CREATE_TABLE: action_log_settings
+CREATE TABLE action_log_settings (
+    tenant_id uuid PRIMARY KEY REFERENCES tenants (id) ON DELETE CASCADE,
+    retention_days int NOT NULL DEFAULT 0 CHECK (retention_days >= 0),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Change CREATE_INDEX: action_logs_entity_idx
+CREATE INDEX action_logs_entity_idx ON action_logs (tenant_id, entity, entity_id, created_at);
+
+-- Change CREATE_INDEX: action_logs_created_at_idx
+CREATE INDEX action_logs_created_at_idx ON action_logs (created_at);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: action_logs_created_at_idx
+DROP INDEX IF EXISTS action_logs_created_at_idx;
+
+-- Undo CREATE_INDEX: action_logs_entity_idx
+DROP INDEX IF EXISTS action_logs_entity_idx;
+
+-- Undo CREATE_TABLE: action_log_settings
+DROP TABLE IF EXISTS action_log_settings CASCADE;
+
+-- Undo ALTER_COLUMN: action_logs.path
+UPDATE action_logs SET path = '' WHERE path IS NULL;
+ALTER TABLE action_logs ALTER COLUMN path SET NOT NULL;
+
+-- Undo ALTER_COLUMN: action_logs.method
+UPDATE action_logs SET method = '' WHERE method IS NULL;
+ALTER TABLE action_logs ALTER COLUMN method SET NOT NULL;
+
+-- Undo ADD_COLUMN: action_logs.entity_id
+ALTER TABLE action_logs DROP COLUMN IF EXISTS entity_id;
+
+-- Undo ADD_COLUMN: action_logs.entity
+ALTER TABLE action_logs DROP COLUMN IF EXISTS entity;
+
+-- Undo ADD_COLUMN: action_logs.action
+ALTER TABLE action_logs DROP COLUMN IF EXISTS action;
@@ -0,0 +1,31 @@
+-- +migrate Up
+-- Change ADD_COLUMN: expenses.created_by_id
+ALTER TABLE expenses ADD COLUMN created_by_id int REFERENCES users (id) ON DELETE SET NULL;
+
+-- Change ADD_COLUMN: clients.created_by_id
+ALTER TABLE clients ADD COLUMN created_by_id int REFERENCES users (id) ON DELETE SET NULL;
+
+-- Change ADD_COLUMN: clients.assignee_id
+ALTER TABLE clients ADD COLUMN assignee_id int REFERENCES users (id) ON DELETE SET NULL;
+
+-- Change ADD_COLUMN: clients.assignee_group_id
+ALTER TABLE clients ADD COLUMN assignee_group_id uuid REFERENCES user_groups (id) ON DELETE SET NULL;
+
+-- Change ADD_COLUMN: warehouse_orders.created_by_id
+ALTER TABLE warehouse_orders ADD COLUMN created_by_id int REFERENCES users (id) ON DELETE SET NULL;
+
+-- +migrate Down
+-- Undo ADD_COLUMN: warehouse_orders.created_by_id
+ALTER TABLE warehouse_orders DROP COLUMN IF EXISTS created_by_id;
+
+-- Undo ADD_COLUMN: clients.assignee_group_id
+ALTER TABLE clients DROP COLUMN IF EXISTS assignee_group_id;
+
+-- Undo ADD_COLUMN: clients.assignee_id
+ALTER TABLE clients DROP COLUMN IF EXISTS assignee_id;
+
+-- Undo ADD_COLUMN: clients.created_by_id
+ALTER TABLE clients DROP COLUMN IF EXISTS created_by_id;
+
+-- Undo ADD_COLUMN: expenses.created_by_id
+ALTER TABLE expenses DROP COLUMN IF EXISTS created_by_id;
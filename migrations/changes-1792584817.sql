@@ -0,0 +1,26 @@
+-- +migrate Up
+-- Change CREATE_TABLE: policies
+CREATE TABLE policies (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    resource varchar(255) NOT NULL,
+    action varchar(255) NOT NULL,
+    effect varchar(10) NOT NULL CHECK (effect IN ('allow', 'deny')),
+    condition text NOT NULL DEFAULT 'true',
+    enabled boolean NOT NULL DEFAULT TRUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, name)
+);
+
+-- Change CREATE_INDEX: policies_tenant_id_resource_action_idx
+CREATE INDEX policies_tenant_id_resource_action_idx ON policies (tenant_id, resource, action);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: policies_tenant_id_resource_action_idx
+DROP INDEX IF EXISTS policies_tenant_id_resource_action_idx;
+
+-- Undo CREATE_TABLE: policies
+DROP TABLE IF EXISTS policies CASCADE;
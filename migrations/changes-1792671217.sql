@@ -0,0 +1,85 @@
+-- +migrate Up
+-- Change CREATE_TABLE: leave_types
+CREATE TABLE leave_types (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    paid boolean NOT NULL DEFAULT TRUE,
+    annual_allowance numeric(6, 2) NOT NULL DEFAULT 0,
+    accrual varchar(10) NOT NULL CHECK (accrual IN ('none', 'yearly', 'monthly')),
+    carry_over_limit numeric(6, 2) NOT NULL DEFAULT 0,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (tenant_id, name)
+);
+
+-- Change CREATE_TABLE: leave_requests
+CREATE TABLE leave_requests (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    employee_id int NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    leave_type_id int NOT NULL REFERENCES leave_types (id) ON DELETE RESTRICT,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    days numeric(6, 2) NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    status varchar(20) NOT NULL CHECK (status IN ('pending', 'approved', 'rejected', 'canceled')),
+    requested_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    reviewed_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    reviewed_at timestamp with time zone,
+    review_comment text NOT NULL DEFAULT '',
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    CHECK (end_date >= start_date)
+);
+
+-- Change CREATE_TABLE: attendances
+CREATE TABLE attendances (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    employee_id int NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    date date NOT NULL,
+    status varchar(20) NOT NULL CHECK (status IN ('present', 'remote', 'absent')),
+    check_in timestamp with time zone,
+    check_out timestamp with time zone,
+    source varchar(20) NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'import')),
+    note text NOT NULL DEFAULT '',
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (employee_id, date)
+);
+
+-- Change CREATE_INDEX: leave_types_tenant_id_idx
+CREATE INDEX leave_types_tenant_id_idx ON leave_types (tenant_id);
+
+-- Change CREATE_INDEX: leave_requests_tenant_id_idx
+CREATE INDEX leave_requests_tenant_id_idx ON leave_requests (tenant_id);
+
+-- Change CREATE_INDEX: leave_requests_employee_id_idx
+CREATE INDEX leave_requests_employee_id_idx ON leave_requests (employee_id, start_date, end_date);
+
+-- Change CREATE_INDEX: attendances_tenant_id_date_idx
+CREATE INDEX attendances_tenant_id_date_idx ON attendances (tenant_id, date);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: attendances_tenant_id_date_idx
+DROP INDEX IF EXISTS attendances_tenant_id_date_idx;
+
+-- Undo CREATE_INDEX: leave_requests_employee_id_idx
+DROP INDEX IF EXISTS leave_requests_employee_id_idx;
+
+-- Undo CREATE_INDEX: leave_requests_tenant_id_idx
+DROP INDEX IF EXISTS leave_requests_tenant_id_idx;
+
+-- Undo CREATE_INDEX: leave_types_tenant_id_idx
+DROP INDEX IF EXISTS leave_types_tenant_id_idx;
+
+-- Undo CREATE_TABLE: attendances
+DROP TABLE IF EXISTS attendances CASCADE;
+
+-- Undo CREATE_TABLE: leave_requests
+DROP TABLE IF EXISTS leave_requests CASCADE;
+
+-- Undo CREATE_TABLE: leave_types
+DROP TABLE IF EXISTS leave_types CASCADE;
@@ -0,0 +1,66 @@
+-- +migrate Up
+-- Change CREATE_TABLE: payroll_rules
+CREATE TABLE payroll_rules (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    kind varchar(20) NOT NULL CHECK (kind IN ('bonus', 'deduction', 'tax')),
+    method varchar(20) NOT NULL CHECK (method IN ('percent', 'fixed')),
+    value numeric(18, 4) NOT NULL DEFAULT 0,
+    pre_tax boolean NOT NULL DEFAULT FALSE,
+    enabled boolean NOT NULL DEFAULT TRUE,
+    position int NOT NULL DEFAULT 0,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (tenant_id, name)
+);
+
+-- Change CREATE_TABLE: payroll_runs
+CREATE TABLE payroll_runs (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    year int NOT NULL,
+    month int NOT NULL CHECK (month BETWEEN 1 AND 12),
+    status varchar(20) NOT NULL CHECK (status IN ('draft', 'posted')),
+    created_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    posted_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (tenant_id, year, month)
+);
+
+-- Change CREATE_TABLE: payslips
+CREATE TABLE payslips (
+    id serial PRIMARY KEY,
+    payroll_run_id int NOT NULL REFERENCES payroll_runs (id) ON DELETE CASCADE,
+    employee_id int NOT NULL REFERENCES employees (id) ON DELETE RESTRICT,
+    currency_id varchar(3) NOT NULL REFERENCES currencies (code) ON DELETE RESTRICT,
+    base_salary bigint NOT NULL,
+    working_days int NOT NULL,
+    payable_days numeric(6, 2) NOT NULL,
+    earned bigint NOT NULL,
+    bonus bigint NOT NULL DEFAULT 0,
+    gross bigint NOT NULL,
+    taxable bigint NOT NULL,
+    deductions bigint NOT NULL,
+    taxes bigint NOT NULL,
+    net bigint NOT NULL,
+    lines jsonb NOT NULL DEFAULT '[]',
+    UNIQUE (payroll_run_id, employee_id)
+);
+
+-- Change CREATE_INDEX: payroll_rules_tenant_id_idx
+CREATE INDEX payroll_rules_tenant_id_idx ON payroll_rules (tenant_id);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: payroll_rules_tenant_id_idx
+DROP INDEX IF EXISTS payroll_rules_tenant_id_idx;
+
+-- Undo CREATE_TABLE: payslips
+DROP TABLE IF EXISTS payslips CASCADE;
+
+-- Undo CREATE_TABLE: payroll_runs
+DROP TABLE IF EXISTS payroll_runs CASCADE;
+
+-- Undo CREATE_TABLE: payroll_rules
+DROP TABLE IF EXISTS payroll_rules CASCADE;
@@ -0,0 +1,46 @@
+-- +migrate Up
+-- Change CREATE_TABLE: departments
+CREATE TABLE departments (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    description text,
+    parent_id int REFERENCES departments (id) ON DELETE RESTRICT,
+    manager_id int REFERENCES employees (id) ON DELETE SET NULL,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (tenant_id, name)
+);
+
+-- Change CREATE_TABLE: employee_assignments
+CREATE TABLE employee_assignments (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    employee_id int NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    department_id int REFERENCES departments (id) ON DELETE SET NULL,
+    manager_id int REFERENCES employees (id) ON DELETE SET NULL,
+    start_date date NOT NULL,
+    end_date date,
+    note text,
+    created_at timestamp with time zone DEFAULT now(),
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+-- Change CREATE_INDEX: departments_tenant_id_idx
+CREATE INDEX departments_tenant_id_idx ON departments (tenant_id);
+
+-- Change CREATE_INDEX: employee_assignments_employee_id_idx
+CREATE INDEX employee_assignments_employee_id_idx ON employee_assignments (employee_id, start_date);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: employee_assignments_employee_id_idx
+DROP INDEX IF EXISTS employee_assignments_employee_id_idx;
+
+-- Undo CREATE_INDEX: departments_tenant_id_idx
+DROP INDEX IF EXISTS departments_tenant_id_idx;
+
+-- Undo CREATE_TABLE: employee_assignments
+DROP TABLE IF EXISTS employee_assignments CASCADE;
+
+-- Undo CREATE_TABLE: departments
+DROP TABLE IF EXISTS departments CASCADE;
@@ -0,0 +1,86 @@
+-- +migrate Up
+-- Change CREATE_TABLE: pipeline_stages
+CREATE TABLE pipeline_stages (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    position int NOT NULL DEFAULT 0,
+    probability int NOT NULL DEFAULT 0 CHECK (probability BETWEEN 0 AND 100),
+    outcome varchar(10) NOT NULL DEFAULT 'open', -- open, won, lost
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now()
+);
+
+-- Change CREATE_TABLE: deals
+CREATE TABLE deals (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    title varchar(255) NOT NULL,
+    client_id int NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    stage_id int NOT NULL REFERENCES pipeline_stages (id) ON DELETE RESTRICT,
+    amount bigint NOT NULL DEFAULT 0,
+    currency_id varchar(3) NOT NULL REFERENCES currencies (code) ON DELETE RESTRICT,
+    probability int NOT NULL DEFAULT 0 CHECK (probability BETWEEN 0 AND 100),
+    owner_id int REFERENCES users (id) ON DELETE SET NULL,
+    expected_close_date date,
+    closed_at timestamp with time zone,
+    description text,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now()
+);
+
+-- Change CREATE_TABLE: deal_stage_changes
+CREATE TABLE deal_stage_changes (
+    id serial PRIMARY KEY,
+    deal_id int NOT NULL REFERENCES deals (id) ON DELETE CASCADE,
+    from_stage_id int REFERENCES pipeline_stages (id) ON DELETE SET NULL,
+    to_stage_id int REFERENCES pipeline_stages (id) ON DELETE SET NULL,
+    changed_by int REFERENCES users (id) ON DELETE SET NULL,
+    changed_at timestamp with time zone DEFAULT now()
+);
+
+-- Change CREATE_INDEX: idx_pipeline_stages_tenant_id
+CREATE INDEX idx_pipeline_stages_tenant_id ON pipeline_stages (tenant_id);
+
+-- Change CREATE_INDEX: idx_deals_tenant_id
+CREATE INDEX idx_deals_tenant_id ON deals (tenant_id);
+
+-- Change CREATE_INDEX: idx_deals_client_id
+CREATE INDEX idx_deals_client_id ON deals (client_id);
+
+-- Change CREATE_INDEX: idx_deals_stage_id
+CREATE INDEX idx_deals_stage_id ON deals (stage_id);
+
+-- Change CREATE_INDEX: idx_deals_owner_id
+CREATE INDEX idx_deals_owner_id ON deals (owner_id);
+
+-- Change CREATE_INDEX: idx_deal_stage_changes_deal_id
+CREATE INDEX idx_deal_stage_changes_deal_id ON deal_stage_changes (deal_id);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: idx_deal_stage_changes_deal_id
+DROP INDEX IF EXISTS idx_deal_stage_changes_deal_id;
+
+-- Undo CREATE_INDEX: idx_deals_owner_id
+DROP INDEX IF EXISTS idx_deals_owner_id;
+
+-- Undo CREATE_INDEX: idx_deals_stage_id
+DROP INDEX IF EXISTS idx_deals_stage_id;
+
+-- Undo CREATE_INDEX: idx_deals_client_id
+DROP INDEX IF EXISTS idx_deals_client_id;
+
+-- Undo CREATE_INDEX: idx_deals_tenant_id
+DROP INDEX IF EXISTS idx_deals_tenant_id;
+
+-- Undo CREATE_INDEX: idx_pipeline_stages_tenant_id
+DROP INDEX IF EXISTS idx_pipeline_stages_tenant_id;
+
+-- Undo CREATE_TABLE: deal_stage_changes
+DROP TABLE IF EXISTS deal_stage_changes CASCADE;
+
+-- Undo CREATE_TABLE: deals
+DROP TABLE IF EXISTS deals CASCADE;
+
+-- Undo CREATE_TABLE: pipeline_stages
+DROP TABLE IF EXISTS pipeline_stages CASCADE;
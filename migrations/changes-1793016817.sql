@@ -0,0 +1,15 @@
+-- +migrate Up
+-- Change ADD_COLUMN: external_id
+ALTER TABLE messages
+    ADD COLUMN external_id VARCHAR(255);
+
+-- Change CREATE_INDEX: idx_messages_external_id
+CREATE INDEX idx_messages_external_id ON messages (external_id);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: idx_messages_external_id
+DROP INDEX IF EXISTS idx_messages_external_id;
+
+-- Undo ADD_COLUMN: external_id
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,54 @@
+-- +migrate Up
+-- Change CREATE_TABLE: client_duplicates
+CREATE TABLE client_duplicates (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    client_id int NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    duplicate_id int NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    score int NOT NULL CHECK (score BETWEEN 0 AND 100),
+    reasons jsonb NOT NULL DEFAULT '[]',
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    CHECK (client_id < duplicate_id),
+    UNIQUE (client_id, duplicate_id)
+);
+
+-- Change CREATE_TABLE: client_merges
+CREATE TABLE client_merges (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    survivor_id int REFERENCES clients (id) ON DELETE SET NULL,
+    merged_id int NOT NULL,
+    merged_client jsonb NOT NULL DEFAULT '{}',
+    moved_contacts int NOT NULL DEFAULT 0,
+    moved_messages int NOT NULL DEFAULT 0,
+    moved_deals int NOT NULL DEFAULT 0,
+    merged_by int REFERENCES users (id) ON DELETE SET NULL,
+    merged_at timestamp with time zone DEFAULT now()
+);
+
+-- Change CREATE_INDEX: idx_client_duplicates_tenant_id
+CREATE INDEX idx_client_duplicates_tenant_id ON client_duplicates (tenant_id);
+
+-- Change CREATE_INDEX: idx_client_duplicates_duplicate_id
+CREATE INDEX idx_client_duplicates_duplicate_id ON client_duplicates (duplicate_id);
+
+-- Change CREATE_INDEX: idx_client_merges_survivor_id
+CREATE INDEX idx_client_merges_survivor_id ON client_merges (survivor_id);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: idx_client_merges_survivor_id
+DROP INDEX IF EXISTS idx_client_merges_survivor_id;
+
+-- Undo CREATE_INDEX: idx_client_duplicates_duplicate_id
+DROP INDEX IF EXISTS idx_client_duplicates_duplicate_id;
+
+-- Undo CREATE_INDEX: idx_client_duplicates_tenant_id
+DROP INDEX IF EXISTS idx_client_duplicates_tenant_id;
+
+-- Undo CREATE_TABLE: client_merges
+DROP TABLE IF EXISTS client_merges CASCADE;
+
+-- Undo CREATE_TABLE: client_duplicates
+DROP TABLE IF EXISTS client_duplicates CASCADE;
@@ -0,0 +1,41 @@
+-- +migrate Up
+-- Change ADD_COLUMN: ui_language
+ALTER TABLE tenants
+    ADD COLUMN ui_language VARCHAR(3) NOT NULL DEFAULT '';
+
+-- Change CREATE_TABLE: user_invites
+CREATE TABLE user_invites (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash varchar(64) NOT NULL UNIQUE,
+    expires_at timestamp with time zone NOT NULL,
+    accepted_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Change CREATE_INDEX: tenants_domain_key
+CREATE UNIQUE INDEX tenants_domain_key ON tenants (domain) WHERE domain <> '';
+
+-- Change CREATE_INDEX: user_invites_tenant_id_idx
+CREATE INDEX user_invites_tenant_id_idx ON user_invites (tenant_id);
+
+-- Change CREATE_INDEX: user_invites_user_id_idx
+CREATE INDEX user_invites_user_id_idx ON user_invites (user_id);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: user_invites_user_id_idx
+DROP INDEX IF EXISTS user_invites_user_id_idx;
+
+-- Undo CREATE_INDEX: user_invites_tenant_id_idx
+DROP INDEX IF EXISTS user_invites_tenant_id_idx;
+
+-- Undo CREATE_INDEX: tenants_domain_key
+DROP INDEX IF EXISTS tenants_domain_key;
+
+-- Undo CREATE_TABLE: user_invites
+DROP TABLE IF EXISTS user_invites CASCADE;
+
+-- Undo ADD_COLUMN: ui_language
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS ui_language;
@@ -0,0 +1,41 @@
+-- +migrate Up
+-- Change CREATE_TABLE: api_tokens
+CREATE TABLE api_tokens (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    token_hash varchar(64) NOT NULL UNIQUE,
+    permissions jsonb NOT NULL DEFAULT '[]',
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Change ALTER_CONSTRAINT: users_type_check
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_type_check,
+    ADD CONSTRAINT users_type_check CHECK (type IN ('system', 'user', 'service'));
+
+-- Change CREATE_INDEX: api_tokens_tenant_id_idx
+CREATE INDEX api_tokens_tenant_id_idx ON api_tokens (tenant_id);
+
+-- Change CREATE_INDEX: api_tokens_user_id_idx
+CREATE INDEX api_tokens_user_id_idx ON api_tokens (user_id);
+
+-- +migrate Down
+-- Undo CREATE_INDEX: api_tokens_user_id_idx
+DROP INDEX IF EXISTS api_tokens_user_id_idx;
+
+-- Undo CREATE_INDEX: api_tokens_tenant_id_idx
+DROP INDEX IF EXISTS api_tokens_tenant_id_idx;
+
+-- Undo ALTER_CONSTRAINT: users_type_check
+DELETE FROM users WHERE type = 'service';
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_type_check,
+    ADD CONSTRAINT users_type_check CHECK (type IN ('system', 'user'));
+
+-- Undo CREATE_TABLE: api_tokens
+DROP TABLE IF EXISTS api_tokens CASCADE;
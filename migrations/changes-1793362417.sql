@@ -0,0 +1,63 @@
+-- +migrate Up
+-- Change CREATE_TABLE: webhook_endpoints
+CREATE TABLE webhook_endpoints (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    url varchar(2048) NOT NULL,
+    description varchar(255) NOT NULL DEFAULT '',
+    secret varchar(255) NOT NULL,
+    event_types jsonb NOT NULL DEFAULT '[]',
+    enabled boolean NOT NULL DEFAULT TRUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Change CREATE_TABLE: webhook_deliveries
+CREATE TABLE webhook_deliveries (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    endpoint_id int NOT NULL REFERENCES webhook_endpoints (id) ON DELETE CASCADE,
+    event_id uuid NOT NULL,
+    event_type varchar(255) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'delivered', 'failed')),
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    response_status int NOT NULL DEFAULT 0,
+    response_body text NOT NULL DEFAULT '',
+    error text NOT NULL DEFAULT '',
+    delivered_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Change CREATE_INDEX: webhook_endpoints_tenant_id_idx
+CREATE INDEX webhook_endpoints_tenant_id_idx ON webhook_endpoints (tenant_id);
+
+-- Change CREATE_INDEX: webhook_deliveries_tenant_id_idx
+CREATE INDEX webhook_deliveries_tenant_id_idx ON webhook_deliveries (tenant_id);
+
+-- Change CREATE_INDEX: webhook_deliveries_endpoint_id_idx
+CREATE INDEX webhook_deliveries_endpoint_id_idx ON webhook_deliveries (endpoint_id, created_at);
+
+-- Change CREATE_INDEX: webhook_deliveries_due_idx
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+-- +migrate Down
+-- Undo CREATE_INDEX: webhook_deliveries_due_idx
+DROP INDEX IF EXISTS webhook_deliveries_due_idx;
+
+-- Undo CREATE_INDEX: webhook_deliveries_endpoint_id_idx
+DROP INDEX IF EXISTS webhook_deliveries_endpoint_id_idx;
+
+-- Undo CREATE_INDEX: webhook_deliveries_tenant_id_idx
+DROP INDEX IF EXISTS webhook_deliveries_tenant_id_idx;
+
+-- Undo CREATE_INDEX: webhook_endpoints_tenant_id_idx
+DROP INDEX IF EXISTS webhook_endpoints_tenant_id_idx;
+
+-- Undo CREATE_TABLE: webhook_deliveries
+DROP TABLE IF EXISTS webhook_deliveries CASCADE;
+
+-- Undo CREATE_TABLE: webhook_endpoints
+DROP TABLE IF EXISTS webhook_endpoints CASCADE;
@@ -0,0 +1,11 @@
+-- +migrate Up
+-- Change CREATE_TABLE: graphql_persisted_queries
+CREATE TABLE graphql_persisted_queries (
+    hash varchar(64) PRIMARY KEY,
+    query text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+-- Undo CREATE_TABLE: graphql_persisted_queries
+DROP TABLE IF EXISTS graphql_persisted_queries CASCADE;
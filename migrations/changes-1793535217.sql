@@ -0,0 +1,13 @@
+-- +migrate Up
+-- Change ADD_COLUMN: expenses.approved_by_id
+ALTER TABLE expenses ADD COLUMN approved_by_id int REFERENCES users (id) ON DELETE SET NULL;
+
+-- Change ADD_COLUMN: expenses.approved_at
+ALTER TABLE expenses ADD COLUMN approved_at timestamp with time zone;
+
+-- +migrate Down
+-- Undo ADD_COLUMN: expenses.approved_at
+ALTER TABLE expenses DROP COLUMN IF EXISTS approved_at;
+
+-- Undo ADD_COLUMN: expenses.approved_by_id
+ALTER TABLE expenses DROP COLUMN IF EXISTS approved_by_id;
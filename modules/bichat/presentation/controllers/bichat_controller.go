@@ -38,6 +38,7 @@ func (c *BiChatController) Register(r *mux.Router) {
 		middleware.Authorize(),
 		middleware.RedirectNotAuthenticated(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.ProvideDynamicLogo(c.app),
 		middleware.Tabs(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
@@ -39,18 +39,21 @@ type DeletedEvent struct {
 }
 
 type StatusChangedEvent struct {
+	TenantID      uuid.UUID
 	TransactionID uuid.UUID
 	Data          Status
 	Result        Status
 }
 
 type AmountChangedEvent struct {
+	TenantID      uuid.UUID
 	TransactionID uuid.UUID
 	Data          Amount
 	Result        Amount
 }
 
 type DetailsChangedEvent struct {
+	TenantID      uuid.UUID
 	TransactionID uuid.UUID
 	Data          details.Details
 	Result        details.Details
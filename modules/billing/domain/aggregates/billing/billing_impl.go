@@ -158,6 +158,7 @@ func (t *transaction) SetTenantID(tenantID uuid.UUID) Transaction {
 func (t *transaction) SetStatus(status Status) Transaction {
 	result := *t
 	event := &StatusChangedEvent{
+		TenantID:      result.tenantID,
 		TransactionID: result.id,
 		Data:          result.status,
 		Result:        status,
@@ -179,6 +180,7 @@ func (t *transaction) SetAmount(quantity float64, currency Currency) Transaction
 
 	result := *t
 	event := &AmountChangedEvent{
+		TenantID:      result.tenantID,
 		TransactionID: result.id,
 		Data:          result.amount,
 		Result:        amount,
@@ -195,6 +197,7 @@ func (t *transaction) SetAmount(quantity float64, currency Currency) Transaction
 func (t *transaction) SetDetails(details details.Details) Transaction {
 	result := *t
 	event := &DetailsChangedEvent{
+		TenantID:      result.tenantID,
 		TransactionID: result.id,
 		Data:          result.details,
 		Result:        details,
@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/billing/domain/aggregates/billing"
+	"github.com/iota-uz/iota-sdk/modules/billing/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// findCallbackTransactions looks up the transactions a payment gateway callback refers to.
+// Callbacks come without a session, so the tenant is only known once the transaction is found:
+// the lookup spans tenants and the returned context is scoped to the tenant of the first transaction
+func findCallbackTransactions(
+	ctx context.Context,
+	billingService *services.BillingService,
+	gateway billing.Gateway,
+	filters []billing.DetailsFieldFilter,
+) (context.Context, []billing.Transaction, error) {
+	entities, err := billingService.GetByDetailsFields(composables.WithoutTenantIsolation(ctx), gateway, filters)
+	if err != nil {
+		return ctx, nil, err
+	}
+	if len(entities) > 0 {
+		ctx = composables.WithTenantID(ctx, entities[0].TenantID())
+	}
+	return ctx, entities, nil
+}
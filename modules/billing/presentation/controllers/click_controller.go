@@ -50,8 +50,9 @@ func (c *ClickController) Prepare(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		r.Context(),
+		c.billingService,
 		billing.Click,
 		[]billing.DetailsFieldFilter{
 			{
@@ -116,7 +117,7 @@ func (c *ClickController) Prepare(w http.ResponseWriter, r *http.Request) {
 				SetSignString(dto.SignString),
 		)
 
-	entity, err = c.billingService.Save(r.Context(), entity)
+	entity, err = c.billingService.Save(ctx, entity)
 	if err != nil {
 		log.Printf("Failed to update transaction: %v", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -155,8 +156,9 @@ func (c *ClickController) Complete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		r.Context(),
+		c.billingService,
 		billing.Click,
 		[]billing.DetailsFieldFilter{
 			{
@@ -220,7 +222,7 @@ func (c *ClickController) Complete(w http.ResponseWriter, r *http.Request) {
 				SetSignString(dto.SignString),
 		)
 
-	entity, err = c.billingService.Save(r.Context(), entity)
+	entity, err = c.billingService.Save(ctx, entity)
 	if err != nil {
 		log.Printf("Failed to update transaction: %v", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
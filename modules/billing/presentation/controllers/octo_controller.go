@@ -68,8 +68,9 @@ func (c *OctoController) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		r.Context(),
+		c.billingService,
 		billing.Octo,
 		[]billing.DetailsFieldFilter{
 			{
@@ -127,7 +128,7 @@ func (c *OctoController) Handle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	entity = entity.SetDetails(octoDetails)
-	entity, err = c.billingService.Save(r.Context(), entity)
+	entity, err = c.billingService.Save(ctx, entity)
 	if err != nil {
 		log.Printf("Failed to update transaction: %v", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -156,9 +157,9 @@ func (c *OctoController) Handle(w http.ResponseWriter, r *http.Request) {
 			FinalAmount:     entity.Amount().Quantity(),
 		}
 
-		ctx := context.Background()
+		acceptCtx := context.Background()
 		resp, httpResp, err := apiClient.TransactionManagementAPI.
-			SetAcceptPost(ctx).
+			SetAcceptPost(acceptCtx).
 			SetAcceptRequest(req).
 			Execute()
 
@@ -190,7 +191,7 @@ func (c *OctoController) Handle(w http.ResponseWriter, r *http.Request) {
 		}
 
 		entity = entity.SetDetails(octoDetails)
-		entity, err = c.billingService.Save(r.Context(), entity)
+		entity, err = c.billingService.Save(ctx, entity)
 		if err != nil {
 			log.Printf("Failed to update transaction: %v", err)
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
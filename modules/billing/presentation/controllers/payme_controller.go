@@ -193,8 +193,9 @@ func (c *PaymeController) Handle(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *PaymeController) check(ctx context.Context, r *paymeapi.CheckTransactionRequest) (*paymeapi.CheckTransactionResponse, *paymeapi.JSONRPCErrorResponseError) {
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		ctx,
+		c.billingService,
 		billing.Payme,
 		[]billing.DetailsFieldFilter{
 			{
@@ -243,8 +244,9 @@ func (c *PaymeController) create(ctx context.Context, r *paymeapi.CreateTransact
 			Value:    v,
 		})
 	}
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		ctx,
+		c.billingService,
 		billing.Payme,
 		filters,
 	)
@@ -320,8 +322,9 @@ func (c *PaymeController) create(ctx context.Context, r *paymeapi.CreateTransact
 }
 
 func (c *PaymeController) cancel(ctx context.Context, r *paymeapi.CancelTransactionRequest) (*paymeapi.CancelTransactionResponse, *paymeapi.JSONRPCErrorResponseError) {
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		ctx,
+		c.billingService,
 		billing.Payme,
 		[]billing.DetailsFieldFilter{
 			{
@@ -392,8 +395,9 @@ func (c *PaymeController) checkPerform(ctx context.Context, r *paymeapi.CheckPer
 			Value:    v,
 		})
 	}
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		ctx,
+		c.billingService,
 		billing.Payme,
 		filters,
 	)
@@ -445,8 +449,9 @@ func (c *PaymeController) checkPerform(ctx context.Context, r *paymeapi.CheckPer
 }
 
 func (c *PaymeController) perform(ctx context.Context, r *paymeapi.PerformTransactionRequest) (*paymeapi.PerformTransactionResponse, *paymeapi.JSONRPCErrorResponseError) {
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		ctx,
+		c.billingService,
 		billing.Payme,
 		[]billing.DetailsFieldFilter{
 			{
@@ -502,8 +507,9 @@ func (c *PaymeController) perform(ctx context.Context, r *paymeapi.PerformTransa
 }
 
 func (c *PaymeController) getStatement(ctx context.Context, r *paymeapi.GetStatementRequest) (*paymeapi.GetStatementResponse, *paymeapi.JSONRPCErrorResponseError) {
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		ctx,
+		c.billingService,
 		billing.Payme,
 		[]billing.DetailsFieldFilter{
 			{
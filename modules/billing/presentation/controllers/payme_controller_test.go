@@ -0,0 +1,82 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	paymeapi "github.com/iota-uz/payme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/billing"
+	billingagg "github.com/iota-uz/iota-sdk/modules/billing/domain/aggregates/billing"
+	"github.com/iota-uz/iota-sdk/modules/billing/domain/aggregates/details"
+	"github.com/iota-uz/iota-sdk/modules/billing/presentation/controllers"
+	"github.com/iota-uz/iota-sdk/modules/billing/services"
+	"github.com/iota-uz/iota-sdk/modules/core"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+)
+
+const paymeBasePath = "/billing/payme"
+
+// Gateway callbacks come without a session, the tenant is only known from the transaction
+func TestPaymeController_CheckPerform_WithoutTenant(t *testing.T) {
+	t.Parallel()
+	suite := itf.HTTP(t, core.NewModule(), billing.NewModule()).
+		WithMiddleware(func(ctx context.Context, _ *http.Request) context.Context {
+			return context.WithValue(ctx, constants.TenantIDKey, nil)
+		})
+	env := suite.Environment()
+
+	payme := configuration.PaymeOptions{User: "Paycom", SecretKey: "secret"}
+	suite.Register(controllers.NewPaymeController(env.App, payme, paymeBasePath))
+
+	billingService := env.App.Service(services.BillingService{}).(*services.BillingService)
+	account := map[string]any{"order_id": "order-1"}
+	created, err := billingService.Save(env.Ctx, billingagg.New(
+		1000,
+		billingagg.UZS,
+		billingagg.Payme,
+		details.NewPaymeDetails(
+			"",
+			details.PaymeWithAccount(account),
+			details.PaymeWithState(paymeapi.TransactionStateCreated),
+		),
+		billingagg.WithTenantID(env.Tenant.ID),
+	))
+	require.NoError(t, err)
+
+	// The tenant policies hide the transaction from connections without a tenant
+	unscoped, err := billingService.GetByDetailsFields(
+		composables.WithPool(context.Background(), env.Pool),
+		billingagg.Payme,
+		[]billingagg.DetailsFieldFilter{{Path: []string{"account", "order_id"}, Operator: billingagg.OpEqual, Value: "order-1"}},
+	)
+	require.NoError(t, err)
+	require.Empty(t, unscoped)
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(payme.User + ":" + payme.SecretKey))
+	suite.POST(paymeBasePath).
+		Header("Authorization", "Basic "+credentials).
+		JSON(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  paymeapi.CheckPerformTransactionMethod,
+			"params": map[string]any{
+				"amount":  1000 * 100,
+				"account": account,
+			},
+		}).
+		Expect(t).
+		Status(http.StatusOK).
+		Contains(`"allow":true`)
+
+	updated, err := billingService.GetByID(env.Ctx, created.ID())
+	require.NoError(t, err)
+	assert.Equal(t, billingagg.Pending, updated.Status())
+}
@@ -0,0 +1,13 @@
+package controllers_test
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	if err := os.Chdir("../../../../"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
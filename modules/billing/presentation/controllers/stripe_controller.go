@@ -96,8 +96,9 @@ func (c *StripeController) handleCheckoutCompleted(ctx context.Context, event st
 		return
 	}
 
-	entities, err := c.billingService.GetByDetailsFields(
+	ctx, entities, err := findCallbackTransactions(
 		ctx,
+		c.billingService,
 		billing.Stripe,
 		[]billing.DetailsFieldFilter{
 			{
@@ -178,7 +179,7 @@ func (c *StripeController) handleInvoiceCreated(ctx context.Context, event strip
 		return
 	}
 
-	entities, err := c.billingService.GetByDetailsFields(ctx, billing.Stripe, []billing.DetailsFieldFilter{
+	ctx, entities, err := findCallbackTransactions(ctx, c.billingService, billing.Stripe, []billing.DetailsFieldFilter{
 		{
 			Path:     []string{"subscription_id"},
 			Operator: billing.OpEqual,
@@ -238,7 +239,7 @@ func (c *StripeController) invoicePaymentSucceeded(ctx context.Context, event st
 		return
 	}
 
-	entities, err := c.billingService.GetByDetailsFields(ctx, billing.Stripe, []billing.DetailsFieldFilter{
+	ctx, entities, err := findCallbackTransactions(ctx, c.billingService, billing.Stripe, []billing.DetailsFieldFilter{
 		{
 			Path:     []string{"invoice_id"},
 			Operator: billing.OpEqual,
@@ -290,7 +291,7 @@ func (c *StripeController) handleInvoicePaymentFailed(ctx context.Context, event
 		return
 	}
 
-	entities, err := c.billingService.GetByDetailsFields(ctx, billing.Stripe, []billing.DetailsFieldFilter{
+	ctx, entities, err := findCallbackTransactions(ctx, c.billingService, billing.Stripe, []billing.DetailsFieldFilter{
 		{
 			Path:     []string{"invoice_id"},
 			Operator: billing.OpEqual,
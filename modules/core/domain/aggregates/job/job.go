@@ -0,0 +1,74 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ---- Value Objects ----
+
+type Type string
+
+const (
+	TypeExport Type = "export"
+	TypeImport Type = "import"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsFinal reports whether a job in this status will never run again
+func (s Status) IsFinal() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusCanceled
+}
+
+// ---- Interfaces ----
+
+type Job interface {
+	ID() uint
+	TenantID() uuid.UUID
+	UserID() uint
+	Type() Type
+	Title() string
+	Status() Status
+	Payload() json.RawMessage
+	Progress() int
+	Attempts() int
+	MaxAttempts() int
+	ResultUploadID() uint
+	ErrorUploadID() uint
+	Error() string
+	RunAt() time.Time
+	StartedAt() time.Time
+	FinishedAt() time.Time
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// DecodePayload unmarshals the job payload into v
+	DecodePayload(v any) error
+
+	CanCancel() bool
+	CanRetry() bool
+
+	Start() Job
+	SetProgress(progress int) Job
+	Complete(resultUploadID uint) Job
+	// Fail records err and schedules another attempt at retryAt if attempts are left.
+	// A zero retryAt marks the job as failed regardless of the remaining attempts.
+	Fail(err error, errorUploadID uint, retryAt time.Time) Job
+	Cancel() Job
+	Retry() Job
+}
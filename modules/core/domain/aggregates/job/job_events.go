@@ -0,0 +1,22 @@
+package job
+
+func NewCreatedEvent(result Job) *CreatedEvent {
+	return &CreatedEvent{
+		Result: result,
+	}
+}
+
+func NewUpdatedEvent(result Job) *UpdatedEvent {
+	return &UpdatedEvent{
+		Result: result,
+	}
+}
+
+type CreatedEvent struct {
+	Result Job
+}
+
+// UpdatedEvent is published whenever the status or progress of a job changes
+type UpdatedEvent struct {
+	Result Job
+}
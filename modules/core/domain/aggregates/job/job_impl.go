@@ -0,0 +1,325 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const DefaultMaxAttempts = 3
+
+type Option func(j *job)
+
+// --- Option setters ---
+
+func WithID(id uint) Option {
+	return func(j *job) {
+		j.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(j *job) {
+		j.tenantID = tenantID
+	}
+}
+
+func WithUserID(userID uint) Option {
+	return func(j *job) {
+		j.userID = userID
+	}
+}
+
+func WithTitle(title string) Option {
+	return func(j *job) {
+		j.title = title
+	}
+}
+
+func WithStatus(status Status) Option {
+	return func(j *job) {
+		j.status = status
+	}
+}
+
+func WithProgress(progress int) Option {
+	return func(j *job) {
+		j.progress = progress
+	}
+}
+
+func WithAttempts(attempts int) Option {
+	return func(j *job) {
+		j.attempts = attempts
+	}
+}
+
+func WithMaxAttempts(maxAttempts int) Option {
+	return func(j *job) {
+		j.maxAttempts = maxAttempts
+	}
+}
+
+func WithResultUploadID(id uint) Option {
+	return func(j *job) {
+		j.resultUploadID = id
+	}
+}
+
+func WithErrorUploadID(id uint) Option {
+	return func(j *job) {
+		j.errorUploadID = id
+	}
+}
+
+func WithError(err string) Option {
+	return func(j *job) {
+		j.err = err
+	}
+}
+
+func WithRunAt(runAt time.Time) Option {
+	return func(j *job) {
+		j.runAt = runAt
+	}
+}
+
+func WithStartedAt(startedAt time.Time) Option {
+	return func(j *job) {
+		j.startedAt = startedAt
+	}
+}
+
+func WithFinishedAt(finishedAt time.Time) Option {
+	return func(j *job) {
+		j.finishedAt = finishedAt
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(j *job) {
+		j.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(j *job) {
+		j.updatedAt = updatedAt
+	}
+}
+
+// ---- Implementation ----
+
+// New creates a pending job. The payload is stored as JSON and handed to the job handler.
+func New(jobType Type, payload any, opts ...Option) (Job, error) {
+	var raw json.RawMessage
+	switch p := payload.(type) {
+	case nil:
+		raw = json.RawMessage("{}")
+	case json.RawMessage:
+		raw = p
+	default:
+		data, err := json.Marshal(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal job payload: %w", err)
+		}
+		raw = data
+	}
+
+	now := time.Now()
+	j := &job{
+		jobType:     jobType,
+		status:      StatusPending,
+		payload:     raw,
+		maxAttempts: DefaultMaxAttempts,
+		runAt:       now,
+		createdAt:   now,
+		updatedAt:   now,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j, nil
+}
+
+type job struct {
+	id             uint
+	tenantID       uuid.UUID
+	userID         uint
+	jobType        Type
+	title          string
+	status         Status
+	payload        json.RawMessage
+	progress       int
+	attempts       int
+	maxAttempts    int
+	resultUploadID uint
+	errorUploadID  uint
+	err            string
+	runAt          time.Time
+	startedAt      time.Time
+	finishedAt     time.Time
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+func (j *job) ID() uint {
+	return j.id
+}
+
+func (j *job) TenantID() uuid.UUID {
+	return j.tenantID
+}
+
+func (j *job) UserID() uint {
+	return j.userID
+}
+
+func (j *job) Type() Type {
+	return j.jobType
+}
+
+func (j *job) Title() string {
+	return j.title
+}
+
+func (j *job) Status() Status {
+	return j.status
+}
+
+func (j *job) Payload() json.RawMessage {
+	return j.payload
+}
+
+func (j *job) Progress() int {
+	return j.progress
+}
+
+func (j *job) Attempts() int {
+	return j.attempts
+}
+
+func (j *job) MaxAttempts() int {
+	return j.maxAttempts
+}
+
+func (j *job) ResultUploadID() uint {
+	return j.resultUploadID
+}
+
+func (j *job) ErrorUploadID() uint {
+	return j.errorUploadID
+}
+
+func (j *job) Error() string {
+	return j.err
+}
+
+func (j *job) RunAt() time.Time {
+	return j.runAt
+}
+
+func (j *job) StartedAt() time.Time {
+	return j.startedAt
+}
+
+func (j *job) FinishedAt() time.Time {
+	return j.finishedAt
+}
+
+func (j *job) CreatedAt() time.Time {
+	return j.createdAt
+}
+
+func (j *job) UpdatedAt() time.Time {
+	return j.updatedAt
+}
+
+func (j *job) DecodePayload(v any) error {
+	if err := json.Unmarshal(j.payload, v); err != nil {
+		return fmt.Errorf("failed to decode job payload: %w", err)
+	}
+	return nil
+}
+
+func (j *job) CanCancel() bool {
+	return !j.status.IsFinal()
+}
+
+func (j *job) CanRetry() bool {
+	return j.status == StatusFailed || j.status == StatusCanceled
+}
+
+func (j *job) Start() Job {
+	result := *j
+	now := time.Now()
+	result.status = StatusRunning
+	result.attempts++
+	result.progress = 0
+	result.err = ""
+	result.startedAt = now
+	result.updatedAt = now
+	return &result
+}
+
+func (j *job) SetProgress(progress int) Job {
+	result := *j
+	result.progress = min(max(progress, 0), 100)
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (j *job) Complete(resultUploadID uint) Job {
+	result := *j
+	now := time.Now()
+	result.status = StatusCompleted
+	result.progress = 100
+	result.resultUploadID = resultUploadID
+	result.err = ""
+	result.finishedAt = now
+	result.updatedAt = now
+	return &result
+}
+
+func (j *job) Fail(err error, errorUploadID uint, retryAt time.Time) Job {
+	result := *j
+	now := time.Now()
+	if err != nil {
+		result.err = err.Error()
+	}
+	result.errorUploadID = errorUploadID
+	result.updatedAt = now
+	if !retryAt.IsZero() && result.attempts < result.maxAttempts {
+		result.status = StatusPending
+		result.runAt = retryAt
+		return &result
+	}
+	result.status = StatusFailed
+	result.finishedAt = now
+	return &result
+}
+
+func (j *job) Cancel() Job {
+	result := *j
+	now := time.Now()
+	result.status = StatusCanceled
+	result.finishedAt = now
+	result.updatedAt = now
+	return &result
+}
+
+func (j *job) Retry() Job {
+	result := *j
+	now := time.Now()
+	result.status = StatusPending
+	result.attempts = 0
+	result.progress = 0
+	result.err = ""
+	result.errorUploadID = 0
+	result.runAt = now
+	result.finishedAt = time.Time{}
+	result.updatedAt = now
+	return &result
+}
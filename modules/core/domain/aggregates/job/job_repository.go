@@ -0,0 +1,45 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+type Field int
+
+const (
+	CreatedAtField Field = iota
+	UpdatedAtField
+	RunAtField
+)
+
+type SortByField = repo.SortByField[Field]
+type SortBy = repo.SortBy[Field]
+
+type FindParams struct {
+	Limit    int
+	Offset   int
+	SortBy   SortBy
+	UserID   uint
+	Type     Type
+	Statuses []Status
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]Job, error)
+	GetByID(ctx context.Context, id uint) (Job, error)
+	Save(ctx context.Context, data Job) (Job, error)
+	Delete(ctx context.Context, id uint) error
+
+	// Claim locks the oldest pending job that is due, marks it as running and returns it.
+	// It works across tenants and returns a nil job when nothing is due.
+	Claim(ctx context.Context, types []Type) (Job, error)
+	// Heartbeat stores the progress of a running job, bumps its updated_at and returns its current status.
+	// It does not touch jobs that are no longer running, e.g. canceled ones.
+	Heartbeat(ctx context.Context, id uint, progress int) (Status, error)
+	// RequeueStale moves running jobs that stopped sending heartbeats before the given time back to pending
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+}
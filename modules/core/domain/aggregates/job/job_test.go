@@ -0,0 +1,107 @@
+package job_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("marshals payload", func(t *testing.T) {
+		j, err := job.New(job.TypeExport, map[string]string{"filename": "report"})
+		require.NoError(t, err)
+
+		assert.Equal(t, job.StatusPending, j.Status())
+		assert.Equal(t, job.DefaultMaxAttempts, j.MaxAttempts())
+		assert.JSONEq(t, `{"filename":"report"}`, string(j.Payload()))
+
+		var payload map[string]string
+		require.NoError(t, j.DecodePayload(&payload))
+		assert.Equal(t, "report", payload["filename"])
+	})
+
+	t.Run("nil payload", func(t *testing.T) {
+		j, err := job.New(job.TypeImport, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(j.Payload()))
+	})
+
+	t.Run("raw payload is kept as is", func(t *testing.T) {
+		j, err := job.New(job.TypeImport, json.RawMessage(`{"fileId":1}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"fileId":1}`, string(j.Payload()))
+	})
+}
+
+func TestJob_Fail(t *testing.T) {
+	retryAt := time.Now().Add(time.Minute)
+
+	t.Run("schedules another attempt", func(t *testing.T) {
+		j, err := job.New(job.TypeExport, nil, job.WithMaxAttempts(2))
+		require.NoError(t, err)
+
+		failed := j.Start().Fail(errors.New("connection reset"), 0, retryAt)
+		assert.Equal(t, job.StatusPending, failed.Status())
+		assert.Equal(t, 1, failed.Attempts())
+		assert.Equal(t, "connection reset", failed.Error())
+		assert.True(t, failed.RunAt().Equal(retryAt))
+		assert.False(t, failed.CanRetry())
+	})
+
+	t.Run("fails after the last attempt", func(t *testing.T) {
+		j, err := job.New(job.TypeExport, nil, job.WithMaxAttempts(2))
+		require.NoError(t, err)
+
+		failed := j.Start().Fail(errors.New("boom"), 0, retryAt).Start().Fail(errors.New("boom"), 0, retryAt)
+		assert.Equal(t, job.StatusFailed, failed.Status())
+		assert.Equal(t, 2, failed.Attempts())
+		assert.True(t, failed.CanRetry())
+		assert.False(t, failed.CanCancel())
+	})
+
+	t.Run("permanent failure keeps the error report", func(t *testing.T) {
+		j, err := job.New(job.TypeImport, nil)
+		require.NoError(t, err)
+
+		failed := j.Start().Fail(errors.New("invalid rows"), 42, time.Time{})
+		assert.Equal(t, job.StatusFailed, failed.Status())
+		assert.Equal(t, uint(42), failed.ErrorUploadID())
+	})
+}
+
+func TestJob_CancelAndRetry(t *testing.T) {
+	j, err := job.New(job.TypeExport, nil)
+	require.NoError(t, err)
+
+	running := j.Start().SetProgress(150)
+	assert.Equal(t, 100, running.Progress())
+	require.True(t, running.CanCancel())
+
+	canceled := running.Cancel()
+	assert.Equal(t, job.StatusCanceled, canceled.Status())
+	assert.Equal(t, job.StatusRunning, running.Status(), "setters must not mutate the receiver")
+	require.True(t, canceled.CanRetry())
+
+	retried := canceled.Retry()
+	assert.Equal(t, job.StatusPending, retried.Status())
+	assert.Equal(t, 0, retried.Progress())
+	assert.Empty(t, retried.Error())
+}
+
+func TestJob_Complete(t *testing.T) {
+	j, err := job.New(job.TypeExport, nil)
+	require.NoError(t, err)
+
+	completed := j.Start().Complete(7)
+	assert.Equal(t, job.StatusCompleted, completed.Status())
+	assert.Equal(t, 100, completed.Progress())
+	assert.Equal(t, uint(7), completed.ResultUploadID())
+	assert.False(t, completed.FinishedAt().IsZero())
+	assert.False(t, completed.CanCancel())
+}
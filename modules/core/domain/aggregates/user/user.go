@@ -22,6 +22,8 @@ type Option func(u *user)
 const (
 	TypeSystem Type = "system"
 	TypeUser   Type = "user"
+	// TypeService is a non-human account that only accesses the API with its tokens
+	TypeService Type = "service"
 )
 
 // --- Option setters ---
@@ -21,6 +21,7 @@ const (
 	CreatedAtField
 	UpdatedAtField
 	TenantIDField
+	TypeField
 )
 
 type SortBy = repo.SortBy[Field]
@@ -0,0 +1,166 @@
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+)
+
+// Prefix marks API tokens so that they can be told apart from session tokens
+const Prefix = "iota_"
+
+// lastUsedInterval limits how often the last use of a token is written
+const lastUsedInterval = time.Minute
+
+var (
+	ErrExpired = errors.New("api token expired")
+)
+
+// Token lets scripts call the API as a user without a password. Only the hash of the
+// token is stored, requests are limited to the permissions the token is scoped to.
+type Token struct {
+	id          uint
+	tenantID    uuid.UUID
+	userID      uint
+	name        string
+	tokenHash   string
+	permissions []string
+	expiresAt   *time.Time
+	lastUsedAt  *time.Time
+	createdAt   time.Time
+}
+
+type Option func(*Token)
+
+func WithID(id uint) Option {
+	return func(t *Token) {
+		t.id = id
+	}
+}
+
+func WithTokenHash(tokenHash string) Option {
+	return func(t *Token) {
+		t.tokenHash = tokenHash
+	}
+}
+
+func WithExpiresAt(expiresAt *time.Time) Option {
+	return func(t *Token) {
+		t.expiresAt = expiresAt
+	}
+}
+
+func WithLastUsedAt(lastUsedAt *time.Time) Option {
+	return func(t *Token) {
+		t.lastUsedAt = lastUsedAt
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(t *Token) {
+		t.createdAt = createdAt
+	}
+}
+
+// New creates a token of the user scoped to the permissions with the given names
+func New(tenantID uuid.UUID, userID uint, name string, permissions []string, opts ...Option) *Token {
+	t := &Token{
+		tenantID:    tenantID,
+		userID:      userID,
+		name:        name,
+		permissions: permissions,
+		createdAt:   time.Now(),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Issue creates a token along with the secret handed out to the client, the secret is not kept
+func Issue(tenantID uuid.UUID, userID uint, name string, permissions []string, expiresAt *time.Time) (*Token, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	secret := Prefix + base64.RawURLEncoding.EncodeToString(b)
+	return New(tenantID, userID, name, permissions, WithTokenHash(HashToken(secret)), WithExpiresAt(expiresAt)), secret, nil
+}
+
+// IsToken reports whether the value of an Authorization header looks like an API token
+func IsToken(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// HashToken returns the hash tokens are looked up by
+func HashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t *Token) ID() uint {
+	return t.id
+}
+
+func (t *Token) TenantID() uuid.UUID {
+	return t.tenantID
+}
+
+func (t *Token) UserID() uint {
+	return t.userID
+}
+
+func (t *Token) Name() string {
+	return t.name
+}
+
+func (t *Token) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *Token) Permissions() []string {
+	return t.permissions
+}
+
+func (t *Token) ExpiresAt() *time.Time {
+	return t.expiresAt
+}
+
+func (t *Token) LastUsedAt() *time.Time {
+	return t.lastUsedAt
+}
+
+func (t *Token) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *Token) IsExpired() bool {
+	return t.expiresAt != nil && time.Now().After(*t.expiresAt)
+}
+
+// Allows reports whether the token is scoped to the permission
+func (t *Token) Allows(perm *permission.Permission) bool {
+	return slices.Contains(t.permissions, perm.Name)
+}
+
+// Use records a request made with the token, it reports whether the last use has to be saved
+func (t *Token) Use() (bool, error) {
+	if t.IsExpired() {
+		return false, ErrExpired
+	}
+	now := time.Now()
+	if t.lastUsedAt != nil && now.Sub(*t.lastUsedAt) < lastUsedInterval {
+		return false, nil
+	}
+	t.lastUsedAt = &now
+	return true, nil
+}
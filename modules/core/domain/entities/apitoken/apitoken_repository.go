@@ -0,0 +1,15 @@
+package apitoken
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	GetByID(ctx context.Context, id uint) (*Token, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Token, error)
+	GetByUserIDs(ctx context.Context, userIDs []uint) ([]*Token, error)
+	Create(ctx context.Context, token *Token) (*Token, error)
+	UpdateLastUsedAt(ctx context.Context, id uint, lastUsedAt time.Time) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,54 @@
+package apitoken_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssue(t *testing.T) {
+	tenantID := uuid.New()
+	token, secret, err := apitoken.Issue(tenantID, 7, "CI", []string{"User.Read"}, nil)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(secret, apitoken.Prefix))
+	assert.True(t, apitoken.IsToken(secret))
+	assert.Equal(t, apitoken.HashToken(secret), token.TokenHash())
+	assert.Equal(t, tenantID, token.TenantID())
+	assert.Equal(t, uint(7), token.UserID())
+	assert.Equal(t, "CI", token.Name())
+	assert.False(t, token.IsExpired())
+
+	_, other, err := apitoken.Issue(tenantID, 7, "CI", nil, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestToken_Allows(t *testing.T) {
+	token := apitoken.New(uuid.New(), 1, "CI", []string{"User.Read"})
+	assert.True(t, token.Allows(&permission.Permission{Name: "User.Read"}))
+	assert.False(t, token.Allows(&permission.Permission{Name: "User.Delete"}))
+}
+
+func TestToken_Use(t *testing.T) {
+	token := apitoken.New(uuid.New(), 1, "CI", nil)
+	save, err := token.Use()
+	require.NoError(t, err)
+	assert.True(t, save)
+	require.NotNil(t, token.LastUsedAt())
+
+	save, err = token.Use()
+	require.NoError(t, err)
+	assert.False(t, save, "uses within a minute are not saved again")
+
+	expiresAt := time.Now().Add(-time.Minute)
+	expired := apitoken.New(uuid.New(), 1, "CI", nil, apitoken.WithExpiresAt(&expiresAt))
+	_, err = expired.Use()
+	require.ErrorIs(t, err, apitoken.ErrExpired)
+}
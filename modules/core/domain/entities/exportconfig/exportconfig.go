@@ -2,6 +2,7 @@ package exportconfig
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/iota-uz/iota-sdk/pkg/excel"
@@ -57,17 +58,42 @@ func WithStyleOptions(opts *excel.StyleOptions) Option {
 	}
 }
 
+// WithFormat sets the output file format
+func WithFormat(format excel.Format) Option {
+	return func(c *exportConfig) {
+		c.format = format
+	}
+}
+
+// WithCSVOptions sets CSV output options
+func WithCSVOptions(opts *excel.CSVOptions) Option {
+	return func(c *exportConfig) {
+		c.csvOpts = opts
+	}
+}
+
+// WithPDFOptions sets PDF output options
+func WithPDFOptions(opts *excel.PDFOptions) Option {
+	return func(c *exportConfig) {
+		c.pdfOpts = opts
+	}
+}
+
 // ExportConfig represents export configuration
 type ExportConfig interface {
 	Filename() string
+	Format() excel.Format
 	ExportOptions() *excel.ExportOptions
 	StyleOptions() *excel.StyleOptions
+	CSVOptions() *excel.CSVOptions
+	PDFOptions() *excel.PDFOptions
 }
 
 // New creates a new export configuration
 func New(opts ...Option) ExportConfig {
 	config := &exportConfig{
 		filename:   "",
+		format:     excel.FormatXLSX,
 		exportOpts: nil,
 		styleOpts:  nil,
 	}
@@ -79,21 +105,32 @@ func New(opts ...Option) ExportConfig {
 
 type exportConfig struct {
 	filename   string
+	format     excel.Format
 	exportOpts *excel.ExportOptions
 	styleOpts  *excel.StyleOptions
+	csvOpts    *excel.CSVOptions
+	pdfOpts    *excel.PDFOptions
 }
 
 func (c *exportConfig) Filename() string {
+	ext := c.Format().Extension()
 	if c.filename == "" {
-		return fmt.Sprintf("export_%s.xlsx", time.Now().Format("20060102_150405"))
+		return fmt.Sprintf("export_%s%s", time.Now().Format("20060102_150405"), ext)
 	}
-	// Ensure filename has .xlsx extension
-	if len(c.filename) < 5 || c.filename[len(c.filename)-5:] != ".xlsx" {
-		return c.filename + ".xlsx"
+	// Ensure filename has the extension of the format
+	if !strings.HasSuffix(c.filename, ext) {
+		return c.filename + ext
 	}
 	return c.filename
 }
 
+func (c *exportConfig) Format() excel.Format {
+	if c.format == "" {
+		return excel.FormatXLSX
+	}
+	return c.format
+}
+
 func (c *exportConfig) ExportOptions() *excel.ExportOptions {
 	return c.exportOpts
 }
@@ -101,3 +138,11 @@ func (c *exportConfig) ExportOptions() *excel.ExportOptions {
 func (c *exportConfig) StyleOptions() *excel.StyleOptions {
 	return c.styleOpts
 }
+
+func (c *exportConfig) CSVOptions() *excel.CSVOptions {
+	return c.csvOpts
+}
+
+func (c *exportConfig) PDFOptions() *excel.PDFOptions {
+	return c.pdfOpts
+}
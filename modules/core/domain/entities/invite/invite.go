@@ -0,0 +1,132 @@
+package invite
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrExpired  = errors.New("invite expired")
+	ErrAccepted = errors.New("invite already accepted")
+)
+
+// Invite lets a user without a password set one and log in, the token of the invite link is only stored hashed
+type Invite struct {
+	id         uint
+	tenantID   uuid.UUID
+	userID     uint
+	tokenHash  string
+	expiresAt  time.Time
+	acceptedAt *time.Time
+	createdAt  time.Time
+}
+
+type Option func(*Invite)
+
+func WithID(id uint) Option {
+	return func(i *Invite) {
+		i.id = id
+	}
+}
+
+func WithTokenHash(tokenHash string) Option {
+	return func(i *Invite) {
+		i.tokenHash = tokenHash
+	}
+}
+
+func WithAcceptedAt(acceptedAt *time.Time) Option {
+	return func(i *Invite) {
+		i.acceptedAt = acceptedAt
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(i *Invite) {
+		i.createdAt = createdAt
+	}
+}
+
+func New(tenantID uuid.UUID, userID uint, expiresAt time.Time, opts ...Option) *Invite {
+	i := &Invite{
+		tenantID:  tenantID,
+		userID:    userID,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Issue creates an invite valid for ttl along with the token to put in the invite link
+func Issue(tenantID uuid.UUID, userID uint, ttl time.Duration) (*Invite, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return New(tenantID, userID, time.Now().Add(ttl), WithTokenHash(HashToken(token))), token, nil
+}
+
+// HashToken returns the hash invites are looked up by
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (i *Invite) ID() uint {
+	return i.id
+}
+
+func (i *Invite) TenantID() uuid.UUID {
+	return i.tenantID
+}
+
+func (i *Invite) UserID() uint {
+	return i.userID
+}
+
+func (i *Invite) TokenHash() string {
+	return i.tokenHash
+}
+
+func (i *Invite) ExpiresAt() time.Time {
+	return i.expiresAt
+}
+
+func (i *Invite) AcceptedAt() *time.Time {
+	return i.acceptedAt
+}
+
+func (i *Invite) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+func (i *Invite) IsAccepted() bool {
+	return i.acceptedAt != nil
+}
+
+func (i *Invite) IsExpired() bool {
+	return time.Now().After(i.expiresAt)
+}
+
+// Accept marks the invite as used, an invite can only be accepted once and before it expires
+func (i *Invite) Accept() error {
+	if i.IsAccepted() {
+		return ErrAccepted
+	}
+	if i.IsExpired() {
+		return ErrExpired
+	}
+	now := time.Now()
+	i.acceptedAt = &now
+	return nil
+}
@@ -0,0 +1,11 @@
+package invite
+
+import (
+	"context"
+)
+
+type Repository interface {
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Invite, error)
+	Create(ctx context.Context, invite *Invite) (*Invite, error)
+	Update(ctx context.Context, invite *Invite) error
+}
@@ -0,0 +1,40 @@
+package invite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/invite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssue(t *testing.T) {
+	tenantID := uuid.New()
+	inv, token, err := invite.Issue(tenantID, 7, time.Hour)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, token)
+	assert.Equal(t, invite.HashToken(token), inv.TokenHash())
+	assert.NotEqual(t, token, inv.TokenHash())
+	assert.Equal(t, tenantID, inv.TenantID())
+	assert.Equal(t, uint(7), inv.UserID())
+	assert.False(t, inv.IsExpired())
+	assert.False(t, inv.IsAccepted())
+
+	_, other, err := invite.Issue(tenantID, 7, time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestInvite_Accept(t *testing.T) {
+	inv := invite.New(uuid.New(), 1, time.Now().Add(time.Hour))
+	require.NoError(t, inv.Accept())
+	assert.True(t, inv.IsAccepted())
+	require.ErrorIs(t, inv.Accept(), invite.ErrAccepted)
+
+	expired := invite.New(uuid.New(), 1, time.Now().Add(-time.Minute))
+	require.ErrorIs(t, expired.Accept(), invite.ErrExpired)
+	assert.False(t, expired.IsAccepted())
+}
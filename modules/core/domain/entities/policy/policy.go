@@ -0,0 +1,213 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+)
+
+type Option func(p *policy)
+
+func WithID(id uuid.UUID) Option {
+	return func(p *policy) {
+		p.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(p *policy) {
+		p.tenantID = tenantID
+	}
+}
+
+func WithDescription(description string) Option {
+	return func(p *policy) {
+		p.description = description
+	}
+}
+
+func WithEnabled(enabled bool) Option {
+	return func(p *policy) {
+		p.enabled = enabled
+	}
+}
+
+func WithCreatedAt(t time.Time) Option {
+	return func(p *policy) {
+		p.createdAt = t
+	}
+}
+
+func WithUpdatedAt(t time.Time) Option {
+	return func(p *policy) {
+		p.updatedAt = t
+	}
+}
+
+// Policy is a tenant's attribute based access rule, see rbac.Policy
+type Policy interface {
+	ID() uuid.UUID
+	TenantID() uuid.UUID
+	Name() string
+	Description() string
+	Resource() permission.Resource
+	Action() permission.Action
+	Effect() rbac.Effect
+	Condition() string
+	Enabled() bool
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	SetName(name string) Policy
+	SetDescription(description string) Policy
+	SetEffect(effect rbac.Effect) Policy
+	SetCondition(condition string) Policy
+	SetTarget(resource permission.Resource, action permission.Action) Policy
+	SetEnabled(enabled bool) Policy
+
+	// Compile parses the condition into an rbac.Policy
+	Compile() (rbac.Policy, error)
+}
+
+func New(
+	name string,
+	resource permission.Resource,
+	action permission.Action,
+	effect rbac.Effect,
+	condition string,
+	opts ...Option,
+) Policy {
+	p := &policy{
+		id:        uuid.New(),
+		name:      name,
+		resource:  resource,
+		action:    action,
+		effect:    effect,
+		condition: condition,
+		enabled:   true,
+		createdAt: time.Now(),
+		updatedAt: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+type policy struct {
+	id          uuid.UUID
+	tenantID    uuid.UUID
+	name        string
+	description string
+	resource    permission.Resource
+	action      permission.Action
+	effect      rbac.Effect
+	condition   string
+	enabled     bool
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func (p *policy) ID() uuid.UUID {
+	return p.id
+}
+
+func (p *policy) TenantID() uuid.UUID {
+	return p.tenantID
+}
+
+func (p *policy) Name() string {
+	return p.name
+}
+
+func (p *policy) Description() string {
+	return p.description
+}
+
+func (p *policy) Resource() permission.Resource {
+	return p.resource
+}
+
+func (p *policy) Action() permission.Action {
+	return p.action
+}
+
+func (p *policy) Effect() rbac.Effect {
+	return p.effect
+}
+
+func (p *policy) Condition() string {
+	return p.condition
+}
+
+func (p *policy) Enabled() bool {
+	return p.enabled
+}
+
+func (p *policy) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *policy) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+func (p *policy) SetName(name string) Policy {
+	result := *p
+	result.name = name
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (p *policy) SetDescription(description string) Policy {
+	result := *p
+	result.description = description
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (p *policy) SetEffect(effect rbac.Effect) Policy {
+	result := *p
+	result.effect = effect
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (p *policy) SetCondition(condition string) Policy {
+	result := *p
+	result.condition = condition
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (p *policy) SetTarget(resource permission.Resource, action permission.Action) Policy {
+	result := *p
+	result.resource = resource
+	result.action = action
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (p *policy) SetEnabled(enabled bool) Policy {
+	result := *p
+	result.enabled = enabled
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (p *policy) Compile() (rbac.Policy, error) {
+	condition, err := rbac.ParseExpr(p.condition)
+	if err != nil {
+		return rbac.Policy{}, err
+	}
+	return rbac.Policy{
+		Name:      p.name,
+		Resource:  p.resource,
+		Action:    p.action,
+		Effect:    p.effect,
+		Condition: condition,
+	}, nil
+}
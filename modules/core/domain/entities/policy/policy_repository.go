@@ -0,0 +1,23 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+)
+
+type FindParams struct {
+	Resource    permission.Resource
+	Action      permission.Action
+	EnabledOnly bool
+}
+
+type Repository interface {
+	GetAll(ctx context.Context, params *FindParams) ([]Policy, error)
+	GetByID(ctx context.Context, id uuid.UUID) (Policy, error)
+	Create(ctx context.Context, p Policy) (Policy, error)
+	Update(ctx context.Context, p Policy) (Policy, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
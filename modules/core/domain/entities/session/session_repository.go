@@ -3,6 +3,8 @@ package session
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 )
 
@@ -32,4 +34,5 @@ type Repository interface {
 	Update(ctx context.Context, user *Session) error
 	Delete(ctx context.Context, token string) error
 	DeleteByUserId(ctx context.Context, userId uint) ([]*Session, error)
+	DeleteByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*Session, error)
 }
@@ -11,6 +11,7 @@ type Tenant struct {
 	name          string
 	domain        string
 	isActive      bool
+	uiLanguage    string
 	logoID        *int
 	logoCompactID *int
 	createdAt     time.Time
@@ -37,6 +38,12 @@ func WithIsActive(isActive bool) Option {
 	}
 }
 
+func WithUILanguage(uiLanguage string) Option {
+	return func(t *Tenant) {
+		t.uiLanguage = uiLanguage
+	}
+}
+
 func WithCreatedAt(createdAt time.Time) Option {
 	return func(t *Tenant) {
 		t.createdAt = createdAt
@@ -91,6 +98,11 @@ func (t *Tenant) IsActive() bool {
 	return t.isActive
 }
 
+// UILanguage is the language of the tenant's pages before login, empty when the browser's language is used
+func (t *Tenant) UILanguage() string {
+	return t.uiLanguage
+}
+
 func (t *Tenant) CreatedAt() time.Time {
 	return t.createdAt
 }
@@ -116,3 +128,14 @@ func (t *Tenant) SetLogoCompactID(logoCompactID *int) {
 	t.logoCompactID = logoCompactID
 	t.updatedAt = time.Now()
 }
+
+// Suspend locks the tenant's users out until it is activated again
+func (t *Tenant) Suspend() {
+	t.isActive = false
+	t.updatedAt = time.Now()
+}
+
+func (t *Tenant) Activate() {
+	t.isActive = true
+	t.updatedAt = time.Now()
+}
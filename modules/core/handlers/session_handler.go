@@ -46,7 +46,10 @@ func (h *SessionEventsHandler) onSessionCreated(event session.CreatedEvent) {
 	if err != nil {
 		log.Fatalf("failed to begin transaction: %v", err)
 	}
-	ctx := composables.WithTx(context.Background(), tx)
+	ctx := composables.WithTx(composables.WithTenantID(context.Background(), sess.TenantID), tx)
+	if err := composables.ApplyTenant(ctx, tx); err != nil {
+		log.Fatalf("failed to apply tenant: %v", err)
+	}
 	if err := h.authLogService.Create(ctx, logEntity); err != nil {
 		log.Fatalf("failed to create auth log: %v", err)
 	}
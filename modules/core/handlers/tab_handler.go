@@ -38,6 +38,7 @@ func (h *TabHandler) Register(publisher eventbus.EventBus) {
 
 func (h *TabHandler) HandleUserCreated(event *user.CreatedEvent) {
 	ctx := composables.WithPool(context.Background(), h.app.DB())
+	ctx = composables.WithTenantID(ctx, event.Result.TenantID())
 	h.createUserTabs(ctx, event.Result)
 }
 
@@ -33,6 +33,8 @@ func RegisterUserHandler(app application.Application) *UserHandler {
 func (h *UserHandler) onUserPasswordUpdated(event *user.UpdatedPasswordEvent) {
 	ctx := context.Background()
 	ctx = composables.WithPool(ctx, h.pool)
+	// The event carries no tenant, user ids are unique across tenants
+	ctx = composables.WithoutTenantIsolation(ctx)
 
 	if _, err := h.sessionService.DeleteByUserId(ctx, event.UserID); err != nil {
 		log.Printf("failed to register client chat: %v", err)
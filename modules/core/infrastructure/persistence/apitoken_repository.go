@@ -0,0 +1,172 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrAPITokenNotFound = errors.New("api token not found")
+)
+
+const (
+	selectAPITokenQuery = `
+		SELECT id, tenant_id, user_id, name, token_hash, permissions, expires_at, last_used_at, created_at
+		FROM api_tokens`
+
+	insertAPITokenQuery = `
+		INSERT INTO api_tokens (tenant_id, user_id, name, token_hash, permissions, expires_at, last_used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+
+	updateAPITokenLastUsedQuery = `UPDATE api_tokens SET last_used_at = $1 WHERE id = $2`
+	deleteAPITokenQuery         = `DELETE FROM api_tokens WHERE id = $1 AND tenant_id = $2`
+)
+
+type PgAPITokenRepository struct{}
+
+func NewAPITokenRepository() apitoken.Repository {
+	return &PgAPITokenRepository{}
+}
+
+func (g *PgAPITokenRepository) GetByID(ctx context.Context, id uint) (*apitoken.Token, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := g.queryTokens(ctx, selectAPITokenQuery+" WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get api token")
+	}
+	if len(tokens) == 0 {
+		return nil, ErrAPITokenNotFound
+	}
+	return tokens[0], nil
+}
+
+// GetByTokenHash looks the token up across tenants as the tenant of a request is only known from its token
+func (g *PgAPITokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*apitoken.Token, error) {
+	tokens, err := g.queryTokens(ctx, selectAPITokenQuery+" WHERE token_hash = $1", tokenHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get api token")
+	}
+	if len(tokens) == 0 {
+		return nil, ErrAPITokenNotFound
+	}
+	return tokens[0], nil
+}
+
+func (g *PgAPITokenRepository) GetByUserIDs(ctx context.Context, userIDs []uint) ([]*apitoken.Token, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := g.queryTokens(
+		ctx,
+		selectAPITokenQuery+" WHERE user_id = ANY($1) AND tenant_id = $2 ORDER BY created_at DESC",
+		userIDs,
+		tenantID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get api tokens")
+	}
+	return tokens, nil
+}
+
+func (g *PgAPITokenRepository) Create(ctx context.Context, data *apitoken.Token) (*apitoken.Token, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbToken, err := ToDBAPIToken(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.QueryRow(
+		ctx,
+		insertAPITokenQuery,
+		dbToken.TenantID,
+		dbToken.UserID,
+		dbToken.Name,
+		dbToken.TokenHash,
+		dbToken.Permissions,
+		dbToken.ExpiresAt,
+		dbToken.LastUsedAt,
+		dbToken.CreatedAt,
+	).Scan(&dbToken.ID); err != nil {
+		return nil, errors.Wrap(err, "failed to insert api token")
+	}
+	return ToDomainAPIToken(dbToken)
+}
+
+func (g *PgAPITokenRepository) UpdateLastUsedAt(ctx context.Context, id uint, lastUsedAt time.Time) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, updateAPITokenLastUsedQuery, lastUsedAt, id); err != nil {
+		return errors.Wrap(err, "failed to update api token")
+	}
+	return nil
+}
+
+func (g *PgAPITokenRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, deleteAPITokenQuery, id, tenantID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete api token")
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+func (g *PgAPITokenRepository) queryTokens(ctx context.Context, query string, args ...interface{}) ([]*apitoken.Token, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*apitoken.Token
+	for rows.Next() {
+		var dbToken models.APIToken
+		if err := rows.Scan(
+			&dbToken.ID,
+			&dbToken.TenantID,
+			&dbToken.UserID,
+			&dbToken.Name,
+			&dbToken.TokenHash,
+			&dbToken.Permissions,
+			&dbToken.ExpiresAt,
+			&dbToken.LastUsedAt,
+			&dbToken.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := ToDomainAPIToken(&dbToken)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, entity)
+	}
+	return tokens, rows.Err()
+}
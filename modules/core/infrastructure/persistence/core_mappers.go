@@ -10,12 +10,16 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/group"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/authlog"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/invite"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/passport"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policy"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tab"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
@@ -26,6 +30,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/tax"
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
 )
 
 func ToDomainUser(dbUser *models.User, dbUpload *models.Upload, roles []role.Role, groupIDs []uuid.UUID, permissions []*permission.Permission) (user.User, error) {
@@ -535,3 +540,165 @@ func ToDBGroup(g group.Group) *models.Group {
 		UpdatedAt:   g.UpdatedAt(),
 	}
 }
+
+func ToDBJob(entity job.Job) *models.Job {
+	return &models.Job{
+		ID:             entity.ID(),
+		TenantID:       entity.TenantID().String(),
+		UserID:         mapping.ValueToSQLNullInt32(int32(entity.UserID())),
+		Type:           string(entity.Type()),
+		Title:          entity.Title(),
+		Status:         string(entity.Status()),
+		Payload:        entity.Payload(),
+		Progress:       entity.Progress(),
+		Attempts:       entity.Attempts(),
+		MaxAttempts:    entity.MaxAttempts(),
+		ResultUploadID: mapping.ValueToSQLNullInt32(int32(entity.ResultUploadID())),
+		ErrorUploadID:  mapping.ValueToSQLNullInt32(int32(entity.ErrorUploadID())),
+		Error:          entity.Error(),
+		RunAt:          entity.RunAt(),
+		StartedAt:      mapping.ValueToSQLNullTime(entity.StartedAt()),
+		FinishedAt:     mapping.ValueToSQLNullTime(entity.FinishedAt()),
+		CreatedAt:      entity.CreatedAt(),
+		UpdatedAt:      entity.UpdatedAt(),
+	}
+}
+
+func ToDomainJob(dbJob *models.Job) (job.Job, error) {
+	tenantID, err := uuid.Parse(dbJob.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant id")
+	}
+	return job.New(
+		job.Type(dbJob.Type),
+		json.RawMessage(dbJob.Payload),
+		job.WithID(dbJob.ID),
+		job.WithTenantID(tenantID),
+		job.WithUserID(uint(dbJob.UserID.Int32)),
+		job.WithTitle(dbJob.Title),
+		job.WithStatus(job.Status(dbJob.Status)),
+		job.WithProgress(dbJob.Progress),
+		job.WithAttempts(dbJob.Attempts),
+		job.WithMaxAttempts(dbJob.MaxAttempts),
+		job.WithResultUploadID(uint(dbJob.ResultUploadID.Int32)),
+		job.WithErrorUploadID(uint(dbJob.ErrorUploadID.Int32)),
+		job.WithError(dbJob.Error),
+		job.WithRunAt(dbJob.RunAt),
+		job.WithStartedAt(dbJob.StartedAt.Time),
+		job.WithFinishedAt(dbJob.FinishedAt.Time),
+		job.WithCreatedAt(dbJob.CreatedAt),
+		job.WithUpdatedAt(dbJob.UpdatedAt),
+	)
+}
+
+func ToDBUserInvite(entity *invite.Invite) *models.UserInvite {
+	return &models.UserInvite{
+		ID:         entity.ID(),
+		TenantID:   entity.TenantID().String(),
+		UserID:     entity.UserID(),
+		TokenHash:  entity.TokenHash(),
+		ExpiresAt:  entity.ExpiresAt(),
+		AcceptedAt: mapping.PointerToSQLNullTime(entity.AcceptedAt()),
+		CreatedAt:  entity.CreatedAt(),
+	}
+}
+
+func ToDomainUserInvite(dbInvite *models.UserInvite) (*invite.Invite, error) {
+	tenantID, err := uuid.Parse(dbInvite.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant id")
+	}
+	return invite.New(
+		tenantID,
+		dbInvite.UserID,
+		dbInvite.ExpiresAt,
+		invite.WithID(dbInvite.ID),
+		invite.WithTokenHash(dbInvite.TokenHash),
+		invite.WithAcceptedAt(mapping.SQLNullTimeToPointer(dbInvite.AcceptedAt)),
+		invite.WithCreatedAt(dbInvite.CreatedAt),
+	), nil
+}
+
+func ToDBAPIToken(entity *apitoken.Token) (*models.APIToken, error) {
+	permissions := entity.Permissions()
+	if permissions == nil {
+		permissions = []string{}
+	}
+	permissionsJSON, err := json.Marshal(permissions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal permissions")
+	}
+	return &models.APIToken{
+		ID:          entity.ID(),
+		TenantID:    entity.TenantID().String(),
+		UserID:      entity.UserID(),
+		Name:        entity.Name(),
+		TokenHash:   entity.TokenHash(),
+		Permissions: permissionsJSON,
+		ExpiresAt:   mapping.PointerToSQLNullTime(entity.ExpiresAt()),
+		LastUsedAt:  mapping.PointerToSQLNullTime(entity.LastUsedAt()),
+		CreatedAt:   entity.CreatedAt(),
+	}, nil
+}
+
+func ToDomainAPIToken(dbToken *models.APIToken) (*apitoken.Token, error) {
+	tenantID, err := uuid.Parse(dbToken.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant id")
+	}
+	var permissions []string
+	if err := json.Unmarshal(dbToken.Permissions, &permissions); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal permissions")
+	}
+	return apitoken.New(
+		tenantID,
+		dbToken.UserID,
+		dbToken.Name,
+		permissions,
+		apitoken.WithID(dbToken.ID),
+		apitoken.WithTokenHash(dbToken.TokenHash),
+		apitoken.WithExpiresAt(mapping.SQLNullTimeToPointer(dbToken.ExpiresAt)),
+		apitoken.WithLastUsedAt(mapping.SQLNullTimeToPointer(dbToken.LastUsedAt)),
+		apitoken.WithCreatedAt(dbToken.CreatedAt),
+	), nil
+}
+
+func ToDomainPolicy(dbPolicy *models.Policy) (policy.Policy, error) {
+	id, err := uuid.Parse(dbPolicy.ID)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := uuid.Parse(dbPolicy.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return policy.New(
+		dbPolicy.Name,
+		permission.Resource(dbPolicy.Resource),
+		permission.Action(dbPolicy.Action),
+		rbac.Effect(dbPolicy.Effect),
+		dbPolicy.Condition,
+		policy.WithID(id),
+		policy.WithTenantID(tenantID),
+		policy.WithDescription(dbPolicy.Description),
+		policy.WithEnabled(dbPolicy.Enabled),
+		policy.WithCreatedAt(dbPolicy.CreatedAt),
+		policy.WithUpdatedAt(dbPolicy.UpdatedAt),
+	), nil
+}
+
+func ToDBPolicy(p policy.Policy) *models.Policy {
+	return &models.Policy{
+		ID:          p.ID().String(),
+		TenantID:    p.TenantID().String(),
+		Name:        p.Name(),
+		Description: p.Description(),
+		Resource:    string(p.Resource()),
+		Action:      string(p.Action()),
+		Effect:      string(p.Effect()),
+		Condition:   p.Condition(),
+		Enabled:     p.Enabled(),
+		CreatedAt:   p.CreatedAt(),
+		UpdatedAt:   p.UpdatedAt(),
+	}
+}
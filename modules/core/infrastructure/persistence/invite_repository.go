@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/invite"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrInviteNotFound = errors.New("invite not found")
+)
+
+const (
+	selectInviteQuery = `
+		SELECT id, tenant_id, user_id, token_hash, expires_at, accepted_at, created_at
+		FROM user_invites`
+
+	insertInviteQuery = `
+		INSERT INTO user_invites (tenant_id, user_id, token_hash, expires_at, accepted_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	updateInviteQuery = `UPDATE user_invites SET expires_at = $1, accepted_at = $2 WHERE id = $3`
+)
+
+type PgInviteRepository struct{}
+
+func NewInviteRepository() invite.Repository {
+	return &PgInviteRepository{}
+}
+
+// GetByTokenHash looks the invite up across tenants as invite links are opened before login
+func (g *PgInviteRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*invite.Invite, error) {
+	invites, err := g.queryInvites(ctx, selectInviteQuery+" WHERE token_hash = $1", tokenHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get invite")
+	}
+	if len(invites) == 0 {
+		return nil, ErrInviteNotFound
+	}
+	return invites[0], nil
+}
+
+func (g *PgInviteRepository) Create(ctx context.Context, data *invite.Invite) (*invite.Invite, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbInvite := ToDBUserInvite(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertInviteQuery,
+		dbInvite.TenantID,
+		dbInvite.UserID,
+		dbInvite.TokenHash,
+		dbInvite.ExpiresAt,
+		dbInvite.AcceptedAt,
+		dbInvite.CreatedAt,
+	).Scan(&dbInvite.ID); err != nil {
+		return nil, errors.Wrap(err, "failed to insert invite")
+	}
+	return ToDomainUserInvite(dbInvite)
+}
+
+func (g *PgInviteRepository) Update(ctx context.Context, data *invite.Invite) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbInvite := ToDBUserInvite(data)
+	if _, err := tx.Exec(ctx, updateInviteQuery, dbInvite.ExpiresAt, dbInvite.AcceptedAt, dbInvite.ID); err != nil {
+		return errors.Wrap(err, "failed to update invite")
+	}
+	return nil
+}
+
+func (g *PgInviteRepository) queryInvites(ctx context.Context, query string, args ...interface{}) ([]*invite.Invite, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invites []*invite.Invite
+	for rows.Next() {
+		var dbInvite models.UserInvite
+		if err := rows.Scan(
+			&dbInvite.ID,
+			&dbInvite.TenantID,
+			&dbInvite.UserID,
+			&dbInvite.TokenHash,
+			&dbInvite.ExpiresAt,
+			&dbInvite.AcceptedAt,
+			&dbInvite.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := ToDomainUserInvite(&dbInvite)
+		if err != nil {
+			return nil, err
+		}
+		invites = append(invites, entity)
+	}
+	return invites, rows.Err()
+}
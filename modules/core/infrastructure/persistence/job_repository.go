@@ -0,0 +1,388 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+)
+
+const (
+	jobColumns = `
+		jobs.id,
+		jobs.tenant_id,
+		jobs.user_id,
+		jobs.type,
+		jobs.title,
+		jobs.status,
+		jobs.payload,
+		jobs.progress,
+		jobs.attempts,
+		jobs.max_attempts,
+		jobs.result_upload_id,
+		jobs.error_upload_id,
+		jobs.error,
+		jobs.run_at,
+		jobs.started_at,
+		jobs.finished_at,
+		jobs.created_at,
+		jobs.updated_at`
+
+	selectJobQuery = `SELECT` + jobColumns + ` FROM jobs`
+
+	countJobQuery = `SELECT COUNT(*) FROM jobs`
+
+	insertJobQuery = `
+		INSERT INTO jobs (
+			tenant_id,
+			user_id,
+			type,
+			title,
+			status,
+			payload,
+			progress,
+			attempts,
+			max_attempts,
+			result_upload_id,
+			error_upload_id,
+			error,
+			run_at,
+			started_at,
+			finished_at,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING id`
+
+	updateJobQuery = `
+		UPDATE jobs SET
+			title = $1,
+			status = $2,
+			payload = $3,
+			progress = $4,
+			attempts = $5,
+			max_attempts = $6,
+			result_upload_id = $7,
+			error_upload_id = $8,
+			error = $9,
+			run_at = $10,
+			started_at = $11,
+			finished_at = $12,
+			updated_at = $13
+		WHERE id = $14 AND tenant_id = $15`
+
+	deleteJobQuery = `DELETE FROM jobs WHERE id = $1 AND tenant_id = $2`
+
+	claimJobQuery = `
+		UPDATE jobs SET
+			status = 'running',
+			attempts = attempts + 1,
+			progress = 0,
+			error = '',
+			started_at = NOW(),
+			updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = 'pending' AND run_at <= NOW() AND type = ANY($1)
+			ORDER BY run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + jobColumns
+
+	heartbeatJobQuery = `UPDATE jobs SET progress = $2, updated_at = NOW() WHERE id = $1 AND status = 'running' RETURNING status`
+
+	selectJobStatusQuery = `SELECT status FROM jobs WHERE id = $1`
+
+	requeueStaleJobsQuery = `UPDATE jobs SET status = 'pending', run_at = NOW(), updated_at = NOW() WHERE status = 'running' AND updated_at < $1`
+)
+
+type PgJobRepository struct {
+	fieldMap map[job.Field]string
+}
+
+func NewJobRepository() job.Repository {
+	return &PgJobRepository{
+		fieldMap: map[job.Field]string{
+			job.CreatedAtField: "jobs.created_at",
+			job.UpdatedAtField: "jobs.updated_at",
+			job.RunAtField:     "jobs.run_at",
+		},
+	}
+}
+
+func (g *PgJobRepository) buildJobFilters(ctx context.Context, params *job.FindParams) ([]string, []interface{}, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	where, args := []string{"jobs.tenant_id = $1"}, []interface{}{tenantID}
+
+	if params.UserID != 0 {
+		where = append(where, fmt.Sprintf("jobs.user_id = $%d", len(args)+1))
+		args = append(args, params.UserID)
+	}
+
+	if params.Type != "" {
+		where = append(where, fmt.Sprintf("jobs.type = $%d", len(args)+1))
+		args = append(args, params.Type)
+	}
+
+	if len(params.Statuses) > 0 {
+		statuses := make([]string, len(params.Statuses))
+		for i, s := range params.Statuses {
+			statuses[i] = string(s)
+		}
+		where = append(where, fmt.Sprintf("jobs.status = ANY($%d)", len(args)+1))
+		args = append(args, statuses)
+	}
+
+	return where, args, nil
+}
+
+func (g *PgJobRepository) Count(ctx context.Context, params *job.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args, err := g.buildJobFilters(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countJobQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count jobs")
+	}
+	return count, nil
+}
+
+func (g *PgJobRepository) GetPaginated(ctx context.Context, params *job.FindParams) ([]job.Job, error) {
+	where, args, err := g.buildJobFilters(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	query := repo.Join(
+		selectJobQuery,
+		repo.JoinWhere(where...),
+		params.SortBy.ToSQL(g.fieldMap),
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	jobs, err := g.queryJobs(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get paginated jobs")
+	}
+	return jobs, nil
+}
+
+func (g *PgJobRepository) GetByID(ctx context.Context, id uint) (job.Job, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	jobs, err := g.queryJobs(ctx, repo.Join(selectJobQuery, "WHERE jobs.id = $1 AND jobs.tenant_id = $2"), id, tenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get job with id %d", id)
+	}
+	if len(jobs) == 0 {
+		return nil, ErrJobNotFound
+	}
+	return jobs[0], nil
+}
+
+func (g *PgJobRepository) Save(ctx context.Context, data job.Job) (job.Job, error) {
+	if data.ID() == 0 {
+		return g.create(ctx, data)
+	}
+	return g.update(ctx, data)
+}
+
+func (g *PgJobRepository) Delete(ctx context.Context, id uint) error {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tenant from context")
+	}
+	return g.execQuery(ctx, deleteJobQuery, id, tenantID)
+}
+
+func (g *PgJobRepository) Claim(ctx context.Context, types []job.Type) (job.Job, error) {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	jobs, err := g.queryJobs(ctx, claimJobQuery, names)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to claim job")
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+func (g *PgJobRepository) Heartbeat(ctx context.Context, id uint, progress int) (job.Status, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var status string
+	err = tx.QueryRow(ctx, heartbeatJobQuery, id, progress).Scan(&status)
+	if err == nil {
+		return job.Status(status), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", errors.Wrapf(err, "failed to update job %d", id)
+	}
+	// The job is no longer running, e.g. it was canceled
+	if err := tx.QueryRow(ctx, selectJobStatusQuery, id).Scan(&status); err != nil {
+		return "", errors.Wrapf(err, "failed to get status of job %d", id)
+	}
+	return job.Status(status), nil
+}
+
+func (g *PgJobRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tag, err := tx.Exec(ctx, requeueStaleJobsQuery, before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to requeue stale jobs")
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (g *PgJobRepository) create(ctx context.Context, data job.Job) (job.Job, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID := data.TenantID()
+	if id, err := composables.UseTenantID(ctx); err == nil {
+		tenantID = id
+	}
+	dbJob := ToDBJob(data)
+	dbJob.TenantID = tenantID.String()
+	if err := tx.QueryRow(
+		ctx,
+		insertJobQuery,
+		dbJob.TenantID,
+		dbJob.UserID,
+		dbJob.Type,
+		dbJob.Title,
+		dbJob.Status,
+		dbJob.Payload,
+		dbJob.Progress,
+		dbJob.Attempts,
+		dbJob.MaxAttempts,
+		dbJob.ResultUploadID,
+		dbJob.ErrorUploadID,
+		dbJob.Error,
+		dbJob.RunAt,
+		dbJob.StartedAt,
+		dbJob.FinishedAt,
+		dbJob.CreatedAt,
+		dbJob.UpdatedAt,
+	).Scan(&dbJob.ID); err != nil {
+		return nil, errors.Wrap(err, "failed to insert job")
+	}
+	return g.GetByID(composables.WithTenantID(ctx, tenantID), dbJob.ID)
+}
+
+func (g *PgJobRepository) update(ctx context.Context, data job.Job) (job.Job, error) {
+	dbJob := ToDBJob(data)
+	if err := g.execQuery(
+		ctx,
+		updateJobQuery,
+		dbJob.Title,
+		dbJob.Status,
+		dbJob.Payload,
+		dbJob.Progress,
+		dbJob.Attempts,
+		dbJob.MaxAttempts,
+		dbJob.ResultUploadID,
+		dbJob.ErrorUploadID,
+		dbJob.Error,
+		dbJob.RunAt,
+		dbJob.StartedAt,
+		dbJob.FinishedAt,
+		dbJob.UpdatedAt,
+		dbJob.ID,
+		dbJob.TenantID,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to update job")
+	}
+	return g.GetByID(composables.WithTenantID(ctx, data.TenantID()), data.ID())
+}
+
+func (g *PgJobRepository) queryJobs(ctx context.Context, query string, args ...interface{}) ([]job.Job, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []job.Job
+	for rows.Next() {
+		var dbJob models.Job
+		if err := rows.Scan(
+			&dbJob.ID,
+			&dbJob.TenantID,
+			&dbJob.UserID,
+			&dbJob.Type,
+			&dbJob.Title,
+			&dbJob.Status,
+			&dbJob.Payload,
+			&dbJob.Progress,
+			&dbJob.Attempts,
+			&dbJob.MaxAttempts,
+			&dbJob.ResultUploadID,
+			&dbJob.ErrorUploadID,
+			&dbJob.Error,
+			&dbJob.RunAt,
+			&dbJob.StartedAt,
+			&dbJob.FinishedAt,
+			&dbJob.CreatedAt,
+			&dbJob.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := ToDomainJob(&dbJob)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (g *PgJobRepository) execQuery(ctx context.Context, query string, args ...interface{}) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
@@ -10,6 +10,7 @@ type Tenant struct {
 	Name          string
 	Domain        sql.NullString
 	IsActive      bool
+	UILanguage    string
 	LogoID        sql.NullInt32
 	LogoCompactID sql.NullInt32
 	CreatedAt     time.Time
@@ -184,3 +185,60 @@ type GroupRole struct {
 	RoleID    uint
 	CreatedAt time.Time
 }
+
+type UserInvite struct {
+	ID         uint
+	TenantID   string
+	UserID     uint
+	TokenHash  string
+	ExpiresAt  time.Time
+	AcceptedAt sql.NullTime
+	CreatedAt  time.Time
+}
+
+type APIToken struct {
+	ID          uint
+	TenantID    string
+	UserID      uint
+	Name        string
+	TokenHash   string
+	Permissions []byte
+	ExpiresAt   sql.NullTime
+	LastUsedAt  sql.NullTime
+	CreatedAt   time.Time
+}
+
+type Job struct {
+	ID             uint
+	TenantID       string
+	UserID         sql.NullInt32
+	Type           string
+	Title          string
+	Status         string
+	Payload        []byte
+	Progress       int
+	Attempts       int
+	MaxAttempts    int
+	ResultUploadID sql.NullInt32
+	ErrorUploadID  sql.NullInt32
+	Error          string
+	RunAt          time.Time
+	StartedAt      sql.NullTime
+	FinishedAt     sql.NullTime
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type Policy struct {
+	ID          string
+	TenantID    string
+	Name        string
+	Description string
+	Resource    string
+	Action      string
+	Effect      string
+	Condition   string
+	Enabled     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 var (
@@ -21,6 +22,11 @@ const (
 	permissionsSelectQuery = `SELECT id, name, resource, action, modifier, description, tenant_id FROM permissions`
 	permissionsCountQuery  = `SELECT COUNT(*) FROM permissions`
 	permissionsInsertQuery = `
+		INSERT INTO permissions (id, name, resource, action, modifier, description, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT DO NOTHING
+		RETURNING id`
+	permissionsUpsertQuery = `
 		INSERT INTO permissions (id, name, resource, action, modifier, description, tenant_id)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT (tenant_id, name) DO UPDATE SET resource = permissions.resource
@@ -123,6 +129,9 @@ func (g *PgPermissionRepository) GetByID(ctx context.Context, id string) (*permi
 	return permissions[0], nil
 }
 
+// Save stores the permission for the tenant in ctx. Permission ids are unique across tenants,
+// so the row keeps the id of the definition only in the first tenant it is saved for,
+// the rows of other tenants get an id derived from the tenant and the permission name.
 func (g *PgPermissionRepository) Save(ctx context.Context, data *permission.Permission) error {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
@@ -137,7 +146,7 @@ func (g *PgPermissionRepository) Save(ctx context.Context, data *permission.Perm
 	dbPerm := toDBPermission(data)
 	dbPerm.TenantID = tenantID.String()
 
-	if err := tx.QueryRow(
+	err = tx.QueryRow(
 		ctx,
 		permissionsInsertQuery,
 		dbPerm.ID,
@@ -147,10 +156,24 @@ func (g *PgPermissionRepository) Save(ctx context.Context, data *permission.Perm
 		dbPerm.Modifier,
 		dbPerm.Description,
 		dbPerm.TenantID,
-	).Scan(&data.ID); err != nil {
+	).Scan(&dbPerm.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
 		return err
 	}
-	return nil
+	return tx.QueryRow(
+		ctx,
+		permissionsUpsertQuery,
+		uuid.NewSHA1(tenantID, []byte(dbPerm.Name)).String(),
+		dbPerm.Name,
+		dbPerm.Resource,
+		dbPerm.Action,
+		dbPerm.Modifier,
+		dbPerm.Description,
+		dbPerm.TenantID,
+	).Scan(&dbPerm.ID)
 }
 
 func (g *PgPermissionRepository) Delete(ctx context.Context, id string) error {
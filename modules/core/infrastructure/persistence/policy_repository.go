@@ -0,0 +1,197 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policy"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrPolicyNotFound = errors.New("policy not found")
+)
+
+const (
+	policyFindQuery = `
+		SELECT
+			p.id,
+			p.tenant_id,
+			p.name,
+			p.description,
+			p.resource,
+			p.action,
+			p.effect,
+			p.condition,
+			p.enabled,
+			p.created_at,
+			p.updated_at
+		FROM policies p`
+	policyInsertQuery = `
+		INSERT INTO policies (id, tenant_id, name, description, resource, action, effect, condition, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	policyUpdateQuery = `
+		UPDATE policies
+		SET name = $1, description = $2, resource = $3, action = $4, effect = $5, condition = $6, enabled = $7, updated_at = $8
+		WHERE id = $9 AND tenant_id = $10`
+	policyDeleteQuery = `DELETE FROM policies WHERE id = $1 AND tenant_id = $2`
+)
+
+type policyRepository struct{}
+
+func NewPolicyRepository() policy.Repository {
+	return &policyRepository{}
+}
+
+func (g *policyRepository) GetAll(ctx context.Context, params *policy.FindParams) ([]policy.Policy, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	where, args := []string{"p.tenant_id = $1"}, []interface{}{tenantID}
+	if params.Resource != "" {
+		where, args = append(where, fmt.Sprintf("p.resource = $%d", len(args)+1)), append(args, params.Resource)
+	}
+	if params.Action != "" {
+		where, args = append(where, fmt.Sprintf("p.action = $%d", len(args)+1)), append(args, params.Action)
+	}
+	if params.EnabledOnly {
+		where = append(where, "p.enabled")
+	}
+
+	return g.queryPolicies(ctx, repo.Join(policyFindQuery, repo.JoinWhere(where...), "ORDER BY p.resource, p.action, p.name"), args...)
+}
+
+func (g *policyRepository) GetByID(ctx context.Context, id uuid.UUID) (policy.Policy, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	policies, err := g.queryPolicies(ctx, policyFindQuery+" WHERE p.id = $1 AND p.tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query policies")
+	}
+	if len(policies) == 0 {
+		return nil, ErrPolicyNotFound
+	}
+	return policies[0], nil
+}
+
+func (g *policyRepository) Create(ctx context.Context, data policy.Policy) (policy.Policy, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	dbPolicy := ToDBPolicy(data)
+	dbPolicy.TenantID = tenantID.String()
+	if err := g.execQuery(
+		ctx,
+		policyInsertQuery,
+		dbPolicy.ID,
+		dbPolicy.TenantID,
+		dbPolicy.Name,
+		dbPolicy.Description,
+		dbPolicy.Resource,
+		dbPolicy.Action,
+		dbPolicy.Effect,
+		dbPolicy.Condition,
+		dbPolicy.Enabled,
+		dbPolicy.CreatedAt,
+		dbPolicy.UpdatedAt,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to insert policy")
+	}
+	return g.GetByID(ctx, data.ID())
+}
+
+func (g *policyRepository) Update(ctx context.Context, data policy.Policy) (policy.Policy, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	dbPolicy := ToDBPolicy(data)
+	if err := g.execQuery(
+		ctx,
+		policyUpdateQuery,
+		dbPolicy.Name,
+		dbPolicy.Description,
+		dbPolicy.Resource,
+		dbPolicy.Action,
+		dbPolicy.Effect,
+		dbPolicy.Condition,
+		dbPolicy.Enabled,
+		dbPolicy.UpdatedAt,
+		dbPolicy.ID,
+		tenantID.String(),
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to update policy")
+	}
+	return g.GetByID(ctx, data.ID())
+}
+
+func (g *policyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tenant from context")
+	}
+	return g.execQuery(ctx, policyDeleteQuery, id, tenantID)
+}
+
+func (g *policyRepository) queryPolicies(ctx context.Context, query string, args ...interface{}) ([]policy.Policy, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := make([]policy.Policy, 0)
+	for rows.Next() {
+		var p models.Policy
+		if err := rows.Scan(
+			&p.ID,
+			&p.TenantID,
+			&p.Name,
+			&p.Description,
+			&p.Resource,
+			&p.Action,
+			&p.Effect,
+			&p.Condition,
+			&p.Enabled,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		domainPolicy, err := ToDomainPolicy(&p)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, domainPolicy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (g *policyRepository) execQuery(ctx context.Context, query string, args ...interface{}) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
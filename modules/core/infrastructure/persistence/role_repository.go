@@ -41,9 +41,10 @@ const (
 	roleInsertQuery            = `INSERT INTO roles (type, name, description, tenant_id) VALUES ($1, $2, $3, $4) RETURNING id`
 	roleUpdateQuery            = `UPDATE roles SET name = $1, description = $2, updated_at = $3	WHERE id = $4 AND tenant_id = $5`
 	roleDeletePermissionsQuery = `DELETE FROM role_permissions WHERE role_id = $1`
-	roleInsertPermissionQuery  = `
+	// Permissions are looked up by name as every tenant has its own permission rows
+	roleInsertPermissionQuery = `
 		INSERT INTO role_permissions (role_id, permission_id)
-		VALUES ($1, $2)
+		SELECT $1, id FROM permissions WHERE name = $2 AND tenant_id = $3
 		ON CONFLICT (role_id, permission_id) DO NOTHING`
 	roleDeleteQuery = `DELETE FROM roles WHERE id = $1 AND tenant_id = $2`
 )
@@ -216,7 +217,8 @@ func (g *GormRoleRepository) Create(ctx context.Context, data role.Role) (role.R
 	for _, permission := range permissions {
 		if err := g.execQuery(ctx, roleInsertPermissionQuery,
 			id,
-			permission.ID,
+			permission.Name,
+			entity.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -253,7 +255,8 @@ func (g *GormRoleRepository) Update(ctx context.Context, data role.Role) (role.R
 	for _, permission := range dbPermissions {
 		if err := g.execQuery(ctx, roleInsertPermissionQuery,
 			dbRole.ID,
-			permission.ID,
+			permission.Name,
+			dbRole.TenantID,
 		); err != nil {
 			return nil, err
 		}
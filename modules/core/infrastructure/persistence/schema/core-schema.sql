@@ -3,6 +3,7 @@ CREATE TABLE tenants (
     name varchar(255) NOT NULL UNIQUE,
     domain varchar(255),
     is_active boolean NOT NULL DEFAULT TRUE,
+    ui_language varchar(3) NOT NULL DEFAULT '',
     logo_id int REFERENCES uploads (id) ON DELETE SET NULL,
     logo_compact_id int REFERENCES uploads (id) ON DELETE SET NULL,
     created_at timestamp with time zone DEFAULT now(),
@@ -84,7 +85,7 @@ CREATE TABLE roles (
 CREATE TABLE users (
     id serial PRIMARY KEY,
     tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
-    type varchar(50) NOT NULL CHECK (type IN ('system', 'user')),
+    type varchar(50) NOT NULL CHECK (type IN ('system', 'user', 'service')),
     first_name varchar(255) NOT NULL,
     last_name varchar(255) NOT NULL,
     middle_name varchar(255),
@@ -102,6 +103,28 @@ CREATE TABLE users (
     UNIQUE (tenant_id, phone)
 );
 
+CREATE TABLE user_invites (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash varchar(64) NOT NULL UNIQUE, -- sha256 of the token sent in the invite link
+    expires_at timestamp with time zone NOT NULL,
+    accepted_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE api_tokens (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    token_hash varchar(64) NOT NULL UNIQUE, -- sha256 of the token, the token itself is only shown once
+    permissions jsonb NOT NULL DEFAULT '[]', -- names of the permissions the token is scoped to
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
 CREATE TABLE user_roles (
     user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
     role_id int NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
@@ -187,12 +210,64 @@ CREATE TABLE tabs (
     UNIQUE (tenant_id, href, user_id)
 );
 
+CREATE TABLE policies (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    resource varchar(255) NOT NULL,
+    action varchar(255) NOT NULL,
+    effect varchar(10) NOT NULL CHECK (effect IN ('allow', 'deny')),
+    condition text NOT NULL DEFAULT 'true',
+    enabled boolean NOT NULL DEFAULT TRUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE jobs (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    user_id int REFERENCES users (id) ON DELETE CASCADE,
+    type varchar(50) NOT NULL, -- export, import, etc.
+    title varchar(255) NOT NULL DEFAULT '',
+    status varchar(20) NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed', 'canceled')),
+    payload jsonb NOT NULL DEFAULT '{}',
+    progress int NOT NULL DEFAULT 0, -- 0..100
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL DEFAULT 3,
+    result_upload_id int REFERENCES uploads (id) ON DELETE SET NULL,
+    error_upload_id int REFERENCES uploads (id) ON DELETE SET NULL,
+    error text NOT NULL DEFAULT '',
+    run_at timestamp with time zone NOT NULL DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE graphql_persisted_queries (
+    hash varchar(64) PRIMARY KEY, -- sha256 of the query
+    query text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX tenants_domain_key ON tenants (domain) WHERE domain <> '';
+
 CREATE INDEX users_tenant_id_idx ON users (tenant_id);
 
 CREATE INDEX users_first_name_idx ON users (first_name);
 
 CREATE INDEX users_last_name_idx ON users (last_name);
 
+CREATE INDEX user_invites_tenant_id_idx ON user_invites (tenant_id);
+
+CREATE INDEX user_invites_user_id_idx ON user_invites (user_id);
+
+CREATE INDEX api_tokens_tenant_id_idx ON api_tokens (tenant_id);
+
+CREATE INDEX api_tokens_user_id_idx ON api_tokens (user_id);
+
 CREATE INDEX sessions_tenant_id_idx ON sessions (tenant_id);
 
 CREATE INDEX sessions_user_id_idx ON sessions (user_id);
@@ -215,3 +290,10 @@ CREATE INDEX permissions_tenant_id_idx ON permissions (tenant_id);
 
 CREATE INDEX tabs_tenant_id_idx ON tabs (tenant_id);
 
+CREATE INDEX jobs_tenant_id_idx ON jobs (tenant_id);
+
+CREATE INDEX jobs_user_id_idx ON jobs (user_id);
+
+CREATE INDEX jobs_status_run_at_idx ON jobs (status, run_at);
+
+CREATE INDEX policies_tenant_id_resource_action_idx ON policies (tenant_id, resource, action);
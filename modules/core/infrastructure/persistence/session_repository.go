@@ -8,9 +8,9 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
-	"github.com/iota-uz/psql-parser/util/uuid"
 
 	"github.com/go-faster/errors"
+	"github.com/google/uuid"
 )
 
 var (
@@ -37,8 +37,9 @@ const (
             ip = $2,
             user_agent = $3
         WHERE token = $4 AND tenant_id = $5`
-	deleteUserSessionQuery = `DELETE FROM sessions WHERE user_id = $1`
-	deleteSessionQuery     = `DELETE FROM sessions WHERE token = $1 AND tenant_id = $2`
+	deleteUserSessionQuery   = `DELETE FROM sessions WHERE user_id = $1`
+	deleteTenantSessionQuery = `DELETE FROM sessions WHERE tenant_id = $1`
+	deleteSessionQuery       = `DELETE FROM sessions WHERE token = $1 AND tenant_id = $2`
 )
 
 type SessionRepository struct {
@@ -218,6 +219,29 @@ func (g *SessionRepository) DeleteByUserId(ctx context.Context, userId uint) ([]
 	return sessions, nil
 }
 
+func (g *SessionRepository) DeleteByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*session.Session, error) {
+	sql := repo.Join(
+		selectSessionQuery,
+		repo.JoinWhere("sessions.tenant_id = $1"),
+	)
+	sessions, err := g.querySessions(
+		ctx,
+		sql,
+		tenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	err = g.execQuery(ctx, deleteTenantSessionQuery, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
 func (g *SessionRepository) querySessions(ctx context.Context, query string, args ...interface{}) ([]*session.Session, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
@@ -17,7 +17,7 @@ var (
 )
 
 const (
-	tenantFindQuery = `SELECT id, name, domain, is_active, logo_id, logo_compact_id, created_at, updated_at FROM tenants`
+	tenantFindQuery = `SELECT id, name, domain, is_active, ui_language, logo_id, logo_compact_id, created_at, updated_at FROM tenants`
 )
 
 type TenantRepository struct{}
@@ -56,8 +56,8 @@ func (r *TenantRepository) GetByDomain(ctx context.Context, domain string) (*ten
 
 func (r *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) (*tenant.Tenant, error) {
 	query := `
-		INSERT INTO tenants (id, name, domain, is_active, logo_id, logo_compact_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO tenants (id, name, domain, is_active, ui_language, logo_id, logo_compact_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 	`
 	tx, err := composables.UseTx(ctx)
@@ -73,6 +73,7 @@ func (r *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) (*tenan
 		t.Name(),
 		t.Domain(),
 		t.IsActive(),
+		t.UILanguage(),
 		mapping.PointerToSQLNullInt32(t.LogoID()),
 		mapping.PointerToSQLNullInt32(t.LogoCompactID()),
 		t.CreatedAt(),
@@ -92,8 +93,8 @@ func (r *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) (*tenan
 func (r *TenantRepository) Update(ctx context.Context, t *tenant.Tenant) (*tenant.Tenant, error) {
 	query := `
 		UPDATE tenants
-		SET name = $1, domain = $2, is_active = $3, logo_id = $4, logo_compact_id = $5, updated_at = $6
-		WHERE id = $7
+		SET name = $1, domain = $2, is_active = $3, ui_language = $4, logo_id = $5, logo_compact_id = $6, updated_at = $7
+		WHERE id = $8
 		RETURNING id
 	`
 	tx, err := composables.UseTx(ctx)
@@ -108,6 +109,7 @@ func (r *TenantRepository) Update(ctx context.Context, t *tenant.Tenant) (*tenan
 		t.Name(),
 		t.Domain(),
 		t.IsActive(),
+		t.UILanguage(),
 		mapping.PointerToSQLNullInt32(t.LogoID()),
 		mapping.PointerToSQLNullInt32(t.LogoCompactID()),
 		t.UpdatedAt(),
@@ -136,7 +138,7 @@ func (r *TenantRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *TenantRepository) List(ctx context.Context) ([]*tenant.Tenant, error) {
-	return r.queryTenants(ctx, tenantFindQuery)
+	return r.queryTenants(ctx, tenantFindQuery+" ORDER BY created_at")
 }
 
 func (r *TenantRepository) queryTenants(ctx context.Context, query string, args ...interface{}) ([]*tenant.Tenant, error) {
@@ -159,6 +161,7 @@ func (r *TenantRepository) queryTenants(ctx context.Context, query string, args
 			&t.Name,
 			&t.Domain,
 			&t.IsActive,
+			&t.UILanguage,
 			&t.LogoID,
 			&t.LogoCompactID,
 			&t.CreatedAt,
@@ -188,6 +191,7 @@ func toDomainTenant(t *models.Tenant) *tenant.Tenant {
 		tenant.WithID(id),
 		tenant.WithDomain(t.Domain.String),
 		tenant.WithIsActive(t.IsActive),
+		tenant.WithUILanguage(t.UILanguage),
 		tenant.WithLogoID(mapping.SQLNullInt32ToPointer(t.LogoID)),
 		tenant.WithLogoCompactID(mapping.SQLNullInt32ToPointer(t.LogoCompactID)),
 		tenant.WithCreatedAt(t.CreatedAt),
@@ -106,6 +106,7 @@ func NewUserRepository(uploadRepo upload.Repository) user.Repository {
 			user.CreatedAtField:    "u.created_at",
 			user.UpdatedAtField:    "u.updated_at",
 			user.TenantIDField:     "u.tenant_id",
+			user.TypeField:         "u.type",
 		},
 	}
 }
@@ -1,9 +1,12 @@
 package core
 
 import (
+	"context"
 	"embed"
 	"time"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
 	"github.com/iota-uz/iota-sdk/pkg/crud"
 
@@ -18,9 +21,13 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/permissions"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/assets"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
+	"github.com/iota-uz/iota-sdk/modules/core/seed"
 	"github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
 )
 
 //go:generate go run github.com/99designs/gqlgen generate
@@ -67,15 +74,29 @@ func (m *Module) Register(app application.Application) error {
 	// Create services
 	tabService := services.NewTabService(persistence.NewTabRepository())
 	tenantService := services.NewTenantService(tenantRepo)
+	policyService := services.NewPolicyService(persistence.NewPolicyRepository())
 	uploadService := services.NewUploadService(uploadRepo, fsStorage, app.EventPublisher())
+	excelExportService := services.NewExcelExportService(app.DB(), uploadService, tenantService)
+	userService := services.NewUserService(userRepo, userValidator, app.EventPublisher())
+	sessionService := services.NewSessionService(persistence.NewSessionRepository(), app.EventPublisher())
+	inviteService := services.NewInviteService(persistence.NewInviteRepository(), userRepo)
+	provisioningService := services.NewTenantProvisioningService(app, tenantRepo, userRepo, sessionService, inviteService)
+
+	// Background jobs, modules register their importers with ImportJobHandler
+	jobService := services.NewJobService(persistence.NewJobRepository(), app.EventPublisher())
+	importJobHandler := services.NewImportJobHandler(uploadService, excelExportService)
+	jobService.RegisterHandler(job.TypeExport, services.NewExportJobHandler(app.DB(), excelExportService))
+	jobService.RegisterHandler(job.TypeImport, importJobHandler)
 
 	app.RegisterServices(
 		uploadService,
-		services.NewUserService(userRepo, userValidator, app.EventPublisher()),
+		userService,
 		services.NewUserQueryService(userQueryRepo),
 		services.NewGroupQueryService(groupQueryRepo),
-		services.NewSessionService(persistence.NewSessionRepository(), app.EventPublisher()),
-		services.NewExcelExportService(app.DB(), uploadService),
+		sessionService,
+		excelExportService,
+		jobService,
+		importJobHandler,
 	)
 	app.RegisterServices(
 		services.NewAuthService(app),
@@ -86,6 +107,43 @@ func (m *Module) Register(app application.Application) error {
 		services.NewPermissionService(permRepo, app.EventPublisher()),
 		services.NewTabService(persistence.NewTabRepository()),
 		services.NewGroupService(persistence.NewGroupRepository(userRepo, roleRepo), app.EventPublisher()),
+		policyService,
+		inviteService,
+		provisioningService,
+		services.NewTenantDataService(app, tenantRepo, fsStorage, provisioningService),
+		services.NewAPITokenService(
+			persistence.NewAPITokenRepository(),
+			userRepo,
+			roleRepo,
+			tenantRepo,
+			userService,
+			app.RBAC(),
+		),
+	)
+	// New tenants get their own copy of the permissions, the admin role is created with the admin invite
+	app.RegisterTenantSeeds(
+		seed.CreatePermissions,
+	)
+	// Modules loaded after core register their user attributes with the app,
+	// so the providers are looked up on every evaluation
+	policyEngine := rbac.NewEngine(policyService, rbac.WithUserAttributes(
+		func(ctx context.Context, u user.User) (map[string]any, error) {
+			attrs := map[string]any{}
+			for _, provider := range app.UserAttributes() {
+				extra, err := provider(ctx, u)
+				if err != nil {
+					return nil, err
+				}
+				for k, v := range extra {
+					attrs[k] = v
+				}
+			}
+			return attrs, nil
+		},
+	))
+	app.RegisterServices(policyEngine)
+	app.RegisterMiddleware(
+		middleware.Provide(constants.PolicyEngineKey, policyEngine),
 	)
 
 	tabHandler := handlers.NewTabHandler(
@@ -145,6 +203,7 @@ func (m *Module) Register(app application.Application) error {
 		controllers.NewLensEventsController(app),
 		controllers.NewLoginController(app),
 		controllers.NewSpotlightController(app),
+		controllers.NewAPITokensController(app),
 		controllers.NewAccountController(app),
 		controllers.NewLogoutController(app),
 		controllers.NewUploadController(app),
@@ -154,11 +213,20 @@ func (m *Module) Register(app application.Application) error {
 		controllers.NewShowcaseController(app),
 		controllers.NewWebSocketController(app),
 		controllers.NewSettingsController(app),
+		controllers.NewJobsController(app),
+		controllers.NewTenantsController(app),
+		controllers.NewInviteController(app),
 		controllers.NewCrudController[currency.Currency](
 			"/currencies",
 			app,
 			builder,
 		),
+		controllers.NewCrudAPIController[currency.Currency](
+			"/api/v1/currencies",
+			app,
+			builder,
+		),
+		controllers.NewOpenAPIController(app),
 	)
 	app.RegisterHashFsAssets(assets.HashFS)
 	app.RegisterGraphSchema(application.GraphSchema{
@@ -172,6 +240,8 @@ func (m *Module) Register(app application.Application) error {
 		spotlight.NewQuickLink(DashboardLink.Icon, DashboardLink.Name, DashboardLink.Href),
 		spotlight.NewQuickLink(UsersLink.Icon, UsersLink.Name, UsersLink.Href),
 		spotlight.NewQuickLink(GroupsLink.Icon, GroupsLink.Name, GroupsLink.Href),
+		spotlight.NewQuickLink(nil, "NavigationLinks.Navbar.Jobs", "/jobs"),
+		spotlight.NewQuickLink(nil, "NavigationLinks.Navbar.Tenants", "/tenants"),
 		spotlight.NewQuickLink(
 			icons.PlusCircle(icons.Props{Size: "24"}),
 			"Users.List.New",
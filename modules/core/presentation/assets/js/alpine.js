@@ -603,6 +603,40 @@ let disableFormElementsWhen = (query) => ({
   }
 })
 
+// kanban moves cards between columns with native drag and drop. Cards carry
+// data-move-url and data-column, columns call drop() with their own value and
+// the move is posted through htmx, swapping the board with the response.
+let kanban = () => ({
+  card: null,
+  over: null,
+  start(event) {
+    this.card = event.target.closest('[data-move-url]');
+    if (this.card == null) return;
+    event.dataTransfer.effectAllowed = 'move';
+    event.dataTransfer.setData('text/plain', this.card.dataset.moveUrl);
+  },
+  end() {
+    this.card = null;
+    this.over = null;
+  },
+  enter(column) {
+    if (this.card != null) this.over = column;
+  },
+  isOver(column) {
+    return this.card != null && this.over === column && this.card.dataset.column !== column;
+  },
+  drop(column, field = 'StageID') {
+    let card = this.card;
+    this.end();
+    if (card == null || card.dataset.column === column) return;
+    htmx.ajax('POST', card.dataset.moveUrl, {
+      target: this.$root,
+      swap: 'outerHTML',
+      values: {[field]: column},
+    });
+  }
+})
+
 document.addEventListener("alpine:init", () => {
   Alpine.data("relativeformat", relativeFormat);
   Alpine.data("passwordVisibility", passwordVisibility);
@@ -616,4 +650,5 @@ document.addEventListener("alpine:init", () => {
   Alpine.data("navTabs", navTabs);
   Alpine.data("sidebar", sidebar);
   Alpine.data("disableFormElementsWhen", disableFormElementsWhen);
+  Alpine.data("kanban", kanban);
 });
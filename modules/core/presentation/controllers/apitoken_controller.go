@@ -0,0 +1,384 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/permissions"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/pages/account"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+	"github.com/iota-uz/iota-sdk/pkg/validators"
+)
+
+type APITokensController struct {
+	app      application.Application
+	basePath string
+}
+
+func NewAPITokensController(app application.Application) application.Controller {
+	return &APITokensController{
+		app:      app,
+		basePath: "/account/tokens",
+	}
+}
+
+func (c *APITokensController) Key() string {
+	return c.basePath
+}
+
+func (c *APITokensController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", di.H(c.List)).Methods(http.MethodGet)
+	router.HandleFunc("", di.H(c.Create)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}", di.H(c.Revoke)).Methods(http.MethodDelete)
+	router.HandleFunc("/service-accounts", di.H(c.CreateServiceAccount)).Methods(http.MethodPost)
+}
+
+// serviceAccounts returns the service accounts tokens can be issued for, none when the user can not manage them
+func (c *APITokensController) serviceAccounts(
+	ctx context.Context,
+	apiTokenService *services.APITokenService,
+) ([]user.User, error) {
+	if composables.CanUser(ctx, permissions.UserUpdate) != nil {
+		return nil, nil
+	}
+	return apiTokenService.ServiceAccounts(ctx)
+}
+
+// permissionGroups returns the permissions the current user holds grouped by resource
+func (c *APITokensController) permissionGroups(u user.User, selected []string) []*viewmodels.PermissionGroup {
+	isSelected := func(name string) bool {
+		for _, s := range selected {
+			if s == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	groupedByResource := c.app.RBAC().PermissionsByResource()
+	groups := make([]*viewmodels.PermissionGroup, 0, len(groupedByResource))
+	for resource, perms := range groupedByResource {
+		var permList []*viewmodels.PermissionItem
+		for _, perm := range perms {
+			if !u.Can(perm) {
+				continue
+			}
+			permList = append(permList, &viewmodels.PermissionItem{
+				ID:      perm.ID.String(),
+				Name:    perm.Name,
+				Checked: isSelected(perm.Name),
+			})
+		}
+		if len(permList) == 0 {
+			continue
+		}
+		groups = append(groups, &viewmodels.PermissionGroup{
+			Resource:    resource,
+			Permissions: permList,
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Resource < groups[j].Resource
+	})
+	return groups
+}
+
+func (c *APITokensController) tokenForm(
+	ctx context.Context,
+	accounts []user.User,
+	dto *dtos.CreateAPITokenDTO,
+	errorsMap map[string]string,
+) (*account.APITokenFormProps, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owners := []*account.TokenOwner{
+		{
+			ID:   strconv.FormatUint(uint64(u.ID()), 10),
+			Name: intl.MustT(ctx, "APITokens.Single.Me"),
+		},
+	}
+	for _, a := range accounts {
+		owners = append(owners, &account.TokenOwner{
+			ID:   strconv.FormatUint(uint64(a.ID()), 10),
+			Name: a.FirstName(),
+		})
+	}
+	if errorsMap == nil {
+		errorsMap = map[string]string{}
+	}
+	props := &account.APITokenFormProps{
+		ExpiresIn:        "90",
+		Owners:           owners,
+		PermissionGroups: c.permissionGroups(u, nil),
+		Errors:           errorsMap,
+	}
+	if dto != nil {
+		props.Name = dto.Name
+		props.ExpiresIn = dto.ExpiresIn
+		props.PermissionGroups = c.permissionGroups(u, dto.Permissions)
+		if dto.OwnerID != 0 {
+			props.OwnerID = strconv.FormatUint(uint64(dto.OwnerID), 10)
+		}
+	}
+	return props, nil
+}
+
+func (c *APITokensController) serviceAccountForm(
+	ctx context.Context,
+	roleService *services.RoleService,
+	dto *dtos.CreateServiceAccountDTO,
+	errorsMap map[string]string,
+) (*account.ServiceAccountFormProps, error) {
+	roles, err := roleService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if errorsMap == nil {
+		errorsMap = map[string]string{}
+	}
+	props := &account.ServiceAccountFormProps{
+		Roles:  mapping.MapViewModels(roles, mappers.RoleToViewModel),
+		Errors: errorsMap,
+	}
+	if dto != nil {
+		props.Name = dto.Name
+		props.Email = dto.Email
+		if dto.RoleID != 0 {
+			props.RoleID = strconv.FormatUint(uint64(dto.RoleID), 10)
+		}
+	}
+	return props, nil
+}
+
+// ownerNames maps the ids of token owners to the names shown in the tokens table
+func ownerNames(ctx context.Context, current user.User, accounts []user.User) map[uint]string {
+	names := map[uint]string{
+		current.ID(): intl.MustT(ctx, "APITokens.Single.Me"),
+	}
+	for _, a := range accounts {
+		names[a.ID()] = a.FirstName()
+	}
+	return names
+}
+
+func (c *APITokensController) List(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	apiTokenService *services.APITokenService,
+	roleService *services.RoleService,
+) {
+	ctx := r.Context()
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		logger.Errorf("Error getting user: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	accounts, err := c.serviceAccounts(ctx, apiTokenService)
+	if err != nil {
+		logger.Errorf("Error retrieving service accounts: %v", err)
+		http.Error(w, "Error retrieving service accounts", http.StatusInternalServerError)
+		return
+	}
+	tokens, err := apiTokenService.GetAll(ctx)
+	if err != nil {
+		logger.Errorf("Error retrieving api tokens: %v", err)
+		http.Error(w, "Error retrieving api tokens", http.StatusInternalServerError)
+		return
+	}
+	names := ownerNames(ctx, u, accounts)
+	tokenViewModels := make([]*viewmodels.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		tokenViewModels = append(tokenViewModels, mappers.APITokenToViewModel(token, names[token.UserID()]))
+	}
+
+	form, err := c.tokenForm(ctx, accounts, nil, nil)
+	if err != nil {
+		logger.Errorf("Error building token form: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &account.APITokensPageProps{
+		Tokens:          tokenViewModels,
+		ServiceAccounts: mapping.MapViewModels(accounts, mappers.UserToViewModel),
+		Form:            form,
+	}
+	if composables.CanUser(ctx, permissions.UserCreate) == nil {
+		props.ServiceAccountForm, err = c.serviceAccountForm(ctx, roleService, nil, nil)
+		if err != nil {
+			logger.Errorf("Error retrieving roles: %v", err)
+			http.Error(w, "Error retrieving roles", http.StatusInternalServerError)
+			return
+		}
+	}
+	templ.Handler(account.APITokens(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *APITokensController) Create(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	apiTokenService *services.APITokenService,
+) {
+	ctx := r.Context()
+	dto, err := composables.UseForm(&dtos.CreateAPITokenDTO{}, r)
+	if err != nil {
+		logger.Errorf("Error parsing form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		logger.Errorf("Error getting user: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	accounts, err := c.serviceAccounts(ctx, apiTokenService)
+	if err != nil {
+		logger.Errorf("Error retrieving service accounts: %v", err)
+		http.Error(w, "Error retrieving service accounts", http.StatusInternalServerError)
+		return
+	}
+	respondWithForm := func(errorsMap map[string]string) {
+		props, err := c.tokenForm(ctx, accounts, dto, errorsMap)
+		if err != nil {
+			logger.Errorf("Error building token form: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		templ.Handler(account.APITokenForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+
+	if errorsMap, ok := dto.Ok(ctx); !ok {
+		respondWithForm(errorsMap)
+		return
+	}
+	ownerID := dto.OwnerID
+	if ownerID == 0 {
+		ownerID = u.ID()
+	}
+	token, secret, err := apiTokenService.Issue(ctx, ownerID, dto.Name, dto.Permissions, dto.ExpiresAt())
+	if errors.Is(err, services.ErrTokenPermissionNotHeld) {
+		respondWithForm(map[string]string{
+			"Permissions": intl.MustT(ctx, "APITokens.Errors.PermissionNotHeld"),
+		})
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error issuing api token: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	props, err := c.tokenForm(ctx, accounts, nil, nil)
+	if err != nil {
+		logger.Errorf("Error building token form: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props.Secret = secret
+	names := ownerNames(ctx, u, accounts)
+	templ.Handler(account.APITokenForm(props)).ServeHTTP(w, r)
+	templ.Handler(account.APITokenCreated(mappers.APITokenToViewModel(token, names[token.UserID()]))).ServeHTTP(w, r)
+}
+
+func (c *APITokensController) Revoke(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	apiTokenService *services.APITokenService,
+) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		logger.Errorf("Error parsing api token ID: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := apiTokenService.Revoke(r.Context(), id); err != nil {
+		logger.Errorf("Error revoking api token: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *APITokensController) CreateServiceAccount(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	apiTokenService *services.APITokenService,
+	roleService *services.RoleService,
+) {
+	ctx := r.Context()
+	dto, err := composables.UseForm(&dtos.CreateServiceAccountDTO{}, r)
+	if err != nil {
+		logger.Errorf("Error parsing form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	respondWithForm := func(errorsMap map[string]string) {
+		props, err := c.serviceAccountForm(ctx, roleService, dto, errorsMap)
+		if err != nil {
+			logger.Errorf("Error retrieving roles: %v", err)
+			http.Error(w, "Error retrieving roles", http.StatusInternalServerError)
+			return
+		}
+		templ.Handler(account.ServiceAccountForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+
+	if errorsMap, ok := dto.Ok(ctx); !ok {
+		respondWithForm(errorsMap)
+		return
+	}
+	params, err := dto.ToParams()
+	if err != nil {
+		logger.Errorf("Error converting DTO to params: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := apiTokenService.CreateServiceAccount(ctx, params); err != nil {
+		var errs *validators.ValidationError
+		if errors.As(err, &errs) {
+			respondWithForm(errs.Fields)
+			return
+		}
+		logger.Errorf("Error creating service account: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	htmx.Refresh(w)
+}
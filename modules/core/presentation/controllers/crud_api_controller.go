@@ -0,0 +1,599 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+// Error codes of the JSON API
+const (
+	apiErrInvalidRequest = "INVALID_REQUEST"
+	apiErrNotFound       = "NOT_FOUND"
+	apiErrValidation     = "VALIDATION_ERROR"
+	apiErrInternal       = "INTERNAL_SERVER_ERROR"
+)
+
+// maxAPIBodySize limits the size of JSON request bodies
+const maxAPIBodySize = 1 << 20
+
+// OpenAPIDescriber is implemented by controllers publishing their endpoints in the OpenAPI document
+type OpenAPIDescriber interface {
+	DescribeAPI(doc *crud.OpenAPIDocument)
+}
+
+type apiError struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+type apiPage struct {
+	Items  []map[string]any `json:"items"`
+	Total  int64            `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+// CrudAPIController serves a JSON REST API for a crud schema.
+// It shares the schema, service and validation with CrudController, only the representation differs.
+type CrudAPIController[TEntity any] struct {
+	basePath string
+	app      application.Application
+	schema   crud.Schema[TEntity]
+	service  crud.Service[TEntity]
+
+	primaryKeyField crud.Field
+	filterFields    map[string]crud.Field
+	uploadFields    []crud.UploadField
+
+	enableCreate bool
+	enableUpdate bool
+	enableDelete bool
+}
+
+// CrudAPIOption defines options for CrudAPIController
+type CrudAPIOption[TEntity any] func(*CrudAPIController[TEntity])
+
+// WithoutAPICreate disables the create endpoint
+func WithoutAPICreate[TEntity any]() CrudAPIOption[TEntity] {
+	return func(c *CrudAPIController[TEntity]) {
+		c.enableCreate = false
+	}
+}
+
+// WithoutAPIUpdate disables the update endpoint
+func WithoutAPIUpdate[TEntity any]() CrudAPIOption[TEntity] {
+	return func(c *CrudAPIController[TEntity]) {
+		c.enableUpdate = false
+	}
+}
+
+// WithoutAPIDelete disables the delete endpoint
+func WithoutAPIDelete[TEntity any]() CrudAPIOption[TEntity] {
+	return func(c *CrudAPIController[TEntity]) {
+		c.enableDelete = false
+	}
+}
+
+func NewCrudAPIController[TEntity any](
+	basePath string,
+	app application.Application,
+	builder crud.Builder[TEntity],
+	opts ...CrudAPIOption[TEntity],
+) application.Controller {
+	controller := &CrudAPIController[TEntity]{
+		basePath:     basePath,
+		app:          app,
+		schema:       builder.Schema(),
+		service:      builder.Service(),
+		filterFields: make(map[string]crud.Field),
+		enableCreate: true,
+		enableUpdate: true,
+		enableDelete: true,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	for _, f := range controller.schema.Fields().Fields() {
+		if f.Key() && controller.primaryKeyField == nil {
+			controller.primaryKeyField = f
+		}
+		if uf, ok := f.(crud.UploadField); ok {
+			controller.uploadFields = append(controller.uploadFields, uf)
+		}
+		if f.Hidden() || f.Type() == crud.CollectionFieldType {
+			continue
+		}
+		controller.filterFields[f.Name()] = f
+	}
+
+	if controller.primaryKeyField == nil {
+		panic(fmt.Sprintf("CrudAPIController: no primary key field found in schema for %s", controller.schema.Name()))
+	}
+
+	return controller
+}
+
+func (c *CrudAPIController[TEntity]) Key() string {
+	return c.basePath
+}
+
+func (c *CrudAPIController[TEntity]) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RequireAuthorization(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+	)
+
+	router.HandleFunc("", c.List).Methods(http.MethodGet)
+	router.HandleFunc("/{id}", c.Get).Methods(http.MethodGet)
+
+	if c.enableCreate {
+		router.HandleFunc("", c.Create).Methods(http.MethodPost)
+	}
+	if c.enableUpdate {
+		router.HandleFunc("/{id}", c.Update).Methods(http.MethodPatch)
+	}
+	if c.enableDelete {
+		router.HandleFunc("/{id}", c.Delete).Methods(http.MethodDelete)
+	}
+}
+
+func (c *CrudAPIController[TEntity]) DescribeAPI(doc *crud.OpenAPIDocument) {
+	doc.AddResource(c.basePath, c.schema, crud.APIOperations{
+		Create: c.enableCreate,
+		Update: c.enableUpdate,
+		Delete: c.enableDelete,
+	})
+}
+
+func (c *CrudAPIController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params, err := c.parseFindParams(r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, apiErrInvalidRequest, err.Error(), nil)
+		return
+	}
+
+	entities, err := c.service.List(ctx, params)
+	if err != nil {
+		log.Printf("[CrudAPIController.List] Failed to list entities: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Failed to retrieve data", nil)
+		return
+	}
+
+	total, err := c.service.Count(ctx, &crud.FindParams{
+		Query:   params.Query,
+		Filters: params.Filters,
+	})
+	if err != nil {
+		log.Printf("[CrudAPIController.List] Failed to count entities: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Failed to retrieve data", nil)
+		return
+	}
+
+	items := make([]map[string]any, 0, len(entities))
+	for _, entity := range entities {
+		item, err := c.toJSON(ctx, entity)
+		if err != nil {
+			log.Printf("[CrudAPIController.List] Failed to map entity: %v", err)
+			writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Failed to retrieve data", nil)
+			return
+		}
+		items = append(items, item)
+	}
+
+	writeJSON(w, http.StatusOK, apiPage{
+		Items:  items,
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
+
+func (c *CrudAPIController[TEntity]) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	entity, ok := c.findEntity(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := c.toJSON(ctx, entity)
+	if err != nil {
+		log.Printf("[CrudAPIController.Get] Failed to map entity: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Failed to retrieve data", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (c *CrudAPIController[TEntity]) Create(w http.ResponseWriter, r *http.Request) {
+	fieldValues, ok := c.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	existingFields := make(map[string]bool, len(fieldValues))
+	for _, fv := range fieldValues {
+		existingFields[fv.Field().Name()] = true
+	}
+	for _, f := range c.schema.Fields().Fields() {
+		if !existingFields[f.Name()] {
+			fieldValues = append(fieldValues, f.Value(f.InitialValue()))
+		}
+	}
+
+	c.save(w, r, fieldValues, http.StatusCreated)
+}
+
+func (c *CrudAPIController[TEntity]) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbEntity, ok := c.findEntity(w, r)
+	if !ok {
+		return
+	}
+
+	fieldValues, ok := c.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	dbFvs, err := c.schema.Mapper().ToFieldValues(ctx, dbEntity)
+	if err != nil {
+		log.Printf("[CrudAPIController.Update] Failed to map entity: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Internal server error", nil)
+		return
+	}
+
+	// Fields left out of the body keep their stored values, the key always comes from the path
+	existingFields := make(map[string]bool, len(fieldValues))
+	for _, fv := range fieldValues {
+		existingFields[fv.Field().Name()] = true
+	}
+	delete(existingFields, c.primaryKeyField.Name())
+	fieldValues = slices.DeleteFunc(fieldValues, func(fv crud.FieldValue) bool {
+		return fv.Field().Key()
+	})
+	for _, fv := range dbFvs {
+		if !existingFields[fv.Field().Name()] {
+			fieldValues = append(fieldValues, fv)
+		}
+	}
+
+	c.save(w, r, fieldValues, http.StatusOK)
+}
+
+func (c *CrudAPIController[TEntity]) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idValue, ok := c.parseID(w, r)
+	if !ok {
+		return
+	}
+	if !c.exists(w, r, idValue) {
+		return
+	}
+
+	deleted, err := c.service.Delete(ctx, idValue)
+	if err != nil {
+		log.Printf("[CrudAPIController.Delete] Failed to delete entity %v: %v", idValue.Value(), err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Failed to delete data", nil)
+		return
+	}
+
+	item, err := c.toJSON(ctx, deleted)
+	if err != nil {
+		// The record is gone, only its representation is missing
+		log.Printf("[CrudAPIController.Delete] Failed to map deleted entity: %v", err)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+// save validates the field values and stores the entity, responding with its stored representation
+func (c *CrudAPIController[TEntity]) save(w http.ResponseWriter, r *http.Request, fieldValues []crud.FieldValue, status int) {
+	ctx := r.Context()
+
+	if fieldErrors := c.validateFieldValues(fieldValues); len(fieldErrors) > 0 {
+		writeAPIError(w, http.StatusUnprocessableEntity, apiErrValidation, "Validation failed", fieldErrors)
+		return
+	}
+
+	if err := c.validateUploads(ctx, fieldValues); err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, apiErrValidation, err.Error(), nil)
+		return
+	}
+
+	entity, err := c.schema.Mapper().ToEntity(ctx, fieldValues)
+	if err != nil {
+		log.Printf("[CrudAPIController.save] Failed to map to entity: %v", err)
+		writeAPIError(w, http.StatusBadRequest, apiErrInvalidRequest, "Invalid data", nil)
+		return
+	}
+
+	for _, validator := range c.schema.Validators() {
+		if err := validator(entity); err != nil {
+			writeAPIError(w, http.StatusUnprocessableEntity, apiErrValidation, err.Error(), nil)
+			return
+		}
+	}
+
+	saved, err := c.service.Save(ctx, entity)
+	if err != nil {
+		log.Printf("[CrudAPIController.save] Failed to save entity: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Failed to save data", nil)
+		return
+	}
+
+	item, err := c.toJSON(ctx, saved)
+	if err != nil {
+		log.Printf("[CrudAPIController.save] Failed to map saved entity: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Internal server error", nil)
+		return
+	}
+	writeJSON(w, status, item)
+}
+
+// findEntity loads the entity addressed by the path, responding with an error when it cannot
+func (c *CrudAPIController[TEntity]) findEntity(w http.ResponseWriter, r *http.Request) (TEntity, bool) {
+	var zero TEntity
+
+	idValue, ok := c.parseID(w, r)
+	if !ok {
+		return zero, false
+	}
+	if !c.exists(w, r, idValue) {
+		return zero, false
+	}
+
+	entity, err := c.service.Get(r.Context(), idValue)
+	if err != nil {
+		log.Printf("[CrudAPIController] Failed to get entity %v: %v", idValue.Value(), err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Failed to retrieve data", nil)
+		return zero, false
+	}
+	return entity, true
+}
+
+func (c *CrudAPIController[TEntity]) exists(w http.ResponseWriter, r *http.Request, idValue crud.FieldValue) bool {
+	exists, err := c.service.Exists(r.Context(), idValue)
+	if err != nil {
+		log.Printf("[CrudAPIController] Failed to check entity %v: %v", idValue.Value(), err)
+		writeAPIError(w, http.StatusInternalServerError, apiErrInternal, "Failed to retrieve data", nil)
+		return false
+	}
+	if !exists {
+		writeAPIError(w, http.StatusNotFound, apiErrNotFound, "Record not found", nil)
+		return false
+	}
+	return true
+}
+
+func (c *CrudAPIController[TEntity]) parseID(w http.ResponseWriter, r *http.Request) (crud.FieldValue, bool) {
+	idValue, err := crud.ValueFromText(c.primaryKeyField, mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, apiErrInvalidRequest, err.Error(), nil)
+		return nil, false
+	}
+	return idValue, true
+}
+
+// readBody decodes the JSON object of the request into field values
+func (c *CrudAPIController[TEntity]) readBody(w http.ResponseWriter, r *http.Request) ([]crud.FieldValue, bool) {
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBodySize)).Decode(&body); err != nil {
+		writeAPIError(w, http.StatusBadRequest, apiErrInvalidRequest, "Request body must be a JSON object", nil)
+		return nil, false
+	}
+
+	fieldValues, err := crud.ValuesFromJSON(c.schema.Fields(), body)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, crud.ErrInvalidValue) {
+			status = http.StatusUnprocessableEntity
+		}
+		writeAPIError(w, status, apiErrInvalidRequest, err.Error(), nil)
+		return nil, false
+	}
+	return fieldValues, true
+}
+
+// parseFindParams reads pagination, sorting, search and filters from the query string.
+// Filters are "<field>=<value>" or "<field>__<operator>=<value>", "in" takes comma separated values.
+func (c *CrudAPIController[TEntity]) parseFindParams(r *http.Request) (*crud.FindParams, error) {
+	conf := configuration.Use()
+	query := r.URL.Query()
+
+	pagination := composables.UsePaginated(r)
+	params := &crud.FindParams{
+		Query:  query.Get("search"),
+		Limit:  pagination.Limit,
+		Offset: pagination.Offset,
+	}
+	if params.Limit < 1 {
+		params.Limit = conf.PageSize
+	}
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid offset %q", raw)
+		}
+		params.Offset = offset
+	}
+
+	if raw := query.Get("sort"); raw != "" {
+		for _, name := range strings.Split(raw, ",") {
+			ascending := !strings.HasPrefix(name, "-")
+			name = strings.TrimPrefix(name, "-")
+			if _, ok := c.filterFields[name]; !ok {
+				return nil, fmt.Errorf("unknown sort field %q", name)
+			}
+			params.SortBy.Fields = append(params.SortBy.Fields, repo.SortByField[string]{
+				Field:     name,
+				Ascending: ascending,
+				NullsLast: true,
+			})
+		}
+	}
+
+	for key, values := range query {
+		switch key {
+		case "search", "sort", "limit", "offset", "page":
+			continue
+		}
+		name, operator := key, crud.FilterEq
+		if _, ok := c.filterFields[key]; !ok {
+			if i := strings.LastIndex(key, "__"); i > 0 {
+				name, operator = key[:i], key[i+2:]
+			}
+		}
+		f, ok := c.filterFields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter field %q", name)
+		}
+		for _, value := range values {
+			filter, err := buildAPIFilter(f, operator, value)
+			if err != nil {
+				return nil, err
+			}
+			params.Filters = append(params.Filters, crud.Filter{Column: name, Filter: filter})
+		}
+	}
+
+	return params, nil
+}
+
+func buildAPIFilter(f crud.Field, operator, raw string) (repo.Filter, error) {
+	supported := false
+	for _, op := range crud.FilterOperators(f.Type()) {
+		if op == operator {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("operator %q is not supported for field %q", operator, f.Name())
+	}
+
+	if operator == crud.FilterLike {
+		return repo.ILike("%" + raw + "%"), nil
+	}
+	if operator == crud.FilterIn {
+		parts := strings.Split(raw, ",")
+		values := make([]any, 0, len(parts))
+		for _, part := range parts {
+			fv, err := crud.ValueFromText(f, part)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, fv.Value())
+		}
+		return repo.In(values), nil
+	}
+
+	fv, err := crud.ValueFromText(f, raw)
+	if err != nil {
+		return nil, err
+	}
+	switch operator {
+	case crud.FilterNotEq:
+		return repo.NotEq(fv.Value()), nil
+	case crud.FilterGt:
+		return repo.Gt(fv.Value()), nil
+	case crud.FilterGte:
+		return repo.Gte(fv.Value()), nil
+	case crud.FilterLt:
+		return repo.Lt(fv.Value()), nil
+	case crud.FilterLte:
+		return repo.Lte(fv.Value()), nil
+	}
+	return repo.Eq(fv.Value()), nil
+}
+
+func (c *CrudAPIController[TEntity]) toJSON(ctx context.Context, entity TEntity) (map[string]any, error) {
+	fieldValues, err := c.schema.Mapper().ToFieldValues(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return crud.ValuesToJSON(fieldValues), nil
+}
+
+// validateFieldValues applies the field rules, reporting the first failure of every field
+func (c *CrudAPIController[TEntity]) validateFieldValues(fieldValues []crud.FieldValue) map[string]string {
+	fieldErrors := make(map[string]string)
+	for _, fv := range fieldValues {
+		for _, rule := range fv.Field().Rules() {
+			if err := rule(fv); err != nil {
+				fieldErrors[fv.Field().Name()] = err.Error()
+				break
+			}
+		}
+	}
+	return fieldErrors
+}
+
+// validateUploads checks that the referenced uploads exist
+func (c *CrudAPIController[TEntity]) validateUploads(ctx context.Context, fieldValues []crud.FieldValue) error {
+	if len(c.uploadFields) == 0 {
+		return nil
+	}
+	for _, fv := range fieldValues {
+		if _, ok := fv.Field().(crud.UploadField); !ok || fv.Value() == nil || fv.IsZero() {
+			continue
+		}
+		id, ok := uploadID(fv.Value())
+		if !ok {
+			return fmt.Errorf("invalid upload ID for field %s: %v", fv.Field().Name(), fv.Value())
+		}
+		exists, err := c.app.Service(services.UploadService{}).(*services.UploadService).Exists(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check upload %d: %w", id, err)
+		}
+		if !exists {
+			return fmt.Errorf("upload %d of field %s not found", id, fv.Field().Name())
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, code, message string, fieldErrors map[string]string) {
+	writeJSON(w, status, apiError{
+		Code:    code,
+		Message: message,
+		Errors:  fieldErrors,
+	})
+}
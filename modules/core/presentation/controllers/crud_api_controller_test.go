@@ -0,0 +1,146 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/core"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCrudAPI(t *testing.T, service *testService) *itf.Suite {
+	t.Helper()
+	suite := itf.HTTP(t, core.NewModule()).AsUser(itf.User())
+	env := suite.Environment()
+	suite.Register(controllers.NewCrudAPIController[TestEntity]("/api/test", env.App, createTestBuilder(service)))
+	return suite
+}
+
+func decodeJSON(t *testing.T, body string) map[string]any {
+	t.Helper()
+	var result map[string]any
+	require.NoError(t, json.Unmarshal([]byte(body), &result))
+	return result
+}
+
+func TestCrudAPIController_List(t *testing.T) {
+	service := newTestService()
+	for _, name := range []string{"Apple", "Banana", "Cherry"} {
+		entity := TestEntity{ID: uuid.New(), Name: name, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		service.entities[entity.ID] = entity
+	}
+	suite := setupCrudAPI(t, service)
+
+	body := suite.GET("/api/test?limit=2").Expect(t).Status(http.StatusOK).Body()
+
+	page := decodeJSON(t, body)
+	assert.InDelta(t, 3, page["total"], 0)
+	assert.InDelta(t, 2, page["limit"], 0)
+	assert.Len(t, page["items"], 2)
+}
+
+func TestCrudAPIController_List_InvalidFilter(t *testing.T) {
+	suite := setupCrudAPI(t, newTestService())
+
+	suite.GET("/api/test?color=red").Expect(t).Status(http.StatusBadRequest)
+	suite.GET("/api/test?amount__like=1").Expect(t).Status(http.StatusBadRequest)
+	suite.GET("/api/test?sort=-color").Expect(t).Status(http.StatusBadRequest)
+}
+
+func TestCrudAPIController_Get(t *testing.T) {
+	service := newTestService()
+	entity := TestEntity{ID: uuid.New(), Name: "Apple", Amount: 10, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	service.entities[entity.ID] = entity
+	suite := setupCrudAPI(t, service)
+
+	body := suite.GET("/api/test/" + entity.ID.String()).Expect(t).Status(http.StatusOK).Body()
+	item := decodeJSON(t, body)
+	assert.Equal(t, entity.ID.String(), item["id"])
+	assert.Equal(t, "Apple", item["name"])
+
+	suite.GET("/api/test/" + uuid.NewString()).Expect(t).Status(http.StatusNotFound)
+	suite.GET("/api/test/not-a-uuid").Expect(t).Status(http.StatusBadRequest)
+}
+
+func TestCrudAPIController_Create(t *testing.T) {
+	service := newTestService()
+	suite := setupCrudAPI(t, service)
+
+	body := suite.POST("/api/test").
+		JSON(map[string]any{"name": "Apple", "amount": 12.5, "is_active": true}).
+		Expect(t).
+		Status(http.StatusCreated).
+		Body()
+
+	item := decodeJSON(t, body)
+	assert.Equal(t, "Apple", item["name"])
+	require.Len(t, service.entities, 1)
+	for _, created := range service.entities {
+		assert.InDelta(t, 12.5, created.Amount, 0.001)
+		assert.True(t, created.IsActive)
+	}
+
+	suite.POST("/api/test").
+		JSON(map[string]any{"name": 5}).
+		Expect(t).
+		Status(http.StatusUnprocessableEntity)
+	suite.POST("/api/test").
+		JSON(map[string]any{"color": "red"}).
+		Expect(t).
+		Status(http.StatusBadRequest)
+}
+
+func TestCrudAPIController_Update(t *testing.T) {
+	service := newTestService()
+	entity := TestEntity{ID: uuid.New(), Name: "Apple", Description: "Green", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	service.entities[entity.ID] = entity
+	suite := setupCrudAPI(t, service)
+
+	suite.PATCH("/api/test/" + entity.ID.String()).
+		JSON(map[string]any{"name": "Pear", "id": uuid.NewString()}).
+		Expect(t).
+		Status(http.StatusOK)
+
+	updated := service.entities[entity.ID]
+	assert.Equal(t, "Pear", updated.Name)
+	assert.Equal(t, "Green", updated.Description)
+	assert.Len(t, service.entities, 1)
+}
+
+func TestCrudAPIController_Delete(t *testing.T) {
+	service := newTestService()
+	entity := TestEntity{ID: uuid.New(), Name: "Apple", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	service.entities[entity.ID] = entity
+	suite := setupCrudAPI(t, service)
+
+	suite.DELETE("/api/test/" + entity.ID.String()).Expect(t).Status(http.StatusOK)
+	assert.Empty(t, service.entities)
+
+	suite.DELETE("/api/test/" + entity.ID.String()).Expect(t).Status(http.StatusNotFound)
+}
+
+func TestOpenAPIController(t *testing.T) {
+	suite := itf.HTTP(t, core.NewModule()).AsUser(itf.User())
+	env := suite.Environment()
+	env.App.RegisterControllers(
+		controllers.NewCrudAPIController[TestEntity]("/api/test", env.App, createTestBuilder(newTestService()), controllers.WithoutAPIDelete[TestEntity]()),
+	)
+	suite.Register(controllers.NewOpenAPIController(env.App))
+
+	body := suite.GET("/api/v1/openapi.json").Expect(t).Status(http.StatusOK).Body()
+
+	var doc crud.OpenAPIDocument
+	require.NoError(t, json.Unmarshal([]byte(body), &doc))
+	assert.Equal(t, crud.OpenAPIVersion, doc.OpenAPI)
+	require.Contains(t, doc.Paths, "/api/test/{id}")
+	assert.Contains(t, doc.Paths["/api/test/{id}"], "patch")
+	assert.NotContains(t, doc.Paths["/api/test/{id}"], "delete")
+	assert.Contains(t, doc.Components.Schemas, "TestEntities")
+}
@@ -7,7 +7,9 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/a-h/templ"
@@ -48,6 +50,8 @@ type CrudController[TEntity any] struct {
 	visibleFields   []crud.Field
 	formFields      []crud.Field
 	primaryKeyField crud.Field
+	references      map[string]crud.ReferenceField
+	uploadFields    []crud.UploadField
 
 	// options
 	enableEdit   bool
@@ -112,6 +116,7 @@ func (c *CrudController[TEntity]) Register(r *mux.Router) {
 		middleware.Authorize(),
 		middleware.RedirectNotAuthenticated(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.ProvideDynamicLogo(c.app),
 		middleware.Tabs(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
@@ -120,6 +125,9 @@ func (c *CrudController[TEntity]) Register(r *mux.Router) {
 	)
 
 	router.HandleFunc("", c.List).Methods(http.MethodGet)
+	if len(c.references) > 0 {
+		router.HandleFunc("/references/{field}", c.SearchReference).Methods(http.MethodGet)
+	}
 	router.HandleFunc("/{id}/details", c.Details).Methods(http.MethodGet)
 
 	if c.enableCreate {
@@ -147,12 +155,27 @@ func (c *CrudController[TEntity]) initFieldCache() {
 
 	c.visibleFields = make([]crud.Field, 0, len(allFields))
 	c.formFields = make([]crud.Field, 0, len(allFields))
+	c.references = make(map[string]crud.ReferenceField)
 
 	for _, f := range allFields {
 		if f.Key() && c.primaryKeyField == nil {
 			c.primaryKeyField = f
 		}
 
+		switch rf := f.(type) {
+		case crud.ReferenceField:
+			c.references[rf.Name()] = rf
+		case crud.UploadField:
+			c.uploadFields = append(c.uploadFields, rf)
+		case crud.CollectionField:
+			// References of child rows are searched under "<collection>.<field>"
+			for _, child := range rf.EditableFields() {
+				if ref, ok := child.(crud.ReferenceField); ok {
+					c.references[childReferenceKey(rf, ref)] = ref
+				}
+			}
+		}
+
 		if !f.Hidden() {
 			c.visibleFields = append(c.visibleFields, f)
 
@@ -196,7 +219,7 @@ func (c *CrudController[TEntity]) validateID(id string) error {
 		if _, err := uuid.Parse(id); err != nil {
 			return fmt.Errorf("invalid UUID: %s", id)
 		}
-	case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType:
+	case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.CollectionFieldType:
 		// These types don't need special validation for ID format
 	}
 	return nil
@@ -222,7 +245,7 @@ func (c *CrudController[TEntity]) parseIDValue(id string) any {
 		}
 		// If parsing fails, return nil UUID instead of nil
 		return uuid.Nil
-	case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType:
+	case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.CollectionFieldType:
 		// For all other types, return the string as-is
 		return id
 	}
@@ -250,125 +273,21 @@ func (c *CrudController[TEntity]) buildFieldValuesFromForm(r *http.Request) ([]c
 			continue
 		}
 
-		formValue := r.Form.Get(fieldName)
-		var value any
-
-		// Check if this is a select field and handle value type accordingly
-		if selectField, ok := field.(crud.SelectField); ok {
-			// Parse value based on the select field's value type
-			fieldType := selectField.ValueType()
-			switch fieldType {
-			case crud.IntFieldType:
-				if formValue != "" {
-					if int64Val, err := strconv.ParseInt(formValue, 10, 64); err == nil {
-						if int64Val >= math.MinInt32 && int64Val <= math.MaxInt32 {
-							value = int(int64Val)
-						} else {
-							value = int64Val
-						}
-					} else {
-						return nil, fmt.Errorf("invalid integer value for select field %s: %v", fieldName, err)
-					}
-				} else {
-					continue // Skip empty values
-				}
-			case crud.BoolFieldType:
-				value = formValue == "true" || formValue == "1"
-			case crud.FloatFieldType:
-				if formValue != "" {
-					if floatVal, err := strconv.ParseFloat(formValue, 64); err == nil {
-						value = floatVal
-					} else {
-						return nil, fmt.Errorf("invalid float value for select field %s: %v", fieldName, err)
-					}
-				} else {
-					continue
-				}
-			case crud.StringFieldType, crud.DecimalFieldType, crud.DateFieldType,
-				crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.UUIDFieldType:
-				value = formValue
-			default:
-				// Default to string for any unknown types
-				value = formValue
+		if cf, ok := field.(crud.CollectionField); ok {
+			fv, err := c.buildCollectionValue(r, cf)
+			if err != nil {
+				return nil, err
 			}
-		} else {
-			// Convert form value based on field type
-			switch field.Type() {
-			case crud.BoolFieldType:
-				value = formValue == "on" || formValue == "true" || formValue == "1"
-			case crud.IntFieldType:
-				if formValue != "" {
-					if int64Val, err := strconv.ParseInt(formValue, 10, 64); err == nil {
-						if int64Val >= math.MinInt32 && int64Val <= math.MaxInt32 {
-							value = int(int64Val)
-						} else {
-							value = int64Val
-						}
-					} else {
-						return nil, fmt.Errorf("invalid integer value for field %s: %v", fieldName, err)
-					}
-				} else {
-					continue // Skip empty values
-				}
-			case crud.FloatFieldType:
-				if formValue != "" {
-					if floatVal, err := strconv.ParseFloat(formValue, 64); err == nil {
-						value = floatVal
-					} else {
-						return nil, fmt.Errorf("invalid float value for field %s: %v", fieldName, err)
-					}
-				} else {
-					continue // Skip empty values
-				}
-			case crud.DateFieldType, crud.DateTimeFieldType, crud.TimeFieldType:
-				if formValue != "" {
-					parsedTime, err := time.Parse(time.RFC3339, formValue)
-					if err != nil {
-						// Try common HTML5 formats based on field type
-						formats := []string{}
-						switch field.Type() {
-						case crud.DateFieldType:
-							formats = []string{"2006-01-02"}
-						case crud.TimeFieldType:
-							formats = []string{"15:04", "15:04:05"}
-						case crud.DateTimeFieldType:
-							formats = []string{"2006-01-02T15:04", "2006-01-02T15:04:05"}
-						case crud.StringFieldType, crud.IntFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.TimestampFieldType, crud.UUIDFieldType:
-							// These types are handled elsewhere
-							formats = []string{}
-						}
+			fieldValues = append(fieldValues, fv)
+			continue
+		}
 
-						for _, format := range formats {
-							if parsedTime, err = time.Parse(format, formValue); err == nil {
-								break
-							}
-						}
-					}
-					if err == nil {
-						value = parsedTime
-					} else {
-						return nil, fmt.Errorf("invalid time value for field %s: %v", fieldName, err)
-					}
-				} else {
-					continue // Skip empty values
-				}
-			case crud.UUIDFieldType:
-				if formValue != "" {
-					if uid, err := uuid.Parse(formValue); err == nil {
-						value = uid
-					} else {
-						return nil, fmt.Errorf("invalid UUID value for field %s: %v", fieldName, err)
-					}
-				} else {
-					continue // Skip empty values
-				}
-			case crud.DecimalFieldType:
-				// Decimal fields are stored as strings
-				value = formValue
-			case crud.StringFieldType, crud.TimestampFieldType:
-				// String and timestamp fields are handled as strings from forms
-				value = formValue
-			}
+		value, ok, err := c.parseFormValue(field, r.Form.Get(fieldName))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
 		}
 
 		fieldValues = append(fieldValues, field.Value(value))
@@ -401,6 +320,196 @@ func (c *CrudController[TEntity]) buildFieldValuesFromForm(r *http.Request) ([]c
 	return fieldValues, nil
 }
 
+// buildCollectionValue collects the rows of a collection field submitted as "<name>[<index>].<child>" keys.
+// Rows keep the order of their indexes, the child key is only present for stored rows.
+func (c *CrudController[TEntity]) buildCollectionValue(r *http.Request, field crud.CollectionField) (crud.FieldValue, error) {
+	prefix := field.Name() + "["
+	seen := make(map[int]bool)
+	indexes := make([]int, 0)
+	for key := range r.Form {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		end := strings.Index(key, "].")
+		if end < len(prefix) {
+			continue
+		}
+		index, err := strconv.Atoi(key[len(prefix):end])
+		if err != nil || seen[index] {
+			continue
+		}
+		seen[index] = true
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	childFields := append([]crud.Field{field.Child().Fields().KeyField()}, field.EditableFields()...)
+	rows := make([][]crud.FieldValue, 0, len(indexes))
+	for _, index := range indexes {
+		rowPrefix := fmt.Sprintf("%s[%d].", field.Name(), index)
+		row := make([]crud.FieldValue, 0, len(childFields))
+		for _, child := range childFields {
+			if !child.Key() && (child.Hidden() || child.Readonly()) {
+				continue
+			}
+			formValue := r.Form.Get(rowPrefix + child.Name())
+			if _, isSelect := child.(crud.SelectField); !isSelect && child.Type() == crud.BoolFieldType {
+				// Unchecked checkboxes are not submitted
+				row = append(row, child.Value(formValue == "on" || formValue == "true" || formValue == "1"))
+				continue
+			}
+			value, ok, err := c.parseFormValue(child, formValue)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				row = append(row, child.Value(value))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return field.Value(rows), nil
+}
+
+// parseFormValue converts a submitted form value to the type of the field.
+// It reports false for empty values that should not be set.
+func (c *CrudController[TEntity]) parseFormValue(field crud.Field, formValue string) (any, bool, error) {
+	var value any
+
+	// Check if this is a select field and handle value type accordingly
+	if selectField, ok := field.(crud.SelectField); ok {
+		// Parse value based on the select field's value type
+		fieldType := selectField.ValueType()
+		switch fieldType {
+		case crud.IntFieldType:
+			if formValue != "" {
+				if int64Val, err := strconv.ParseInt(formValue, 10, 64); err == nil {
+					if int64Val >= math.MinInt32 && int64Val <= math.MaxInt32 {
+						value = int(int64Val)
+					} else {
+						value = int64Val
+					}
+				} else {
+					return nil, false, fmt.Errorf("invalid integer value for select field %s: %v", field.Name(), err)
+				}
+			} else {
+				return nil, false, nil // Skip empty values
+			}
+		case crud.BoolFieldType:
+			value = formValue == "true" || formValue == "1"
+		case crud.FloatFieldType:
+			if formValue != "" {
+				if floatVal, err := strconv.ParseFloat(formValue, 64); err == nil {
+					value = floatVal
+				} else {
+					return nil, false, fmt.Errorf("invalid float value for select field %s: %v", field.Name(), err)
+				}
+			} else {
+				return nil, false, nil
+			}
+		case crud.UUIDFieldType:
+			if formValue == "" {
+				return nil, false, nil
+			}
+			uid, err := uuid.Parse(formValue)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid UUID value for select field %s: %v", field.Name(), err)
+			}
+			value = uid
+		case crud.StringFieldType, crud.DecimalFieldType, crud.DateFieldType,
+			crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.CollectionFieldType:
+			value = formValue
+		default:
+			// Default to string for any unknown types
+			value = formValue
+		}
+	} else {
+		// Convert form value based on field type
+		switch field.Type() {
+		case crud.BoolFieldType:
+			value = formValue == "on" || formValue == "true" || formValue == "1"
+		case crud.IntFieldType:
+			if formValue != "" {
+				if int64Val, err := strconv.ParseInt(formValue, 10, 64); err == nil {
+					if int64Val >= math.MinInt32 && int64Val <= math.MaxInt32 {
+						value = int(int64Val)
+					} else {
+						value = int64Val
+					}
+				} else {
+					return nil, false, fmt.Errorf("invalid integer value for field %s: %v", field.Name(), err)
+				}
+			} else {
+				return nil, false, nil // Skip empty values
+			}
+		case crud.FloatFieldType:
+			if formValue != "" {
+				if floatVal, err := strconv.ParseFloat(formValue, 64); err == nil {
+					value = floatVal
+				} else {
+					return nil, false, fmt.Errorf("invalid float value for field %s: %v", field.Name(), err)
+				}
+			} else {
+				return nil, false, nil // Skip empty values
+			}
+		case crud.DateFieldType, crud.DateTimeFieldType, crud.TimeFieldType:
+			if formValue != "" {
+				parsedTime, err := time.Parse(time.RFC3339, formValue)
+				if err != nil {
+					// Try common HTML5 formats based on field type
+					formats := []string{}
+					switch field.Type() {
+					case crud.DateFieldType:
+						formats = []string{"2006-01-02"}
+					case crud.TimeFieldType:
+						formats = []string{"15:04", "15:04:05"}
+					case crud.DateTimeFieldType:
+						formats = []string{"2006-01-02T15:04", "2006-01-02T15:04:05"}
+					case crud.StringFieldType, crud.IntFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.TimestampFieldType, crud.UUIDFieldType:
+						// These types are handled elsewhere
+						formats = []string{}
+					}
+
+					for _, format := range formats {
+						if parsedTime, err = time.Parse(format, formValue); err == nil {
+							break
+						}
+					}
+				}
+				if err == nil {
+					value = parsedTime
+				} else {
+					return nil, false, fmt.Errorf("invalid time value for field %s: %v", field.Name(), err)
+				}
+			} else {
+				return nil, false, nil // Skip empty values
+			}
+		case crud.UUIDFieldType:
+			if formValue != "" {
+				if uid, err := uuid.Parse(formValue); err == nil {
+					value = uid
+				} else {
+					return nil, false, fmt.Errorf("invalid UUID value for field %s: %v", field.Name(), err)
+				}
+			} else {
+				return nil, false, nil // Skip empty values
+			}
+		case crud.DecimalFieldType:
+			// Decimal fields are stored as strings
+			value = formValue
+		case crud.StringFieldType, crud.TimestampFieldType:
+			// String and timestamp fields are handled as strings from forms
+			value = formValue
+		case crud.CollectionFieldType:
+			// Collections are parsed row by row in buildCollectionValue
+			return nil, false, nil
+		}
+	}
+
+	return value, true, nil
+}
+
 func (c *CrudController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -533,14 +642,20 @@ func (c *CrudController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert entities to table rows
+	entityValues := make([][]crud.FieldValue, 0, len(entities))
 	for _, entity := range entities {
 		fieldValues, err := c.schema.Mapper().ToFieldValues(ctx, entity)
 		if err != nil {
 			log.Printf("[CrudController.List] Failed to map entity: %v", err)
 			continue
 		}
+		entityValues = append(entityValues, fieldValues)
+	}
 
-		row, err := c.buildTableRow(ctx, fieldValues)
+	// Resolve reference labels and uploads of the whole page at once
+	related := c.loadRelatedValues(ctx, entityValues)
+	for _, fieldValues := range entityValues {
+		row, err := c.buildTableRow(ctx, fieldValues, related)
 		if err != nil {
 			log.Printf("[CrudController.List] Failed to build row: %v", err)
 			continue
@@ -622,6 +737,7 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 	}
 
 	// Map field values to detail field values
+	related := c.loadRelatedValues(ctx, [][]crud.FieldValue{fieldValues})
 	detailFields := make([]table.DetailFieldValue, 0, len(c.visibleFields))
 	for _, field := range c.visibleFields {
 		if fv, exists := fieldValueMap[field.Name()]; exists {
@@ -631,6 +747,12 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 				fieldLabel = field.Name()
 			}
 
+			if detail, ok := c.relatedDetailField(ctx, field, fv, related); ok {
+				detail.Label = fieldLabel
+				detailFields = append(detailFields, detail)
+				continue
+			}
+
 			// Convert field value to string and determine type
 			var valueStr string
 			var fieldType table.DetailFieldType
@@ -695,7 +817,7 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 					case crud.DecimalFieldType:
 						valueStr = fmt.Sprintf("%v", fv.Value())
 						fieldType = table.DetailFieldTypeText
-					case crud.UUIDFieldType:
+					case crud.UUIDFieldType, crud.CollectionFieldType:
 						valueStr = fmt.Sprintf("%v", fv.Value())
 						fieldType = table.DetailFieldTypeText
 					default:
@@ -761,7 +883,7 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 }
 
 // buildTableRow creates a table row from field values
-func (c *CrudController[TEntity]) buildTableRow(ctx context.Context, fieldValues []crud.FieldValue) (table.TableRow, error) {
+func (c *CrudController[TEntity]) buildTableRow(ctx context.Context, fieldValues []crud.FieldValue, related *relatedValues) (table.TableRow, error) {
 	var primaryKey any
 	components := make([]templ.Component, 0, len(c.visibleFields)+1)
 
@@ -777,7 +899,7 @@ func (c *CrudController[TEntity]) buildTableRow(ctx context.Context, fieldValues
 	// Build components in the order of visible fields
 	for _, field := range c.visibleFields {
 		if fv, exists := fieldValueMap[field.Name()]; exists {
-			components = append(components, c.fieldValueToTableCell(ctx, field, fv))
+			components = append(components, c.fieldValueToTableCell(ctx, field, fv, related))
 		} else {
 			components = append(components, templ.Raw(""))
 		}
@@ -974,6 +1096,13 @@ func (c *CrudController[TEntity]) Create(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := c.validateUploads(ctx, fieldValues); err != nil {
+		log.Printf("[CrudController.Create] Invalid upload: %v", err)
+		errorMsg, _ := c.localize(ctx, errInvalidFormData, "Invalid form data")
+		http.Error(w, errorMsg, http.StatusBadRequest)
+		return
+	}
+
 	existingFields := make(map[string]bool, len(fieldValues))
 	for _, fv := range fieldValues {
 		existingFields[fv.Field().Name()] = true
@@ -1059,6 +1188,13 @@ func (c *CrudController[TEntity]) Update(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := c.validateUploads(ctx, fieldValues); err != nil {
+		log.Printf("[CrudController.Update] Invalid upload: %v", err)
+		errorMsg, _ := c.localize(ctx, errInvalidFormData, "Invalid form data")
+		http.Error(w, errorMsg, http.StatusBadRequest)
+		return
+	}
+
 	var keyFieldValue crud.FieldValue
 	for i, fv := range fieldValues {
 		if fv.Field().Key() {
@@ -1200,6 +1336,15 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		fieldLabel = field.Name()
 	}
 
+	if formField, ok := c.relationFormField(ctx, field, fieldLabel, value); ok {
+		return formField
+	}
+
+	return c.buildFormField(ctx, field, field.Name(), fieldLabel, value)
+}
+
+// buildFormField creates the input of a field under the given form key
+func (c *CrudController[TEntity]) buildFormField(ctx context.Context, field crud.Field, key, fieldLabel string, value crud.FieldValue) form.Field {
 	// Get the actual value to use
 	var currentValue any
 	if value != nil && !value.IsZero() {
@@ -1212,7 +1357,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 	case crud.StringFieldType:
 		// Check if this is actually a select field
 		if selectField, ok := field.(crud.SelectField); ok {
-			return c.handleSelectField(ctx, selectField, fieldLabel, currentValue)
+			return c.handleSelectField(ctx, selectField, key, fieldLabel, currentValue)
 		}
 
 		sf, err := field.AsStringField()
@@ -1220,7 +1365,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			return nil
 		}
 
-		builder := form.Text(field.Name(), fieldLabel)
+		builder := form.Text(key, fieldLabel)
 
 		if sf.MaxLen() > 0 {
 			builder = builder.MaxLen(sf.MaxLen())
@@ -1230,7 +1375,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		}
 
 		if sf.Multiline() {
-			textareaBuilder := form.Textarea(field.Name(), fieldLabel)
+			textareaBuilder := form.Textarea(key, fieldLabel)
 			if sf.MaxLen() > 0 {
 				textareaBuilder = textareaBuilder.MaxLen(sf.MaxLen())
 			}
@@ -1274,7 +1419,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 	case crud.IntFieldType:
 		// Check if this is actually a select field with int values
 		if selectField, ok := field.(crud.SelectField); ok {
-			return c.handleSelectField(ctx, selectField, fieldLabel, currentValue)
+			return c.handleSelectField(ctx, selectField, key, fieldLabel, currentValue)
 		}
 
 		intField, err := field.AsIntField()
@@ -1282,7 +1427,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			return nil
 		}
 
-		builder := form.NewNumberField(field.Name(), fieldLabel)
+		builder := form.NewNumberField(key, fieldLabel)
 
 		if intField.Min() != 0 {
 			builder = builder.Min(float64(intField.Min()))
@@ -1315,10 +1460,10 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 	case crud.BoolFieldType:
 		// Check if this is actually a select field with bool values
 		if selectField, ok := field.(crud.SelectField); ok {
-			return c.handleSelectField(ctx, selectField, fieldLabel, currentValue)
+			return c.handleSelectField(ctx, selectField, key, fieldLabel, currentValue)
 		}
 
-		builder := form.Checkbox(field.Name(), fieldLabel)
+		builder := form.Checkbox(key, fieldLabel)
 
 		if field.Readonly() {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
@@ -1342,7 +1487,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			return nil
 		}
 
-		builder := form.NewNumberField(field.Name(), fieldLabel)
+		builder := form.NewNumberField(key, fieldLabel)
 
 		if floatField.Min() != 0 {
 			builder = builder.Min(floatField.Min())
@@ -1377,7 +1522,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		return builder.Build()
 
 	case crud.DateFieldType:
-		builder := form.Date(field.Name(), fieldLabel)
+		builder := form.Date(key, fieldLabel)
 
 		dateField, err := field.AsDateField()
 		if err == nil {
@@ -1406,7 +1551,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		return builder.Build()
 
 	case crud.TimeFieldType:
-		builder := form.Time(field.Name(), fieldLabel)
+		builder := form.Time(key, fieldLabel)
 
 		if field.Readonly() {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
@@ -1425,7 +1570,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		return builder.Build()
 
 	case crud.DateTimeFieldType:
-		builder := form.DateTime(field.Name(), fieldLabel)
+		builder := form.DateTime(key, fieldLabel)
 
 		dateTimeField, err := field.AsDateTimeField()
 		if err == nil {
@@ -1454,7 +1599,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		return builder.Build()
 
 	case crud.UUIDFieldType:
-		builder := form.Text(field.Name(), fieldLabel)
+		builder := form.Text(key, fieldLabel)
 
 		if field.Readonly() {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
@@ -1477,7 +1622,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 
 	case crud.TimestampFieldType:
 		// Timestamp fields are treated like datetime fields
-		builder := form.DateTime(field.Name(), fieldLabel)
+		builder := form.DateTime(key, fieldLabel)
 
 		if field.Readonly() {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
@@ -1502,7 +1647,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			return nil
 		}
 
-		builder := form.NewNumberField(field.Name(), fieldLabel)
+		builder := form.NewNumberField(key, fieldLabel)
 
 		if decimalField.Min() != "" {
 			if minVal, err := strconv.ParseFloat(decimalField.Min(), 64); err == nil {
@@ -1556,8 +1701,12 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 
 		return builder.Build()
 
+	case crud.CollectionFieldType:
+		// Collections are built by collectionFormField
+		return nil
+
 	default:
-		builder := form.Text(field.Name(), field.Name())
+		builder := form.Text(key, fieldLabel)
 		if currentValue != nil {
 			builder = builder.Default(fmt.Sprintf("%v", currentValue))
 		}
@@ -1566,7 +1715,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 }
 
 // handleSelectField processes select fields and returns appropriate form fields
-func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectField crud.SelectField, fieldLabel string, currentValue any) form.Field {
+func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectField crud.SelectField, key, fieldLabel string, currentValue any) form.Field {
 	// Convert current value to string for comparison
 	var valueStr string
 	if currentValue != nil {
@@ -1607,7 +1756,7 @@ func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectF
 			}
 		}
 
-		builder := form.Select(selectField.Name(), fieldLabel).
+		builder := form.Select(key, fieldLabel).
 			Options(formOptions)
 
 		if selectField.Placeholder() != "" {
@@ -1631,7 +1780,7 @@ func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectF
 
 	case crud.SelectTypeSearchable:
 		builder := form.SearchSelect().
-			Key(selectField.Name()).
+			Key(key).
 			Label(fieldLabel).
 			Endpoint(selectField.Endpoint()).
 			Placeholder(selectField.Placeholder())
@@ -1652,7 +1801,7 @@ func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectF
 
 	case crud.SelectTypeCombobox:
 		builder := form.Combobox().
-			Key(selectField.Name()).
+			Key(key).
 			Label(fieldLabel).
 			Endpoint(selectField.Endpoint()).
 			Placeholder(selectField.Placeholder()).
@@ -1674,7 +1823,7 @@ func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectF
 
 	default:
 		// Fallback to regular select
-		return form.Select(selectField.Name(), fieldLabel).Build()
+		return form.Select(key, fieldLabel).Build()
 	}
 }
 
@@ -1705,7 +1854,7 @@ func (c *CrudController[TEntity]) convertValueToString(value any, fieldType crud
 		case float32:
 			return strconv.FormatFloat(float64(v), 'f', -1, 32)
 		}
-	case crud.StringFieldType, crud.DecimalFieldType, crud.UUIDFieldType:
+	case crud.StringFieldType, crud.DecimalFieldType, crud.UUIDFieldType, crud.CollectionFieldType:
 		return fmt.Sprintf("%v", value)
 	case crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType:
 		// For date/time types, format as string
@@ -1841,11 +1990,15 @@ func (c *CrudController[TEntity]) compareSelectValues(optionValue, fieldValue an
 	}
 }
 
-func (c *CrudController[TEntity]) fieldValueToTableCell(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+func (c *CrudController[TEntity]) fieldValueToTableCell(ctx context.Context, field crud.Field, value crud.FieldValue, related *relatedValues) templ.Component {
 	if value.IsZero() {
 		return templ.Raw("")
 	}
 
+	if cell, ok := c.relatedTableCell(ctx, field, value, related); ok {
+		return cell
+	}
+
 	// Check if this is a select field and handle label display
 	if selectField, ok := field.(crud.SelectField); ok {
 		return c.getSelectFieldLabel(ctx, selectField, value)
@@ -1961,6 +2114,10 @@ func (c *CrudController[TEntity]) fieldValueToTableCell(ctx context.Context, fie
 		}
 		return templ.Raw(uuidVal.String())
 
+	case crud.CollectionFieldType:
+		// Collections are rendered by relatedTableCell
+		return templ.Raw("")
+
 	default:
 		return templ.Raw(fmt.Sprintf("%v", value.Value()))
 	}
@@ -0,0 +1,397 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/components/base"
+	"github.com/iota-uz/iota-sdk/components/scaffold/form"
+	"github.com/iota-uz/iota-sdk/components/scaffold/table"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+)
+
+// referenceSearchLimit is the number of options returned by SearchReference
+const referenceSearchLimit = 10
+
+// relatedValues holds what is displayed instead of the stored keys of reference and upload fields
+type relatedValues struct {
+	// labels of referenced records by field name and formatted key
+	labels  map[string]map[string]string
+	uploads map[uint]upload.Upload
+}
+
+func (rv *relatedValues) label(field crud.ReferenceField, value any) (string, bool) {
+	if rv == nil {
+		return "", false
+	}
+	label, ok := rv.labels[field.Name()][fmt.Sprint(value)]
+	return label, ok
+}
+
+func (rv *relatedValues) upload(value any) (upload.Upload, bool) {
+	if rv == nil {
+		return nil, false
+	}
+	id, ok := uploadID(value)
+	if !ok {
+		return nil, false
+	}
+	u, ok := rv.uploads[id]
+	return u, ok
+}
+
+// SearchReference returns the options of a reference field whose label matches the "q" query parameter
+func (c *CrudController[TEntity]) SearchReference(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	field, ok := c.references[mux.Vars(r)["field"]]
+	if !ok {
+		errorMsg, _ := c.localize(ctx, errEntityNotFound, "Entity not found")
+		http.Error(w, errorMsg, http.StatusNotFound)
+		return
+	}
+
+	options, err := field.Search(ctx, r.URL.Query().Get("q"), referenceSearchLimit)
+	if err != nil {
+		log.Printf("[CrudController.SearchReference] Failed to search %s: %v", field.Name(), err)
+		errorMsg, _ := c.localize(ctx, errFailedToRetrieve, "Failed to retrieve data")
+		http.Error(w, errorMsg, http.StatusInternalServerError)
+		return
+	}
+
+	props := make([]*base.ComboboxOption, 0, len(options))
+	for _, opt := range options {
+		props = append(props, &base.ComboboxOption{
+			Value: fmt.Sprint(opt.Value),
+			Label: opt.Label,
+		})
+	}
+	templ.Handler(base.ComboboxOptions(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// loadRelatedValues resolves the reference labels and uploads of the given rows with one query per field
+func (c *CrudController[TEntity]) loadRelatedValues(ctx context.Context, rows [][]crud.FieldValue) *relatedValues {
+	related := &relatedValues{
+		labels:  make(map[string]map[string]string, len(c.references)),
+		uploads: make(map[uint]upload.Upload),
+	}
+
+	keys := make(map[string][]any)
+	uploadIDs := make([]uint, 0)
+	for _, row := range rows {
+		for _, fv := range row {
+			if fv.IsZero() {
+				continue
+			}
+			switch fv.Field().(type) {
+			case crud.ReferenceField:
+				keys[fv.Field().Name()] = append(keys[fv.Field().Name()], fv.Value())
+			case crud.UploadField:
+				if id, ok := uploadID(fv.Value()); ok {
+					uploadIDs = append(uploadIDs, id)
+				}
+			}
+		}
+	}
+
+	for name, fieldKeys := range keys {
+		labels, err := c.references[name].Labels(ctx, fieldKeys...)
+		if err != nil {
+			log.Printf("[CrudController] Failed to load labels of %s: %v", name, err)
+			continue
+		}
+		related.labels[name] = labels
+	}
+
+	if len(uploadIDs) > 0 {
+		uploadService := c.uploadService()
+		for _, id := range uploadIDs {
+			if _, ok := related.uploads[id]; ok {
+				continue
+			}
+			u, err := uploadService.GetByID(ctx, id)
+			if err != nil {
+				log.Printf("[CrudController] Failed to load upload %d: %v", id, err)
+				continue
+			}
+			related.uploads[id] = u
+		}
+	}
+
+	return related
+}
+
+// relatedTableCell renders reference, upload and collection values in the list table
+func (c *CrudController[TEntity]) relatedTableCell(_ context.Context, field crud.Field, value crud.FieldValue, related *relatedValues) (templ.Component, bool) {
+	switch f := field.(type) {
+	case crud.ReferenceField:
+		if label, ok := related.label(f, value.Value()); ok {
+			return templ.Raw(html.EscapeString(label)), true
+		}
+		return templ.Raw(html.EscapeString(fmt.Sprint(value.Value()))), true
+	case crud.UploadField:
+		u, ok := related.upload(value.Value())
+		if !ok {
+			return templ.Raw(""), true
+		}
+		return templ.Raw(fmt.Sprintf(
+			`<a href="%s" target="_blank" class="text-brand-500 hover:underline">%s</a>`,
+			html.EscapeString(u.URL().String()),
+			html.EscapeString(u.Name()),
+		)), true
+	case crud.CollectionField:
+		rows, _ := value.Value().([][]crud.FieldValue)
+		return templ.Raw(strconv.Itoa(len(rows))), true
+	}
+	return nil, false
+}
+
+// relatedDetailField converts reference, upload and collection values for the details drawer
+func (c *CrudController[TEntity]) relatedDetailField(_ context.Context, field crud.Field, value crud.FieldValue, related *relatedValues) (table.DetailFieldValue, bool) {
+	detail := table.DetailFieldValue{
+		Name: field.Name(),
+		Type: table.DetailFieldTypeText,
+	}
+
+	switch f := field.(type) {
+	case crud.ReferenceField:
+		if !value.IsZero() {
+			detail.Value = fmt.Sprint(value.Value())
+			if label, ok := related.label(f, value.Value()); ok {
+				detail.Value = label
+			}
+		}
+		return detail, true
+	case crud.UploadField:
+		if u, ok := related.upload(value.Value()); ok {
+			detail.Value = u.Name()
+			detail.URL = u.URL().String()
+			detail.Type = table.DetailFieldTypeLink
+		}
+		return detail, true
+	case crud.CollectionField:
+		rows, _ := value.Value().([][]crud.FieldValue)
+		detail.Type = table.DetailFieldTypeList
+		detail.Value = strconv.Itoa(len(rows))
+		detail.Items = make([]string, 0, len(rows))
+		for _, row := range rows {
+			detail.Items = append(detail.Items, c.summarizeRow(f, row))
+		}
+		return detail, true
+	}
+	return detail, false
+}
+
+// summarizeRow joins the non-empty editable values of a child row
+func (c *CrudController[TEntity]) summarizeRow(field crud.CollectionField, row []crud.FieldValue) string {
+	parts := make([]string, 0, len(row))
+	for _, child := range field.EditableFields() {
+		for _, fv := range row {
+			if fv.Field().Name() != child.Name() || fv.IsZero() {
+				continue
+			}
+			parts = append(parts, c.convertValueToString(fv.Value(), child.Type()))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// relationFormField creates the inputs of reference, upload and collection fields
+func (c *CrudController[TEntity]) relationFormField(ctx context.Context, field crud.Field, fieldLabel string, value crud.FieldValue) (form.Field, bool) {
+	switch f := field.(type) {
+	case crud.ReferenceField:
+		return c.referenceFormField(ctx, f, f.Name(), f.Name(), fieldLabel, value), true
+	case crud.UploadField:
+		return c.uploadFormField(ctx, f, fieldLabel, value), true
+	case crud.CollectionField:
+		return c.collectionFormField(ctx, f, fieldLabel, value), true
+	}
+	return nil, false
+}
+
+// referenceFormField creates a searchable select backed by SearchReference.
+// searchKey is the key the field is registered under in c.references.
+func (c *CrudController[TEntity]) referenceFormField(
+	ctx context.Context,
+	field crud.ReferenceField,
+	searchKey, key, fieldLabel string,
+	value crud.FieldValue,
+) form.Field {
+	builder := form.SearchSelect().
+		Key(key).
+		Label(fieldLabel).
+		Endpoint(fmt.Sprintf("%s/references/%s", c.basePath, searchKey)).
+		Placeholder(field.Placeholder())
+
+	if field.Readonly() {
+		builder = builder.Attrs(templ.Attributes{"disabled": true})
+	}
+
+	if len(field.Rules()) > 0 {
+		builder = builder.WithRequired(true)
+	}
+
+	if value != nil && !value.IsZero() {
+		builder = builder.WithValue(c.convertValueToString(value.Value(), field.ValueType()))
+		labels, err := field.Labels(ctx, value.Value())
+		if err != nil {
+			log.Printf("[CrudController] Failed to load label of %s: %v", field.Name(), err)
+		} else if label, ok := labels[fmt.Sprint(value.Value())]; ok {
+			builder = builder.ValueLabel(label)
+		}
+	}
+
+	return builder.Build()
+}
+
+// uploadFormField creates a file input showing the current upload
+func (c *CrudController[TEntity]) uploadFormField(ctx context.Context, field crud.UploadField, fieldLabel string, value crud.FieldValue) form.Field {
+	builder := form.Upload(field.Name(), fieldLabel).
+		Accept(field.Accept())
+
+	if len(field.Rules()) > 0 {
+		builder = builder.Required()
+	}
+
+	if value != nil && !value.IsZero() {
+		if id, ok := uploadID(value.Value()); ok {
+			u, err := c.uploadService().GetByID(ctx, id)
+			if err != nil {
+				log.Printf("[CrudController] Failed to load upload %d: %v", id, err)
+			} else {
+				builder = builder.Default(mappers.UploadToViewModel(u))
+			}
+		}
+	}
+
+	return builder.Build()
+}
+
+// collectionFormField creates an inline table editing the rows of a one-to-many field
+func (c *CrudController[TEntity]) collectionFormField(ctx context.Context, field crud.CollectionField, fieldLabel string, value crud.FieldValue) form.Field {
+	child := field.Child()
+	columns := make([]string, 0)
+	editable := make([]crud.Field, 0)
+	for _, f := range field.EditableFields() {
+		if f.Hidden() {
+			continue
+		}
+		label, err := c.localize(ctx, fmt.Sprintf("%s.Fields.%s", child.Name(), f.Name()), f.Name())
+		if err != nil {
+			label = f.Name()
+		}
+		columns = append(columns, label)
+		editable = append(editable, f)
+	}
+
+	addLabel, _ := c.localize(ctx, "Add", "Add")
+	removeLabel, _ := c.localize(ctx, "Remove", "Remove")
+	builder := form.Collection(field.Name(), fieldLabel).
+		Columns(columns...).
+		AddLabel(addLabel).
+		RemoveLabel(removeLabel)
+
+	var rows [][]crud.FieldValue
+	if value != nil {
+		rows, _ = value.Value().([][]crud.FieldValue)
+	}
+	for i, row := range rows {
+		builder = builder.Row(c.collectionRowFields(ctx, field, editable, strconv.Itoa(i), row)...)
+	}
+	builder = builder.Template(c.collectionRowFields(ctx, field, editable, form.CollectionIndexPlaceholder, nil)...)
+
+	return builder.Build()
+}
+
+// collectionRowFields creates the inputs of one child row keyed "<collection>[<index>].<field>"
+func (c *CrudController[TEntity]) collectionRowFields(
+	ctx context.Context,
+	field crud.CollectionField,
+	editable []crud.Field,
+	index string,
+	row []crud.FieldValue,
+) []form.Field {
+	rowKey := func(name string) string {
+		return fmt.Sprintf("%s[%s].%s", field.Name(), index, name)
+	}
+	values := make(map[string]crud.FieldValue, len(row))
+	for _, fv := range row {
+		values[fv.Field().Name()] = fv
+	}
+
+	fields := make([]form.Field, 0, len(editable)+1)
+	keyField := field.Child().Fields().KeyField()
+	if key, ok := values[keyField.Name()]; ok && !key.IsZero() {
+		fields = append(fields, form.Hidden(rowKey(keyField.Name())).
+			Default(c.convertValueToString(key.Value(), keyField.Type())).
+			Build())
+	}
+
+	for _, f := range editable {
+		var formField form.Field
+		if ref, ok := f.(crud.ReferenceField); ok {
+			formField = c.referenceFormField(ctx, ref, childReferenceKey(field, ref), rowKey(f.Name()), "", values[f.Name()])
+		} else {
+			formField = c.buildFormField(ctx, f, rowKey(f.Name()), "", values[f.Name()])
+		}
+		if formField != nil {
+			fields = append(fields, formField)
+		}
+	}
+	return fields
+}
+
+// validateUploads checks that the submitted upload IDs exist
+func (c *CrudController[TEntity]) validateUploads(ctx context.Context, fieldValues []crud.FieldValue) error {
+	if len(c.uploadFields) == 0 {
+		return nil
+	}
+	for _, fv := range fieldValues {
+		if _, ok := fv.Field().(crud.UploadField); !ok || fv.IsZero() {
+			continue
+		}
+		id, ok := uploadID(fv.Value())
+		if !ok {
+			return fmt.Errorf("invalid upload ID for field %s: %v", fv.Field().Name(), fv.Value())
+		}
+		exists, err := c.uploadService().Exists(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check upload %d: %w", id, err)
+		}
+		if !exists {
+			return fmt.Errorf("upload %d of field %s not found", id, fv.Field().Name())
+		}
+	}
+	return nil
+}
+
+func (c *CrudController[TEntity]) uploadService() *services.UploadService {
+	return c.app.Service(services.UploadService{}).(*services.UploadService)
+}
+
+func childReferenceKey(collection crud.CollectionField, ref crud.ReferenceField) string {
+	return collection.Name() + "." + ref.Name()
+}
+
+func uploadID(value any) (uint, bool) {
+	switch v := value.(type) {
+	case int:
+		return uint(v), v > 0
+	case int32:
+		return uint(v), v > 0
+	case int64:
+		return uint(v), v > 0
+	}
+	return 0, false
+}
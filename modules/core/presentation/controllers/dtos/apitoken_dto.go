@@ -0,0 +1,100 @@
+package dtos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/go-i18n/v2/i18n"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/validators"
+)
+
+type CreateAPITokenDTO struct {
+	Name        string   `validate:"required,max=255"`
+	OwnerID     uint     `label:"Owner"` // the current user when empty
+	ExpiresIn   string   `validate:"required,oneof=30 90 365 never"`
+	Permissions []string `validate:"required"`
+}
+
+type CreateServiceAccountDTO struct {
+	Name   string `validate:"required,max=255"`
+	Email  string `validate:"required,email"`
+	RoleID uint   `validate:"required" label:"Role"`
+}
+
+func (dto *CreateAPITokenDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("APITokens.Single.%s", validators.FieldLabel(dto, err)),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+
+	return errorMessages, len(errorMessages) == 0
+}
+
+// ExpiresAt returns the expiry of the token counted from now, nil for tokens that never expire
+func (dto *CreateAPITokenDTO) ExpiresAt() *time.Time {
+	var days int
+	if _, err := fmt.Sscan(dto.ExpiresIn, &days); err != nil {
+		return nil
+	}
+	expiresAt := time.Now().AddDate(0, 0, days)
+	return &expiresAt
+}
+
+func (dto *CreateServiceAccountDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("APITokens.ServiceAccounts.%s", validators.FieldLabel(dto, err)),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (dto *CreateServiceAccountDTO) ToParams() (*services.CreateServiceAccountParams, error) {
+	email, err := internet.NewEmail(dto.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &services.CreateServiceAccountParams{
+		Name:    dto.Name,
+		Email:   email,
+		RoleIDs: []uint{dto.RoleID},
+	}, nil
+}
@@ -0,0 +1,141 @@
+package dtos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/iota-uz/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policy"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+	"github.com/iota-uz/iota-sdk/pkg/validators"
+)
+
+type CreatePolicyDTO struct {
+	Name         string `validate:"required"`
+	Description  string
+	PermissionID string `validate:"required,uuid"`
+	Effect       string `validate:"required"`
+	Condition    string `validate:"required"`
+}
+
+type SimulatePolicyDTO struct {
+	UserID         uint   `validate:"required"`
+	PermissionID   string `validate:"required,uuid"`
+	Resource       string
+	Request        string
+	DraftEffect    string
+	DraftCondition string
+}
+
+func (dto *CreatePolicyDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	errorMessages := policyValidationErrors(ctx, dto)
+	if _, err := rbac.ParseExpr(dto.Condition); dto.Condition != "" && err != nil {
+		errorMessages["Condition"] = err.Error()
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (dto *CreatePolicyDTO) ToEntity(r rbac.RBAC) (policy.Policy, error) {
+	perm, err := policyPermission(r, dto.PermissionID)
+	if err != nil {
+		return nil, err
+	}
+	return policy.New(
+		dto.Name,
+		perm.Resource,
+		perm.Action,
+		rbac.Effect(dto.Effect),
+		dto.Condition,
+		policy.WithDescription(dto.Description),
+	), nil
+}
+
+func (dto *SimulatePolicyDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	errorMessages := policyValidationErrors(ctx, dto)
+	if _, err := parseAttributes(dto.Resource); err != nil {
+		errorMessages["Resource"] = err.Error()
+	}
+	if _, err := parseAttributes(dto.Request); err != nil {
+		errorMessages["Request"] = err.Error()
+	}
+	if _, err := rbac.ParseExpr(dto.DraftCondition); dto.DraftCondition != "" && err != nil {
+		errorMessages["DraftCondition"] = err.Error()
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (dto *SimulatePolicyDTO) Permission(r rbac.RBAC) (*permission.Permission, error) {
+	return policyPermission(r, dto.PermissionID)
+}
+
+func (dto *SimulatePolicyDTO) Attributes() (resource map[string]any, request map[string]any, err error) {
+	if resource, err = parseAttributes(dto.Resource); err != nil {
+		return nil, nil, err
+	}
+	if request, err = parseAttributes(dto.Request); err != nil {
+		return nil, nil, err
+	}
+	return resource, request, nil
+}
+
+// Draft returns the policy being tried out, or nil when no draft condition is given
+func (dto *SimulatePolicyDTO) Draft(perm *permission.Permission) policy.Policy {
+	if dto.DraftCondition == "" {
+		return nil
+	}
+	effect := rbac.EffectAllow
+	if dto.DraftEffect != "" {
+		effect = rbac.Effect(dto.DraftEffect)
+	}
+	return policy.New("draft", perm.Resource, perm.Action, effect, dto.DraftCondition)
+}
+
+func policyPermission(r rbac.RBAC, id string) (*permission.Permission, error) {
+	permID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.Get(permID)
+}
+
+func parseAttributes(src string) (map[string]any, error) {
+	attrs := map[string]any{}
+	if src == "" {
+		return attrs, nil
+	}
+	if err := json.Unmarshal([]byte(src), &attrs); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+func policyValidationErrors(ctx context.Context, dto any) map[string]string {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("Policies.Single.%s.Label", validators.FieldLabel(dto, err)),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+	return errorMessages
+}
@@ -0,0 +1,131 @@
+package dtos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/go-i18n/v2/i18n"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/validators"
+)
+
+type CreateTenantDTO struct {
+	Name       string `validate:"required,max=255"`
+	Subdomain  string `validate:"omitempty,hostname_rfc1123,max=63"`
+	UILanguage string `validate:"required,oneof=en ru uz"`
+	AdminEmail string `validate:"required,email"`
+	FirstName  string `validate:"required"`
+	LastName   string `validate:"required"`
+}
+
+type ImportTenantDTO struct {
+	Name      string `validate:"max=255"`
+	Subdomain string `validate:"omitempty,hostname_rfc1123,max=63"`
+}
+
+type AcceptInviteDTO struct {
+	Password        string `validate:"required,min=8"`
+	PasswordConfirm string `validate:"required,eqfield=Password"`
+}
+
+func (dto *CreateTenantDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("Tenants.Single.%s", validators.FieldLabel(dto, err)),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (dto *CreateTenantDTO) ToParams() (*services.ProvisionTenantParams, error) {
+	email, err := internet.NewEmail(dto.AdminEmail)
+	if err != nil {
+		return nil, err
+	}
+	return &services.ProvisionTenantParams{
+		Name:       dto.Name,
+		Subdomain:  dto.Subdomain,
+		UILanguage: user.UILanguage(dto.UILanguage),
+		AdminEmail: email,
+		FirstName:  dto.FirstName,
+		LastName:   dto.LastName,
+	}, nil
+}
+
+func (dto *ImportTenantDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("Tenants.Single.%s", validators.FieldLabel(dto, err)),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (dto *ImportTenantDTO) ToParams() *services.ImportTenantParams {
+	return &services.ImportTenantParams{
+		Name:      dto.Name,
+		Subdomain: dto.Subdomain,
+	}
+}
+
+func (dto *AcceptInviteDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("Invite.%s", validators.FieldLabel(dto, err)),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+
+	return errorMessages, len(errorMessages) == 0
+}
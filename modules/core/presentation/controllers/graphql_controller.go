@@ -8,12 +8,15 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
 
 	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/mux"
 	"github.com/iota-uz/iota-sdk/modules/core/interfaces/graph"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/graphql"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/ratelimit"
 )
 
 type GraphQLController struct {
@@ -25,18 +28,36 @@ func (g *GraphQLController) Key() string {
 }
 
 func (g *GraphQLController) Register(r *mux.Router) {
-	schema := graph.NewExecutableSchema(
-		graph.Config{
-			Resolvers: graph.NewResolver(g.app),
-		},
-	)
-	srv := graphql.NewBaseServer(schema)
-	for _, schema := range g.app.GraphSchemas() {
+	conf := configuration.Use().GraphQL
+	queryStore, err := graphql.NewQueryStore(conf, g.app.DB())
+	if err != nil {
+		panic(err)
+	}
+	newExecutor := func(schema application.GraphSchema) *executor.Executor {
 		exec := executor.New(schema.Value)
+		exec.Use(&graphql.CostLimit{
+			MaxComplexity: mapping.Or(schema.MaxComplexity, conf.MaxComplexity),
+			MaxDepth:      mapping.Or(schema.MaxDepth, conf.MaxDepth),
+		})
+		exec.Use(graphql.RateLimit{Limits: ratelimit.Use()})
+		if queryStore != nil {
+			exec.Use(extension.AutomaticPersistedQuery{Cache: queryStore})
+		}
 		if schema.ExecutorCb != nil {
 			schema.ExecutorCb(exec)
 		}
-		srv.AddExecutor(exec)
+		return exec
+	}
+
+	srv := graphql.NewHandler(newExecutor(application.GraphSchema{
+		Value: graph.NewExecutableSchema(
+			graph.Config{
+				Resolvers: graph.NewResolver(g.app),
+			},
+		),
+	}))
+	for _, schema := range g.app.GraphSchemas() {
+		srv.AddExecutor(newExecutor(schema))
 	}
 	router := r.Methods(http.MethodGet, http.MethodPost).Subrouter()
 	router.Use(
@@ -48,11 +69,7 @@ func (g *GraphQLController) Register(r *mux.Router) {
 	router.Handle("/query", srv)
 	router.Handle("/playground", playground.Handler("GraphQL playground", "/query"))
 	for _, schema := range g.app.GraphSchemas() {
-		exec := executor.New(schema.Value)
-		if schema.ExecutorCb != nil {
-			schema.ExecutorCb(exec)
-		}
-		router.Handle(path.Join("/query", schema.BasePath), graphql.NewHandler(exec))
+		router.Handle(path.Join("/query", schema.BasePath), graphql.NewHandler(newExecutor(schema)))
 	}
 	log.Printf("See %s/playground for GraphQL playground", configuration.Use().Origin)
 }
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/invite"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/pages/login"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// InviteController lets invited users set their password, the link is opened before login
+type InviteController struct {
+	app           application.Application
+	inviteService *services.InviteService
+	basePath      string
+}
+
+func NewInviteController(app application.Application) application.Controller {
+	return &InviteController{
+		app:           app,
+		inviteService: app.Service(services.InviteService{}).(*services.InviteService),
+		basePath:      "/invite",
+	}
+}
+
+func (c *InviteController) Key() string {
+	return c.basePath
+}
+
+func (c *InviteController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("/{token}", c.Get).Methods(http.MethodGet)
+	router.HandleFunc("/{token}", c.Post).Methods(http.MethodPost)
+}
+
+func (c *InviteController) Get(w http.ResponseWriter, r *http.Request) {
+	errorsMap, err := composables.UseFlashMap[string, string](w, r, "errorsMap")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &login.InviteProps{
+		ErrorsMap: errorsMap,
+		Valid:     true,
+	}
+	entity, err := c.inviteService.GetByToken(r.Context(), mux.Vars(r)["token"])
+	if err == nil {
+		u, userErr := c.inviteService.GetUser(r.Context(), entity)
+		if userErr != nil {
+			http.Error(w, userErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Email = u.Email().Value()
+	} else {
+		props.Valid = false
+		props.ErrorMessage = c.errorMessage(r, err)
+	}
+	if err := login.Invite(props).Render(r.Context(), w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (c *InviteController) Post(w http.ResponseWriter, r *http.Request) {
+	logger := composables.UseLogger(r.Context())
+	token := mux.Vars(r)["token"]
+	dto, err := composables.UseForm(&dtos.AcceptInviteDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		shared.SetFlashMap(w, "errorsMap", errorsMap)
+		http.Redirect(w, r, fmt.Sprintf("%s/%s", c.basePath, token), http.StatusFound)
+		return
+	}
+
+	u, err := c.inviteService.Accept(r.Context(), token, dto.Password)
+	if err != nil {
+		logger.WithError(err).Error("failed to accept invite")
+		if err := login.Invite(&login.InviteProps{
+			ErrorMessage: c.errorMessage(r, err),
+		}).Render(r.Context(), w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	http.Redirect(w, r, "/login?email="+url.QueryEscape(u.Email().Value()), http.StatusFound)
+}
+
+func (c *InviteController) errorMessage(r *http.Request, err error) string {
+	switch {
+	case errors.Is(err, persistence.ErrInviteNotFound):
+		return intl.MustT(r.Context(), "Invite.Errors.NotFound")
+	case errors.Is(err, invite.ErrExpired):
+		return intl.MustT(r.Context(), "Invite.Errors.Expired")
+	case errors.Is(err, invite.ErrAccepted):
+		return intl.MustT(r.Context(), "Invite.Errors.Accepted")
+	default:
+		return intl.MustT(r.Context(), "Errors.Internal")
+	}
+}
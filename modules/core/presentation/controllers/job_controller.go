@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/components/base"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/pages/jobs"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// JobRealtimeUpdates pushes job status and progress changes to the websocket connections of the job owner
+type JobRealtimeUpdates struct {
+	app application.Application
+}
+
+func NewJobRealtimeUpdates(app application.Application) *JobRealtimeUpdates {
+	return &JobRealtimeUpdates{
+		app: app,
+	}
+}
+
+func (ru *JobRealtimeUpdates) Register() {
+	ru.app.EventPublisher().Subscribe(ru.onJobCreated)
+	ru.app.EventPublisher().Subscribe(ru.onJobUpdated)
+}
+
+func (ru *JobRealtimeUpdates) onJobCreated(event *job.CreatedEvent) {
+	ru.send(event.Result, jobs.JobCreatedEvent(mappers.JobToViewModel(event.Result)))
+}
+
+func (ru *JobRealtimeUpdates) onJobUpdated(event *job.UpdatedEvent) {
+	ru.send(event.Result, jobs.JobRow(mappers.JobToViewModel(event.Result), &base.TableRowProps{
+		Attrs: templ.Attributes{
+			"hx-swap-oob": "true",
+		},
+	}))
+}
+
+func (ru *JobRealtimeUpdates) send(entity job.Job, component templ.Component) {
+	if entity.UserID() == 0 {
+		return
+	}
+	logger := configuration.Use().Logger()
+	channel := fmt.Sprintf("user/%d", entity.UserID())
+
+	if err := ru.app.Websocket().ForEach(channel, func(connCtx context.Context, conn application.Connection) error {
+		var buf bytes.Buffer
+		if err := component.Render(connCtx, &buf); err != nil {
+			logger.WithError(err).Error("failed to render job event for websocket")
+			return nil // Continue processing other connections
+		}
+		if err := conn.SendMessage(buf.Bytes()); err != nil {
+			logger.WithError(err).Error("failed to send job event to websocket connection")
+			return nil // Continue processing other connections
+		}
+		return nil
+	}); err != nil {
+		logger.WithError(err).Error("failed to broadcast job event to websocket")
+	}
+}
+
+type JobsController struct {
+	app      application.Application
+	basePath string
+	realtime *JobRealtimeUpdates
+}
+
+func NewJobsController(app application.Application) application.Controller {
+	return &JobsController{
+		app:      app,
+		basePath: "/jobs",
+		realtime: NewJobRealtimeUpdates(app),
+	}
+}
+
+func (c *JobsController) Key() string {
+	return c.basePath
+}
+
+func (c *JobsController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", di.H(c.List)).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}/download", di.H(c.Download)).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}/errors", di.H(c.ErrorReport)).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}/cancel", di.H(c.Cancel)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/retry", di.H(c.Retry)).Methods(http.MethodPost)
+
+	c.realtime.Register()
+}
+
+func (c *JobsController) List(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	jobService *services.JobService,
+) {
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	params := composables.UsePaginated(r)
+	findParams := &job.FindParams{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		UserID: u.ID(),
+		SortBy: job.SortBy{
+			Fields: []job.SortByField{
+				{Field: job.CreatedAtField, Ascending: false},
+			},
+		},
+	}
+
+	entities, err := jobService.GetPaginated(r.Context(), findParams)
+	if err != nil {
+		logger.Errorf("Error retrieving jobs: %v", err)
+		http.Error(w, "Error retrieving jobs", http.StatusInternalServerError)
+		return
+	}
+	total, err := jobService.Count(r.Context(), findParams)
+	if err != nil {
+		logger.Errorf("Error counting jobs: %v", err)
+		http.Error(w, "Error counting jobs", http.StatusInternalServerError)
+		return
+	}
+
+	props := &jobs.IndexPageProps{
+		Jobs:    mapping.MapViewModels(entities, mappers.JobToViewModel),
+		Page:    params.Page,
+		PerPage: params.Limit,
+		HasMore: total > int64(params.Page*params.Limit),
+	}
+	if htmx.IsHxRequest(r) && params.Page > 1 {
+		templ.Handler(jobs.JobRows(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(jobs.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *JobsController) Download(
+	r *http.Request,
+	w http.ResponseWriter,
+	jobService *services.JobService,
+	uploadService *services.UploadService,
+) {
+	entity, ok := c.ownedJob(w, r, jobService)
+	if !ok {
+		return
+	}
+	c.redirectToUpload(w, r, uploadService, entity.ResultUploadID())
+}
+
+func (c *JobsController) ErrorReport(
+	r *http.Request,
+	w http.ResponseWriter,
+	jobService *services.JobService,
+	uploadService *services.UploadService,
+) {
+	entity, ok := c.ownedJob(w, r, jobService)
+	if !ok {
+		return
+	}
+	c.redirectToUpload(w, r, uploadService, entity.ErrorUploadID())
+}
+
+func (c *JobsController) Cancel(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	jobService *services.JobService,
+) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := jobService.Cancel(r.Context(), id)
+	c.renderRow(w, r, logger, entity, err)
+}
+
+func (c *JobsController) Retry(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	jobService *services.JobService,
+) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := jobService.Retry(r.Context(), id)
+	c.renderRow(w, r, logger, entity, err)
+}
+
+func (c *JobsController) renderRow(w http.ResponseWriter, r *http.Request, logger *logrus.Entry, entity job.Job, err error) {
+	switch {
+	case errors.Is(err, persistence.ErrJobNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrJobNotCancelable), errors.Is(err, services.ErrJobNotRetryable):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		logger.Errorf("Error updating job: %v", err)
+		http.Error(w, "Error updating job", http.StatusInternalServerError)
+		return
+	}
+	row := jobs.JobRow(mappers.JobToViewModel(entity), &base.TableRowProps{Attrs: templ.Attributes{}})
+	templ.Handler(row, templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// ownedJob loads the job from the request path and makes sure it belongs to the current user
+func (c *JobsController) ownedJob(w http.ResponseWriter, r *http.Request, jobService *services.JobService) (job.Job, bool) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+	entity, err := jobService.GetByID(r.Context(), id)
+	if err != nil || entity.UserID() != u.ID() {
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return nil, false
+	}
+	return entity, true
+}
+
+func (c *JobsController) redirectToUpload(w http.ResponseWriter, r *http.Request, uploadService *services.UploadService, uploadID uint) {
+	if uploadID == 0 {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	upload, err := uploadService.GetByID(r.Context(), uploadID)
+	if err != nil {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	http.Redirect(w, r, upload.URL().String(), http.StatusSeeOther)
+}
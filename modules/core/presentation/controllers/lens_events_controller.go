@@ -37,6 +37,7 @@ func (c *LensEventsController) Register(r *mux.Router) {
 	router.Use(
 		middleware.Authorize(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
 	)
 
@@ -74,6 +74,7 @@ func (c *LoginController) Key() string {
 func (c *LoginController) Register(r *mux.Router) {
 	getRouter := r.PathPrefix("/").Subrouter()
 	getRouter.Use(
+		middleware.ProvideDynamicLogo(c.app),
 		middleware.ProvideLocalizer(c.app.Bundle()),
 		middleware.WithPageContext(),
 	)
@@ -120,6 +121,8 @@ func (c *LoginController) GoogleCallback(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		if errors.Is(err, persistence.ErrUserNotFound) {
 			queryParams.Set("error", intl.MustT(r.Context(), "Login.Errors.UserNotFound"))
+		} else if errors.Is(err, services.ErrTenantSuspended) {
+			queryParams.Set("error", intl.MustT(r.Context(), "Login.Errors.TenantSuspended"))
 		} else {
 			queryParams.Set("error", intl.MustT(r.Context(), "Errors.Internal"))
 		}
@@ -177,6 +180,8 @@ func (c *LoginController) Post(w http.ResponseWriter, r *http.Request) {
 			shared.SetFlash(w, "error", []byte(intl.MustT(r.Context(), "Login.Errors.PasswordInvalid")))
 		} else if errors.Is(err, persistence.ErrUserNotFound) {
 			shared.SetFlash(w, "error", []byte(intl.MustT(r.Context(), "Login.Errors.PasswordInvalid")))
+		} else if errors.Is(err, services.ErrTenantSuspended) {
+			shared.SetFlash(w, "error", []byte(intl.MustT(r.Context(), "Login.Errors.TenantSuspended")))
 		} else {
 			shared.SetFlash(w, "error", []byte(intl.MustT(r.Context(), "Errors.Internal")))
 		}
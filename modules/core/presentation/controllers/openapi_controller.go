@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+)
+
+const (
+	openAPIPath    = "/api/v1/openapi.json"
+	openAPITitle   = "IOTA SDK API"
+	openAPIVersion = "1.0.0"
+)
+
+func NewOpenAPIController(app application.Application) application.Controller {
+	return &OpenAPIController{
+		app: app,
+	}
+}
+
+// OpenAPIController publishes an OpenAPI 3 document of the endpoints of every registered OpenAPIDescriber
+type OpenAPIController struct {
+	app application.Application
+}
+
+func (c *OpenAPIController) Key() string {
+	return openAPIPath
+}
+
+func (c *OpenAPIController) Register(r *mux.Router) {
+	router := r.Methods(http.MethodGet).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RequireAuthorization(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+	)
+	router.HandleFunc(openAPIPath, c.Get)
+}
+
+func (c *OpenAPIController) Get(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, c.Document())
+}
+
+// Document builds the document from the controllers registered at the time of the call,
+// so controllers of modules loaded later are included
+func (c *OpenAPIController) Document() *crud.OpenAPIDocument {
+	doc := crud.NewOpenAPIDocument(openAPITitle, openAPIVersion)
+	doc.Components.SecuritySchemes = map[string]*crud.OpenAPISecurityScheme{
+		"bearerAuth": {Type: "http", Scheme: "bearer"},
+		"cookieAuth": {Type: "apiKey", In: "cookie", Name: configuration.Use().SidCookieKey},
+	}
+	doc.Security = []map[string][]string{
+		{"bearerAuth": {}},
+		{"cookieAuth": {}},
+	}
+	for _, controller := range c.app.Controllers() {
+		if describer, ok := controller.(OpenAPIDescriber); ok {
+			describer.DescribeAPI(doc)
+		}
+	}
+	return doc
+}
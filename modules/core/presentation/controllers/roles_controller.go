@@ -3,9 +3,11 @@ package controllers
 import (
 	"net/http"
 	"sort"
+	"strconv"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policy"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/pages/roles"
@@ -23,6 +25,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/a-h/templ"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
@@ -49,6 +52,7 @@ func (c *RolesController) Register(r *mux.Router) {
 		middleware.RedirectNotAuthenticated(),
 		middleware.RequireAuthorization(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.ProvideDynamicLogo(c.app),
 		middleware.Tabs(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
@@ -58,19 +62,24 @@ func (c *RolesController) Register(r *mux.Router) {
 	router.HandleFunc("", di.H(c.List)).Methods(http.MethodGet)
 	router.HandleFunc("/new", di.H(c.GetNew)).Methods(http.MethodGet)
 	router.HandleFunc("/{id:[0-9]+}", di.H(c.GetEdit)).Methods(http.MethodGet)
+	router.HandleFunc("/policies", di.H(c.Policies)).Methods(http.MethodGet)
 
 	router.HandleFunc("", di.H(c.Create)).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9]+}", di.H(c.Update)).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9]+}", di.H(c.Delete)).Methods(http.MethodDelete)
+	router.HandleFunc("/policies", di.H(c.CreatePolicy)).Methods(http.MethodPost)
+	router.HandleFunc("/policies/simulate", di.H(c.SimulatePolicy)).Methods(http.MethodPost)
+	router.HandleFunc("/policies/{id}", di.H(c.DeletePolicy)).Methods(http.MethodDelete)
 }
 
 func (c *RolesController) permissionGroups(
 	rbac rbac.RBAC,
 	selected ...*permission.Permission,
 ) []*viewmodels.PermissionGroup {
+	// Compared by name, the ids of stored permissions differ between tenants
 	isSelected := func(p2 *permission.Permission) bool {
 		for _, p1 := range selected {
-			if p1.ID == p2.ID {
+			if p1.Name == p2.Name {
 				return true
 			}
 		}
@@ -311,3 +320,192 @@ func (c *RolesController) Create(
 
 	shared.Redirect(w, r, c.basePath)
 }
+
+func (c *RolesController) policyPermissions() []*viewmodels.Permission {
+	perms := mapping.MapViewModels(c.app.RBAC().Permissions(), mappers.PermissionToViewModel)
+	sort.Slice(perms, func(i, j int) bool {
+		return perms[i].Name < perms[j].Name
+	})
+	return perms
+}
+
+func (c *RolesController) simulatorProps(
+	r *http.Request,
+	userService *services.UserService,
+	dto *dtos.SimulatePolicyDTO,
+) (*roles.SimulatorProps, error) {
+	users, err := userService.GetAll(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	props := &roles.SimulatorProps{
+		Permissions: c.policyPermissions(),
+		Users:       mapping.MapViewModels(users, mappers.UserToViewModel),
+		Errors:      map[string]string{},
+	}
+	if dto != nil {
+		props.UserID = strconv.FormatUint(uint64(dto.UserID), 10)
+		props.PermissionID = dto.PermissionID
+		props.Resource = dto.Resource
+		props.Request = dto.Request
+		props.DraftEffect = dto.DraftEffect
+		props.DraftCondition = dto.DraftCondition
+	}
+	return props, nil
+}
+
+func (c *RolesController) Policies(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	policyService *services.PolicyService,
+	userService *services.UserService,
+) {
+	policies, err := policyService.GetAll(r.Context(), &policy.FindParams{})
+	if err != nil {
+		logger.Errorf("Error retrieving policies: %v", err)
+		http.Error(w, "Error retrieving policies", http.StatusInternalServerError)
+		return
+	}
+	simulator, err := c.simulatorProps(r, userService, nil)
+	if err != nil {
+		logger.Errorf("Error retrieving users: %v", err)
+		http.Error(w, "Error retrieving users", http.StatusInternalServerError)
+		return
+	}
+	props := &roles.PoliciesPageProps{
+		Policies: mapping.MapViewModels(policies, mappers.PolicyToViewModel),
+		Form: &roles.PolicyFormProps{
+			Permissions: c.policyPermissions(),
+			Policy:      &viewmodels.Policy{Effect: string(rbac.EffectAllow)},
+			Errors:      map[string]string{},
+		},
+		Simulator: simulator,
+	}
+	templ.Handler(roles.Policies(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *RolesController) CreatePolicy(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	policyService *services.PolicyService,
+) {
+	dto, err := composables.UseForm(&dtos.CreatePolicyDTO{}, r)
+	if err != nil {
+		logger.Errorf("Error parsing form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if errors, ok := dto.Ok(r.Context()); !ok {
+		props := &roles.PolicyFormProps{
+			Permissions:  c.policyPermissions(),
+			PermissionID: dto.PermissionID,
+			Policy: &viewmodels.Policy{
+				Name:        dto.Name,
+				Description: dto.Description,
+				Effect:      dto.Effect,
+				Condition:   dto.Condition,
+			},
+			Errors: errors,
+		}
+		templ.Handler(roles.PolicyForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+
+	entity, err := dto.ToEntity(c.app.RBAC())
+	if err != nil {
+		logger.Errorf("Error converting DTO to entity: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := policyService.Create(r.Context(), entity); err != nil {
+		logger.Errorf("Error creating policy: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	shared.Redirect(w, r, c.basePath+"/policies")
+}
+
+func (c *RolesController) DeletePolicy(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	policyService *services.PolicyService,
+) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Errorf("Error parsing policy ID: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := policyService.Delete(r.Context(), id); err != nil {
+		logger.Errorf("Error deleting policy: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *RolesController) SimulatePolicy(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	policyService *services.PolicyService,
+	userService *services.UserService,
+) {
+	dto, err := composables.UseForm(&dtos.SimulatePolicyDTO{}, r)
+	if err != nil {
+		logger.Errorf("Error parsing form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	props, err := c.simulatorProps(r, userService, dto)
+	if err != nil {
+		logger.Errorf("Error retrieving users: %v", err)
+		http.Error(w, "Error retrieving users", http.StatusInternalServerError)
+		return
+	}
+
+	if errors, ok := dto.Ok(r.Context()); !ok {
+		props.Errors = errors
+		templ.Handler(roles.Simulator(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+
+	u, err := userService.GetByID(r.Context(), dto.UserID)
+	if err != nil {
+		logger.Errorf("Error retrieving user: %v", err)
+		http.Error(w, "Error retrieving user", http.StatusInternalServerError)
+		return
+	}
+	perm, err := dto.Permission(c.app.RBAC())
+	if err != nil {
+		logger.Errorf("Error retrieving permission: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resource, request, err := dto.Attributes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	engine, ok := composables.UsePolicyEngine(r.Context())
+	if !ok {
+		engine = rbac.NewEngine(policyService)
+	}
+	decision, err := policyService.Simulate(r.Context(), engine, u, perm, resource, request, dto.Draft(perm))
+	if err != nil {
+		logger.Errorf("Error simulating policies: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props.Decision = mappers.PolicyDecisionToViewModel(decision)
+	templ.Handler(roles.Simulator(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
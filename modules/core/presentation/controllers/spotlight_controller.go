@@ -38,6 +38,7 @@ func (c *SpotlightController) Register(r *mux.Router) {
 	router.Use(
 		middleware.Authorize(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.RedirectNotAuthenticated(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
 	)
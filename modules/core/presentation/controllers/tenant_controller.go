@@ -0,0 +1,339 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tenant"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/pages/tenants"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/tenantdata"
+)
+
+// maxTenantArchiveSize limits the size of an uploaded tenant archive
+const maxTenantArchiveSize = 512 << 20
+
+// TenantsController is the console the super admins of the platform tenant manage tenants in
+type TenantsController struct {
+	app      application.Application
+	basePath string
+}
+
+func NewTenantsController(app application.Application) application.Controller {
+	return &TenantsController{
+		app:      app,
+		basePath: "/tenants",
+	}
+}
+
+func (c *TenantsController) Key() string {
+	return c.basePath
+}
+
+func (c *TenantsController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.RequireSuperAdmin(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", di.H(c.List)).Methods(http.MethodGet)
+	router.HandleFunc("", di.H(c.Create)).Methods(http.MethodPost)
+	router.HandleFunc("/import", di.H(c.Import)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9a-fA-F-]+}/export", di.H(c.Export)).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9a-fA-F-]+}/suspend", di.H(c.Suspend)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9a-fA-F-]+}/activate", di.H(c.Activate)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9a-fA-F-]+}", di.H(c.Delete)).Methods(http.MethodDelete)
+}
+
+func (c *TenantsController) List(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	tenantService *services.TenantService,
+) {
+	entities, err := tenantService.List(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving tenants: %v", err)
+		http.Error(w, "Error retrieving tenants", http.StatusInternalServerError)
+		return
+	}
+	props := &tenants.IndexPageProps{
+		Tenants:    mapping.MapViewModels(entities, mappers.TenantToViewModel),
+		Form:       c.formProps(&dtos.CreateTenantDTO{}, nil),
+		ImportForm: c.importFormProps(&dtos.ImportTenantDTO{}, nil),
+	}
+	templ.Handler(tenants.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *TenantsController) Create(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	provisioningService *services.TenantProvisioningService,
+) {
+	dto, err := composables.UseForm(&dtos.CreateTenantDTO{}, r)
+	if err != nil {
+		logger.Errorf("Error parsing form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		templ.Handler(tenants.TenantForm(c.formProps(dto, errorsMap)), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+
+	params, err := dto.ToParams()
+	if err != nil {
+		logger.Errorf("Error converting DTO to params: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	created, token, err := provisioningService.Provision(r.Context(), params)
+	switch {
+	case errors.Is(err, services.ErrTenantNameTaken):
+		errorsMap := map[string]string{"Name": intl.MustT(r.Context(), "Tenants.Errors.NameTaken")}
+		templ.Handler(tenants.TenantForm(c.formProps(dto, errorsMap)), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	case errors.Is(err, services.ErrTenantDomainTaken):
+		errorsMap := map[string]string{"Subdomain": intl.MustT(r.Context(), "Tenants.Errors.DomainTaken")}
+		templ.Handler(tenants.TenantForm(c.formProps(dto, errorsMap)), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	case err != nil:
+		logger.Errorf("Error provisioning tenant: %v", err)
+		http.Error(w, "Error provisioning tenant", http.StatusInternalServerError)
+		return
+	}
+
+	form := c.formProps(&dtos.CreateTenantDTO{}, nil)
+	form.InviteURL = provisioningService.InviteURL(created, token)
+	if err := tenants.TenantForm(form).Render(r.Context(), w); err != nil {
+		logger.Errorf("Error rendering tenant form: %v", err)
+		return
+	}
+	if err := tenants.TenantCreated(mappers.TenantToViewModel(created)).Render(r.Context(), w); err != nil {
+		logger.Errorf("Error rendering tenant row: %v", err)
+	}
+}
+
+// Export downloads the archive with all rows and files of the tenant
+func (c *TenantsController) Export(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	tenantDataService *services.TenantDataService,
+) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Buffered so that a failed export is reported instead of a truncated file
+	var buf bytes.Buffer
+	_, err = tenantDataService.Export(r.Context(), id, &buf)
+	switch {
+	case errors.Is(err, persistence.ErrTenantNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		logger.Errorf("Error exporting tenant: %v", err)
+		http.Error(w, "Error exporting tenant", http.StatusInternalServerError)
+		return
+	}
+	filename := fmt.Sprintf("tenant-%s-%s.zip", id, time.Now().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	if _, err := buf.WriteTo(w); err != nil {
+		logger.Errorf("Error writing tenant archive: %v", err)
+	}
+}
+
+// Import creates a new tenant from an uploaded archive of Export
+func (c *TenantsController) Import(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	tenantDataService *services.TenantDataService,
+) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxTenantArchiveSize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&dtos.ImportTenantDTO{}, r)
+	if err != nil {
+		logger.Errorf("Error parsing form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		templ.Handler(tenants.TenantImportForm(c.importFormProps(dto, errorsMap)), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	created, _, err := tenantDataService.Import(r.Context(), bytes.NewReader(data), int64(len(data)), dto.ToParams())
+	var errorsMap map[string]string
+	switch {
+	case errors.Is(err, services.ErrTenantNameTaken):
+		errorsMap = map[string]string{"Name": intl.MustT(r.Context(), "Tenants.Errors.NameTaken")}
+	case errors.Is(err, services.ErrTenantDomainTaken):
+		errorsMap = map[string]string{"Subdomain": intl.MustT(r.Context(), "Tenants.Errors.DomainTaken")}
+	case errors.Is(err, tenantdata.ErrNoManifest), errors.Is(err, tenantdata.ErrUnsupportedVersion):
+		errorsMap = map[string]string{"File": intl.MustT(r.Context(), "Tenants.Errors.InvalidArchive")}
+	case err != nil:
+		logger.Errorf("Error importing tenant: %v", err)
+		http.Error(w, "Error importing tenant", http.StatusInternalServerError)
+		return
+	}
+	if errorsMap != nil {
+		templ.Handler(tenants.TenantImportForm(c.importFormProps(dto, errorsMap)), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+
+	form := c.importFormProps(&dtos.ImportTenantDTO{}, nil)
+	form.Imported = created.Name()
+	if err := tenants.TenantImportForm(form).Render(r.Context(), w); err != nil {
+		logger.Errorf("Error rendering tenant import form: %v", err)
+		return
+	}
+	if err := tenants.TenantCreated(mappers.TenantToViewModel(created)).Render(r.Context(), w); err != nil {
+		logger.Errorf("Error rendering tenant row: %v", err)
+	}
+}
+
+func (c *TenantsController) Suspend(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	provisioningService *services.TenantProvisioningService,
+) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := provisioningService.Suspend(r.Context(), id)
+	c.renderRow(w, r, logger, entity, err)
+}
+
+func (c *TenantsController) Activate(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	provisioningService *services.TenantProvisioningService,
+) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := provisioningService.Activate(r.Context(), id)
+	c.renderRow(w, r, logger, entity, err)
+}
+
+func (c *TenantsController) Delete(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	provisioningService *services.TenantProvisioningService,
+) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = provisioningService.Delete(r.Context(), id)
+	switch {
+	case errors.Is(err, persistence.ErrTenantNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, services.ErrPlatformTenantLock):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		logger.Errorf("Error deleting tenant: %v", err)
+		http.Error(w, "Error deleting tenant", http.StatusInternalServerError)
+	}
+}
+
+func (c *TenantsController) renderRow(w http.ResponseWriter, r *http.Request, logger *logrus.Entry, entity *tenant.Tenant, err error) {
+	switch {
+	case errors.Is(err, persistence.ErrTenantNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrPlatformTenantLock):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		logger.Errorf("Error updating tenant: %v", err)
+		http.Error(w, "Error updating tenant", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(tenants.TenantRow(mappers.TenantToViewModel(entity)), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *TenantsController) formProps(dto *dtos.CreateTenantDTO, errorsMap map[string]string) *tenants.TenantFormProps {
+	if errorsMap == nil {
+		errorsMap = map[string]string{}
+	}
+	return &tenants.TenantFormProps{
+		Name:       dto.Name,
+		Subdomain:  dto.Subdomain,
+		UILanguage: dto.UILanguage,
+		AdminEmail: dto.AdminEmail,
+		FirstName:  dto.FirstName,
+		LastName:   dto.LastName,
+		BaseDomain: configuration.Use().Tenants.BaseDomain,
+		Errors:     errorsMap,
+	}
+}
+
+func (c *TenantsController) importFormProps(dto *dtos.ImportTenantDTO, errorsMap map[string]string) *tenants.TenantImportFormProps {
+	if errorsMap == nil {
+		errorsMap = map[string]string{}
+	}
+	return &tenants.TenantImportFormProps{
+		Name:       dto.Name,
+		Subdomain:  dto.Subdomain,
+		BaseDomain: configuration.Use().Tenants.BaseDomain,
+		Errors:     errorsMap,
+	}
+}
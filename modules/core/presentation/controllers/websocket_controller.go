@@ -25,6 +25,7 @@ func (c *WebSocketController) Register(r *mux.Router) {
 	router.Use(
 		middleware.Authorize(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 	)
 
 	router.Handle("", c.app.Websocket())
@@ -1,18 +1,25 @@
 package mappers
 
 import (
+	"encoding/json"
 	"strconv"
 	"time"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/group"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policy"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tab"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tenant"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
 )
 
 func UserToViewModel(entity user.User) *viewmodels.User {
@@ -123,3 +130,95 @@ func GroupToViewModel(entity group.Group) *viewmodels.Group {
 		CanDelete:   entity.CanDelete(),
 	}
 }
+
+func JobToViewModel(entity job.Job) *viewmodels.Job {
+	var finishedAt string
+	if !entity.FinishedAt().IsZero() {
+		finishedAt = entity.FinishedAt().Format(time.RFC3339)
+	}
+	return &viewmodels.Job{
+		ID:             strconv.FormatUint(uint64(entity.ID()), 10),
+		Type:           string(entity.Type()),
+		Title:          entity.Title(),
+		Status:         string(entity.Status()),
+		Progress:       entity.Progress(),
+		Attempts:       entity.Attempts(),
+		MaxAttempts:    entity.MaxAttempts(),
+		Error:          entity.Error(),
+		HasResult:      entity.ResultUploadID() != 0,
+		HasErrorReport: entity.ErrorUploadID() != 0,
+		CanCancel:      entity.CanCancel(),
+		CanRetry:       entity.CanRetry(),
+		CreatedAt:      entity.CreatedAt().Format(time.RFC3339),
+		FinishedAt:     finishedAt,
+	}
+}
+
+func TenantToViewModel(entity *tenant.Tenant) *viewmodels.Tenant {
+	return &viewmodels.Tenant{
+		ID:         entity.ID().String(),
+		Name:       entity.Name(),
+		Domain:     entity.Domain(),
+		UILanguage: entity.UILanguage(),
+		IsActive:   entity.IsActive(),
+		IsPlatform: entity.ID().String() == configuration.Use().Tenants.PlatformTenantID,
+		CreatedAt:  entity.CreatedAt().Format(time.RFC3339),
+	}
+}
+
+func APITokenToViewModel(entity *apitoken.Token, owner string) *viewmodels.APIToken {
+	vm := &viewmodels.APIToken{
+		ID:          strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:        entity.Name(),
+		Owner:       owner,
+		Permissions: entity.Permissions(),
+		CreatedAt:   entity.CreatedAt().Format(time.RFC3339),
+	}
+	if entity.ExpiresAt() != nil {
+		vm.ExpiresAt = entity.ExpiresAt().Format(time.RFC3339)
+	}
+	if entity.LastUsedAt() != nil {
+		vm.LastUsedAt = entity.LastUsedAt().Format(time.RFC3339)
+	}
+	return vm
+}
+
+func PolicyToViewModel(entity policy.Policy) *viewmodels.Policy {
+	return &viewmodels.Policy{
+		ID:          entity.ID().String(),
+		Name:        entity.Name(),
+		Description: entity.Description(),
+		Resource:    string(entity.Resource()),
+		Action:      string(entity.Action()),
+		Effect:      string(entity.Effect()),
+		Condition:   entity.Condition(),
+		Enabled:     entity.Enabled(),
+	}
+}
+
+func PolicyDecisionToViewModel(decision rbac.Decision) *viewmodels.PolicyDecision {
+	attributes, err := json.MarshalIndent(decision.Attributes, "", "  ")
+	if err != nil {
+		attributes = []byte(err.Error())
+	}
+	results := make([]*viewmodels.PolicyResult, 0, len(decision.Results))
+	for _, r := range decision.Results {
+		result := &viewmodels.PolicyResult{
+			Name:      r.Policy.Name,
+			Effect:    string(r.Policy.Effect),
+			Condition: r.Policy.Condition.String(),
+			Matched:   r.Matched,
+		}
+		if r.Err != nil {
+			result.Error = r.Err.Error()
+		}
+		results = append(results, result)
+	}
+	return &viewmodels.PolicyDecision{
+		Allowed:    decision.Allowed,
+		Reason:     string(decision.Reason),
+		Policy:     decision.Policy,
+		Attributes: string(attributes),
+		Results:    results,
+	}
+}
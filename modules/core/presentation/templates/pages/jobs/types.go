@@ -0,0 +1,11 @@
+package jobs
+
+import "github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+
+// IndexPageProps holds the data for rendering the "My jobs" page
+type IndexPageProps struct {
+	Jobs    []*viewmodels.Job
+	Page    int
+	PerPage int
+	HasMore bool
+}
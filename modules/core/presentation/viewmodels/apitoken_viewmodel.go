@@ -0,0 +1,12 @@
+package viewmodels
+
+type APIToken struct {
+	ID          string
+	Name        string
+	Owner       string
+	Permissions []string
+	// ExpiresAt and LastUsedAt are empty when the token never expires or was not used yet
+	ExpiresAt  string
+	LastUsedAt string
+	CreatedAt  string
+}
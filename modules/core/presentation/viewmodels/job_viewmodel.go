@@ -0,0 +1,22 @@
+package viewmodels
+
+type Job struct {
+	ID             string
+	Type           string
+	Title          string
+	Status         string
+	Progress       int
+	Attempts       int
+	MaxAttempts    int
+	Error          string
+	HasResult      bool
+	HasErrorReport bool
+	CanCancel      bool
+	CanRetry       bool
+	CreatedAt      string
+	FinishedAt     string
+}
+
+func (j *Job) IsRunning() bool {
+	return j.Status == "running"
+}
@@ -0,0 +1,29 @@
+package viewmodels
+
+type Policy struct {
+	ID          string
+	Name        string
+	Description string
+	Resource    string
+	Action      string
+	Effect      string
+	Condition   string
+	Enabled     bool
+}
+
+// PolicyDecision is the outcome of the policy simulator
+type PolicyDecision struct {
+	Allowed    bool
+	Reason     string
+	Policy     string
+	Attributes string
+	Results    []*PolicyResult
+}
+
+type PolicyResult struct {
+	Name      string
+	Effect    string
+	Condition string
+	Matched   bool
+	Error     string
+}
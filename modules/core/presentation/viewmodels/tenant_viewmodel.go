@@ -0,0 +1,12 @@
+package viewmodels
+
+type Tenant struct {
+	ID         string
+	Name       string
+	Domain     string
+	UILanguage string
+	IsActive   bool
+	// IsPlatform marks the tenant of the super admins, it can not be suspended or deleted
+	IsPlatform bool
+	CreatedAt  string
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tenant"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/core/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrTokenPermissionNotHeld = errors.New("token can not be scoped to a permission its owner does not hold")
+	ErrNotServiceAccount      = errors.New("user is not a service account")
+)
+
+type CreateServiceAccountParams struct {
+	Name    string
+	Email   internet.Email
+	RoleIDs []uint
+}
+
+// APITokenService issues the tokens scripts and service accounts call the API with
+type APITokenService struct {
+	repo        apitoken.Repository
+	userRepo    user.Repository
+	roleRepo    role.Repository
+	tenantRepo  tenant.Repository
+	userService *UserService
+	rbac        rbac.RBAC
+}
+
+func NewAPITokenService(
+	repo apitoken.Repository,
+	userRepo user.Repository,
+	roleRepo role.Repository,
+	tenantRepo tenant.Repository,
+	userService *UserService,
+	rbac rbac.RBAC,
+) *APITokenService {
+	return &APITokenService{
+		repo:        repo,
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		tenantRepo:  tenantRepo,
+		userService: userService,
+		rbac:        rbac,
+	}
+}
+
+// Authenticate returns the token of the secret and records its use.
+// Tokens of suspended tenants and expired tokens are rejected.
+func (s *APITokenService) Authenticate(ctx context.Context, secret string) (*apitoken.Token, error) {
+	token, err := s.repo.GetByTokenHash(ctx, apitoken.HashToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	save, err := token.Use()
+	if err != nil {
+		return nil, err
+	}
+	tenantCtx := composables.WithTenantID(ctx, token.TenantID())
+	t, err := s.tenantRepo.GetByID(tenantCtx, token.TenantID())
+	if err != nil {
+		return nil, err
+	}
+	if !t.IsActive() {
+		return nil, ErrTenantSuspended
+	}
+	if save {
+		if err := s.repo.UpdateLastUsedAt(tenantCtx, token.ID(), *token.LastUsedAt()); err != nil {
+			return nil, err
+		}
+	}
+	return token, nil
+}
+
+// GetAll returns the tokens of the current user together with the tokens of the service accounts they manage
+func (s *APITokenService) GetAll(ctx context.Context) ([]*apitoken.Token, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := []uint{u.ID()}
+	if composables.CanUser(ctx, permissions.UserUpdate) == nil {
+		accounts, err := s.ServiceAccounts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range accounts {
+			userIDs = append(userIDs, a.ID())
+		}
+	}
+	return s.repo.GetByUserIDs(ctx, userIDs)
+}
+
+// Issue creates a token of the owner and returns it with its secret, the secret can not be retrieved later.
+// A token is scoped to permissions held by both its owner and the user issuing it.
+func (s *APITokenService) Issue(
+	ctx context.Context,
+	ownerID uint,
+	name string,
+	permissionNames []string,
+	expiresAt *time.Time,
+) (*apitoken.Token, string, error) {
+	owner, err := s.manageableUser(ctx, ownerID)
+	if err != nil {
+		return nil, "", err
+	}
+	issuer, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, name := range permissionNames {
+		perm := s.permissionByName(name)
+		if perm == nil || !owner.Can(perm) || !issuer.Can(perm) {
+			return nil, "", ErrTokenPermissionNotHeld
+		}
+	}
+	entity, secret, err := apitoken.Issue(owner.TenantID(), owner.ID(), name, permissionNames, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	created, err := s.repo.Create(ctx, entity)
+	if err != nil {
+		return nil, "", err
+	}
+	return created, secret, nil
+}
+
+// Revoke deletes a token of the current user or of a service account they manage
+func (s *APITokenService) Revoke(ctx context.Context, id uint) error {
+	token, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.manageableUser(ctx, token.UserID()); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ServiceAccounts returns the service accounts of the tenant
+func (s *APITokenService) ServiceAccounts(ctx context.Context) ([]user.User, error) {
+	if err := composables.CanUser(ctx, permissions.UserRead); err != nil {
+		return nil, err
+	}
+	return s.userRepo.GetPaginated(ctx, &user.FindParams{
+		Filters: []user.Filter{
+			{
+				Column: user.TypeField,
+				Filter: repo.Eq(string(user.TypeService)),
+			},
+		},
+		SortBy: user.SortBy{
+			Fields: []repo.SortByField[user.Field]{
+				{Field: user.FirstNameField, Ascending: true},
+			},
+		},
+	})
+}
+
+// CreateServiceAccount creates a user without a password that only accesses the API with its tokens
+func (s *APITokenService) CreateServiceAccount(ctx context.Context, params *CreateServiceAccountParams) (user.User, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]role.Role, 0, len(params.RoleIDs))
+	for _, id := range params.RoleIDs {
+		r, err := s.roleRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, r)
+	}
+	uiLanguage := user.UILanguageEN
+	if u, err := composables.UseUser(ctx); err == nil {
+		uiLanguage = u.UILanguage()
+	}
+	return s.userService.Create(ctx, user.New(
+		params.Name,
+		"",
+		params.Email,
+		uiLanguage,
+		user.WithType(user.TypeService),
+		user.WithTenantID(tenantID),
+		user.WithRoles(roles),
+	))
+}
+
+// ScopeUser limits the permissions of the user to the ones the token is scoped to
+func ScopeUser(u user.User, token *apitoken.Token) user.User {
+	var scoped []*permission.Permission
+	seen := make(map[string]bool)
+	add := func(perms []*permission.Permission) {
+		for _, p := range perms {
+			if token.Allows(p) && !seen[p.Name] {
+				seen[p.Name] = true
+				scoped = append(scoped, p)
+			}
+		}
+	}
+	add(u.Permissions())
+	for _, r := range u.Roles() {
+		add(r.Permissions())
+	}
+	return u.SetRoles(nil).SetPermissions(scoped)
+}
+
+// manageableUser returns the user when it is the current user or a service account the current user may update
+func (s *APITokenService) manageableUser(ctx context.Context, id uint) (user.User, error) {
+	current, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current.ID() == id {
+		return current, nil
+	}
+	if err := composables.CanUser(ctx, permissions.UserUpdate); err != nil {
+		return nil, err
+	}
+	u, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if u.Type() != user.TypeService {
+		return nil, ErrNotServiceAccount
+	}
+	return u, nil
+}
+
+func (s *APITokenService) permissionByName(name string) *permission.Permission {
+	for _, p := range s.rbac.Permissions() {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
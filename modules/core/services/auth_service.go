@@ -59,7 +59,7 @@ func (s *AuthService) AuthenticateGoogle(ctx context.Context, code string) (user
 	if err != nil {
 		return nil, nil, err
 	}
-	u, err := s.usersService.GetByEmail(ctx, p.EmailAddresses[0].Value)
+	u, err := s.usersService.GetByEmail(lookupContext(ctx), p.EmailAddresses[0].Value)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -119,10 +119,30 @@ func (s *AuthService) newSessionToken() (string, error) {
 	return encoded, nil
 }
 
+// lookupContext is the context users are looked up with on login. Without a host tenant
+// the tenant is only known from the user itself, so the lookup has to span tenants
+func lookupContext(ctx context.Context) context.Context {
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return composables.WithoutTenantIsolation(ctx)
+	}
+	return ctx
+}
+
 func (s *AuthService) authenticate(ctx context.Context, u user.User) (*session.Session, error) {
 	logger := configuration.Use().Logger()
 	logger.Infof("Creating session for user ID: %d, tenant ID: %d", u.ID(), u.TenantID())
 
+	// The session belongs to the tenant of the user, whichever host the login came from
+	ctx = composables.WithTenantID(ctx, u.TenantID())
+	tenantService := s.app.Service(TenantService{}).(*TenantService)
+	t, err := tenantService.GetByID(ctx, u.TenantID())
+	if err != nil {
+		return nil, err
+	}
+	if !t.IsActive() {
+		return nil, ErrTenantSuspended
+	}
+
 	// Get IP and user agent
 	ip, ok := composables.UseIP(ctx)
 	if !ok {
@@ -176,7 +196,7 @@ func (s *AuthService) authenticate(ctx context.Context, u user.User) (*session.S
 }
 
 func (s *AuthService) AuthenticateWithUserID(ctx context.Context, id uint, password string) (user.User, *session.Session, error) {
-	u, err := s.usersService.GetByID(ctx, id)
+	u, err := s.usersService.GetByID(lookupContext(ctx), id)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -212,7 +232,7 @@ func (s *AuthService) Authenticate(ctx context.Context, email, password string)
 	logger := configuration.Use().Logger()
 	logger.Infof("Authentication attempt for email: %s", email)
 
-	u, err := s.usersService.GetByEmail(ctx, email)
+	u, err := s.usersService.GetByEmail(lookupContext(ctx), email)
 	if err != nil {
 		logger.Errorf("Failed to get user by email: %v", err)
 		return nil, nil, err
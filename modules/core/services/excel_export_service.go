@@ -4,93 +4,129 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exportconfig"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/excel"
 )
 
-// ExcelExportService handles Excel export operations
+// ExcelExportService handles export operations to Excel and other file formats
 type ExcelExportService struct {
 	db            *pgxpool.Pool
 	uploadService *UploadService
+	tenantService *TenantService
 }
 
 // NewExcelExportService creates a new Excel export service
-func NewExcelExportService(db *pgxpool.Pool, uploadService *UploadService) *ExcelExportService {
+func NewExcelExportService(db *pgxpool.Pool, uploadService *UploadService, tenantService *TenantService) *ExcelExportService {
 	return &ExcelExportService{
 		db:            db,
 		uploadService: uploadService,
+		tenantService: tenantService,
 	}
 }
 
-// ExportFromQuery exports SQL query results to Excel and saves as upload
+// ExportFromQuery exports SQL query results in the configured format and saves as upload
 func (s *ExcelExportService) ExportFromQuery(ctx context.Context, query exportconfig.Query, config exportconfig.ExportConfig) (upload.Upload, error) {
 	// Create pgx data source
 	datasource := excel.NewPgxDataSource(s.db, query.SQL(), query.Args()...)
 	if config.Filename() != "" {
 		// Use filename without extension as sheet name
-		sheetName := config.Filename()
+		sheetName := strings.TrimSuffix(config.Filename(), config.Format().Extension())
 		if len(sheetName) > 31 { // Excel sheet name limit
 			sheetName = sheetName[:31]
 		}
 		datasource.WithSheetName(sheetName)
 	}
 
-	// Create Excel exporter with options
-	exporter := excel.NewExcelExporter(config.ExportOptions(), config.StyleOptions())
+	return s.ExportFromDataSource(ctx, datasource, config)
+}
 
-	// Export to Excel
-	data, err := exporter.Export(ctx, datasource)
+// ExportFromDataSource exports from a custom data source in the configured format
+func (s *ExcelExportService) ExportFromDataSource(
+	ctx context.Context,
+	datasource excel.DataSource,
+	config exportconfig.ExportConfig,
+) (upload.Upload, error) {
+	exporter, err := s.exporter(ctx, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to export to Excel: %w", err)
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.ExportTo(ctx, &buf, datasource); err != nil {
+		return nil, fmt.Errorf("failed to export to %s: %w", config.Format().Name(), err)
 	}
 
 	// Create upload DTO
 	uploadDTO := &upload.CreateDTO{
-		File: bytes.NewReader(data),
+		File: bytes.NewReader(buf.Bytes()),
 		Name: config.Filename(),
-		Size: len(data),
+		Size: buf.Len(),
 	}
 
 	// Save to upload service
 	uploadEntity, err := s.uploadService.Create(ctx, uploadDTO)
 	if err != nil {
-		return nil, fmt.Errorf("failed to save Excel file: %w", err)
+		return nil, fmt.Errorf("failed to save %s file: %w", config.Format().Name(), err)
 	}
 
 	return uploadEntity, nil
 }
 
-// ExportFromDataSource exports from a custom data source to Excel
-func (s *ExcelExportService) ExportFromDataSource(
-	ctx context.Context,
-	datasource excel.DataSource,
-	config exportconfig.ExportConfig,
-) (upload.Upload, error) {
-	// Create Excel exporter
-	exporter := excel.NewExcelExporter(config.ExportOptions(), config.StyleOptions())
-
-	// Export to Excel
-	data, err := exporter.Export(ctx, datasource)
-	if err != nil {
-		return nil, fmt.Errorf("failed to export to Excel: %w", err)
+// exporter returns the exporter for the configured format
+func (s *ExcelExportService) exporter(ctx context.Context, config exportconfig.ExportConfig) (excel.FormatExporter, error) {
+	switch config.Format() {
+	case excel.FormatXLSX:
+		return excel.NewExcelExporter(config.ExportOptions(), config.StyleOptions()), nil
+	case excel.FormatCSV:
+		return excel.NewCSVExporter(config.ExportOptions(), config.CSVOptions()), nil
+	case excel.FormatODS:
+		return excel.NewODSExporter(config.ExportOptions()), nil
+	case excel.FormatPDF:
+		pdfOpts := excel.DefaultPDFOptions()
+		if config.PDFOptions() != nil {
+			opts := *config.PDFOptions()
+			pdfOpts = &opts
+		}
+		if len(pdfOpts.Logo) == 0 {
+			// The logo is decoration, a missing upload must not fail the export
+			logo, err := s.tenantLogo(ctx)
+			switch {
+			case err != nil:
+				configuration.Use().Logger().WithError(err).Warn("failed to load tenant logo, exporting pdf without it")
+			case len(logo) > 0 && !excel.IsPDFImage(logo):
+				configuration.Use().Logger().Warn("tenant logo is not a PNG, JPEG or GIF image, exporting pdf without it")
+			default:
+				pdfOpts.Logo = logo
+			}
+		}
+		return excel.NewPDFExporter(config.ExportOptions(), pdfOpts), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", config.Format())
 	}
+}
 
-	// Create upload DTO
-	uploadDTO := &upload.CreateDTO{
-		File: bytes.NewReader(data),
-		Name: config.Filename(),
-		Size: len(data),
+// tenantLogo returns the logo of the current tenant, or nil if it has none
+func (s *ExcelExportService) tenantLogo(ctx context.Context) ([]byte, error) {
+	if s.tenantService == nil {
+		return nil, nil
 	}
-
-	// Save to upload service
-	uploadEntity, err := s.uploadService.Create(ctx, uploadDTO)
+	tenantID, err := composables.UseTenantID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to save Excel file: %w", err)
+		return nil, nil
 	}
-
-	return uploadEntity, nil
+	t, err := s.tenantService.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if t.LogoID() == nil {
+		return nil, nil
+	}
+	return s.uploadService.Open(ctx, uint(*t.LogoID()))
 }
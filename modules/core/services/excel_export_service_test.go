@@ -179,7 +179,7 @@ func TestExcelExportService_ExportFromDataSource(t *testing.T) {
 	uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
 
 	// Create Excel export service (with nil DB since we're using custom datasource)
-	excelService := services.NewExcelExportService(nil, uploadService)
+	excelService := services.NewExcelExportService(nil, uploadService, nil)
 
 	// Create mock datasource
 	headers := []string{"id", "name", "email"}
@@ -253,7 +253,7 @@ func TestExcelExportService_ExportFromDataSourceWithOptions(t *testing.T) {
 
 	// Create services
 	uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
-	excelService := services.NewExcelExportService(nil, uploadService)
+	excelService := services.NewExcelExportService(nil, uploadService, nil)
 
 	// Create mock datasource
 	headers := []string{"id", "name", "score"}
@@ -313,7 +313,7 @@ func TestExcelExportService_ExportFromDataSource_EmptyFilename(t *testing.T) {
 
 	// Create services
 	uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
-	excelService := services.NewExcelExportService(nil, uploadService)
+	excelService := services.NewExcelExportService(nil, uploadService, nil)
 
 	// Create mock datasource
 	datasource := &mockDataSource{
@@ -354,7 +354,7 @@ func TestExcelExportService_ExportError(t *testing.T) {
 
 	// Create services
 	uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
-	excelService := services.NewExcelExportService(nil, uploadService)
+	excelService := services.NewExcelExportService(nil, uploadService, nil)
 
 	// Create a datasource that returns error
 	datasource := &errorDataSource{}
@@ -382,3 +382,48 @@ func (e *errorDataSource) GetSheetName() string {
 func (e *errorDataSource) GetRows(ctx context.Context) (func() ([]interface{}, error), error) {
 	return nil, fmt.Errorf("datasource error")
 }
+
+func TestExcelExportService_ExportFromDataSource_Formats(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   excel.Format
+		expected string
+	}{
+		{name: "CSV", format: excel.FormatCSV, expected: "report.csv"},
+		{name: "ODS", format: excel.FormatODS, expected: "report.ods"},
+		{name: "PDF", format: excel.FormatPDF, expected: "report.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUploadRepository)
+			mockStorage := new(MockUploadStorage)
+			mockEventBus := eventbus.NewEventPublisher(logrus.New())
+
+			uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
+			exportService := services.NewExcelExportService(nil, uploadService, nil)
+
+			datasource := &mockDataSource{
+				headers: []string{"id", "name"},
+				rows:    [][]interface{}{{1, "John Doe"}},
+			}
+
+			mockUpload := new(MockUpload)
+			mockRepo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, persistence.ErrUploadNotFound)
+			mockRepo.On("Create", mock.Anything, mock.Anything).Return(mockUpload, nil)
+			mockStorage.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			config := exportconfig.New(
+				exportconfig.WithFilename("report"),
+				exportconfig.WithFormat(tt.format),
+			)
+			result, err := exportService.ExportFromDataSource(context.Background(), datasource, config)
+			require.NoError(t, err)
+			assert.NotNil(t, result)
+
+			createdEntity := mockRepo.Calls[1].Arguments[1].(upload.Upload)
+			assert.Equal(t, tt.expected, createdEntity.Name())
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
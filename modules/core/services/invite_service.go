@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/invite"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type InviteService struct {
+	repo     invite.Repository
+	userRepo user.Repository
+}
+
+func NewInviteService(repo invite.Repository, userRepo user.Repository) *InviteService {
+	return &InviteService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// Issue creates an invite for the user and returns the token of the invite link
+func (s *InviteService) Issue(ctx context.Context, userID uint, ttl time.Duration) (string, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return "", err
+	}
+	entity, token, err := invite.Issue(tenantID, userID, ttl)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.repo.Create(ctx, entity); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// GetByToken returns the invite of the link token, expired and accepted invites are returned as errors
+func (s *InviteService) GetByToken(ctx context.Context, token string) (*invite.Invite, error) {
+	entity, err := s.repo.GetByTokenHash(ctx, invite.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if entity.IsAccepted() {
+		return nil, invite.ErrAccepted
+	}
+	if entity.IsExpired() {
+		return nil, invite.ErrExpired
+	}
+	return entity, nil
+}
+
+// GetUser returns the invited user, it is looked up in the tenant of the invite
+func (s *InviteService) GetUser(ctx context.Context, entity *invite.Invite) (user.User, error) {
+	return s.userRepo.GetByID(composables.WithTenantID(ctx, entity.TenantID()), entity.UserID())
+}
+
+// Accept sets the password of the invited user and marks the invite as used
+func (s *InviteService) Accept(ctx context.Context, token, password string) (user.User, error) {
+	entity, err := s.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	var result user.User
+	err = composables.InTx(composables.WithTenantID(ctx, entity.TenantID()), func(txCtx context.Context) error {
+		u, err := s.userRepo.GetByID(txCtx, entity.UserID())
+		if err != nil {
+			return err
+		}
+		u, err = u.SetPassword(password)
+		if err != nil {
+			return err
+		}
+		if err := s.userRepo.Update(txCtx, u); err != nil {
+			return err
+		}
+		if err := entity.Accept(); err != nil {
+			return err
+		}
+		if err := s.repo.Update(txCtx, entity); err != nil {
+			return err
+		}
+		result = u
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/iota-uz/go-i18n/v2/i18n"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exportconfig"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/pkg/excel"
+	importpkg "github.com/iota-uz/iota-sdk/pkg/import"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+)
+
+// ExportJobPayload describes a query export executed in the background
+type ExportJobPayload struct {
+	SQL        string            `json:"sql"`
+	Args       []interface{}     `json:"args,omitempty"`
+	Filename   string            `json:"filename"`
+	Format     excel.Format      `json:"format,omitempty"`
+	CSVOptions *excel.CSVOptions `json:"csvOptions,omitempty"`
+}
+
+// ExportJobHandler runs query exports through ExcelExportService and stores the file as the job result
+type ExportJobHandler struct {
+	db            *pgxpool.Pool
+	exportService *ExcelExportService
+}
+
+func NewExportJobHandler(db *pgxpool.Pool, exportService *ExcelExportService) *ExportJobHandler {
+	return &ExportJobHandler{
+		db:            db,
+		exportService: exportService,
+	}
+}
+
+func (h *ExportJobHandler) Handle(ctx context.Context, j job.Job, progress JobProgressFunc) (*JobResult, error) {
+	var payload ExportJobPayload
+	if err := j.DecodePayload(&payload); err != nil {
+		return nil, NewPermanentJobError(err)
+	}
+	if payload.Format == "" {
+		payload.Format = excel.FormatXLSX
+	}
+	if !payload.Format.IsValid() {
+		return nil, NewPermanentJobError(fmt.Errorf("unsupported export format %q", payload.Format))
+	}
+	args := normalizeJSONArgs(payload.Args)
+
+	var total int
+	if err := h.db.QueryRow(ctx, fmt.Sprintf("SELECT COUNT(*) FROM (%s) q", payload.SQL), args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count export rows: %w", err)
+	}
+
+	datasource := excel.NewPgxDataSource(h.db, payload.SQL, args...)
+	if payload.Filename != "" {
+		datasource.WithSheetName(truncateSheetName(payload.Filename))
+	}
+	config := exportconfig.New(
+		exportconfig.WithFilename(payload.Filename),
+		exportconfig.WithFormat(payload.Format),
+		exportconfig.WithCSVOptions(payload.CSVOptions),
+	)
+	result, err := h.exportService.ExportFromDataSource(
+		ctx,
+		excel.NewProgressDataSource(datasource, total, func(processed, total int) {
+			if total > 0 {
+				progress(processed * 100 / total)
+			}
+		}),
+		config,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &JobResult{ResultUploadID: result.ID()}, nil
+}
+
+// normalizeJSONArgs turns whole numbers decoded from JSON back into integers
+// so they can be bound to integer query parameters
+func normalizeJSONArgs(args []interface{}) []interface{} {
+	result := make([]interface{}, len(args))
+	for i, arg := range args {
+		if f, ok := arg.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			result[i] = int64(f)
+			continue
+		}
+		result[i] = arg
+	}
+	return result
+}
+
+func truncateSheetName(name string) string {
+	runes := []rune(name)
+	if len(runes) > 31 { // Excel sheet name limit
+		return string(runes[:31])
+	}
+	return name
+}
+
+// ImportJobPayload describes an import of an uploaded spreadsheet executed in the background
+type ImportJobPayload struct {
+	// Importer is the name the row handler factory was registered with
+	Importer string `json:"importer"`
+	// FileID is the upload holding the spreadsheet
+	FileID uint `json:"fileId"`
+	// Params are importer specific parameters
+	Params json.RawMessage `json:"params,omitempty"`
+	// Options hold the column mapping and import mode confirmed in the preview
+	Options importpkg.ImportOptions `json:"options"`
+}
+
+// ImportRowHandlerFactory creates the row handler that validates and stores rows of an import job
+type ImportRowHandlerFactory func(ctx context.Context, payload ImportJobPayload) (importpkg.ExcelRowHandler, error)
+
+// ImportJobHandler imports uploaded spreadsheets with importers registered by modules.
+// When rows fail validation nothing is imported and an error report is attached to the job.
+type ImportJobHandler struct {
+	uploadService *UploadService
+	exportService *ExcelExportService
+	mu            sync.RWMutex
+	importers     map[string]ImportRowHandlerFactory
+}
+
+func NewImportJobHandler(uploadService *UploadService, exportService *ExcelExportService) *ImportJobHandler {
+	return &ImportJobHandler{
+		uploadService: uploadService,
+		exportService: exportService,
+		importers:     make(map[string]ImportRowHandlerFactory),
+	}
+}
+
+// Register registers an importer under the given name
+func (h *ImportJobHandler) Register(name string, factory ImportRowHandlerFactory) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.importers[name] = factory
+}
+
+func (h *ImportJobHandler) Handle(ctx context.Context, j job.Job, progress JobProgressFunc) (*JobResult, error) {
+	var payload ImportJobPayload
+	if err := j.DecodePayload(&payload); err != nil {
+		return nil, NewPermanentJobError(err)
+	}
+	h.mu.RLock()
+	factory, ok := h.importers[payload.Importer]
+	h.mu.RUnlock()
+	if !ok {
+		return nil, NewPermanentJobError(fmt.Errorf("unknown importer %q", payload.Importer))
+	}
+
+	rowHandler, err := factory(ctx, payload)
+	if err != nil {
+		return nil, NewPermanentJobError(err)
+	}
+	importer := importpkg.NewImporter(
+		&importUploadService{uploadService: h.uploadService},
+		importpkg.NewMultiFormatFileReader(),
+	)
+	_, err = importer.Import(ctx, payload.FileID, withProgress(rowHandler, progress), payload.Options)
+	if errors.Is(err, importpkg.ErrModeNotSupported) {
+		return nil, NewPermanentJobError(err)
+	}
+	if err == nil {
+		return &JobResult{}, nil
+	}
+
+	var validationErrors *importpkg.MultiValidationError
+	if !errors.As(err, &validationErrors) {
+		return nil, err
+	}
+	report, reportErr := h.errorReport(ctx, j, validationErrors)
+	if reportErr != nil {
+		return nil, errors.Join(err, reportErr)
+	}
+	return &JobResult{ErrorUploadID: report.ID()}, NewPermanentJobError(err)
+}
+
+// errorReport stores validation errors as a spreadsheet users can download from the jobs page
+func (h *ImportJobHandler) errorReport(
+	ctx context.Context,
+	j job.Job,
+	validationErrors *importpkg.MultiValidationError,
+) (upload.Upload, error) {
+	localizer, _ := intl.UseLocalizer(ctx)
+	headers := []string{
+		localize(localizer, "Jobs.ErrorReport.Row", "Row"),
+		localize(localizer, "Jobs.ErrorReport.Column", "Column"),
+		localize(localizer, "Jobs.ErrorReport.Value", "Value"),
+		localize(localizer, "Jobs.ErrorReport.Error", "Error"),
+	}
+	rows := make([][]interface{}, 0, len(validationErrors.Errors))
+	for _, err := range validationErrors.Errors {
+		var cellErr *importpkg.InvalidCellError
+		var valErr *importpkg.ValidationError
+		var sheetErr *importpkg.SheetError
+		sheetPrefix := ""
+		if errors.As(err, &sheetErr) {
+			sheetPrefix = sheetErr.Sheet + "!"
+		}
+		switch {
+		case errors.As(err, &valErr):
+			rows = append(rows, []interface{}{valErr.RowNum, sheetPrefix + valErr.Col, valErr.Value, valErr.Message})
+		case errors.As(err, &cellErr):
+			rows = append(rows, []interface{}{cellErr.Row, sheetPrefix + cellErr.Col, "", cellErr.Message})
+		default:
+			rows = append(rows, []interface{}{"", "", "", err.Error()})
+		}
+	}
+
+	name := strings.TrimSpace(j.Title())
+	if name == "" {
+		name = string(j.Type())
+	}
+	config := exportconfig.New(
+		exportconfig.WithFilename(fmt.Sprintf("%s_errors_%d", name, j.ID())),
+	)
+	return h.exportService.ExportFromDataSource(
+		ctx,
+		excel.NewSliceDataSource(headers, rows).WithSheetName(truncateSheetName(headers[3])),
+		config,
+	)
+}
+
+func localize(localizer *i18n.Localizer, id, fallback string) string {
+	if localizer == nil {
+		return fallback
+	}
+	msg, err := localizer.Localize(&i18n.LocalizeConfig{MessageID: id})
+	if err != nil {
+		return fallback
+	}
+	return msg
+}
+
+// progressRowHandler forwards row progress of an import to the job
+type progressRowHandler struct {
+	importpkg.ExcelRowHandler
+	progress JobProgressFunc
+}
+
+func (h *progressRowHandler) ReportProgress(processed, total int) {
+	if total > 0 {
+		h.progress(processed * 100 / total)
+	}
+}
+
+// matchingProgressRowHandler keeps the record matching of the wrapped handler visible to the importer
+type matchingProgressRowHandler struct {
+	*progressRowHandler
+	importpkg.RecordMatcher
+}
+
+// updatingProgressRowHandler keeps record matching and updates of the wrapped handler visible to the importer
+type updatingProgressRowHandler struct {
+	*progressRowHandler
+	importpkg.RecordMatcher
+	importpkg.RowUpdater
+}
+
+// withProgress wraps the row handler so it reports progress to the job,
+// preserving the optional interfaces the import modes depend on
+func withProgress(handler importpkg.ExcelRowHandler, progress JobProgressFunc) importpkg.ExcelRowHandler {
+	wrapped := &progressRowHandler{ExcelRowHandler: handler, progress: progress}
+	matcher, canMatch := handler.(importpkg.RecordMatcher)
+	updater, canUpdate := handler.(importpkg.RowUpdater)
+	switch {
+	case canMatch && canUpdate:
+		return &updatingProgressRowHandler{progressRowHandler: wrapped, RecordMatcher: matcher, RowUpdater: updater}
+	case canMatch:
+		return &matchingProgressRowHandler{progressRowHandler: wrapped, RecordMatcher: matcher}
+	default:
+		return wrapped
+	}
+}
+
+// importUploadService adapts UploadService to the import package
+type importUploadService struct {
+	uploadService *UploadService
+}
+
+func (s *importUploadService) GetByID(ctx context.Context, id uint) (importpkg.UploadFile, error) {
+	return s.uploadService.GetByID(ctx, id)
+}
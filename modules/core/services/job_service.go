@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+var (
+	ErrJobHandlerNotFound = errors.New("no handler registered for job type")
+	ErrJobNotCancelable   = errors.New("job can not be canceled")
+	ErrJobNotRetryable    = errors.New("job can not be retried")
+)
+
+// JobProgressFunc reports the progress of a running job in percent
+type JobProgressFunc func(percent int)
+
+// JobResult holds the uploads produced by a job handler
+type JobResult struct {
+	// ResultUploadID is the file users can download once the job completes
+	ResultUploadID uint
+	// ErrorUploadID is a report describing why the job failed, e.g. a list of invalid rows
+	ErrorUploadID uint
+}
+
+// JobHandler executes jobs of a single type.
+// The context carries the tenant, the user who enqueued the job and a database pool,
+// and is canceled when the job gets canceled or the worker shuts down.
+type JobHandler interface {
+	Handle(ctx context.Context, j job.Job, progress JobProgressFunc) (*JobResult, error)
+}
+
+// JobHandlerFunc adapts a function to the JobHandler interface
+type JobHandlerFunc func(ctx context.Context, j job.Job, progress JobProgressFunc) (*JobResult, error)
+
+func (f JobHandlerFunc) Handle(ctx context.Context, j job.Job, progress JobProgressFunc) (*JobResult, error) {
+	return f(ctx, j, progress)
+}
+
+// PermanentJobError marks a failure that will not go away on another attempt, e.g. invalid input.
+// Jobs failing with it are not retried.
+type PermanentJobError struct {
+	Err error
+}
+
+func NewPermanentJobError(err error) error {
+	return &PermanentJobError{Err: err}
+}
+
+func (e *PermanentJobError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentJobError) Unwrap() error {
+	return e.Err
+}
+
+type JobService struct {
+	repo      job.Repository
+	publisher eventbus.EventBus
+	mu        sync.RWMutex
+	handlers  map[job.Type]JobHandler
+}
+
+func NewJobService(repo job.Repository, publisher eventbus.EventBus) *JobService {
+	return &JobService{
+		repo:      repo,
+		publisher: publisher,
+		handlers:  make(map[job.Type]JobHandler),
+	}
+}
+
+// RegisterHandler registers the handler executing jobs of the given type
+func (s *JobService) RegisterHandler(jobType job.Type, handler JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = handler
+}
+
+// Handler returns the handler registered for the job type
+func (s *JobService) Handler(jobType job.Type) (JobHandler, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	h, ok := s.handlers[jobType]
+	return h, ok
+}
+
+// Types returns the job types that have a registered handler
+func (s *JobService) Types() []job.Type {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	types := make([]job.Type, 0, len(s.handlers))
+	for t := range s.handlers {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+func (s *JobService) GetByID(ctx context.Context, id uint) (job.Job, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *JobService) GetPaginated(ctx context.Context, params *job.FindParams) ([]job.Job, error) {
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *JobService) Count(ctx context.Context, params *job.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+// Enqueue stores a new pending job owned by the current user.
+// The payload is marshalled to JSON and passed to the handler of the job type.
+func (s *JobService) Enqueue(ctx context.Context, jobType job.Type, payload any, opts ...job.Option) (job.Job, error) {
+	if _, ok := s.Handler(jobType); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobHandlerNotFound, jobType)
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]job.Option{job.WithTenantID(tenantID)}, opts...)
+	if u, err := composables.UseUser(ctx); err == nil {
+		opts = append(opts, job.WithUserID(u.ID()))
+	}
+	entity, err := job.New(jobType, payload, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Save(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(job.NewCreatedEvent(created))
+	return created, nil
+}
+
+// Cancel cancels a pending or running job of the current user.
+// Running jobs notice the cancellation on their next heartbeat.
+func (s *JobService) Cancel(ctx context.Context, id uint) (job.Job, error) {
+	entity, err := s.getOwned(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.CanCancel() {
+		return nil, ErrJobNotCancelable
+	}
+	return s.save(ctx, entity.Cancel())
+}
+
+// Retry schedules a failed or canceled job of the current user to run again
+func (s *JobService) Retry(ctx context.Context, id uint) (job.Job, error) {
+	entity, err := s.getOwned(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.CanRetry() {
+		return nil, ErrJobNotRetryable
+	}
+	return s.save(ctx, entity.Retry())
+}
+
+// getOwned returns the job if it belongs to the current user
+func (s *JobService) getOwned(ctx context.Context, id uint) (job.Job, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.UserID() != u.ID() {
+		return nil, persistence.ErrJobNotFound
+	}
+	return entity, nil
+}
+
+func (s *JobService) save(ctx context.Context, entity job.Job) (job.Job, error) {
+	updated, err := s.repo.Save(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(job.NewUpdatedEvent(updated))
+	return updated, nil
+}
@@ -0,0 +1,170 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// inMemoryJobRepository keeps jobs in memory so JobService can be tested without a database
+type inMemoryJobRepository struct {
+	mu     sync.Mutex
+	nextID uint
+	jobs   map[uint]job.Job
+}
+
+func newInMemoryJobRepository() *inMemoryJobRepository {
+	return &inMemoryJobRepository{jobs: make(map[uint]job.Job)}
+}
+
+func (r *inMemoryJobRepository) Count(ctx context.Context, params *job.FindParams) (int64, error) {
+	jobs, err := r.GetPaginated(ctx, params)
+	return int64(len(jobs)), err
+}
+
+func (r *inMemoryJobRepository) GetPaginated(_ context.Context, params *job.FindParams) ([]job.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]job.Job, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		if params.UserID != 0 && j.UserID() != params.UserID {
+			continue
+		}
+		result = append(result, j)
+	}
+	return result, nil
+}
+
+func (r *inMemoryJobRepository) GetByID(_ context.Context, id uint) (job.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	j, ok := r.jobs[id]
+	if !ok {
+		return nil, persistence.ErrJobNotFound
+	}
+	return j, nil
+}
+
+func (r *inMemoryJobRepository) Save(_ context.Context, data job.Job) (job.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := data.ID()
+	if id == 0 {
+		r.nextID++
+		id = r.nextID
+	}
+	saved, err := job.New(
+		data.Type(),
+		data.Payload(),
+		job.WithID(id),
+		job.WithTenantID(data.TenantID()),
+		job.WithUserID(data.UserID()),
+		job.WithStatus(data.Status()),
+		job.WithProgress(data.Progress()),
+		job.WithAttempts(data.Attempts()),
+		job.WithMaxAttempts(data.MaxAttempts()),
+		job.WithError(data.Error()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r.jobs[id] = saved
+	return saved, nil
+}
+
+func (r *inMemoryJobRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.jobs, id)
+	return nil
+}
+
+func (r *inMemoryJobRepository) Claim(context.Context, []job.Type) (job.Job, error) {
+	return nil, nil
+}
+
+func (r *inMemoryJobRepository) Heartbeat(ctx context.Context, id uint, _ int) (job.Status, error) {
+	j, err := r.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return j.Status(), nil
+}
+
+func (r *inMemoryJobRepository) RequeueStale(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func jobTestContext(t *testing.T, userID uint) context.Context {
+	t.Helper()
+	email, err := internet.NewEmail("test@example.com")
+	require.NoError(t, err)
+	u := user.New("Test", "User", email, user.UILanguageEN, user.WithID(userID))
+	ctx := composables.WithTenantID(context.Background(), uuid.New())
+	return composables.WithUser(ctx, u)
+}
+
+func newTestJobService() *services.JobService {
+	service := services.NewJobService(newInMemoryJobRepository(), eventbus.NewEventPublisher(logrus.New()))
+	service.RegisterHandler(job.TypeExport, services.JobHandlerFunc(
+		func(context.Context, job.Job, services.JobProgressFunc) (*services.JobResult, error) {
+			return &services.JobResult{}, nil
+		},
+	))
+	return service
+}
+
+func TestJobService_Enqueue(t *testing.T) {
+	t.Parallel()
+	service := newTestJobService()
+	ctx := jobTestContext(t, 7)
+
+	created, err := service.Enqueue(ctx, job.TypeExport, map[string]string{"filename": "report"})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID())
+	assert.Equal(t, uint(7), created.UserID())
+	assert.Equal(t, job.StatusPending, created.Status())
+
+	_, err = service.Enqueue(ctx, job.TypeImport, nil)
+	require.ErrorIs(t, err, services.ErrJobHandlerNotFound)
+}
+
+func TestJobService_CancelAndRetry(t *testing.T) {
+	t.Parallel()
+	service := newTestJobService()
+	ctx := jobTestContext(t, 7)
+
+	created, err := service.Enqueue(ctx, job.TypeExport, nil)
+	require.NoError(t, err)
+
+	_, err = service.Cancel(jobTestContext(t, 8), created.ID())
+	require.ErrorIs(t, err, persistence.ErrJobNotFound, "jobs of other users must not be visible")
+
+	_, err = service.Retry(ctx, created.ID())
+	require.ErrorIs(t, err, services.ErrJobNotRetryable)
+
+	canceled, err := service.Cancel(ctx, created.ID())
+	require.NoError(t, err)
+	assert.Equal(t, job.StatusCanceled, canceled.Status())
+
+	_, err = service.Cancel(ctx, created.ID())
+	require.ErrorIs(t, err, services.ErrJobNotCancelable)
+
+	retried, err := service.Retry(ctx, created.ID())
+	require.NoError(t, err)
+	assert.Equal(t, job.StatusPending, retried.Status())
+}
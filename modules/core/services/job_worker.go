@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iota-uz/go-i18n/v2/i18n"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/job"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+)
+
+type JobWorkerOptions struct {
+	// Concurrency is the number of jobs processed in parallel
+	Concurrency int
+	// PollInterval is how long an idle worker waits before looking for new jobs
+	PollInterval time.Duration
+	// HeartbeatInterval is how often running jobs report that they are alive and check for cancellation
+	HeartbeatInterval time.Duration
+	// StaleAfter is how long a running job may go without a heartbeat before it is put back into the queue
+	StaleAfter time.Duration
+	// Backoff returns the delay before the next attempt of a failed job
+	Backoff func(attempt int) time.Duration
+}
+
+func DefaultJobWorkerOptions() *JobWorkerOptions {
+	conf := configuration.Use()
+	return &JobWorkerOptions{
+		Concurrency:       conf.Jobs.Workers,
+		PollInterval:      conf.Jobs.PollInterval,
+		HeartbeatInterval: 5 * time.Second,
+		StaleAfter:        2 * time.Minute,
+		Backoff: func(attempt int) time.Duration {
+			return time.Duration(attempt*attempt) * 30 * time.Second
+		},
+	}
+}
+
+// JobWorker pulls jobs from the queue and runs them with the handlers registered in JobService
+type JobWorker struct {
+	pool       *pgxpool.Pool
+	jobService *JobService
+	userRepo   user.Repository
+	bundle     *i18n.Bundle
+	logger     *logrus.Logger
+	opts       *JobWorkerOptions
+}
+
+func NewJobWorker(app application.Application, userRepo user.Repository, opts *JobWorkerOptions) *JobWorker {
+	if opts == nil {
+		opts = DefaultJobWorkerOptions()
+	}
+	return &JobWorker{
+		pool:       app.DB(),
+		jobService: app.Service(JobService{}).(*JobService),
+		userRepo:   userRepo,
+		bundle:     app.Bundle(),
+		logger:     configuration.Use().Logger(),
+		opts:       opts,
+	}
+}
+
+// Run processes jobs until ctx is done. Jobs interrupted by shutdown are retried later.
+func (w *JobWorker) Run(ctx context.Context) error {
+	if w.opts.Concurrency <= 0 {
+		return nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < w.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.requeueStale(ctx)
+	}()
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (w *JobWorker) loop(ctx context.Context) {
+	for {
+		processed, err := w.ProcessNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.logger.WithError(err).Error("failed to process job")
+		}
+		if processed {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.opts.PollInterval):
+		}
+	}
+}
+
+func (w *JobWorker) requeueStale(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.StaleAfter / 2)
+	defer ticker.Stop()
+	for {
+		n, err := w.jobService.repo.RequeueStale(composables.WithoutTenantIsolation(composables.WithPool(ctx, w.pool)), time.Now().Add(-w.opts.StaleAfter))
+		if err != nil && ctx.Err() == nil {
+			w.logger.WithError(err).Error("failed to requeue stale jobs")
+		} else if n > 0 {
+			w.logger.WithField("count", n).Warn("requeued stale jobs")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessNext claims and runs a single job. It reports false when the queue had no due jobs.
+func (w *JobWorker) ProcessNext(ctx context.Context) (bool, error) {
+	types := w.jobService.Types()
+	if len(types) == 0 {
+		return false, nil
+	}
+	j, err := w.jobService.repo.Claim(composables.WithoutTenantIsolation(composables.WithPool(ctx, w.pool)), types)
+	if err != nil {
+		return false, err
+	}
+	if j == nil {
+		return false, nil
+	}
+	return true, w.run(ctx, j)
+}
+
+func (w *JobWorker) run(ctx context.Context, j job.Job) error {
+	logger := w.logger.WithFields(logrus.Fields{
+		"job_id":   j.ID(),
+		"job_type": j.Type(),
+		"attempt":  j.Attempts(),
+	})
+	jobCtx, err := w.jobContext(ctx, j, logger)
+	if err != nil {
+		return w.finish(ctx, j, nil, NewPermanentJobError(err))
+	}
+	jobCtx, cancel := context.WithCancel(jobCtx)
+	defer cancel()
+
+	w.jobService.publisher.Publish(job.NewUpdatedEvent(j))
+
+	var (
+		mu       sync.Mutex
+		progress int
+		canceled bool
+	)
+	heartbeat := func(p int) {
+		status, err := w.jobService.repo.Heartbeat(jobCtx, j.ID(), p)
+		if err != nil {
+			if jobCtx.Err() == nil {
+				logger.WithError(err).Error("failed to send job heartbeat")
+			}
+			return
+		}
+		if status == job.StatusCanceled {
+			mu.Lock()
+			canceled = true
+			mu.Unlock()
+			cancel()
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(w.opts.HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+				mu.Lock()
+				p := progress
+				mu.Unlock()
+				heartbeat(p)
+			}
+		}
+	}()
+
+	handler, _ := w.jobService.Handler(j.Type())
+	result, handleErr := w.handle(jobCtx, handler, j, func(percent int) {
+		percent = min(max(percent, 0), 100)
+		mu.Lock()
+		changed := percent != progress
+		progress = percent
+		mu.Unlock()
+		if !changed {
+			return
+		}
+		heartbeat(percent)
+		w.jobService.publisher.Publish(job.NewUpdatedEvent(j.SetProgress(percent)))
+	})
+	close(done)
+
+	mu.Lock()
+	wasCanceled := canceled
+	mu.Unlock()
+	if wasCanceled {
+		logger.Info("job canceled")
+		return nil
+	}
+	if handleErr != nil && ctx.Err() != nil {
+		// The worker is shutting down, let another worker pick the job up again
+		handleErr = fmt.Errorf("interrupted: %w", ctx.Err())
+	}
+	if handleErr != nil {
+		logger.WithError(handleErr).Warn("job failed")
+	}
+	return w.finish(ctx, j, result, handleErr)
+}
+
+// handle runs the handler and turns panics into errors
+func (w *JobWorker) handle(
+	ctx context.Context,
+	handler JobHandler,
+	j job.Job,
+	progress JobProgressFunc,
+) (result *JobResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panicked: %v", r)
+		}
+	}()
+	return handler.Handle(ctx, j, progress)
+}
+
+func (w *JobWorker) finish(ctx context.Context, j job.Job, result *JobResult, handleErr error) error {
+	// The job outcome must be stored even if the worker is shutting down
+	saveCtx := composables.WithTenantID(composables.WithPool(context.WithoutCancel(ctx), w.pool), j.TenantID())
+
+	if result == nil {
+		result = &JobResult{}
+	}
+	var updated job.Job
+	switch {
+	case handleErr == nil:
+		updated = j.Complete(result.ResultUploadID)
+	case errors.As(handleErr, new(*PermanentJobError)):
+		updated = j.Fail(handleErr, result.ErrorUploadID, time.Time{})
+	default:
+		updated = j.Fail(handleErr, result.ErrorUploadID, time.Now().Add(w.opts.Backoff(j.Attempts())))
+	}
+
+	// Do not overwrite a cancellation that happened after the handler returned
+	status, err := w.jobService.repo.Heartbeat(saveCtx, j.ID(), j.Progress())
+	if err != nil {
+		return err
+	}
+	if status != job.StatusRunning {
+		return nil
+	}
+	_, err = w.jobService.save(saveCtx, updated)
+	return err
+}
+
+// jobContext builds the context a job runs in: database pool, tenant, the user who enqueued it and their locale
+func (w *JobWorker) jobContext(ctx context.Context, j job.Job, logger *logrus.Entry) (context.Context, error) {
+	ctx = context.WithValue(ctx, constants.LoggerKey, logger)
+	ctx = composables.WithPool(ctx, w.pool)
+	ctx = composables.WithTenantID(ctx, j.TenantID())
+	if j.UserID() == 0 {
+		return ctx, nil
+	}
+	u, err := w.userRepo.GetByID(ctx, j.UserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job owner: %w", err)
+	}
+	ctx = composables.WithUser(ctx, u)
+	localizer := i18n.NewLocalizer(w.bundle, string(u.UILanguage()))
+	return intl.WithLocalizer(ctx, localizer), nil
+}
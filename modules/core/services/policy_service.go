@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policy"
+	"github.com/iota-uz/iota-sdk/modules/core/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+)
+
+// PolicyService manages tenant policies and is the rbac.PolicySource of the policy engine
+type PolicyService struct {
+	repo policy.Repository
+}
+
+func NewPolicyService(repo policy.Repository) *PolicyService {
+	return &PolicyService{
+		repo: repo,
+	}
+}
+
+func (s *PolicyService) GetAll(ctx context.Context, params *policy.FindParams) ([]policy.Policy, error) {
+	if err := composables.CanUser(ctx, permissions.RoleRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAll(ctx, params)
+}
+
+func (s *PolicyService) GetByID(ctx context.Context, id uuid.UUID) (policy.Policy, error) {
+	if err := composables.CanUser(ctx, permissions.RoleRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// Policies returns the enabled policies of the current tenant for resource and action
+func (s *PolicyService) Policies(ctx context.Context, resource permission.Resource, action permission.Action) ([]rbac.Policy, error) {
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, nil
+	}
+	policies, err := s.repo.GetAll(ctx, &policy.FindParams{
+		Resource:    resource,
+		Action:      action,
+		EnabledOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]rbac.Policy, 0, len(policies))
+	for _, p := range policies {
+		compiled, err := p.Compile()
+		if err != nil {
+			return nil, fmt.Errorf("policy %q: %w", p.Name(), err)
+		}
+		result = append(result, compiled)
+	}
+	return result, nil
+}
+
+func (s *PolicyService) Create(ctx context.Context, data policy.Policy) (policy.Policy, error) {
+	if err := composables.CanUser(ctx, permissions.RoleCreate); err != nil {
+		return nil, err
+	}
+	if err := validatePolicy(data); err != nil {
+		return nil, err
+	}
+	var created policy.Policy
+	err := composables.InTx(ctx, func(txCtx context.Context) error {
+		var err error
+		created, err = s.repo.Create(txCtx, data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *PolicyService) Update(ctx context.Context, data policy.Policy) (policy.Policy, error) {
+	if err := composables.CanUser(ctx, permissions.RoleUpdate); err != nil {
+		return nil, err
+	}
+	if err := validatePolicy(data); err != nil {
+		return nil, err
+	}
+	var updated policy.Policy
+	err := composables.InTx(ctx, func(txCtx context.Context) error {
+		var err error
+		updated, err = s.repo.Update(txCtx, data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *PolicyService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := composables.CanUser(ctx, permissions.RoleDelete); err != nil {
+		return err
+	}
+	return composables.InTx(ctx, func(txCtx context.Context) error {
+		return s.repo.Delete(txCtx, id)
+	})
+}
+
+// Simulate evaluates perm for u against the tenant's policies and an optional draft policy
+// without enforcing anything, the resource and request attributes are given by the caller.
+func (s *PolicyService) Simulate(
+	ctx context.Context,
+	engine *rbac.Engine,
+	u user.User,
+	perm *permission.Permission,
+	resource map[string]any,
+	request map[string]any,
+	draft policy.Policy,
+) (rbac.Decision, error) {
+	if err := composables.CanUser(ctx, permissions.RoleRead); err != nil {
+		return rbac.Decision{}, err
+	}
+	policies, err := s.Policies(ctx, perm.Resource, perm.Action)
+	if err != nil {
+		return rbac.Decision{}, err
+	}
+	if draft != nil {
+		compiled, err := draft.Compile()
+		if err != nil {
+			return rbac.Decision{}, err
+		}
+		policies = append(policies, compiled)
+	}
+	attrs, err := engine.Attributes(ctx, u, resource, request)
+	if err != nil {
+		return rbac.Decision{}, err
+	}
+	return rbac.EvaluatePolicies(u, perm, policies, attrs), nil
+}
+
+func validatePolicy(p policy.Policy) error {
+	if !p.Effect().IsValid() {
+		return fmt.Errorf("invalid policy effect %q", p.Effect())
+	}
+	_, err := p.Compile()
+	return err
+}
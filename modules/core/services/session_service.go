@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
@@ -127,3 +128,26 @@ func (s *SessionService) DeleteByUserId(ctx context.Context, userId uint) ([]*se
 	}
 	return deletedSessions, nil
 }
+
+func (s *SessionService) DeleteByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*session.Session, error) {
+	var deletedSessions []*session.Session
+	err := composables.InTx(ctx, func(txCtx context.Context) error {
+		sessions, err := s.repo.DeleteByTenantID(txCtx, tenantID)
+		if err != nil {
+			return err
+		}
+		deletedSessions = sessions
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, sess := range deletedSessions {
+		deletedEvent, err := session.NewDeletedEvent(*sess)
+		if err != nil {
+			return nil, err
+		}
+		s.publisher.Publish(deletedEvent)
+	}
+	return deletedSessions, nil
+}
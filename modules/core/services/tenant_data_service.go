@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tenant"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/tenantdata"
+)
+
+// skippedTenantTables hold tokens that are bound to the installation and delivery logs, they are not carried over
+var skippedTenantTables = []string{
+	"sessions",
+	"user_invites",
+	"api_tokens",
+	"webhook_deliveries",
+}
+
+// uploadFileColumns are the columns holding the paths of the stored files that are put into the archive
+var uploadFileColumns = map[string]string{
+	"uploads": "path",
+}
+
+type ImportTenantParams struct {
+	// Name and Subdomain replace the ones of the exported tenant when set
+	Name      string
+	Subdomain string
+}
+
+// TenantDataService exports all rows of a tenant across the tables of the registered modules
+// into an archive and imports such archives as new tenants
+type TenantDataService struct {
+	app                 application.Application
+	repo                tenant.Repository
+	storage             upload.Storage
+	provisioningService *TenantProvisioningService
+}
+
+func NewTenantDataService(
+	app application.Application,
+	repo tenant.Repository,
+	storage upload.Storage,
+	provisioningService *TenantProvisioningService,
+) *TenantDataService {
+	return &TenantDataService{
+		app:                 app,
+		repo:                repo,
+		storage:             storage,
+		provisioningService: provisioningService,
+	}
+}
+
+// Export writes the archive of the tenant to w, the rows are read in a single transaction
+func (s *TenantDataService) Export(ctx context.Context, id uuid.UUID, w io.Writer) (*tenantdata.Manifest, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	graph, err := tenantdata.LoadGraph(ctx, s.app.Migrations().SchemaFSs(), composables.UseLogger(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var manifest *tenantdata.Manifest
+	err = composables.InTx(composables.WithTenantID(ctx, id), func(txCtx context.Context) error {
+		tx, err := composables.UseTx(txCtx)
+		if err != nil {
+			return err
+		}
+		manifest, err = tenantdata.Export(txCtx, tx, graph, id, w, tenantdata.ExportOptions{
+			FileColumns: uploadFileColumns,
+			ReadFile:    s.storage.Open,
+			SkipTables:  skippedTenantTables,
+			Logger:      composables.UseLogger(ctx),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Import creates a new tenant from an archive written by Export. The tenant keeps the name and
+// domain it was exported with unless params replace them, both have to be free in this database.
+func (s *TenantDataService) Import(
+	ctx context.Context,
+	r io.ReaderAt,
+	size int64,
+	params *ImportTenantParams,
+) (*tenant.Tenant, *tenantdata.ImportResult, error) {
+	manifest, err := tenantdata.ReadManifest(r, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := manifest.TenantName
+	if params.Name != "" {
+		name = params.Name
+	}
+	domain := manifest.Domain
+	if params.Subdomain != "" {
+		domain = s.provisioningService.Domain(params.Subdomain)
+	}
+	if err := checkTenantAvailable(ctx, s.repo, name, domain); err != nil {
+		return nil, nil, err
+	}
+	graph, err := tenantdata.LoadGraph(ctx, s.app.Migrations().SchemaFSs(), composables.UseLogger(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id := uuid.New()
+	var result *tenantdata.ImportResult
+	// The transaction is scoped to the new tenant so its rows pass the row level security policies
+	err = composables.InTx(composables.WithTenantID(ctx, id), func(txCtx context.Context) error {
+		tx, err := composables.UseTx(txCtx)
+		if err != nil {
+			return err
+		}
+		result, err = tenantdata.Import(txCtx, tx, graph, r, size, tenantdata.ImportOptions{
+			TenantID:  id,
+			Name:      name,
+			Domain:    domain,
+			WriteFile: s.storage.Save,
+			Logger:    composables.UseLogger(ctx),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	created, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return created, result, nil
+}
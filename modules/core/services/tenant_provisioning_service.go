@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tenant"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/core/seed"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+var (
+	ErrTenantSuspended    = errors.New("tenant is suspended")
+	ErrTenantNameTaken    = errors.New("tenant name is already taken")
+	ErrTenantDomainTaken  = errors.New("tenant domain is already taken")
+	ErrPlatformTenantLock = errors.New("platform tenant can not be suspended or deleted")
+)
+
+type ProvisionTenantParams struct {
+	Name       string
+	Subdomain  string
+	UILanguage user.UILanguage
+	AdminEmail internet.Email
+	FirstName  string
+	LastName   string
+}
+
+// TenantProvisioningService creates tenants together with the data of the registered
+// tenant seeds and an invited admin, and suspends or deletes them for the super admins
+type TenantProvisioningService struct {
+	app            application.Application
+	repo           tenant.Repository
+	userRepo       user.Repository
+	sessionService *SessionService
+	inviteService  *InviteService
+}
+
+func NewTenantProvisioningService(
+	app application.Application,
+	repo tenant.Repository,
+	userRepo user.Repository,
+	sessionService *SessionService,
+	inviteService *InviteService,
+) *TenantProvisioningService {
+	return &TenantProvisioningService{
+		app:            app,
+		repo:           repo,
+		userRepo:       userRepo,
+		sessionService: sessionService,
+		inviteService:  inviteService,
+	}
+}
+
+// Domain returns the host a tenant with the given subdomain is served at
+func (s *TenantProvisioningService) Domain(subdomain string) string {
+	subdomain = NormalizeHost(subdomain)
+	baseDomain := configuration.Use().Tenants.BaseDomain
+	if subdomain == "" || baseDomain == "" || strings.Contains(subdomain, ".") {
+		return subdomain
+	}
+	return subdomain + "." + NormalizeHost(baseDomain)
+}
+
+// InviteURL returns the link of an invite token, it points to the host of the tenant
+// and keeps the port of the configured domain so that it also works in development
+func (s *TenantProvisioningService) InviteURL(t *tenant.Tenant, token string) string {
+	conf := configuration.Use()
+	if t.Domain() == "" {
+		return fmt.Sprintf("%s/invite/%s", strings.TrimSuffix(conf.Origin, "/"), token)
+	}
+	host := t.Domain()
+	if _, port, err := net.SplitHostPort(conf.Domain); err == nil {
+		host = net.JoinHostPort(host, port)
+	}
+	return fmt.Sprintf("%s://%s/invite/%s", conf.Scheme(), host, token)
+}
+
+// Provision creates the tenant, runs the tenant seeds of all modules for it and invites its first admin.
+// The returned token belongs to the admin's invite link.
+func (s *TenantProvisioningService) Provision(ctx context.Context, params *ProvisionTenantParams) (*tenant.Tenant, string, error) {
+	if err := checkTenantAvailable(ctx, s.repo, params.Name, s.Domain(params.Subdomain)); err != nil {
+		return nil, "", err
+	}
+	entity := tenant.New(
+		params.Name,
+		tenant.WithDomain(s.Domain(params.Subdomain)),
+		tenant.WithUILanguage(string(params.UILanguage)),
+	)
+	// The transaction is scoped to the new tenant so its rows pass the row level security policies
+	tenantCtx := composables.WithTenantID(ctx, entity.ID())
+
+	var created *tenant.Tenant
+	var token string
+	err := composables.InTx(tenantCtx, func(txCtx context.Context) error {
+		var err error
+		created, err = s.repo.Create(txCtx, entity)
+		if err != nil {
+			return err
+		}
+		for _, seedFunc := range s.app.TenantSeeds() {
+			if err := seedFunc(txCtx, s.app); err != nil {
+				return err
+			}
+		}
+		admin := user.New(
+			params.FirstName,
+			params.LastName,
+			params.AdminEmail,
+			params.UILanguage,
+			user.WithTenantID(created.ID()),
+		)
+		if err := seed.UserSeedFunc(admin)(txCtx, s.app); err != nil {
+			return err
+		}
+		admin, err = s.userRepo.GetByEmail(txCtx, params.AdminEmail.Value())
+		if err != nil {
+			return err
+		}
+		token, err = s.inviteService.Issue(txCtx, admin.ID(), configuration.Use().Tenants.InviteTTL)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return created, token, nil
+}
+
+// Suspend deactivates the tenant and ends the sessions of its users
+func (s *TenantProvisioningService) Suspend(ctx context.Context, id uuid.UUID) (*tenant.Tenant, error) {
+	entity, err := s.unlockedTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	entity.Suspend()
+	updated, err := s.repo.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	// Scoped to the tenant so the sessions pass the row level security policies
+	if _, err := s.sessionService.DeleteByTenantID(composables.WithTenantID(ctx, id), id); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *TenantProvisioningService) Activate(ctx context.Context, id uuid.UUID) (*tenant.Tenant, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	entity.Activate()
+	return s.repo.Update(ctx, entity)
+}
+
+// Delete removes the tenant, the rows of its tables are removed by the ON DELETE CASCADE foreign keys
+func (s *TenantProvisioningService) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.unlockedTenant(ctx, id); err != nil {
+		return err
+	}
+	if _, err := s.sessionService.DeleteByTenantID(composables.WithTenantID(ctx, id), id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *TenantProvisioningService) unlockedTenant(ctx context.Context, id uuid.UUID) (*tenant.Tenant, error) {
+	if id.String() == configuration.Use().Tenants.PlatformTenantID {
+		return nil, ErrPlatformTenantLock
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// checkTenantAvailable makes sure no other tenant uses the name or the domain
+func checkTenantAvailable(ctx context.Context, repo tenant.Repository, name, domain string) error {
+	tenants, err := repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range tenants {
+		if strings.EqualFold(t.Name(), name) {
+			return ErrTenantNameTaken
+		}
+		if domain != "" && t.Domain() == domain {
+			return ErrTenantDomainTaken
+		}
+	}
+	return nil
+}
+
+// IsSuperAdmin reports whether the user may manage tenants, super admins are the users
+// of the platform tenant whose emails are listed in the configuration
+func IsSuperAdmin(u user.User) bool {
+	conf := configuration.Use()
+	if u.TenantID().String() != conf.Tenants.PlatformTenantID {
+		return false
+	}
+	for _, email := range conf.Tenants.SuperAdminEmails {
+		if strings.EqualFold(strings.TrimSpace(email), u.Email().Value()) {
+			return true
+		}
+	}
+	return false
+}
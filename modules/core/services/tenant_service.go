@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"net"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tenant"
@@ -25,6 +27,11 @@ func (s *TenantService) GetByDomain(ctx context.Context, domain string) (*tenant
 	return s.repo.GetByDomain(ctx, domain)
 }
 
+// GetByHost returns the tenant served at the host of a request, the port is ignored
+func (s *TenantService) GetByHost(ctx context.Context, host string) (*tenant.Tenant, error) {
+	return s.repo.GetByDomain(ctx, NormalizeHost(host))
+}
+
 func (s *TenantService) Create(ctx context.Context, name, domain string) (*tenant.Tenant, error) {
 	t := tenant.New(name, tenant.WithDomain(domain))
 	return s.repo.Create(ctx, t)
@@ -41,3 +48,11 @@ func (s *TenantService) Delete(ctx context.Context, id uuid.UUID) error {
 func (s *TenantService) List(ctx context.Context) ([]*tenant.Tenant, error) {
 	return s.repo.List(ctx)
 }
+
+// NormalizeHost strips the port from host and lowercases it
+func NormalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
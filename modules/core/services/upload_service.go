@@ -39,6 +39,15 @@ func (s *UploadService) GetByHash(ctx context.Context, hash string) (upload.Uplo
 	return s.repo.GetByHash(ctx, hash)
 }
 
+// Open returns the stored file contents of an upload
+func (s *UploadService) Open(ctx context.Context, id uint) ([]byte, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.Open(ctx, entity.Path())
+}
+
 func (s *UploadService) GetAll(ctx context.Context) ([]upload.Upload, error) {
 	return s.repo.GetAll(ctx)
 }
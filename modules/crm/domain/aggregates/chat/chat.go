@@ -36,7 +36,7 @@ const (
 type Provider interface {
 	Transport() Transport
 	Send(ctx context.Context, msg Message) error
-	OnReceived(callback func(msg Message) error)
+	OnReceived(callback func(ctx context.Context, msg Message) error)
 }
 
 type Chat interface {
@@ -61,6 +61,8 @@ type Message interface {
 	ChatID() uint
 	Sender() Member
 	Message() string
+	// ExternalID is the identifier the transport assigned to the message, e.g. an email Message-ID
+	ExternalID() string
 	IsRead() bool
 	MarkAsRead()
 	ReadAt() *time.Time
@@ -312,6 +312,12 @@ func WithAttachments(attachments []upload.Upload) MessageOption {
 	}
 }
 
+func WithExternalID(externalID string) MessageOption {
+	return func(m *message) {
+		m.externalID = externalID
+	}
+}
+
 func WithMessageSentAt(sentAt *time.Time) MessageOption {
 	return func(m *message) {
 		if sentAt != nil {
@@ -356,6 +362,7 @@ type message struct {
 	id          uint
 	chatID      uint
 	message     string
+	externalID  string
 	sender      Member
 	readAt      *time.Time
 	sentAt      *time.Time
@@ -363,11 +370,12 @@ type message struct {
 	createdAt   time.Time
 }
 
-func (m *message) ID() uint        { return m.id }
-func (m *message) ChatID() uint    { return m.chatID }
-func (m *message) Sender() Member  { return m.sender }
-func (m *message) Message() string { return m.message }
-func (m *message) IsRead() bool    { return m.readAt != nil }
+func (m *message) ID() uint           { return m.id }
+func (m *message) ChatID() uint       { return m.chatID }
+func (m *message) Sender() Member     { return m.sender }
+func (m *message) Message() string    { return m.message }
+func (m *message) ExternalID() string { return m.externalID }
+func (m *message) IsRead() bool       { return m.readAt != nil }
 func (m *message) MarkAsRead() {
 	if m.readAt == nil {
 		m.readAt = mapping.Pointer(time.Now())
@@ -28,6 +28,8 @@ type Repository interface {
 	GetPaginated(ctx context.Context, params *FindParams) ([]Chat, error)
 	GetByID(ctx context.Context, id uint) (Chat, error)
 	GetByClientID(ctx context.Context, clientID uint) (Chat, error)
+	// GetByExternalMessageID returns the chat holding a message with any of the given external ids
+	GetByExternalMessageID(ctx context.Context, externalIDs []string) (Chat, error)
 	GetMemberByContact(ctx context.Context, contactType string, contactValue string) (Member, error)
 	Save(ctx context.Context, data Chat) (Chat, error)
 	Delete(ctx context.Context, id uint) error
@@ -9,6 +9,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/phone"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/tax"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
 )
 
 type Option func(c *client)
@@ -105,6 +106,24 @@ func WithUpdatedAt(t time.Time) Option {
 	}
 }
 
+func WithCreatedBy(userID uint) Option {
+	return func(c *client) {
+		c.createdBy = userID
+	}
+}
+
+func WithAssigneeID(userID uint) Option {
+	return func(c *client) {
+		c.assigneeID = userID
+	}
+}
+
+func WithAssigneeGroupID(groupID uuid.UUID) Option {
+	return func(c *client) {
+		c.assigneeGroupID = groupID
+	}
+}
+
 // --- Interface ---
 
 type ContactType string
@@ -132,6 +151,10 @@ type Client interface {
 	Pin() tax.Pin
 	Comments() string
 	Contacts() []Contact
+	CreatedBy() uint
+	AssigneeID() uint
+	AssigneeGroupID() uuid.UUID
+	Owner() rbac.Owner
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
 
@@ -147,6 +170,8 @@ type Client interface {
 	SetPassport(p passport.Passport) Client
 	SetPIN(pin tax.Pin) Client
 	SetComments(comments string) Client
+	SetCreatedBy(userID uint) Client
+	Assign(userID uint, groupID uuid.UUID) Client
 }
 
 type Contact interface {
@@ -186,8 +211,12 @@ type client struct {
 	pin         tax.Pin
 	comments    string
 	contacts    []Contact
-	createdAt   time.Time
-	updatedAt   time.Time
+	createdBy   uint
+	assigneeID  uint
+	// assigneeGroupID shares the client with every member of the group
+	assigneeGroupID uuid.UUID
+	createdAt       time.Time
+	updatedAt       time.Time
 }
 
 func (c *client) ID() uint {
@@ -347,6 +376,46 @@ func (c *client) SetComments(comments string) Client {
 	return &result
 }
 
+func (c *client) CreatedBy() uint {
+	return c.createdBy
+}
+
+func (c *client) AssigneeID() uint {
+	return c.assigneeID
+}
+
+func (c *client) AssigneeGroupID() uuid.UUID {
+	return c.assigneeGroupID
+}
+
+// Owner is the creator of the client, its assignee and the members of the assigned group
+func (c *client) Owner() rbac.Owner {
+	owner := rbac.Owner{}
+	for _, id := range []uint{c.createdBy, c.assigneeID} {
+		if id != 0 {
+			owner.UserIDs = append(owner.UserIDs, id)
+		}
+	}
+	if c.assigneeGroupID != uuid.Nil {
+		owner.GroupIDs = append(owner.GroupIDs, c.assigneeGroupID)
+	}
+	return owner
+}
+
+func (c *client) SetCreatedBy(userID uint) Client {
+	result := *c
+	result.createdBy = userID
+	return &result
+}
+
+func (c *client) Assign(userID uint, groupID uuid.UUID) Client {
+	result := *c
+	result.assigneeID = userID
+	result.assigneeGroupID = groupID
+	result.updatedAt = time.Now()
+	return &result
+}
+
 // ContactOption is a function that configures a contact
 type ContactOption func(*contact)
 
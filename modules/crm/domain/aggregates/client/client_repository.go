@@ -14,6 +14,7 @@ const (
 	LastName
 	MiddleName
 	PhoneNumber
+	Email
 	CreatedAt
 	UpdatedAt
 	TenantID
@@ -0,0 +1,268 @@
+package deal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+)
+
+type Option func(d *deal)
+
+// --- Option setters ---
+
+func WithID(id uint) Option {
+	return func(d *deal) {
+		d.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(d *deal) {
+		d.tenantID = tenantID
+	}
+}
+
+func WithProbability(probability int) Option {
+	return func(d *deal) {
+		d.probability = probability
+	}
+}
+
+func WithOwnerID(ownerID uint) Option {
+	return func(d *deal) {
+		d.ownerID = ownerID
+	}
+}
+
+func WithExpectedCloseDate(date *time.Time) Option {
+	return func(d *deal) {
+		d.expectedCloseDate = date
+	}
+}
+
+func WithClosedAt(closedAt *time.Time) Option {
+	return func(d *deal) {
+		d.closedAt = closedAt
+	}
+}
+
+func WithDescription(description string) Option {
+	return func(d *deal) {
+		d.description = description
+	}
+}
+
+func WithCreatedAt(t time.Time) Option {
+	return func(d *deal) {
+		d.createdAt = t
+	}
+}
+
+func WithUpdatedAt(t time.Time) Option {
+	return func(d *deal) {
+		d.updatedAt = t
+	}
+}
+
+// --- Interface ---
+
+// Deal is a sales opportunity with a client moving through the pipeline stages
+type Deal interface {
+	ID() uint
+	TenantID() uuid.UUID
+	Title() string
+	ClientID() uint
+	Amount() *money.Money
+	StageID() uint
+	// Probability is the chance, in percent, of winning the deal
+	Probability() int
+	// WeightedAmount is the amount multiplied by the probability
+	WeightedAmount() *money.Money
+	OwnerID() uint
+	Owner() rbac.Owner
+	ExpectedCloseDate() *time.Time
+	ClosedAt() *time.Time
+	Description() string
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	SetTitle(title string) Deal
+	SetClientID(clientID uint) Deal
+	SetAmount(amount *money.Money) Deal
+	SetProbability(probability int) Deal
+	SetOwnerID(ownerID uint) Deal
+	SetExpectedCloseDate(date *time.Time) Deal
+	SetDescription(description string) Deal
+	// MoveTo puts the deal in the stage, taking over its probability and closing
+	// or reopening the deal depending on the stage outcome
+	MoveTo(stage pipelinestage.Stage) Deal
+}
+
+// --- Implementation ---
+
+func New(title string, clientID uint, amount *money.Money, stageID uint, opts ...Option) Deal {
+	d := &deal{
+		title:     title,
+		clientID:  clientID,
+		amount:    amount,
+		stageID:   stageID,
+		createdAt: time.Now(),
+		updatedAt: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+type deal struct {
+	id                uint
+	tenantID          uuid.UUID
+	title             string
+	clientID          uint
+	amount            *money.Money
+	stageID           uint
+	probability       int
+	ownerID           uint
+	expectedCloseDate *time.Time
+	closedAt          *time.Time
+	description       string
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+func (d *deal) ID() uint {
+	return d.id
+}
+
+func (d *deal) TenantID() uuid.UUID {
+	return d.tenantID
+}
+
+func (d *deal) Title() string {
+	return d.title
+}
+
+func (d *deal) ClientID() uint {
+	return d.clientID
+}
+
+func (d *deal) Amount() *money.Money {
+	return d.amount
+}
+
+func (d *deal) StageID() uint {
+	return d.stageID
+}
+
+func (d *deal) Probability() int {
+	return d.probability
+}
+
+func (d *deal) WeightedAmount() *money.Money {
+	if d.amount == nil {
+		return nil
+	}
+	return money.New(d.amount.Amount()*int64(d.probability)/100, d.amount.Currency().Code)
+}
+
+func (d *deal) OwnerID() uint {
+	return d.ownerID
+}
+
+// Owner is the sales person responsible for the deal
+func (d *deal) Owner() rbac.Owner {
+	owner := rbac.Owner{}
+	if d.ownerID != 0 {
+		owner.UserIDs = append(owner.UserIDs, d.ownerID)
+	}
+	return owner
+}
+
+func (d *deal) ExpectedCloseDate() *time.Time {
+	return d.expectedCloseDate
+}
+
+func (d *deal) ClosedAt() *time.Time {
+	return d.closedAt
+}
+
+func (d *deal) Description() string {
+	return d.description
+}
+
+func (d *deal) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *deal) UpdatedAt() time.Time {
+	return d.updatedAt
+}
+
+func (d *deal) SetTitle(title string) Deal {
+	result := *d
+	result.title = title
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (d *deal) SetClientID(clientID uint) Deal {
+	result := *d
+	result.clientID = clientID
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (d *deal) SetAmount(amount *money.Money) Deal {
+	result := *d
+	result.amount = amount
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (d *deal) SetProbability(probability int) Deal {
+	result := *d
+	result.probability = probability
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (d *deal) SetOwnerID(ownerID uint) Deal {
+	result := *d
+	result.ownerID = ownerID
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (d *deal) SetExpectedCloseDate(date *time.Time) Deal {
+	result := *d
+	result.expectedCloseDate = date
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (d *deal) SetDescription(description string) Deal {
+	result := *d
+	result.description = description
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (d *deal) MoveTo(stage pipelinestage.Stage) Deal {
+	result := *d
+	result.stageID = stage.ID()
+	result.probability = stage.Probability()
+	result.updatedAt = time.Now()
+	switch {
+	case !stage.Closed():
+		result.closedAt = nil
+	case d.closedAt == nil:
+		now := result.updatedAt
+		result.closedAt = &now
+	}
+	return &result
+}
@@ -0,0 +1,89 @@
+package deal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type SaveDTO struct {
+	Title             string  `validate:"required"`
+	ClientID          uint    `validate:"required"`
+	StageID           uint    `validate:"required"`
+	Amount            float64 `validate:"gte=0"`
+	CurrencyCode      string  `validate:"required,len=3"`
+	Probability       int     `validate:"gte=0,lte=100"`
+	OwnerID           uint
+	ExpectedCloseDate shared.DateOnly
+	Description       string
+}
+
+// MoveDTO is posted when a deal is dropped on another column of the board
+type MoveDTO struct {
+	StageID uint `validate:"required"`
+}
+
+func (d *SaveDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+
+	validationErrors := make(serrors.ValidationErrors)
+	getFieldLocaleKey := func(field string) string {
+		return fmt.Sprintf("Deals.Fields.%s.Label", field)
+	}
+
+	errs := constants.Validate.Struct(d)
+	if errs != nil {
+		for field, err := range serrors.ProcessValidatorErrors(errs.(validator.ValidationErrors), getFieldLocaleKey) {
+			validationErrors[field] = err
+		}
+	}
+
+	errorMessages := serrors.LocalizeValidationErrors(validationErrors, l)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *SaveDTO) expectedCloseDate() *time.Time {
+	date := time.Time(d.ExpectedCloseDate)
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+// ToEntity builds a new deal, the stage is applied by the service so that the
+// probability defaults to the one of the stage
+func (d *SaveDTO) ToEntity() Deal {
+	return New(
+		d.Title,
+		d.ClientID,
+		money.NewFromFloat(d.Amount, d.CurrencyCode),
+		d.StageID,
+		WithProbability(d.Probability),
+		WithOwnerID(d.OwnerID),
+		WithExpectedCloseDate(d.expectedCloseDate()),
+		WithDescription(d.Description),
+	)
+}
+
+// Apply updates everything but the stage, which changes through Deal.MoveTo
+func (d *SaveDTO) Apply(entity Deal) Deal {
+	return entity.
+		SetTitle(d.Title).
+		SetClientID(d.ClientID).
+		SetAmount(money.NewFromFloat(d.Amount, d.CurrencyCode)).
+		SetProbability(d.Probability).
+		SetOwnerID(d.OwnerID).
+		SetExpectedCloseDate(d.expectedCloseDate()).
+		SetDescription(d.Description)
+}
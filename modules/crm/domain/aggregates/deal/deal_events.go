@@ -0,0 +1,110 @@
+package deal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+func senderFromContext(ctx context.Context) (user.User, *session.Session, error) {
+	sender, ok := ctx.Value(constants.UserKey).(user.User)
+	if !ok {
+		return nil, nil, errors.New("no user found in context")
+	}
+	sess, ok := ctx.Value(constants.SessionKey).(*session.Session)
+	if !ok {
+		return nil, nil, errors.New("no session found in context")
+	}
+	return sender, sess, nil
+}
+
+// NewCreatedEvent creates a new CreatedEvent for a deal.
+func NewCreatedEvent(ctx context.Context, data Deal) (*CreatedEvent, error) {
+	sender, sess, err := senderFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Data:    data,
+	}, nil
+}
+
+// NewUpdatedEvent creates a new UpdatedEvent for a deal.
+func NewUpdatedEvent(ctx context.Context, data Deal) (*UpdatedEvent, error) {
+	sender, sess, err := senderFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &UpdatedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Data:    data,
+	}, nil
+}
+
+// NewDeletedEvent creates a new DeletedEvent for a deal.
+func NewDeletedEvent(ctx context.Context, data Deal) (*DeletedEvent, error) {
+	sender, sess, err := senderFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &DeletedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Data:    data,
+	}, nil
+}
+
+// NewStageChangedEvent creates a new StageChangedEvent for a deal moved out of fromStageID.
+func NewStageChangedEvent(ctx context.Context, data Deal, fromStageID uint) (*StageChangedEvent, error) {
+	sender, sess, err := senderFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &StageChangedEvent{
+		Sender:      sender,
+		Session:     *sess,
+		Data:        data,
+		FromStageID: fromStageID,
+		ToStageID:   data.StageID(),
+	}, nil
+}
+
+// CreatedEvent represents the event of a deal being created.
+type CreatedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Data    Deal
+	Result  Deal
+}
+
+// UpdatedEvent represents the event of a deal being updated.
+type UpdatedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Data    Deal
+	Result  Deal
+}
+
+// DeletedEvent represents the event of a deal being deleted.
+type DeletedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Data    Deal
+	Result  Deal
+}
+
+// StageChangedEvent represents the event of a deal being moved to another pipeline stage.
+type StageChangedEvent struct {
+	Sender      user.User
+	Session     session.Session
+	Data        Deal
+	Result      Deal
+	FromStageID uint
+	ToStageID   uint
+}
@@ -0,0 +1,40 @@
+package deal
+
+import (
+	"context"
+	"time"
+)
+
+// StageChange is an entry of the stage history of a deal
+type StageChange struct {
+	DealID      uint
+	FromStageID uint // 0 when the deal was created in ToStageID
+	ToStageID   uint
+	ChangedBy   uint
+	ChangedAt   time.Time
+}
+
+type FindParams struct {
+	Limit    int
+	Offset   int
+	Search   string
+	StageID  uint
+	ClientID uint
+	OwnerID  uint
+	// CreatedFrom and CreatedTo restrict the result to deals created within the range
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]Deal, error)
+	GetByID(ctx context.Context, id uint) (Deal, error)
+	Create(ctx context.Context, data Deal) (Deal, error)
+	Update(ctx context.Context, data Deal) error
+	Delete(ctx context.Context, id uint) error
+
+	RecordStageChange(ctx context.Context, change StageChange) error
+	// GetStageChanges returns the history of the given deals, oldest first
+	GetStageChanges(ctx context.Context, dealIDs []uint) ([]StageChange, error)
+}
@@ -0,0 +1,156 @@
+package deal
+
+import (
+	"math"
+	"sort"
+
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+// FunnelStage is the share of the deals that made it to an open stage
+type FunnelStage struct {
+	Stage pipelinestage.Stage
+	// Current is the number of deals sitting in the stage right now
+	Current int
+	// Reached is the number of deals that got to the stage or further
+	Reached int
+	// Conversion is Reached as a percentage of all deals
+	Conversion float64
+	// Amounts is the value of the deals sitting in the stage, one entry per currency
+	Amounts []*money.Money
+}
+
+// FunnelReport is the win/loss funnel of a set of deals
+type FunnelReport struct {
+	Stages []FunnelStage
+	Total  int
+	Won    int
+	Lost   int
+	// WinRate is the percentage of the closed deals that were won
+	WinRate    float64
+	WonAmounts []*money.Money
+	// Pipeline is the value of the open deals and Weighted the same value
+	// multiplied by the probability of each deal
+	Pipeline []*money.Money
+	Weighted []*money.Money
+}
+
+// Funnel computes the funnel of deals over the pipeline stages. A deal reaches
+// every open stage up to the furthest one it has been in according to the
+// changes, won deals reach all open stages.
+func Funnel(stages []pipelinestage.Stage, deals []Deal, changes []StageChange) FunnelReport {
+	byID := make(map[uint]pipelinestage.Stage, len(stages))
+	var open []pipelinestage.Stage
+	for _, s := range stages {
+		byID[s.ID()] = s
+		if !s.Closed() {
+			open = append(open, s)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		return open[i].Position() < open[j].Position()
+	})
+
+	visited := make(map[uint][]uint, len(deals))
+	for _, c := range changes {
+		visited[c.DealID] = append(visited[c.DealID], c.FromStageID, c.ToStageID)
+	}
+
+	report := FunnelReport{
+		Stages: make([]FunnelStage, len(open)),
+		Total:  len(deals),
+	}
+	stageIndex := make(map[uint]int, len(open))
+	stageAmounts := make([]amounts, len(open))
+	for i, s := range open {
+		report.Stages[i] = FunnelStage{Stage: s}
+		stageIndex[s.ID()] = i
+		stageAmounts[i] = amounts{}
+	}
+	won, pipeline, weighted := amounts{}, amounts{}, amounts{}
+
+	for _, d := range deals {
+		furthest := math.MinInt
+		stage, ok := byID[d.StageID()]
+		switch {
+		case !ok:
+		case stage.Outcome() == pipelinestage.OutcomeWon:
+			report.Won++
+			won.add(d.Amount())
+			furthest = math.MaxInt
+		case stage.Outcome() == pipelinestage.OutcomeLost:
+			report.Lost++
+		default:
+			i := stageIndex[stage.ID()]
+			report.Stages[i].Current++
+			stageAmounts[i].add(d.Amount())
+			pipeline.add(d.Amount())
+			weighted.add(d.WeightedAmount())
+			furthest = stage.Position()
+		}
+		for _, id := range visited[d.ID()] {
+			if s, ok := byID[id]; ok && !s.Closed() && s.Position() > furthest {
+				furthest = s.Position()
+			}
+		}
+		for i, s := range open {
+			if s.Position() <= furthest {
+				report.Stages[i].Reached++
+			}
+		}
+	}
+
+	for i := range report.Stages {
+		report.Stages[i].Amounts = stageAmounts[i].list()
+		if report.Total > 0 {
+			report.Stages[i].Conversion = percent(report.Stages[i].Reached, report.Total)
+		}
+	}
+	if closed := report.Won + report.Lost; closed > 0 {
+		report.WinRate = percent(report.Won, closed)
+	}
+	report.WonAmounts = won.list()
+	report.Pipeline = pipeline.list()
+	report.Weighted = weighted.list()
+	return report
+}
+
+func percent(part, total int) float64 {
+	return math.Round(float64(part)*1000/float64(total)) / 10
+}
+
+// Totals sums the amounts of the deals, one entry per currency
+func Totals(deals []Deal) []*money.Money {
+	sums := amounts{}
+	for _, d := range deals {
+		sums.add(d.Amount())
+	}
+	return sums.list()
+}
+
+// amounts sums money per currency
+type amounts map[string]*money.Money
+
+func (a amounts) add(m *money.Money) {
+	if m == nil {
+		return
+	}
+	code := m.Currency().Code
+	if sum, ok := a[code]; ok {
+		a[code] = money.New(sum.Amount()+m.Amount(), code)
+		return
+	}
+	a[code] = m
+}
+
+func (a amounts) list() []*money.Money {
+	result := make([]*money.Money, 0, len(a))
+	for _, m := range a {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Currency().Code < result[j].Currency().Code
+	})
+	return result
+}
@@ -0,0 +1,97 @@
+package deal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func stages() []pipelinestage.Stage {
+	return []pipelinestage.Stage{
+		pipelinestage.New("Won", pipelinestage.WithID(4), pipelinestage.WithPosition(4), pipelinestage.WithProbability(100), pipelinestage.WithOutcome(pipelinestage.OutcomeWon)),
+		pipelinestage.New("Lead", pipelinestage.WithID(1), pipelinestage.WithPosition(1), pipelinestage.WithProbability(10)),
+		pipelinestage.New("Proposal", pipelinestage.WithID(2), pipelinestage.WithPosition(2), pipelinestage.WithProbability(50)),
+		pipelinestage.New("Negotiation", pipelinestage.WithID(3), pipelinestage.WithPosition(3), pipelinestage.WithProbability(80)),
+		pipelinestage.New("Lost", pipelinestage.WithID(5), pipelinestage.WithPosition(5), pipelinestage.WithOutcome(pipelinestage.OutcomeLost)),
+	}
+}
+
+func TestMoveTo(t *testing.T) {
+	all := stages()
+	d := deal.New("Licenses", 1, money.New(100000, "USD"), 1, deal.WithProbability(10))
+
+	d = d.MoveTo(all[2])
+	assert.Equal(t, uint(2), d.StageID())
+	assert.Equal(t, 50, d.Probability())
+	assert.Equal(t, int64(50000), d.WeightedAmount().Amount())
+	assert.Nil(t, d.ClosedAt())
+
+	d = d.MoveTo(all[0])
+	require.NotNil(t, d.ClosedAt())
+	closedAt := *d.ClosedAt()
+	assert.Equal(t, 100, d.Probability())
+
+	d = d.MoveTo(all[4])
+	require.NotNil(t, d.ClosedAt())
+	assert.Equal(t, closedAt, *d.ClosedAt(), "moving between closed stages keeps the closing date")
+
+	d = d.MoveTo(all[1])
+	assert.Nil(t, d.ClosedAt(), "moving back to an open stage reopens the deal")
+}
+
+func TestFunnel(t *testing.T) {
+	deals := []deal.Deal{
+		deal.New("A", 1, money.New(1000, "USD"), 1, deal.WithID(1), deal.WithProbability(10)),
+		deal.New("B", 1, money.New(2000, "USD"), 3, deal.WithID(2), deal.WithProbability(80)),
+		deal.New("C", 1, money.New(5000, "UZS"), 4, deal.WithID(3), deal.WithProbability(100)),
+		deal.New("D", 1, money.New(3000, "USD"), 5, deal.WithID(4)),
+	}
+	changes := []deal.StageChange{
+		{DealID: 2, FromStageID: 0, ToStageID: 1},
+		{DealID: 2, FromStageID: 1, ToStageID: 3},
+		{DealID: 4, FromStageID: 1, ToStageID: 2},
+		{DealID: 4, FromStageID: 2, ToStageID: 5},
+	}
+
+	report := deal.Funnel(stages(), deals, changes)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 1, report.Won)
+	assert.Equal(t, 1, report.Lost)
+	assert.InDelta(t, 50.0, report.WinRate, 0.001)
+
+	require.Len(t, report.Stages, 3)
+	names := make([]string, 0, len(report.Stages))
+	reached := make([]int, 0, len(report.Stages))
+	current := make([]int, 0, len(report.Stages))
+	for _, s := range report.Stages {
+		names = append(names, s.Stage.Name())
+		reached = append(reached, s.Reached)
+		current = append(current, s.Current)
+	}
+	assert.Equal(t, []string{"Lead", "Proposal", "Negotiation"}, names)
+	assert.Equal(t, []int{4, 3, 2}, reached)
+	assert.Equal(t, []int{1, 0, 1}, current)
+	assert.InDelta(t, 75.0, report.Stages[1].Conversion, 0.001)
+
+	require.Len(t, report.Pipeline, 1)
+	assert.Equal(t, int64(3000), report.Pipeline[0].Amount())
+	require.Len(t, report.Weighted, 1)
+	assert.Equal(t, int64(1700), report.Weighted[0].Amount())
+	require.Len(t, report.WonAmounts, 1)
+	assert.Equal(t, "UZS", report.WonAmounts[0].Currency().Code)
+}
+
+func TestFunnel_Empty(t *testing.T) {
+	report := deal.Funnel(stages(), nil, nil)
+	assert.Equal(t, 0, report.Total)
+	assert.Zero(t, report.WinRate)
+	for _, s := range report.Stages {
+		assert.Zero(t, s.Conversion)
+	}
+	assert.Empty(t, report.Pipeline)
+}
@@ -0,0 +1,173 @@
+package clientduplicate
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reason tells which data made two clients look like the same person
+type Reason string
+
+const (
+	ReasonPhone       Reason = "phone"
+	ReasonEmail       Reason = "email"
+	ReasonPassport    Reason = "passport"
+	ReasonPin         Reason = "pin"
+	ReasonName        Reason = "name"
+	ReasonDateOfBirth Reason = "date_of_birth"
+)
+
+// Status of a candidate pair in the review queue
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusDismissed Status = "dismissed"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusDismissed:
+		return true
+	}
+	return false
+}
+
+type Option func(d *duplicate)
+
+func WithID(id uint) Option {
+	return func(d *duplicate) {
+		d.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(d *duplicate) {
+		d.tenantID = tenantID
+	}
+}
+
+func WithStatus(status Status) Option {
+	return func(d *duplicate) {
+		d.status = status
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(d *duplicate) {
+		d.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(d *duplicate) {
+		d.updatedAt = updatedAt
+	}
+}
+
+// Duplicate is a pair of clients that probably describe the same person and wait for a review
+type Duplicate interface {
+	ID() uint
+	TenantID() uuid.UUID
+	// ClientID is the smaller id of the pair
+	ClientID() uint
+	DuplicateID() uint
+	// Score is the confidence, from 0 to 100, that both clients are the same person
+	Score() int
+	Reasons() []Reason
+	Status() Status
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// Includes tells whether the client is one of the pair
+	Includes(clientID uint) bool
+	// Other returns the id of the second client of the pair
+	Other(clientID uint) uint
+	Dismiss() Duplicate
+}
+
+func New(clientID, duplicateID uint, score int, reasons []Reason, opts ...Option) Duplicate {
+	if clientID > duplicateID {
+		clientID, duplicateID = duplicateID, clientID
+	}
+	d := &duplicate{
+		clientID:    clientID,
+		duplicateID: duplicateID,
+		score:       score,
+		reasons:     reasons,
+		status:      StatusPending,
+		createdAt:   time.Now(),
+		updatedAt:   time.Now(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+type duplicate struct {
+	id          uint
+	tenantID    uuid.UUID
+	clientID    uint
+	duplicateID uint
+	score       int
+	reasons     []Reason
+	status      Status
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func (d *duplicate) ID() uint {
+	return d.id
+}
+
+func (d *duplicate) TenantID() uuid.UUID {
+	return d.tenantID
+}
+
+func (d *duplicate) ClientID() uint {
+	return d.clientID
+}
+
+func (d *duplicate) DuplicateID() uint {
+	return d.duplicateID
+}
+
+func (d *duplicate) Score() int {
+	return d.score
+}
+
+func (d *duplicate) Reasons() []Reason {
+	return d.reasons
+}
+
+func (d *duplicate) Status() Status {
+	return d.status
+}
+
+func (d *duplicate) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *duplicate) UpdatedAt() time.Time {
+	return d.updatedAt
+}
+
+func (d *duplicate) Includes(clientID uint) bool {
+	return d.clientID == clientID || d.duplicateID == clientID
+}
+
+func (d *duplicate) Other(clientID uint) uint {
+	if d.clientID == clientID {
+		return d.duplicateID
+	}
+	return d.clientID
+}
+
+func (d *duplicate) Dismiss() Duplicate {
+	result := *d
+	result.status = StatusDismissed
+	result.updatedAt = time.Now()
+	return &result
+}
@@ -0,0 +1,6 @@
+package clientduplicate
+
+// MergeDTO is posted when a reviewer picks the client that survives the merge
+type MergeDTO struct {
+	SurvivorID uint `validate:"required"`
+}
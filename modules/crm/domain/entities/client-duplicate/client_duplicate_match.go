@@ -0,0 +1,262 @@
+package clientduplicate
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/phone"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+)
+
+const (
+	// Threshold is the score from which a pair is queued for a review
+	Threshold = 40
+
+	phoneWeight       = 40
+	emailWeight       = 40
+	passportWeight    = 60
+	pinWeight         = 60
+	nameWeight        = 30
+	dateOfBirthWeight = 20
+
+	// nameSimilarity is the lowest similarity ratio at which two full names count as the same
+	nameSimilarity = 0.85
+	// phoneSuffixLen is enough to tell numbers apart within a country while ignoring
+	// the different ways the country and trunk codes get typed
+	phoneSuffixLen = 9
+	minPhoneLen    = 7
+	// maxBlockSize skips blocking keys shared by too many clients, e.g. a popular first name,
+	// since comparing every pair inside such a block gets quadratic
+	maxBlockSize = 200
+)
+
+// Match is the result of comparing two clients
+type Match struct {
+	Score   int
+	Reasons []Reason
+}
+
+func (m Match) IsCandidate() bool {
+	return m.Score >= Threshold
+}
+
+func (m *Match) add(reason Reason, weight int) {
+	m.Reasons = append(m.Reasons, reason)
+	m.Score = min(m.Score+weight, 100)
+}
+
+// Compare scores how likely it is that a and b describe the same person
+func Compare(a, b client.Client) Match {
+	var m Match
+	if intersects(phones(a), phones(b)) {
+		m.add(ReasonPhone, phoneWeight)
+	}
+	if intersects(emails(a), emails(b)) {
+		m.add(ReasonEmail, emailWeight)
+	}
+	if id := passportID(a); id != "" && id == passportID(b) {
+		m.add(ReasonPassport, passportWeight)
+	}
+	if p := pin(a); p != "" && p == pin(b) {
+		m.add(ReasonPin, pinWeight)
+	}
+	if na, nb := NormalizeName(a), NormalizeName(b); na != "" && nb != "" && Similarity(na, nb) >= nameSimilarity {
+		m.add(ReasonName, nameWeight)
+	}
+	if da, db := a.DateOfBirth(), b.DateOfBirth(); da != nil && db != nil && da.Format("2006-01-02") == db.Format("2006-01-02") {
+		m.add(ReasonDateOfBirth, dateOfBirthWeight)
+	}
+	return m
+}
+
+// FindCandidates returns the pairs of clients that reach the Threshold.
+// Only clients sharing a blocking key are compared, so the scan stays close to linear.
+func FindCandidates(clients []client.Client) []Duplicate {
+	blocks := make(map[string][]int)
+	for i, c := range clients {
+		for _, key := range blockingKeys(c) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+	keys := make([]string, 0, len(blocks))
+	for key := range blocks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seen := make(map[[2]int]bool)
+	result := make([]Duplicate, 0)
+	for _, key := range keys {
+		block := blocks[key]
+		if len(block) > maxBlockSize {
+			continue
+		}
+		for i := 0; i < len(block); i++ {
+			for j := i + 1; j < len(block); j++ {
+				pair := [2]int{block[i], block[j]}
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+				a, b := clients[pair[0]], clients[pair[1]]
+				if m := Compare(a, b); m.IsCandidate() {
+					result = append(result, New(a.ID(), b.ID(), m.Score, m.Reasons, WithTenantID(a.TenantID())))
+				}
+			}
+		}
+	}
+	return result
+}
+
+// FindCandidatesFor returns the pairs the client forms with the others
+func FindCandidatesFor(target client.Client, others []client.Client) []Duplicate {
+	result := make([]Duplicate, 0)
+	for _, other := range others {
+		if other.ID() == target.ID() {
+			continue
+		}
+		if m := Compare(target, other); m.IsCandidate() {
+			result = append(result, New(target.ID(), other.ID(), m.Score, m.Reasons, WithTenantID(target.TenantID())))
+		}
+	}
+	return result
+}
+
+// NormalizePhone keeps the digits a number is recognized by, or returns "" when there are too few of them
+func NormalizePhone(v string) string {
+	digits := strings.TrimPrefix(phone.Strip(v), "00")
+	if len(digits) < minPhoneLen {
+		return ""
+	}
+	if len(digits) > phoneSuffixLen {
+		return digits[len(digits)-phoneSuffixLen:]
+	}
+	return digits
+}
+
+func NormalizeEmail(v string) string {
+	return strings.ToLower(strings.TrimSpace(v))
+}
+
+// NormalizeName lowercases the first and last name and sorts their words, so that swapped names still match
+func NormalizeName(c client.Client) string {
+	words := strings.FieldsFunc(
+		strings.ToLower(c.FirstName()+" "+c.LastName()),
+		func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) },
+	)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// Similarity is 1 minus the Levenshtein distance between a and b relative to the longer one
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func blockingKeys(c client.Client) []string {
+	keys := make([]string, 0)
+	for _, p := range phones(c) {
+		keys = append(keys, "phone:"+p)
+	}
+	for _, e := range emails(c) {
+		keys = append(keys, "email:"+e)
+	}
+	if id := passportID(c); id != "" {
+		keys = append(keys, "passport:"+id)
+	}
+	if p := pin(c); p != "" {
+		keys = append(keys, "pin:"+p)
+	}
+	// A typo rarely hits the start of every word, so sharing a prefix of any word is enough to compare names
+	for _, word := range strings.Fields(NormalizeName(c)) {
+		runes := []rune(word)
+		if len(runes) < 2 {
+			continue
+		}
+		keys = append(keys, "name:"+string(runes[:min(len(runes), 3)]))
+	}
+	return keys
+}
+
+func phones(c client.Client) []string {
+	result := make([]string, 0)
+	if c.Phone() != nil {
+		result = appendUnique(result, NormalizePhone(c.Phone().Value()))
+	}
+	for _, contact := range c.Contacts() {
+		if contact.Type() == client.ContactTypePhone || contact.Type() == client.ContactTypeWhatsApp {
+			result = appendUnique(result, NormalizePhone(contact.Value()))
+		}
+	}
+	return result
+}
+
+func emails(c client.Client) []string {
+	result := make([]string, 0)
+	if c.Email() != nil {
+		result = appendUnique(result, NormalizeEmail(c.Email().Value()))
+	}
+	for _, contact := range c.Contacts() {
+		if contact.Type() == client.ContactTypeEmail {
+			result = appendUnique(result, NormalizeEmail(contact.Value()))
+		}
+	}
+	return result
+}
+
+func passportID(c client.Client) string {
+	if c.Passport() == nil {
+		return ""
+	}
+	return strings.ToUpper(strings.Join(strings.Fields(c.Passport().Identifier()), ""))
+}
+
+func pin(c client.Client) string {
+	if c.Pin() == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.Pin().Value())
+}
+
+func appendUnique(values []string, v string) []string {
+	if v == "" || slices.Contains(values, v) {
+		return values
+	}
+	return append(values, v)
+}
+
+func intersects(a, b []string) bool {
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package clientduplicate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/phone"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	clientduplicate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/client-duplicate"
+)
+
+func newClient(t *testing.T, id uint, firstName, lastName string, opts ...client.Option) client.Client {
+	t.Helper()
+	c, err := client.New(firstName, append([]client.Option{client.WithID(id), client.WithLastName(lastName)}, opts...)...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNormalizePhone(t *testing.T) {
+	assert.Equal(t, "901234567", clientduplicate.NormalizePhone("+998 (90) 123-45-67"))
+	assert.Equal(t, "901234567", clientduplicate.NormalizePhone("00998901234567"))
+	assert.Equal(t, "901234567", clientduplicate.NormalizePhone("90 123 45 67"))
+	assert.Empty(t, clientduplicate.NormalizePhone("12-34"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, clientduplicate.Similarity("doe john", "doe john"), 0.001)
+	assert.InDelta(t, 0.875, clientduplicate.Similarity("doe john", "doe jonn"), 0.001)
+	assert.Less(t, clientduplicate.Similarity("doe john", "smith jane"), 0.5)
+}
+
+func TestCompare(t *testing.T) {
+	p1, err := phone.NewFromE164("+998901234567")
+	require.NoError(t, err)
+	dob := time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	a := newClient(t, 1, "John", "Doe", client.WithPhone(p1), client.WithDateOfBirth(&dob))
+	b := newClient(t, 2, "Doe", "Jonn",
+		client.WithEmail(internet.MustParseEmail("JOHN@example.com")),
+		client.WithContacts([]client.Contact{client.NewContact(client.ContactTypePhone, "90 123 45 67")}),
+	)
+	m := clientduplicate.Compare(a, b)
+	assert.True(t, m.IsCandidate())
+	assert.Equal(t, []clientduplicate.Reason{clientduplicate.ReasonPhone, clientduplicate.ReasonName}, m.Reasons)
+	assert.Equal(t, 70, m.Score)
+
+	c := newClient(t, 3, "Johnny", "Doe", client.WithContacts([]client.Contact{
+		client.NewContact(client.ContactTypeEmail, " john@example.com"),
+	}))
+	m = clientduplicate.Compare(b, c)
+	assert.Equal(t, []clientduplicate.Reason{clientduplicate.ReasonEmail}, m.Reasons)
+	assert.True(t, m.IsCandidate())
+
+	d := newClient(t, 4, "John", "Doe")
+	m = clientduplicate.Compare(a, d)
+	assert.Equal(t, []clientduplicate.Reason{clientduplicate.ReasonName}, m.Reasons)
+	assert.False(t, m.IsCandidate(), "a common name alone is not enough")
+
+	m = clientduplicate.Compare(a, newClient(t, 5, "John", "Doe", client.WithDateOfBirth(&dob)))
+	assert.True(t, m.IsCandidate(), "name and date of birth are")
+}
+
+func TestFindCandidates(t *testing.T) {
+	p1, err := phone.NewFromE164("+998901234567")
+	require.NoError(t, err)
+	clients := []client.Client{
+		newClient(t, 7, "Jane", "Smith", client.WithPhone(p1)),
+		newClient(t, 3, "Bob", "Brown"),
+		newClient(t, 5, "Janet", "Smith", client.WithContacts([]client.Contact{
+			client.NewContact(client.ContactTypeWhatsApp, "+998 90 123 45 67"),
+		})),
+	}
+
+	found := clientduplicate.FindCandidates(clients)
+	require.Len(t, found, 1)
+	assert.Equal(t, uint(5), found[0].ClientID(), "the pair is ordered by id")
+	assert.Equal(t, uint(7), found[0].DuplicateID())
+	assert.Equal(t, clientduplicate.StatusPending, found[0].Status())
+	assert.Equal(t, []clientduplicate.Reason{clientduplicate.ReasonPhone, clientduplicate.ReasonName}, found[0].Reasons())
+
+	found = clientduplicate.FindCandidatesFor(clients[1], clients)
+	assert.Empty(t, found)
+}
+
+func TestAbsorb(t *testing.T) {
+	p1, err := phone.NewFromE164("+998901234567")
+	require.NoError(t, err)
+	survivor := newClient(t, 1, "John", "", client.WithComments("VIP"))
+	merged := newClient(t, 2, "Johnny", "Doe", client.WithPhone(p1), client.WithComments("Prefers calls"))
+
+	result := clientduplicate.Absorb(survivor, merged)
+	assert.Equal(t, "John", result.FirstName())
+	assert.Equal(t, "Doe", result.LastName())
+	assert.Equal(t, "998901234567", result.Phone().Value())
+	assert.Equal(t, "VIP\n\nPrefers calls", result.Comments())
+
+	snapshot := clientduplicate.Snapshot(merged)
+	assert.Equal(t, "Johnny", snapshot["first_name"])
+	assert.Equal(t, "998901234567", snapshot["phone"])
+	assert.NotContains(t, snapshot, "address")
+}
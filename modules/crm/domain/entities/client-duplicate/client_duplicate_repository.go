@@ -0,0 +1,26 @@
+package clientduplicate
+
+import "context"
+
+type FindParams struct {
+	Limit  int
+	Offset int
+	Status Status
+	// ClientID narrows the pairs down to the ones the client belongs to
+	ClientID uint
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	// GetPaginated returns the pairs with the highest score first
+	GetPaginated(ctx context.Context, params *FindParams) ([]Duplicate, error)
+	GetByID(ctx context.Context, id uint) (Duplicate, error)
+	// Enqueue adds the pair to the review queue. A pair that is already queued gets the new score
+	// while a dismissed one stays dismissed.
+	Enqueue(ctx context.Context, data Duplicate) error
+	Update(ctx context.Context, data Duplicate) error
+	// Merge moves the contacts, chat, messages and deals of the merged client onto the survivor
+	// and stores the record with the moved counts. The merged client itself is left for deletion.
+	Merge(ctx context.Context, record MergeRecord) (MergeRecord, error)
+	GetMerges(ctx context.Context, survivorID uint) ([]MergeRecord, error)
+}
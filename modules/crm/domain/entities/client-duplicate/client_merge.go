@@ -0,0 +1,116 @@
+package clientduplicate
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+)
+
+// MergeRecord is the audit entry of a merge. The merged client is gone afterwards,
+// so its data is kept as a snapshot.
+type MergeRecord struct {
+	ID            uint
+	TenantID      uuid.UUID
+	SurvivorID    uint
+	MergedID      uint
+	MergedClient  map[string]string
+	MovedContacts int
+	MovedMessages int
+	MovedDeals    int
+	MergedBy      uint
+	MergedAt      time.Time
+}
+
+// Absorb fills the fields the survivor lacks with the ones of the merged client
+func Absorb(survivor, merged client.Client) client.Client {
+	result := survivor
+	lastName, middleName := survivor.LastName(), survivor.MiddleName()
+	if lastName == "" {
+		lastName = merged.LastName()
+	}
+	if middleName == "" {
+		middleName = merged.MiddleName()
+	}
+	if lastName != survivor.LastName() || middleName != survivor.MiddleName() {
+		result = result.SetName(survivor.FirstName(), lastName, middleName)
+	}
+	if (survivor.Phone() == nil || survivor.Phone().Value() == "") && merged.Phone() != nil {
+		result = result.SetPhone(merged.Phone())
+	}
+	if survivor.Email() == nil && merged.Email() != nil {
+		result = result.SetEmail(merged.Email())
+	}
+	if survivor.Address() == "" && merged.Address() != "" {
+		result = result.SetAddress(merged.Address())
+	}
+	if survivor.DateOfBirth() == nil && merged.DateOfBirth() != nil {
+		result = result.SetDateOfBirth(merged.DateOfBirth())
+	}
+	if (survivor.Gender() == nil || survivor.Gender().String() == "") && merged.Gender() != nil {
+		result = result.SetGender(merged.Gender())
+	}
+	if survivor.Passport() == nil && merged.Passport() != nil {
+		result = result.SetPassport(merged.Passport())
+	}
+	if (survivor.Pin() == nil || survivor.Pin().Value() == "") && merged.Pin() != nil {
+		result = result.SetPIN(merged.Pin())
+	}
+	if merged.Comments() != "" && merged.Comments() != survivor.Comments() {
+		comments := merged.Comments()
+		if survivor.Comments() != "" {
+			comments = survivor.Comments() + "\n\n" + comments
+		}
+		result = result.SetComments(comments)
+	}
+	if survivor.AssigneeID() == 0 && survivor.AssigneeGroupID() == uuid.Nil {
+		if merged.AssigneeID() != 0 || merged.AssigneeGroupID() != uuid.Nil {
+			result = result.Assign(merged.AssigneeID(), merged.AssigneeGroupID())
+		}
+	}
+	return result
+}
+
+// Snapshot flattens the data of a client for the merge record
+func Snapshot(c client.Client) map[string]string {
+	s := map[string]string{
+		"first_name":  c.FirstName(),
+		"last_name":   c.LastName(),
+		"middle_name": c.MiddleName(),
+		"address":     c.Address(),
+		"comments":    c.Comments(),
+		"created_at":  c.CreatedAt().Format(time.RFC3339),
+	}
+	if c.Phone() != nil {
+		s["phone"] = c.Phone().Value()
+	}
+	if c.Email() != nil {
+		s["email"] = c.Email().Value()
+	}
+	if c.DateOfBirth() != nil {
+		s["date_of_birth"] = c.DateOfBirth().Format(time.DateOnly)
+	}
+	if c.Gender() != nil {
+		s["gender"] = c.Gender().String()
+	}
+	if c.Passport() != nil {
+		s["passport"] = c.Passport().Identifier()
+	}
+	if c.Pin() != nil {
+		s["pin"] = c.Pin().Value()
+	}
+	for _, contact := range c.Contacts() {
+		key := "contact_" + string(contact.Type())
+		if s[key] != "" {
+			s[key] += ", "
+		}
+		s[key] += contact.Value()
+	}
+	for k, v := range s {
+		if v == "" {
+			delete(s, k)
+		}
+	}
+	return s
+}
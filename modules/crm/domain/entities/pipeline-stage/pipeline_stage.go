@@ -0,0 +1,167 @@
+package pipelinestage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcome tells whether a deal in the stage is still in progress or closed
+type Outcome string
+
+const (
+	OutcomeOpen Outcome = "open"
+	OutcomeWon  Outcome = "won"
+	OutcomeLost Outcome = "lost"
+)
+
+func (o Outcome) IsValid() bool {
+	switch o {
+	case OutcomeOpen, OutcomeWon, OutcomeLost:
+		return true
+	}
+	return false
+}
+
+type Option func(s *stage)
+
+func WithID(id uint) Option {
+	return func(s *stage) {
+		s.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(s *stage) {
+		s.tenantID = tenantID
+	}
+}
+
+func WithPosition(position int) Option {
+	return func(s *stage) {
+		s.position = position
+	}
+}
+
+func WithProbability(probability int) Option {
+	return func(s *stage) {
+		s.probability = probability
+	}
+}
+
+func WithOutcome(outcome Outcome) Option {
+	return func(s *stage) {
+		s.outcome = outcome
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(s *stage) {
+		s.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(s *stage) {
+		s.updatedAt = updatedAt
+	}
+}
+
+// Stage is a column of the sales pipeline
+type Stage interface {
+	ID() uint
+	TenantID() uuid.UUID
+	Name() string
+	// Position orders the stages from the first contact to the closing
+	Position() int
+	// Probability is the default chance, in percent, of winning a deal that enters the stage
+	Probability() int
+	Outcome() Outcome
+	Closed() bool
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	Update(name string, position, probability int, outcome Outcome) Stage
+}
+
+func New(name string, opts ...Option) Stage {
+	s := &stage{
+		name:      name,
+		outcome:   OutcomeOpen,
+		createdAt: time.Now(),
+		updatedAt: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+type stage struct {
+	id          uint
+	tenantID    uuid.UUID
+	name        string
+	position    int
+	probability int
+	outcome     Outcome
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func (s *stage) ID() uint {
+	return s.id
+}
+
+func (s *stage) TenantID() uuid.UUID {
+	return s.tenantID
+}
+
+func (s *stage) Name() string {
+	return s.name
+}
+
+func (s *stage) Position() int {
+	return s.position
+}
+
+func (s *stage) Probability() int {
+	return s.probability
+}
+
+func (s *stage) Outcome() Outcome {
+	return s.outcome
+}
+
+func (s *stage) Closed() bool {
+	return s.outcome != OutcomeOpen
+}
+
+func (s *stage) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *stage) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+func (s *stage) Update(name string, position, probability int, outcome Outcome) Stage {
+	result := *s
+	result.name = name
+	result.position = position
+	result.probability = probability
+	result.outcome = outcome
+	result.updatedAt = time.Now()
+	return &result
+}
+
+// Defaults is the pipeline given to a tenant that has not configured one yet
+func Defaults() []Stage {
+	return []Stage{
+		New("Lead", WithPosition(1), WithProbability(10)),
+		New("Qualified", WithPosition(2), WithProbability(30)),
+		New("Proposal", WithPosition(3), WithProbability(60)),
+		New("Negotiation", WithPosition(4), WithProbability(80)),
+		New("Won", WithPosition(5), WithProbability(100), WithOutcome(OutcomeWon)),
+		New("Lost", WithPosition(6), WithProbability(0), WithOutcome(OutcomeLost)),
+	}
+}
@@ -0,0 +1,57 @@
+package pipelinestage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+type SaveDTO struct {
+	Name        string `validate:"required"`
+	Position    int
+	Probability int    `validate:"gte=0,lte=100"`
+	Outcome     string `validate:"required"`
+}
+
+func (d *SaveDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+
+	validationErrors := make(serrors.ValidationErrors)
+	getFieldLocaleKey := func(field string) string {
+		return fmt.Sprintf("Pipeline.Fields.%s.Label", field)
+	}
+
+	errs := constants.Validate.Struct(d)
+	if errs != nil {
+		for field, err := range serrors.ProcessValidatorErrors(errs.(validator.ValidationErrors), getFieldLocaleKey) {
+			validationErrors[field] = err
+		}
+	}
+	if d.Outcome != "" && !Outcome(d.Outcome).IsValid() {
+		validationErrors["Outcome"] = serrors.NewFieldRequiredError("Outcome", getFieldLocaleKey("Outcome"))
+	}
+
+	errorMessages := serrors.LocalizeValidationErrors(validationErrors, l)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *SaveDTO) ToEntity() Stage {
+	return New(
+		d.Name,
+		WithPosition(d.Position),
+		WithProbability(d.Probability),
+		WithOutcome(Outcome(d.Outcome)),
+	)
+}
+
+func (d *SaveDTO) Apply(entity Stage) Stage {
+	return entity.Update(d.Name, d.Position, d.Probability, Outcome(d.Outcome))
+}
@@ -0,0 +1,12 @@
+package pipelinestage
+
+import "context"
+
+type Repository interface {
+	// GetAll returns the stages ordered by position
+	GetAll(ctx context.Context) ([]Stage, error)
+	GetByID(ctx context.Context, id uint) (Stage, error)
+	Create(ctx context.Context, data Stage) (Stage, error)
+	Update(ctx context.Context, data Stage) error
+	Delete(ctx context.Context, id uint) error
+}
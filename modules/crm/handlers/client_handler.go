@@ -21,6 +21,7 @@ type ClientHandler struct {
 	pool          *pgxpool.Pool
 	publisher     eventbus.EventBus
 	chatService   *crmservices.ChatService
+	dupService    *crmservices.ClientDuplicateService
 	tenantService *services.TenantService
 }
 
@@ -29,47 +30,34 @@ func RegisterClientHandler(app application.Application) *ClientHandler {
 		pool:          app.DB(),
 		publisher:     app.EventPublisher(),
 		chatService:   app.Service(crmservices.ChatService{}).(*crmservices.ChatService),
+		dupService:    app.Service(crmservices.ClientDuplicateService{}).(*crmservices.ClientDuplicateService),
 		tenantService: app.Service(services.TenantService{}).(*services.TenantService),
 	}
 	app.EventPublisher().Subscribe(handler.onCreated)
+	app.EventPublisher().Subscribe(handler.onCreatedDetectDuplicates)
+	app.EventPublisher().Subscribe(handler.onUpdatedDetectDuplicates)
 	return handler
 }
 
+// createTenantContext scopes event handling to the tenant of the event, events come without a request
 func (h *ClientHandler) createTenantContext(tenantID uuid.UUID) context.Context {
-	ctx := context.Background()
-	ctxWithDb := composables.WithPool(ctx, h.pool)
-	logger := configuration.Use().Logger()
-
-	tenant, err := h.tenantService.GetByID(ctxWithDb, tenantID)
-	if err != nil {
-		logger.WithError(err).Error("failed to get tenant")
-		return composables.WithPool(ctx, h.pool)
-	}
-
-	tenantComposable := &composables.Tenant{
-		ID:     tenant.ID(),
-		Name:   tenant.Name(),
-		Domain: tenant.Domain(),
-	}
-
-	return composables.WithPool(composables.WithTenantID(ctx, tenantComposable.ID), h.pool)
+	return composables.WithPool(composables.WithTenantID(context.Background(), tenantID), h.pool)
 }
 
 func (h *ClientHandler) onCreated(event *client.CreatedEvent) {
 	tenantID := event.Result.TenantID()
 	logger := configuration.Use().Logger()
 
+	ctx := h.createTenantContext(tenantID)
+
 	// Validate tenant exists before creating chat
-	ctxWithDb := composables.WithPool(context.Background(), h.pool)
-	if _, err := h.tenantService.GetByID(ctxWithDb, tenantID); err != nil {
+	if _, err := h.tenantService.GetByID(ctx, tenantID); err != nil {
 		logger.WithFields(logrus.Fields{
 			"tenant_id": tenantID,
 		}).WithError(err).Error("failed to get tenant")
 		return
 	}
 
-	ctx := h.createTenantContext(tenantID)
-
 	if _, err := h.chatService.Save(ctx, chat.New(
 		event.Result.ID(),
 		chat.WithTenantID(tenantID),
@@ -86,3 +74,25 @@ func (h *ClientHandler) onCreated(event *client.CreatedEvent) {
 		"tenant_id": tenantID,
 	}).Info("successfully created chat for client")
 }
+
+func (h *ClientHandler) onCreatedDetectDuplicates(event *client.CreatedEvent) {
+	h.detectDuplicates(event.Result)
+}
+
+func (h *ClientHandler) onUpdatedDetectDuplicates(event *client.UpdatedEvent) {
+	h.detectDuplicates(event.Result)
+}
+
+// detectDuplicates queues the clients that look like the saved one for a review
+func (h *ClientHandler) detectDuplicates(data client.Client) {
+	if data == nil {
+		return
+	}
+	ctx := h.createTenantContext(data.TenantID())
+	if err := h.dupService.Detect(ctx, data); err != nil {
+		configuration.Use().Logger().WithFields(logrus.Fields{
+			"tenant_id": data.TenantID(),
+			"client_id": data.ID(),
+		}).WithError(err).Error("failed to detect client duplicates")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/telegram"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 )
 
 type NotificationHandler struct {
@@ -30,7 +31,7 @@ func RegisterNotificationHandler(app application.Application, botToken string) *
 }
 
 func (h *NotificationHandler) onNewMessage(event *chat.MessagedAddedEvent) {
-	ctx := context.Background()
+	ctx := composables.WithPool(composables.WithTenantID(context.Background(), event.Result.TenantID()), h.pool)
 	chatID := int64(-1001979082001)
 	if err := h.tgBot.SendMessage(
 		ctx,
@@ -0,0 +1,443 @@
+package cpassproviders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-imap"
+	imapclient "github.com/emersion/go-imap/client"
+	"github.com/emersion/go-message/mail"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	clientagg "github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var ErrNoEmailAddress = errors.New("client has no email address")
+
+// Mailbox is an IMAP folder polled for messages from the clients of a tenant
+type Mailbox struct {
+	TenantID uuid.UUID
+	Addr     string
+	Username string
+	Password string
+	Folder   string
+	// TLS dials the server with implicit TLS, usually on port 993
+	TLS bool
+}
+
+// EmailConfig holds the email transport configuration
+type EmailConfig struct {
+	SMTPAddr     string
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	// Subject of outgoing messages, chat messages have none of their own
+	Subject      string
+	Mailboxes    []Mailbox
+	PollInterval time.Duration
+}
+
+// NewEmailProvider creates a provider that sends chat messages over SMTP and ingests replies from IMAP mailboxes
+func NewEmailProvider(
+	config EmailConfig,
+	pool *pgxpool.Pool,
+	clientRepo clientagg.Repository,
+	chatRepo chat.Repository,
+	uploadRepo upload.Repository,
+	storage upload.Storage,
+) *EmailProvider {
+	return &EmailProvider{
+		config:     config,
+		pool:       pool,
+		clientRepo: clientRepo,
+		chatRepo:   chatRepo,
+		uploadRepo: uploadRepo,
+		storage:    storage,
+	}
+}
+
+var _ chat.Provider = &EmailProvider{}
+
+// EmailProvider threads email conversations into client chats using Message-ID and References headers
+type EmailProvider struct {
+	config     EmailConfig
+	pool       *pgxpool.Pool
+	clientRepo clientagg.Repository
+	chatRepo   chat.Repository
+	uploadRepo upload.Repository
+	storage    upload.Storage
+	callbacks  []func(ctx context.Context, msg chat.Message) error
+}
+
+func (p *EmailProvider) Transport() chat.Transport {
+	return chat.EmailTransport
+}
+
+func (p *EmailProvider) OnReceived(callback func(ctx context.Context, msg chat.Message) error) {
+	p.callbacks = append(p.callbacks, callback)
+}
+
+// Send emails a stored chat message to its client, as a reply to the previous emails of the chat
+func (p *EmailProvider) Send(ctx context.Context, msg chat.Message) error {
+	from, err := mail.ParseAddress(p.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	chatEntity, err := p.chatRepo.GetByID(ctx, msg.ChatID())
+	if err != nil {
+		return err
+	}
+	clientEntity, err := p.clientRepo.GetByID(ctx, chatEntity.ClientID())
+	if err != nil {
+		return err
+	}
+	to := clientEmail(clientEntity)
+	if to == "" {
+		return ErrNoEmailAddress
+	}
+
+	attachments := make([]EmailAttachment, 0, len(msg.Attachments()))
+	for _, u := range msg.Attachments() {
+		data, err := p.storage.Open(ctx, u.Path())
+		if err != nil {
+			return fmt.Errorf("failed to open attachment %d: %w", u.ID(), err)
+		}
+		mimeType := "application/octet-stream"
+		if u.Mimetype() != nil {
+			mimeType = u.Mimetype().String()
+		}
+		attachments = append(attachments, EmailAttachment{
+			Filename: u.Name(),
+			MimeType: mimeType,
+			Data:     data,
+		})
+	}
+
+	_, domain, _ := strings.Cut(from.Address, "@")
+	raw, err := ComposeEmail(&OutboundEmail{
+		From:        from,
+		To:          &mail.Address{Name: strings.TrimSpace(clientEntity.FirstName() + " " + clientEntity.LastName()), Address: to},
+		Subject:     p.config.Subject,
+		MessageID:   OutboundMessageID(chatEntity.ID(), msg.ID(), domain),
+		References:  threadReferences(chatEntity, msg.ID(), domain),
+		Date:        msg.CreatedAt(),
+		Text:        msg.Message(),
+		Attachments: attachments,
+	})
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(p.config.SMTPAddr, p.smtpAuth(), from.Address, []string{to}, raw)
+}
+
+func (p *EmailProvider) smtpAuth() smtp.Auth {
+	if p.config.SMTPUsername == "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(p.config.SMTPAddr)
+	if err != nil {
+		host = p.config.SMTPAddr
+	}
+	return smtp.PlainAuth("", p.config.SMTPUsername, p.config.SMTPPassword, host)
+}
+
+// Run polls the mailboxes every poll interval until ctx is done
+func (p *EmailProvider) Run(ctx context.Context) error {
+	if len(p.config.Mailboxes) == 0 || p.config.PollInterval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(p.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		for _, mb := range p.config.Mailboxes {
+			if err := p.Poll(ctx, mb); err != nil && ctx.Err() == nil {
+				configuration.Use().Logger().WithError(err).WithField("mailbox", mb.Username).Error("failed to poll mailbox")
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll ingests the unseen messages of a mailbox. Stored and unparsable messages are marked as seen,
+// the ones that failed to store stay unseen and are retried on the next poll.
+func (p *EmailProvider) Poll(ctx context.Context, mb Mailbox) error {
+	ctx = composables.WithTenantID(composables.WithPool(ctx, p.pool), mb.TenantID)
+	return FetchUnseen(mb, func(r io.Reader) error {
+		email, err := ParseEmail(r)
+		if err != nil {
+			configuration.Use().Logger().WithError(err).WithField("mailbox", mb.Username).Warn("skipping unparsable email")
+			return nil
+		}
+		return p.receive(ctx, mb.TenantID, email)
+	})
+}
+
+// FetchUnseen passes the unseen messages of a mailbox to handle and marks the handled ones as seen
+func FetchUnseen(mb Mailbox, handle func(r io.Reader) error) error {
+	var c *imapclient.Client
+	var err error
+	if mb.TLS {
+		c, err = imapclient.DialTLS(mb.Addr, nil)
+	} else {
+		c, err = imapclient.Dial(mb.Addr)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.Logout()
+	}()
+	if err := c.Login(mb.Username, mb.Password); err != nil {
+		return err
+	}
+	folder := mb.Folder
+	if folder == "" {
+		folder = "INBOX"
+	}
+	if _, err := c.Select(folder, false); err != nil {
+		return err
+	}
+
+	criteria := imap.NewSearchCriteria()
+	criteria.WithoutFlags = []string{imap.SeenFlag}
+	uids, err := c.UidSearch(criteria)
+	if err != nil || len(uids) == 0 {
+		return err
+	}
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uids...)
+	section := &imap.BodySectionName{Peek: true}
+	messages := make(chan *imap.Message, len(uids))
+	if err := c.UidFetch(seqSet, []imap.FetchItem{imap.FetchUid, section.FetchItem()}, messages); err != nil {
+		return err
+	}
+
+	var errs []error
+	seen := new(imap.SeqSet)
+	for msg := range messages {
+		body := msg.GetBody(section)
+		if body == nil {
+			continue
+		}
+		if err := handle(body); err != nil {
+			errs = append(errs, fmt.Errorf("message %d: %w", msg.Uid, err))
+			continue
+		}
+		seen.AddNum(msg.Uid)
+	}
+	if !seen.Empty() {
+		flags := []interface{}{imap.SeenFlag}
+		if err := c.UidStore(seen, imap.FormatFlagsOp(imap.AddFlags, true), flags, nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *EmailProvider) receive(ctx context.Context, tenantID uuid.UUID, email *InboundEmail) error {
+	if email.MessageID != "" {
+		_, err := p.chatRepo.GetByExternalMessageID(ctx, []string{email.MessageID})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, persistence.ErrChatNotFound) {
+			return err
+		}
+	}
+
+	chatEntity, clientEntity, err := p.resolveChat(ctx, tenantID, email)
+	if err != nil {
+		return err
+	}
+	uploads, err := p.saveAttachments(ctx, email.Attachments)
+	if err != nil {
+		return err
+	}
+	msg := chat.NewMessage(
+		email.Text,
+		emailMember(chatEntity, clientEntity, tenantID),
+		chat.WithMessageChatID(chatEntity.ID()),
+		chat.WithExternalID(email.MessageID),
+		chat.WithMessageSentAt(&email.Date),
+		chat.WithAttachments(uploads),
+	)
+	for _, cb := range p.callbacks {
+		if err := cb(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveChat finds the chat an email replies to, falling back to the chat of the client with the sender address.
+// Unknown senders become new clients.
+func (p *EmailProvider) resolveChat(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	email *InboundEmail,
+) (chat.Chat, clientagg.Client, error) {
+	chatEntity, err := p.threadChat(ctx, email.References)
+	if err != nil && !errors.Is(err, persistence.ErrChatNotFound) {
+		return nil, nil, err
+	}
+	if chatEntity != nil {
+		clientEntity, err := p.clientRepo.GetByID(ctx, chatEntity.ClientID())
+		if err != nil {
+			return nil, nil, err
+		}
+		return chatEntity, clientEntity, nil
+	}
+
+	clientEntity, err := p.senderClient(ctx, tenantID, email.From)
+	if err != nil {
+		return nil, nil, err
+	}
+	chatEntity, err = p.chatRepo.GetByClientID(ctx, clientEntity.ID())
+	if errors.Is(err, persistence.ErrChatNotFound) {
+		chatEntity, err = p.chatRepo.Save(ctx, chat.New(clientEntity.ID(), chat.WithTenantID(tenantID)))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return chatEntity, clientEntity, nil
+}
+
+func (p *EmailProvider) threadChat(ctx context.Context, references []string) (chat.Chat, error) {
+	external := make([]string, 0, len(references))
+	for _, ref := range references {
+		chatID, ok := ChatIDFromMessageID(ref)
+		if !ok {
+			external = append(external, ref)
+			continue
+		}
+		chatEntity, err := p.chatRepo.GetByID(ctx, chatID)
+		if err == nil {
+			return chatEntity, nil
+		}
+		if !errors.Is(err, persistence.ErrChatNotFound) {
+			return nil, err
+		}
+	}
+	return p.chatRepo.GetByExternalMessageID(ctx, external)
+}
+
+func (p *EmailProvider) senderClient(ctx context.Context, tenantID uuid.UUID, from *mail.Address) (clientagg.Client, error) {
+	clients, err := p.clientRepo.GetPaginated(ctx, &clientagg.FindParams{
+		Limit: 1,
+		Filters: []clientagg.Filter{
+			{
+				Column: clientagg.Email,
+				Filter: repo.Eq(strings.ToLower(from.Address)),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) > 0 {
+		return clients[0], nil
+	}
+
+	email, err := internet.NewEmail(strings.ToLower(from.Address))
+	if err != nil {
+		return nil, err
+	}
+	firstName, lastName, _ := strings.Cut(strings.TrimSpace(from.Name), " ")
+	if firstName == "" {
+		firstName = email.Username()
+	}
+	entity, err := clientagg.New(
+		firstName,
+		clientagg.WithLastName(strings.TrimSpace(lastName)),
+		clientagg.WithEmail(email),
+		clientagg.WithTenantID(tenantID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return p.clientRepo.Save(ctx, entity)
+}
+
+func (p *EmailProvider) saveAttachments(ctx context.Context, attachments []EmailAttachment) ([]upload.Upload, error) {
+	uploads := make([]upload.Upload, 0, len(attachments))
+	for _, att := range attachments {
+		entity, err := saveUpload(ctx, p.uploadRepo, p.storage, att.Filename, att.Data)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, entity)
+	}
+	return uploads, nil
+}
+
+// emailMember returns the email member of the client in the chat, creating one when the client never wrote by email
+func emailMember(chatEntity chat.Chat, clientEntity clientagg.Client, tenantID uuid.UUID) chat.Member {
+	for _, m := range chatEntity.Members() {
+		sender, ok := m.Sender().(chat.ClientSender)
+		if ok && m.Transport() == chat.EmailTransport && sender.ClientID() == clientEntity.ID() {
+			return m
+		}
+	}
+	var contactID uint
+	for _, c := range clientEntity.Contacts() {
+		if c.Type() == clientagg.ContactTypeEmail {
+			contactID = c.ID()
+			break
+		}
+	}
+	return chat.NewMember(
+		chat.NewClientSender(clientEntity.ID(), contactID, clientEntity.FirstName(), clientEntity.LastName()),
+		chat.EmailTransport,
+		chat.WithMemberTenantID(tenantID),
+	)
+}
+
+func clientEmail(c clientagg.Client) string {
+	if c.Email() != nil && c.Email().Value() != "" {
+		return c.Email().Value()
+	}
+	for _, contact := range c.Contacts() {
+		if contact.Type() == clientagg.ContactTypeEmail {
+			return contact.Value()
+		}
+	}
+	return ""
+}
+
+// threadReferences returns the ids of the emails in the chat before messageID, oldest first
+func threadReferences(chatEntity chat.Chat, messageID uint, domain string) []string {
+	refs := make([]string, 0, maxReferences)
+	for _, m := range chatEntity.Messages() {
+		if m.ID() == messageID {
+			break
+		}
+		switch {
+		case m.ExternalID() != "":
+			refs = append(refs, m.ExternalID())
+		case m.Sender().Transport() == chat.EmailTransport && m.Sender().Sender().Type() == chat.UserSenderType:
+			refs = append(refs, OutboundMessageID(chatEntity.ID(), m.ID(), domain))
+		}
+	}
+	if len(refs) > maxReferences {
+		refs = refs[len(refs)-maxReferences:]
+	}
+	return refs
+}
@@ -0,0 +1,236 @@
+package cpassproviders
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/emersion/go-message/charset"
+	"github.com/emersion/go-message/mail"
+)
+
+const (
+	outboundMessageIDPrefix = "crm."
+	// maxReferences caps the References header of outgoing messages
+	maxReferences = 10
+)
+
+var htmlTagRe = regexp.MustCompile(`(?s)<[^>]*>`)
+
+// EmailAttachment is a file attached to an email
+type EmailAttachment struct {
+	Filename string
+	MimeType string
+	Data     []byte
+}
+
+// InboundEmail is the part of a received email that is stored in a chat
+type InboundEmail struct {
+	MessageID string
+	// References holds In-Reply-To and References ids, the most recent first
+	References  []string
+	From        *mail.Address
+	Subject     string
+	Date        time.Time
+	Text        string
+	Attachments []EmailAttachment
+}
+
+// OutboundEmail is a chat message rendered as an email
+type OutboundEmail struct {
+	From        *mail.Address
+	To          *mail.Address
+	Subject     string
+	MessageID   string
+	References  []string
+	Date        time.Time
+	Text        string
+	Attachments []EmailAttachment
+}
+
+// OutboundMessageID returns the Message-ID of a chat message sent by email.
+// Replies carry it in their In-Reply-To header, which lets the poller find the chat without a lookup table.
+func OutboundMessageID(chatID, messageID uint, domain string) string {
+	return fmt.Sprintf("%s%d.%d@%s", outboundMessageIDPrefix, chatID, messageID, domain)
+}
+
+// ChatIDFromMessageID extracts the chat id from a Message-ID made by OutboundMessageID
+func ChatIDFromMessageID(id string) (uint, bool) {
+	local, _, ok := strings.Cut(id, "@")
+	if !ok || !strings.HasPrefix(local, outboundMessageIDPrefix) {
+		return 0, false
+	}
+	chatPart, _, ok := strings.Cut(strings.TrimPrefix(local, outboundMessageIDPrefix), ".")
+	if !ok {
+		return 0, false
+	}
+	chatID, err := strconv.ParseUint(chatPart, 10, 32)
+	if err != nil || chatID == 0 {
+		return 0, false
+	}
+	return uint(chatID), true
+}
+
+// ParseEmail reads an RFC 5322 message.
+// The text is taken from the first plain text part, falling back to the HTML part and then to the subject.
+func ParseEmail(r io.Reader) (*InboundEmail, error) {
+	mr, err := mail.CreateReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer mr.Close()
+
+	from, err := mr.Header.AddressList("From")
+	if err != nil {
+		return nil, err
+	}
+	if len(from) == 0 {
+		return nil, errors.New("email has no sender")
+	}
+	email := &InboundEmail{From: from[0]}
+	if email.MessageID, err = mr.Header.MessageID(); err != nil {
+		return nil, err
+	}
+	if email.Subject, err = mr.Header.Subject(); err != nil {
+		return nil, err
+	}
+	if email.Date, err = mr.Header.Date(); err != nil || email.Date.IsZero() {
+		email.Date = time.Now()
+	}
+	inReplyTo, err := mr.Header.MsgIDList("In-Reply-To")
+	if err != nil {
+		return nil, err
+	}
+	references, err := mr.Header.MsgIDList("References")
+	if err != nil {
+		return nil, err
+	}
+	email.References = append(email.References, inReplyTo...)
+	for i := len(references) - 1; i >= 0; i-- {
+		email.References = append(email.References, references[i])
+	}
+
+	var plain, rich string
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(part.Body)
+		if err != nil {
+			return nil, err
+		}
+		switch h := part.Header.(type) {
+		case *mail.InlineHeader:
+			contentType, _, _ := h.ContentType()
+			switch {
+			case contentType == "text/plain" && plain == "":
+				plain = string(data)
+			case contentType == "text/html" && rich == "":
+				rich = string(data)
+			}
+		case *mail.AttachmentHeader:
+			filename, err := h.Filename()
+			if err != nil {
+				return nil, err
+			}
+			contentType, _, _ := h.ContentType()
+			email.Attachments = append(email.Attachments, EmailAttachment{
+				Filename: filename,
+				MimeType: contentType,
+				Data:     data,
+			})
+		}
+	}
+
+	switch {
+	case strings.TrimSpace(plain) != "":
+		email.Text = strings.TrimSpace(plain)
+	case strings.TrimSpace(rich) != "":
+		email.Text = strings.TrimSpace(html.UnescapeString(htmlTagRe.ReplaceAllString(rich, "")))
+	default:
+		email.Text = email.Subject
+	}
+	return email, nil
+}
+
+// ComposeEmail renders an outgoing email, multipart when it has attachments
+func ComposeEmail(email *OutboundEmail) ([]byte, error) {
+	var h mail.Header
+	h.SetAddressList("From", []*mail.Address{email.From})
+	h.SetAddressList("To", []*mail.Address{email.To})
+	h.SetSubject(email.Subject)
+	h.SetDate(email.Date)
+	h.SetMessageID(email.MessageID)
+	if len(email.References) > 0 {
+		h.SetMsgIDList("In-Reply-To", email.References[len(email.References)-1:])
+		h.SetMsgIDList("References", email.References)
+	}
+
+	var buf bytes.Buffer
+	var text mail.InlineHeader
+	text.SetContentType("text/plain", map[string]string{"charset": "utf-8"})
+
+	if len(email.Attachments) == 0 {
+		h.SetContentType("text/plain", map[string]string{"charset": "utf-8"})
+		w, err := mail.CreateSingleInlineWriter(&buf, h)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(w, email.Text); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw, err := mail.CreateWriter(&buf, h)
+	if err != nil {
+		return nil, err
+	}
+	tw, err := mw.CreateSingleInline(text)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(tw, email.Text); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	for _, att := range email.Attachments {
+		var ah mail.AttachmentHeader
+		mediaType, params, err := mime.ParseMediaType(att.MimeType)
+		if err != nil {
+			mediaType, params = "application/octet-stream", nil
+		}
+		ah.SetContentType(mediaType, params)
+		ah.SetFilename(att.Filename)
+		aw, err := mw.CreateAttachment(ah)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := aw.Write(att.Data); err != nil {
+			return nil, err
+		}
+		if err := aw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,247 @@
+package cpassproviders_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-message/mail"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+)
+
+const replyEmail = "From: Jane Doe <jane@client.example>\r\n" +
+	"To: sales@example.com\r\n" +
+	"Subject: Re: Offer\r\n" +
+	"Date: Mon, 02 Jun 2025 10:00:00 +0000\r\n" +
+	"Message-ID: <reply-1@client.example>\r\n" +
+	"In-Reply-To: <crm.7.42@example.com>\r\n" +
+	"References: <first@client.example> <crm.7.42@example.com>\r\n" +
+	"Content-Type: multipart/mixed; boundary=b1\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>Sounds good &amp; thanks</p>\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Disposition: attachment; filename=\"terms.txt\"\r\n" +
+	"\r\n" +
+	"signed\r\n" +
+	"--b1--\r\n"
+
+func plainEmail(id, body string) string {
+	return "From: jane@client.example\r\n" +
+		"To: sales@example.com\r\n" +
+		"Subject: Hello\r\n" +
+		"Message-ID: <" + id + ">\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		body
+}
+
+func TestParseEmail(t *testing.T) {
+	t.Parallel()
+
+	email, err := cpassproviders.ParseEmail(strings.NewReader(replyEmail))
+	require.NoError(t, err)
+
+	assert.Equal(t, "reply-1@client.example", email.MessageID)
+	assert.Equal(t, "jane@client.example", email.From.Address)
+	assert.Equal(t, "Jane Doe", email.From.Name)
+	assert.Equal(t, []string{"crm.7.42@example.com", "crm.7.42@example.com", "first@client.example"}, email.References)
+	assert.Equal(t, "Sounds good & thanks", email.Text)
+	require.Len(t, email.Attachments, 1)
+	assert.Equal(t, "terms.txt", email.Attachments[0].Filename)
+	assert.Equal(t, "signed", strings.TrimSpace(string(email.Attachments[0].Data)))
+
+	email, err = cpassproviders.ParseEmail(strings.NewReader(plainEmail("a@b", "")))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", email.Text, "falls back to the subject")
+}
+
+func TestChatIDFromMessageID(t *testing.T) {
+	t.Parallel()
+
+	chatID, ok := cpassproviders.ChatIDFromMessageID(cpassproviders.OutboundMessageID(7, 42, "example.com"))
+	assert.True(t, ok)
+	assert.Equal(t, uint(7), chatID)
+
+	for _, id := range []string{"reply-1@client.example", "crm.x.1@example.com", "crm.7@example.com", "crm.7.1"} {
+		_, ok := cpassproviders.ChatIDFromMessageID(id)
+		assert.False(t, ok, id)
+	}
+}
+
+func TestComposeEmail(t *testing.T) {
+	t.Parallel()
+
+	raw, err := cpassproviders.ComposeEmail(&cpassproviders.OutboundEmail{
+		From:       &mail.Address{Name: "Sales", Address: "sales@example.com"},
+		To:         &mail.Address{Address: "jane@client.example"},
+		Subject:    "Offer",
+		MessageID:  "crm.7.43@example.com",
+		References: []string{"first@client.example", "reply-1@client.example"},
+		Date:       time.Now(),
+		Text:       "Привет",
+		Attachments: []cpassproviders.EmailAttachment{
+			{Filename: "offer.pdf", MimeType: "application/pdf", Data: []byte("%PDF")},
+		},
+	})
+	require.NoError(t, err)
+
+	email, err := cpassproviders.ParseEmail(bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "crm.7.43@example.com", email.MessageID)
+	assert.Equal(t, []string{"reply-1@client.example", "reply-1@client.example", "first@client.example"}, email.References)
+	assert.Equal(t, "Привет", email.Text)
+	require.Len(t, email.Attachments, 1)
+	assert.Equal(t, "offer.pdf", email.Attachments[0].Filename)
+	assert.Equal(t, "application/pdf", email.Attachments[0].MimeType)
+	assert.Equal(t, []byte("%PDF"), email.Attachments[0].Data)
+}
+
+func TestFetchUnseen(t *testing.T) {
+	t.Parallel()
+
+	server := itf.NewMailServer(t)
+	server.Deliver(t, plainEmail("ok@client.example", "stored"))
+	server.Deliver(t, plainEmail("fail@client.example", "retried"))
+
+	mb := cpassproviders.Mailbox{
+		Addr:     server.IMAPAddr,
+		Username: server.Username,
+		Password: server.Password,
+	}
+	var handled []string
+	err := cpassproviders.FetchUnseen(mb, func(r io.Reader) error {
+		email, err := cpassproviders.ParseEmail(r)
+		require.NoError(t, err)
+		handled = append(handled, email.Text)
+		if email.MessageID == "fail@client.example" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	require.Error(t, err)
+	assert.ElementsMatch(t, []string{"stored", "retried"}, handled)
+	assert.Equal(t, 1, server.Unseen(t), "failed message stays unseen")
+
+	handled = nil
+	require.NoError(t, cpassproviders.FetchUnseen(mb, func(r io.Reader) error {
+		email, err := cpassproviders.ParseEmail(r)
+		require.NoError(t, err)
+		handled = append(handled, email.Text)
+		return nil
+	}))
+	assert.Equal(t, []string{"retried"}, handled)
+	assert.Equal(t, 0, server.Unseen(t))
+}
+
+type chatRepoStub struct {
+	chat.Repository
+	chat chat.Chat
+}
+
+func (r *chatRepoStub) GetByID(context.Context, uint) (chat.Chat, error) {
+	return r.chat, nil
+}
+
+type clientRepoStub struct {
+	client.Repository
+	client client.Client
+}
+
+func (r *clientRepoStub) GetByID(context.Context, uint) (client.Client, error) {
+	return r.client, nil
+}
+
+type storageStub map[string][]byte
+
+func (s storageStub) Open(_ context.Context, fileName string) ([]byte, error) {
+	return s[fileName], nil
+}
+
+func (s storageStub) Save(_ context.Context, fileName string, bytes []byte) error {
+	s[fileName] = bytes
+	return nil
+}
+
+func TestEmailProvider_Send(t *testing.T) {
+	t.Parallel()
+
+	server := itf.NewMailServer(t)
+	tenantID := uuid.New()
+
+	clientEntity, err := client.New(
+		"Jane",
+		client.WithID(3),
+		client.WithLastName("Doe"),
+		client.WithEmail(internet.MustParseEmail("jane@client.example")),
+	)
+	require.NoError(t, err)
+	clientMember := chat.NewMember(chat.NewClientSender(3, 0, "Jane", "Doe"), chat.EmailTransport)
+	userMember := chat.NewMember(chat.NewUserSender(1, "John", "Smith"), chat.EmailTransport)
+	attachment := upload.NewWithID(
+		5, tenantID, "hash", "uploads/hash.txt", "terms.txt", 6,
+		mimetype.Lookup("text/plain"), upload.UploadTypeDocument, time.Now(), time.Now(),
+	)
+	reply := chat.NewMessage(
+		"See attached",
+		userMember,
+		chat.WithMessageID(12),
+		chat.WithMessageChatID(7),
+		chat.WithAttachments([]upload.Upload{attachment}),
+	)
+	chatEntity := chat.New(
+		3,
+		chat.WithChatID(7),
+		chat.WithTenantID(tenantID),
+		chat.WithMessages([]chat.Message{
+			chat.NewMessage("Hi", clientMember, chat.WithMessageID(10), chat.WithExternalID("first@client.example")),
+			chat.NewMessage("Hello Jane", userMember, chat.WithMessageID(11)),
+			reply,
+		}),
+	)
+
+	provider := cpassproviders.NewEmailProvider(
+		cpassproviders.EmailConfig{
+			SMTPAddr: server.SMTPAddr,
+			From:     "Sales <sales@example.com>",
+			Subject:  "Your order",
+		},
+		nil,
+		&clientRepoStub{client: clientEntity},
+		&chatRepoStub{chat: chatEntity},
+		nil,
+		storageStub{"uploads/hash.txt": []byte("signed")},
+	)
+	require.NoError(t, provider.Send(context.Background(), reply))
+
+	sent := server.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "sales@example.com", sent[0].From)
+	assert.Equal(t, []string{"jane@client.example"}, sent[0].To)
+
+	email, err := cpassproviders.ParseEmail(bytes.NewReader(sent[0].Data))
+	require.NoError(t, err)
+	assert.Equal(t, "crm.7.12@example.com", email.MessageID)
+	assert.Equal(t, []string{"crm.7.11@example.com", "crm.7.11@example.com", "first@client.example"}, email.References)
+	assert.Equal(t, "Your order", email.Subject)
+	assert.Equal(t, "See attached", email.Text)
+	require.Len(t, email.Attachments, 1)
+	assert.Equal(t, []byte("signed"), email.Attachments[0].Data)
+}
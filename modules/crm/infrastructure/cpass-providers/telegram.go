@@ -0,0 +1,375 @@
+package cpassproviders
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	clientagg "github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/telegram"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+var ErrNoTelegramChat = errors.New("client has not written to the telegram bot")
+
+const telegramSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// TelegramConfig holds the Telegram transport configuration
+type TelegramConfig struct {
+	// Tenant whose clients write to the bot, receiving is disabled when empty
+	TenantID uuid.UUID
+	// WebhookURL is where Telegram delivers updates, the bot long polls when empty
+	WebhookURL    string
+	WebhookSecret string
+	PollTimeout   time.Duration
+}
+
+// NewTelegramProvider creates a provider that receives client messages sent to a bot and replies through it
+func NewTelegramProvider(
+	config TelegramConfig,
+	bot *telegram.Bot,
+	pool *pgxpool.Pool,
+	clientRepo clientagg.Repository,
+	chatRepo chat.Repository,
+	uploadRepo upload.Repository,
+	storage upload.Storage,
+) *TelegramProvider {
+	return &TelegramProvider{
+		config:     config,
+		bot:        bot,
+		pool:       pool,
+		clientRepo: clientRepo,
+		chatRepo:   chatRepo,
+		uploadRepo: uploadRepo,
+		storage:    storage,
+	}
+}
+
+var _ chat.Provider = &TelegramProvider{}
+
+// TelegramProvider maps Telegram users to client contacts, the contact value is the id of the private chat with the bot
+type TelegramProvider struct {
+	config     TelegramConfig
+	bot        *telegram.Bot
+	pool       *pgxpool.Pool
+	clientRepo clientagg.Repository
+	chatRepo   chat.Repository
+	uploadRepo upload.Repository
+	storage    upload.Storage
+	callbacks  []func(ctx context.Context, msg chat.Message) error
+}
+
+func (p *TelegramProvider) Transport() chat.Transport {
+	return chat.TelegramTransport
+}
+
+func (p *TelegramProvider) OnReceived(callback func(ctx context.Context, msg chat.Message) error) {
+	p.callbacks = append(p.callbacks, callback)
+}
+
+// Send delivers a stored chat message to the Telegram chat the client wrote from
+func (p *TelegramProvider) Send(ctx context.Context, msg chat.Message) error {
+	chatEntity, err := p.chatRepo.GetByID(ctx, msg.ChatID())
+	if err != nil {
+		return err
+	}
+	clientEntity, err := p.clientRepo.GetByID(ctx, chatEntity.ClientID())
+	if err != nil {
+		return err
+	}
+	telegramChatID, err := telegramChat(chatEntity, clientEntity)
+	if err != nil {
+		return err
+	}
+
+	if msg.Message() != "" {
+		if err := p.bot.SendMessage(ctx, telegramChatID, msg.Message(), nil); err != nil {
+			return err
+		}
+	}
+	for _, u := range msg.Attachments() {
+		data, err := p.storage.Open(ctx, u.Path())
+		if err != nil {
+			return fmt.Errorf("failed to open attachment %d: %w", u.ID(), err)
+		}
+		if u.IsImage() {
+			err = p.bot.SendPhoto(ctx, telegramChatID, u.Name(), data)
+		} else {
+			err = p.bot.SendDocument(ctx, telegramChatID, u.Name(), data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WebhookHandler receives the updates Telegram posts to the webhook URL
+func (p *TelegramProvider) WebhookHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		secret := r.Header.Get(telegramSecretHeader)
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(p.config.WebhookSecret)) != 1 {
+			http.Error(w, "invalid secret token", http.StatusUnauthorized)
+			return
+		}
+		var update telegram.Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			http.Error(w, "failed to decode update", http.StatusBadRequest)
+			return
+		}
+		if err := p.HandleUpdate(r.Context(), update); err != nil {
+			configuration.Use().Logger().WithError(err).Error("failed to handle telegram update")
+			// Telegram redelivers the update until it gets a successful response
+			http.Error(w, "failed to handle update", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// Run registers the webhook, or long polls for updates until ctx is done when no webhook URL is configured
+func (p *TelegramProvider) Run(ctx context.Context) error {
+	if p.config.TenantID == uuid.Nil {
+		return nil
+	}
+	if p.config.WebhookURL != "" {
+		return p.bot.SetWebhook(ctx, p.config.WebhookURL, p.config.WebhookSecret)
+	}
+	if err := p.bot.DeleteWebhook(ctx); err != nil {
+		return err
+	}
+	logger := configuration.Use().Logger()
+	var offset int64
+	for {
+		updates, err := p.bot.GetUpdates(ctx, offset, p.config.PollTimeout)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			logger.WithError(err).Error("failed to get telegram updates")
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.config.PollTimeout):
+			}
+			continue
+		}
+		for _, update := range updates {
+			if err := p.HandleUpdate(ctx, update); err != nil {
+				logger.WithError(err).WithField("update", update.UpdateId).Error("failed to handle telegram update")
+			}
+			offset = update.UpdateId + 1
+		}
+	}
+}
+
+// HandleUpdate stores a message a client sent to the bot in a private chat, other updates are ignored
+func (p *TelegramProvider) HandleUpdate(ctx context.Context, update telegram.Update) error {
+	message := update.Message
+	if p.config.TenantID == uuid.Nil || message == nil || message.From == nil || message.Chat.Type != "private" {
+		return nil
+	}
+	text := message.Text
+	if text == "" {
+		text = message.Caption
+	}
+	if text == "" && len(message.Photo) == 0 && message.Document == nil {
+		return nil
+	}
+
+	ctx = composables.WithTenantID(ctx, p.config.TenantID)
+	if p.pool != nil {
+		ctx = composables.WithPool(ctx, p.pool)
+	}
+	externalID := fmt.Sprintf("telegram:%d:%d", message.Chat.Id, message.MessageId)
+	_, err := p.chatRepo.GetByExternalMessageID(ctx, []string{externalID})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, persistence.ErrChatNotFound) {
+		return err
+	}
+
+	clientEntity, contact, err := p.senderClient(ctx, message.Chat.Id, message.From)
+	if err != nil {
+		return err
+	}
+	chatEntity, err := p.chatRepo.GetByClientID(ctx, clientEntity.ID())
+	if errors.Is(err, persistence.ErrChatNotFound) {
+		chatEntity, err = p.chatRepo.Save(ctx, chat.New(clientEntity.ID(), chat.WithTenantID(p.config.TenantID)))
+	}
+	if err != nil {
+		return err
+	}
+	uploads, err := p.saveFiles(ctx, message.Photo, message.Document)
+	if err != nil {
+		return err
+	}
+
+	sentAt := time.Unix(message.Date, 0)
+	msg := chat.NewMessage(
+		text,
+		p.member(chatEntity, clientEntity, contact),
+		chat.WithMessageChatID(chatEntity.ID()),
+		chat.WithExternalID(externalID),
+		chat.WithMessageSentAt(&sentAt),
+		chat.WithAttachments(uploads),
+	)
+	for _, cb := range p.callbacks {
+		if err := cb(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// senderClient finds the client by the Telegram chat id, then by the username an operator may have entered.
+// Unknown users become new clients.
+func (p *TelegramProvider) senderClient(
+	ctx context.Context,
+	telegramChatID int64,
+	from *telegram.User,
+) (clientagg.Client, clientagg.Contact, error) {
+	value := strconv.FormatInt(telegramChatID, 10)
+	clientEntity, err := p.clientRepo.GetByContactValue(ctx, clientagg.ContactTypeTelegram, value)
+	if err == nil {
+		return clientEntity, telegramContact(clientEntity, value), nil
+	}
+	if !errors.Is(err, persistence.ErrClientNotFound) {
+		return nil, nil, err
+	}
+
+	if from.Username != "" {
+		for _, username := range []string{from.Username, "@" + from.Username} {
+			clientEntity, err = p.clientRepo.GetByContactValue(ctx, clientagg.ContactTypeTelegram, username)
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, persistence.ErrClientNotFound) {
+				return nil, nil, err
+			}
+		}
+	}
+	if clientEntity == nil {
+		firstName := from.FirstName
+		if firstName == "" {
+			firstName = "Guest"
+		}
+		clientEntity, err = clientagg.New(
+			firstName,
+			clientagg.WithLastName(from.LastName),
+			clientagg.WithTenantID(p.config.TenantID),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	clientEntity, err = p.clientRepo.Save(ctx, clientEntity.AddContact(clientagg.NewContact(clientagg.ContactTypeTelegram, value)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return clientEntity, telegramContact(clientEntity, value), nil
+}
+
+func (p *TelegramProvider) saveFiles(
+	ctx context.Context,
+	photo []telegram.PhotoSize,
+	document *telegram.Document,
+) ([]upload.Upload, error) {
+	type file struct {
+		id   string
+		name string
+	}
+	var files []file
+	if len(photo) > 0 {
+		// Telegram sends the same photo in several sizes, the largest one is last
+		largest := photo[len(photo)-1]
+		files = append(files, file{id: largest.FileId, name: largest.FileUniqueId + ".jpg"})
+	}
+	if document != nil {
+		name := document.FileName
+		if name == "" {
+			name = document.FileUniqueId
+		}
+		files = append(files, file{id: document.FileId, name: name})
+	}
+
+	uploads := make([]upload.Upload, 0, len(files))
+	for _, f := range files {
+		data, err := p.bot.DownloadFile(ctx, f.id)
+		if err != nil {
+			return nil, err
+		}
+		entity, err := saveUpload(ctx, p.uploadRepo, p.storage, f.name, data)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, entity)
+	}
+	return uploads, nil
+}
+
+// member returns the Telegram member of the contact in the chat, creating one on the first message
+func (p *TelegramProvider) member(chatEntity chat.Chat, clientEntity clientagg.Client, contact clientagg.Contact) chat.Member {
+	for _, m := range chatEntity.Members() {
+		sender, ok := m.Sender().(chat.ClientSender)
+		if ok && m.Transport() == chat.TelegramTransport && sender.ContactID() == contact.ID() {
+			return m
+		}
+	}
+	return chat.NewMember(
+		chat.NewClientSender(clientEntity.ID(), contact.ID(), clientEntity.FirstName(), clientEntity.LastName()),
+		chat.TelegramTransport,
+		chat.WithMemberTenantID(p.config.TenantID),
+	)
+}
+
+func telegramContact(clientEntity clientagg.Client, value string) clientagg.Contact {
+	for _, c := range clientEntity.Contacts() {
+		if c.Type() == clientagg.ContactTypeTelegram && c.Value() == value {
+			return c
+		}
+	}
+	return clientagg.NewContact(clientagg.ContactTypeTelegram, value)
+}
+
+// telegramChat returns the id of the Telegram chat the client last wrote to the bot from
+func telegramChat(chatEntity chat.Chat, clientEntity clientagg.Client) (int64, error) {
+	contactIDs := make(map[uint]bool)
+	for _, m := range chatEntity.Members() {
+		if sender, ok := m.Sender().(chat.ClientSender); ok && m.Transport() == chat.TelegramTransport {
+			contactIDs[sender.ContactID()] = true
+		}
+	}
+	messages := chatEntity.Messages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		m := messages[i].Sender()
+		if sender, ok := m.Sender().(chat.ClientSender); ok && m.Transport() == chat.TelegramTransport {
+			contactIDs = map[uint]bool{sender.ContactID(): true}
+			break
+		}
+	}
+	for _, c := range clientEntity.Contacts() {
+		if c.Type() != clientagg.ContactTypeTelegram || !contactIDs[c.ID()] {
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimSpace(c.Value()), 10, 64)
+		if err == nil {
+			return id, nil
+		}
+	}
+	return 0, ErrNoTelegramChat
+}
@@ -0,0 +1,278 @@
+package cpassproviders_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/telegram"
+)
+
+const botToken = "123:token"
+
+type botCall struct {
+	method string
+	params map[string]string
+	files  map[string][]byte
+}
+
+// botAPI is a Bot API server double that records the calls and serves the files in files
+type botAPI struct {
+	mu    sync.Mutex
+	calls []botCall
+	files map[string][]byte
+}
+
+func newBotAPI(t *testing.T, files map[string][]byte) (*botAPI, *telegram.Bot) {
+	t.Helper()
+	api := &botAPI{files: files}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	bot, err := telegram.NewBot(botToken, telegram.WithAPIURL(server.URL))
+	require.NoError(t, err)
+	return api, bot
+}
+
+func (a *botAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if fileID, ok := strings.CutPrefix(r.URL.Path, "/file/bot"+botToken+"/files/"); ok {
+		_, _ = w.Write(a.files[fileID])
+		return
+	}
+	method := strings.TrimPrefix(r.URL.Path, "/bot"+botToken+"/")
+	call := botCall{method: method, params: map[string]string{}, files: map[string][]byte{}}
+	if err := r.ParseMultipartForm(1 << 20); err == nil {
+		for k, v := range r.MultipartForm.Value {
+			call.params[k] = v[0]
+		}
+		for k, v := range r.MultipartForm.File {
+			f, _ := v[0].Open()
+			call.files[k], _ = io.ReadAll(f)
+		}
+	} else {
+		_ = json.NewDecoder(r.Body).Decode(&call.params)
+	}
+	a.mu.Lock()
+	a.calls = append(a.calls, call)
+	a.mu.Unlock()
+
+	var result string
+	switch method {
+	case "getMe":
+		result = `{"id": 1, "is_bot": true, "first_name": "Bot", "username": "crm_bot"}`
+	case "getFile":
+		result = fmt.Sprintf(`{"file_id": %q, "file_unique_id": "u", "file_path": "files/%s"}`, call.params["file_id"], call.params["file_id"])
+	default:
+		result = fmt.Sprintf(`{"message_id": 1, "date": 0, "chat": {"id": %s, "type": "private"}}`, call.params["chat_id"])
+	}
+	_, _ = fmt.Fprintf(w, `{"ok": true, "result": %s}`, result)
+}
+
+func (a *botAPI) sent() []botCall {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var sent []botCall
+	for _, c := range a.calls {
+		if strings.HasPrefix(c.method, "send") {
+			sent = append(sent, c)
+		}
+	}
+	return sent
+}
+
+func (r *chatRepoStub) GetByClientID(context.Context, uint) (chat.Chat, error) {
+	return r.chat, nil
+}
+
+func (r *chatRepoStub) GetByExternalMessageID(context.Context, []string) (chat.Chat, error) {
+	return nil, persistence.ErrChatNotFound
+}
+
+func (r *clientRepoStub) GetByContactValue(_ context.Context, contactType client.ContactType, value string) (client.Client, error) {
+	for _, c := range r.client.Contacts() {
+		if c.Type() == contactType && c.Value() == value {
+			return r.client, nil
+		}
+	}
+	return nil, persistence.ErrClientNotFound
+}
+
+type uploadRepoStub struct {
+	upload.Repository
+}
+
+func (r *uploadRepoStub) GetByHash(context.Context, string) (upload.Upload, error) {
+	return nil, corepersistence.ErrUploadNotFound
+}
+
+func (r *uploadRepoStub) Create(_ context.Context, data upload.Upload) (upload.Upload, error) {
+	return data, nil
+}
+
+func telegramFixture(t *testing.T) (client.Client, chat.Chat, chat.Member) {
+	t.Helper()
+	clientEntity, err := client.New(
+		"Jane",
+		client.WithID(3),
+		client.WithContacts([]client.Contact{
+			client.NewContact(client.ContactTypeTelegram, "jane_doe", client.WithContactID(2)),
+			client.NewContact(client.ContactTypeTelegram, "777", client.WithContactID(4)),
+		}),
+	)
+	require.NoError(t, err)
+	member := chat.NewMember(chat.NewClientSender(3, 4, "Jane", ""), chat.TelegramTransport)
+	chatEntity := chat.New(
+		3,
+		chat.WithChatID(7),
+		chat.WithMembers([]chat.Member{member}),
+		chat.WithMessages([]chat.Message{
+			chat.NewMessage("Hi", member, chat.WithMessageID(10)),
+		}),
+	)
+	return clientEntity, chatEntity, member
+}
+
+func TestTelegramProvider_Send(t *testing.T) {
+	t.Parallel()
+
+	api, bot := newBotAPI(t, nil)
+	clientEntity, chatEntity, _ := telegramFixture(t)
+	photo := upload.NewWithID(
+		5, uuid.New(), "hash", "uploads/hash.png", "offer.png", 4,
+		mimetype.Lookup("image/png"), upload.UploadTypeImage, time.Now(), time.Now(),
+	)
+	reply := chat.NewMessage(
+		"Here is the offer",
+		chat.NewMember(chat.NewUserSender(1, "John", "Smith"), chat.TelegramTransport),
+		chat.WithMessageID(11),
+		chat.WithMessageChatID(7),
+		chat.WithAttachments([]upload.Upload{photo}),
+	)
+	provider := cpassproviders.NewTelegramProvider(
+		cpassproviders.TelegramConfig{},
+		bot,
+		nil,
+		&clientRepoStub{client: clientEntity},
+		&chatRepoStub{chat: chatEntity},
+		nil,
+		storageStub{"uploads/hash.png": []byte("\x89PNG")},
+	)
+	require.NoError(t, provider.Send(context.Background(), reply))
+
+	sent := api.sent()
+	require.Len(t, sent, 2)
+	assert.Equal(t, "sendMessage", sent[0].method)
+	assert.Equal(t, "777", sent[0].params["chat_id"])
+	assert.Equal(t, "Here is the offer", sent[0].params["text"])
+	assert.Equal(t, "sendPhoto", sent[1].method)
+	assert.Equal(t, "777", sent[1].params["chat_id"])
+	require.Len(t, sent[1].files, 1)
+	for _, data := range sent[1].files {
+		assert.Equal(t, []byte("\x89PNG"), data)
+	}
+
+	withoutTelegram, err := client.New("Bob", client.WithID(3))
+	require.NoError(t, err)
+	provider = cpassproviders.NewTelegramProvider(
+		cpassproviders.TelegramConfig{},
+		bot,
+		nil,
+		&clientRepoStub{client: withoutTelegram},
+		&chatRepoStub{chat: chat.New(3, chat.WithChatID(7))},
+		nil,
+		nil,
+	)
+	require.ErrorIs(t, provider.Send(context.Background(), reply), cpassproviders.ErrNoTelegramChat)
+}
+
+func TestTelegramProvider_HandleUpdate(t *testing.T) {
+	t.Parallel()
+
+	_, bot := newBotAPI(t, map[string][]byte{"doc-1": []byte("signed")})
+	clientEntity, chatEntity, member := telegramFixture(t)
+	provider := cpassproviders.NewTelegramProvider(
+		cpassproviders.TelegramConfig{TenantID: uuid.New()},
+		bot,
+		nil,
+		&clientRepoStub{client: clientEntity},
+		&chatRepoStub{chat: chatEntity},
+		&uploadRepoStub{},
+		storageStub{},
+	)
+	var received []chat.Message
+	provider.OnReceived(func(_ context.Context, msg chat.Message) error {
+		received = append(received, msg)
+		return nil
+	})
+
+	var update telegram.Update
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"update_id": 100,
+		"message": {
+			"message_id": 9,
+			"date": 1748858400,
+			"from": {"id": 777, "is_bot": false, "first_name": "Jane"},
+			"chat": {"id": 777, "type": "private"},
+			"caption": "Signed contract",
+			"document": {"file_id": "doc-1", "file_unique_id": "u1", "file_name": "contract.txt"}
+		}
+	}`), &update))
+	require.NoError(t, provider.HandleUpdate(context.Background(), update))
+
+	require.Len(t, received, 1)
+	msg := received[0]
+	assert.Equal(t, "Signed contract", msg.Message())
+	assert.Equal(t, uint(7), msg.ChatID())
+	assert.Equal(t, "telegram:777:9", msg.ExternalID())
+	assert.Equal(t, member.ID(), msg.Sender().ID(), "reuses the member of the telegram contact")
+	require.NotNil(t, msg.SentAt())
+	assert.Equal(t, int64(1748858400), msg.SentAt().Unix())
+	require.Len(t, msg.Attachments(), 1)
+	assert.Equal(t, "contract.txt", msg.Attachments()[0].Name())
+
+	group := update
+	group.Message.Chat.Type = "group"
+	require.NoError(t, provider.HandleUpdate(context.Background(), group))
+	assert.Len(t, received, 1, "group messages are ignored")
+}
+
+func TestTelegramProvider_WebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	_, bot := newBotAPI(t, nil)
+	provider := cpassproviders.NewTelegramProvider(
+		cpassproviders.TelegramConfig{TenantID: uuid.New(), WebhookSecret: "secret"},
+		bot, nil, nil, nil, nil, nil,
+	)
+	handler := provider.WebhookHandler()
+	body := `{"update_id": 1, "message": {"message_id": 1, "date": 0, "chat": {"id": -5, "type": "group"}, "text": "hi"}}`
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/telegram", strings.NewReader(body))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "wrong")
+	handler(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/telegram", strings.NewReader(body))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "secret")
+	handler(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
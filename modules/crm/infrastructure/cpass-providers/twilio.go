@@ -106,7 +106,7 @@ type TwilioProvider struct {
 	chatRepo   chat.Repository
 	client     *twilio.RestClient
 	validator  client.RequestValidator
-	callbacks  []func(msg chat.Message) error
+	callbacks  []func(ctx context.Context, msg chat.Message) error
 }
 
 func (s *TwilioProvider) Transport() chat.Transport {
@@ -161,7 +161,7 @@ func (s *TwilioProvider) Send(ctx context.Context, msg chat.Message) error {
 	return err
 }
 
-func (s *TwilioProvider) OnReceived(callback func(msg chat.Message) error) {
+func (s *TwilioProvider) OnReceived(callback func(ctx context.Context, msg chat.Message) error) {
 	s.callbacks = append(s.callbacks, callback)
 }
 
@@ -213,7 +213,7 @@ func (s *TwilioProvider) WebhookHandler(eventBus eventbus.EventBus) http.Handler
 				params["Body"],
 				member,
 			)
-			if err := cb(msg); err != nil {
+			if err := cb(r.Context(), msg); err != nil {
 				logger.WithError(err).Error("Failed to execute callback")
 				http.Error(w, "failed to execute callback", http.StatusInternalServerError)
 				return
@@ -0,0 +1,40 @@
+package cpassproviders
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+)
+
+// saveUpload stores a file received from a client, reusing the upload with the same content
+func saveUpload(
+	ctx context.Context,
+	uploadRepo upload.Repository,
+	storage upload.Storage,
+	name string,
+	data []byte,
+) (upload.Upload, error) {
+	dto := &upload.CreateDTO{
+		File: bytes.NewReader(data),
+		Name: name,
+		Size: len(data),
+	}
+	entity, content, err := dto.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	existing, err := uploadRepo.GetByHash(ctx, entity.Hash())
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, corepersistence.ErrUploadNotFound) {
+		return nil, err
+	}
+	if err := storage.Save(ctx, entity.Path(), content); err != nil {
+		return nil, err
+	}
+	return uploadRepo.Create(ctx, entity)
+}
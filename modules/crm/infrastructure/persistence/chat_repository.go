@@ -8,6 +8,7 @@ import (
 	"github.com/go-faster/errors"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
 	coremodels "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
@@ -47,6 +48,7 @@ const (
 			m.id,
 			m.chat_id,
 			m.message,
+			m.external_id,
 			m.sender_id,
 			m.read_at,
 			m.sent_at,
@@ -118,11 +120,14 @@ const (
 		INSERT INTO messages (
 			chat_id,
 			message,
+			external_id,
 			read_at,
 			sent_at,
 			sender_id,
 			created_at
-		) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+		) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	insertMessageAttachmentQuery = `INSERT INTO message_media (message_id, upload_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
 
 	updateMessageQuery = `
 		UPDATE messages SET
@@ -286,6 +291,7 @@ func (g *ChatRepository) queryMessages(ctx context.Context, query string, args .
 			&msg.ID,
 			&msg.ChatID,
 			&msg.Message,
+			&msg.ExternalID,
 			&msg.SenderID,
 			&msg.ReadAt,
 			&msg.SentAt,
@@ -443,6 +449,30 @@ func (g *ChatRepository) GetByClientID(ctx context.Context, clientID uint) (chat
 	return chats[0], nil
 }
 
+func (g *ChatRepository) GetByExternalMessageID(ctx context.Context, externalIDs []string) (chat.Chat, error) {
+	if len(externalIDs) == 0 {
+		return nil, ErrChatNotFound
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	q := repo.Join(
+		selectChatQuery,
+		"WHERE c.tenant_id = $1 AND c.id IN (SELECT m.chat_id FROM messages m WHERE m.external_id = ANY($2))",
+		"LIMIT 1",
+	)
+	chats, err := g.queryChats(ctx, q, tenantID, externalIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chat by external message id")
+	}
+	if len(chats) == 0 {
+		return nil, ErrChatNotFound
+	}
+	return chats[0], nil
+}
+
 func (g *ChatRepository) GetMemberByContact(ctx context.Context, contactType string, contactValue string) (chat.Member, error) {
 	query := `
 		SELECT 
@@ -485,6 +515,7 @@ func (g *ChatRepository) insertMessage(ctx context.Context, message *models.Mess
 		insertMessageQuery,
 		message.ChatID,
 		message.Message,
+		message.ExternalID,
 		message.ReadAt,
 		message.SentAt,
 		message.SenderID,
@@ -647,12 +678,29 @@ func (g *ChatRepository) saveMembers(ctx context.Context, chatID uint, data chat
 	return nil
 }
 
-func (g *ChatRepository) saveMessages(ctx context.Context, dbMessages []*models.Message) error {
-	for _, m := range dbMessages {
+func (g *ChatRepository) insertMessageAttachments(ctx context.Context, messageID uint, attachments []upload.Upload) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction")
+	}
+	for _, u := range attachments {
+		if _, err := tx.Exec(ctx, insertMessageAttachmentQuery, messageID, u.ID()); err != nil {
+			return errors.Wrapf(err, "failed to attach upload %d", u.ID())
+		}
+	}
+	return nil
+}
+
+func (g *ChatRepository) saveMessages(ctx context.Context, messages []chat.Message, dbMessages []*models.Message) error {
+	for i, m := range dbMessages {
 		if m.ID == 0 {
-			if _, err := g.insertMessage(ctx, m); err != nil {
+			id, err := g.insertMessage(ctx, m)
+			if err != nil {
 				return errors.Wrap(err, "failed to add message")
 			}
+			if err := g.insertMessageAttachments(ctx, id, messages[i].Attachments()); err != nil {
+				return err
+			}
 		} else {
 			if err := g.updateMessage(ctx, m); err != nil {
 				return errors.Wrap(err, "failed to update message")
@@ -721,7 +769,7 @@ func (g *ChatRepository) create(ctx context.Context, data chat.Chat) (chat.Chat,
 		m.ChatID = dbChat.ID
 	}
 
-	if err := g.saveMessages(ctx, dbMessages); err != nil {
+	if err := g.saveMessages(ctx, data.Messages(), dbMessages); err != nil {
 		return nil, err
 	}
 
@@ -754,7 +802,7 @@ func (g *ChatRepository) update(ctx context.Context, data chat.Chat) (chat.Chat,
 		m.ChatID = dbChat.ID
 	}
 
-	if err := g.saveMessages(ctx, dbMessages); err != nil {
+	if err := g.saveMessages(ctx, data.Messages(), dbMessages); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,407 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	clientduplicate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/client-duplicate"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrClientDuplicateNotFound = errors.New("client duplicate not found")
+)
+
+const (
+	selectClientDuplicateQuery = `
+		SELECT id, tenant_id, client_id, duplicate_id, score, reasons, status, created_at, updated_at
+		FROM client_duplicates`
+	countClientDuplicateQuery   = `SELECT COUNT(*) FROM client_duplicates`
+	enqueueClientDuplicateQuery = `
+		INSERT INTO client_duplicates (tenant_id, client_id, duplicate_id, score, reasons, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (client_id, duplicate_id) DO UPDATE
+		SET score = EXCLUDED.score, reasons = EXCLUDED.reasons, updated_at = EXCLUDED.updated_at
+		WHERE client_duplicates.status = 'pending'`
+	updateClientDuplicateQuery = `
+		UPDATE client_duplicates
+		SET score = $1, reasons = $2, status = $3, updated_at = $4
+		WHERE id = $5 AND tenant_id = $6`
+
+	selectClientChatIDQuery = `SELECT id FROM chats WHERE client_id = $1 AND tenant_id = $2`
+	moveChatMessagesQuery   = `UPDATE messages SET chat_id = $1 WHERE chat_id = $2`
+	moveChatMembersQuery    = `UPDATE chat_members SET chat_id = $1 WHERE chat_id = $2`
+	touchMergedChatQuery    = `
+		UPDATE chats
+		SET last_message_at = GREATEST(last_message_at, (SELECT last_message_at FROM chats WHERE id = $2))
+		WHERE id = $1`
+	deleteMergedChatQuery  = `DELETE FROM chats WHERE id = $1`
+	reassignChatQuery      = `UPDATE chats SET client_id = $1 WHERE id = $2`
+	countChatMessagesQuery = `SELECT COUNT(*) FROM messages WHERE chat_id = $1`
+	reassignMembersQuery   = `UPDATE chat_members SET client_id = $1 WHERE client_id = $2`
+	// Members of a contact the survivor already has switch to the survivor's copy, unless that one is taken
+	repointMemberContactsQuery = `
+		UPDATE chat_members cm
+		SET client_contact_id = sc.id
+		FROM client_contacts mc
+		JOIN client_contacts sc ON sc.client_id = $1 AND sc.contact_type = mc.contact_type AND sc.contact_value = mc.contact_value
+		WHERE mc.client_id = $2
+		  AND cm.client_contact_id = mc.id
+		  AND NOT EXISTS (SELECT 1 FROM chat_members x WHERE x.client_contact_id = sc.id)`
+	moveContactsQuery = `
+		UPDATE client_contacts mc
+		SET client_id = $1, updated_at = now()
+		WHERE mc.client_id = $2
+		  AND NOT EXISTS (
+			SELECT 1 FROM client_contacts sc
+			WHERE sc.client_id = $1 AND sc.contact_type = mc.contact_type AND sc.contact_value = mc.contact_value
+		  )`
+	moveDealsQuery = `UPDATE deals SET client_id = $1, updated_at = now() WHERE client_id = $2 AND tenant_id = $3`
+	// The survivor may have adopted the passport of the merged client, which must outlive its deletion
+	releasePassportQuery = `
+		UPDATE clients SET passport_id = NULL
+		WHERE id = $2 AND passport_id = (SELECT passport_id FROM clients WHERE id = $1)`
+	reassignMergesQuery    = `UPDATE client_merges SET survivor_id = $1 WHERE survivor_id = $2`
+	insertClientMergeQuery = `
+		INSERT INTO client_merges (
+			tenant_id, survivor_id, merged_id, merged_client, moved_contacts, moved_messages, moved_deals, merged_by, merged_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	selectClientMergesQuery = `
+		SELECT id, tenant_id, survivor_id, merged_id, merged_client, moved_contacts, moved_messages, moved_deals, merged_by, merged_at
+		FROM client_merges
+		WHERE survivor_id = $1 AND tenant_id = $2
+		ORDER BY merged_at DESC, id DESC`
+)
+
+type ClientDuplicateRepository struct{}
+
+func NewClientDuplicateRepository() clientduplicate.Repository {
+	return &ClientDuplicateRepository{}
+}
+
+func (r *ClientDuplicateRepository) buildFilters(ctx context.Context, params *clientduplicate.FindParams) ([]string, []interface{}, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	where := []string{"tenant_id = $1"}
+	args := []interface{}{tenantID}
+	if params.Status != "" {
+		where = append(where, fmt.Sprintf("status = $%d", len(args)+1))
+		args = append(args, string(params.Status))
+	}
+	if params.ClientID != 0 {
+		where = append(where, fmt.Sprintf("(client_id = $%d OR duplicate_id = $%d)", len(args)+1, len(args)+1))
+		args = append(args, params.ClientID)
+	}
+	return where, args, nil
+}
+
+func (r *ClientDuplicateRepository) Count(ctx context.Context, params *clientduplicate.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countClientDuplicateQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *ClientDuplicateRepository) GetPaginated(ctx context.Context, params *clientduplicate.FindParams) ([]clientduplicate.Duplicate, error) {
+	where, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return r.queryDuplicates(ctx, repo.Join(
+		selectClientDuplicateQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY score DESC, id",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	), args...)
+}
+
+func (r *ClientDuplicateRepository) GetByID(ctx context.Context, id uint) (clientduplicate.Duplicate, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	duplicates, err := r.queryDuplicates(ctx, selectClientDuplicateQuery+" WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(duplicates) == 0 {
+		return nil, ErrClientDuplicateNotFound
+	}
+	return duplicates[0], nil
+}
+
+func (r *ClientDuplicateRepository) Enqueue(ctx context.Context, data clientduplicate.Duplicate) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow, err := ToDBClientDuplicate(data)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		ctx,
+		enqueueClientDuplicateQuery,
+		tenantID,
+		dbRow.ClientID,
+		dbRow.DuplicateID,
+		dbRow.Score,
+		dbRow.Reasons,
+		dbRow.Status,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	); err != nil {
+		return errors.Wrap(err, "failed to enqueue client duplicate")
+	}
+	return nil
+}
+
+func (r *ClientDuplicateRepository) Update(ctx context.Context, data clientduplicate.Duplicate) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow, err := ToDBClientDuplicate(data)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(
+		ctx,
+		updateClientDuplicateQuery,
+		dbRow.Score,
+		dbRow.Reasons,
+		dbRow.Status,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+		tenantID,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrClientDuplicateNotFound
+	}
+	return nil
+}
+
+func (r *ClientDuplicateRepository) Merge(ctx context.Context, record clientduplicate.MergeRecord) (clientduplicate.MergeRecord, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return record, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return record, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	record.TenantID = tenantID
+	survivorID, mergedID := record.SurvivorID, record.MergedID
+
+	survivorChat, err := r.chatID(ctx, survivorID)
+	if err != nil {
+		return record, err
+	}
+	mergedChat, err := r.chatID(ctx, mergedID)
+	if err != nil {
+		return record, err
+	}
+	switch {
+	case mergedChat.Valid && survivorChat.Valid:
+		result, err := tx.Exec(ctx, moveChatMessagesQuery, survivorChat.Int32, mergedChat.Int32)
+		if err != nil {
+			return record, errors.Wrap(err, "failed to move messages")
+		}
+		record.MovedMessages = int(result.RowsAffected())
+		if _, err := tx.Exec(ctx, moveChatMembersQuery, survivorChat.Int32, mergedChat.Int32); err != nil {
+			return record, errors.Wrap(err, "failed to move chat members")
+		}
+		if _, err := tx.Exec(ctx, touchMergedChatQuery, survivorChat.Int32, mergedChat.Int32); err != nil {
+			return record, err
+		}
+		if _, err := tx.Exec(ctx, deleteMergedChatQuery, mergedChat.Int32); err != nil {
+			return record, errors.Wrap(err, "failed to delete merged chat")
+		}
+	case mergedChat.Valid:
+		if err := tx.QueryRow(ctx, countChatMessagesQuery, mergedChat.Int32).Scan(&record.MovedMessages); err != nil {
+			return record, err
+		}
+		if _, err := tx.Exec(ctx, reassignChatQuery, survivorID, mergedChat.Int32); err != nil {
+			return record, errors.Wrap(err, "failed to reassign chat")
+		}
+	}
+	if _, err := tx.Exec(ctx, reassignMembersQuery, survivorID, mergedID); err != nil {
+		return record, errors.Wrap(err, "failed to reassign chat members")
+	}
+
+	if _, err := tx.Exec(ctx, repointMemberContactsQuery, survivorID, mergedID); err != nil {
+		return record, errors.Wrap(err, "failed to repoint chat members")
+	}
+	result, err := tx.Exec(ctx, moveContactsQuery, survivorID, mergedID)
+	if err != nil {
+		return record, errors.Wrap(err, "failed to move contacts")
+	}
+	record.MovedContacts = int(result.RowsAffected())
+
+	result, err = tx.Exec(ctx, moveDealsQuery, survivorID, mergedID, tenantID)
+	if err != nil {
+		return record, errors.Wrap(err, "failed to move deals")
+	}
+	record.MovedDeals = int(result.RowsAffected())
+
+	if _, err := tx.Exec(ctx, releasePassportQuery, survivorID, mergedID); err != nil {
+		return record, err
+	}
+	if _, err := tx.Exec(ctx, reassignMergesQuery, survivorID, mergedID); err != nil {
+		return record, err
+	}
+
+	dbRow, err := ToDBClientMerge(record)
+	if err != nil {
+		return record, err
+	}
+	if err := tx.QueryRow(
+		ctx,
+		insertClientMergeQuery,
+		tenantID,
+		dbRow.SurvivorID,
+		dbRow.MergedID,
+		dbRow.MergedClient,
+		dbRow.MovedContacts,
+		dbRow.MovedMessages,
+		dbRow.MovedDeals,
+		dbRow.MergedBy,
+		dbRow.MergedAt,
+	).Scan(&record.ID); err != nil {
+		return record, errors.Wrap(err, "failed to insert client merge")
+	}
+	return record, nil
+}
+
+func (r *ClientDuplicateRepository) GetMerges(ctx context.Context, survivorID uint) ([]clientduplicate.MergeRecord, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	rows, err := tx.Query(ctx, selectClientMergesQuery, survivorID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]clientduplicate.MergeRecord, 0)
+	for rows.Next() {
+		var m models.ClientMerge
+		if err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.SurvivorID,
+			&m.MergedID,
+			&m.MergedClient,
+			&m.MovedContacts,
+			&m.MovedMessages,
+			&m.MovedDeals,
+			&m.MergedBy,
+			&m.MergedAt,
+		); err != nil {
+			return nil, err
+		}
+		record, err := ToDomainClientMerge(&m)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *ClientDuplicateRepository) chatID(ctx context.Context, clientID uint) (sql.NullInt32, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return sql.NullInt32{}, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	rows, err := tx.Query(ctx, selectClientChatIDQuery, clientID, tenantID)
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+	defer rows.Close()
+	var id sql.NullInt32
+	if rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return sql.NullInt32{}, err
+		}
+	}
+	return id, rows.Err()
+}
+
+func (r *ClientDuplicateRepository) queryDuplicates(ctx context.Context, query string, args ...interface{}) ([]clientduplicate.Duplicate, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	duplicates := make([]clientduplicate.Duplicate, 0)
+	for rows.Next() {
+		var d models.ClientDuplicate
+		if err := rows.Scan(
+			&d.ID,
+			&d.TenantID,
+			&d.ClientID,
+			&d.DuplicateID,
+			&d.Score,
+			&d.Reasons,
+			&d.Status,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := ToDomainClientDuplicate(&d)
+		if err != nil {
+			return nil, err
+		}
+		duplicates = append(duplicates, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return duplicates, nil
+}
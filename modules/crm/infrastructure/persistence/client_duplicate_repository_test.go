@@ -0,0 +1,116 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	clientduplicate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/client-duplicate"
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func TestClientDuplicateRepository(t *testing.T) {
+	t.Parallel()
+	f := setupTest(t)
+
+	clientRepo := persistence.NewClientRepository(corepersistence.NewPassportRepository())
+	chatRepo := persistence.NewChatRepository()
+	dealRepo := persistence.NewDealRepository()
+	dupRepo := persistence.NewClientDuplicateRepository()
+
+	survivor, err := clientRepo.Save(f.Ctx, createTestClient(t, f.TenantID(), false))
+	require.NoError(t, err)
+	merged, err := client.New(
+		"Jon",
+		client.WithTenantID(f.TenantID()),
+		client.WithLastName("Doe"),
+		client.WithContacts([]client.Contact{
+			client.NewContact(client.ContactTypePhone, "12345678901"),
+			client.NewContact(client.ContactTypeTelegram, "jon_doe"),
+		}),
+	)
+	require.NoError(t, err)
+	merged, err = clientRepo.Save(f.Ctx, merged)
+	require.NoError(t, err)
+
+	_, err = chatRepo.Save(f.Ctx, chat.New(survivor.ID(), chat.WithTenantID(f.TenantID())))
+	require.NoError(t, err)
+	member := chat.NewMember(
+		chat.NewClientSender(merged.ID(), merged.Contacts()[0].ID(), "Jon", "Doe"),
+		chat.TelegramTransport,
+		chat.WithMemberTenantID(f.TenantID()),
+	)
+	_, err = chatRepo.Save(f.Ctx, chat.New(
+		merged.ID(),
+		chat.WithTenantID(f.TenantID()),
+		chat.WithMessages([]chat.Message{chat.NewMessage("Hi", member)}),
+	))
+	require.NoError(t, err)
+
+	require.NoError(t, corepersistence.NewCurrencyRepository().Create(f.Ctx, &currency.USD))
+	stage, err := persistence.NewPipelineStageRepository().Create(f.Ctx, pipelinestage.New("Lead"))
+	require.NoError(t, err)
+	_, err = dealRepo.Create(f.Ctx, deal.New("Renewal", merged.ID(), money.New(1000, "USD"), stage.ID()))
+	require.NoError(t, err)
+
+	t.Run("Enqueue keeps dismissed pairs dismissed", func(t *testing.T) {
+		pair := clientduplicate.New(merged.ID(), survivor.ID(), 70, []clientduplicate.Reason{clientduplicate.ReasonPhone, clientduplicate.ReasonName})
+		require.NoError(t, dupRepo.Enqueue(f.Ctx, pair))
+		require.NoError(t, dupRepo.Enqueue(f.Ctx, pair))
+
+		pending, err := dupRepo.GetPaginated(f.Ctx, &clientduplicate.FindParams{Status: clientduplicate.StatusPending})
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, survivor.ID(), pending[0].ClientID())
+		assert.Equal(t, []clientduplicate.Reason{clientduplicate.ReasonPhone, clientduplicate.ReasonName}, pending[0].Reasons())
+
+		require.NoError(t, dupRepo.Update(f.Ctx, pending[0].Dismiss()))
+		require.NoError(t, dupRepo.Enqueue(f.Ctx, pair))
+		count, err := dupRepo.Count(f.Ctx, &clientduplicate.FindParams{Status: clientduplicate.StatusPending})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		record, err := dupRepo.Merge(f.Ctx, clientduplicate.MergeRecord{
+			SurvivorID:   survivor.ID(),
+			MergedID:     merged.ID(),
+			MergedClient: clientduplicate.Snapshot(merged),
+			MergedAt:     time.Now(),
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, record.ID)
+		assert.Equal(t, 1, record.MovedContacts, "the phone contact is already on the survivor")
+		assert.Equal(t, 1, record.MovedMessages)
+		assert.Equal(t, 1, record.MovedDeals)
+
+		require.NoError(t, clientRepo.Delete(f.Ctx, merged.ID()))
+
+		survivorChat, err := chatRepo.GetByClientID(f.Ctx, survivor.ID())
+		require.NoError(t, err)
+		require.Len(t, survivorChat.Messages(), 1)
+		assert.Equal(t, "Hi", survivorChat.Messages()[0].Message())
+
+		fetched, err := clientRepo.GetByID(f.Ctx, survivor.ID())
+		require.NoError(t, err)
+		assert.Len(t, fetched.Contacts(), 2)
+
+		deals, err := dealRepo.GetPaginated(f.Ctx, &deal.FindParams{ClientID: survivor.ID()})
+		require.NoError(t, err)
+		assert.Len(t, deals, 1)
+
+		merges, err := dupRepo.GetMerges(f.Ctx, survivor.ID())
+		require.NoError(t, err)
+		require.Len(t, merges, 1)
+		assert.Equal(t, "Jon", merges[0].MergedClient["first_name"])
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/passport"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 )
@@ -35,6 +36,9 @@ const (
 			c.passport_id,
 			c.pin,
 			c.comments,
+			c.created_by_id,
+			c.assignee_id,
+			c.assignee_group_id,
 			c.created_at,
 			c.updated_at
 		FROM clients c
@@ -78,6 +82,7 @@ func NewClientRepository(passportRepo passport.Repository) client.Repository {
 			client.LastName:    "c.last_name",
 			client.MiddleName:  "c.middle_name",
 			client.PhoneNumber: "c.phone_number",
+			client.Email:       "c.email",
 			client.UpdatedAt:   "c.updated_at",
 			client.CreatedAt:   "c.created_at",
 			client.TenantID:    "c.tenant_id",
@@ -121,6 +126,9 @@ func (g *ClientRepository) queryClients(
 			&c.PassportID,
 			&c.Pin,
 			&c.Comments,
+			&c.CreatedBy,
+			&c.AssigneeID,
+			&c.AssigneeGroupID,
 			&c.CreatedAt,
 			&c.UpdatedAt,
 		); err != nil {
@@ -223,6 +231,25 @@ func (g *ClientRepository) exists(ctx context.Context, id uint) (bool, error) {
 	return exists, nil
 }
 
+// ownerFilter narrows the query down to the clients created by, assigned to or shared with
+// the user when they hold the "own" read permission only
+func ownerFilter(ctx context.Context, where []string, args []interface{}) ([]string, []interface{}) {
+	u, ok := composables.UseOwnScope(ctx, permissions.ClientRead)
+	if !ok {
+		return where, args
+	}
+	userIdx, groupsIdx := len(args)+1, len(args)+2
+	where = append(where, fmt.Sprintf(
+		"(c.created_by_id = $%d OR c.assignee_id = $%d OR c.assignee_group_id = ANY($%d::uuid[]))",
+		userIdx, userIdx, groupsIdx,
+	))
+	groupIDs := make([]string, 0, len(u.GroupIDs()))
+	for _, id := range u.GroupIDs() {
+		groupIDs = append(groupIDs, id.String())
+	}
+	return where, append(args, u.ID(), groupIDs)
+}
+
 func (g *ClientRepository) GetPaginated(
 	ctx context.Context, params *client.FindParams,
 ) ([]client.Client, error) {
@@ -238,6 +265,8 @@ func (g *ClientRepository) GetPaginated(
 	where = append(where, fmt.Sprintf("c.tenant_id = $%d", len(args)+1))
 	args = append(args, tenantID)
 
+	where, args = ownerFilter(ctx, where, args)
+
 	// Apply filters
 	for _, filter := range params.Filters {
 		column, ok := g.fieldMap[filter.Column]
@@ -250,7 +279,7 @@ func (g *ClientRepository) GetPaginated(
 
 	if params.Search != "" {
 		searchPlaceholder := fmt.Sprintf("$%d", len(args)+1)
-		where = append(where, fmt.Sprintf("c.first_name ILIKE %s OR c.last_name ILIKE %s OR c.middle_name ILIKE %s OR c.phone_number ILIKE %s", searchPlaceholder, searchPlaceholder, searchPlaceholder, searchPlaceholder))
+		where = append(where, fmt.Sprintf("(c.first_name ILIKE %s OR c.last_name ILIKE %s OR c.middle_name ILIKE %s OR c.phone_number ILIKE %s)", searchPlaceholder, searchPlaceholder, searchPlaceholder, searchPlaceholder))
 		args = append(args, "%"+params.Search+"%")
 	}
 
@@ -286,6 +315,8 @@ func (g *ClientRepository) Count(ctx context.Context, params *client.FindParams)
 	where = append(where, fmt.Sprintf("c.tenant_id = $%d", len(args)+1))
 	args = append(args, tenantID)
 
+	where, args = ownerFilter(ctx, where, args)
+
 	// Apply filters
 	for _, filter := range params.Filters {
 		column, ok := g.fieldMap[filter.Column]
@@ -298,7 +329,7 @@ func (g *ClientRepository) Count(ctx context.Context, params *client.FindParams)
 
 	if params.Search != "" {
 		searchPlaceholder := fmt.Sprintf("$%d", len(args)+1)
-		where = append(where, fmt.Sprintf("c.first_name ILIKE %s OR c.last_name ILIKE %s OR c.middle_name ILIKE %s OR c.phone_number ILIKE %s", searchPlaceholder, searchPlaceholder, searchPlaceholder, searchPlaceholder))
+		where = append(where, fmt.Sprintf("(c.first_name ILIKE %s OR c.last_name ILIKE %s OR c.middle_name ILIKE %s OR c.phone_number ILIKE %s)", searchPlaceholder, searchPlaceholder, searchPlaceholder, searchPlaceholder))
 		args = append(args, "%"+params.Search+"%")
 	}
 
@@ -381,6 +412,9 @@ func (g *ClientRepository) create(ctx context.Context, data client.Client) (clie
 		"gender",
 		"passport_id",
 		"pin",
+		"created_by_id",
+		"assignee_id",
+		"assignee_group_id",
 		"created_at",
 		"updated_at",
 	}
@@ -397,6 +431,9 @@ func (g *ClientRepository) create(ctx context.Context, data client.Client) (clie
 		dbRow.Gender,
 		dbRow.PassportID,
 		dbRow.Pin,
+		dbRow.CreatedBy,
+		dbRow.AssigneeID,
+		dbRow.AssigneeGroupID,
 		dbRow.CreatedAt,
 		dbRow.UpdatedAt,
 	}
@@ -492,6 +529,8 @@ func (g *ClientRepository) update(ctx context.Context, data client.Client) (clie
 		"passport_id",
 		"pin",
 		"comments",
+		"assignee_id",
+		"assignee_group_id",
 		"updated_at",
 	}
 
@@ -507,6 +546,8 @@ func (g *ClientRepository) update(ctx context.Context, data client.Client) (clie
 		dbRow.PassportID,
 		dbRow.Pin,
 		dbRow.Comments,
+		dbRow.AssigneeID,
+		dbRow.AssigneeGroupID,
 		dbRow.UpdatedAt,
 	}
 
@@ -18,9 +18,13 @@ import (
 	coremodels "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	clientduplicate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/client-duplicate"
 	messagetemplate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/money"
 )
 
 func ToDomainClient(dbRow *models.Client, passportData passport.Passport) (client.Client, error) {
@@ -34,6 +38,9 @@ func ToDomainClient(dbRow *models.Client, passportData passport.Passport) (clien
 		client.WithTenantID(tenantID),
 		client.WithCreatedAt(dbRow.CreatedAt),
 		client.WithUpdatedAt(dbRow.UpdatedAt),
+		client.WithCreatedBy(uint(dbRow.CreatedBy.Int64)),
+		client.WithAssigneeID(uint(dbRow.AssigneeID.Int64)),
+		client.WithAssigneeGroupID(mapping.SQLNullStringToUUID(dbRow.AssigneeGroupID)),
 	}
 
 	if dbRow.LastName.Valid {
@@ -133,33 +140,37 @@ func ToDBClient(domainEntity client.Client) *models.Client {
 	}
 
 	return &models.Client{
-		ID:          domainEntity.ID(),
-		TenantID:    domainEntity.TenantID().String(),
-		FirstName:   domainEntity.FirstName(),
-		LastName:    mapping.ValueToSQLNullString(domainEntity.LastName()),
-		MiddleName:  mapping.ValueToSQLNullString(domainEntity.MiddleName()),
-		PhoneNumber: phone,
-		Address:     mapping.ValueToSQLNullString(domainEntity.Address()),
-		Email:       email,
-		DateOfBirth: mapping.PointerToSQLNullTime(domainEntity.DateOfBirth()),
-		Gender:      gender,
-		PassportID:  passportID,
-		Pin:         pin,
-		Comments:    comments,
-		CreatedAt:   domainEntity.CreatedAt(),
-		UpdatedAt:   domainEntity.UpdatedAt(),
+		ID:              domainEntity.ID(),
+		TenantID:        domainEntity.TenantID().String(),
+		FirstName:       domainEntity.FirstName(),
+		LastName:        mapping.ValueToSQLNullString(domainEntity.LastName()),
+		MiddleName:      mapping.ValueToSQLNullString(domainEntity.MiddleName()),
+		PhoneNumber:     phone,
+		Address:         mapping.ValueToSQLNullString(domainEntity.Address()),
+		Email:           email,
+		DateOfBirth:     mapping.PointerToSQLNullTime(domainEntity.DateOfBirth()),
+		Gender:          gender,
+		PassportID:      passportID,
+		Pin:             pin,
+		Comments:        comments,
+		CreatedBy:       mapping.ValueToSQLNullInt64(int64(domainEntity.CreatedBy())),
+		AssigneeID:      mapping.ValueToSQLNullInt64(int64(domainEntity.AssigneeID())),
+		AssigneeGroupID: mapping.UUIDToSQLNullString(domainEntity.AssigneeGroupID()),
+		CreatedAt:       domainEntity.CreatedAt(),
+		UpdatedAt:       domainEntity.UpdatedAt(),
 	}
 }
 
 func ToDBMessage(entity chat.Message) *models.Message {
 	dbMessage := &models.Message{
-		ID:        entity.ID(),
-		Message:   entity.Message(),
-		ChatID:    entity.ChatID(),
-		ReadAt:    mapping.PointerToSQLNullTime(entity.ReadAt()),
-		SentAt:    mapping.PointerToSQLNullTime(entity.SentAt()),
-		SenderID:  entity.Sender().ID().String(),
-		CreatedAt: entity.CreatedAt(),
+		ID:         entity.ID(),
+		Message:    entity.Message(),
+		ExternalID: mapping.ValueToSQLNullString(entity.ExternalID()),
+		ChatID:     entity.ChatID(),
+		ReadAt:     mapping.PointerToSQLNullTime(entity.ReadAt()),
+		SentAt:     mapping.PointerToSQLNullTime(entity.SentAt()),
+		SenderID:   entity.Sender().ID().String(),
+		CreatedAt:  entity.CreatedAt(),
 	}
 	return dbMessage
 }
@@ -182,6 +193,7 @@ func ToDomainMessage(
 		sender,
 		chat.WithMessageChatID(dbRow.ChatID),
 		chat.WithMessageID(dbRow.ID),
+		chat.WithExternalID(dbRow.ExternalID.String),
 		chat.WithReadAt(mapping.SQLNullTimeToPointer(dbRow.ReadAt)),
 		chat.WithMessageSentAt(mapping.SQLNullTimeToPointer(dbRow.SentAt)),
 		chat.WithAttachments(uploads),
@@ -502,3 +514,179 @@ func WebsiteMetaToSender(baseSender chat.Sender, meta *models.WebsiteMeta) (chat
 
 	return chat.NewWebsiteSender(baseSender, phoneObj, emailObj), nil
 }
+
+func ToDomainPipelineStage(dbStage *models.PipelineStage) (pipelinestage.Stage, error) {
+	tenantID, err := uuid.Parse(dbStage.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return pipelinestage.New(
+		dbStage.Name,
+		pipelinestage.WithID(dbStage.ID),
+		pipelinestage.WithTenantID(tenantID),
+		pipelinestage.WithPosition(dbStage.Position),
+		pipelinestage.WithProbability(dbStage.Probability),
+		pipelinestage.WithOutcome(pipelinestage.Outcome(dbStage.Outcome)),
+		pipelinestage.WithCreatedAt(dbStage.CreatedAt),
+		pipelinestage.WithUpdatedAt(dbStage.UpdatedAt),
+	), nil
+}
+
+func ToDBPipelineStage(entity pipelinestage.Stage) *models.PipelineStage {
+	return &models.PipelineStage{
+		ID:          entity.ID(),
+		TenantID:    entity.TenantID().String(),
+		Name:        entity.Name(),
+		Position:    entity.Position(),
+		Probability: entity.Probability(),
+		Outcome:     string(entity.Outcome()),
+		CreatedAt:   entity.CreatedAt(),
+		UpdatedAt:   entity.UpdatedAt(),
+	}
+}
+
+func ToDomainDeal(dbDeal *models.Deal) (deal.Deal, error) {
+	tenantID, err := uuid.Parse(dbDeal.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return deal.New(
+		dbDeal.Title,
+		dbDeal.ClientID,
+		money.New(dbDeal.Amount, dbDeal.CurrencyID),
+		dbDeal.StageID,
+		deal.WithID(dbDeal.ID),
+		deal.WithTenantID(tenantID),
+		deal.WithProbability(dbDeal.Probability),
+		deal.WithOwnerID(uint(dbDeal.OwnerID.Int32)),
+		deal.WithExpectedCloseDate(mapping.SQLNullTimeToPointer(dbDeal.ExpectedCloseDate)),
+		deal.WithClosedAt(mapping.SQLNullTimeToPointer(dbDeal.ClosedAt)),
+		deal.WithDescription(dbDeal.Description.String),
+		deal.WithCreatedAt(dbDeal.CreatedAt),
+		deal.WithUpdatedAt(dbDeal.UpdatedAt),
+	), nil
+}
+
+func ToDBDeal(entity deal.Deal) *models.Deal {
+	dbDeal := &models.Deal{
+		ID:                entity.ID(),
+		TenantID:          entity.TenantID().String(),
+		Title:             entity.Title(),
+		ClientID:          entity.ClientID(),
+		StageID:           entity.StageID(),
+		Probability:       entity.Probability(),
+		OwnerID:           mapping.ValueToSQLNullInt32(int32(entity.OwnerID())),
+		ExpectedCloseDate: mapping.PointerToSQLNullTime(entity.ExpectedCloseDate()),
+		ClosedAt:          mapping.PointerToSQLNullTime(entity.ClosedAt()),
+		Description:       mapping.ValueToSQLNullString(entity.Description()),
+		CreatedAt:         entity.CreatedAt(),
+		UpdatedAt:         entity.UpdatedAt(),
+	}
+	if amount := entity.Amount(); amount != nil {
+		dbDeal.Amount = amount.Amount()
+		dbDeal.CurrencyID = amount.Currency().Code
+	}
+	return dbDeal
+}
+
+func ToDomainDealStageChange(dbChange *models.DealStageChange) deal.StageChange {
+	return deal.StageChange{
+		DealID:      dbChange.DealID,
+		FromStageID: uint(dbChange.FromStageID.Int32),
+		ToStageID:   uint(dbChange.ToStageID.Int32),
+		ChangedBy:   uint(dbChange.ChangedBy.Int32),
+		ChangedAt:   dbChange.ChangedAt,
+	}
+}
+
+func ToDBDealStageChange(change deal.StageChange) *models.DealStageChange {
+	return &models.DealStageChange{
+		DealID:      change.DealID,
+		FromStageID: mapping.ValueToSQLNullInt32(int32(change.FromStageID)),
+		ToStageID:   mapping.ValueToSQLNullInt32(int32(change.ToStageID)),
+		ChangedBy:   mapping.ValueToSQLNullInt32(int32(change.ChangedBy)),
+		ChangedAt:   change.ChangedAt,
+	}
+}
+
+func ToDomainClientDuplicate(dbRow *models.ClientDuplicate) (clientduplicate.Duplicate, error) {
+	tenantID, err := uuid.Parse(dbRow.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	reasons := make([]clientduplicate.Reason, 0)
+	if err := json.Unmarshal(dbRow.Reasons, &reasons); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal duplicate reasons")
+	}
+	return clientduplicate.New(
+		dbRow.ClientID,
+		dbRow.DuplicateID,
+		dbRow.Score,
+		reasons,
+		clientduplicate.WithID(dbRow.ID),
+		clientduplicate.WithTenantID(tenantID),
+		clientduplicate.WithStatus(clientduplicate.Status(dbRow.Status)),
+		clientduplicate.WithCreatedAt(dbRow.CreatedAt),
+		clientduplicate.WithUpdatedAt(dbRow.UpdatedAt),
+	), nil
+}
+
+func ToDBClientDuplicate(entity clientduplicate.Duplicate) (*models.ClientDuplicate, error) {
+	reasons, err := json.Marshal(entity.Reasons())
+	if err != nil {
+		return nil, err
+	}
+	return &models.ClientDuplicate{
+		ID:          entity.ID(),
+		TenantID:    entity.TenantID().String(),
+		ClientID:    entity.ClientID(),
+		DuplicateID: entity.DuplicateID(),
+		Score:       entity.Score(),
+		Reasons:     reasons,
+		Status:      string(entity.Status()),
+		CreatedAt:   entity.CreatedAt(),
+		UpdatedAt:   entity.UpdatedAt(),
+	}, nil
+}
+
+func ToDomainClientMerge(dbRow *models.ClientMerge) (clientduplicate.MergeRecord, error) {
+	tenantID, err := uuid.Parse(dbRow.TenantID)
+	if err != nil {
+		return clientduplicate.MergeRecord{}, err
+	}
+	snapshot := make(map[string]string)
+	if err := json.Unmarshal(dbRow.MergedClient, &snapshot); err != nil {
+		return clientduplicate.MergeRecord{}, errors.Wrap(err, "failed to unmarshal merged client")
+	}
+	return clientduplicate.MergeRecord{
+		ID:            dbRow.ID,
+		TenantID:      tenantID,
+		SurvivorID:    uint(dbRow.SurvivorID.Int32),
+		MergedID:      dbRow.MergedID,
+		MergedClient:  snapshot,
+		MovedContacts: dbRow.MovedContacts,
+		MovedMessages: dbRow.MovedMessages,
+		MovedDeals:    dbRow.MovedDeals,
+		MergedBy:      uint(dbRow.MergedBy.Int32),
+		MergedAt:      dbRow.MergedAt,
+	}, nil
+}
+
+func ToDBClientMerge(record clientduplicate.MergeRecord) (*models.ClientMerge, error) {
+	snapshot, err := json.Marshal(record.MergedClient)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ClientMerge{
+		ID:            record.ID,
+		TenantID:      record.TenantID.String(),
+		SurvivorID:    mapping.ValueToSQLNullInt32(int32(record.SurvivorID)),
+		MergedID:      record.MergedID,
+		MergedClient:  snapshot,
+		MovedContacts: record.MovedContacts,
+		MovedMessages: record.MovedMessages,
+		MovedDeals:    record.MovedDeals,
+		MergedBy:      mapping.ValueToSQLNullInt32(int32(record.MergedBy)),
+		MergedAt:      record.MergedAt,
+	}, nil
+}
@@ -0,0 +1,371 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrDealNotFound = errors.New("deal not found")
+)
+
+const (
+	selectDealQuery = `
+		SELECT
+			d.id,
+			d.tenant_id,
+			d.title,
+			d.client_id,
+			d.stage_id,
+			d.amount,
+			d.currency_id,
+			d.probability,
+			d.owner_id,
+			d.expected_close_date,
+			d.closed_at,
+			d.description,
+			d.created_at,
+			d.updated_at
+		FROM deals d`
+
+	countDealQuery = `SELECT COUNT(*) FROM deals d`
+
+	insertDealQuery = `
+		INSERT INTO deals (
+			tenant_id,
+			title,
+			client_id,
+			stage_id,
+			amount,
+			currency_id,
+			probability,
+			owner_id,
+			expected_close_date,
+			closed_at,
+			description,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
+
+	updateDealQuery = `
+		UPDATE deals
+		SET title = $1,
+			client_id = $2,
+			stage_id = $3,
+			amount = $4,
+			currency_id = $5,
+			probability = $6,
+			owner_id = $7,
+			expected_close_date = $8,
+			closed_at = $9,
+			description = $10,
+			updated_at = $11
+		WHERE id = $12 AND tenant_id = $13`
+
+	deleteDealQuery = `DELETE FROM deals WHERE id = $1 AND tenant_id = $2`
+
+	insertDealStageChangeQuery = `
+		INSERT INTO deal_stage_changes (deal_id, from_stage_id, to_stage_id, changed_by, changed_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	selectDealStageChangesQuery = `
+		SELECT id, deal_id, from_stage_id, to_stage_id, changed_by, changed_at
+		FROM deal_stage_changes
+		WHERE deal_id = ANY($1)
+		ORDER BY changed_at, id`
+)
+
+type DealRepository struct{}
+
+func NewDealRepository() deal.Repository {
+	return &DealRepository{}
+}
+
+// dealOwnerFilter narrows the query down to the deals owned by the user when
+// they hold the "own" read permission only
+func dealOwnerFilter(ctx context.Context, where []string, args []interface{}) ([]string, []interface{}) {
+	u, ok := composables.UseOwnScope(ctx, permissions.DealRead)
+	if !ok {
+		return where, args
+	}
+	where = append(where, fmt.Sprintf("d.owner_id = $%d", len(args)+1))
+	return where, append(args, u.ID())
+}
+
+func (r *DealRepository) buildFilters(ctx context.Context, params *deal.FindParams) ([]string, []interface{}, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	where := []string{"d.tenant_id = $1"}
+	args := []interface{}{tenantID}
+
+	where, args = dealOwnerFilter(ctx, where, args)
+
+	if params.StageID != 0 {
+		where = append(where, fmt.Sprintf("d.stage_id = $%d", len(args)+1))
+		args = append(args, params.StageID)
+	}
+	if params.ClientID != 0 {
+		where = append(where, fmt.Sprintf("d.client_id = $%d", len(args)+1))
+		args = append(args, params.ClientID)
+	}
+	if params.OwnerID != 0 {
+		where = append(where, fmt.Sprintf("d.owner_id = $%d", len(args)+1))
+		args = append(args, params.OwnerID)
+	}
+	if !params.CreatedFrom.IsZero() {
+		where = append(where, fmt.Sprintf("d.created_at >= $%d", len(args)+1))
+		args = append(args, params.CreatedFrom)
+	}
+	if !params.CreatedTo.IsZero() {
+		where = append(where, fmt.Sprintf("d.created_at < $%d", len(args)+1))
+		args = append(args, params.CreatedTo)
+	}
+	if params.Search != "" {
+		where = append(where, fmt.Sprintf("d.title ILIKE $%d", len(args)+1))
+		args = append(args, "%"+params.Search+"%")
+	}
+	return where, args, nil
+}
+
+func (r *DealRepository) GetPaginated(ctx context.Context, params *deal.FindParams) ([]deal.Deal, error) {
+	where, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return r.queryDeals(
+		ctx,
+		repo.Join(
+			selectDealQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY d.updated_at DESC, d.id DESC",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (r *DealRepository) Count(ctx context.Context, params *deal.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countDealQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *DealRepository) GetByID(ctx context.Context, id uint) (deal.Deal, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	deals, err := r.queryDeals(ctx, selectDealQuery+" WHERE d.id = $1 AND d.tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(deals) == 0 {
+		return nil, ErrDealNotFound
+	}
+	return deals[0], nil
+}
+
+func (r *DealRepository) Create(ctx context.Context, data deal.Deal) (deal.Deal, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := ToDBDeal(data)
+	var id uint
+	if err := tx.QueryRow(
+		ctx,
+		insertDealQuery,
+		tenantID,
+		dbRow.Title,
+		dbRow.ClientID,
+		dbRow.StageID,
+		dbRow.Amount,
+		dbRow.CurrencyID,
+		dbRow.Probability,
+		dbRow.OwnerID,
+		dbRow.ExpectedCloseDate,
+		dbRow.ClosedAt,
+		dbRow.Description,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&id); err != nil {
+		return nil, errors.Wrap(err, "failed to insert deal")
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *DealRepository) Update(ctx context.Context, data deal.Deal) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := ToDBDeal(data)
+	result, err := tx.Exec(
+		ctx,
+		updateDealQuery,
+		dbRow.Title,
+		dbRow.ClientID,
+		dbRow.StageID,
+		dbRow.Amount,
+		dbRow.CurrencyID,
+		dbRow.Probability,
+		dbRow.OwnerID,
+		dbRow.ExpectedCloseDate,
+		dbRow.ClosedAt,
+		dbRow.Description,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+		tenantID,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDealNotFound
+	}
+	return nil
+}
+
+func (r *DealRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	result, err := tx.Exec(ctx, deleteDealQuery, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDealNotFound
+	}
+	return nil
+}
+
+func (r *DealRepository) RecordStageChange(ctx context.Context, change deal.StageChange) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbRow := ToDBDealStageChange(change)
+	_, err = tx.Exec(
+		ctx,
+		insertDealStageChangeQuery,
+		dbRow.DealID,
+		dbRow.FromStageID,
+		dbRow.ToStageID,
+		dbRow.ChangedBy,
+		dbRow.ChangedAt,
+	)
+	return err
+}
+
+func (r *DealRepository) GetStageChanges(ctx context.Context, dealIDs []uint) ([]deal.StageChange, error) {
+	if len(dealIDs) == 0 {
+		return []deal.StageChange{}, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectDealStageChangesQuery, dealIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]deal.StageChange, 0)
+	for rows.Next() {
+		var c models.DealStageChange
+		if err := rows.Scan(
+			&c.ID,
+			&c.DealID,
+			&c.FromStageID,
+			&c.ToStageID,
+			&c.ChangedBy,
+			&c.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		changes = append(changes, ToDomainDealStageChange(&c))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *DealRepository) queryDeals(ctx context.Context, query string, args ...interface{}) ([]deal.Deal, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deals := make([]deal.Deal, 0)
+	for rows.Next() {
+		var d models.Deal
+		if err := rows.Scan(
+			&d.ID,
+			&d.TenantID,
+			&d.Title,
+			&d.ClientID,
+			&d.StageID,
+			&d.Amount,
+			&d.CurrencyID,
+			&d.Probability,
+			&d.OwnerID,
+			&d.ExpectedCloseDate,
+			&d.ClosedAt,
+			&d.Description,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := ToDomainDeal(&d)
+		if err != nil {
+			return nil, err
+		}
+		deals = append(deals, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deals, nil
+}
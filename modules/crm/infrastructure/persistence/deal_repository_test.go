@@ -0,0 +1,92 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func TestDealRepository(t *testing.T) {
+	t.Parallel()
+	f := setupTest(t)
+
+	require.NoError(t, corepersistence.NewCurrencyRepository().Create(f.Ctx, &currency.USD))
+	clientRepo := persistence.NewClientRepository(corepersistence.NewPassportRepository())
+	stageRepo := persistence.NewPipelineStageRepository()
+	dealRepo := persistence.NewDealRepository()
+
+	createdClient, err := clientRepo.Save(f.Ctx, createTestClient(t, f.TenantID(), false))
+	require.NoError(t, err)
+
+	stages := make([]pipelinestage.Stage, 0, len(pipelinestage.Defaults()))
+	for _, s := range pipelinestage.Defaults() {
+		created, err := stageRepo.Create(f.Ctx, s)
+		require.NoError(t, err)
+		stages = append(stages, created)
+	}
+
+	all, err := stageRepo.GetAll(f.Ctx)
+	require.NoError(t, err)
+	require.Len(t, all, len(stages))
+	assert.Equal(t, "Lead", all[0].Name())
+	assert.Equal(t, pipelinestage.OutcomeLost, all[len(all)-1].Outcome())
+
+	created, err := dealRepo.Create(f.Ctx, deal.New(
+		"Annual subscription",
+		createdClient.ID(),
+		money.New(150000, "USD"),
+		stages[0].ID(),
+		deal.WithProbability(stages[0].Probability()),
+	))
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID())
+	assert.Equal(t, int64(150000), created.Amount().Amount())
+	assert.Equal(t, "USD", created.Amount().Currency().Code)
+	assert.Nil(t, created.ClosedAt())
+
+	t.Run("Move deal to a won stage", func(t *testing.T) {
+		moved := created.MoveTo(stages[4])
+		require.NoError(t, dealRepo.Update(f.Ctx, moved))
+		require.NoError(t, dealRepo.RecordStageChange(f.Ctx, deal.StageChange{
+			DealID:      created.ID(),
+			FromStageID: stages[0].ID(),
+			ToStageID:   stages[4].ID(),
+			ChangedAt:   moved.UpdatedAt(),
+		}))
+
+		fetched, err := dealRepo.GetByID(f.Ctx, created.ID())
+		require.NoError(t, err)
+		assert.Equal(t, stages[4].ID(), fetched.StageID())
+		assert.Equal(t, 100, fetched.Probability())
+		assert.NotNil(t, fetched.ClosedAt())
+
+		changes, err := dealRepo.GetStageChanges(f.Ctx, []uint{created.ID()})
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, stages[0].ID(), changes[0].FromStageID)
+	})
+
+	t.Run("Filter by stage", func(t *testing.T) {
+		count, err := dealRepo.Count(f.Ctx, &deal.FindParams{StageID: stages[4].ID()})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		deals, err := dealRepo.GetPaginated(f.Ctx, &deal.FindParams{StageID: stages[0].ID()})
+		require.NoError(t, err)
+		assert.Empty(t, deals)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, dealRepo.Delete(f.Ctx, created.ID()))
+		_, err := dealRepo.GetByID(f.Ctx, created.ID())
+		require.ErrorIs(t, err, persistence.ErrDealNotFound)
+	})
+}
@@ -22,10 +22,76 @@ type Client struct {
 	PassportID  sql.NullString // UUID reference to passports table
 	Pin         sql.NullString
 	Comments    sql.NullString
+	CreatedBy   sql.NullInt64
+	AssigneeID  sql.NullInt64
+	// AssigneeGroupID is a UUID reference to user_groups table
+	AssigneeGroupID sql.NullString
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type PipelineStage struct {
+	ID          uint
+	TenantID    string
+	Name        string
+	Position    int
+	Probability int
+	Outcome     string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
+type Deal struct {
+	ID                uint
+	TenantID          string
+	Title             string
+	ClientID          uint
+	StageID           uint
+	Amount            int64
+	CurrencyID        string
+	Probability       int
+	OwnerID           sql.NullInt32
+	ExpectedCloseDate sql.NullTime
+	ClosedAt          sql.NullTime
+	Description       sql.NullString
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type DealStageChange struct {
+	ID          uint
+	DealID      uint
+	FromStageID sql.NullInt32
+	ToStageID   sql.NullInt32
+	ChangedBy   sql.NullInt32
+	ChangedAt   time.Time
+}
+
+type ClientDuplicate struct {
+	ID          uint
+	TenantID    string
+	ClientID    uint
+	DuplicateID uint
+	Score       int
+	Reasons     []byte
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ClientMerge struct {
+	ID            uint
+	TenantID      string
+	SurvivorID    sql.NullInt32
+	MergedID      uint
+	MergedClient  []byte
+	MovedContacts int
+	MovedMessages int
+	MovedDeals    int
+	MergedBy      sql.NullInt32
+	MergedAt      time.Time
+}
+
 type ClientContact struct {
 	ID           uint
 	ClientID     uint
@@ -57,13 +123,14 @@ type ChatMember struct {
 }
 
 type Message struct {
-	ID        uint
-	ChatID    uint
-	Message   string
-	ReadAt    sql.NullTime
-	SenderID  string
-	SentAt    sql.NullTime
-	CreatedAt time.Time
+	ID         uint
+	ChatID     uint
+	Message    string
+	ExternalID sql.NullString
+	ReadAt     sql.NullTime
+	SenderID   string
+	SentAt     sql.NullTime
+	CreatedAt  time.Time
 }
 
 func NewTransportMeta(value any) *TransportMeta {
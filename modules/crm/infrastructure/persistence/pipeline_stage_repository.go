@@ -0,0 +1,173 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrPipelineStageNotFound = errors.New("pipeline stage not found")
+)
+
+const (
+	selectPipelineStageQuery = `
+		SELECT id, tenant_id, name, position, probability, outcome, created_at, updated_at
+		FROM pipeline_stages`
+	insertPipelineStageQuery = `
+		INSERT INTO pipeline_stages (tenant_id, name, position, probability, outcome, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	updatePipelineStageQuery = `
+		UPDATE pipeline_stages
+		SET name = $1, position = $2, probability = $3, outcome = $4, updated_at = $5
+		WHERE id = $6 AND tenant_id = $7`
+	deletePipelineStageQuery = `DELETE FROM pipeline_stages WHERE id = $1 AND tenant_id = $2`
+)
+
+type PipelineStageRepository struct{}
+
+func NewPipelineStageRepository() pipelinestage.Repository {
+	return &PipelineStageRepository{}
+}
+
+func (r *PipelineStageRepository) GetAll(ctx context.Context) ([]pipelinestage.Stage, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	return r.queryStages(ctx, selectPipelineStageQuery+" WHERE tenant_id = $1 ORDER BY position, id", tenantID)
+}
+
+func (r *PipelineStageRepository) GetByID(ctx context.Context, id uint) (pipelinestage.Stage, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	stages, err := r.queryStages(ctx, selectPipelineStageQuery+" WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 {
+		return nil, ErrPipelineStageNotFound
+	}
+	return stages[0], nil
+}
+
+func (r *PipelineStageRepository) Create(ctx context.Context, data pipelinestage.Stage) (pipelinestage.Stage, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := ToDBPipelineStage(data)
+	var id uint
+	if err := tx.QueryRow(
+		ctx,
+		insertPipelineStageQuery,
+		tenantID,
+		dbRow.Name,
+		dbRow.Position,
+		dbRow.Probability,
+		dbRow.Outcome,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&id); err != nil {
+		return nil, errors.Wrap(err, "failed to insert pipeline stage")
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *PipelineStageRepository) Update(ctx context.Context, data pipelinestage.Stage) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := ToDBPipelineStage(data)
+	result, err := tx.Exec(
+		ctx,
+		updatePipelineStageQuery,
+		dbRow.Name,
+		dbRow.Position,
+		dbRow.Probability,
+		dbRow.Outcome,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+		tenantID,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrPipelineStageNotFound
+	}
+	return nil
+}
+
+func (r *PipelineStageRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	result, err := tx.Exec(ctx, deletePipelineStageQuery, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrPipelineStageNotFound
+	}
+	return nil
+}
+
+func (r *PipelineStageRepository) queryStages(ctx context.Context, query string, args ...interface{}) ([]pipelinestage.Stage, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stages := make([]pipelinestage.Stage, 0)
+	for rows.Next() {
+		var s models.PipelineStage
+		if err := rows.Scan(
+			&s.ID,
+			&s.TenantID,
+			&s.Name,
+			&s.Position,
+			&s.Probability,
+			&s.Outcome,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := ToDomainPipelineStage(&s)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
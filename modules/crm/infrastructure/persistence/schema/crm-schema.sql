@@ -12,6 +12,9 @@ CREATE TABLE clients (
     passport_id uuid REFERENCES passports (id) ON DELETE SET NULL ON UPDATE CASCADE,
     pin varchar(128), -- Personal Identification Number
     comments text,
+    created_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    assignee_id int REFERENCES users (id) ON DELETE SET NULL,
+    assignee_group_id uuid REFERENCES user_groups (id) ON DELETE SET NULL,
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now()
 );
@@ -53,6 +56,7 @@ CREATE TABLE messages (
     chat_id int NOT NULL REFERENCES chats (id) ON DELETE RESTRICT ON UPDATE CASCADE,
     sender_id uuid NOT NULL REFERENCES chat_members (id) ON DELETE RESTRICT ON UPDATE CASCADE,
     message text NOT NULL,
+    external_id varchar(255),
     sent_at timestamp(3),
     read_at timestamp(3)
 );
@@ -70,10 +74,76 @@ CREATE TABLE message_templates (
     created_at timestamp with time zone DEFAULT now()
 );
 
+CREATE TABLE pipeline_stages (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    position int NOT NULL DEFAULT 0,
+    probability int NOT NULL DEFAULT 0 CHECK (probability BETWEEN 0 AND 100),
+    outcome varchar(10) NOT NULL DEFAULT 'open', -- open, won, lost
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now()
+);
+
+CREATE TABLE deals (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    title varchar(255) NOT NULL,
+    client_id int NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    stage_id int NOT NULL REFERENCES pipeline_stages (id) ON DELETE RESTRICT,
+    amount bigint NOT NULL DEFAULT 0,
+    currency_id varchar(3) NOT NULL REFERENCES currencies (code) ON DELETE RESTRICT,
+    probability int NOT NULL DEFAULT 0 CHECK (probability BETWEEN 0 AND 100),
+    owner_id int REFERENCES users (id) ON DELETE SET NULL,
+    expected_close_date date,
+    closed_at timestamp with time zone,
+    description text,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now()
+);
+
+CREATE TABLE deal_stage_changes (
+    id serial PRIMARY KEY,
+    deal_id int NOT NULL REFERENCES deals (id) ON DELETE CASCADE,
+    from_stage_id int REFERENCES pipeline_stages (id) ON DELETE SET NULL,
+    to_stage_id int REFERENCES pipeline_stages (id) ON DELETE SET NULL,
+    changed_by int REFERENCES users (id) ON DELETE SET NULL,
+    changed_at timestamp with time zone DEFAULT now()
+);
+
+CREATE TABLE client_duplicates (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    client_id int NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    duplicate_id int NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    score int NOT NULL CHECK (score BETWEEN 0 AND 100),
+    reasons jsonb NOT NULL DEFAULT '[]', -- phone, email, passport, pin, name, date_of_birth
+    status varchar(20) NOT NULL DEFAULT 'pending', -- pending, dismissed
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    CHECK (client_id < duplicate_id),
+    UNIQUE (client_id, duplicate_id)
+);
+
+CREATE TABLE client_merges (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    survivor_id int REFERENCES clients (id) ON DELETE SET NULL,
+    merged_id int NOT NULL, -- the merged client is deleted, merged_client keeps its data
+    merged_client jsonb NOT NULL DEFAULT '{}',
+    moved_contacts int NOT NULL DEFAULT 0,
+    moved_messages int NOT NULL DEFAULT 0,
+    moved_deals int NOT NULL DEFAULT 0,
+    merged_by int REFERENCES users (id) ON DELETE SET NULL,
+    merged_at timestamp with time zone DEFAULT now()
+);
+
 CREATE INDEX idx_chats_client_id ON chats (client_id);
 
 CREATE INDEX idx_messages_chat_id ON messages (chat_id);
 
+CREATE INDEX idx_messages_external_id ON messages (external_id);
+
 CREATE INDEX idx_messages_sender_user_id ON messages (sender_user_id);
 
 CREATE INDEX idx_messages_sender_client_id ON messages (sender_client_id);
@@ -94,3 +164,20 @@ CREATE INDEX idx_chats_tenant_id ON chats (tenant_id);
 
 CREATE INDEX idx_message_templates_tenant_id ON message_templates (tenant_id);
 
+CREATE INDEX idx_pipeline_stages_tenant_id ON pipeline_stages (tenant_id);
+
+CREATE INDEX idx_deals_tenant_id ON deals (tenant_id);
+
+CREATE INDEX idx_deals_client_id ON deals (client_id);
+
+CREATE INDEX idx_deals_stage_id ON deals (stage_id);
+
+CREATE INDEX idx_deals_owner_id ON deals (owner_id);
+
+CREATE INDEX idx_deal_stage_changes_deal_id ON deal_stage_changes (deal_id);
+
+CREATE INDEX idx_client_duplicates_tenant_id ON client_duplicates (tenant_id);
+
+CREATE INDEX idx_client_duplicates_duplicate_id ON client_duplicates (duplicate_id);
+
+CREATE INDEX idx_client_merges_survivor_id ON client_merges (survivor_id);
@@ -1,20 +1,57 @@
 package telegram
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 )
 
 type SendMessageOpts gotgbot.SendMessageOpts
 
+// Update is an incoming update delivered by a webhook or long polling
+type Update = gotgbot.Update
+
+type (
+	User      = gotgbot.User
+	PhotoSize = gotgbot.PhotoSize
+	Document  = gotgbot.Document
+)
+
+type BotOption func(o *botOptions)
+
+type botOptions struct {
+	apiURL string
+}
+
+// WithAPIURL points the bot to a Bot API server other than api.telegram.org, e.g. a local one or a test double
+func WithAPIURL(url string) BotOption {
+	return func(o *botOptions) {
+		o.apiURL = url
+	}
+}
+
 type Bot struct {
 	client *gotgbot.Bot
 }
 
-func NewBot(token string) (*Bot, error) {
-	client, err := gotgbot.NewBot(token, &gotgbot.BotOpts{})
+func NewBot(token string, opts ...BotOption) (*Bot, error) {
+	o := &botOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	botOpts := &gotgbot.BotOpts{}
+	if o.apiURL != "" {
+		botOpts.BotClient = &gotgbot.BaseBotClient{
+			Client:             http.Client{},
+			DefaultRequestOpts: &gotgbot.RequestOpts{APIURL: o.apiURL},
+		}
+	}
+	client, err := gotgbot.NewBot(token, botOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bot: %w", err)
 	}
@@ -22,9 +59,84 @@ func NewBot(token string) (*Bot, error) {
 }
 
 func (b *Bot) SendMessage(ctx context.Context, chatID int64, text string, options *SendMessageOpts) error {
-	_, err := b.client.SendMessage(chatID, text, (*gotgbot.SendMessageOpts)(options))
+	_, err := b.client.SendMessageWithContext(ctx, chatID, text, (*gotgbot.SendMessageOpts)(options))
 	if err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
 	return nil
 }
+
+// SendPhoto uploads an image to the chat
+func (b *Bot) SendPhoto(ctx context.Context, chatID int64, name string, data []byte) error {
+	_, err := b.client.SendPhotoWithContext(ctx, chatID, gotgbot.InputFileByReader(name, bytes.NewReader(data)), nil)
+	if err != nil {
+		return fmt.Errorf("failed to send photo: %w", err)
+	}
+	return nil
+}
+
+// SendDocument uploads a file to the chat
+func (b *Bot) SendDocument(ctx context.Context, chatID int64, name string, data []byte) error {
+	_, err := b.client.SendDocumentWithContext(ctx, chatID, gotgbot.InputFileByReader(name, bytes.NewReader(data)), nil)
+	if err != nil {
+		return fmt.Errorf("failed to send document: %w", err)
+	}
+	return nil
+}
+
+// GetUpdates long polls for updates starting from offset, waiting up to timeout for new ones
+func (b *Bot) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	updates, err := b.client.GetUpdatesWithContext(ctx, &gotgbot.GetUpdatesOpts{
+		Offset:         offset,
+		Timeout:        int64(timeout.Seconds()),
+		AllowedUpdates: []string{"message"},
+		RequestOpts: &gotgbot.RequestOpts{
+			// The HTTP request has to outlive the long poll
+			Timeout: timeout + 10*time.Second,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updates: %w", err)
+	}
+	return updates, nil
+}
+
+// SetWebhook makes Telegram deliver updates to url, signed with secret in the X-Telegram-Bot-Api-Secret-Token header
+func (b *Bot) SetWebhook(ctx context.Context, url, secret string) error {
+	if _, err := b.client.SetWebhookWithContext(ctx, url, &gotgbot.SetWebhookOpts{
+		SecretToken:    secret,
+		AllowedUpdates: []string{"message"},
+	}); err != nil {
+		return fmt.Errorf("failed to set webhook: %w", err)
+	}
+	return nil
+}
+
+// DeleteWebhook switches the bot back to long polling
+func (b *Bot) DeleteWebhook(ctx context.Context) error {
+	if _, err := b.client.DeleteWebhookWithContext(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// DownloadFile returns the content of a file sent to the bot
+func (b *Bot) DownloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	file, err := b.client.GetFileWithContext(ctx, fileID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.URL(b.client, nil), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
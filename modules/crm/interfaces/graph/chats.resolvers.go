@@ -0,0 +1,141 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	model "github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// Client is the resolver for the client field.
+func (r *chatResolver) Client(ctx context.Context, obj *model.Chat) (*model.Client, error) {
+	entity, err := r.loaders(ctx).Client.Load(ctx, uint(obj.ClientID))
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, nil
+	}
+	return mappers.ClientToGraphModel(entity), nil
+}
+
+// SendMessage is the resolver for the sendMessage field.
+func (r *mutationResolver) SendMessage(ctx context.Context, input model.SendMessage) (*model.Chat, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientRead); err != nil {
+		return nil, err
+	}
+	transport := chat.SMSTransport
+	if input.Transport != nil && *input.Transport != "" {
+		transport = chat.Transport(*input.Transport)
+	}
+	entity, err := r.chatService.SendMessage(ctx, services.SendMessageCommand{
+		ChatID:    uint(input.ChatID),
+		Message:   input.Message,
+		Transport: transport,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ChatToGraphModel(entity), nil
+}
+
+// Chat is the resolver for the chat field.
+func (r *queryResolver) Chat(ctx context.Context, id int64) (*model.Chat, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientRead); err != nil {
+		return nil, err
+	}
+	entity, err := r.chatService.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ChatToGraphModel(entity), nil
+}
+
+// Chats is the resolver for the chats field.
+func (r *queryResolver) Chats(ctx context.Context, offset int, limit int, filter *model.ChatFilter) (*model.PaginatedChats, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientRead); err != nil {
+		return nil, err
+	}
+	params := &chat.FindParams{
+		Offset: offset,
+		Limit:  limit,
+		SortBy: chat.SortBy{
+			Fields: []chat.SortByField{
+				{Field: chat.LastMessageAtField, Ascending: false, NullsLast: true},
+				{Field: chat.CreatedAtField, Ascending: false},
+			},
+		},
+	}
+	if filter != nil {
+		params.Search = mapping.Value(filter.Search)
+	}
+	entities, err := r.chatService.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.chatService.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PaginatedChats{
+		Data:  ChatsToGraphModel(entities),
+		Total: total,
+	}, nil
+}
+
+// ChatMessageAdded is the resolver for the chatMessageAdded field.
+func (r *subscriptionResolver) ChatMessageAdded(ctx context.Context, chatID *int64) (<-chan *model.ChatMessage, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, serrors.UnauthorizedGQLError(graphql.GetPath(ctx))
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientRead); err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sdkgraphql.Subscribe(ctx, r.messageAdded, func(c chat.Chat) (*model.ChatMessage, bool) {
+		if c.TenantID() != tenantID || (chatID != nil && int64(c.ID()) != *chatID) {
+			return nil, false
+		}
+		msg, err := c.LastMessage()
+		if err != nil {
+			return nil, false
+		}
+		return mappers.MessageToGraphModel(msg), true
+	}), nil
+}
+
+// Chat returns ChatResolver implementation.
+func (r *Resolver) Chat() ChatResolver { return &chatResolver{r} }
+
+type chatResolver struct{ *Resolver }
@@ -0,0 +1,214 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	model "github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// CreateClient is the resolver for the createClient field.
+func (r *mutationResolver) CreateClient(ctx context.Context, input model.CreateClient) (*model.Client, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUser(ctx, permissions.ClientCreate); err != nil {
+		return nil, err
+	}
+	dto := &dtos.CreateClientDTO{
+		FirstName:      input.FirstName,
+		LastName:       input.LastName,
+		MiddleName:     mapping.Value(input.MiddleName),
+		Phone:          input.Phone,
+		Email:          mapping.Value(input.Email),
+		Address:        mapping.Value(input.Address),
+		PassportSeries: mapping.Value(input.PassportSeries),
+		PassportNumber: mapping.Value(input.PassportNumber),
+		Pin:            mapping.Value(input.Pin),
+		CountryCode:    mapping.Value(input.CountryCode),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := dto.ToEntity(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	created, err := r.clientService.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ClientToGraphModel(created), nil
+}
+
+// UpdateClient is the resolver for the updateClient field.
+func (r *mutationResolver) UpdateClient(ctx context.Context, id int64, input model.UpdateClient) (*model.Client, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientUpdate); err != nil {
+		return nil, err
+	}
+	existing, err := r.clientService.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	var existingPhone string
+	if existing.Phone() != nil {
+		existingPhone = existing.Phone().Value()
+	}
+	dto := &dtos.UpdateClientPersonalDTO{
+		FirstName:  input.FirstName,
+		LastName:   input.LastName,
+		MiddleName: mapping.Or(mapping.Value(input.MiddleName), existing.MiddleName()),
+		Phone:      mapping.Or(mapping.Value(input.Phone), existingPhone),
+		Email:      mapping.Value(input.Email),
+		Address:    mapping.Value(input.Address),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	updated, err := dto.Apply(existing)
+	if err != nil {
+		return nil, err
+	}
+	if input.Comments != nil {
+		notes := &dtos.UpdateClientNotesDTO{Comments: *input.Comments}
+		if updated, err = notes.Apply(updated); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.clientService.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	entity, err := r.clientService.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ClientToGraphModel(entity), nil
+}
+
+// DeleteClient is the resolver for the deleteClient field.
+func (r *mutationResolver) DeleteClient(ctx context.Context, id int64) (*model.Client, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientDelete); err != nil {
+		return nil, err
+	}
+	deleted, err := r.clientService.Delete(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ClientToGraphModel(deleted), nil
+}
+
+// Client is the resolver for the client field.
+func (r *queryResolver) Client(ctx context.Context, id int64) (*model.Client, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientRead); err != nil {
+		return nil, err
+	}
+	entity, err := r.clientService.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ClientToGraphModel(entity), nil
+}
+
+// Clients is the resolver for the clients field.
+func (r *queryResolver) Clients(ctx context.Context, offset int, limit int, filter *model.ClientFilter) (*model.PaginatedClients, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientRead); err != nil {
+		return nil, err
+	}
+	params := &client.FindParams{
+		SortBy: client.SortBy{
+			Fields: []client.SortByField{
+				{Field: client.CreatedAt, Ascending: false},
+			},
+		},
+	}
+	if filter != nil {
+		params.Search = mapping.Value(filter.Search)
+		if filter.CreatedFrom != nil {
+			params.Filters = append(params.Filters, client.Filter{
+				Column: client.CreatedAt,
+				Filter: repo.Gte(*filter.CreatedFrom),
+			})
+		}
+		if filter.CreatedTo != nil {
+			params.Filters = append(params.Filters, client.Filter{
+				Column: client.CreatedAt,
+				Filter: repo.Lte(*filter.CreatedTo),
+			})
+		}
+	}
+	total, err := r.clientService.Count(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	params.Offset, params.Limit = offset, limit
+	entities, err := r.clientService.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PaginatedClients{
+		Data:  ClientsToGraphModel(entities),
+		Total: total,
+	}, nil
+}
+
+// ClientCreated is the resolver for the clientCreated field.
+func (r *subscriptionResolver) ClientCreated(ctx context.Context) (<-chan *model.Client, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, serrors.UnauthorizedGQLError(graphql.GetPath(ctx))
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ClientRead); err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sdkgraphql.Subscribe(ctx, r.clientCreated, func(c client.Client) (*model.Client, bool) {
+		if c.TenantID() != tenantID || composables.CanUserAccess(ctx, permissions.ClientRead, c) != nil {
+			return nil, false
+		}
+		return mappers.ClientToGraphModel(c), true
+	}), nil
+}
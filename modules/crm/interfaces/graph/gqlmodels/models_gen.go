@@ -0,0 +1,108 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+type Chat struct {
+	ID             int64          `json:"id"`
+	ClientID       int64          `json:"clientId"`
+	Client         *Client        `json:"client,omitempty"`
+	UnreadMessages int            `json:"unreadMessages"`
+	LastMessageAt  *time.Time     `json:"lastMessageAt,omitempty"`
+	Messages       []*ChatMessage `json:"messages"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+type ChatFilter struct {
+	Search *string `json:"search,omitempty"`
+}
+
+type ChatMessage struct {
+	ID         int64      `json:"id"`
+	ChatID     int64      `json:"chatId"`
+	Message    string     `json:"message"`
+	Transport  string     `json:"transport"`
+	SenderType string     `json:"senderType"`
+	IsRead     bool       `json:"isRead"`
+	SentAt     *time.Time `json:"sentAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type Client struct {
+	ID          int64            `json:"id"`
+	FirstName   string           `json:"firstName"`
+	LastName    string           `json:"lastName"`
+	MiddleName  string           `json:"middleName"`
+	Phone       string           `json:"phone"`
+	Email       string           `json:"email"`
+	Address     string           `json:"address"`
+	DateOfBirth *time.Time       `json:"dateOfBirth,omitempty"`
+	Gender      string           `json:"gender"`
+	Comments    string           `json:"comments"`
+	Contacts    []*ClientContact `json:"contacts"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+type ClientContact struct {
+	ID    int64  `json:"id"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type ClientFilter struct {
+	Search      *string    `json:"search,omitempty"`
+	CreatedFrom *time.Time `json:"createdFrom,omitempty"`
+	CreatedTo   *time.Time `json:"createdTo,omitempty"`
+}
+
+type CreateClient struct {
+	FirstName      string  `json:"firstName"`
+	LastName       string  `json:"lastName"`
+	MiddleName     *string `json:"middleName,omitempty"`
+	Phone          string  `json:"phone"`
+	Email          *string `json:"email,omitempty"`
+	Address        *string `json:"address,omitempty"`
+	PassportSeries *string `json:"passportSeries,omitempty"`
+	PassportNumber *string `json:"passportNumber,omitempty"`
+	Pin            *string `json:"pin,omitempty"`
+	CountryCode    *string `json:"countryCode,omitempty"`
+}
+
+type Mutation struct {
+}
+
+type PaginatedChats struct {
+	Data  []*Chat `json:"data"`
+	Total int64   `json:"total"`
+}
+
+type PaginatedClients struct {
+	Data  []*Client `json:"data"`
+	Total int64     `json:"total"`
+}
+
+type Query struct {
+}
+
+type SendMessage struct {
+	ChatID    int64   `json:"chatId"`
+	Message   string  `json:"message"`
+	Transport *string `json:"transport,omitempty"`
+}
+
+type Subscription struct {
+}
+
+type UpdateClient struct {
+	FirstName  string  `json:"firstName"`
+	LastName   string  `json:"lastName"`
+	MiddleName *string `json:"middleName,omitempty"`
+	Phone      *string `json:"phone,omitempty"`
+	Email      *string `json:"email,omitempty"`
+	Address    *string `json:"address,omitempty"`
+	Comments   *string `json:"comments,omitempty"`
+}
@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+type loadersKey struct{}
+
+// Loaders batch the relation fields resolved within one operation
+type Loaders struct {
+	Client *sdkgraphql.Loader[uint, client.Client]
+}
+
+func (r *Resolver) NewLoaders() *Loaders {
+	return &Loaders{
+		Client: sdkgraphql.NewLoader(r.loadClients),
+	}
+}
+
+// AroundOperations gives every operation its own loaders, register it on the module executor
+func (r *Resolver) AroundOperations(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	return next(context.WithValue(ctx, loadersKey{}, r.NewLoaders()))
+}
+
+func (r *Resolver) loaders(ctx context.Context) *Loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*Loaders); ok {
+		return l
+	}
+	return r.NewLoaders()
+}
+
+func (r *Resolver) loadClients(ctx context.Context, ids []uint) (map[uint]client.Client, error) {
+	entities, err := r.clientService.GetPaginated(ctx, &client.FindParams{
+		Filters: []client.Filter{
+			{Column: client.ID, Filter: repo.In(ids)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint]client.Client, len(entities))
+	for _, c := range entities {
+		result[c.ID()] = c
+	}
+	return result, nil
+}
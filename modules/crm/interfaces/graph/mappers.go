@@ -0,0 +1,14 @@
+package graph
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	model "github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/pkg/fp"
+)
+
+var (
+	ClientsToGraphModel = fp.Map[client.Client, *model.Client](mappers.ClientToGraphModel)
+	ChatsToGraphModel   = fp.Map[chat.Chat, *model.Chat](mappers.ChatToGraphModel)
+)
@@ -0,0 +1,39 @@
+package mappers
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	model "github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+)
+
+func MessageToGraphModel(entity chat.Message) *model.ChatMessage {
+	var transport, senderType string
+	if member := entity.Sender(); member != nil {
+		transport = string(member.Transport())
+		if member.Sender() != nil {
+			senderType = string(member.Sender().Type())
+		}
+	}
+	return &model.ChatMessage{
+		ID:         int64(entity.ID()),
+		ChatID:     int64(entity.ChatID()),
+		Message:    entity.Message(),
+		Transport:  transport,
+		SenderType: senderType,
+		IsRead:     entity.IsRead(),
+		SentAt:     entity.SentAt(),
+		CreatedAt:  entity.CreatedAt(),
+	}
+}
+
+// ChatToGraphModel leaves the client to the field resolver
+func ChatToGraphModel(entity chat.Chat) *model.Chat {
+	return &model.Chat{
+		ID:             int64(entity.ID()),
+		ClientID:       int64(entity.ClientID()),
+		UnreadMessages: entity.UnreadMessages(),
+		LastMessageAt:  entity.LastMessageAt(),
+		Messages:       mapping.MapViewModels(entity.Messages(), MessageToGraphModel),
+		CreatedAt:      entity.CreatedAt(),
+	}
+}
@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	model "github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+)
+
+func ClientContactToGraphModel(entity client.Contact) *model.ClientContact {
+	return &model.ClientContact{
+		ID:    int64(entity.ID()),
+		Type:  string(entity.Type()),
+		Value: entity.Value(),
+	}
+}
+
+func ClientToGraphModel(entity client.Client) *model.Client {
+	var phone string
+	if entity.Phone() != nil {
+		phone = entity.Phone().Value()
+	}
+	var email string
+	if entity.Email() != nil {
+		email = entity.Email().Value()
+	}
+	var gender string
+	if entity.Gender() != nil {
+		gender = entity.Gender().String()
+	}
+	return &model.Client{
+		ID:          int64(entity.ID()),
+		FirstName:   entity.FirstName(),
+		LastName:    entity.LastName(),
+		MiddleName:  entity.MiddleName(),
+		Phone:       phone,
+		Email:       email,
+		Address:     entity.Address(),
+		DateOfBirth: entity.DateOfBirth(),
+		Gender:      gender,
+		Comments:    entity.Comments(),
+		Contacts:    mapping.MapViewModels(entity.Contacts(), ClientContactToGraphModel),
+		CreatedAt:   entity.CreatedAt(),
+		UpdatedAt:   entity.UpdatedAt(),
+	}
+}
@@ -0,0 +1,38 @@
+package graph
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+)
+
+// This file will not be regenerated automatically.
+//
+// It serves as dependency injection for your app, add any dependencies you require here.
+
+type Resolver struct {
+	app           application.Application
+	clientService *services.ClientService
+	chatService   *services.ChatService
+	clientCreated *sdkgraphql.Broker[client.Client]
+	messageAdded  *sdkgraphql.Broker[chat.Chat]
+}
+
+func NewResolver(app application.Application) *Resolver {
+	r := &Resolver{
+		app:           app,
+		clientService: app.Service(services.ClientService{}).(*services.ClientService),
+		chatService:   app.Service(services.ChatService{}).(*services.ChatService),
+		clientCreated: sdkgraphql.NewBroker[client.Client](),
+		messageAdded:  sdkgraphql.NewBroker[chat.Chat](),
+	}
+	app.EventPublisher().Subscribe(func(e *client.CreatedEvent) {
+		r.clientCreated.Publish(e.Result)
+	})
+	app.EventPublisher().Subscribe(func(e *chat.MessagedAddedEvent) {
+		r.messageAdded.Publish(e.Result)
+	})
+	return r
+}
@@ -3,6 +3,8 @@ package crm
 import (
 	icons "github.com/iota-uz/icons/phosphor"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/types"
 )
 
@@ -20,13 +22,31 @@ var ChatsLink = types.NavigationItem{
 	Children: nil,
 }
 
+var DealsLink = types.NavigationItem{
+	Name:        "NavigationLinks.Deals",
+	Icon:        icons.Kanban(icons.Props{Size: "20"}),
+	Href:        "/crm/deals",
+	Permissions: []*permission.Permission{permissions.DealRead},
+	Children:    nil,
+}
+
+var DuplicatesLink = types.NavigationItem{
+	Name:        "NavigationLinks.Duplicates",
+	Icon:        icons.UsersThree(icons.Props{Size: "20"}),
+	Href:        "/crm/duplicates",
+	Permissions: []*permission.Permission{permissions.ClientUpdate},
+	Children:    nil,
+}
+
 var CRMLink = types.NavigationItem{
 	Name: "NavigationLinks.CRM",
 	Icon: icons.Handshake(icons.Props{Size: "20"}),
 	Href: "/crm",
 	Children: []types.NavigationItem{
 		ClientsLink,
+		DealsLink,
 		ChatsLink,
+		DuplicatesLink,
 	},
 }
 
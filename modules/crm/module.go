@@ -1,13 +1,20 @@
 package crm
 
 import (
+	"context"
 	"embed"
+	"fmt"
 
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/google/uuid"
 	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
 	"github.com/iota-uz/iota-sdk/modules/crm/handlers"
 	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/telegram"
+	"github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph"
 	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/crm/services"
@@ -47,6 +54,25 @@ func (m *Module) Register(app application.Application) error {
 		clientRepo,
 		chatRepo,
 	)
+	providers := []chat.Provider{twilioProvider}
+	var emailProvider *cpassproviders.EmailProvider
+	if conf.Email.SMTPAddr != "" {
+		var err error
+		emailProvider, err = newEmailProvider(app, conf.Email, clientRepo, chatRepo)
+		if err != nil {
+			return err
+		}
+		providers = append(providers, emailProvider)
+	}
+	var telegramProvider *cpassproviders.TelegramProvider
+	if conf.TelegramBotToken != "" {
+		var err error
+		telegramProvider, err = newTelegramProvider(app, conf, clientRepo, chatRepo)
+		if err != nil {
+			return err
+		}
+		providers = append(providers, telegramProvider)
+	}
 	clientService := services.NewClientService(
 		clientRepo,
 		app.EventPublisher(),
@@ -55,12 +81,23 @@ func (m *Module) Register(app application.Application) error {
 		chatRepo,
 		clientRepo,
 		clientService,
-		[]chat.Provider{twilioProvider},
+		providers,
 		app.EventPublisher(),
 	)
 	app.RegisterServices(
 		chatsService,
 		clientService,
+		services.NewClientDuplicateService(
+			persistence.NewClientDuplicateRepository(),
+			clientRepo,
+			clientService,
+			app.EventPublisher(),
+		),
+		services.NewDealService(
+			persistence.NewDealRepository(),
+			persistence.NewPipelineStageRepository(),
+			app.EventPublisher(),
+		),
 		services.NewMessageTemplateService(
 			persistence.NewMessageTemplateRepository(),
 			app.EventPublisher(),
@@ -69,6 +106,7 @@ func (m *Module) Register(app application.Application) error {
 
 	app.QuickLinks().Add(
 		spotlight.NewQuickLink(ClientsLink.Icon, ClientsLink.Name, ClientsLink.Href),
+		spotlight.NewQuickLink(DealsLink.Icon, DealsLink.Name, DealsLink.Href),
 	)
 	app.Spotlight().Register(&ClientDataSource{})
 
@@ -80,13 +118,19 @@ func (m *Module) Register(app application.Application) error {
 			Tabs: []controllers.TabDefinition{
 				controllers.ProfileTab(basePath),
 				controllers.ChatTab(basePath),
+				controllers.HistoryTab(),
 				controllers.ActionsTab(),
 			},
 		}),
 		controllers.NewChatController(app, "/crm/chats"),
+		controllers.NewDealController(app, "/crm/deals"),
+		controllers.NewClientDuplicateController(app, "/crm/duplicates"),
 		controllers.NewMessageTemplateController(app, "/crm/instant-messages"),
 		controllers.NewTwilioController(app, twilioProvider),
 	)
+	if telegramProvider != nil {
+		app.RegisterControllers(controllers.NewTelegramController(app, telegramProvider))
+	}
 
 	handlers.RegisterClientHandler(app)
 	handlers.RegisterSMSHandlers(app)
@@ -94,12 +138,116 @@ func (m *Module) Register(app application.Application) error {
 		handlers.RegisterNotificationHandler(app, botToken)
 	}
 
+	if emailProvider != nil {
+		go func() {
+			if err := emailProvider.Run(context.Background()); err != nil {
+				conf.Logger().WithError(err).Error("email poller stopped")
+			}
+		}()
+	}
+	if telegramProvider != nil {
+		go func() {
+			if err := telegramProvider.Run(context.Background()); err != nil {
+				conf.Logger().WithError(err).Error("telegram receiver stopped")
+			}
+		}()
+	}
+
+	resolver := graph.NewResolver(app)
+	app.RegisterGraphSchema(application.GraphSchema{
+		Value: graph.NewExecutableSchema(graph.Config{
+			Resolvers: resolver,
+		}),
+		BasePath: "/crm",
+		ExecutorCb: func(exec *executor.Executor) {
+			exec.AroundOperations(resolver.AroundOperations)
+		},
+	})
+
 	app.RBAC().Register(permissions.Permissions...)
 	app.RegisterLocaleFiles(&LocaleFiles)
 	app.Migrations().RegisterSchema(&MigrationFiles)
 	return nil
 }
 
+func newEmailProvider(
+	app application.Application,
+	conf configuration.EmailOptions,
+	clientRepo client.Repository,
+	chatRepo chat.Repository,
+) (*cpassproviders.EmailProvider, error) {
+	storage, err := corepersistence.NewFSStorage()
+	if err != nil {
+		return nil, err
+	}
+	config := cpassproviders.EmailConfig{
+		SMTPAddr:     conf.SMTPAddr,
+		SMTPUsername: conf.SMTPUsername,
+		SMTPPassword: conf.SMTPPassword,
+		From:         conf.From,
+		Subject:      conf.Subject,
+		PollInterval: conf.PollInterval,
+	}
+	if conf.IMAPAddr != "" {
+		tenantID, err := uuid.Parse(conf.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EMAIL_TENANT_ID: %w", err)
+		}
+		config.Mailboxes = append(config.Mailboxes, cpassproviders.Mailbox{
+			TenantID: tenantID,
+			Addr:     conf.IMAPAddr,
+			Username: conf.IMAPUsername,
+			Password: conf.IMAPPassword,
+			Folder:   conf.IMAPFolder,
+			TLS:      conf.IMAPTLS,
+		})
+	}
+	return cpassproviders.NewEmailProvider(
+		config,
+		app.DB(),
+		clientRepo,
+		chatRepo,
+		corepersistence.NewUploadRepository(),
+		storage,
+	), nil
+}
+
+func newTelegramProvider(
+	app application.Application,
+	conf *configuration.Configuration,
+	clientRepo client.Repository,
+	chatRepo chat.Repository,
+) (*cpassproviders.TelegramProvider, error) {
+	bot, err := telegram.NewBot(conf.TelegramBotToken)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := corepersistence.NewFSStorage()
+	if err != nil {
+		return nil, err
+	}
+	config := cpassproviders.TelegramConfig{
+		WebhookURL:    conf.Telegram.WebhookURL,
+		WebhookSecret: conf.Telegram.WebhookSecret,
+		PollTimeout:   conf.Telegram.PollTimeout,
+	}
+	if conf.Telegram.TenantID != "" {
+		config.TenantID, err = uuid.Parse(conf.Telegram.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TELEGRAM_TENANT_ID: %w", err)
+		}
+	}
+	return cpassproviders.NewTelegramProvider(
+		config,
+		bot,
+		app.DB(),
+		clientRepo,
+		chatRepo,
+		corepersistence.NewUploadRepository(),
+		storage,
+	), nil
+}
+
 func (m *Module) Name() string {
 	return "crm"
 }
@@ -7,7 +7,9 @@ import (
 )
 
 const (
-	ResourceClient permission.Resource = "client"
+	ResourceClient   permission.Resource = "client"
+	ResourceDeal     permission.Resource = "deal"
+	ResourcePipeline permission.Resource = "pipeline"
 )
 
 var (
@@ -39,6 +41,83 @@ var (
 		Action:   permission.ActionDelete,
 		Modifier: permission.ModifierAll,
 	}
+	ClientReadOwn = &permission.Permission{
+		ID:       uuid.MustParse("e9a4c1b7-3d6f-4a28-b5e0-7f2d8c4a1e96"),
+		Name:     "Client.ReadOwn",
+		Resource: ResourceClient,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierOwn,
+	}
+	ClientUpdateOwn = &permission.Permission{
+		ID:       uuid.MustParse("f5b0d7c3-9e2a-4f64-8c1b-3a6e0d9b5f27"),
+		Name:     "Client.UpdateOwn",
+		Resource: ResourceClient,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierOwn,
+	}
+	ClientDeleteOwn = &permission.Permission{
+		ID:       uuid.MustParse("a2c6e8f0-4b1d-4d97-9a35-8e7f1c0b6d54"),
+		Name:     "Client.DeleteOwn",
+		Resource: ResourceClient,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierOwn,
+	}
+	DealCreate = &permission.Permission{
+		ID:       uuid.MustParse("db8c61a0-d249-41fe-b467-aa6984587db7"),
+		Name:     "Deal.Create",
+		Resource: ResourceDeal,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	DealRead = &permission.Permission{
+		ID:       uuid.MustParse("784835cb-e621-4980-b58c-9dd97b675c53"),
+		Name:     "Deal.Read",
+		Resource: ResourceDeal,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	DealUpdate = &permission.Permission{
+		ID:       uuid.MustParse("1c23f087-d73d-4440-9e00-c71d000eec80"),
+		Name:     "Deal.Update",
+		Resource: ResourceDeal,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	DealDelete = &permission.Permission{
+		ID:       uuid.MustParse("cf97a679-795e-4d82-8b38-5d86db21b090"),
+		Name:     "Deal.Delete",
+		Resource: ResourceDeal,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	DealReadOwn = &permission.Permission{
+		ID:       uuid.MustParse("b37cabc7-9ce7-4558-8731-0b8c72d6ae63"),
+		Name:     "Deal.ReadOwn",
+		Resource: ResourceDeal,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierOwn,
+	}
+	DealUpdateOwn = &permission.Permission{
+		ID:       uuid.MustParse("1e1adbd7-4823-4d09-992c-5ce0bf982d17"),
+		Name:     "Deal.UpdateOwn",
+		Resource: ResourceDeal,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierOwn,
+	}
+	DealDeleteOwn = &permission.Permission{
+		ID:       uuid.MustParse("5d0f485c-02f1-47c6-8f13-088a985f00ba"),
+		Name:     "Deal.DeleteOwn",
+		Resource: ResourceDeal,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierOwn,
+	}
+	PipelineUpdate = &permission.Permission{
+		ID:       uuid.MustParse("0f577c4e-1259-4295-83da-db221045aed6"),
+		Name:     "Pipeline.Update",
+		Resource: ResourcePipeline,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
 )
 
 var Permissions = []*permission.Permission{
@@ -46,4 +125,15 @@ var Permissions = []*permission.Permission{
 	ClientRead,
 	ClientUpdate,
 	ClientDelete,
+	ClientReadOwn,
+	ClientUpdateOwn,
+	ClientDeleteOwn,
+	DealCreate,
+	DealRead,
+	DealUpdate,
+	DealDelete,
+	DealReadOwn,
+	DealUpdateOwn,
+	DealDeleteOwn,
+	PipelineUpdate,
 }
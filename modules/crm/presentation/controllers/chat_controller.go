@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 
@@ -16,8 +17,10 @@ import (
 	"github.com/a-h/templ"
 	"github.com/gorilla/mux"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
 	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/controllers/dtos"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
@@ -36,12 +39,14 @@ type CreateChatDTO struct {
 }
 
 type SendMessageDTO struct {
-	Message string
+	Message   string
+	Transport string
 }
 
 type ChatController struct {
 	app             application.Application
 	userService     *coreservices.UserService
+	uploadService   *coreservices.UploadService
 	templateService *services.MessageTemplateService
 	clientService   *services.ClientService
 	chatService     *services.ChatService
@@ -55,6 +60,7 @@ func NewChatController(app application.Application, basePath string) application
 		app:             app,
 		logger:          configuration.Use().Logger(),
 		userService:     app.Service(coreservices.UserService{}).(*coreservices.UserService),
+		uploadService:   app.Service(coreservices.UploadService{}).(*coreservices.UploadService),
 		clientService:   app.Service(services.ClientService{}).(*services.ClientService),
 		chatService:     app.Service(services.ChatService{}).(*services.ChatService),
 		templateService: app.Service(services.MessageTemplateService{}).(*services.MessageTemplateService),
@@ -73,6 +79,7 @@ func (c *ChatController) Register(r *mux.Router) {
 		middleware.Authorize(),
 		middleware.RedirectNotAuthenticated(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.ProvideDynamicLogo(c.app),
 		middleware.Tabs(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
@@ -89,23 +96,9 @@ func (c *ChatController) Register(r *mux.Router) {
 	c.app.EventPublisher().Subscribe(c.onChatCreated)
 }
 
+// createTenantContext scopes event handling to the tenant of the event, events come without a request
 func (c *ChatController) createTenantContext(tenantID uuid.UUID) context.Context {
-	ctx := context.Background()
-	ctxWithDb := composables.WithPool(ctx, c.app.DB())
-
-	tenant, err := c.tenantService.GetByID(ctxWithDb, tenantID)
-	if err != nil {
-		c.logger.WithError(err).WithField("tenantID", tenantID).Error("failed to get tenant")
-		return composables.WithPool(ctx, c.app.DB())
-	}
-
-	tenantComposable := &composables.Tenant{
-		ID:     tenant.ID(),
-		Name:   tenant.Name(),
-		Domain: tenant.Domain(),
-	}
-
-	return composables.WithPool(composables.WithTenantID(ctx, tenantComposable.ID), c.app.DB())
+	return composables.WithPool(composables.WithTenantID(context.Background(), tenantID), c.app.DB())
 }
 
 func (c *ChatController) onChatCreated(event *chat.CreatedEvent) {
@@ -465,7 +458,7 @@ func (c *ChatController) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = c.clientService.Create(r.Context(), clientEntity); err != nil {
+	if _, err = c.clientService.Create(r.Context(), clientEntity); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -494,19 +487,39 @@ func (c *ChatController) SendMessage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := r.ParseMultipartForm(32 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	dto, err := composables.UseForm(&SendMessageDTO{}, r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	attachments, err := c.attachments(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	transport := chat.SMSTransport
+	if dto.Transport != "" {
+		transport = chat.Transport(dto.Transport)
+	}
 	chatEntity, err := c.chatService.SendMessage(
 		r.Context(),
 		services.SendMessageCommand{
-			ChatID:    chatID,
-			Message:   dto.Message,
-			Transport: chat.SMSTransport,
+			ChatID:      chatID,
+			Message:     dto.Message,
+			Transport:   transport,
+			Attachments: attachments,
 		},
 	)
+	if errors.Is(err, services.ErrUnsupportedTransport) ||
+		errors.Is(err, cpassproviders.ErrNoEmailAddress) ||
+		errors.Is(err, cpassproviders.ErrNoTelegramChat) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -529,3 +542,33 @@ func (c *ChatController) SendMessage(w http.ResponseWriter, r *http.Request) {
 	}
 	templ.Handler(chatsui.SelectedChat(props)).ServeHTTP(w, r)
 }
+
+// attachments stores the files attached to the message form
+func (c *ChatController) attachments(r *http.Request) ([]*upload.Upload, error) {
+	if r.MultipartForm == nil {
+		return nil, nil
+	}
+	headers := r.MultipartForm.File["Attachment"]
+	dtos := make([]*upload.CreateDTO, 0, len(headers))
+	for _, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer func(file multipart.File) {
+			if err := file.Close(); err != nil {
+				c.logger.WithError(err).Error("failed to close attachment")
+			}
+		}(file)
+		dtos = append(dtos, &upload.CreateDTO{
+			File: file,
+			Name: header.Filename,
+			Size: int(header.Size),
+		})
+	}
+	uploads, err := c.uploadService.CreateMany(r.Context(), dtos)
+	if err != nil {
+		return nil, err
+	}
+	return mapping.PointerSlice(uploads), nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/clients"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
 	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	loggingPermissions "github.com/iota-uz/iota-sdk/modules/logging/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
@@ -233,8 +234,37 @@ var (
 			},
 		}
 	}
+
+	// HistoryTab shows the audit trail of the client recorded by the logging module
+	HistoryTab = func() TabDefinition {
+		return TabDefinition{
+			ID:        "history",
+			NameKey:   "Clients.Tabs.History",
+			SortOrder: 90,
+			Permissions: []*permission.Permission{
+				loggingPermissions.ViewLogs,
+			},
+			Component: func(r *http.Request, clientID uint) (templ.Component, error) {
+				return clients.HistoryTab(strconv.Itoa(int(clientID))), nil
+			},
+		}
+	}
 )
 
+// canViewTab reports whether u holds any of the tab permissions.
+// The "own" modifier is enough, ownership of the client is checked when it's fetched.
+func canViewTab(u userdomain.User, tab TabDefinition) bool {
+	if len(tab.Permissions) == 0 {
+		return true
+	}
+	for _, p := range tab.Permissions {
+		if rbac.CanScoped(u, p) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ClientController) RegisterTab(tab TabDefinition) {
 	c.tabsByID[tab.ID] = tab
 
@@ -261,6 +291,7 @@ func (c *ClientController) Register(r *mux.Router) {
 			middleware.Authorize(),
 			middleware.RedirectNotAuthenticated(),
 			middleware.ProvideUser(),
+			middleware.RateLimit(),
 			middleware.ProvideDynamicLogo(c.app),
 			middleware.ProvideLocalizer(c.app.Bundle()),
 			middleware.WithPageContext(),
@@ -367,7 +398,7 @@ func (c *ClientController) List(
 	user userdomain.User,
 	clientService *services.ClientService,
 ) {
-	if !user.Can(crmPermissions.ClientRead) {
+	if !rbac.CanScoped(user, crmPermissions.ClientRead) {
 		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
@@ -468,7 +499,7 @@ func (c *ClientController) Create(
 		return
 	}
 
-	if err = clientService.Create(r.Context(), clientEntity); err != nil {
+	if _, err = clientService.Create(r.Context(), clientEntity); err != nil {
 		logger.Errorf("Error creating client: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -499,18 +530,9 @@ func (c *ClientController) tabToComponent(
 		return clients.NotFound(), nil
 	}
 
-	// Check permissions if specified
-	if len(tab.Permissions) > 0 {
-		// Convert permission pointers to rbac.Permission types
-		perms := make([]rbac.Permission, 0, len(tab.Permissions))
-		for _, p := range tab.Permissions {
-			perms = append(perms, rbac.Perm(p))
-		}
-
-		// If user doesn't have any of the required permissions, return NotFound
-		if !rbac.Or(perms...).Can(currentUser) {
-			return clients.NotFound(), nil
-		}
+	// If user doesn't have any of the required permissions, return NotFound
+	if !canViewTab(currentUser, tab) {
+		return clients.NotFound(), nil
 	}
 
 	// Generate the component using the tab's component function
@@ -525,7 +547,7 @@ func (c *ClientController) View(
 	clientService *services.ClientService,
 	chatService *services.ChatService,
 ) {
-	if !user.Can(crmPermissions.ClientRead) {
+	if !rbac.CanScoped(user, crmPermissions.ClientRead) {
 		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
@@ -581,17 +603,8 @@ func (c *ClientController) View(
 
 	// Build tabs from configured tabs
 	for _, tabDef := range c.tabsOrder {
-		// Check permissions if needed here
-		if len(tabDef.Permissions) > 0 {
-			// Convert permission pointers to rbac.Permission types
-			perms := make([]rbac.Permission, 0, len(tabDef.Permissions))
-			for _, p := range tabDef.Permissions {
-				perms = append(perms, rbac.Perm(p))
-			}
-
-			if err := composables.CanUserAny(r.Context(), rbac.Or(perms...)); err != nil {
-				continue // Skip this tab if user doesn't have permission
-			}
+		if !canViewTab(user, tabDef) {
+			continue // Skip this tab if user doesn't have permission
 		}
 
 		q := url.Values{}
@@ -737,7 +750,7 @@ func (c *ClientController) UpdatePersonal(
 	logger *logrus.Entry,
 	clientService *services.ClientService,
 ) {
-	if !user.Can(crmPermissions.ClientUpdate) {
+	if !rbac.CanScoped(user, crmPermissions.ClientUpdate) {
 		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
@@ -1046,7 +1059,7 @@ func (c *ClientController) Delete(
 	logger *logrus.Entry,
 	clientService *services.ClientService,
 ) {
-	if !user.Can(crmPermissions.ClientDelete) {
+	if !rbac.CanScoped(user, crmPermissions.ClientDelete) {
 		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
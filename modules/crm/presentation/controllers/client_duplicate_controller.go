@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	clientduplicate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/client-duplicate"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/duplicates"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// duplicatesPageSize is how many pairs the review queue shows at once, the most likely ones first
+const duplicatesPageSize = 100
+
+type ClientDuplicateController struct {
+	app              application.Application
+	basePath         string
+	duplicateService *services.ClientDuplicateService
+	clientService    *services.ClientService
+}
+
+func NewClientDuplicateController(app application.Application, basePath string) application.Controller {
+	return &ClientDuplicateController{
+		app:              app,
+		basePath:         basePath,
+		duplicateService: app.Service(services.ClientDuplicateService{}).(*services.ClientDuplicateService),
+		clientService:    app.Service(services.ClientService{}).(*services.ClientService),
+	}
+}
+
+func (c *ClientDuplicateController) Key() string {
+	return c.basePath
+}
+
+func (c *ClientDuplicateController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("/scan", c.Scan).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/merge", c.Merge).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/dismiss", c.Dismiss).Methods(http.MethodPost)
+}
+
+// can reports whether the current user holds perm with any modifier
+func (c *ClientDuplicateController) can(r *http.Request, perm *permission.Permission) bool {
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		return false
+	}
+	return rbac.CanScoped(u, perm)
+}
+
+func (c *ClientDuplicateController) List(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.ClientUpdate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	params := &clientduplicate.FindParams{
+		Status: clientduplicate.StatusPending,
+		Limit:  duplicatesPageSize,
+	}
+	total, err := c.duplicateService.Count(r.Context(), params)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error counting duplicates").Error(), http.StatusInternalServerError)
+		return
+	}
+	entities, err := c.duplicateService.GetPaginated(r.Context(), params)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving duplicates").Error(), http.StatusInternalServerError)
+		return
+	}
+	clients := make(map[uint]client.Client)
+	vms := make([]*viewmodels.ClientDuplicate, 0, len(entities))
+	for _, d := range entities {
+		first, err := c.loadClient(r, clients, d.ClientID())
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "Error retrieving client").Error(), duplicateErrorStatus(err))
+			return
+		}
+		second, err := c.loadClient(r, clients, d.DuplicateID())
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "Error retrieving client").Error(), duplicateErrorStatus(err))
+			return
+		}
+		// Pairs with a client the user may not see are left to someone who can
+		if first == nil || second == nil {
+			continue
+		}
+		vms = append(vms, mappers.ClientDuplicateToViewModel(d, first, second))
+	}
+	templ.Handler(duplicates.Index(&duplicates.IndexPageProps{
+		BaseURL:    c.basePath,
+		ClientsURL: "/crm/clients",
+		Duplicates: vms,
+		Total:      total,
+		CanScan:    c.can(r, permissions.ClientUpdate),
+		CanMerge:   c.can(r, permissions.ClientDelete),
+		CanDismiss: c.can(r, permissions.ClientUpdate),
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// loadClient returns the client from the cache or the service, or nil when the user is not allowed to see it
+func (c *ClientDuplicateController) loadClient(r *http.Request, cache map[uint]client.Client, id uint) (client.Client, error) {
+	if entity, ok := cache[id]; ok {
+		return entity, nil
+	}
+	entity, err := c.clientService.GetByID(r.Context(), id)
+	if errors.Is(err, composables.ErrForbidden) {
+		cache[id] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cache[id] = entity
+	return entity, nil
+}
+
+func (c *ClientDuplicateController) Scan(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.ClientUpdate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	if _, err := c.duplicateService.Scan(r.Context()); err != nil {
+		http.Error(w, errors.Wrap(err, "Error scanning clients").Error(), duplicateErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *ClientDuplicateController) Merge(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.ClientUpdate) || !c.can(r, permissions.ClientDelete) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&clientduplicate.MergeDTO{}, r)
+	if err != nil || dto.SurvivorID == 0 {
+		http.Error(w, "Error parsing survivor", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.duplicateService.MergeDuplicate(r.Context(), id, dto.SurvivorID); err != nil {
+		http.Error(w, err.Error(), duplicateErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("/crm/clients/%d", dto.SurvivorID))
+}
+
+func (c *ClientDuplicateController) Dismiss(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.ClientUpdate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if err := c.duplicateService.Dismiss(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), duplicateErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func duplicateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, composables.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, persistence.ErrClientDuplicateNotFound),
+		errors.Is(err, persistence.ErrClientNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrMergeSameClient),
+		errors.Is(err, services.ErrNotInDuplicate),
+		errors.Is(err, services.ErrDuplicateNotOpen):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
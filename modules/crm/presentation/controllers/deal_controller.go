@@ -0,0 +1,579 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/deals"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type DealController struct {
+	app             application.Application
+	basePath        string
+	dealService     *services.DealService
+	clientService   *services.ClientService
+	userService     *coreservices.UserService
+	currencyService *coreservices.CurrencyService
+}
+
+func NewDealController(app application.Application, basePath string) application.Controller {
+	return &DealController{
+		app:             app,
+		basePath:        basePath,
+		dealService:     app.Service(services.DealService{}).(*services.DealService),
+		clientService:   app.Service(services.ClientService{}).(*services.ClientService),
+		userService:     app.Service(coreservices.UserService{}).(*coreservices.UserService),
+		currencyService: app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService),
+	}
+}
+
+func (c *DealController) Key() string {
+	return c.basePath
+}
+
+func (c *DealController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.Board).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/report", c.Report).Methods(http.MethodGet)
+	getRouter.HandleFunc("/stages", c.Stages).Methods(http.MethodGet)
+	getRouter.HandleFunc("/stages/{id:[0-9]+}", c.GetStage).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/move", c.Move).Methods(http.MethodPost)
+	setRouter.HandleFunc("/stages", c.CreateStage).Methods(http.MethodPost)
+	setRouter.HandleFunc("/stages/{id:[0-9]+}", c.UpdateStage).Methods(http.MethodPost)
+	setRouter.HandleFunc("/stages/{id:[0-9]+}", c.DeleteStage).Methods(http.MethodDelete)
+}
+
+// can reports whether the current user holds perm with any modifier
+func (c *DealController) can(r *http.Request, perm *permission.Permission) bool {
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		return false
+	}
+	return rbac.CanScoped(u, perm)
+}
+
+func (c *DealController) stagesURL() string {
+	return fmt.Sprintf("%s/stages", c.basePath)
+}
+
+// dealRefs are the clients and users a deal refers to
+type dealRefs struct {
+	clients     []client.Client
+	users       []user.User
+	clientNames map[uint]string
+	userNames   map[uint]string
+}
+
+func (c *DealController) refs(r *http.Request) (*dealRefs, error) {
+	clients, err := c.clientService.GetPaginated(r.Context(), &client.FindParams{})
+	if err != nil {
+		return nil, err
+	}
+	users, err := c.userService.GetAll(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	refs := &dealRefs{
+		clients:     clients,
+		users:       users,
+		clientNames: make(map[uint]string, len(clients)),
+		userNames:   make(map[uint]string, len(users)),
+	}
+	for _, cl := range clients {
+		refs.clientNames[cl.ID()] = mappers.ClientToViewModel(cl).FullName()
+	}
+	for _, u := range users {
+		refs.userNames[u.ID()] = coremappers.UserToViewModel(u).FullName()
+	}
+	return refs, nil
+}
+
+func (c *DealController) boardProps(r *http.Request) (*deals.BoardPageProps, error) {
+	stages, err := c.dealService.Stages(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	entities, err := c.dealService.GetPaginated(r.Context(), &deal.FindParams{})
+	if err != nil {
+		return nil, err
+	}
+	refs, err := c.refs(r)
+	if err != nil {
+		return nil, err
+	}
+	return &deals.BoardPageProps{
+		BaseURL:   c.basePath,
+		Columns:   mappers.DealBoardToViewModels(stages, entities, refs.clientNames, refs.userNames),
+		CanCreate: c.can(r, permissions.DealCreate),
+		CanMove:   c.can(r, permissions.DealUpdate),
+		CanConfig: c.can(r, permissions.PipelineUpdate),
+	}, nil
+}
+
+func (c *DealController) Board(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.DealRead) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	props, err := c.boardProps(r)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving deals").Error(), dealErrorStatus(err))
+		return
+	}
+	if htmx.IsHxRequest(r) {
+		templ.Handler(deals.Board(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	templ.Handler(deals.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *DealController) formProps(
+	r *http.Request,
+	stages []pipelinestage.Stage,
+	refs *dealRefs,
+	vm *viewmodels.Deal,
+	saveURL string,
+	errorsMap map[string]string,
+) (*deals.FormProps, error) {
+	currencies, err := c.currencyService.GetAll(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &deals.FormProps{
+		Deal:       vm,
+		Stages:     mapping.MapViewModels(stages, mappers.PipelineStageToViewModel),
+		Clients:    mapping.MapViewModels(refs.clients, mappers.ClientToViewModel),
+		Owners:     mapping.MapViewModels(refs.users, coremappers.UserToViewModel),
+		Currencies: mapping.MapViewModels(currencies, coremappers.CurrencyToViewModel),
+		SaveURL:    saveURL,
+		Errors:     errorsMap,
+	}, nil
+}
+
+// newFormProps loads the stages, clients and users offered in the selects of the deal form
+func (c *DealController) newFormProps(
+	r *http.Request,
+	vm *viewmodels.Deal,
+	saveURL string,
+	errorsMap map[string]string,
+) (*deals.FormProps, error) {
+	stages, err := c.dealService.Stages(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	refs, err := c.refs(r)
+	if err != nil {
+		return nil, err
+	}
+	return c.formProps(r, stages, refs, vm, saveURL, errorsMap)
+}
+
+func (c *DealController) GetNew(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.DealCreate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	vm := &viewmodels.Deal{
+		ClientID: r.URL.Query().Get("client"),
+	}
+	props, err := c.newFormProps(r, vm, c.basePath, map[string]string{})
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving deal form data").Error(), dealErrorStatus(err))
+		return
+	}
+	templ.Handler(deals.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *DealController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.DealRead) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	entity, err := c.dealService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving deal").Error(), dealErrorStatus(err))
+		return
+	}
+	history, err := c.dealService.History(r.Context(), id)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving deal history").Error(), dealErrorStatus(err))
+		return
+	}
+	stages, err := c.dealService.Stages(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving stages").Error(), dealErrorStatus(err))
+		return
+	}
+	refs, err := c.refs(r)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving deal form data").Error(), dealErrorStatus(err))
+		return
+	}
+	url := fmt.Sprintf("%s/%d", c.basePath, id)
+	props, err := c.formProps(r, stages, refs, mappers.DealToViewModel(entity, nil, nil), url, map[string]string{})
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving deal form data").Error(), dealErrorStatus(err))
+		return
+	}
+	if c.can(r, permissions.DealDelete) {
+		props.DeleteURL = url
+	}
+	stageNames := make(map[uint]string, len(stages))
+	for _, s := range stages {
+		stageNames[s.ID()] = s.Name()
+	}
+	templ.Handler(deals.Edit(&deals.EditPageProps{
+		BaseURL: c.basePath,
+		Form:    props,
+		History: mapping.MapViewModels(history, func(change deal.StageChange) *viewmodels.DealStageChange {
+			return mappers.DealStageChangeToViewModel(change, stageNames, refs.userNames)
+		}),
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *DealController) Create(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.DealCreate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	c.save(w, r, 0)
+}
+
+func (c *DealController) Update(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.DealUpdate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	c.save(w, r, id)
+}
+
+// save creates a deal when id is zero and updates it otherwise, a changed stage goes through Move
+// so that it lands in the history of the deal
+func (c *DealController) save(w http.ResponseWriter, r *http.Request, id uint) {
+	dto, err := composables.UseForm(&deal.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	saveURL := c.basePath
+	if id != 0 {
+		saveURL = fmt.Sprintf("%s/%d", c.basePath, id)
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		vm := &viewmodels.Deal{
+			ID:           formatID(id),
+			Title:        dto.Title,
+			ClientID:     formatID(dto.ClientID),
+			StageID:      formatID(dto.StageID),
+			Amount:       fmt.Sprintf("%.2f", dto.Amount),
+			CurrencyCode: dto.CurrencyCode,
+			OwnerID:      formatID(dto.OwnerID),
+			Description:  dto.Description,
+		}
+		if dto.Probability != 0 {
+			vm.Probability = fmt.Sprint(dto.Probability)
+		}
+		if date := time.Time(dto.ExpectedCloseDate); !date.IsZero() {
+			vm.ExpectedCloseDate = date.Format(time.DateOnly)
+		}
+		props, err := c.newFormProps(r, vm, saveURL, errorsMap)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "Error retrieving deal form data").Error(), dealErrorStatus(err))
+			return
+		}
+		templ.Handler(deals.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+
+	if id == 0 {
+		created, err := c.dealService.Create(r.Context(), dto.ToEntity())
+		if err != nil {
+			http.Error(w, err.Error(), dealErrorStatus(err))
+			return
+		}
+		shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, created.ID()))
+		return
+	}
+	existing, err := c.dealService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), dealErrorStatus(err))
+		return
+	}
+	if err := c.dealService.Update(r.Context(), dto.Apply(existing)); err != nil {
+		http.Error(w, err.Error(), dealErrorStatus(err))
+		return
+	}
+	if dto.StageID != existing.StageID() {
+		if _, err := c.dealService.Move(r.Context(), id, dto.StageID); err != nil {
+			http.Error(w, err.Error(), dealErrorStatus(err))
+			return
+		}
+	}
+	shared.Redirect(w, r, saveURL)
+}
+
+// Move handles the drop of a card on another column of the board and answers with the updated board
+func (c *DealController) Move(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.DealUpdate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&deal.MoveDTO{}, r)
+	if err != nil || dto.StageID == 0 {
+		http.Error(w, "Error parsing stage", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.dealService.Move(r.Context(), id, dto.StageID); err != nil {
+		http.Error(w, err.Error(), dealErrorStatus(err))
+		return
+	}
+	props, err := c.boardProps(r)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving deals").Error(), dealErrorStatus(err))
+		return
+	}
+	templ.Handler(deals.Board(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *DealController) Delete(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.DealDelete) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.dealService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), dealErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *DealController) Report(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.DealRead) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	props := &deals.ReportPageProps{
+		BaseURL: c.basePath,
+		From:    r.URL.Query().Get("From"),
+		To:      r.URL.Query().Get("To"),
+	}
+	var from, to time.Time
+	if t, err := time.Parse(time.DateOnly, props.From); err == nil {
+		from = t
+	}
+	if t, err := time.Parse(time.DateOnly, props.To); err == nil {
+		to = t.AddDate(0, 0, 1)
+	}
+	report, err := c.dealService.Funnel(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error building funnel").Error(), dealErrorStatus(err))
+		return
+	}
+	props.Report = mappers.FunnelReportToViewModel(report)
+	if htmx.IsHxRequest(r) {
+		templ.Handler(deals.Funnel(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	templ.Handler(deals.Report(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *DealController) Stages(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.PipelineUpdate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	stages, err := c.dealService.Stages(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving stages").Error(), dealErrorStatus(err))
+		return
+	}
+	templ.Handler(deals.Stages(&deals.StagesPageProps{
+		BaseURL: c.basePath,
+		Stages:  mapping.MapViewModels(stages, mappers.PipelineStageToViewModel),
+		Form: &deals.StageFormProps{
+			Stage: &viewmodels.PipelineStage{
+				Position: fmt.Sprint(len(stages) + 1),
+				Outcome:  string(pipelinestage.OutcomeOpen),
+			},
+			SaveURL: c.stagesURL(),
+			Errors:  map[string]string{},
+		},
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *DealController) GetStage(w http.ResponseWriter, r *http.Request) {
+	if !c.can(r, permissions.PipelineUpdate) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	stage, err := c.dealService.GetStageByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving stage").Error(), dealErrorStatus(err))
+		return
+	}
+	templ.Handler(deals.EditStage(&deals.StageFormProps{
+		Stage:   mappers.PipelineStageToViewModel(stage),
+		SaveURL: fmt.Sprintf("%s/%d", c.stagesURL(), id),
+		Errors:  map[string]string{},
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *DealController) CreateStage(w http.ResponseWriter, r *http.Request) {
+	c.saveStage(w, r, 0)
+}
+
+func (c *DealController) UpdateStage(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	c.saveStage(w, r, id)
+}
+
+// saveStage creates a stage when id is zero and updates it otherwise
+func (c *DealController) saveStage(w http.ResponseWriter, r *http.Request, id uint) {
+	dto, err := composables.UseForm(&pipelinestage.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	saveURL := c.stagesURL()
+	if id != 0 {
+		saveURL = fmt.Sprintf("%s/%d", saveURL, id)
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		templ.Handler(deals.StageForm(&deals.StageFormProps{
+			Stage: &viewmodels.PipelineStage{
+				ID:          formatID(id),
+				Name:        dto.Name,
+				Position:    fmt.Sprint(dto.Position),
+				Probability: fmt.Sprint(dto.Probability),
+				Outcome:     dto.Outcome,
+			},
+			SaveURL: saveURL,
+			Errors:  errorsMap,
+		}), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if id == 0 {
+		_, err = c.dealService.CreateStage(r.Context(), dto.ToEntity())
+	} else {
+		var existing pipelinestage.Stage
+		existing, err = c.dealService.GetStageByID(r.Context(), id)
+		if err == nil {
+			err = c.dealService.UpdateStage(r.Context(), dto.Apply(existing))
+		}
+	}
+	if err != nil {
+		http.Error(w, err.Error(), dealErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.stagesURL())
+}
+
+func (c *DealController) DeleteStage(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if err := c.dealService.DeleteStage(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), dealErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func formatID(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return fmt.Sprint(id)
+}
+
+func dealErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, composables.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, persistence.ErrDealNotFound),
+		errors.Is(err, persistence.ErrPipelineStageNotFound),
+		errors.Is(err, persistence.ErrClientNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrStageInUse),
+		errors.Is(err, services.ErrStageChangedByUpdate):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
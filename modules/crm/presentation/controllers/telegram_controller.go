@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+)
+
+func NewTelegramController(
+	app application.Application,
+	telegramProvider *cpassproviders.TelegramProvider,
+) *TelegramController {
+	return &TelegramController{
+		app:              app,
+		telegramProvider: telegramProvider,
+	}
+}
+
+type TelegramController struct {
+	app              application.Application
+	telegramProvider *cpassproviders.TelegramProvider
+}
+
+func (c *TelegramController) Register(r *mux.Router) {
+	// The provider runs its own queries, a request-wide transaction would hide the chats it creates
+	// from the transactions of the chat service
+	r.HandleFunc("/telegram", c.telegramProvider.WebhookHandler()).Methods(http.MethodPost)
+}
+
+func (c *TelegramController) Key() string {
+	return "TelegramController"
+}
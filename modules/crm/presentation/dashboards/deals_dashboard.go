@@ -0,0 +1,141 @@
+// Package dashboards holds lens dashboards built from CRM data
+package dashboards
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/lens"
+	"github.com/iota-uz/iota-sdk/pkg/lens/builder"
+)
+
+// dealFunnelQuery counts, for every open stage, the deals that got to it or further.
+// A deal got as far as the furthest open stage in its history, won deals went through all of them.
+const dealFunnelQuery = `
+	WITH history AS (
+		SELECT deal_id, to_stage_id AS stage_id FROM deal_stage_changes
+		UNION
+		SELECT deal_id, from_stage_id FROM deal_stage_changes
+		UNION
+		SELECT id, stage_id FROM deals
+	), progress AS (
+		SELECT
+			d.id,
+			s.outcome,
+			MAX(CASE WHEN hs.outcome = 'open' THEN hs.position END) AS furthest
+		FROM deals d
+		JOIN pipeline_stages s ON s.id = d.stage_id
+		LEFT JOIN history h ON h.deal_id = d.id
+		LEFT JOIN pipeline_stages hs ON hs.id = h.stage_id
+		WHERE d.tenant_id = $tenant_id
+		GROUP BY d.id, s.outcome
+	)
+	SELECT
+		ps.name AS label,
+		COUNT(p.id)::float8 AS value
+	FROM pipeline_stages ps
+	LEFT JOIN progress p ON p.outcome = 'won' OR p.furthest >= ps.position
+	WHERE ps.tenant_id = $tenant_id AND ps.outcome = 'open'
+	GROUP BY ps.id, ps.name, ps.position
+	ORDER BY ps.position
+`
+
+// DealPanels returns the sales pipeline panels laid out on a 12 columns grid starting at row y,
+// so that they can be added to any dashboard declaring the tenant_id variable
+func DealPanels(y int) []lens.PanelConfig {
+	return []lens.PanelConfig{
+		builder.MetricCard().
+			ID("deals-open").
+			Title("Open Deals").
+			Position(0, y).
+			Size(4, 2).
+			DataSource("postgres").
+			Query(`
+				SELECT
+					'Open Deals' AS timestamp,
+					COUNT(*)::float8 AS value
+				FROM deals d
+				JOIN pipeline_stages s ON s.id = d.stage_id
+				WHERE d.tenant_id = $tenant_id AND s.outcome = 'open'
+			`).
+			Option("color", "#3b82f6").
+			Build(),
+		builder.MetricCard().
+			ID("deals-weighted-pipeline").
+			Title("Weighted Pipeline").
+			Position(4, y).
+			Size(4, 2).
+			DataSource("postgres").
+			Query(`
+				SELECT
+					'Weighted Pipeline' AS timestamp,
+					COALESCE(SUM(d.amount * d.probability / 100) / 100.0, 0)::float8 AS value
+				FROM deals d
+				JOIN pipeline_stages s ON s.id = d.stage_id
+				WHERE d.tenant_id = $tenant_id AND s.outcome = 'open'
+			`).
+			Option("color", "#10b981").
+			Build(),
+		builder.MetricCard().
+			ID("deals-win-rate").
+			Title("Win Rate").
+			Position(8, y).
+			Size(4, 2).
+			DataSource("postgres").
+			Query(`
+				SELECT
+					'Win Rate' AS timestamp,
+					COALESCE(
+						100.0 * COUNT(*) FILTER (WHERE s.outcome = 'won')
+							/ NULLIF(COUNT(*) FILTER (WHERE s.outcome <> 'open'), 0),
+						0
+					)::float8 AS value
+				FROM deals d
+				JOIN pipeline_stages s ON s.id = d.stage_id
+				WHERE d.tenant_id = $tenant_id
+			`).
+			Option("unit", "%").
+			Option("color", "#f59e0b").
+			Build(),
+		builder.BarChart().
+			ID("deals-funnel").
+			Title("Deal Funnel").
+			Position(0, y+2).
+			Size(8, 4).
+			DataSource("postgres").
+			Query(dealFunnelQuery).
+			Option("colors", []string{"#6366f1"}).
+			Build(),
+		builder.PieChart().
+			ID("deals-won-lost").
+			Title("Won vs Lost").
+			Position(8, y+2).
+			Size(4, 4).
+			DataSource("postgres").
+			Query(`
+				SELECT
+					s.name AS label,
+					COUNT(*)::float8 AS value
+				FROM deals d
+				JOIN pipeline_stages s ON s.id = d.stage_id
+				WHERE d.tenant_id = $tenant_id AND s.outcome <> 'open'
+				GROUP BY s.name
+				ORDER BY s.name
+			`).
+			Option("colors", []string{"#10b981", "#ef4444"}).
+			Build(),
+	}
+}
+
+// Deals is a standalone dashboard of the sales pipeline of a tenant
+func Deals(tenantID uuid.UUID) lens.DashboardConfig {
+	dashboard := builder.NewDashboard().
+		ID("crm-deals-dashboard").
+		Title("Sales Pipeline").
+		Description("Open pipeline, funnel and win/loss of the deals").
+		Grid(12, 120).
+		Variable("tenant_id", tenantID.String())
+	for _, panel := range DealPanels(0) {
+		dashboard = dashboard.Panel(panel)
+	}
+	return dashboard.Build()
+}
@@ -0,0 +1,33 @@
+package dashboards_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/dashboards"
+	"github.com/iota-uz/iota-sdk/pkg/lens/builder"
+)
+
+func TestDeals(t *testing.T) {
+	tenantID := uuid.New()
+	dashboard := dashboards.Deals(tenantID)
+	require.NoError(t, builder.ValidateDashboard(dashboard))
+
+	require.Len(t, dashboard.Variables, 1)
+	assert.Equal(t, "tenant_id", dashboard.Variables[0].Name)
+	assert.Equal(t, tenantID.String(), dashboard.Variables[0].Value)
+
+	for _, panel := range dashboard.Panels {
+		assert.Contains(t, panel.Query, "$tenant_id", "panel %s must be scoped to the tenant", panel.ID)
+		assert.LessOrEqual(t, panel.Position.X+panel.Dimensions.Width, dashboard.Grid.Columns, "panel %s overflows the grid", panel.ID)
+	}
+}
+
+func TestDealPanels_Offset(t *testing.T) {
+	for _, panel := range dashboards.DealPanels(6) {
+		assert.GreaterOrEqual(t, panel.Position.Y, 6)
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/passport"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	clientduplicate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/client-duplicate"
 	messagetemplate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/money"
 	"github.com/iota-uz/iota-sdk/pkg/shared"
 )
 
@@ -114,6 +118,7 @@ func MessageToViewModel(entity chat.Message) *viewmodels.Message {
 		ID:        strconv.FormatUint(uint64(entity.ID()), 10),
 		Sender:    SenderToViewModel(entity.Sender().Sender()),
 		Message:   entity.Message(),
+		Transport: string(entity.Sender().Transport()),
 		CreatedAt: entity.CreatedAt(),
 	}
 }
@@ -123,6 +128,7 @@ func ChatToViewModel(entity chat.Chat, clientEntity client.Client) *viewmodels.C
 		ID:             strconv.FormatUint(uint64(entity.ID()), 10),
 		Client:         ClientToViewModel(clientEntity),
 		Messages:       mapping.MapViewModels(entity.Messages(), MessageToViewModel),
+		Members:        mapping.MapViewModels(entity.Members(), MemberToViewModel),
 		UnreadMessages: entity.UnreadMessages(),
 		CreatedAt:      entity.CreatedAt().Format(time.RFC3339),
 	}
@@ -135,3 +141,131 @@ func MessageTemplateToViewModel(entity messagetemplate.MessageTemplate) *viewmod
 		CreatedAt: entity.CreatedAt().Format(time.RFC3339),
 	}
 }
+
+func formatID(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func displayAmounts(amounts []*money.Money) []string {
+	result := make([]string, 0, len(amounts))
+	for _, m := range amounts {
+		result = append(result, m.Display())
+	}
+	return result
+}
+
+func PipelineStageToViewModel(entity pipelinestage.Stage) *viewmodels.PipelineStage {
+	return &viewmodels.PipelineStage{
+		ID:          formatID(entity.ID()),
+		Name:        entity.Name(),
+		Position:    strconv.Itoa(entity.Position()),
+		Probability: strconv.Itoa(entity.Probability()),
+		Outcome:     string(entity.Outcome()),
+		Closed:      entity.Closed(),
+	}
+}
+
+// DealToViewModel maps a deal, clientNames and ownerNames resolve the names of the client and the owner
+func DealToViewModel(entity deal.Deal, clientNames, ownerNames map[uint]string) *viewmodels.Deal {
+	vm := &viewmodels.Deal{
+		ID:          formatID(entity.ID()),
+		Title:       entity.Title(),
+		ClientID:    formatID(entity.ClientID()),
+		ClientName:  clientNames[entity.ClientID()],
+		StageID:     formatID(entity.StageID()),
+		Probability: strconv.Itoa(entity.Probability()),
+		OwnerID:     formatID(entity.OwnerID()),
+		OwnerName:   ownerNames[entity.OwnerID()],
+		Description: entity.Description(),
+		CreatedAt:   entity.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:   entity.UpdatedAt().Format(time.RFC3339),
+	}
+	if amount := entity.Amount(); amount != nil {
+		vm.Amount = fmt.Sprintf("%.2f", amount.AsMajorUnits())
+		vm.CurrencyCode = amount.Currency().Code
+		vm.AmountWithCurrency = amount.Display()
+	}
+	if date := entity.ExpectedCloseDate(); date != nil {
+		vm.ExpectedCloseDate = date.Format(time.DateOnly)
+	}
+	if closedAt := entity.ClosedAt(); closedAt != nil {
+		vm.ClosedAt = closedAt.Format(time.DateOnly)
+	}
+	return vm
+}
+
+// DealBoardToViewModels spreads the deals over the columns of the stages they are in
+func DealBoardToViewModels(
+	stages []pipelinestage.Stage,
+	deals []deal.Deal,
+	clientNames, ownerNames map[uint]string,
+) []*viewmodels.DealColumn {
+	byStage := make(map[uint][]deal.Deal, len(stages))
+	for _, d := range deals {
+		byStage[d.StageID()] = append(byStage[d.StageID()], d)
+	}
+	columns := make([]*viewmodels.DealColumn, 0, len(stages))
+	for _, s := range stages {
+		stageDeals := byStage[s.ID()]
+		columns = append(columns, &viewmodels.DealColumn{
+			Stage: PipelineStageToViewModel(s),
+			Deals: mapping.MapViewModels(stageDeals, func(d deal.Deal) *viewmodels.Deal {
+				return DealToViewModel(d, clientNames, ownerNames)
+			}),
+			Totals: displayAmounts(deal.Totals(stageDeals)),
+		})
+	}
+	return columns
+}
+
+func DealStageChangeToViewModel(
+	change deal.StageChange,
+	stageNames, userNames map[uint]string,
+) *viewmodels.DealStageChange {
+	return &viewmodels.DealStageChange{
+		FromStage: stageNames[change.FromStageID],
+		ToStage:   stageNames[change.ToStageID],
+		ChangedBy: userNames[change.ChangedBy],
+		ChangedAt: change.ChangedAt.Format(time.DateTime),
+	}
+}
+
+func FunnelReportToViewModel(report deal.FunnelReport) *viewmodels.FunnelReport {
+	stages := make([]*viewmodels.FunnelStage, 0, len(report.Stages))
+	for _, s := range report.Stages {
+		stages = append(stages, &viewmodels.FunnelStage{
+			Name:       s.Stage.Name(),
+			Current:    s.Current,
+			Reached:    s.Reached,
+			Conversion: s.Conversion,
+			Amounts:    displayAmounts(s.Amounts),
+		})
+	}
+	return &viewmodels.FunnelReport{
+		Stages:     stages,
+		Total:      report.Total,
+		Won:        report.Won,
+		Lost:       report.Lost,
+		WinRate:    report.WinRate,
+		WonAmounts: displayAmounts(report.WonAmounts),
+		Pipeline:   displayAmounts(report.Pipeline),
+		Weighted:   displayAmounts(report.Weighted),
+	}
+}
+
+func ClientDuplicateToViewModel(entity clientduplicate.Duplicate, first, second client.Client) *viewmodels.ClientDuplicate {
+	reasons := make([]string, 0, len(entity.Reasons()))
+	for _, r := range entity.Reasons() {
+		reasons = append(reasons, string(r))
+	}
+	return &viewmodels.ClientDuplicate{
+		ID:        strconv.FormatUint(uint64(entity.ID()), 10),
+		Client:    ClientToViewModel(first),
+		Duplicate: ClientToViewModel(second),
+		Score:     strconv.Itoa(entity.Score()),
+		Reasons:   reasons,
+	}
+}
@@ -10,6 +10,7 @@ type Chat struct {
 	Client         *Client
 	CreatedAt      string
 	Messages       []*Message
+	Members        []*Member
 	UnreadMessages int
 }
 
@@ -28,6 +29,34 @@ func (c *Chat) LastMessage() *Message {
 	return c.Messages[len(c.Messages)-1]
 }
 
+// Transports lists the transports a reply can be sent with.
+// The first one is the default, which is the transport the client last wrote with.
+func (c *Chat) Transports() []string {
+	transports := []string{"sms"}
+	if c.Client != nil && c.Client.Email != "" {
+		transports = append(transports, "email")
+	}
+	for _, m := range c.Members {
+		if m.Transport == "telegram" && m.Sender.IsClient() {
+			transports = append(transports, "telegram")
+			break
+		}
+	}
+	for i := len(c.Messages) - 1; i >= 0; i-- {
+		if !c.Messages[i].Sender.IsClient() {
+			continue
+		}
+		for j, t := range transports {
+			if t == c.Messages[i].Transport {
+				transports[0], transports[j] = transports[j], transports[0]
+				break
+			}
+		}
+		break
+	}
+	return transports
+}
+
 func (c *Chat) HasUnreadMessages() bool {
 	return c.UnreadMessages > 0
 }
@@ -95,6 +124,7 @@ type Message struct {
 	ID        string
 	Message   string
 	Sender    MessageSender
+	Transport string
 	CreatedAt time.Time
 }
 
@@ -59,3 +59,12 @@ func (c *Client) FullName() string {
 func (c *Client) Initials() string {
 	return shared.GetInitials(c.FirstName, c.LastName)
 }
+
+// ClientDuplicate is a pair of clients waiting for a merge or a dismissal
+type ClientDuplicate struct {
+	ID        string
+	Client    *Client
+	Duplicate *Client
+	Score     string
+	Reasons   []string
+}
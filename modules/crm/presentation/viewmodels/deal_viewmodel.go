@@ -0,0 +1,62 @@
+package viewmodels
+
+type PipelineStage struct {
+	ID          string
+	Name        string
+	Position    string
+	Probability string
+	Outcome     string
+	Closed      bool
+}
+
+type Deal struct {
+	ID                 string
+	Title              string
+	ClientID           string
+	ClientName         string
+	StageID            string
+	Amount             string
+	CurrencyCode       string
+	AmountWithCurrency string
+	Probability        string
+	OwnerID            string
+	OwnerName          string
+	ExpectedCloseDate  string
+	ClosedAt           string
+	Description        string
+	CreatedAt          string
+	UpdatedAt          string
+}
+
+// DealColumn is a stage of the Kanban board with its deals
+type DealColumn struct {
+	Stage  *PipelineStage
+	Deals  []*Deal
+	Totals []string
+}
+
+type DealStageChange struct {
+	FromStage string
+	ToStage   string
+	ChangedBy string
+	ChangedAt string
+}
+
+type FunnelStage struct {
+	Name       string
+	Current    int
+	Reached    int
+	Conversion float64
+	Amounts    []string
+}
+
+type FunnelReport struct {
+	Stages     []*FunnelStage
+	Total      int
+	Won        int
+	Lost       int
+	WinRate    float64
+	WonAmounts []string
+	Pipeline   []string
+	Weighted   []string
+}
@@ -12,6 +12,12 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+)
+
+var (
+	ErrUnsupportedTransport = errors.New("no provider is configured for the transport")
+	ErrUnknownSender        = errors.New("received message is not from a client")
 )
 
 // MessageMedia represents media attached to a message
@@ -236,9 +242,26 @@ func (s *ChatService) GetOrCreateChatByPhone(ctx context.Context, phoneNumber st
 	return chatEntity, clientEntity, nil
 }
 
-func (s *ChatService) onMessageReceived(msg chat.Message) error {
-	// Get or create a chat for the client
-	return nil
+// onMessageReceived stores a message delivered by a provider.
+// Providers that resolved the thread set the chat id, otherwise the chat of the sending client is used.
+func (s *ChatService) onMessageReceived(ctx context.Context, msg chat.Message) error {
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		ctx = composables.WithTenantID(ctx, msg.Sender().TenantID())
+	}
+	chatID := msg.ChatID()
+	if chatID == 0 {
+		sender, ok := msg.Sender().Sender().(chat.ClientSender)
+		if !ok {
+			return ErrUnknownSender
+		}
+		chatEntity, err := s.GetByClientIDOrCreate(ctx, sender.ClientID())
+		if err != nil {
+			return err
+		}
+		chatID = chatEntity.ID()
+	}
+	_, err := s.AddMessageToChat(ctx, chatID, msg)
+	return err
 }
 
 //func (s *ChatService) RegisterClientMessage(
@@ -323,20 +346,27 @@ func (s *ChatService) SendMessage(ctx context.Context, cmd SendMessageCommand) (
 			chat.WithMemberTenantID(tenantID),
 		)
 
-		msg := chat.NewMessage(cmd.Message, member)
+		provider, ok := s.providers[cmd.Transport]
+		if !ok {
+			return ErrUnsupportedTransport
+		}
+
+		msg := chat.NewMessage(
+			cmd.Message,
+			member,
+			chat.WithAttachments(mapping.ValueSlice(cmd.Attachments)),
+		)
 
 		updatedChat, err = s.repo.Save(txCtx, chatEntity.AddMessage(msg))
 		if err != nil {
 			return err
 		}
-		provider := s.providers[cmd.Transport]
-
-		//		cpassproviders.SendMessageDTO{
-		//			From:    configuration.Use().Twilio.PhoneNumber,
-		//			To:      clientEntity.Phone().Value(),
-		//			Message: msg.Message(),
-		//		}
-		return provider.Send(txCtx, msg)
+		// Send the stored message so that providers can rely on its chat and message ids
+		sent, err := updatedChat.LastMessage()
+		if err != nil {
+			return err
+		}
+		return provider.Send(txCtx, sent)
 	})
 	if err != nil {
 		return nil, err
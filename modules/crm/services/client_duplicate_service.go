@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	clientduplicate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/client-duplicate"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrMergeSameClient  = errors.New("a client can not be merged into itself")
+	ErrNotInDuplicate   = errors.New("client is not part of the duplicate pair")
+	ErrDuplicateNotOpen = errors.New("duplicate pair is already dismissed")
+)
+
+// detectLimit caps the clients fetched per lookup when checking a single client for duplicates
+const detectLimit = 200
+
+type ClientDuplicateService struct {
+	repo          clientduplicate.Repository
+	clientRepo    client.Repository
+	clientService *ClientService
+	publisher     eventbus.EventBus
+}
+
+func NewClientDuplicateService(
+	repo clientduplicate.Repository,
+	clientRepo client.Repository,
+	clientService *ClientService,
+	publisher eventbus.EventBus,
+) *ClientDuplicateService {
+	return &ClientDuplicateService{
+		repo:          repo,
+		clientRepo:    clientRepo,
+		clientService: clientService,
+		publisher:     publisher,
+	}
+}
+
+func (s *ClientDuplicateService) Count(ctx context.Context, params *clientduplicate.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *ClientDuplicateService) GetPaginated(ctx context.Context, params *clientduplicate.FindParams) ([]clientduplicate.Duplicate, error) {
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *ClientDuplicateService) GetByID(ctx context.Context, id uint) (clientduplicate.Duplicate, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetMerges returns the clients merged into the client, the latest first
+func (s *ClientDuplicateService) GetMerges(ctx context.Context, clientID uint) ([]clientduplicate.MergeRecord, error) {
+	return s.repo.GetMerges(ctx, clientID)
+}
+
+// Scan compares every client of the tenant and queues the likely duplicates, returning how many pairs were found
+func (s *ClientDuplicateService) Scan(ctx context.Context) (int, error) {
+	clients, err := s.clientRepo.GetPaginated(ctx, &client.FindParams{})
+	if err != nil {
+		return 0, err
+	}
+	candidates := clientduplicate.FindCandidates(clients)
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		for _, c := range candidates {
+			if err := s.repo.Enqueue(txCtx, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(candidates), nil
+}
+
+// Detect queues the pairs a new or changed client forms with the existing ones.
+// Only the clients sharing a phone, an email or a name with it are compared.
+func (s *ClientDuplicateService) Detect(ctx context.Context, target client.Client) error {
+	lookups := make([]*client.FindParams, 0, 4)
+	if target.Phone() != nil {
+		if p := clientduplicate.NormalizePhone(target.Phone().Value()); p != "" {
+			lookups = append(lookups, &client.FindParams{Search: p})
+		}
+	}
+	if target.Email() != nil {
+		lookups = append(lookups, &client.FindParams{
+			Filters: []client.Filter{{Column: client.Email, Filter: repo.Eq(target.Email().Value())}},
+		})
+	}
+	for _, name := range []string{target.LastName(), target.FirstName()} {
+		if len([]rune(name)) >= 2 {
+			lookups = append(lookups, &client.FindParams{Search: name})
+		}
+	}
+
+	seen := map[uint]bool{target.ID(): true}
+	others := make([]client.Client, 0)
+	for _, params := range lookups {
+		params.Limit = detectLimit
+		found, err := s.clientRepo.GetPaginated(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, c := range found {
+			if !seen[c.ID()] {
+				seen[c.ID()] = true
+				others = append(others, c)
+			}
+		}
+	}
+
+	for _, c := range clientduplicate.FindCandidatesFor(target, others) {
+		if err := s.repo.Enqueue(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dismiss marks the pair as different people, so that further scans leave it alone
+func (s *ClientDuplicateService) Dismiss(ctx context.Context, id uint) error {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, entity.Dismiss())
+}
+
+// MergeDuplicate merges the pending pair into the chosen survivor
+func (s *ClientDuplicateService) MergeDuplicate(ctx context.Context, id, survivorID uint) (clientduplicate.MergeRecord, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return clientduplicate.MergeRecord{}, err
+	}
+	if entity.Status() != clientduplicate.StatusPending {
+		return clientduplicate.MergeRecord{}, ErrDuplicateNotOpen
+	}
+	if !entity.Includes(survivorID) {
+		return clientduplicate.MergeRecord{}, ErrNotInDuplicate
+	}
+	return s.Merge(ctx, survivorID, entity.Other(survivorID))
+}
+
+// Merge moves the contacts, chat history and deals of the merged client onto the survivor,
+// fills the blanks of the survivor with the merged client's data and deletes the merged client
+func (s *ClientDuplicateService) Merge(ctx context.Context, survivorID, mergedID uint) (clientduplicate.MergeRecord, error) {
+	if survivorID == mergedID {
+		return clientduplicate.MergeRecord{}, ErrMergeSameClient
+	}
+	survivor, err := s.clientRepo.GetByID(ctx, survivorID)
+	if err != nil {
+		return clientduplicate.MergeRecord{}, err
+	}
+	if err := s.clientService.checkOwnership(ctx, permissions.ClientUpdate, survivor); err != nil {
+		return clientduplicate.MergeRecord{}, err
+	}
+	merged, err := s.clientRepo.GetByID(ctx, mergedID)
+	if err != nil {
+		return clientduplicate.MergeRecord{}, err
+	}
+	if err := s.clientService.checkOwnership(ctx, permissions.ClientDelete, merged); err != nil {
+		return clientduplicate.MergeRecord{}, err
+	}
+
+	record := clientduplicate.MergeRecord{
+		SurvivorID:   survivorID,
+		MergedID:     mergedID,
+		MergedClient: clientduplicate.Snapshot(merged),
+		MergedAt:     time.Now(),
+	}
+	if u, err := composables.UseUser(ctx); err == nil {
+		record.MergedBy = u.ID()
+	}
+
+	var updated client.Client
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		var err error
+		updated, err = s.clientRepo.Save(txCtx, clientduplicate.Absorb(survivor, merged))
+		if err != nil {
+			return err
+		}
+		record, err = s.repo.Merge(txCtx, record)
+		if err != nil {
+			return err
+		}
+		return s.clientRepo.Delete(txCtx, mergedID)
+	})
+	if err != nil {
+		return clientduplicate.MergeRecord{}, err
+	}
+
+	updatedEvent, err := client.NewUpdatedEvent(ctx, survivor)
+	if err != nil {
+		return record, err
+	}
+	updatedEvent.Result = updated
+	s.publisher.Publish(updatedEvent)
+
+	deletedEvent, err := client.NewDeletedEvent(ctx, merged)
+	if err != nil {
+		return record, err
+	}
+	deletedEvent.Result = merged
+	s.publisher.Publish(deletedEvent)
+	return record, nil
+}
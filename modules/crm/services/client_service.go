@@ -3,7 +3,9 @@ package services
 import (
 	"context"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 )
@@ -31,15 +33,34 @@ func (s *ClientService) GetPaginated(ctx context.Context, params *client.FindPar
 	return s.repo.GetPaginated(ctx, params)
 }
 
+// checkOwnership rejects users that hold perm with ModifierOwn only and don't own entity.
+// Plain permission checks are left to the controllers.
+func (s *ClientService) checkOwnership(ctx context.Context, perm *permission.Permission, entity client.Client) error {
+	if _, ok := composables.UseOwnScope(ctx, perm); !ok {
+		return nil
+	}
+	return composables.CanUserAccess(ctx, perm, entity)
+}
+
 func (s *ClientService) GetByID(ctx context.Context, id uint) (client.Client, error) {
-	return s.repo.GetByID(ctx, id)
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwnership(ctx, permissions.ClientRead, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
 }
 
 func (s *ClientService) GetByPhone(ctx context.Context, phoneNumber string) (client.Client, error) {
 	return s.repo.GetByPhone(ctx, phoneNumber)
 }
 
-func (s *ClientService) Create(ctx context.Context, data client.Client) error {
+func (s *ClientService) Create(ctx context.Context, data client.Client) (client.Client, error) {
+	if u, err := composables.UseUser(ctx); err == nil && data.CreatedBy() == 0 {
+		data = data.SetCreatedBy(u.ID())
+	}
 	var err error
 	var createdClient client.Client
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
@@ -51,19 +72,25 @@ func (s *ClientService) Create(ctx context.Context, data client.Client) error {
 		return nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	createdEvent, err := client.NewCreatedEvent(ctx, data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	createdEvent.Result = createdClient
 	s.publisher.Publish(createdEvent)
-	return nil
+	return createdClient, nil
 }
 
 func (s *ClientService) Update(ctx context.Context, data client.Client) error {
-	var err error
+	existing, err := s.repo.GetByID(ctx, data.ID())
+	if err != nil {
+		return err
+	}
+	if err := s.checkOwnership(ctx, permissions.ClientUpdate, existing); err != nil {
+		return err
+	}
 	var updatedClient client.Client
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
 		updated, err := s.repo.Save(ctx, data)
@@ -86,10 +113,13 @@ func (s *ClientService) Update(ctx context.Context, data client.Client) error {
 }
 
 func (s *ClientService) Delete(ctx context.Context, id uint) (client.Client, error) {
-	entity, err := s.GetByID(ctx, id)
+	entity, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkOwnership(ctx, permissions.ClientDelete, entity); err != nil {
+		return nil, err
+	}
 
 	var deletedClient client.Client
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	pipelinestage "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline-stage"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+var (
+	ErrStageInUse           = errors.New("pipeline stage has deals")
+	ErrStageChangedByUpdate = errors.New("deal stage can only be changed through Move")
+)
+
+type DealService struct {
+	repo      deal.Repository
+	stageRepo pipelinestage.Repository
+	publisher eventbus.EventBus
+}
+
+func NewDealService(
+	repo deal.Repository,
+	stageRepo pipelinestage.Repository,
+	publisher eventbus.EventBus,
+) *DealService {
+	return &DealService{
+		repo:      repo,
+		stageRepo: stageRepo,
+		publisher: publisher,
+	}
+}
+
+// Stages returns the pipeline of the tenant ordered by position, a tenant without
+// stages gets the default pipeline
+func (s *DealService) Stages(ctx context.Context) ([]pipelinestage.Stage, error) {
+	stages, err := s.stageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) > 0 {
+		return stages, nil
+	}
+	defaults := pipelinestage.Defaults()
+	stages = make([]pipelinestage.Stage, 0, len(defaults))
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		for _, stage := range defaults {
+			created, err := s.stageRepo.Create(txCtx, stage)
+			if err != nil {
+				return err
+			}
+			stages = append(stages, created)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+func (s *DealService) GetStageByID(ctx context.Context, id uint) (pipelinestage.Stage, error) {
+	return s.stageRepo.GetByID(ctx, id)
+}
+
+func (s *DealService) CreateStage(ctx context.Context, data pipelinestage.Stage) (pipelinestage.Stage, error) {
+	if err := composables.CanUser(ctx, permissions.PipelineUpdate); err != nil {
+		return nil, err
+	}
+	return s.stageRepo.Create(ctx, data)
+}
+
+func (s *DealService) UpdateStage(ctx context.Context, data pipelinestage.Stage) error {
+	if err := composables.CanUser(ctx, permissions.PipelineUpdate); err != nil {
+		return err
+	}
+	return s.stageRepo.Update(ctx, data)
+}
+
+func (s *DealService) DeleteStage(ctx context.Context, id uint) error {
+	if err := composables.CanUser(ctx, permissions.PipelineUpdate); err != nil {
+		return err
+	}
+	count, err := s.repo.Count(ctx, &deal.FindParams{StageID: id})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrStageInUse
+	}
+	return s.stageRepo.Delete(ctx, id)
+}
+
+func (s *DealService) Count(ctx context.Context, params *deal.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *DealService) GetPaginated(ctx context.Context, params *deal.FindParams) ([]deal.Deal, error) {
+	return s.repo.GetPaginated(ctx, params)
+}
+
+// checkOwnership rejects users that hold perm with ModifierOwn only and don't own entity.
+// Plain permission checks are left to the controllers.
+func (s *DealService) checkOwnership(ctx context.Context, perm *permission.Permission, entity deal.Deal) error {
+	if _, ok := composables.UseOwnScope(ctx, perm); !ok {
+		return nil
+	}
+	return composables.CanUserAccess(ctx, perm, entity)
+}
+
+func (s *DealService) GetByID(ctx context.Context, id uint) (deal.Deal, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwnership(ctx, permissions.DealRead, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// History returns the stage changes of the deal, oldest first
+func (s *DealService) History(ctx context.Context, id uint) ([]deal.StageChange, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStageChanges(ctx, []uint{id})
+}
+
+func (s *DealService) changedBy(ctx context.Context) uint {
+	if u, err := composables.UseUser(ctx); err == nil {
+		return u.ID()
+	}
+	return 0
+}
+
+func (s *DealService) Create(ctx context.Context, data deal.Deal) (deal.Deal, error) {
+	stage, err := s.stageRepo.GetByID(ctx, data.StageID())
+	if err != nil {
+		return nil, err
+	}
+	probability := data.Probability()
+	data = data.MoveTo(stage)
+	if probability != 0 {
+		data = data.SetProbability(probability)
+	}
+	userID := s.changedBy(ctx)
+	if data.OwnerID() == 0 {
+		data = data.SetOwnerID(userID)
+	}
+
+	var createdDeal deal.Deal
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		created, err := s.repo.Create(txCtx, data)
+		if err != nil {
+			return err
+		}
+		createdDeal = created
+		return s.repo.RecordStageChange(txCtx, deal.StageChange{
+			DealID:    created.ID(),
+			ToStageID: created.StageID(),
+			ChangedBy: userID,
+			ChangedAt: created.CreatedAt(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	createdEvent, err := deal.NewCreatedEvent(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	createdEvent.Result = createdDeal
+	s.publisher.Publish(createdEvent)
+	return createdDeal, nil
+}
+
+// Update saves everything but the stage of the deal, use Move to change it
+func (s *DealService) Update(ctx context.Context, data deal.Deal) error {
+	existing, err := s.repo.GetByID(ctx, data.ID())
+	if err != nil {
+		return err
+	}
+	if err := s.checkOwnership(ctx, permissions.DealUpdate, existing); err != nil {
+		return err
+	}
+	if data.StageID() != existing.StageID() {
+		return ErrStageChangedByUpdate
+	}
+	if err := s.repo.Update(ctx, data); err != nil {
+		return err
+	}
+	updated, err := s.repo.GetByID(ctx, data.ID())
+	if err != nil {
+		return err
+	}
+	updatedEvent, err := deal.NewUpdatedEvent(ctx, data)
+	if err != nil {
+		return err
+	}
+	updatedEvent.Result = updated
+	s.publisher.Publish(updatedEvent)
+	return nil
+}
+
+// Move puts the deal in another stage of the pipeline and records the change in its history
+func (s *DealService) Move(ctx context.Context, id, stageID uint) (deal.Deal, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwnership(ctx, permissions.DealUpdate, existing); err != nil {
+		return nil, err
+	}
+	if existing.StageID() == stageID {
+		return existing, nil
+	}
+	stage, err := s.stageRepo.GetByID(ctx, stageID)
+	if err != nil {
+		return nil, err
+	}
+	moved := existing.MoveTo(stage)
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, moved); err != nil {
+			return err
+		}
+		return s.repo.RecordStageChange(txCtx, deal.StageChange{
+			DealID:      id,
+			FromStageID: existing.StageID(),
+			ToStageID:   stageID,
+			ChangedBy:   s.changedBy(ctx),
+			ChangedAt:   moved.UpdatedAt(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	stageChangedEvent, err := deal.NewStageChangedEvent(ctx, moved, existing.StageID())
+	if err != nil {
+		return nil, err
+	}
+	stageChangedEvent.Result = moved
+	s.publisher.Publish(stageChangedEvent)
+	return moved, nil
+}
+
+func (s *DealService) Delete(ctx context.Context, id uint) (deal.Deal, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwnership(ctx, permissions.DealDelete, entity); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	deletedEvent, err := deal.NewDeletedEvent(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	deletedEvent.Result = entity
+	s.publisher.Publish(deletedEvent)
+	return entity, nil
+}
+
+// Funnel reports the win/loss funnel of the deals created within [from, to),
+// zero bounds are left open
+func (s *DealService) Funnel(ctx context.Context, from, to time.Time) (deal.FunnelReport, error) {
+	stages, err := s.Stages(ctx)
+	if err != nil {
+		return deal.FunnelReport{}, err
+	}
+	deals, err := s.repo.GetPaginated(ctx, &deal.FindParams{
+		CreatedFrom: from,
+		CreatedTo:   to,
+	})
+	if err != nil {
+		return deal.FunnelReport{}, err
+	}
+	ids := make([]uint, 0, len(deals))
+	for _, d := range deals {
+		ids = append(ids, d.ID())
+	}
+	changes, err := s.repo.GetStageChanges(ctx, ids)
+	if err != nil {
+		return deal.FunnelReport{}, err
+	}
+	return deal.Funnel(stages, deals, changes), nil
+}
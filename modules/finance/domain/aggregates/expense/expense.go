@@ -1,14 +1,18 @@
 package expense
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
 	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
 	"github.com/iota-uz/iota-sdk/pkg/money"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
 )
 
+var ErrAlreadyApproved = errors.New("expense is already approved")
+
 type Option func(e *expense)
 
 // Option setters
@@ -78,6 +82,19 @@ func WithTenantID(tenantID uuid.UUID) Option {
 	}
 }
 
+func WithCreatedBy(userID uint) Option {
+	return func(e *expense) {
+		e.createdBy = userID
+	}
+}
+
+func WithApproval(userID uint, approvedAt time.Time) Option {
+	return func(e *expense) {
+		e.approvedBy = userID
+		e.approvedAt = approvedAt
+	}
+}
+
 // Interface
 type Expense interface {
 	ID() uuid.UUID
@@ -91,6 +108,13 @@ type Expense interface {
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
 	TenantID() uuid.UUID
+	CreatedBy() uint
+	ApprovedBy() uint
+	ApprovedAt() time.Time
+	Approved() bool
+	Owner() rbac.Owner
+	// Attributes exposes the expense to access policies
+	Attributes() map[string]any
 
 	SetAccount(account moneyaccount.Account) Expense
 	SetCategory(category category.ExpenseCategory) Expense
@@ -98,6 +122,9 @@ type Expense interface {
 	SetAmount(amount *money.Money) Expense
 	SetDate(date time.Time) Expense
 	SetAccountingPeriod(period time.Time) Expense
+	SetCreatedBy(userID uint) Expense
+	// Approve records the approval of the expense by the user, ErrAlreadyApproved if it has been approved before
+	Approve(userID uint) (Expense, error)
 }
 
 // Implementation
@@ -139,6 +166,9 @@ type expense struct {
 	createdAt        time.Time
 	updatedAt        time.Time
 	tenantID         uuid.UUID
+	createdBy        uint
+	approvedBy       uint
+	approvedAt       time.Time
 }
 
 func (e *expense) ID() uuid.UUID {
@@ -223,6 +253,66 @@ func (e *expense) SetAccountingPeriod(period time.Time) Expense {
 	return &result
 }
 
+func (e *expense) SetCreatedBy(userID uint) Expense {
+	result := *e
+	result.createdBy = userID
+	return &result
+}
+
 func (e *expense) TenantID() uuid.UUID {
 	return e.tenantID
 }
+
+func (e *expense) CreatedBy() uint {
+	return e.createdBy
+}
+
+func (e *expense) ApprovedBy() uint {
+	return e.approvedBy
+}
+
+func (e *expense) ApprovedAt() time.Time {
+	return e.approvedAt
+}
+
+func (e *expense) Approved() bool {
+	return e.approvedBy != 0
+}
+
+func (e *expense) Approve(userID uint) (Expense, error) {
+	if e.Approved() {
+		return nil, ErrAlreadyApproved
+	}
+	result := *e
+	result.approvedBy = userID
+	result.approvedAt = time.Now()
+	return &result, nil
+}
+
+func (e *expense) Owner() rbac.Owner {
+	if e.createdBy == 0 {
+		return rbac.Owner{}
+	}
+	return rbac.Owner{UserIDs: []uint{e.createdBy}}
+}
+
+func (e *expense) Attributes() map[string]any {
+	attrs := map[string]any{
+		"id":         e.id.String(),
+		"comment":    e.comment,
+		"date":       e.date,
+		"created_by": e.createdBy,
+		"approved":   e.Approved(),
+	}
+	if e.amount != nil {
+		attrs["amount"] = e.amount.AsMajorUnits()
+		attrs["currency"] = e.amount.Currency().Code
+	}
+	if e.account != nil {
+		attrs["account_id"] = e.account.ID().String()
+	}
+	if e.category != nil {
+		attrs["category_id"] = e.category.ID().String()
+	}
+	return attrs
+}
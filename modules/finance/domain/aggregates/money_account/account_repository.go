@@ -12,6 +12,7 @@ type DateRange struct {
 }
 
 type FindParams struct {
+	IDs       []uuid.UUID
 	Limit     int
 	Offset    int
 	SortBy    []string
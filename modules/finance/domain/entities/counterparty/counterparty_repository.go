@@ -18,6 +18,7 @@ const (
 	CreatedAtField
 	UpdatedAtField
 	TenantIDField
+	IDField
 )
 
 type SortBy = repo.SortBy[Field]
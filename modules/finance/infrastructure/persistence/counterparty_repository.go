@@ -64,6 +64,7 @@ func NewCounterpartyRepository() counterparty.Repository {
 			counterparty.CreatedAtField:    "cp.created_at",
 			counterparty.UpdatedAtField:    "cp.updated_at",
 			counterparty.TenantIDField:     "cp.tenant_id",
+			counterparty.IDField:           "cp.id",
 		},
 	}
 }
@@ -10,6 +10,7 @@ import (
 	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
 	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 )
@@ -17,7 +18,8 @@ import (
 const (
 	// SQL queries
 	expenseFindQuery = `
-		SELECT ex.id, ex.transaction_id, ex.category_id, ex.tenant_id, ex.created_at, ex.updated_at,
+		SELECT ex.id, ex.transaction_id, ex.category_id, ex.tenant_id, ex.created_by_id, ex.approved_by_id, ex.approved_at,
+		ex.created_at, ex.updated_at,
 		tr.amount, tr.transaction_date, tr.accounting_period, tr.transaction_type, tr.comment,
 		tr.origin_account_id, tr.destination_account_id
 		FROM expenses ex LEFT JOIN transactions tr on tr.id = ex.transaction_id`
@@ -25,14 +27,14 @@ const (
 	expenseCountQuery = `SELECT COUNT(ex.id) FROM expenses ex LEFT JOIN transactions tr on tr.id = ex.transaction_id`
 
 	expenseInsertQuery = `
-		INSERT INTO expenses (id, transaction_id, category_id, tenant_id)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO expenses (id, transaction_id, category_id, tenant_id, created_by_id)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id`
 
 	expenseUpdateQuery = `
 		UPDATE expenses
-		SET transaction_id = $1, category_id = $2
-		WHERE id = $3`
+		SET transaction_id = $1, category_id = $2, approved_by_id = $3, approved_at = $4
+		WHERE id = $5`
 
 	expenseDeleteQuery = `DELETE FROM expenses where id = $1`
 )
@@ -70,6 +72,12 @@ func (g *GormExpenseRepository) buildExpenseFilters(ctx context.Context, params
 	where := []string{"ex.tenant_id = $1"}
 	args := []interface{}{tenantID}
 
+	// users holding only the "own" read permission see the expenses they created
+	if u, ok := composables.UseOwnScope(ctx, permissions.ExpenseRead); ok {
+		where = append(where, fmt.Sprintf("ex.created_by_id = $%d", len(args)+1))
+		args = append(args, u.ID())
+	}
+
 	for _, filter := range params.Filters {
 		column, ok := g.fieldMap[filter.Column]
 		if !ok {
@@ -124,6 +132,9 @@ func (g *GormExpenseRepository) queryExpenses(ctx context.Context, query string,
 			&dbExpense.TransactionID,
 			&dbExpense.CategoryID,
 			&dbExpense.TenantID,
+			&dbExpense.CreatedBy,
+			&dbExpense.ApprovedBy,
+			&dbExpense.ApprovedAt,
 			&dbExpense.CreatedAt,
 			&dbExpense.UpdatedAt,
 			&dbTransaction.Amount,
@@ -173,6 +184,9 @@ func (g *GormExpenseRepository) queryExpenses(ctx context.Context, query string,
 			expense.WithAccountingPeriod(data.domainExpense.AccountingPeriod()),
 			expense.WithCreatedAt(data.domainExpense.CreatedAt()),
 			expense.WithUpdatedAt(data.domainExpense.UpdatedAt()),
+			expense.WithTenantID(data.domainExpense.TenantID()),
+			expense.WithCreatedBy(data.domainExpense.CreatedBy()),
+			expense.WithApproval(data.domainExpense.ApprovedBy(), data.domainExpense.ApprovedAt()),
 		)
 		expenses = append(expenses, exp)
 	}
@@ -221,16 +235,15 @@ func (g *GormExpenseRepository) Count(ctx context.Context, params *expense.FindP
 }
 
 func (g *GormExpenseRepository) GetAll(ctx context.Context) ([]expense.Expense, error) {
-	tenantID, err := composables.UseTenantID(ctx)
+	where, args, err := g.buildExpenseFilters(ctx, &expense.FindParams{})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get tenant from context")
+		return nil, errors.Wrap(err, "failed to build filters")
 	}
-
 	query := repo.Join(
 		expenseFindQuery,
-		repo.JoinWhere("ex.tenant_id = $1"),
+		repo.JoinWhere(where...),
 	)
-	return g.queryExpenses(ctx, query, tenantID)
+	return g.queryExpenses(ctx, query, args...)
 }
 
 func (g *GormExpenseRepository) GetByID(ctx context.Context, id uuid.UUID) (expense.Expense, error) {
@@ -273,6 +286,7 @@ func (g *GormExpenseRepository) Create(ctx context.Context, data expense.Expense
 		createdTransaction.ID(),
 		expenseRow.CategoryID,
 		expenseRow.TenantID,
+		expenseRow.CreatedBy,
 	).Scan(&id); err != nil {
 		return nil, errors.Wrap(err, "failed to create expense")
 	}
@@ -294,6 +308,8 @@ func (g *GormExpenseRepository) Update(ctx context.Context, data expense.Expense
 		expenseUpdateQuery,
 		updatedTransaction.ID().String(),
 		expenseRow.CategoryID,
+		expenseRow.ApprovedBy,
+		expenseRow.ApprovedAt,
 		expenseRow.ID,
 	); err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("failed to update expense with ID: %s", expenseRow.ID))
@@ -306,6 +306,8 @@ func ToDomainExpense(dbExpense *models.Expense, dbTransaction *models.Transactio
 		expense.WithAccountingPeriod(dbTransaction.AccountingPeriod),
 		expense.WithCreatedAt(dbExpense.CreatedAt),
 		expense.WithUpdatedAt(dbExpense.UpdatedAt),
+		expense.WithCreatedBy(uint(dbExpense.CreatedBy.Int64)),
+		expense.WithApproval(uint(dbExpense.ApprovedBy.Int64), dbExpense.ApprovedAt.Time),
 	)
 
 	return domainExpense, nil
@@ -332,6 +334,9 @@ func ToDBExpense(entity expense.Expense) (*models.Expense, transaction.Transacti
 		TenantID:      tenantID.String(),
 		CategoryID:    entity.Category().ID().String(),
 		TransactionID: entity.TransactionID().String(),
+		CreatedBy:     mapping.ValueToSQLNullInt64(int64(entity.CreatedBy())),
+		ApprovedBy:    mapping.ValueToSQLNullInt64(int64(entity.ApprovedBy())),
+		ApprovedAt:    mapping.ValueToSQLNullTime(entity.ApprovedAt()),
 		CreatedAt:     entity.CreatedAt(),
 		UpdatedAt:     entity.UpdatedAt(),
 	}
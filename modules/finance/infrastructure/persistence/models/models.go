@@ -46,6 +46,9 @@ type Expense struct {
 	TransactionID string
 	CategoryID    string
 	TenantID      string
+	CreatedBy     sql.NullInt64
+	ApprovedBy    sql.NullInt64
+	ApprovedAt    sql.NullTime
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
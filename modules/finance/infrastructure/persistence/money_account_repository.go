@@ -79,6 +79,10 @@ func (g *GormMoneyAccountRepository) GetPaginated(ctx context.Context, params *m
 		where = append(where, fmt.Sprintf("ma.%s::VARCHAR ILIKE $%d", params.Field, len(args)+1))
 		args = append(args, "%"+params.Query+"%")
 	}
+	if len(params.IDs) > 0 {
+		where = append(where, fmt.Sprintf("ma.id = ANY($%d)", len(args)+1))
+		args = append(args, params.IDs)
+	}
 	q := repo.Join(
 		findQuery,
 		repo.JoinWhere(where...),
@@ -80,6 +80,9 @@ CREATE TABLE expenses (
     tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
     transaction_id uuid NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
     category_id uuid NOT NULL REFERENCES expense_categories (id) ON DELETE CASCADE,
+    created_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    approved_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    approved_at timestamp with time zone,
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now()
 );
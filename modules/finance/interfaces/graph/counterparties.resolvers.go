@@ -0,0 +1,158 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// CreateCounterparty is the resolver for the createCounterparty field.
+func (r *mutationResolver) CreateCounterparty(ctx context.Context, input model.SaveCounterparty) (*model.Counterparty, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	dto := &dtos.CounterpartyCreateDTO{
+		TIN:          mapping.Value(input.Tin),
+		Name:         input.Name,
+		Type:         input.Type,
+		LegalType:    input.LegalType,
+		LegalAddress: mapping.Value(input.LegalAddress),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := dto.ToEntity(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	created, err := r.counterpartyService.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.CounterpartyToGraphModel(created), nil
+}
+
+// UpdateCounterparty is the resolver for the updateCounterparty field.
+func (r *mutationResolver) UpdateCounterparty(ctx context.Context, id uuid.UUID, input model.SaveCounterparty) (*model.Counterparty, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	dto := &dtos.CounterpartyUpdateDTO{
+		TIN:          mapping.Value(input.Tin),
+		Name:         input.Name,
+		Type:         input.Type,
+		LegalType:    input.LegalType,
+		LegalAddress: mapping.Value(input.LegalAddress),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	existing, err := r.counterpartyService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := dto.Apply(existing)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := r.counterpartyService.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.CounterpartyToGraphModel(updated), nil
+}
+
+// DeleteCounterparty is the resolver for the deleteCounterparty field.
+func (r *mutationResolver) DeleteCounterparty(ctx context.Context, id uuid.UUID) (*model.Counterparty, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	deleted, err := r.counterpartyService.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.CounterpartyToGraphModel(deleted), nil
+}
+
+// Counterparty is the resolver for the counterparty field.
+func (r *queryResolver) Counterparty(ctx context.Context, id uuid.UUID) (*model.Counterparty, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	entity, err := r.counterpartyService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.CounterpartyToGraphModel(entity), nil
+}
+
+// Counterparties is the resolver for the counterparties field.
+func (r *queryResolver) Counterparties(ctx context.Context, offset int, limit int, filter *model.CounterpartyFilter) (*model.PaginatedCounterparties, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	params := &counterparty.FindParams{
+		SortBy: counterparty.SortBy{
+			Fields: []repo.SortByField[counterparty.Field]{
+				{Field: counterparty.CreatedAtField, Ascending: false},
+			},
+		},
+	}
+	if filter != nil {
+		params.Search = mapping.Value(filter.Search)
+		if filter.Type != nil {
+			params.Filters = append(params.Filters, counterparty.Filter{
+				Column: counterparty.TypeField,
+				Filter: repo.Eq(*filter.Type),
+			})
+		}
+		if filter.LegalType != nil {
+			params.Filters = append(params.Filters, counterparty.Filter{
+				Column: counterparty.LegalTypeField,
+				Filter: repo.Eq(*filter.LegalType),
+			})
+		}
+	}
+	total, err := r.counterpartyService.Count(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	params.Offset, params.Limit = offset, limit
+	entities, err := r.counterpartyService.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PaginatedCounterparties{
+		Data:  CounterpartiesToGraphModel(entities),
+		Total: total,
+	}, nil
+}
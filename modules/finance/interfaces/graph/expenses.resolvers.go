@@ -0,0 +1,217 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// Account is the resolver for the account field.
+func (r *expenseResolver) Account(ctx context.Context, obj *model.Expense) (*model.MoneyAccount, error) {
+	account, err := r.loaders(ctx).MoneyAccount.Load(ctx, obj.AccountID)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	return mappers.MoneyAccountToGraphModel(account), nil
+}
+
+// CreateExpense is the resolver for the createExpense field.
+func (r *mutationResolver) CreateExpense(ctx context.Context, input model.CreateExpense) (*model.Expense, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	dto := &dtos.ExpenseCreateDTO{
+		Amount:           input.Amount,
+		AccountID:        input.AccountID.String(),
+		CategoryID:       input.CategoryID.String(),
+		Comment:          mapping.Value(input.Comment),
+		AccountingPeriod: shared.DateOnly(input.AccountingPeriod),
+		Date:             shared.DateOnly(input.Date),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	category, err := r.expenseCategoryService.GetByID(ctx, input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := r.moneyAccountService.GetByID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := dto.ToEntityWithReferences(tenantID, account, category)
+	if err != nil {
+		return nil, err
+	}
+	created, err := r.expenseService.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	r.loaders(ctx).MoneyAccount.Prime(account.ID(), account)
+	return mappers.ExpenseToGraphModel(created), nil
+}
+
+// UpdateExpense is the resolver for the updateExpense field.
+func (r *mutationResolver) UpdateExpense(ctx context.Context, id uuid.UUID, input model.UpdateExpense) (*model.Expense, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	dto := &dtos.ExpenseUpdateDTO{
+		Amount:           input.Amount,
+		AccountID:        input.AccountID.String(),
+		CategoryID:       input.CategoryID.String(),
+		Comment:          mapping.Value(input.Comment),
+		AccountingPeriod: shared.DateOnly(input.AccountingPeriod),
+		Date:             shared.DateOnly(input.Date),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	existing, err := r.expenseService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	category, err := r.expenseCategoryService.GetByID(ctx, input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := dto.Apply(existing, category)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := r.expenseService.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ExpenseToGraphModel(updated), nil
+}
+
+// DeleteExpense is the resolver for the deleteExpense field.
+func (r *mutationResolver) DeleteExpense(ctx context.Context, id uuid.UUID) (*model.Expense, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	deleted, err := r.expenseService.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ExpenseToGraphModel(deleted), nil
+}
+
+// Expense is the resolver for the expense field.
+func (r *queryResolver) Expense(ctx context.Context, id uuid.UUID) (*model.Expense, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	entity, err := r.expenseService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ExpenseToGraphModel(entity), nil
+}
+
+// Expenses is the resolver for the expenses field.
+func (r *queryResolver) Expenses(ctx context.Context, offset int, limit int, filter *model.ExpenseFilter) (*model.PaginatedExpenses, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	params := &expense.FindParams{
+		SortBy: expense.SortBy{
+			Fields: []repo.SortByField[expense.Field]{
+				{Field: expense.CreatedAt, Ascending: false},
+			},
+		},
+	}
+	if filter != nil {
+		params.Search = mapping.Value(filter.Search)
+		if filter.CategoryID != nil {
+			params.Filters = append(params.Filters, expense.Filter{
+				Column: expense.CategoryID,
+				Filter: repo.Eq(*filter.CategoryID),
+			})
+		}
+		if filter.CreatedFrom != nil {
+			params.Filters = append(params.Filters, expense.Filter{
+				Column: expense.CreatedAt,
+				Filter: repo.Gte(*filter.CreatedFrom),
+			})
+		}
+		if filter.CreatedTo != nil {
+			params.Filters = append(params.Filters, expense.Filter{
+				Column: expense.CreatedAt,
+				Filter: repo.Lte(*filter.CreatedTo),
+			})
+		}
+	}
+	total, err := r.expenseService.Count(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	params.Offset, params.Limit = offset, limit
+	entities, err := r.expenseService.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PaginatedExpenses{
+		Data:  ExpensesToGraphModel(entities),
+		Total: int64(total),
+	}, nil
+}
+
+// ExpenseCreated is the resolver for the expenseCreated field.
+func (r *subscriptionResolver) ExpenseCreated(ctx context.Context) (<-chan *model.Expense, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, serrors.UnauthorizedGQLError(graphql.GetPath(ctx))
+	}
+	if err := composables.CanUserScoped(ctx, permissions.ExpenseRead); err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sdkgraphql.Subscribe(ctx, r.expenseCreated, func(e expense.Expense) (*model.Expense, bool) {
+		if e.TenantID() != tenantID || composables.CanUserAccess(ctx, permissions.ExpenseRead, e) != nil {
+			return nil, false
+		}
+		return mappers.ExpenseToGraphModel(e), true
+	}), nil
+}
+
+// Expense returns ExpenseResolver implementation.
+func (r *Resolver) Expense() ExpenseResolver { return &expenseResolver{r} }
+
+type expenseResolver struct{ *Resolver }
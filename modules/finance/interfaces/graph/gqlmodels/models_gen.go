@@ -0,0 +1,183 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Counterparty struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Tin          string    `json:"tin"`
+	Type         string    `json:"type"`
+	LegalType    string    `json:"legalType"`
+	LegalAddress string    `json:"legalAddress"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type CounterpartyFilter struct {
+	Search    *string `json:"search,omitempty"`
+	Type      *string `json:"type,omitempty"`
+	LegalType *string `json:"legalType,omitempty"`
+}
+
+type CreateExpense struct {
+	Amount           float64   `json:"amount"`
+	AccountID        uuid.UUID `json:"accountId"`
+	CategoryID       uuid.UUID `json:"categoryId"`
+	Date             time.Time `json:"date"`
+	AccountingPeriod time.Time `json:"accountingPeriod"`
+	Comment          *string   `json:"comment,omitempty"`
+}
+
+type CreateMoneyAccount struct {
+	Name          string  `json:"name"`
+	AccountNumber *string `json:"accountNumber,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	Balance       float64 `json:"balance"`
+	CurrencyCode  string  `json:"currencyCode"`
+}
+
+type CreatePayment struct {
+	Amount           float64   `json:"amount"`
+	AccountID        uuid.UUID `json:"accountId"`
+	CounterpartyID   uuid.UUID `json:"counterpartyId"`
+	CategoryID       uuid.UUID `json:"categoryId"`
+	TransactionDate  time.Time `json:"transactionDate"`
+	AccountingPeriod time.Time `json:"accountingPeriod"`
+	Comment          *string   `json:"comment,omitempty"`
+}
+
+type Expense struct {
+	ID               uuid.UUID        `json:"id"`
+	Amount           *Money           `json:"amount"`
+	Date             time.Time        `json:"date"`
+	AccountingPeriod time.Time        `json:"accountingPeriod"`
+	Comment          string           `json:"comment"`
+	Category         *ExpenseCategory `json:"category,omitempty"`
+	AccountID        uuid.UUID        `json:"accountId"`
+	Account          *MoneyAccount    `json:"account,omitempty"`
+	CreatedAt        time.Time        `json:"createdAt"`
+	UpdatedAt        time.Time        `json:"updatedAt"`
+}
+
+type ExpenseCategory struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+type ExpenseFilter struct {
+	Search      *string    `json:"search,omitempty"`
+	CategoryID  *uuid.UUID `json:"categoryId,omitempty"`
+	CreatedFrom *time.Time `json:"createdFrom,omitempty"`
+	CreatedTo   *time.Time `json:"createdTo,omitempty"`
+}
+
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+type MoneyAccount struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	AccountNumber string    `json:"accountNumber"`
+	Description   string    `json:"description"`
+	Balance       *Money    `json:"balance"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type MoneyAccountFilter struct {
+	Name        *string    `json:"name,omitempty"`
+	CreatedFrom *time.Time `json:"createdFrom,omitempty"`
+	CreatedTo   *time.Time `json:"createdTo,omitempty"`
+}
+
+type Mutation struct {
+}
+
+type PaginatedCounterparties struct {
+	Data  []*Counterparty `json:"data"`
+	Total int64           `json:"total"`
+}
+
+type PaginatedExpenses struct {
+	Data  []*Expense `json:"data"`
+	Total int64      `json:"total"`
+}
+
+type PaginatedMoneyAccounts struct {
+	Data  []*MoneyAccount `json:"data"`
+	Total int64           `json:"total"`
+}
+
+type PaginatedPayments struct {
+	Data  []*Payment `json:"data"`
+	Total int64      `json:"total"`
+}
+
+type Payment struct {
+	ID               uuid.UUID     `json:"id"`
+	Amount           *Money        `json:"amount"`
+	TransactionDate  time.Time     `json:"transactionDate"`
+	AccountingPeriod time.Time     `json:"accountingPeriod"`
+	Comment          string        `json:"comment"`
+	CategoryID       *uuid.UUID    `json:"categoryId,omitempty"`
+	AccountID        uuid.UUID     `json:"accountId"`
+	Account          *MoneyAccount `json:"account,omitempty"`
+	CounterpartyID   uuid.UUID     `json:"counterpartyId"`
+	Counterparty     *Counterparty `json:"counterparty,omitempty"`
+	CreatedAt        time.Time     `json:"createdAt"`
+	UpdatedAt        time.Time     `json:"updatedAt"`
+}
+
+type PaymentFilter struct {
+	CreatedFrom *time.Time `json:"createdFrom,omitempty"`
+	CreatedTo   *time.Time `json:"createdTo,omitempty"`
+}
+
+type Query struct {
+}
+
+type SaveCounterparty struct {
+	Name         string  `json:"name"`
+	Tin          *string `json:"tin,omitempty"`
+	Type         string  `json:"type"`
+	LegalType    string  `json:"legalType"`
+	LegalAddress *string `json:"legalAddress,omitempty"`
+}
+
+type Subscription struct {
+}
+
+type UpdateExpense struct {
+	Amount           float64   `json:"amount"`
+	AccountID        uuid.UUID `json:"accountId"`
+	CategoryID       uuid.UUID `json:"categoryId"`
+	Date             time.Time `json:"date"`
+	AccountingPeriod time.Time `json:"accountingPeriod"`
+	Comment          *string   `json:"comment,omitempty"`
+}
+
+type UpdateMoneyAccount struct {
+	Name          string   `json:"name"`
+	AccountNumber *string  `json:"accountNumber,omitempty"`
+	Description   *string  `json:"description,omitempty"`
+	Balance       *float64 `json:"balance,omitempty"`
+	CurrencyCode  *string  `json:"currencyCode,omitempty"`
+}
+
+type UpdatePayment struct {
+	Amount           float64    `json:"amount"`
+	CounterpartyID   *uuid.UUID `json:"counterpartyId,omitempty"`
+	CategoryID       *uuid.UUID `json:"categoryId,omitempty"`
+	TransactionDate  time.Time  `json:"transactionDate"`
+	AccountingPeriod time.Time  `json:"accountingPeriod"`
+	Comment          *string    `json:"comment,omitempty"`
+}
@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+type loadersKey struct{}
+
+// Loaders batch the relation fields resolved within one operation
+type Loaders struct {
+	MoneyAccount *sdkgraphql.Loader[uuid.UUID, moneyaccount.Account]
+	Counterparty *sdkgraphql.Loader[uuid.UUID, counterparty.Counterparty]
+}
+
+func (r *Resolver) NewLoaders() *Loaders {
+	return &Loaders{
+		MoneyAccount: sdkgraphql.NewLoader(r.loadMoneyAccounts),
+		Counterparty: sdkgraphql.NewLoader(r.loadCounterparties),
+	}
+}
+
+// AroundOperations gives every operation its own loaders, register it on the module executor
+func (r *Resolver) AroundOperations(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	return next(context.WithValue(ctx, loadersKey{}, r.NewLoaders()))
+}
+
+func (r *Resolver) loaders(ctx context.Context) *Loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*Loaders); ok {
+		return l
+	}
+	return r.NewLoaders()
+}
+
+func (r *Resolver) loadMoneyAccounts(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]moneyaccount.Account, error) {
+	accounts, err := r.moneyAccountService.GetPaginated(ctx, &moneyaccount.FindParams{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uuid.UUID]moneyaccount.Account, len(accounts))
+	for _, a := range accounts {
+		result[a.ID()] = a
+	}
+	return result, nil
+}
+
+func (r *Resolver) loadCounterparties(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]counterparty.Counterparty, error) {
+	entities, err := r.counterpartyService.GetPaginated(ctx, &counterparty.FindParams{
+		Filters: []counterparty.Filter{
+			{Column: counterparty.IDField, Filter: repo.In(ids)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uuid.UUID]counterparty.Counterparty, len(entities))
+	for _, c := range entities {
+		result[c.ID()] = c
+	}
+	return result, nil
+}
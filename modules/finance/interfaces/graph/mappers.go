@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/pkg/fp"
+)
+
+var (
+	PaymentsToGraphModel       = fp.Map[payment.Payment, *model.Payment](mappers.PaymentToGraphModel)
+	ExpensesToGraphModel       = fp.Map[expense.Expense, *model.Expense](mappers.ExpenseToGraphModel)
+	MoneyAccountsToGraphModel  = fp.Map[moneyaccount.Account, *model.MoneyAccount](mappers.MoneyAccountToGraphModel)
+	CounterpartiesToGraphModel = fp.Map[counterparty.Counterparty, *model.Counterparty](mappers.CounterpartyToGraphModel)
+)
+
+// createdAtRange turns optional bounds into the From/To strings of the repositories' DateRange,
+// an open bound stretches to infinity
+func createdAtRange(from, to *time.Time) (string, string) {
+	if from == nil && to == nil {
+		return "", ""
+	}
+	lower, upper := "-infinity", "infinity"
+	if from != nil {
+		lower = from.Format(time.RFC3339)
+	}
+	if to != nil {
+		upper = to.Format(time.RFC3339)
+	}
+	return lower, upper
+}
@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+)
+
+func CounterpartyToGraphModel(entity counterparty.Counterparty) *model.Counterparty {
+	var tin string
+	if entity.Tin() != nil {
+		tin = entity.Tin().Value()
+	}
+	return &model.Counterparty{
+		ID:           entity.ID(),
+		Name:         entity.Name(),
+		Tin:          tin,
+		Type:         string(entity.Type()),
+		LegalType:    string(entity.LegalType()),
+		LegalAddress: entity.LegalAddress(),
+		CreatedAt:    entity.CreatedAt(),
+		UpdatedAt:    entity.UpdatedAt(),
+	}
+}
@@ -0,0 +1,35 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+)
+
+// ExpenseToGraphModel leaves the account to the field resolver, the repository only loads its id
+func ExpenseToGraphModel(entity expense.Expense) *model.Expense {
+	var category *model.ExpenseCategory
+	if c := entity.Category(); c != nil {
+		category = &model.ExpenseCategory{
+			ID:          c.ID(),
+			Name:        c.Name(),
+			Description: c.Description(),
+		}
+	}
+	var accountID uuid.UUID
+	if entity.Account() != nil {
+		accountID = entity.Account().ID()
+	}
+	return &model.Expense{
+		ID:               entity.ID(),
+		Amount:           MoneyToGraphModel(entity.Amount()),
+		Date:             entity.Date(),
+		AccountingPeriod: entity.AccountingPeriod(),
+		Comment:          entity.Comment(),
+		Category:         category,
+		AccountID:        accountID,
+		CreatedAt:        entity.CreatedAt(),
+		UpdatedAt:        entity.UpdatedAt(),
+	}
+}
@@ -0,0 +1,29 @@
+package mappers
+
+import (
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func MoneyToGraphModel(m *money.Money) *model.Money {
+	if m == nil {
+		return &model.Money{}
+	}
+	return &model.Money{
+		Amount:   m.AsMajorUnits(),
+		Currency: m.Currency().Code,
+	}
+}
+
+func MoneyAccountToGraphModel(entity moneyaccount.Account) *model.MoneyAccount {
+	return &model.MoneyAccount{
+		ID:            entity.ID(),
+		Name:          entity.Name(),
+		AccountNumber: entity.AccountNumber(),
+		Description:   entity.Description(),
+		Balance:       MoneyToGraphModel(entity.Balance()),
+		CreatedAt:     entity.CreatedAt(),
+		UpdatedAt:     entity.UpdatedAt(),
+	}
+}
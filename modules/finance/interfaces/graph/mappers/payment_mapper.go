@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+)
+
+// PaymentToGraphModel leaves the account and the counterparty to the field resolvers,
+// the repository only loads their ids
+func PaymentToGraphModel(entity payment.Payment) *model.Payment {
+	var categoryID *uuid.UUID
+	if entity.Category() != nil && entity.Category().ID() != uuid.Nil {
+		id := entity.Category().ID()
+		categoryID = &id
+	}
+	var accountID uuid.UUID
+	if entity.Account() != nil {
+		accountID = entity.Account().ID()
+	}
+	return &model.Payment{
+		ID:               entity.ID(),
+		Amount:           MoneyToGraphModel(entity.Amount()),
+		TransactionDate:  entity.TransactionDate(),
+		AccountingPeriod: entity.AccountingPeriod(),
+		Comment:          entity.Comment(),
+		CategoryID:       categoryID,
+		AccountID:        accountID,
+		CounterpartyID:   entity.CounterpartyID(),
+		CreatedAt:        entity.CreatedAt(),
+		UpdatedAt:        entity.UpdatedAt(),
+	}
+}
@@ -0,0 +1,144 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// CreateMoneyAccount is the resolver for the createMoneyAccount field.
+func (r *mutationResolver) CreateMoneyAccount(ctx context.Context, input model.CreateMoneyAccount) (*model.MoneyAccount, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	dto := &dtos.MoneyAccountCreateDTO{
+		Name:          input.Name,
+		Balance:       input.Balance,
+		AccountNumber: mapping.Value(input.AccountNumber),
+		CurrencyCode:  input.CurrencyCode,
+		Description:   mapping.Value(input.Description),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := dto.ToEntity(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	created, err := r.moneyAccountService.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MoneyAccountToGraphModel(created), nil
+}
+
+// UpdateMoneyAccount is the resolver for the updateMoneyAccount field.
+func (r *mutationResolver) UpdateMoneyAccount(ctx context.Context, id uuid.UUID, input model.UpdateMoneyAccount) (*model.MoneyAccount, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	dto := &dtos.MoneyAccountUpdateDTO{
+		Name:          input.Name,
+		Balance:       mapping.Value(input.Balance),
+		AccountNumber: mapping.Value(input.AccountNumber),
+		CurrencyCode:  mapping.Value(input.CurrencyCode),
+		Description:   mapping.Value(input.Description),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	existing, err := r.moneyAccountService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := dto.Apply(existing)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := r.moneyAccountService.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MoneyAccountToGraphModel(updated), nil
+}
+
+// DeleteMoneyAccount is the resolver for the deleteMoneyAccount field.
+func (r *mutationResolver) DeleteMoneyAccount(ctx context.Context, id uuid.UUID) (*model.MoneyAccount, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	deleted, err := r.moneyAccountService.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MoneyAccountToGraphModel(deleted), nil
+}
+
+// MoneyAccount is the resolver for the moneyAccount field.
+func (r *queryResolver) MoneyAccount(ctx context.Context, id uuid.UUID) (*model.MoneyAccount, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	entity, err := r.moneyAccountService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.MoneyAccountToGraphModel(entity), nil
+}
+
+// MoneyAccounts is the resolver for the moneyAccounts field.
+func (r *queryResolver) MoneyAccounts(ctx context.Context, offset int, limit int, filter *model.MoneyAccountFilter) (*model.PaginatedMoneyAccounts, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	params := &moneyaccount.FindParams{
+		Offset: offset,
+		Limit:  limit,
+	}
+	if filter != nil {
+		if filter.Name != nil {
+			params.Field, params.Query = "name", *filter.Name
+		}
+		params.CreatedAt.From, params.CreatedAt.To = createdAtRange(filter.CreatedFrom, filter.CreatedTo)
+	}
+	entities, err := r.moneyAccountService.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.moneyAccountService.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PaginatedMoneyAccounts{
+		Data:  MoneyAccountsToGraphModel(entities),
+		Total: total,
+	}, nil
+}
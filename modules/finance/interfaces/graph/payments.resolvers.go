@@ -0,0 +1,201 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	model "github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// CreatePayment is the resolver for the createPayment field.
+func (r *mutationResolver) CreatePayment(ctx context.Context, input model.CreatePayment) (*model.Payment, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	dto := &dtos.PaymentCreateDTO{
+		Amount:            input.Amount,
+		AccountID:         input.AccountID.String(),
+		TransactionDate:   shared.DateOnly(input.TransactionDate),
+		AccountingPeriod:  shared.DateOnly(input.AccountingPeriod),
+		CounterpartyID:    input.CounterpartyID.String(),
+		PaymentCategoryID: input.CategoryID.String(),
+		UserID:            u.ID(),
+		Comment:           mapping.Value(input.Comment),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	category, err := r.paymentCategoryService.GetByID(ctx, input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	created, err := r.paymentService.Create(ctx, dto.ToEntity(tenantID, category))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.PaymentToGraphModel(created), nil
+}
+
+// UpdatePayment is the resolver for the updatePayment field.
+func (r *mutationResolver) UpdatePayment(ctx context.Context, id uuid.UUID, input model.UpdatePayment) (*model.Payment, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	existing, err := r.paymentService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// fields left out keep their current values
+	counterpartyID := mapping.Or(mapping.Value(input.CounterpartyID), existing.CounterpartyID())
+	dto := &dtos.PaymentUpdateDTO{
+		Amount:           input.Amount,
+		CounterpartyID:   counterpartyID.String(),
+		TransactionDate:  shared.DateOnly(input.TransactionDate),
+		AccountingPeriod: shared.DateOnly(input.AccountingPeriod),
+		Comment:          mapping.Value(input.Comment),
+		UserID:           u.ID(),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	category := existing.Category()
+	if input.CategoryID != nil {
+		category, err = r.paymentCategoryService.GetByID(ctx, *input.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	entity, err := dto.Apply(existing, category, u)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := r.paymentService.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.PaymentToGraphModel(updated), nil
+}
+
+// DeletePayment is the resolver for the deletePayment field.
+func (r *mutationResolver) DeletePayment(ctx context.Context, id uuid.UUID) (*model.Payment, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	deleted, err := r.paymentService.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.PaymentToGraphModel(deleted), nil
+}
+
+// Account is the resolver for the account field.
+func (r *paymentResolver) Account(ctx context.Context, obj *model.Payment) (*model.MoneyAccount, error) {
+	account, err := r.loaders(ctx).MoneyAccount.Load(ctx, obj.AccountID)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	return mappers.MoneyAccountToGraphModel(account), nil
+}
+
+// Counterparty is the resolver for the counterparty field.
+func (r *paymentResolver) Counterparty(ctx context.Context, obj *model.Payment) (*model.Counterparty, error) {
+	entity, err := r.loaders(ctx).Counterparty.Load(ctx, obj.CounterpartyID)
+	if err != nil || entity == nil {
+		return nil, err
+	}
+	return mappers.CounterpartyToGraphModel(entity), nil
+}
+
+// Payment is the resolver for the payment field.
+func (r *queryResolver) Payment(ctx context.Context, id uuid.UUID) (*model.Payment, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	entity, err := r.paymentService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.PaymentToGraphModel(entity), nil
+}
+
+// Payments is the resolver for the payments field.
+func (r *queryResolver) Payments(ctx context.Context, offset int, limit int, filter *model.PaymentFilter) (*model.PaginatedPayments, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	params := &payment.FindParams{
+		Offset: offset,
+		Limit:  limit,
+	}
+	if filter != nil {
+		params.CreatedAt.From, params.CreatedAt.To = createdAtRange(filter.CreatedFrom, filter.CreatedTo)
+	}
+	entities, err := r.paymentService.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.paymentService.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PaginatedPayments{
+		Data:  PaymentsToGraphModel(entities),
+		Total: total,
+	}, nil
+}
+
+// PaymentCreated is the resolver for the paymentCreated field.
+func (r *subscriptionResolver) PaymentCreated(ctx context.Context) (<-chan *model.Payment, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, serrors.UnauthorizedGQLError(graphql.GetPath(ctx))
+	}
+	if err := composables.CanUser(ctx, permissions.PaymentRead); err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sdkgraphql.Subscribe(ctx, r.paymentCreated, func(p payment.Payment) (*model.Payment, bool) {
+		if p.TenantID() != tenantID {
+			return nil, false
+		}
+		return mappers.PaymentToGraphModel(p), true
+	}), nil
+}
+
+// Payment returns PaymentResolver implementation.
+func (r *Resolver) Payment() PaymentResolver { return &paymentResolver{r} }
+
+type paymentResolver struct{ *Resolver }
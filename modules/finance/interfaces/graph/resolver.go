@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+)
+
+// This file will not be regenerated automatically.
+//
+// It serves as dependency injection for your app, add any dependencies you require here.
+
+type Resolver struct {
+	app                    application.Application
+	paymentService         *services.PaymentService
+	paymentCategoryService *services.PaymentCategoryService
+	expenseService         *services.ExpenseService
+	expenseCategoryService *services.ExpenseCategoryService
+	moneyAccountService    *services.MoneyAccountService
+	counterpartyService    *services.CounterpartyService
+	paymentCreated         *sdkgraphql.Broker[payment.Payment]
+	expenseCreated         *sdkgraphql.Broker[expense.Expense]
+}
+
+func NewResolver(app application.Application) *Resolver {
+	r := &Resolver{
+		app:                    app,
+		paymentService:         app.Service(services.PaymentService{}).(*services.PaymentService),
+		paymentCategoryService: app.Service(services.PaymentCategoryService{}).(*services.PaymentCategoryService),
+		expenseService:         app.Service(services.ExpenseService{}).(*services.ExpenseService),
+		expenseCategoryService: app.Service(services.ExpenseCategoryService{}).(*services.ExpenseCategoryService),
+		moneyAccountService:    app.Service(services.MoneyAccountService{}).(*services.MoneyAccountService),
+		counterpartyService:    app.Service(services.CounterpartyService{}).(*services.CounterpartyService),
+		paymentCreated:         sdkgraphql.NewBroker[payment.Payment](),
+		expenseCreated:         sdkgraphql.NewBroker[expense.Expense](),
+	}
+	app.EventPublisher().Subscribe(func(e *payment.Created) {
+		r.paymentCreated.Publish(e.Result)
+	})
+	app.EventPublisher().Subscribe(func(e *expense.CreatedEvent) {
+		r.expenseCreated.Publish(e.Result)
+	})
+	return r
+}
@@ -3,8 +3,10 @@ package finance
 import (
 	"embed"
 
+	"github.com/99designs/gqlgen/graphql/executor"
 	icons "github.com/iota-uz/icons/phosphor"
 	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/interfaces/graph"
 	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
 	"github.com/iota-uz/iota-sdk/modules/finance/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/finance/services"
@@ -105,6 +107,17 @@ func (m *Module) Register(app application.Application) error {
 		),
 	)
 
+	resolver := graph.NewResolver(app)
+	app.RegisterGraphSchema(application.GraphSchema{
+		Value: graph.NewExecutableSchema(graph.Config{
+			Resolvers: resolver,
+		}),
+		BasePath: "/finance",
+		ExecutorCb: func(exec *executor.Executor) {
+			exec.AroundOperations(resolver.AroundOperations)
+		},
+	})
+
 	app.RBAC().Register(permissions.Permissions...)
 	app.RegisterLocaleFiles(&localeFiles)
 	app.Migrations().RegisterSchema(&migrationFiles)
@@ -7,6 +7,7 @@ import (
 
 const (
 	ResourceExpense         permission.Resource = "expense"
+	ResourceExpenseApproval permission.Resource = "expense_approval"
 	ResourcePayment         permission.Resource = "payment"
 	ResourceExpenseCategory permission.Resource = "expense_category"
 )
@@ -68,6 +69,34 @@ var (
 		Action:   permission.ActionDelete,
 		Modifier: permission.ModifierAll,
 	}
+	ExpenseReadOwn = &permission.Permission{
+		ID:       uuid.MustParse("b1d5c2f4-8a3e-4c6b-9f71-2e0a5d3c7b18"),
+		Name:     "Expense.ReadOwn",
+		Resource: ResourceExpense,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierOwn,
+	}
+	ExpenseUpdateOwn = &permission.Permission{
+		ID:       uuid.MustParse("c7e2a9d1-5b4f-4e83-a06c-9d1f3b7e2a45"),
+		Name:     "Expense.UpdateOwn",
+		Resource: ResourceExpense,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierOwn,
+	}
+	ExpenseDeleteOwn = &permission.Permission{
+		ID:       uuid.MustParse("d3f8b6e2-1c7a-4b59-8e24-6a0c9f5d1b73"),
+		Name:     "Expense.DeleteOwn",
+		Resource: ResourceExpense,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierOwn,
+	}
+	ExpenseApprove = &permission.Permission{
+		ID:       uuid.MustParse("8c4a1c69-88bc-4544-a615-8a35632b56ba"),
+		Name:     "Expense.Approve",
+		Resource: ResourceExpenseApproval,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
 	ExpenseCategoryCreate = &permission.Permission{
 		ID:       uuid.MustParse("c75c9bc8-f13f-4612-980b-68288c3a87be"),
 		Name:     "ExpenseCategory.Create",
@@ -107,6 +136,10 @@ var Permissions = []*permission.Permission{
 	ExpenseRead,
 	ExpenseUpdate,
 	ExpenseDelete,
+	ExpenseReadOwn,
+	ExpenseUpdateOwn,
+	ExpenseDeleteOwn,
+	ExpenseApprove,
 	ExpenseCategoryCreate,
 	ExpenseCategoryRead,
 	ExpenseCategoryUpdate,
@@ -62,6 +62,7 @@ func (c *ExpenseController) Register(r *mux.Router) {
 		middleware.Authorize(),
 		middleware.RedirectNotAuthenticated(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.ProvideDynamicLogo(c.app),
 		middleware.Tabs(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
@@ -74,6 +75,7 @@ func (c *ExpenseController) Register(r *mux.Router) {
 	router.HandleFunc("/new", di.H(c.GetNew)).Methods(http.MethodGet)
 	router.HandleFunc("", di.H(c.Create)).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9a-fA-F-]+}", di.H(c.Update)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9a-fA-F-]+}/approve", di.H(c.Approve)).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9a-fA-F-]+}", di.H(c.Delete)).Methods(http.MethodDelete)
 }
 
@@ -155,7 +157,7 @@ func (c *ExpenseController) Export(
 	w http.ResponseWriter,
 	excelService *coreservices.ExcelExportService,
 ) {
-	if err := composables.CanUser(r.Context(), permissions.ExpenseRead); err != nil {
+	if err := composables.CanUserScoped(r.Context(), permissions.ExpenseRead); err != nil {
 		http.Error(w, "forbidden", http.StatusForbidden)
 		return
 	}
@@ -187,40 +189,41 @@ func (c *ExpenseController) Export(
 		FROM expenses ex 
 		LEFT JOIN transactions tr ON tr.id = ex.transaction_id
 		LEFT JOIN expense_categories ec ON ec.id = ex.category_id
-		WHERE ex.tenant_id = $1
-		ORDER BY ex.created_at DESC`
+		WHERE ex.tenant_id = $1`
 
 	args := []interface{}{tenantID}
+	if u, ok := composables.UseOwnScope(ctx, permissions.ExpenseRead); ok {
+		query += " AND ex.created_by_id = $2"
+		args = append(args, u.ID())
+	}
+	query += " ORDER BY ex.created_at DESC"
 
-	// For now, only handle Excel export (as per the example)
-	// TODO: Extend the exporter service to support other formats
-	switch format {
-	case export.ExportFormatExcel:
-		queryObj := exportconfig.NewQuery(query, args...)
-		config := exportconfig.New(exportconfig.WithFilename("expenses_export"))
-		upload, err := excelService.ExportFromQuery(
-			ctx,
-			queryObj,
-			config,
-		)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	fileFormat, ok := export.GetFileFormat(format)
+	if !ok {
+		http.Error(w, "Export format not yet implemented", http.StatusNotImplemented)
+		return
+	}
 
-		if htmx.IsHxRequest(r) {
-			htmx.Redirect(w, upload.URL().String())
-		} else {
-			http.Redirect(w, r, upload.URL().String(), http.StatusSeeOther)
-		}
-	case export.ExportFormatCSV:
-		http.Error(w, "CSV export not yet implemented", http.StatusNotImplemented)
-	case export.ExportFormatJSON:
-		http.Error(w, "JSON export not yet implemented", http.StatusNotImplemented)
-	case export.ExportFormatTXT:
-		http.Error(w, "TXT export not yet implemented", http.StatusNotImplemented)
-	default:
-		http.Error(w, "Invalid export format", http.StatusBadRequest)
+	queryObj := exportconfig.NewQuery(query, args...)
+	config := exportconfig.New(
+		exportconfig.WithFilename("expenses_export"),
+		exportconfig.WithFormat(fileFormat),
+		exportconfig.WithCSVOptions(export.GetCSVOptions(r)),
+	)
+	upload, err := excelService.ExportFromQuery(
+		ctx,
+		queryObj,
+		config,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if htmx.IsHxRequest(r) {
+		htmx.Redirect(w, upload.URL().String())
+	} else {
+		http.Redirect(w, r, upload.URL().String(), http.StatusSeeOther)
 	}
 }
 
@@ -290,6 +293,27 @@ func (c *ExpenseController) Delete(
 	shared.Redirect(w, r, c.basePath)
 }
 
+func (c *ExpenseController) Approve(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	expenseService *services.ExpenseService,
+) {
+	id, err := shared.ParseUUID(r)
+	if err != nil {
+		logger.Errorf("Error parsing expense ID: %v", err)
+		http.Error(w, "Error parsing id", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := expenseService.Approve(r.Context(), id); err != nil {
+		logger.Errorf("Error approving expense: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
 func (c *ExpenseController) Update(
 	r *http.Request,
 	w http.ResponseWriter,
@@ -636,6 +636,101 @@ func TestExpenseController_Delete_NotFound(t *testing.T) {
 		Status(500)
 }
 
+func TestExpenseController_Approve_Success(t *testing.T) {
+	t.Parallel()
+	adminUser := itf.User(
+		permissions.ExpenseApprove,
+		permissions.ExpenseRead,
+		permissions.ExpenseCreate,
+	)
+
+	suite := itf.HTTP(t, core.NewModule(), finance.NewModule()).
+		AsUser(adminUser)
+
+	env := suite.Environment()
+	createCurrencies(t, env.Ctx, &currency.USD)
+
+	controller := controllers.NewExpensesController(env.App)
+	suite.Register(controller)
+
+	expenseService := env.App.Service(services.ExpenseService{}).(*services.ExpenseService)
+	expense1 := createTestExpense(t, env, "Expense to Approve")
+
+	suite.POST(fmt.Sprintf("%s/%s/approve", ExpenseBasePath, expense1.ID().String())).
+		Expect(t).
+		Status(302).
+		RedirectTo(ExpenseBasePath)
+
+	approvedExpense, err := expenseService.GetByID(env.Ctx, expense1.ID())
+	require.NoError(t, err)
+	require.True(t, approvedExpense.Approved())
+	require.Equal(t, adminUser.ID(), approvedExpense.ApprovedBy())
+
+	suite.POST(fmt.Sprintf("%s/%s/approve", ExpenseBasePath, expense1.ID().String())).
+		Expect(t).
+		Status(500).
+		Contains(expenseAggregate.ErrAlreadyApproved.Error())
+}
+
+func TestExpenseController_Approve_Forbidden(t *testing.T) {
+	t.Parallel()
+	user := itf.User(
+		permissions.ExpenseUpdate,
+		permissions.ExpenseRead,
+		permissions.ExpenseCreate,
+	)
+
+	suite := itf.HTTP(t, core.NewModule(), finance.NewModule()).
+		AsUser(user)
+
+	env := suite.Environment()
+	createCurrencies(t, env.Ctx, &currency.USD)
+
+	controller := controllers.NewExpensesController(env.App)
+	suite.Register(controller)
+
+	expenseService := env.App.Service(services.ExpenseService{}).(*services.ExpenseService)
+	expense1 := createTestExpense(t, env, "Expense not to Approve")
+
+	suite.POST(fmt.Sprintf("%s/%s/approve", ExpenseBasePath, expense1.ID().String())).
+		Expect(t).
+		Status(500)
+
+	existingExpense, err := expenseService.GetByID(env.Ctx, expense1.ID())
+	require.NoError(t, err)
+	require.False(t, existingExpense.Approved())
+}
+
+func createTestExpense(t *testing.T, env *itf.TestEnvironment, comment string) expenseAggregate.Expense {
+	t.Helper()
+	expenseService := env.App.Service(services.ExpenseService{}).(*services.ExpenseService)
+	moneyAccountService := env.App.Service(services.MoneyAccountService{}).(*services.MoneyAccountService)
+
+	createdAccount, err := moneyAccountService.Create(env.Ctx, moneyAccountEntity.New(
+		"Approval Test Account",
+		money.NewFromFloat(1000.00, "USD"),
+		moneyAccountEntity.WithTenantID(env.Tenant.ID),
+	))
+	require.NoError(t, err)
+
+	createdCategory, err := persistence.NewExpenseCategoryRepository().Create(env.Ctx, expenseCategoryEntity.New(
+		"Approval Test Category",
+		expenseCategoryEntity.WithTenantID(env.Tenant.ID),
+	))
+	require.NoError(t, err)
+
+	created, err := expenseService.Create(env.Ctx, expenseAggregate.New(
+		money.NewFromFloat(100.00, "USD"),
+		createdAccount,
+		createdCategory,
+		time.Now(),
+		expenseAggregate.WithTenantID(env.Tenant.ID),
+		expenseAggregate.WithComment(comment),
+	))
+	require.NoError(t, err)
+	return created
+}
+
 func TestExpenseController_InvalidUUID(t *testing.T) {
 	t.Parallel()
 	adminUser := itf.User(
@@ -730,6 +825,33 @@ func TestExpenseController_Export_Excel_Success(t *testing.T) {
 	require.Contains(t, redirectLocation, ".xlsx") // Check for Excel file extension
 }
 
+func TestExpenseController_Export_OtherFormats(t *testing.T) {
+	t.Parallel()
+	adminUser := itf.User(
+		permissions.ExpenseRead,
+	)
+
+	suite := itf.HTTP(t, core.NewModule(), finance.NewModule()).
+		AsUser(adminUser)
+
+	env := suite.Environment()
+	createCurrencies(t, env.Ctx, &currency.USD)
+
+	controller := controllers.NewExpensesController(env.App)
+	suite.Register(controller)
+
+	for _, format := range []string{"csv", "ods", "pdf"} {
+		response := suite.POST(ExpenseBasePath + "/export?format=" + format).
+			Expect(t)
+
+		rawResponse := response.Raw()
+		statusCode := rawResponse.StatusCode
+		_ = rawResponse.Body.Close()
+		require.True(t, statusCode == 302 || statusCode == 303, "Expected 302 or 303 for %s, got %d", format, statusCode)
+		require.Contains(t, response.Header("Location"), "."+format)
+	}
+}
+
 func TestExpenseController_Export_InvalidFormat(t *testing.T) {
 	t.Parallel()
 	adminUser := itf.User(
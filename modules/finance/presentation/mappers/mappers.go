@@ -96,6 +96,7 @@ func ExpenseToViewModel(entity expense.Expense) *viewmodels.Expense {
 		Date:               entity.Date().Format(time.RFC3339),
 		CreatedAt:          entity.CreatedAt().Format(time.RFC3339),
 		UpdatedAt:          entity.UpdatedAt().Format(time.RFC3339),
+		Approved:           entity.Approved(),
 	}
 }
 
@@ -437,7 +437,8 @@ func ExpensesContent(props *IndexPageProps) templ.Component {
 			Formats: []export.ExportFormat{
 				export.ExportFormatExcel,
 				export.ExportFormatCSV,
-				export.ExportFormatJSON,
+				export.ExportFormatODS,
+				export.ExportFormatPDF,
 			},
 			ExportURL: "/finance/expenses/export",
 			Label:     pageCtx.T("Expenses.List.Export"),
@@ -466,7 +467,7 @@ func ExpensesContent(props *IndexPageProps) templ.Component {
 			var templ_7745c5c3_Var19 string
 			templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(pageCtx.T("Expenses.List.New"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `modules/finance/presentation/templates/pages/expenses/expenses.templ`, Line: 144, Col: 38}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `modules/finance/presentation/templates/pages/expenses/expenses.templ`, Line: 145, Col: 38}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
 			if templ_7745c5c3_Err != nil {
@@ -13,4 +13,5 @@ type Expense struct {
 	Date               string
 	CreatedAt          string
 	UpdatedAt          string
+	Approved           bool
 }
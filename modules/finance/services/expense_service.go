@@ -29,14 +29,21 @@ func NewExpenseService(
 }
 
 func (s *ExpenseService) GetByID(ctx context.Context, id uuid.UUID) (expense.Expense, error) {
-	if err := composables.CanUser(ctx, permissions.ExpenseRead); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.ExpenseRead); err != nil {
 		return nil, err
 	}
-	return s.repo.GetByID(ctx, id)
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := composables.CanUserAccess(ctx, permissions.ExpenseRead, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
 }
 
 func (s *ExpenseService) GetAll(ctx context.Context) ([]expense.Expense, error) {
-	if err := composables.CanUser(ctx, permissions.ExpenseRead); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.ExpenseRead); err != nil {
 		return nil, err
 	}
 	return s.repo.GetAll(ctx)
@@ -45,7 +52,7 @@ func (s *ExpenseService) GetAll(ctx context.Context) ([]expense.Expense, error)
 func (s *ExpenseService) GetPaginated(
 	ctx context.Context, params *expense.FindParams,
 ) ([]expense.Expense, error) {
-	if err := composables.CanUser(ctx, permissions.ExpenseRead); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.ExpenseRead); err != nil {
 		return nil, err
 	}
 	return s.repo.GetPaginated(ctx, params)
@@ -55,6 +62,9 @@ func (s *ExpenseService) Create(ctx context.Context, entity expense.Expense) (ex
 	if err := composables.CanUser(ctx, permissions.ExpenseCreate); err != nil {
 		return nil, err
 	}
+	if u, err := composables.UseUser(ctx); err == nil && entity.CreatedBy() == 0 {
+		entity = entity.SetCreatedBy(u.ID())
+	}
 
 	createdEvent, err := expense.NewCreatedEvent(ctx, entity)
 	if err != nil {
@@ -76,12 +86,20 @@ func (s *ExpenseService) Create(ctx context.Context, entity expense.Expense) (ex
 		return nil, err
 	}
 
+	createdEvent.Result = created
 	s.publisher.Publish(createdEvent)
 	return created, nil
 }
 
 func (s *ExpenseService) Update(ctx context.Context, entity expense.Expense) (expense.Expense, error) {
-	if err := composables.CanUser(ctx, permissions.ExpenseUpdate); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.ExpenseUpdate); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetByID(ctx, entity.ID())
+	if err != nil {
+		return nil, err
+	}
+	if err := composables.Authorize(ctx, permissions.ExpenseUpdate, entity.SetCreatedBy(existing.CreatedBy())); err != nil {
 		return nil, err
 	}
 
@@ -109,8 +127,48 @@ func (s *ExpenseService) Update(ctx context.Context, entity expense.Expense) (ex
 	return updated, nil
 }
 
+// Approve records the approval of an expense by the current user. Besides the permission,
+// the tenant's policies decide which expenses the user may approve, e.g. up to an amount
+func (s *ExpenseService) Approve(ctx context.Context, id uuid.UUID) (expense.Expense, error) {
+	if err := composables.CanUser(ctx, permissions.ExpenseApprove); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := composables.Authorize(ctx, permissions.ExpenseApprove, entity); err != nil {
+		return nil, err
+	}
+	approved, err := entity.Approve(u.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	updatedEvent, err := expense.NewUpdatedEvent(ctx, approved)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated expense.Expense
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		updated, err = s.repo.Update(txCtx, approved)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publisher.Publish(updatedEvent)
+	return updated, nil
+}
+
 func (s *ExpenseService) Delete(ctx context.Context, id uuid.UUID) (expense.Expense, error) {
-	if err := composables.CanUser(ctx, permissions.ExpenseDelete); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.ExpenseDelete); err != nil {
 		return nil, err
 	}
 
@@ -118,6 +176,9 @@ func (s *ExpenseService) Delete(ctx context.Context, id uuid.UUID) (expense.Expe
 	if err != nil {
 		return nil, err
 	}
+	if err := composables.Authorize(ctx, permissions.ExpenseDelete, entity); err != nil {
+		return nil, err
+	}
 
 	deletedEvent, err := expense.NewDeletedEvent(ctx, entity)
 	if err != nil {
@@ -0,0 +1,138 @@
+package department
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCycle       = errors.New("department can not be placed under itself or its subdepartments")
+	ErrHasChildren = errors.New("department has subdepartments")
+)
+
+type Option func(d *department)
+
+func WithID(id uint) Option {
+	return func(d *department) {
+		d.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(d *department) {
+		d.tenantID = tenantID
+	}
+}
+
+func WithDescription(description string) Option {
+	return func(d *department) {
+		d.description = description
+	}
+}
+
+func WithParentID(parentID uint) Option {
+	return func(d *department) {
+		d.parentID = parentID
+	}
+}
+
+func WithManagerID(managerID uint) Option {
+	return func(d *department) {
+		d.managerID = managerID
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(d *department) {
+		d.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(d *department) {
+		d.updatedAt = updatedAt
+	}
+}
+
+// Department is a unit of the organization. Departments form a tree through their parent.
+type Department interface {
+	ID() uint
+	TenantID() uuid.UUID
+	Name() string
+	Description() string
+	// ParentID is zero for top-level departments
+	ParentID() uint
+	// ManagerID is the employee heading the department, zero when there is none
+	ManagerID() uint
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	Update(name, description string, parentID, managerID uint) Department
+}
+
+func New(name string, opts ...Option) Department {
+	d := &department{
+		name:      name,
+		createdAt: time.Now(),
+		updatedAt: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+type department struct {
+	id          uint
+	tenantID    uuid.UUID
+	name        string
+	description string
+	parentID    uint
+	managerID   uint
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func (d *department) ID() uint {
+	return d.id
+}
+
+func (d *department) TenantID() uuid.UUID {
+	return d.tenantID
+}
+
+func (d *department) Name() string {
+	return d.name
+}
+
+func (d *department) Description() string {
+	return d.description
+}
+
+func (d *department) ParentID() uint {
+	return d.parentID
+}
+
+func (d *department) ManagerID() uint {
+	return d.managerID
+}
+
+func (d *department) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *department) UpdatedAt() time.Time {
+	return d.updatedAt
+}
+
+func (d *department) Update(name, description string, parentID, managerID uint) Department {
+	result := *d
+	result.name = name
+	result.description = description
+	result.parentID = parentID
+	result.managerID = managerID
+	result.updatedAt = time.Now()
+	return &result
+}
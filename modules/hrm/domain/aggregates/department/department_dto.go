@@ -0,0 +1,55 @@
+package department
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// SaveDTO is used both to create and to update a department
+type SaveDTO struct {
+	Name        string `validate:"required"`
+	Description string
+	ParentID    uint
+	ManagerID   uint
+}
+
+func (d *SaveDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+
+	validationErrors := make(serrors.ValidationErrors)
+	getFieldLocaleKey := func(field string) string {
+		return fmt.Sprintf("Departments.Fields.%s.Label", field)
+	}
+
+	errs := constants.Validate.Struct(d)
+	if errs != nil {
+		for field, err := range serrors.ProcessValidatorErrors(errs.(validator.ValidationErrors), getFieldLocaleKey) {
+			validationErrors[field] = err
+		}
+	}
+
+	errorMessages := serrors.LocalizeValidationErrors(validationErrors, l)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *SaveDTO) ToEntity() Department {
+	return New(
+		d.Name,
+		WithDescription(d.Description),
+		WithParentID(d.ParentID),
+		WithManagerID(d.ManagerID),
+	)
+}
+
+func (d *SaveDTO) Apply(entity Department) Department {
+	return entity.Update(d.Name, d.Description, d.ParentID, d.ManagerID)
+}
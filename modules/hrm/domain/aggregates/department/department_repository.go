@@ -0,0 +1,11 @@
+package department
+
+import "context"
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]Department, error)
+	GetByID(ctx context.Context, id uint) (Department, error)
+	Create(ctx context.Context, data Department) (Department, error)
+	Update(ctx context.Context, data Department) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,73 @@
+package department
+
+import "sort"
+
+// Node is a department with its subdepartments
+type Node struct {
+	Department Department
+	Children   []*Node
+}
+
+// Tree arranges departments by their parents. Departments whose parent is missing become roots.
+// Siblings are sorted by name.
+func Tree(departments []Department) []*Node {
+	nodes := make(map[uint]*Node, len(departments))
+	for _, d := range departments {
+		nodes[d.ID()] = &Node{Department: d}
+	}
+	var roots []*Node
+	for _, d := range departments {
+		node := nodes[d.ID()]
+		if parent, ok := nodes[d.ParentID()]; ok && d.ParentID() != d.ID() {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	sortNodes(roots)
+	return roots
+}
+
+func sortNodes(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Department.Name() < nodes[j].Department.Name()
+	})
+	for _, n := range nodes {
+		sortNodes(n.Children)
+	}
+}
+
+// Ancestors returns the chain of parents of a department starting with the direct parent
+func Ancestors(departments []Department, id uint) []Department {
+	byID := make(map[uint]Department, len(departments))
+	for _, d := range departments {
+		byID[d.ID()] = d
+	}
+	var chain []Department
+	seen := map[uint]bool{id: true}
+	current, ok := byID[id]
+	for ok && current.ParentID() != 0 && !seen[current.ParentID()] {
+		seen[current.ParentID()] = true
+		current, ok = byID[current.ParentID()]
+		if ok {
+			chain = append(chain, current)
+		}
+	}
+	return chain
+}
+
+// CheckParent returns ErrCycle when moving department id under parentID would create a loop
+func CheckParent(departments []Department, id, parentID uint) error {
+	if parentID == 0 {
+		return nil
+	}
+	if parentID == id {
+		return ErrCycle
+	}
+	for _, a := range Ancestors(departments, parentID) {
+		if a.ID() == id {
+			return ErrCycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package department_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/department"
+)
+
+func departments() []department.Department {
+	return []department.Department{
+		department.New("Company", department.WithID(1)),
+		department.New("Sales", department.WithID(2), department.WithParentID(1)),
+		department.New("Engineering", department.WithID(3), department.WithParentID(1)),
+		department.New("Backend", department.WithID(4), department.WithParentID(3)),
+		department.New("Orphan", department.WithID(5), department.WithParentID(42)),
+	}
+}
+
+func TestTree(t *testing.T) {
+	roots := department.Tree(departments())
+	require.Len(t, roots, 2)
+	assert.Equal(t, "Company", roots[0].Department.Name())
+	assert.Equal(t, "Orphan", roots[1].Department.Name())
+
+	children := roots[0].Children
+	require.Len(t, children, 2)
+	assert.Equal(t, "Engineering", children[0].Department.Name())
+	assert.Equal(t, "Sales", children[1].Department.Name())
+	require.Len(t, children[0].Children, 1)
+	assert.Equal(t, "Backend", children[0].Children[0].Department.Name())
+}
+
+func TestAncestors(t *testing.T) {
+	chain := department.Ancestors(departments(), 4)
+	require.Len(t, chain, 2)
+	assert.Equal(t, uint(3), chain[0].ID())
+	assert.Equal(t, uint(1), chain[1].ID())
+	assert.Empty(t, department.Ancestors(departments(), 1))
+}
+
+func TestCheckParent(t *testing.T) {
+	all := departments()
+	require.NoError(t, department.CheckParent(all, 2, 3))
+	require.NoError(t, department.CheckParent(all, 1, 0))
+	require.ErrorIs(t, department.CheckParent(all, 3, 3), department.ErrCycle)
+	require.ErrorIs(t, department.CheckParent(all, 1, 4), department.ErrCycle)
+}
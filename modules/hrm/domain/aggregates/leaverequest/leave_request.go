@@ -0,0 +1,312 @@
+package leaverequest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotPending     = errors.New("leave request is not pending")
+	ErrInvalidPeriod  = errors.New("leave end date is before its start date")
+	ErrNoWorkingDays  = errors.New("leave period has no working days")
+	ErrOverlap        = errors.New("leave overlaps with another request")
+	ErrBalanceTooLow  = errors.New("not enough leave balance")
+	ErrCannotCancel   = errors.New("leave request can not be canceled")
+	ErrSelfApproval   = errors.New("leave request can not be reviewed by its requester")
+	ErrAlreadyStarted = errors.New("approved leave has already started")
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+	StatusCanceled Status = "canceled"
+)
+
+type Option func(r *leaveRequest)
+
+func WithID(id uint) Option {
+	return func(r *leaveRequest) {
+		r.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(r *leaveRequest) {
+		r.tenantID = tenantID
+	}
+}
+
+func WithReason(reason string) Option {
+	return func(r *leaveRequest) {
+		r.reason = reason
+	}
+}
+
+func WithStatus(status Status) Option {
+	return func(r *leaveRequest) {
+		r.status = status
+	}
+}
+
+func WithDays(days float64) Option {
+	return func(r *leaveRequest) {
+		r.days = days
+	}
+}
+
+func WithRequestedBy(userID uint) Option {
+	return func(r *leaveRequest) {
+		r.requestedBy = userID
+	}
+}
+
+func WithReview(reviewerID uint, comment string, reviewedAt time.Time) Option {
+	return func(r *leaveRequest) {
+		r.reviewedBy = reviewerID
+		r.reviewComment = comment
+		r.reviewedAt = &reviewedAt
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(r *leaveRequest) {
+		r.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(r *leaveRequest) {
+		r.updatedAt = updatedAt
+	}
+}
+
+// LeaveRequest is an employee's request for leave that goes through manager approval
+type LeaveRequest interface {
+	ID() uint
+	TenantID() uuid.UUID
+	EmployeeID() uint
+	LeaveTypeID() uint
+	StartDate() time.Time
+	EndDate() time.Time
+	// Days is the number of working days the leave takes
+	Days() float64
+	// DaysIn is the part of Days that falls in the given year, so leave spanning
+	// the new year is charged to the allowance of each year separately
+	DaysIn(year int) float64
+	Reason() string
+	Status() Status
+	RequestedBy() uint
+	ReviewedBy() uint
+	ReviewedAt() *time.Time
+	ReviewComment() string
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// Covers reports whether the leave includes day
+	Covers(day time.Time) bool
+	// Blocking reports whether the request reserves its days, i.e. it is pending or approved
+	Blocking() bool
+
+	Approve(reviewerID uint, comment string) (LeaveRequest, error)
+	Reject(reviewerID uint, comment string) (LeaveRequest, error)
+	Cancel(now time.Time) (LeaveRequest, error)
+}
+
+// New creates a pending leave request for the working days between start and end inclusive
+func New(employeeID, leaveTypeID uint, start, end time.Time, opts ...Option) (LeaveRequest, error) {
+	start, end = truncateDay(start), truncateDay(end)
+	if end.Before(start) {
+		return nil, ErrInvalidPeriod
+	}
+	r := &leaveRequest{
+		employeeID:  employeeID,
+		leaveTypeID: leaveTypeID,
+		startDate:   start,
+		endDate:     end,
+		days:        float64(WorkingDays(start, end)),
+		status:      StatusPending,
+		createdAt:   time.Now(),
+		updatedAt:   time.Now(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.days <= 0 {
+		return nil, ErrNoWorkingDays
+	}
+	return r, nil
+}
+
+// WorkingDays counts the days from start to end inclusive that fall on Monday to Friday
+func WorkingDays(start, end time.Time) int {
+	days := 0
+	for d := truncateDay(start); !d.After(truncateDay(end)); d = d.AddDate(0, 0, 1) {
+		if IsWorkingDay(d) {
+			days++
+		}
+	}
+	return days
+}
+
+func IsWorkingDay(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+type leaveRequest struct {
+	id            uint
+	tenantID      uuid.UUID
+	employeeID    uint
+	leaveTypeID   uint
+	startDate     time.Time
+	endDate       time.Time
+	days          float64
+	reason        string
+	status        Status
+	requestedBy   uint
+	reviewedBy    uint
+	reviewedAt    *time.Time
+	reviewComment string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+func (r *leaveRequest) ID() uint {
+	return r.id
+}
+
+func (r *leaveRequest) TenantID() uuid.UUID {
+	return r.tenantID
+}
+
+func (r *leaveRequest) EmployeeID() uint {
+	return r.employeeID
+}
+
+func (r *leaveRequest) LeaveTypeID() uint {
+	return r.leaveTypeID
+}
+
+func (r *leaveRequest) StartDate() time.Time {
+	return r.startDate
+}
+
+func (r *leaveRequest) EndDate() time.Time {
+	return r.endDate
+}
+
+func (r *leaveRequest) Days() float64 {
+	return r.days
+}
+
+func (r *leaveRequest) DaysIn(year int) float64 {
+	if r.startDate.Year() == r.endDate.Year() {
+		if r.startDate.Year() == year {
+			return r.days
+		}
+		return 0
+	}
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if r.startDate.After(from) {
+		from = r.startDate
+	}
+	if r.endDate.Before(to) {
+		to = r.endDate
+	}
+	if to.Before(from) {
+		return 0
+	}
+	return float64(WorkingDays(from, to))
+}
+
+func (r *leaveRequest) Reason() string {
+	return r.reason
+}
+
+func (r *leaveRequest) Status() Status {
+	return r.status
+}
+
+func (r *leaveRequest) RequestedBy() uint {
+	return r.requestedBy
+}
+
+func (r *leaveRequest) ReviewedBy() uint {
+	return r.reviewedBy
+}
+
+func (r *leaveRequest) ReviewedAt() *time.Time {
+	return r.reviewedAt
+}
+
+func (r *leaveRequest) ReviewComment() string {
+	return r.reviewComment
+}
+
+func (r *leaveRequest) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *leaveRequest) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+func (r *leaveRequest) Covers(day time.Time) bool {
+	day = truncateDay(day)
+	return !day.Before(r.startDate) && !day.After(r.endDate)
+}
+
+func (r *leaveRequest) Blocking() bool {
+	return r.status == StatusPending || r.status == StatusApproved
+}
+
+func (r *leaveRequest) review(status Status, reviewerID uint, comment string) (LeaveRequest, error) {
+	if r.status != StatusPending {
+		return nil, ErrNotPending
+	}
+	if reviewerID != 0 && reviewerID == r.requestedBy {
+		return nil, ErrSelfApproval
+	}
+	now := time.Now()
+	result := *r
+	result.status = status
+	result.reviewedBy = reviewerID
+	result.reviewComment = comment
+	result.reviewedAt = &now
+	result.updatedAt = now
+	return &result, nil
+}
+
+func (r *leaveRequest) Approve(reviewerID uint, comment string) (LeaveRequest, error) {
+	return r.review(StatusApproved, reviewerID, comment)
+}
+
+func (r *leaveRequest) Reject(reviewerID uint, comment string) (LeaveRequest, error) {
+	return r.review(StatusRejected, reviewerID, comment)
+}
+
+// Cancel withdraws a pending request or an approved leave that has not started yet
+func (r *leaveRequest) Cancel(now time.Time) (LeaveRequest, error) {
+	switch r.status {
+	case StatusPending:
+	case StatusApproved:
+		if !truncateDay(now).Before(r.startDate) {
+			return nil, ErrAlreadyStarted
+		}
+	case StatusRejected, StatusCanceled:
+		return nil, ErrCannotCancel
+	}
+	result := *r
+	result.status = StatusCanceled
+	result.updatedAt = time.Now()
+	return &result, nil
+}
@@ -0,0 +1,77 @@
+package leaverequest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type CreateDTO struct {
+	EmployeeID  uint `validate:"required"`
+	LeaveTypeID uint `validate:"required"`
+	StartDate   shared.DateOnly
+	EndDate     shared.DateOnly
+	Reason      string
+}
+
+func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+
+	validationErrors := make(serrors.ValidationErrors)
+	getFieldLocaleKey := func(field string) string {
+		return fmt.Sprintf("Leaves.Fields.%s.Label", field)
+	}
+
+	errs := constants.Validate.Struct(d)
+	if errs != nil {
+		for field, err := range serrors.ProcessValidatorErrors(errs.(validator.ValidationErrors), getFieldLocaleKey) {
+			validationErrors[field] = err
+		}
+	}
+
+	start, end := time.Time(d.StartDate), time.Time(d.EndDate)
+	if start.IsZero() {
+		validationErrors["StartDate"] = serrors.NewFieldRequiredError("StartDate", getFieldLocaleKey("StartDate"))
+	}
+	if end.IsZero() {
+		validationErrors["EndDate"] = serrors.NewFieldRequiredError("EndDate", getFieldLocaleKey("EndDate"))
+	}
+	if !start.IsZero() && !end.IsZero() {
+		if end.Before(start) {
+			validationErrors["EndDate"] = serrors.NewValidationError(
+				"EndDate",
+				"VALIDATION_LEAVE_PERIOD",
+				ErrInvalidPeriod.Error(),
+				"ValidationErrors.leavePeriod",
+			).WithFieldName(getFieldLocaleKey("EndDate"))
+		} else if WorkingDays(start, end) == 0 {
+			validationErrors["EndDate"] = serrors.NewValidationError(
+				"EndDate",
+				"VALIDATION_LEAVE_WORKING_DAYS",
+				ErrNoWorkingDays.Error(),
+				"ValidationErrors.leaveWorkingDays",
+			).WithFieldName(getFieldLocaleKey("EndDate"))
+		}
+	}
+
+	errorMessages := serrors.LocalizeValidationErrors(validationErrors, l)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) ToEntity(requestedBy uint) (LeaveRequest, error) {
+	opts := []Option{WithRequestedBy(requestedBy)}
+	if d.Reason != "" {
+		opts = append(opts, WithReason(d.Reason))
+	}
+	return New(d.EmployeeID, d.LeaveTypeID, time.Time(d.StartDate), time.Time(d.EndDate), opts...)
+}
@@ -0,0 +1,56 @@
+package leaverequest
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func NewCreatedEvent(ctx context.Context, result LeaveRequest) (*CreatedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+func NewStatusChangedEvent(ctx context.Context, previous Status, result LeaveRequest) (*StatusChangedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &StatusChangedEvent{
+		Sender:   sender,
+		Session:  *sess,
+		Previous: previous,
+		Result:   result,
+	}, nil
+}
+
+type CreatedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  LeaveRequest
+}
+
+// StatusChangedEvent is published when a request is approved, rejected or canceled
+type StatusChangedEvent struct {
+	Sender   user.User
+	Session  session.Session
+	Previous Status
+	Result   LeaveRequest
+}
@@ -0,0 +1,25 @@
+package leaverequest
+
+import (
+	"context"
+	"time"
+)
+
+type FindParams struct {
+	EmployeeID  uint
+	LeaveTypeID uint
+	Statuses    []Status
+	// From and To select requests overlapping the period
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]LeaveRequest, error)
+	GetByID(ctx context.Context, id uint) (LeaveRequest, error)
+	Create(ctx context.Context, data LeaveRequest) (LeaveRequest, error)
+	Update(ctx context.Context, data LeaveRequest) error
+}
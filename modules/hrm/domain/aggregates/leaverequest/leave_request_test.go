@@ -0,0 +1,102 @@
+package leaverequest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestWorkingDays(t *testing.T) {
+	// 2025-03-03 is a Monday
+	assert.Equal(t, 5, leaverequest.WorkingDays(date("2025-03-03"), date("2025-03-09")))
+	assert.Equal(t, 6, leaverequest.WorkingDays(date("2025-03-07"), date("2025-03-14")))
+	assert.Equal(t, 0, leaverequest.WorkingDays(date("2025-03-08"), date("2025-03-09")))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("counts working days of the period", func(t *testing.T) {
+		r, err := leaverequest.New(1, 2, date("2025-03-03"), date("2025-03-11"))
+		require.NoError(t, err)
+		assert.InDelta(t, 7.0, r.Days(), 0.001)
+		assert.Equal(t, leaverequest.StatusPending, r.Status())
+		assert.True(t, r.Covers(date("2025-03-08")))
+		assert.False(t, r.Covers(date("2025-03-12")))
+	})
+
+	t.Run("rejects invalid periods", func(t *testing.T) {
+		_, err := leaverequest.New(1, 2, date("2025-03-05"), date("2025-03-04"))
+		require.ErrorIs(t, err, leaverequest.ErrInvalidPeriod)
+
+		_, err = leaverequest.New(1, 2, date("2025-03-08"), date("2025-03-09"))
+		require.ErrorIs(t, err, leaverequest.ErrNoWorkingDays)
+	})
+}
+
+func TestDaysIn(t *testing.T) {
+	// 2025-12-29 is a Monday
+	r, err := leaverequest.New(1, 2, date("2025-12-29"), date("2026-01-09"))
+	require.NoError(t, err)
+	assert.InDelta(t, 10.0, r.Days(), 0.001)
+	assert.InDelta(t, 3.0, r.DaysIn(2025), 0.001, "charged to the old year up to December 31")
+	assert.InDelta(t, 7.0, r.DaysIn(2026), 0.001)
+	assert.InDelta(t, 0.0, r.DaysIn(2027), 0.001)
+
+	half, err := leaverequest.New(1, 2, date("2025-03-03"), date("2025-03-03"), leaverequest.WithDays(0.5))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, half.DaysIn(2025), 0.001, "requests within a year keep their days")
+	assert.InDelta(t, 0.0, half.DaysIn(2024), 0.001)
+}
+
+func TestReview(t *testing.T) {
+	r, err := leaverequest.New(1, 2, date("2025-03-03"), date("2025-03-04"), leaverequest.WithRequestedBy(10))
+	require.NoError(t, err)
+
+	t.Run("requester can not review their own request", func(t *testing.T) {
+		_, err := r.Approve(10, "")
+		require.ErrorIs(t, err, leaverequest.ErrSelfApproval)
+	})
+
+	t.Run("approves a pending request once", func(t *testing.T) {
+		approved, err := r.Approve(20, "enjoy")
+		require.NoError(t, err)
+		assert.Equal(t, leaverequest.StatusApproved, approved.Status())
+		assert.Equal(t, uint(20), approved.ReviewedBy())
+		assert.Equal(t, "enjoy", approved.ReviewComment())
+		assert.NotNil(t, approved.ReviewedAt())
+		assert.Equal(t, leaverequest.StatusPending, r.Status())
+
+		_, err = approved.Reject(20, "")
+		require.ErrorIs(t, err, leaverequest.ErrNotPending)
+	})
+}
+
+func TestCancel(t *testing.T) {
+	r, err := leaverequest.New(1, 2, date("2025-03-03"), date("2025-03-07"))
+	require.NoError(t, err)
+
+	canceled, err := r.Cancel(date("2025-03-05"))
+	require.NoError(t, err)
+	assert.Equal(t, leaverequest.StatusCanceled, canceled.Status())
+
+	_, err = canceled.Cancel(date("2025-03-01"))
+	require.ErrorIs(t, err, leaverequest.ErrCannotCancel)
+
+	approved, err := r.Approve(20, "")
+	require.NoError(t, err)
+	_, err = approved.Cancel(date("2025-03-01"))
+	require.NoError(t, err)
+	_, err = approved.Cancel(date("2025-03-03"))
+	require.ErrorIs(t, err, leaverequest.ErrAlreadyStarted)
+}
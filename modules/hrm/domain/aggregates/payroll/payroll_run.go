@@ -0,0 +1,219 @@
+package payroll
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+var (
+	ErrAlreadyPosted    = errors.New("payroll run is already posted")
+	ErrRunExists        = errors.New("payroll run for this period already exists")
+	ErrEmptyRun         = errors.New("payroll run has no payslips")
+	ErrPayslipNotFound  = errors.New("payslip not found")
+	ErrCurrencyMismatch = errors.New("payslip currency does not match the money account")
+)
+
+type Status string
+
+const (
+	StatusDraft  Status = "draft"
+	StatusPosted Status = "posted"
+)
+
+type Option func(r *run)
+
+func WithID(id uint) Option {
+	return func(r *run) {
+		r.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(r *run) {
+		r.tenantID = tenantID
+	}
+}
+
+func WithStatus(status Status) Option {
+	return func(r *run) {
+		r.status = status
+	}
+}
+
+func WithCreatedBy(userID uint) Option {
+	return func(r *run) {
+		r.createdBy = userID
+	}
+}
+
+func WithPostedAt(postedAt *time.Time) Option {
+	return func(r *run) {
+		r.postedAt = postedAt
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(r *run) {
+		r.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(r *run) {
+		r.updatedAt = updatedAt
+	}
+}
+
+// Total is the sum of the payslips of a run in one currency
+type Total struct {
+	Gross *money.Money
+	Net   *money.Money
+}
+
+// Run is the payroll of a tenant for a month
+type Run interface {
+	ID() uint
+	TenantID() uuid.UUID
+	Year() int
+	Month() time.Month
+	Status() Status
+	Payslips() []Payslip
+	CreatedBy() uint
+	PostedAt() *time.Time
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// Period returns the first day of the month of the run
+	Period() time.Time
+	// Totals returns the gross and net pay per currency
+	Totals() []Total
+	Payslip(id uint) (Payslip, error)
+
+	SetPayslips(payslips []Payslip) (Run, error)
+	Post(at time.Time) (Run, error)
+}
+
+func New(year int, month time.Month, payslips []Payslip, opts ...Option) Run {
+	r := &run{
+		year:      year,
+		month:     month,
+		status:    StatusDraft,
+		payslips:  payslips,
+		createdAt: time.Now(),
+		updatedAt: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+type run struct {
+	id        uint
+	tenantID  uuid.UUID
+	year      int
+	month     time.Month
+	status    Status
+	payslips  []Payslip
+	createdBy uint
+	postedAt  *time.Time
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (r *run) ID() uint {
+	return r.id
+}
+
+func (r *run) TenantID() uuid.UUID {
+	return r.tenantID
+}
+
+func (r *run) Year() int {
+	return r.year
+}
+
+func (r *run) Month() time.Month {
+	return r.month
+}
+
+func (r *run) Status() Status {
+	return r.status
+}
+
+func (r *run) Payslips() []Payslip {
+	return r.payslips
+}
+
+func (r *run) CreatedBy() uint {
+	return r.createdBy
+}
+
+func (r *run) PostedAt() *time.Time {
+	return r.postedAt
+}
+
+func (r *run) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *run) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+func (r *run) Period() time.Time {
+	return time.Date(r.year, r.month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (r *run) Totals() []Total {
+	var totals []Total
+	index := make(map[string]int)
+	for _, p := range r.payslips {
+		i, ok := index[p.Currency]
+		if !ok {
+			i = len(totals)
+			index[p.Currency] = i
+			totals = append(totals, Total{Gross: p.Money(0), Net: p.Money(0)})
+		}
+		totals[i].Gross = money.New(totals[i].Gross.Amount()+p.Gross, p.Currency)
+		totals[i].Net = money.New(totals[i].Net.Amount()+p.Net, p.Currency)
+	}
+	return totals
+}
+
+func (r *run) Payslip(id uint) (Payslip, error) {
+	for _, p := range r.payslips {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return Payslip{}, ErrPayslipNotFound
+}
+
+func (r *run) SetPayslips(payslips []Payslip) (Run, error) {
+	if r.status == StatusPosted {
+		return nil, ErrAlreadyPosted
+	}
+	result := *r
+	result.payslips = payslips
+	result.updatedAt = time.Now()
+	return &result, nil
+}
+
+func (r *run) Post(at time.Time) (Run, error) {
+	if r.status == StatusPosted {
+		return nil, ErrAlreadyPosted
+	}
+	if len(r.payslips) == 0 {
+		return nil, ErrEmptyRun
+	}
+	result := *r
+	result.status = StatusPosted
+	result.postedAt = &at
+	result.updatedAt = at
+	return &result, nil
+}
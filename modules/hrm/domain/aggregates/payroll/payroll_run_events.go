@@ -0,0 +1,54 @@
+package payroll
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func NewCreatedEvent(ctx context.Context, result Run) (*CreatedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+func NewPostedEvent(ctx context.Context, result Run) (*PostedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PostedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+type CreatedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Run
+}
+
+// PostedEvent is published when the salary expenses of a run are posted to finance
+type PostedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Run
+}
@@ -0,0 +1,23 @@
+package payroll
+
+import (
+	"context"
+	"time"
+)
+
+type FindParams struct {
+	Limit  int
+	Offset int
+}
+
+type Repository interface {
+	Count(ctx context.Context) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]Run, error)
+	GetByID(ctx context.Context, id uint) (Run, error)
+	// GetByPeriod returns the run of the month or an error wrapping the not found error of the implementation
+	GetByPeriod(ctx context.Context, year int, month time.Month) (Run, error)
+	Create(ctx context.Context, data Run) (Run, error)
+	// Update saves the status of the run and replaces its payslips
+	Update(ctx context.Context, data Run) (Run, error)
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,110 @@
+package payroll
+
+import (
+	"math"
+	"sort"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/payrollrule"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/timesheet"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+// Line is a single bonus, deduction or tax on a payslip
+type Line struct {
+	Kind   payrollrule.Kind `json:"kind"`
+	Name   string           `json:"name"`
+	Amount int64            `json:"amount"`
+}
+
+// Payslip is the pay of an employee for the period of a run. Amounts are in minor units of Currency.
+type Payslip struct {
+	ID         uint
+	EmployeeID uint
+	Currency   string
+	// BaseSalary is the monthly salary of the employee when the run was calculated
+	BaseSalary  int64
+	WorkingDays int
+	PayableDays float64
+	// Earned is the base salary prorated by the payable days
+	Earned int64
+	// Bonus is a one-off bonus entered for this run
+	Bonus      int64
+	Lines      []Line
+	Gross      int64
+	Taxable    int64
+	Deductions int64
+	Taxes      int64
+	Net        int64
+}
+
+func (p Payslip) Money(amount int64) *money.Money {
+	return money.New(amount, p.Currency)
+}
+
+// LinesOf returns the lines of the given kind
+func (p Payslip) LinesOf(kind payrollrule.Kind) []Line {
+	var lines []Line
+	for _, l := range p.Lines {
+		if l.Kind == kind {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// Calculate computes the payslip of an employee from the monthly salary, the timesheet of the period,
+// the tenant's payroll rules and a one-off bonus in minor units of the salary currency.
+//
+// Bonus rules in percent apply to the base salary, deductions to the gross pay
+// and taxes to the gross pay less pre-tax deductions.
+func Calculate(salary *money.Money, ts timesheet.Timesheet, rules []payrollrule.PayrollRule, bonus int64) Payslip {
+	p := Payslip{
+		EmployeeID:  ts.EmployeeID,
+		Currency:    salary.Currency().Code,
+		BaseSalary:  salary.Amount(),
+		WorkingDays: ts.WorkingDays,
+		PayableDays: ts.PayableDays(),
+		Bonus:       bonus,
+	}
+	p.Earned = p.BaseSalary
+	if ts.WorkingDays > 0 && p.PayableDays < float64(ts.WorkingDays) {
+		p.Earned = int64(math.Round(float64(p.BaseSalary) * p.PayableDays / float64(ts.WorkingDays)))
+	}
+
+	active := make([]payrollrule.PayrollRule, 0, len(rules))
+	for _, r := range rules {
+		if r.Enabled() {
+			active = append(active, r)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Position() < active[j].Position()
+	})
+	apply := func(kind payrollrule.Kind, base int64) int64 {
+		var total int64
+		for _, r := range active {
+			if r.Kind() != kind {
+				continue
+			}
+			amount := r.Amount(p.Money(base)).Amount()
+			p.Lines = append(p.Lines, Line{Kind: kind, Name: r.Name(), Amount: amount})
+			total += amount
+		}
+		return total
+	}
+
+	p.Gross = p.Earned + p.Bonus + apply(payrollrule.KindBonus, p.BaseSalary)
+	p.Deductions = apply(payrollrule.KindDeduction, p.Gross)
+	p.Taxable = p.Gross
+	for _, r := range active {
+		if r.Kind() == payrollrule.KindDeduction && r.PreTax() {
+			p.Taxable -= r.Amount(p.Money(p.Gross)).Amount()
+		}
+	}
+	if p.Taxable < 0 {
+		p.Taxable = 0
+	}
+	p.Taxes = apply(payrollrule.KindTax, p.Taxable)
+	p.Net = p.Gross - p.Deductions - p.Taxes
+	return p
+}
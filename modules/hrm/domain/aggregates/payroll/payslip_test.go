@@ -0,0 +1,95 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/payroll"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/payrollrule"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/timesheet"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func fullMonth() timesheet.Timesheet {
+	return timesheet.Timesheet{EmployeeID: 1, Year: 2025, Month: time.March, WorkingDays: 20, AttendedDays: 20}
+}
+
+func TestCalculate(t *testing.T) {
+	salary := money.New(100000, "USD") // 1000.00
+
+	t.Run("full month without rules", func(t *testing.T) {
+		p := payroll.Calculate(salary, fullMonth(), nil, 0)
+		assert.Equal(t, int64(100000), p.Earned)
+		assert.Equal(t, int64(100000), p.Gross)
+		assert.Equal(t, int64(100000), p.Net)
+		assert.Equal(t, "USD", p.Currency)
+	})
+
+	t.Run("salary is prorated by payable days", func(t *testing.T) {
+		ts := fullMonth()
+		ts.AttendedDays = 12
+		ts.PaidLeaveDays = 3
+		p := payroll.Calculate(salary, ts, nil, 0)
+		assert.Equal(t, int64(75000), p.Earned)
+		assert.InDelta(t, 15.0, p.PayableDays, 0.001)
+	})
+
+	t.Run("bonuses, deductions and taxes", func(t *testing.T) {
+		rules := []payrollrule.PayrollRule{
+			payrollrule.New("Income tax", payrollrule.KindTax, payrollrule.MethodPercent, 10, payrollrule.WithPosition(2)),
+			payrollrule.New("Pension", payrollrule.KindDeduction, payrollrule.MethodPercent, 5, payrollrule.WithPreTax(true)),
+			payrollrule.New("Union", payrollrule.KindDeduction, payrollrule.MethodFixed, 10),
+			payrollrule.New("Performance", payrollrule.KindBonus, payrollrule.MethodPercent, 20),
+			payrollrule.New("Disabled", payrollrule.KindTax, payrollrule.MethodPercent, 50, payrollrule.WithEnabled(false)),
+		}
+		p := payroll.Calculate(salary, fullMonth(), rules, 5000)
+
+		// 1000 + 50 one-off + 200 performance
+		require.Equal(t, int64(125000), p.Gross)
+		// 5% of 1250 + 10 fixed
+		assert.Equal(t, int64(6250+1000), p.Deductions)
+		// only the pre-tax pension reduces the taxable pay
+		assert.Equal(t, int64(118750), p.Taxable)
+		assert.Equal(t, int64(11875), p.Taxes)
+		assert.Equal(t, int64(125000-7250-11875), p.Net)
+
+		assert.Len(t, p.Lines, 4)
+		assert.Len(t, p.LinesOf(payrollrule.KindDeduction), 2)
+		assert.Equal(t, "Income tax", p.LinesOf(payrollrule.KindTax)[0].Name)
+	})
+}
+
+func TestRun(t *testing.T) {
+	p1 := payroll.Calculate(money.New(100000, "USD"), fullMonth(), nil, 0)
+	p2 := payroll.Calculate(money.New(50000, "USD"), fullMonth(), nil, 0)
+	p3 := payroll.Calculate(money.New(900000, "UZS"), fullMonth(), nil, 0)
+
+	t.Run("totals per currency", func(t *testing.T) {
+		r := payroll.New(2025, time.March, []payroll.Payslip{p1, p2, p3})
+		totals := r.Totals()
+		require.Len(t, totals, 2)
+		assert.Equal(t, int64(150000), totals[0].Gross.Amount())
+		assert.Equal(t, "UZS", totals[1].Net.Currency().Code)
+	})
+
+	t.Run("posted runs are final", func(t *testing.T) {
+		r := payroll.New(2025, time.March, []payroll.Payslip{p1})
+		posted, err := r.Post(time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, payroll.StatusPosted, posted.Status())
+		assert.Equal(t, payroll.StatusDraft, r.Status())
+
+		_, err = posted.Post(time.Now())
+		require.ErrorIs(t, err, payroll.ErrAlreadyPosted)
+		_, err = posted.SetPayslips(nil)
+		require.ErrorIs(t, err, payroll.ErrAlreadyPosted)
+	})
+
+	t.Run("empty runs can not be posted", func(t *testing.T) {
+		_, err := payroll.New(2025, time.March, nil).Post(time.Now())
+		require.ErrorIs(t, err, payroll.ErrEmptyRun)
+	})
+}
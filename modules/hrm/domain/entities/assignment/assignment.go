@@ -0,0 +1,171 @@
+package assignment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSelfManager   = errors.New("employee can not be their own manager")
+	ErrManagerCycle  = errors.New("manager reports to the employee")
+	ErrStartTooEarly = errors.New("transfer date is before the start of the current assignment")
+)
+
+type Option func(a *assignment)
+
+func WithID(id uint) Option {
+	return func(a *assignment) {
+		a.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(a *assignment) {
+		a.tenantID = tenantID
+	}
+}
+
+func WithDepartmentID(departmentID uint) Option {
+	return func(a *assignment) {
+		a.departmentID = departmentID
+	}
+}
+
+func WithManagerID(managerID uint) Option {
+	return func(a *assignment) {
+		a.managerID = managerID
+	}
+}
+
+func WithEndDate(endDate *time.Time) Option {
+	return func(a *assignment) {
+		a.endDate = endDate
+	}
+}
+
+func WithNote(note string) Option {
+	return func(a *assignment) {
+		a.note = note
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(a *assignment) {
+		a.createdAt = createdAt
+	}
+}
+
+// Assignment places an employee in a department under a manager for a period of time.
+// The history of transfers of an employee is the list of their assignments.
+type Assignment interface {
+	ID() uint
+	TenantID() uuid.UUID
+	EmployeeID() uint
+	// DepartmentID is zero when the employee is not in a department
+	DepartmentID() uint
+	// ManagerID is the employee the assignee reports to directly, zero when not set
+	ManagerID() uint
+	StartDate() time.Time
+	// EndDate is nil for the current assignment
+	EndDate() *time.Time
+	Note() string
+	CreatedAt() time.Time
+
+	Current() bool
+	End(date time.Time) Assignment
+}
+
+func New(employeeID uint, startDate time.Time, opts ...Option) Assignment {
+	a := &assignment{
+		employeeID: employeeID,
+		startDate:  startDate,
+		createdAt:  time.Now(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+type assignment struct {
+	id           uint
+	tenantID     uuid.UUID
+	employeeID   uint
+	departmentID uint
+	managerID    uint
+	startDate    time.Time
+	endDate      *time.Time
+	note         string
+	createdAt    time.Time
+}
+
+func (a *assignment) ID() uint {
+	return a.id
+}
+
+func (a *assignment) TenantID() uuid.UUID {
+	return a.tenantID
+}
+
+func (a *assignment) EmployeeID() uint {
+	return a.employeeID
+}
+
+func (a *assignment) DepartmentID() uint {
+	return a.departmentID
+}
+
+func (a *assignment) ManagerID() uint {
+	return a.managerID
+}
+
+func (a *assignment) StartDate() time.Time {
+	return a.startDate
+}
+
+func (a *assignment) EndDate() *time.Time {
+	return a.endDate
+}
+
+func (a *assignment) Note() string {
+	return a.note
+}
+
+func (a *assignment) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *assignment) Current() bool {
+	return a.endDate == nil
+}
+
+func (a *assignment) End(date time.Time) Assignment {
+	result := *a
+	result.endDate = &date
+	return &result
+}
+
+// CheckManager makes sure that assigning managerID to employeeID does not create a loop
+// in the reporting lines described by the current assignments.
+func CheckManager(current []Assignment, employeeID, managerID uint) error {
+	if managerID == 0 {
+		return nil
+	}
+	if managerID == employeeID {
+		return ErrSelfManager
+	}
+	managers := make(map[uint]uint, len(current))
+	for _, a := range current {
+		managers[a.EmployeeID()] = a.ManagerID()
+	}
+	seen := map[uint]bool{}
+	for id := managerID; id != 0 && !seen[id]; id = managers[id] {
+		if id == employeeID {
+			return ErrManagerCycle
+		}
+		seen[id] = true
+	}
+	return nil
+}
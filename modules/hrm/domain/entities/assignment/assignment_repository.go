@@ -0,0 +1,12 @@
+package assignment
+
+import "context"
+
+type Repository interface {
+	// GetCurrent returns the open assignments of all employees
+	GetCurrent(ctx context.Context) ([]Assignment, error)
+	// GetByEmployee returns the history of an employee, latest first
+	GetByEmployee(ctx context.Context, employeeID uint) ([]Assignment, error)
+	Create(ctx context.Context, data Assignment) (Assignment, error)
+	Update(ctx context.Context, data Assignment) error
+}
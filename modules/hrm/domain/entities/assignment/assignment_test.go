@@ -0,0 +1,33 @@
+package assignment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/assignment"
+)
+
+func TestCheckManager(t *testing.T) {
+	now := time.Now()
+	current := []assignment.Assignment{
+		assignment.New(2, now, assignment.WithManagerID(1)),
+		assignment.New(3, now, assignment.WithManagerID(2)),
+		assignment.New(4, now),
+	}
+
+	require.NoError(t, assignment.CheckManager(current, 4, 3))
+	require.NoError(t, assignment.CheckManager(current, 1, 0))
+	require.ErrorIs(t, assignment.CheckManager(current, 4, 4), assignment.ErrSelfManager)
+	require.ErrorIs(t, assignment.CheckManager(current, 1, 3), assignment.ErrManagerCycle)
+}
+
+func TestEnd(t *testing.T) {
+	a := assignment.New(1, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.True(t, a.Current())
+
+	ended := a.End(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+	require.False(t, ended.Current())
+	require.True(t, a.Current())
+}
@@ -0,0 +1,65 @@
+package assignment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type TransferDTO struct {
+	EmployeeID   uint `validate:"required"`
+	DepartmentID uint
+	ManagerID    uint
+	StartDate    shared.DateOnly
+	Note         string
+}
+
+func (d *TransferDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+
+	validationErrors := make(serrors.ValidationErrors)
+	getFieldLocaleKey := func(field string) string {
+		return fmt.Sprintf("Transfers.Fields.%s.Label", field)
+	}
+
+	errs := constants.Validate.Struct(d)
+	if errs != nil {
+		for field, err := range serrors.ProcessValidatorErrors(errs.(validator.ValidationErrors), getFieldLocaleKey) {
+			validationErrors[field] = err
+		}
+	}
+	if time.Time(d.StartDate).IsZero() {
+		validationErrors["StartDate"] = serrors.NewFieldRequiredError("StartDate", getFieldLocaleKey("StartDate"))
+	}
+	if d.ManagerID != 0 && d.ManagerID == d.EmployeeID {
+		validationErrors["ManagerID"] = serrors.NewValidationError(
+			"ManagerID",
+			"self_manager",
+			ErrSelfManager.Error(),
+			"ValidationErrors.selfManager",
+		).WithFieldName(getFieldLocaleKey("ManagerID"))
+	}
+
+	errorMessages := serrors.LocalizeValidationErrors(validationErrors, l)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *TransferDTO) ToEntity() Assignment {
+	return New(
+		d.EmployeeID,
+		time.Time(d.StartDate),
+		WithDepartmentID(d.DepartmentID),
+		WithManagerID(d.ManagerID),
+		WithNote(d.Note),
+	)
+}
@@ -0,0 +1,189 @@
+package attendance
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCheckOutBeforeCheckIn = errors.New("check-out is before check-in")
+)
+
+type Status string
+
+const (
+	StatusPresent Status = "present"
+	StatusRemote  Status = "remote"
+	StatusAbsent  Status = "absent"
+)
+
+func (s Status) IsValid() bool {
+	return s == StatusPresent || s == StatusRemote || s == StatusAbsent
+}
+
+// Source tells where a record came from
+type Source string
+
+const (
+	SourceManual Source = "manual"
+	SourceImport Source = "import"
+)
+
+type Option func(a *attendance)
+
+func WithID(id uint) Option {
+	return func(a *attendance) {
+		a.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(a *attendance) {
+		a.tenantID = tenantID
+	}
+}
+
+func WithCheckIn(t time.Time) Option {
+	return func(a *attendance) {
+		a.checkIn = &t
+	}
+}
+
+func WithCheckOut(t time.Time) Option {
+	return func(a *attendance) {
+		a.checkOut = &t
+	}
+}
+
+func WithSource(source Source) Option {
+	return func(a *attendance) {
+		a.source = source
+	}
+}
+
+func WithNote(note string) Option {
+	return func(a *attendance) {
+		a.note = note
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(a *attendance) {
+		a.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(a *attendance) {
+		a.updatedAt = updatedAt
+	}
+}
+
+// Attendance is an employee's record for a single day
+type Attendance interface {
+	ID() uint
+	TenantID() uuid.UUID
+	EmployeeID() uint
+	Date() time.Time
+	Status() Status
+	CheckIn() *time.Time
+	CheckOut() *time.Time
+	Source() Source
+	Note() string
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// WorkedHours is the time between check-in and check-out
+	WorkedHours() float64
+	// Attended reports whether the employee worked that day, on site or remotely
+	Attended() bool
+}
+
+func New(employeeID uint, date time.Time, status Status, opts ...Option) (Attendance, error) {
+	a := &attendance{
+		employeeID: employeeID,
+		date:       time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		status:     status,
+		source:     SourceManual,
+		createdAt:  time.Now(),
+		updatedAt:  time.Now(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.checkIn != nil && a.checkOut != nil && a.checkOut.Before(*a.checkIn) {
+		return nil, ErrCheckOutBeforeCheckIn
+	}
+	return a, nil
+}
+
+type attendance struct {
+	id         uint
+	tenantID   uuid.UUID
+	employeeID uint
+	date       time.Time
+	status     Status
+	checkIn    *time.Time
+	checkOut   *time.Time
+	source     Source
+	note       string
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+func (a *attendance) ID() uint {
+	return a.id
+}
+
+func (a *attendance) TenantID() uuid.UUID {
+	return a.tenantID
+}
+
+func (a *attendance) EmployeeID() uint {
+	return a.employeeID
+}
+
+func (a *attendance) Date() time.Time {
+	return a.date
+}
+
+func (a *attendance) Status() Status {
+	return a.status
+}
+
+func (a *attendance) CheckIn() *time.Time {
+	return a.checkIn
+}
+
+func (a *attendance) CheckOut() *time.Time {
+	return a.checkOut
+}
+
+func (a *attendance) Source() Source {
+	return a.source
+}
+
+func (a *attendance) Note() string {
+	return a.note
+}
+
+func (a *attendance) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *attendance) UpdatedAt() time.Time {
+	return a.updatedAt
+}
+
+func (a *attendance) WorkedHours() float64 {
+	if a.checkIn == nil || a.checkOut == nil {
+		return 0
+	}
+	return a.checkOut.Sub(*a.checkIn).Hours()
+}
+
+func (a *attendance) Attended() bool {
+	return a.status == StatusPresent || a.status == StatusRemote
+}
@@ -0,0 +1,109 @@
+package attendance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// TimeLayout is the layout of check-in and check-out times in forms and imports
+const TimeLayout = "15:04"
+
+type CreateDTO struct {
+	EmployeeID uint   `validate:"required"`
+	Status     string `validate:"required"`
+	Date       shared.DateOnly
+	CheckIn    string
+	CheckOut   string
+	Note       string
+}
+
+func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+
+	validationErrors := make(serrors.ValidationErrors)
+	getFieldLocaleKey := func(field string) string {
+		return fmt.Sprintf("Attendance.Fields.%s.Label", field)
+	}
+
+	errs := constants.Validate.Struct(d)
+	if errs != nil {
+		for field, err := range serrors.ProcessValidatorErrors(errs.(validator.ValidationErrors), getFieldLocaleKey) {
+			validationErrors[field] = err
+		}
+	}
+
+	if time.Time(d.Date).IsZero() {
+		validationErrors["Date"] = serrors.NewFieldRequiredError("Date", getFieldLocaleKey("Date"))
+	}
+	if d.Status != "" && !Status(d.Status).IsValid() {
+		validationErrors["Status"] = serrors.NewValidationError(
+			"Status",
+			"VALIDATION_INVALID_VALUE",
+			fmt.Sprintf("invalid status: %s", d.Status),
+			"ValidationErrors.invalidValue",
+		).WithFieldName(getFieldLocaleKey("Status"))
+	}
+	for field, value := range map[string]string{"CheckIn": d.CheckIn, "CheckOut": d.CheckOut} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(TimeLayout, value); err != nil {
+			validationErrors[field] = serrors.NewValidationError(
+				field,
+				"VALIDATION_INVALID_VALUE",
+				err.Error(),
+				"ValidationErrors.invalidValue",
+			).WithFieldName(getFieldLocaleKey(field))
+		}
+	}
+	if _, ok := validationErrors["CheckOut"]; !ok && d.CheckIn != "" && d.CheckOut != "" && d.CheckOut < d.CheckIn {
+		validationErrors["CheckOut"] = serrors.NewValidationError(
+			"CheckOut",
+			"VALIDATION_CHECK_OUT",
+			ErrCheckOutBeforeCheckIn.Error(),
+			"ValidationErrors.checkOutBeforeCheckIn",
+		).WithFieldName(getFieldLocaleKey("CheckOut"))
+	}
+
+	errorMessages := serrors.LocalizeValidationErrors(validationErrors, l)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) ToEntity() (Attendance, error) {
+	date := time.Time(d.Date)
+	opts := []Option{WithSource(SourceManual), WithNote(d.Note)}
+	for _, v := range []struct {
+		value string
+		opt   func(time.Time) Option
+	}{{d.CheckIn, WithCheckIn}, {d.CheckOut, WithCheckOut}} {
+		if v.value == "" {
+			continue
+		}
+		t, err := AtTime(date, v.value)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, v.opt(t))
+	}
+	return New(d.EmployeeID, date, Status(d.Status), opts...)
+}
+
+// AtTime combines a date with a clock time in TimeLayout
+func AtTime(date time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse(TimeLayout, clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC), nil
+}
@@ -0,0 +1,23 @@
+package attendance
+
+import (
+	"context"
+	"time"
+)
+
+type FindParams struct {
+	EmployeeID uint
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Offset     int
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]Attendance, error)
+	GetByID(ctx context.Context, id uint) (Attendance, error)
+	// Save inserts the record or replaces the one of the same employee and date
+	Save(ctx context.Context, data Attendance) (Attendance, error)
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,49 @@
+package leavetype
+
+import (
+	"math"
+	"time"
+)
+
+// Balance is the state of an employee's allowance of a leave type in a year
+type Balance struct {
+	LeaveType   LeaveType
+	Year        int
+	Accrued     float64
+	CarriedOver float64
+	Used        float64
+	Pending     float64
+}
+
+// Available returns the days that can still be requested
+func (b Balance) Available() float64 {
+	return b.Accrued + b.CarriedOver - b.Used - b.Pending
+}
+
+// NewBalance computes the balance of a year as of the given moment.
+// used and pending are the days of approved and pending requests in the year,
+// previousUsed the days taken in the year before, whose unused allowance is carried over
+// up to the carry-over limit of the leave type.
+func NewBalance(t LeaveType, hireDate time.Time, year int, asOf time.Time, used, pending, previousUsed float64) Balance {
+	b := Balance{
+		LeaveType: t,
+		Year:      year,
+		Used:      used,
+		Pending:   pending,
+	}
+	if !t.Limited() {
+		return b
+	}
+	switch {
+	case asOf.Year() > year:
+		asOf = time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	case asOf.Year() < year:
+		asOf = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	b.Accrued = t.Accrued(hireDate, asOf)
+	if t.CarryOverLimit() > 0 {
+		previous := t.Accrued(hireDate, time.Date(year-1, time.December, 31, 0, 0, 0, 0, time.UTC))
+		b.CarriedOver = math.Min(math.Max(previous-previousUsed, 0), t.CarryOverLimit())
+	}
+	return b
+}
@@ -0,0 +1,191 @@
+package leavetype
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Accrual defines how the annual allowance of a leave type is earned
+type Accrual string
+
+const (
+	// AccrualNone means the leave is not limited, e.g. unpaid leave
+	AccrualNone Accrual = "none"
+	// AccrualYearly grants the whole allowance at the start of the year
+	AccrualYearly Accrual = "yearly"
+	// AccrualMonthly earns a twelfth of the allowance for every started month
+	AccrualMonthly Accrual = "monthly"
+)
+
+func (a Accrual) IsValid() bool {
+	return a == AccrualNone || a == AccrualYearly || a == AccrualMonthly
+}
+
+type Option func(t *leaveType)
+
+func WithID(id uint) Option {
+	return func(t *leaveType) {
+		t.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(t *leaveType) {
+		t.tenantID = tenantID
+	}
+}
+
+func WithDescription(description string) Option {
+	return func(t *leaveType) {
+		t.description = description
+	}
+}
+
+func WithPaid(paid bool) Option {
+	return func(t *leaveType) {
+		t.paid = paid
+	}
+}
+
+func WithCarryOverLimit(days float64) Option {
+	return func(t *leaveType) {
+		t.carryOverLimit = days
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(t *leaveType) {
+		t.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(t *leaveType) {
+		t.updatedAt = updatedAt
+	}
+}
+
+type LeaveType interface {
+	ID() uint
+	TenantID() uuid.UUID
+	Name() string
+	Description() string
+	Paid() bool
+	// AnnualAllowance is the number of working days granted per year
+	AnnualAllowance() float64
+	Accrual() Accrual
+	// CarryOverLimit is the number of unused days that move to the next year
+	CarryOverLimit() float64
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// Limited reports whether requests are checked against the balance
+	Limited() bool
+	// Accrued returns the days earned in the year of asOf by an employee hired at hireDate
+	Accrued(hireDate time.Time, asOf time.Time) float64
+}
+
+func New(name string, annualAllowance float64, accrual Accrual, opts ...Option) LeaveType {
+	t := &leaveType{
+		name:            name,
+		paid:            true,
+		annualAllowance: annualAllowance,
+		accrual:         accrual,
+		createdAt:       time.Now(),
+		updatedAt:       time.Now(),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+type leaveType struct {
+	id              uint
+	tenantID        uuid.UUID
+	name            string
+	description     string
+	paid            bool
+	annualAllowance float64
+	accrual         Accrual
+	carryOverLimit  float64
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+func (t *leaveType) ID() uint {
+	return t.id
+}
+
+func (t *leaveType) TenantID() uuid.UUID {
+	return t.tenantID
+}
+
+func (t *leaveType) Name() string {
+	return t.name
+}
+
+func (t *leaveType) Description() string {
+	return t.description
+}
+
+func (t *leaveType) Paid() bool {
+	return t.paid
+}
+
+func (t *leaveType) AnnualAllowance() float64 {
+	return t.annualAllowance
+}
+
+func (t *leaveType) Accrual() Accrual {
+	return t.accrual
+}
+
+func (t *leaveType) CarryOverLimit() float64 {
+	return t.carryOverLimit
+}
+
+func (t *leaveType) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *leaveType) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+func (t *leaveType) Limited() bool {
+	return t.accrual != AccrualNone
+}
+
+func (t *leaveType) Accrued(hireDate time.Time, asOf time.Time) float64 {
+	year := asOf.Year()
+	if !hireDate.IsZero() && hireDate.Year() > year {
+		return 0
+	}
+	// months of the year the employee is employed in, counting the month of hire
+	firstMonth := time.January
+	if !hireDate.IsZero() && hireDate.Year() == year {
+		firstMonth = hireDate.Month()
+	}
+
+	var months int
+	switch t.accrual {
+	case AccrualNone:
+		return 0
+	case AccrualYearly:
+		months = int(time.December-firstMonth) + 1
+	case AccrualMonthly:
+		months = int(asOf.Month()-firstMonth) + 1
+	}
+	if months <= 0 {
+		return 0
+	}
+	return round(t.annualAllowance * float64(months) / 12)
+}
+
+// round keeps accrued days at half-day precision
+func round(days float64) float64 {
+	return math.Floor(days*2) / 2
+}
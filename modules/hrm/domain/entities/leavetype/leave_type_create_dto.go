@@ -0,0 +1,76 @@
+package leavetype
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+type CreateDTO struct {
+	Name            string `validate:"required"`
+	Description     string
+	Paid            bool
+	AnnualAllowance float64
+	Accrual         string `validate:"required"`
+	CarryOverLimit  float64
+}
+
+func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+
+	validationErrors := make(serrors.ValidationErrors)
+	getFieldLocaleKey := func(field string) string {
+		return fmt.Sprintf("LeaveTypes.Fields.%s.Label", field)
+	}
+
+	errs := constants.Validate.Struct(d)
+	if errs != nil {
+		for field, err := range serrors.ProcessValidatorErrors(errs.(validator.ValidationErrors), getFieldLocaleKey) {
+			validationErrors[field] = err
+		}
+	}
+
+	if d.Accrual != "" && !Accrual(d.Accrual).IsValid() {
+		validationErrors["Accrual"] = serrors.NewValidationError(
+			"Accrual",
+			"VALIDATION_INVALID_VALUE",
+			fmt.Sprintf("invalid accrual: %s", d.Accrual),
+			"ValidationErrors.invalidValue",
+		).WithFieldName(getFieldLocaleKey("Accrual"))
+	}
+	for field, value := range map[string]float64{
+		"AnnualAllowance": d.AnnualAllowance,
+		"CarryOverLimit":  d.CarryOverLimit,
+	} {
+		if value < 0 {
+			validationErrors[field] = serrors.NewValidationError(
+				field,
+				"VALIDATION_INVALID_VALUE",
+				fmt.Sprintf("%s must not be negative", field),
+				"ValidationErrors.invalidValue",
+			).WithFieldName(getFieldLocaleKey(field))
+		}
+	}
+
+	errorMessages := serrors.LocalizeValidationErrors(validationErrors, l)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) ToEntity() LeaveType {
+	return New(
+		d.Name,
+		d.AnnualAllowance,
+		Accrual(d.Accrual),
+		WithDescription(d.Description),
+		WithPaid(d.Paid),
+		WithCarryOverLimit(d.CarryOverLimit),
+	)
+}
@@ -0,0 +1,10 @@
+package leavetype
+
+import "context"
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]LeaveType, error)
+	GetByID(ctx context.Context, id uint) (LeaveType, error)
+	Create(ctx context.Context, data LeaveType) (LeaveType, error)
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,74 @@
+package leavetype_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/leavetype"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAccrued(t *testing.T) {
+	longAgo := date(2020, time.January, 10)
+
+	t.Run("yearly grants the full allowance", func(t *testing.T) {
+		lt := leavetype.New("Vacation", 24, leavetype.AccrualYearly)
+		assert.InDelta(t, 24.0, lt.Accrued(longAgo, date(2025, time.February, 1)), 0.001)
+	})
+
+	t.Run("yearly is pro-rated in the year of hire", func(t *testing.T) {
+		lt := leavetype.New("Vacation", 24, leavetype.AccrualYearly)
+		assert.InDelta(t, 12.0, lt.Accrued(date(2025, time.July, 15), date(2025, time.August, 1)), 0.001)
+	})
+
+	t.Run("monthly accrues up to the current month", func(t *testing.T) {
+		lt := leavetype.New("Vacation", 21, leavetype.AccrualMonthly)
+		// 3 months * 1.75 days
+		assert.InDelta(t, 5.0, lt.Accrued(longAgo, date(2025, time.March, 20)), 0.001)
+		assert.InDelta(t, 1.5, lt.Accrued(date(2025, time.March, 1), date(2025, time.March, 20)), 0.001)
+	})
+
+	t.Run("nothing before hire or without accrual", func(t *testing.T) {
+		lt := leavetype.New("Vacation", 24, leavetype.AccrualMonthly)
+		assert.Zero(t, lt.Accrued(date(2026, time.January, 1), date(2025, time.December, 1)))
+		assert.Zero(t, leavetype.New("Sick", 0, leavetype.AccrualNone).Accrued(longAgo, date(2025, time.June, 1)))
+	})
+}
+
+func TestNewBalance(t *testing.T) {
+	hired := date(2020, time.January, 10)
+
+	t.Run("carries over unused days up to the limit", func(t *testing.T) {
+		lt := leavetype.New("Vacation", 24, leavetype.AccrualYearly, leavetype.WithCarryOverLimit(5))
+		b := leavetype.NewBalance(lt, hired, 2025, date(2025, time.April, 1), 4, 2, 10)
+
+		assert.InDelta(t, 24.0, b.Accrued, 0.001)
+		assert.InDelta(t, 5.0, b.CarriedOver, 0.001)
+		assert.InDelta(t, 23.0, b.Available(), 0.001)
+	})
+
+	t.Run("does not carry over when everything was used", func(t *testing.T) {
+		lt := leavetype.New("Vacation", 24, leavetype.AccrualYearly, leavetype.WithCarryOverLimit(5))
+		b := leavetype.NewBalance(lt, hired, 2025, date(2025, time.April, 1), 0, 0, 24)
+		assert.Zero(t, b.CarriedOver)
+	})
+
+	t.Run("past years accrue in full", func(t *testing.T) {
+		lt := leavetype.New("Vacation", 12, leavetype.AccrualMonthly)
+		b := leavetype.NewBalance(lt, hired, 2024, date(2025, time.April, 1), 0, 0, 0)
+		assert.InDelta(t, 12.0, b.Accrued, 0.001)
+	})
+
+	t.Run("unlimited types only track usage", func(t *testing.T) {
+		lt := leavetype.New("Sick", 0, leavetype.AccrualNone, leavetype.WithPaid(false))
+		b := leavetype.NewBalance(lt, hired, 2025, date(2025, time.April, 1), 3, 1, 0)
+		assert.False(t, b.LeaveType.Limited())
+		assert.Zero(t, b.Accrued)
+		assert.InDelta(t, 3.0, b.Used, 0.001)
+	})
+}
@@ -0,0 +1,198 @@
+package payrollrule
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+// Kind defines how a rule affects the pay of an employee
+type Kind string
+
+const (
+	// KindBonus is added to the gross pay
+	KindBonus Kind = "bonus"
+	// KindDeduction is withheld from the gross pay, e.g. pension contributions
+	KindDeduction Kind = "deduction"
+	// KindTax is withheld from the taxable pay
+	KindTax Kind = "tax"
+)
+
+func (k Kind) IsValid() bool {
+	return k == KindBonus || k == KindDeduction || k == KindTax
+}
+
+// Method defines how the amount of a rule is computed
+type Method string
+
+const (
+	// MethodPercent takes Value percent of the base the rule applies to
+	MethodPercent Method = "percent"
+	// MethodFixed is a fixed amount in major units of the salary currency
+	MethodFixed Method = "fixed"
+)
+
+func (m Method) IsValid() bool {
+	return m == MethodPercent || m == MethodFixed
+}
+
+type Option func(r *payrollRule)
+
+func WithID(id uint) Option {
+	return func(r *payrollRule) {
+		r.id = id
+	}
+}
+
+func WithTenantID(tenantID uuid.UUID) Option {
+	return func(r *payrollRule) {
+		r.tenantID = tenantID
+	}
+}
+
+func WithPreTax(preTax bool) Option {
+	return func(r *payrollRule) {
+		r.preTax = preTax
+	}
+}
+
+func WithEnabled(enabled bool) Option {
+	return func(r *payrollRule) {
+		r.enabled = enabled
+	}
+}
+
+func WithPosition(position int) Option {
+	return func(r *payrollRule) {
+		r.position = position
+	}
+}
+
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(r *payrollRule) {
+		r.createdAt = createdAt
+	}
+}
+
+func WithUpdatedAt(updatedAt time.Time) Option {
+	return func(r *payrollRule) {
+		r.updatedAt = updatedAt
+	}
+}
+
+// PayrollRule is a tenant defined bonus, deduction or tax applied to every payslip
+type PayrollRule interface {
+	ID() uint
+	TenantID() uuid.UUID
+	Name() string
+	Kind() Kind
+	Method() Method
+	Value() float64
+	// PreTax reports whether a deduction reduces the taxable pay
+	PreTax() bool
+	Enabled() bool
+	// Position orders rules of the same kind on a payslip
+	Position() int
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// Amount returns the amount of the rule for the given base
+	Amount(base *money.Money) *money.Money
+	SetEnabled(enabled bool) PayrollRule
+}
+
+func New(name string, kind Kind, method Method, value float64, opts ...Option) PayrollRule {
+	r := &payrollRule{
+		name:      name,
+		kind:      kind,
+		method:    method,
+		value:     value,
+		enabled:   true,
+		createdAt: time.Now(),
+		updatedAt: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+type payrollRule struct {
+	id        uint
+	tenantID  uuid.UUID
+	name      string
+	kind      Kind
+	method    Method
+	value     float64
+	preTax    bool
+	enabled   bool
+	position  int
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (r *payrollRule) ID() uint {
+	return r.id
+}
+
+func (r *payrollRule) TenantID() uuid.UUID {
+	return r.tenantID
+}
+
+func (r *payrollRule) Name() string {
+	return r.name
+}
+
+func (r *payrollRule) Kind() Kind {
+	return r.kind
+}
+
+func (r *payrollRule) Method() Method {
+	return r.method
+}
+
+func (r *payrollRule) Value() float64 {
+	return r.value
+}
+
+func (r *payrollRule) PreTax() bool {
+	return r.preTax
+}
+
+func (r *payrollRule) Enabled() bool {
+	return r.enabled
+}
+
+func (r *payrollRule) Position() int {
+	return r.position
+}
+
+func (r *payrollRule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *payrollRule) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+func (r *payrollRule) Amount(base *money.Money) *money.Money {
+	code := base.Currency().Code
+	switch r.method {
+	case MethodPercent:
+		return money.New(int64(math.Round(float64(base.Amount())*r.value/100)), code)
+	case MethodFixed:
+		fraction := math.Pow10(base.Currency().Fraction)
+		return money.New(int64(math.Round(r.value*fraction)), code)
+	}
+	return money.New(0, code)
+}
+
+func (r *payrollRule) SetEnabled(enabled bool) PayrollRule {
+	result := *r
+	result.enabled = enabled
+	result.updatedAt = time.Now()
+	return &result
+}
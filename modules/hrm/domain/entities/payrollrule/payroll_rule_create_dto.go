@@ -0,0 +1,74 @@
+package payrollrule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+type CreateDTO struct {
+	Name     string `validate:"required"`
+	Kind     string `validate:"required"`
+	Method   string `validate:"required"`
+	Value    float64
+	PreTax   bool
+	Enabled  bool
+	Position int
+}
+
+func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+
+	validationErrors := make(serrors.ValidationErrors)
+	getFieldLocaleKey := func(field string) string {
+		return fmt.Sprintf("PayrollRules.Fields.%s.Label", field)
+	}
+
+	errs := constants.Validate.Struct(d)
+	if errs != nil {
+		for field, err := range serrors.ProcessValidatorErrors(errs.(validator.ValidationErrors), getFieldLocaleKey) {
+			validationErrors[field] = err
+		}
+	}
+
+	invalid := func(field, message string) {
+		validationErrors[field] = serrors.NewValidationError(
+			field,
+			"VALIDATION_INVALID_VALUE",
+			message,
+			"ValidationErrors.invalidValue",
+		).WithFieldName(getFieldLocaleKey(field))
+	}
+	if d.Kind != "" && !Kind(d.Kind).IsValid() {
+		invalid("Kind", fmt.Sprintf("invalid kind: %s", d.Kind))
+	}
+	if d.Method != "" && !Method(d.Method).IsValid() {
+		invalid("Method", fmt.Sprintf("invalid method: %s", d.Method))
+	}
+	if d.Value < 0 || (Method(d.Method) == MethodPercent && d.Value > 100) {
+		invalid("Value", fmt.Sprintf("value out of range: %v", d.Value))
+	}
+
+	errorMessages := serrors.LocalizeValidationErrors(validationErrors, l)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) ToEntity() PayrollRule {
+	return New(
+		d.Name,
+		Kind(d.Kind),
+		Method(d.Method),
+		d.Value,
+		WithPreTax(Kind(d.Kind) == KindDeduction && d.PreTax),
+		WithEnabled(d.Enabled),
+		WithPosition(d.Position),
+	)
+}
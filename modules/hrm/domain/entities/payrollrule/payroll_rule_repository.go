@@ -0,0 +1,11 @@
+package payrollrule
+
+import "context"
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]PayrollRule, error)
+	GetByID(ctx context.Context, id uint) (PayrollRule, error)
+	Create(ctx context.Context, data PayrollRule) (PayrollRule, error)
+	Update(ctx context.Context, data PayrollRule) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,86 @@
+package timesheet
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+)
+
+// Timesheet summarizes an employee's working time in a month, it is the input of payroll
+type Timesheet struct {
+	EmployeeID uint
+	Year       int
+	Month      time.Month
+	// WorkingDays is the number of working days in the month
+	WorkingDays     int
+	AttendedDays    int
+	AbsentDays      int
+	WorkedHours     float64
+	PaidLeaveDays   float64
+	UnpaidLeaveDays float64
+}
+
+// PayableDays are the days the employee is paid for
+func (t Timesheet) PayableDays() float64 {
+	return float64(t.AttendedDays) + t.PaidLeaveDays
+}
+
+// Build computes the timesheet of an employee from attendance records and approved leaves.
+// Working days after asOf are not counted as absences.
+func Build(
+	employeeID uint,
+	year int,
+	month time.Month,
+	records []attendance.Attendance,
+	leaves []leaverequest.LeaveRequest,
+	paidLeave func(leaveTypeID uint) bool,
+	asOf time.Time,
+) Timesheet {
+	t := Timesheet{
+		EmployeeID: employeeID,
+		Year:       year,
+		Month:      month,
+	}
+	byDate := make(map[string]attendance.Attendance, len(records))
+	for _, r := range records {
+		if r.EmployeeID() == employeeID {
+			byDate[r.Date().Format(time.DateOnly)] = r
+		}
+	}
+	asOf = time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	for day := first; day.Month() == month; day = day.AddDate(0, 0, 1) {
+		working := leaverequest.IsWorkingDay(day)
+		if working {
+			t.WorkingDays++
+		}
+		if leave := leaveOn(employeeID, leaves, day); leave != nil && working {
+			if paidLeave(leave.LeaveTypeID()) {
+				t.PaidLeaveDays++
+			} else {
+				t.UnpaidLeaveDays++
+			}
+			continue
+		}
+		if record, ok := byDate[day.Format(time.DateOnly)]; ok && record.Attended() {
+			t.AttendedDays++
+			t.WorkedHours += record.WorkedHours()
+			continue
+		}
+		if working && !day.After(asOf) {
+			t.AbsentDays++
+		}
+	}
+	return t
+}
+
+func leaveOn(employeeID uint, leaves []leaverequest.LeaveRequest, day time.Time) leaverequest.LeaveRequest {
+	for _, l := range leaves {
+		if l.EmployeeID() == employeeID && l.Status() == leaverequest.StatusApproved && l.Covers(day) {
+			return l
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package timesheet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/timesheet"
+)
+
+func at(day, hour int) time.Time {
+	return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestBuild(t *testing.T) {
+	var records []attendance.Attendance
+	// March 2025 starts on a Saturday; attend the first week
+	for day := 3; day <= 7; day++ {
+		r, err := attendance.New(1, at(day, 0), attendance.StatusPresent,
+			attendance.WithCheckIn(at(day, 9)), attendance.WithCheckOut(at(day, 18)))
+		require.NoError(t, err)
+		records = append(records, r)
+	}
+	absent, err := attendance.New(1, at(10, 0), attendance.StatusAbsent)
+	require.NoError(t, err)
+	other, err := attendance.New(2, at(11, 0), attendance.StatusPresent)
+	require.NoError(t, err)
+	records = append(records, absent, other)
+
+	paid, err := leaverequest.New(1, 100, at(12, 0), at(14, 0))
+	require.NoError(t, err)
+	paid, err = paid.Approve(0, "")
+	require.NoError(t, err)
+	unpaid, err := leaverequest.New(1, 200, at(17, 0), at(17, 0))
+	require.NoError(t, err)
+	unpaid, err = unpaid.Approve(0, "")
+	require.NoError(t, err)
+	pending, err := leaverequest.New(1, 100, at(18, 0), at(18, 0))
+	require.NoError(t, err)
+
+	ts := timesheet.Build(
+		1,
+		2025,
+		time.March,
+		records,
+		[]leaverequest.LeaveRequest{paid, unpaid, pending},
+		func(leaveTypeID uint) bool { return leaveTypeID == 100 },
+		at(20, 0),
+	)
+
+	assert.Equal(t, 21, ts.WorkingDays)
+	assert.Equal(t, 5, ts.AttendedDays)
+	assert.InDelta(t, 45.0, ts.WorkedHours, 0.001)
+	assert.InDelta(t, 3.0, ts.PaidLeaveDays, 0.001)
+	assert.InDelta(t, 1.0, ts.UnpaidLeaveDays, 0.001)
+	// working days up to the 20th without attendance or leave: 10, 11, 18, 19, 20
+	assert.Equal(t, 5, ts.AbsentDays)
+	assert.InDelta(t, 8.0, ts.PayableDays(), 0.001)
+}
@@ -0,0 +1,143 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/assignment"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const (
+	assignmentFindQuery = `
+		SELECT id, tenant_id, employee_id, department_id, manager_id, start_date, end_date, note, created_at
+		FROM employee_assignments`
+	assignmentInsertQuery = `
+		INSERT INTO employee_assignments (tenant_id, employee_id, department_id, manager_id, start_date, end_date, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	assignmentUpdateQuery = `
+		UPDATE employee_assignments
+		SET department_id = $1, manager_id = $2, start_date = $3, end_date = $4, note = $5
+		WHERE id = $6 AND tenant_id = $7`
+)
+
+type AssignmentRepository struct{}
+
+func NewAssignmentRepository() assignment.Repository {
+	return &AssignmentRepository{}
+}
+
+func (g *AssignmentRepository) GetCurrent(ctx context.Context) ([]assignment.Assignment, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	return g.queryAssignments(ctx, assignmentFindQuery+" WHERE tenant_id = $1 AND end_date IS NULL", tenantID)
+}
+
+func (g *AssignmentRepository) GetByEmployee(ctx context.Context, employeeID uint) ([]assignment.Assignment, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	return g.queryAssignments(
+		ctx,
+		assignmentFindQuery+" WHERE employee_id = $1 AND tenant_id = $2 ORDER BY start_date DESC, id DESC",
+		employeeID,
+		tenantID,
+	)
+}
+
+func (g *AssignmentRepository) Create(ctx context.Context, data assignment.Assignment) (assignment.Assignment, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBAssignment(data)
+	if err := tx.QueryRow(
+		ctx,
+		assignmentInsertQuery,
+		tenantID,
+		dbRow.EmployeeID,
+		dbRow.DepartmentID,
+		dbRow.ManagerID,
+		dbRow.StartDate,
+		dbRow.EndDate,
+		dbRow.Note,
+		dbRow.CreatedAt,
+	).Scan(&dbRow.ID); err != nil {
+		return nil, errors.Wrap(err, "failed to insert assignment")
+	}
+	dbRow.TenantID = tenantID.String()
+	return toDomainAssignment(dbRow)
+}
+
+func (g *AssignmentRepository) Update(ctx context.Context, data assignment.Assignment) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBAssignment(data)
+	_, err = tx.Exec(
+		ctx,
+		assignmentUpdateQuery,
+		dbRow.DepartmentID,
+		dbRow.ManagerID,
+		dbRow.StartDate,
+		dbRow.EndDate,
+		dbRow.Note,
+		dbRow.ID,
+		tenantID,
+	)
+	return err
+}
+
+func (g *AssignmentRepository) queryAssignments(ctx context.Context, query string, args ...interface{}) ([]assignment.Assignment, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := make([]assignment.Assignment, 0)
+	for rows.Next() {
+		var a models.EmployeeAssignment
+		if err := rows.Scan(
+			&a.ID,
+			&a.TenantID,
+			&a.EmployeeID,
+			&a.DepartmentID,
+			&a.ManagerID,
+			&a.StartDate,
+			&a.EndDate,
+			&a.Note,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainAssignment(&a)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
@@ -0,0 +1,199 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrAttendanceNotFound = errors.New("attendance not found")
+)
+
+const (
+	attendanceFindQuery = `
+		SELECT a.id,
+		       a.tenant_id,
+		       a.employee_id,
+		       a.date,
+		       a.status,
+		       a.check_in,
+		       a.check_out,
+		       a.source,
+		       a.note,
+		       a.created_at,
+		       a.updated_at
+		  FROM attendances a`
+	attendanceCountQuery  = `SELECT COUNT(*) FROM attendances a`
+	attendanceUpsertQuery = `
+		INSERT INTO attendances (
+			tenant_id, employee_id, date, status, check_in, check_out, source, note, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (employee_id, date) DO UPDATE
+		   SET status = EXCLUDED.status,
+		       check_in = EXCLUDED.check_in,
+		       check_out = EXCLUDED.check_out,
+		       source = EXCLUDED.source,
+		       note = EXCLUDED.note,
+		       updated_at = EXCLUDED.updated_at
+		RETURNING id`
+	attendanceDeleteQuery = `DELETE FROM attendances WHERE id = $1 AND tenant_id = $2`
+)
+
+type AttendanceRepository struct{}
+
+func NewAttendanceRepository() attendance.Repository {
+	return &AttendanceRepository{}
+}
+
+func (g *AttendanceRepository) buildFilters(ctx context.Context, params *attendance.FindParams) ([]string, []interface{}, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	where, args := []string{"a.tenant_id = $1"}, []interface{}{tenantID}
+	if params.EmployeeID != 0 {
+		where, args = append(where, fmt.Sprintf("a.employee_id = $%d", len(args)+1)), append(args, params.EmployeeID)
+	}
+	if !params.From.IsZero() {
+		where, args = append(where, fmt.Sprintf("a.date >= $%d", len(args)+1)), append(args, params.From)
+	}
+	if !params.To.IsZero() {
+		where, args = append(where, fmt.Sprintf("a.date <= $%d", len(args)+1)), append(args, params.To)
+	}
+	return where, args, nil
+}
+
+func (g *AttendanceRepository) Count(ctx context.Context, params *attendance.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args, err := g.buildFilters(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(attendanceCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *AttendanceRepository) GetPaginated(ctx context.Context, params *attendance.FindParams) ([]attendance.Attendance, error) {
+	where, args, err := g.buildFilters(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return g.queryAttendances(ctx, repo.Join(
+		attendanceFindQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY a.date DESC, a.employee_id",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	), args...)
+}
+
+func (g *AttendanceRepository) GetByID(ctx context.Context, id uint) (attendance.Attendance, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	records, err := g.queryAttendances(ctx, attendanceFindQuery+" WHERE a.id = $1 AND a.tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrAttendanceNotFound
+	}
+	return records[0], nil
+}
+
+func (g *AttendanceRepository) Save(ctx context.Context, data attendance.Attendance) (attendance.Attendance, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBAttendance(data)
+	var id uint
+	if err := tx.QueryRow(
+		ctx,
+		attendanceUpsertQuery,
+		tenantID,
+		dbRow.EmployeeID,
+		dbRow.Date,
+		dbRow.Status,
+		dbRow.CheckIn,
+		dbRow.CheckOut,
+		dbRow.Source,
+		dbRow.Note,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&id); err != nil {
+		return nil, errors.Wrap(err, "failed to save attendance")
+	}
+	return g.GetByID(ctx, id)
+}
+
+func (g *AttendanceRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	_, err = tx.Exec(ctx, attendanceDeleteQuery, id, tenantID)
+	return err
+}
+
+func (g *AttendanceRepository) queryAttendances(ctx context.Context, query string, args ...interface{}) ([]attendance.Attendance, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]attendance.Attendance, 0)
+	for rows.Next() {
+		var a models.Attendance
+		if err := rows.Scan(
+			&a.ID,
+			&a.TenantID,
+			&a.EmployeeID,
+			&a.Date,
+			&a.Status,
+			&a.CheckIn,
+			&a.CheckOut,
+			&a.Source,
+			&a.Note,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainAttendance(&a)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/department"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrDepartmentNotFound = errors.New("department not found")
+)
+
+const (
+	departmentFindQuery = `
+		SELECT id, tenant_id, name, description, parent_id, manager_id, created_at, updated_at
+		FROM departments`
+	departmentInsertQuery = `
+		INSERT INTO departments (tenant_id, name, description, parent_id, manager_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	departmentUpdateQuery = `
+		UPDATE departments
+		SET name = $1, description = $2, parent_id = $3, manager_id = $4, updated_at = $5
+		WHERE id = $6 AND tenant_id = $7`
+	departmentDeleteQuery = `DELETE FROM departments WHERE id = $1 AND tenant_id = $2`
+)
+
+type DepartmentRepository struct{}
+
+func NewDepartmentRepository() department.Repository {
+	return &DepartmentRepository{}
+}
+
+func (g *DepartmentRepository) GetAll(ctx context.Context) ([]department.Department, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	return g.queryDepartments(ctx, departmentFindQuery+" WHERE tenant_id = $1 ORDER BY name", tenantID)
+}
+
+func (g *DepartmentRepository) GetByID(ctx context.Context, id uint) (department.Department, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	departments, err := g.queryDepartments(ctx, departmentFindQuery+" WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(departments) == 0 {
+		return nil, ErrDepartmentNotFound
+	}
+	return departments[0], nil
+}
+
+func (g *DepartmentRepository) Create(ctx context.Context, data department.Department) (department.Department, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBDepartment(data)
+	var id uint
+	if err := tx.QueryRow(
+		ctx,
+		departmentInsertQuery,
+		tenantID,
+		dbRow.Name,
+		dbRow.Description,
+		dbRow.ParentID,
+		dbRow.ManagerID,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&id); err != nil {
+		return nil, errors.Wrap(err, "failed to insert department")
+	}
+	return g.GetByID(ctx, id)
+}
+
+func (g *DepartmentRepository) Update(ctx context.Context, data department.Department) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBDepartment(data)
+	_, err = tx.Exec(
+		ctx,
+		departmentUpdateQuery,
+		dbRow.Name,
+		dbRow.Description,
+		dbRow.ParentID,
+		dbRow.ManagerID,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+		tenantID,
+	)
+	return err
+}
+
+func (g *DepartmentRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	_, err = tx.Exec(ctx, departmentDeleteQuery, id, tenantID)
+	return err
+}
+
+func (g *DepartmentRepository) queryDepartments(ctx context.Context, query string, args ...interface{}) ([]department.Department, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	departments := make([]department.Department, 0)
+	for rows.Next() {
+		var d models.Department
+		if err := rows.Scan(
+			&d.ID,
+			&d.TenantID,
+			&d.Name,
+			&d.Description,
+			&d.ParentID,
+			&d.ManagerID,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainDepartment(&d)
+		if err != nil {
+			return nil, err
+		}
+		departments = append(departments, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return departments, nil
+}
@@ -1,12 +1,22 @@
 package persistence
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/country"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
 	coremappers "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/department"
 	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/payroll"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/assignment"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/leavetype"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/payrollrule"
 	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/position"
 	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
@@ -110,3 +120,313 @@ func toDBPosition(position *position.Position) *models.Position {
 		UpdatedAt:   position.UpdatedAt,
 	}
 }
+
+func toDomainLeaveType(dbLeaveType *models.LeaveType) (leavetype.LeaveType, error) {
+	tenantID, err := uuid.Parse(dbLeaveType.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return leavetype.New(
+		dbLeaveType.Name,
+		dbLeaveType.AnnualAllowance,
+		leavetype.Accrual(dbLeaveType.Accrual),
+		leavetype.WithID(dbLeaveType.ID),
+		leavetype.WithTenantID(tenantID),
+		leavetype.WithDescription(dbLeaveType.Description),
+		leavetype.WithPaid(dbLeaveType.Paid),
+		leavetype.WithCarryOverLimit(dbLeaveType.CarryOverLimit),
+		leavetype.WithCreatedAt(dbLeaveType.CreatedAt),
+		leavetype.WithUpdatedAt(dbLeaveType.UpdatedAt),
+	), nil
+}
+
+func toDBLeaveType(entity leavetype.LeaveType) *models.LeaveType {
+	return &models.LeaveType{
+		ID:              entity.ID(),
+		TenantID:        entity.TenantID().String(),
+		Name:            entity.Name(),
+		Description:     entity.Description(),
+		Paid:            entity.Paid(),
+		AnnualAllowance: entity.AnnualAllowance(),
+		Accrual:         string(entity.Accrual()),
+		CarryOverLimit:  entity.CarryOverLimit(),
+		CreatedAt:       entity.CreatedAt(),
+		UpdatedAt:       entity.UpdatedAt(),
+	}
+}
+
+func toDomainLeaveRequest(dbRequest *models.LeaveRequest) (leaverequest.LeaveRequest, error) {
+	tenantID, err := uuid.Parse(dbRequest.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	opts := []leaverequest.Option{
+		leaverequest.WithID(dbRequest.ID),
+		leaverequest.WithTenantID(tenantID),
+		leaverequest.WithDays(dbRequest.Days),
+		leaverequest.WithReason(dbRequest.Reason),
+		leaverequest.WithStatus(leaverequest.Status(dbRequest.Status)),
+		leaverequest.WithRequestedBy(uint(dbRequest.RequestedByID.Int32)),
+		leaverequest.WithCreatedAt(dbRequest.CreatedAt),
+		leaverequest.WithUpdatedAt(dbRequest.UpdatedAt),
+	}
+	if dbRequest.ReviewedAt.Valid {
+		opts = append(opts, leaverequest.WithReview(
+			uint(dbRequest.ReviewedByID.Int32),
+			dbRequest.ReviewComment,
+			dbRequest.ReviewedAt.Time,
+		))
+	}
+	return leaverequest.New(
+		dbRequest.EmployeeID,
+		dbRequest.LeaveTypeID,
+		dbRequest.StartDate,
+		dbRequest.EndDate,
+		opts...,
+	)
+}
+
+func toDBLeaveRequest(entity leaverequest.LeaveRequest) *models.LeaveRequest {
+	return &models.LeaveRequest{
+		ID:            entity.ID(),
+		TenantID:      entity.TenantID().String(),
+		EmployeeID:    entity.EmployeeID(),
+		LeaveTypeID:   entity.LeaveTypeID(),
+		StartDate:     entity.StartDate(),
+		EndDate:       entity.EndDate(),
+		Days:          entity.Days(),
+		Reason:        entity.Reason(),
+		Status:        string(entity.Status()),
+		RequestedByID: mapping.ValueToSQLNullInt32(int32(entity.RequestedBy())),
+		ReviewedByID:  mapping.ValueToSQLNullInt32(int32(entity.ReviewedBy())),
+		ReviewedAt:    mapping.PointerToSQLNullTime(entity.ReviewedAt()),
+		ReviewComment: entity.ReviewComment(),
+		CreatedAt:     entity.CreatedAt(),
+		UpdatedAt:     entity.UpdatedAt(),
+	}
+}
+
+func toDomainAttendance(dbAttendance *models.Attendance) (attendance.Attendance, error) {
+	tenantID, err := uuid.Parse(dbAttendance.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	opts := []attendance.Option{
+		attendance.WithID(dbAttendance.ID),
+		attendance.WithTenantID(tenantID),
+		attendance.WithSource(attendance.Source(dbAttendance.Source)),
+		attendance.WithNote(dbAttendance.Note),
+		attendance.WithCreatedAt(dbAttendance.CreatedAt),
+		attendance.WithUpdatedAt(dbAttendance.UpdatedAt),
+	}
+	if dbAttendance.CheckIn.Valid {
+		opts = append(opts, attendance.WithCheckIn(dbAttendance.CheckIn.Time))
+	}
+	if dbAttendance.CheckOut.Valid {
+		opts = append(opts, attendance.WithCheckOut(dbAttendance.CheckOut.Time))
+	}
+	return attendance.New(
+		dbAttendance.EmployeeID,
+		dbAttendance.Date,
+		attendance.Status(dbAttendance.Status),
+		opts...,
+	)
+}
+
+func toDBAttendance(entity attendance.Attendance) *models.Attendance {
+	return &models.Attendance{
+		ID:         entity.ID(),
+		TenantID:   entity.TenantID().String(),
+		EmployeeID: entity.EmployeeID(),
+		Date:       entity.Date(),
+		Status:     string(entity.Status()),
+		CheckIn:    mapping.PointerToSQLNullTime(entity.CheckIn()),
+		CheckOut:   mapping.PointerToSQLNullTime(entity.CheckOut()),
+		Source:     string(entity.Source()),
+		Note:       entity.Note(),
+		CreatedAt:  entity.CreatedAt(),
+		UpdatedAt:  entity.UpdatedAt(),
+	}
+}
+
+func toDomainPayrollRule(dbRule *models.PayrollRule) (payrollrule.PayrollRule, error) {
+	tenantID, err := uuid.Parse(dbRule.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return payrollrule.New(
+		dbRule.Name,
+		payrollrule.Kind(dbRule.Kind),
+		payrollrule.Method(dbRule.Method),
+		dbRule.Value,
+		payrollrule.WithID(dbRule.ID),
+		payrollrule.WithTenantID(tenantID),
+		payrollrule.WithPreTax(dbRule.PreTax),
+		payrollrule.WithEnabled(dbRule.Enabled),
+		payrollrule.WithPosition(dbRule.Position),
+		payrollrule.WithCreatedAt(dbRule.CreatedAt),
+		payrollrule.WithUpdatedAt(dbRule.UpdatedAt),
+	), nil
+}
+
+func toDBPayrollRule(entity payrollrule.PayrollRule) *models.PayrollRule {
+	return &models.PayrollRule{
+		ID:        entity.ID(),
+		TenantID:  entity.TenantID().String(),
+		Name:      entity.Name(),
+		Kind:      string(entity.Kind()),
+		Method:    string(entity.Method()),
+		Value:     entity.Value(),
+		PreTax:    entity.PreTax(),
+		Enabled:   entity.Enabled(),
+		Position:  entity.Position(),
+		CreatedAt: entity.CreatedAt(),
+		UpdatedAt: entity.UpdatedAt(),
+	}
+}
+
+func toDomainPayslip(dbPayslip *models.Payslip) (payroll.Payslip, error) {
+	var lines []payroll.Line
+	if err := json.Unmarshal(dbPayslip.Lines, &lines); err != nil {
+		return payroll.Payslip{}, err
+	}
+	return payroll.Payslip{
+		ID:          dbPayslip.ID,
+		EmployeeID:  dbPayslip.EmployeeID,
+		Currency:    dbPayslip.CurrencyID,
+		BaseSalary:  dbPayslip.BaseSalary,
+		WorkingDays: dbPayslip.WorkingDays,
+		PayableDays: dbPayslip.PayableDays,
+		Earned:      dbPayslip.Earned,
+		Bonus:       dbPayslip.Bonus,
+		Lines:       lines,
+		Gross:       dbPayslip.Gross,
+		Taxable:     dbPayslip.Taxable,
+		Deductions:  dbPayslip.Deductions,
+		Taxes:       dbPayslip.Taxes,
+		Net:         dbPayslip.Net,
+	}, nil
+}
+
+func toDBPayslip(runID uint, p payroll.Payslip) (*models.Payslip, error) {
+	lines := p.Lines
+	if lines == nil {
+		lines = []payroll.Line{}
+	}
+	data, err := json.Marshal(lines)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Payslip{
+		ID:           p.ID,
+		PayrollRunID: runID,
+		EmployeeID:   p.EmployeeID,
+		CurrencyID:   p.Currency,
+		BaseSalary:   p.BaseSalary,
+		WorkingDays:  p.WorkingDays,
+		PayableDays:  p.PayableDays,
+		Earned:       p.Earned,
+		Bonus:        p.Bonus,
+		Gross:        p.Gross,
+		Taxable:      p.Taxable,
+		Deductions:   p.Deductions,
+		Taxes:        p.Taxes,
+		Net:          p.Net,
+		Lines:        data,
+	}, nil
+}
+
+func toDomainPayrollRun(dbRun *models.PayrollRun, payslips []payroll.Payslip) (payroll.Run, error) {
+	tenantID, err := uuid.Parse(dbRun.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return payroll.New(
+		dbRun.Year,
+		time.Month(dbRun.Month),
+		payslips,
+		payroll.WithID(dbRun.ID),
+		payroll.WithTenantID(tenantID),
+		payroll.WithStatus(payroll.Status(dbRun.Status)),
+		payroll.WithCreatedBy(uint(dbRun.CreatedByID.Int32)),
+		payroll.WithPostedAt(mapping.SQLNullTimeToPointer(dbRun.PostedAt)),
+		payroll.WithCreatedAt(dbRun.CreatedAt),
+		payroll.WithUpdatedAt(dbRun.UpdatedAt),
+	), nil
+}
+
+func toDBPayrollRun(entity payroll.Run) *models.PayrollRun {
+	return &models.PayrollRun{
+		ID:          entity.ID(),
+		TenantID:    entity.TenantID().String(),
+		Year:        entity.Year(),
+		Month:       int(entity.Month()),
+		Status:      string(entity.Status()),
+		CreatedByID: mapping.ValueToSQLNullInt32(int32(entity.CreatedBy())),
+		PostedAt:    mapping.PointerToSQLNullTime(entity.PostedAt()),
+		CreatedAt:   entity.CreatedAt(),
+		UpdatedAt:   entity.UpdatedAt(),
+	}
+}
+
+func toDomainDepartment(dbDepartment *models.Department) (department.Department, error) {
+	tenantID, err := uuid.Parse(dbDepartment.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return department.New(
+		dbDepartment.Name,
+		department.WithID(dbDepartment.ID),
+		department.WithTenantID(tenantID),
+		department.WithDescription(dbDepartment.Description.String),
+		department.WithParentID(uint(dbDepartment.ParentID.Int32)),
+		department.WithManagerID(uint(dbDepartment.ManagerID.Int32)),
+		department.WithCreatedAt(dbDepartment.CreatedAt),
+		department.WithUpdatedAt(dbDepartment.UpdatedAt),
+	), nil
+}
+
+func toDBDepartment(entity department.Department) *models.Department {
+	return &models.Department{
+		ID:          entity.ID(),
+		TenantID:    entity.TenantID().String(),
+		Name:        entity.Name(),
+		Description: mapping.ValueToSQLNullString(entity.Description()),
+		ParentID:    mapping.ValueToSQLNullInt32(int32(entity.ParentID())),
+		ManagerID:   mapping.ValueToSQLNullInt32(int32(entity.ManagerID())),
+		CreatedAt:   entity.CreatedAt(),
+		UpdatedAt:   entity.UpdatedAt(),
+	}
+}
+
+func toDomainAssignment(dbAssignment *models.EmployeeAssignment) (assignment.Assignment, error) {
+	tenantID, err := uuid.Parse(dbAssignment.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return assignment.New(
+		dbAssignment.EmployeeID,
+		dbAssignment.StartDate,
+		assignment.WithID(dbAssignment.ID),
+		assignment.WithTenantID(tenantID),
+		assignment.WithDepartmentID(uint(dbAssignment.DepartmentID.Int32)),
+		assignment.WithManagerID(uint(dbAssignment.ManagerID.Int32)),
+		assignment.WithEndDate(mapping.SQLNullTimeToPointer(dbAssignment.EndDate)),
+		assignment.WithNote(dbAssignment.Note.String),
+		assignment.WithCreatedAt(dbAssignment.CreatedAt),
+	), nil
+}
+
+func toDBAssignment(entity assignment.Assignment) *models.EmployeeAssignment {
+	return &models.EmployeeAssignment{
+		ID:           entity.ID(),
+		TenantID:     entity.TenantID().String(),
+		EmployeeID:   entity.EmployeeID(),
+		DepartmentID: mapping.ValueToSQLNullInt32(int32(entity.DepartmentID())),
+		ManagerID:    mapping.ValueToSQLNullInt32(int32(entity.ManagerID())),
+		StartDate:    entity.StartDate(),
+		EndDate:      mapping.PointerToSQLNullTime(entity.EndDate()),
+		Note:         mapping.ValueToSQLNullString(entity.Note()),
+		CreatedAt:    entity.CreatedAt(),
+	}
+}
@@ -0,0 +1,225 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrLeaveRequestNotFound = errors.New("leave request not found")
+)
+
+const (
+	leaveRequestFindQuery = `
+		SELECT lr.id,
+		       lr.tenant_id,
+		       lr.employee_id,
+		       lr.leave_type_id,
+		       lr.start_date,
+		       lr.end_date,
+		       lr.days,
+		       lr.reason,
+		       lr.status,
+		       lr.requested_by_id,
+		       lr.reviewed_by_id,
+		       lr.reviewed_at,
+		       lr.review_comment,
+		       lr.created_at,
+		       lr.updated_at
+		  FROM leave_requests lr`
+	leaveRequestCountQuery  = `SELECT COUNT(*) FROM leave_requests lr`
+	leaveRequestInsertQuery = `
+		INSERT INTO leave_requests (
+			tenant_id, employee_id, leave_type_id, start_date, end_date, days, reason, status,
+			requested_by_id, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`
+	leaveRequestUpdateQuery = `
+		UPDATE leave_requests
+		   SET status = $1, reviewed_by_id = $2, reviewed_at = $3, review_comment = $4, updated_at = $5
+		 WHERE id = $6 AND tenant_id = $7`
+)
+
+type LeaveRequestRepository struct{}
+
+func NewLeaveRequestRepository() leaverequest.Repository {
+	return &LeaveRequestRepository{}
+}
+
+func (g *LeaveRequestRepository) buildFilters(ctx context.Context, params *leaverequest.FindParams) ([]string, []interface{}, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	where, args := []string{"lr.tenant_id = $1"}, []interface{}{tenantID}
+	if params.EmployeeID != 0 {
+		where, args = append(where, fmt.Sprintf("lr.employee_id = $%d", len(args)+1)), append(args, params.EmployeeID)
+	}
+	if params.LeaveTypeID != 0 {
+		where, args = append(where, fmt.Sprintf("lr.leave_type_id = $%d", len(args)+1)), append(args, params.LeaveTypeID)
+	}
+	if len(params.Statuses) > 0 {
+		statuses := make([]string, 0, len(params.Statuses))
+		for _, s := range params.Statuses {
+			statuses = append(statuses, string(s))
+		}
+		where, args = append(where, fmt.Sprintf("lr.status = ANY($%d)", len(args)+1)), append(args, statuses)
+	}
+	if !params.To.IsZero() {
+		where, args = append(where, fmt.Sprintf("lr.start_date <= $%d", len(args)+1)), append(args, params.To)
+	}
+	if !params.From.IsZero() {
+		where, args = append(where, fmt.Sprintf("lr.end_date >= $%d", len(args)+1)), append(args, params.From)
+	}
+	return where, args, nil
+}
+
+func (g *LeaveRequestRepository) Count(ctx context.Context, params *leaverequest.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args, err := g.buildFilters(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(leaveRequestCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *LeaveRequestRepository) GetPaginated(ctx context.Context, params *leaverequest.FindParams) ([]leaverequest.LeaveRequest, error) {
+	where, args, err := g.buildFilters(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return g.queryLeaveRequests(ctx, repo.Join(
+		leaveRequestFindQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY lr.start_date DESC, lr.id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	), args...)
+}
+
+func (g *LeaveRequestRepository) GetByID(ctx context.Context, id uint) (leaverequest.LeaveRequest, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	requests, err := g.queryLeaveRequests(ctx, leaveRequestFindQuery+" WHERE lr.id = $1 AND lr.tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, ErrLeaveRequestNotFound
+	}
+	return requests[0], nil
+}
+
+func (g *LeaveRequestRepository) Create(ctx context.Context, data leaverequest.LeaveRequest) (leaverequest.LeaveRequest, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBLeaveRequest(data)
+	var id uint
+	if err := tx.QueryRow(
+		ctx,
+		leaveRequestInsertQuery,
+		tenantID,
+		dbRow.EmployeeID,
+		dbRow.LeaveTypeID,
+		dbRow.StartDate,
+		dbRow.EndDate,
+		dbRow.Days,
+		dbRow.Reason,
+		dbRow.Status,
+		dbRow.RequestedByID,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&id); err != nil {
+		return nil, errors.Wrap(err, "failed to insert leave request")
+	}
+	return g.GetByID(ctx, id)
+}
+
+func (g *LeaveRequestRepository) Update(ctx context.Context, data leaverequest.LeaveRequest) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBLeaveRequest(data)
+	_, err = tx.Exec(
+		ctx,
+		leaveRequestUpdateQuery,
+		dbRow.Status,
+		dbRow.ReviewedByID,
+		dbRow.ReviewedAt,
+		dbRow.ReviewComment,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+		tenantID,
+	)
+	return err
+}
+
+func (g *LeaveRequestRepository) queryLeaveRequests(ctx context.Context, query string, args ...interface{}) ([]leaverequest.LeaveRequest, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make([]leaverequest.LeaveRequest, 0)
+	for rows.Next() {
+		var r models.LeaveRequest
+		if err := rows.Scan(
+			&r.ID,
+			&r.TenantID,
+			&r.EmployeeID,
+			&r.LeaveTypeID,
+			&r.StartDate,
+			&r.EndDate,
+			&r.Days,
+			&r.Reason,
+			&r.Status,
+			&r.RequestedByID,
+			&r.ReviewedByID,
+			&r.ReviewedAt,
+			&r.ReviewComment,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainLeaveRequest(&r)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
@@ -0,0 +1,137 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/leavetype"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrLeaveTypeNotFound = errors.New("leave type not found")
+)
+
+const (
+	leaveTypeFindQuery = `
+		SELECT id, tenant_id, name, description, paid, annual_allowance, accrual, carry_over_limit, created_at, updated_at
+		FROM leave_types`
+	leaveTypeInsertQuery = `
+		INSERT INTO leave_types (tenant_id, name, description, paid, annual_allowance, accrual, carry_over_limit, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	leaveTypeDeleteQuery = `DELETE FROM leave_types WHERE id = $1 AND tenant_id = $2`
+)
+
+type LeaveTypeRepository struct{}
+
+func NewLeaveTypeRepository() leavetype.Repository {
+	return &LeaveTypeRepository{}
+}
+
+func (g *LeaveTypeRepository) GetAll(ctx context.Context) ([]leavetype.LeaveType, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	return g.queryLeaveTypes(ctx, leaveTypeFindQuery+" WHERE tenant_id = $1 ORDER BY name", tenantID)
+}
+
+func (g *LeaveTypeRepository) GetByID(ctx context.Context, id uint) (leavetype.LeaveType, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	leaveTypes, err := g.queryLeaveTypes(ctx, leaveTypeFindQuery+" WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(leaveTypes) == 0 {
+		return nil, ErrLeaveTypeNotFound
+	}
+	return leaveTypes[0], nil
+}
+
+func (g *LeaveTypeRepository) Create(ctx context.Context, data leavetype.LeaveType) (leavetype.LeaveType, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBLeaveType(data)
+	var id uint
+	if err := tx.QueryRow(
+		ctx,
+		leaveTypeInsertQuery,
+		tenantID,
+		dbRow.Name,
+		dbRow.Description,
+		dbRow.Paid,
+		dbRow.AnnualAllowance,
+		dbRow.Accrual,
+		dbRow.CarryOverLimit,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&id); err != nil {
+		return nil, errors.Wrap(err, "failed to insert leave type")
+	}
+	return g.GetByID(ctx, id)
+}
+
+func (g *LeaveTypeRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	_, err = tx.Exec(ctx, leaveTypeDeleteQuery, id, tenantID)
+	return err
+}
+
+func (g *LeaveTypeRepository) queryLeaveTypes(ctx context.Context, query string, args ...interface{}) ([]leavetype.LeaveType, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	leaveTypes := make([]leavetype.LeaveType, 0)
+	for rows.Next() {
+		var t models.LeaveType
+		if err := rows.Scan(
+			&t.ID,
+			&t.TenantID,
+			&t.Name,
+			&t.Description,
+			&t.Paid,
+			&t.AnnualAllowance,
+			&t.Accrual,
+			&t.CarryOverLimit,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainLeaveType(&t)
+		if err != nil {
+			return nil, err
+		}
+		leaveTypes = append(leaveTypes, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return leaveTypes, nil
+}
@@ -46,3 +46,115 @@ type EmployeePosition struct {
 	EmployeeID uint
 	PositionID uint
 }
+
+type LeaveType struct {
+	ID              uint
+	TenantID        string
+	Name            string
+	Description     string
+	Paid            bool
+	AnnualAllowance float64
+	Accrual         string
+	CarryOverLimit  float64
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type LeaveRequest struct {
+	ID            uint
+	TenantID      string
+	EmployeeID    uint
+	LeaveTypeID   uint
+	StartDate     time.Time
+	EndDate       time.Time
+	Days          float64
+	Reason        string
+	Status        string
+	RequestedByID sql.NullInt32
+	ReviewedByID  sql.NullInt32
+	ReviewedAt    sql.NullTime
+	ReviewComment string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type Attendance struct {
+	ID         uint
+	TenantID   string
+	EmployeeID uint
+	Date       time.Time
+	Status     string
+	CheckIn    sql.NullTime
+	CheckOut   sql.NullTime
+	Source     string
+	Note       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type PayrollRule struct {
+	ID        uint
+	TenantID  string
+	Name      string
+	Kind      string
+	Method    string
+	Value     float64
+	PreTax    bool
+	Enabled   bool
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type PayrollRun struct {
+	ID          uint
+	TenantID    string
+	Year        int
+	Month       int
+	Status      string
+	CreatedByID sql.NullInt32
+	PostedAt    sql.NullTime
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Payslip struct {
+	ID           uint
+	PayrollRunID uint
+	EmployeeID   uint
+	CurrencyID   string
+	BaseSalary   int64
+	WorkingDays  int
+	PayableDays  float64
+	Earned       int64
+	Bonus        int64
+	Gross        int64
+	Taxable      int64
+	Deductions   int64
+	Taxes        int64
+	Net          int64
+	Lines        []byte
+}
+
+type Department struct {
+	ID          uint
+	TenantID    string
+	Name        string
+	Description sql.NullString
+	ParentID    sql.NullInt32
+	ManagerID   sql.NullInt32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type EmployeeAssignment struct {
+	ID           uint
+	TenantID     string
+	EmployeeID   uint
+	DepartmentID sql.NullInt32
+	ManagerID    sql.NullInt32
+	StartDate    time.Time
+	EndDate      sql.NullTime
+	Note         sql.NullString
+	CreatedAt    time.Time
+}
@@ -0,0 +1,170 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/payrollrule"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrPayrollRuleNotFound = errors.New("payroll rule not found")
+)
+
+const (
+	payrollRuleFindQuery = `
+		SELECT id, tenant_id, name, kind, method, value, pre_tax, enabled, position, created_at, updated_at
+		FROM payroll_rules`
+	payrollRuleInsertQuery = `
+		INSERT INTO payroll_rules (tenant_id, name, kind, method, value, pre_tax, enabled, position, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+	payrollRuleUpdateQuery = `
+		UPDATE payroll_rules
+		SET name = $1, kind = $2, method = $3, value = $4, pre_tax = $5, enabled = $6, position = $7, updated_at = $8
+		WHERE id = $9 AND tenant_id = $10`
+	payrollRuleDeleteQuery = `DELETE FROM payroll_rules WHERE id = $1 AND tenant_id = $2`
+)
+
+type PayrollRuleRepository struct{}
+
+func NewPayrollRuleRepository() payrollrule.Repository {
+	return &PayrollRuleRepository{}
+}
+
+func (g *PayrollRuleRepository) GetAll(ctx context.Context) ([]payrollrule.PayrollRule, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	return g.queryPayrollRules(ctx, payrollRuleFindQuery+" WHERE tenant_id = $1 ORDER BY kind, position, name", tenantID)
+}
+
+func (g *PayrollRuleRepository) GetByID(ctx context.Context, id uint) (payrollrule.PayrollRule, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	rules, err := g.queryPayrollRules(ctx, payrollRuleFindQuery+" WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, ErrPayrollRuleNotFound
+	}
+	return rules[0], nil
+}
+
+func (g *PayrollRuleRepository) Create(ctx context.Context, data payrollrule.PayrollRule) (payrollrule.PayrollRule, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBPayrollRule(data)
+	var id uint
+	if err := tx.QueryRow(
+		ctx,
+		payrollRuleInsertQuery,
+		tenantID,
+		dbRow.Name,
+		dbRow.Kind,
+		dbRow.Method,
+		dbRow.Value,
+		dbRow.PreTax,
+		dbRow.Enabled,
+		dbRow.Position,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&id); err != nil {
+		return nil, errors.Wrap(err, "failed to insert payroll rule")
+	}
+	return g.GetByID(ctx, id)
+}
+
+func (g *PayrollRuleRepository) Update(ctx context.Context, data payrollrule.PayrollRule) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBPayrollRule(data)
+	_, err = tx.Exec(
+		ctx,
+		payrollRuleUpdateQuery,
+		dbRow.Name,
+		dbRow.Kind,
+		dbRow.Method,
+		dbRow.Value,
+		dbRow.PreTax,
+		dbRow.Enabled,
+		dbRow.Position,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+		tenantID,
+	)
+	return err
+}
+
+func (g *PayrollRuleRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	_, err = tx.Exec(ctx, payrollRuleDeleteQuery, id, tenantID)
+	return err
+}
+
+func (g *PayrollRuleRepository) queryPayrollRules(ctx context.Context, query string, args ...interface{}) ([]payrollrule.PayrollRule, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]payrollrule.PayrollRule, 0)
+	for rows.Next() {
+		var r models.PayrollRule
+		if err := rows.Scan(
+			&r.ID,
+			&r.TenantID,
+			&r.Name,
+			&r.Kind,
+			&r.Method,
+			&r.Value,
+			&r.PreTax,
+			&r.Enabled,
+			&r.Position,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainPayrollRule(&r)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,322 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/payroll"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrPayrollRunNotFound = errors.New("payroll run not found")
+)
+
+const (
+	payrollRunFindQuery = `
+		SELECT id, tenant_id, year, month, status, created_by_id, posted_at, created_at, updated_at
+		FROM payroll_runs`
+	payrollRunCountQuery  = `SELECT COUNT(*) FROM payroll_runs WHERE tenant_id = $1`
+	payrollRunInsertQuery = `
+		INSERT INTO payroll_runs (tenant_id, year, month, status, created_by_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	payrollRunUpdateQuery = `
+		UPDATE payroll_runs
+		   SET status = $1, posted_at = $2, updated_at = $3
+		 WHERE id = $4 AND tenant_id = $5`
+	payrollRunDeleteQuery = `DELETE FROM payroll_runs WHERE id = $1 AND tenant_id = $2`
+
+	payslipFindQuery = `
+		SELECT id, payroll_run_id, employee_id, currency_id, base_salary, working_days, payable_days,
+		       earned, bonus, gross, taxable, deductions, taxes, net, lines
+		FROM payslips
+		WHERE payroll_run_id = ANY($1)
+		ORDER BY id`
+	payslipInsertQuery = `
+		INSERT INTO payslips (
+			payroll_run_id, employee_id, currency_id, base_salary, working_days, payable_days,
+			earned, bonus, gross, taxable, deductions, taxes, net, lines
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	payslipDeleteQuery = `DELETE FROM payslips WHERE payroll_run_id = $1`
+)
+
+type PayrollRunRepository struct{}
+
+func NewPayrollRunRepository() payroll.Repository {
+	return &PayrollRunRepository{}
+}
+
+func (g *PayrollRunRepository) Count(ctx context.Context) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, payrollRunCountQuery, tenantID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *PayrollRunRepository) GetPaginated(ctx context.Context, params *payroll.FindParams) ([]payroll.Run, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	return g.queryPayrollRuns(ctx, repo.Join(
+		payrollRunFindQuery,
+		"WHERE tenant_id = $1",
+		"ORDER BY year DESC, month DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	), tenantID)
+}
+
+func (g *PayrollRunRepository) GetByID(ctx context.Context, id uint) (payroll.Run, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	runs, err := g.queryPayrollRuns(ctx, payrollRunFindQuery+" WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, ErrPayrollRunNotFound
+	}
+	return runs[0], nil
+}
+
+func (g *PayrollRunRepository) GetByPeriod(ctx context.Context, year int, month time.Month) (payroll.Run, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	runs, err := g.queryPayrollRuns(
+		ctx,
+		payrollRunFindQuery+" WHERE year = $1 AND month = $2 AND tenant_id = $3",
+		year,
+		int(month),
+		tenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, ErrPayrollRunNotFound
+	}
+	return runs[0], nil
+}
+
+func (g *PayrollRunRepository) Create(ctx context.Context, data payroll.Run) (payroll.Run, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBPayrollRun(data)
+	var id uint
+	if err := tx.QueryRow(
+		ctx,
+		payrollRunInsertQuery,
+		tenantID,
+		dbRow.Year,
+		dbRow.Month,
+		dbRow.Status,
+		dbRow.CreatedByID,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&id); err != nil {
+		return nil, errors.Wrap(err, "failed to insert payroll run")
+	}
+	if err := g.insertPayslips(ctx, id, data.Payslips()); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, id)
+}
+
+func (g *PayrollRunRepository) Update(ctx context.Context, data payroll.Run) (payroll.Run, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	dbRow := toDBPayrollRun(data)
+	if _, err := tx.Exec(
+		ctx,
+		payrollRunUpdateQuery,
+		dbRow.Status,
+		dbRow.PostedAt,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+		tenantID,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to update payroll run")
+	}
+	if _, err := tx.Exec(ctx, payslipDeleteQuery, dbRow.ID); err != nil {
+		return nil, errors.Wrap(err, "failed to delete payslips")
+	}
+	if err := g.insertPayslips(ctx, dbRow.ID, data.Payslips()); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, dbRow.ID)
+}
+
+func (g *PayrollRunRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant from context: %w", err)
+	}
+	_, err = tx.Exec(ctx, payrollRunDeleteQuery, id, tenantID)
+	return err
+}
+
+func (g *PayrollRunRepository) insertPayslips(ctx context.Context, runID uint, payslips []payroll.Payslip) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range payslips {
+		dbRow, err := toDBPayslip(runID, p)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(
+			ctx,
+			payslipInsertQuery,
+			dbRow.PayrollRunID,
+			dbRow.EmployeeID,
+			dbRow.CurrencyID,
+			dbRow.BaseSalary,
+			dbRow.WorkingDays,
+			dbRow.PayableDays,
+			dbRow.Earned,
+			dbRow.Bonus,
+			dbRow.Gross,
+			dbRow.Taxable,
+			dbRow.Deductions,
+			dbRow.Taxes,
+			dbRow.Net,
+			dbRow.Lines,
+		); err != nil {
+			return errors.Wrap(err, "failed to insert payslip")
+		}
+	}
+	return nil
+}
+
+func (g *PayrollRunRepository) queryPayrollRuns(ctx context.Context, query string, args ...interface{}) ([]payroll.Run, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dbRuns := make([]models.PayrollRun, 0)
+	for rows.Next() {
+		var r models.PayrollRun
+		if err := rows.Scan(
+			&r.ID,
+			&r.TenantID,
+			&r.Year,
+			&r.Month,
+			&r.Status,
+			&r.CreatedByID,
+			&r.PostedAt,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbRuns = append(dbRuns, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	ids := make([]uint, 0, len(dbRuns))
+	for _, r := range dbRuns {
+		ids = append(ids, r.ID)
+	}
+	payslips, err := g.queryPayslips(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]payroll.Run, 0, len(dbRuns))
+	for i := range dbRuns {
+		entity, err := toDomainPayrollRun(&dbRuns[i], payslips[dbRuns[i].ID])
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, entity)
+	}
+	return runs, nil
+}
+
+func (g *PayrollRunRepository) queryPayslips(ctx context.Context, runIDs []uint) (map[uint][]payroll.Payslip, error) {
+	result := make(map[uint][]payroll.Payslip, len(runIDs))
+	if len(runIDs) == 0 {
+		return result, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, payslipFindQuery, runIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p models.Payslip
+		if err := rows.Scan(
+			&p.ID,
+			&p.PayrollRunID,
+			&p.EmployeeID,
+			&p.CurrencyID,
+			&p.BaseSalary,
+			&p.WorkingDays,
+			&p.PayableDays,
+			&p.Earned,
+			&p.Bonus,
+			&p.Gross,
+			&p.Taxable,
+			&p.Deductions,
+			&p.Taxes,
+			&p.Net,
+			&p.Lines,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainPayslip(&p)
+		if err != nil {
+			return nil, err
+		}
+		result[p.PayrollRunID] = append(result[p.PayrollRunID], entity)
+	}
+	return result, rows.Err()
+}
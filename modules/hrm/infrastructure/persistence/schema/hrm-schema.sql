@@ -54,6 +54,126 @@ CREATE TABLE employee_contacts (
     updated_at timestamp with time zone DEFAULT now()
 );
 
+CREATE TABLE leave_types (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    paid boolean NOT NULL DEFAULT TRUE,
+    annual_allowance numeric(6, 2) NOT NULL DEFAULT 0,
+    accrual varchar(10) NOT NULL CHECK (accrual IN ('none', 'yearly', 'monthly')),
+    carry_over_limit numeric(6, 2) NOT NULL DEFAULT 0,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE leave_requests (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    employee_id int NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    leave_type_id int NOT NULL REFERENCES leave_types (id) ON DELETE RESTRICT,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    days numeric(6, 2) NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    status varchar(20) NOT NULL CHECK (status IN ('pending', 'approved', 'rejected', 'canceled')),
+    requested_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    reviewed_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    reviewed_at timestamp with time zone,
+    review_comment text NOT NULL DEFAULT '',
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    CHECK (end_date >= start_date)
+);
+
+CREATE TABLE attendances (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    employee_id int NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    date date NOT NULL,
+    status varchar(20) NOT NULL CHECK (status IN ('present', 'remote', 'absent')),
+    check_in timestamp with time zone,
+    check_out timestamp with time zone,
+    source varchar(20) NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'import')),
+    note text NOT NULL DEFAULT '',
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (employee_id, date)
+);
+
+CREATE TABLE payroll_rules (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    kind varchar(20) NOT NULL CHECK (kind IN ('bonus', 'deduction', 'tax')),
+    method varchar(20) NOT NULL CHECK (method IN ('percent', 'fixed')),
+    value numeric(18, 4) NOT NULL DEFAULT 0,
+    pre_tax boolean NOT NULL DEFAULT FALSE,
+    enabled boolean NOT NULL DEFAULT TRUE,
+    position int NOT NULL DEFAULT 0,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE payroll_runs (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    year int NOT NULL,
+    month int NOT NULL CHECK (month BETWEEN 1 AND 12),
+    status varchar(20) NOT NULL CHECK (status IN ('draft', 'posted')),
+    created_by_id int REFERENCES users (id) ON DELETE SET NULL,
+    posted_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (tenant_id, year, month)
+);
+
+CREATE TABLE payslips (
+    id serial PRIMARY KEY,
+    payroll_run_id int NOT NULL REFERENCES payroll_runs (id) ON DELETE CASCADE,
+    employee_id int NOT NULL REFERENCES employees (id) ON DELETE RESTRICT,
+    currency_id varchar(3) NOT NULL REFERENCES currencies (code) ON DELETE RESTRICT,
+    base_salary bigint NOT NULL,
+    working_days int NOT NULL,
+    payable_days numeric(6, 2) NOT NULL,
+    earned bigint NOT NULL,
+    bonus bigint NOT NULL DEFAULT 0,
+    gross bigint NOT NULL,
+    taxable bigint NOT NULL,
+    deductions bigint NOT NULL,
+    taxes bigint NOT NULL,
+    net bigint NOT NULL,
+    lines jsonb NOT NULL DEFAULT '[]',
+    UNIQUE (payroll_run_id, employee_id)
+);
+
+CREATE TABLE departments (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    description text,
+    parent_id int REFERENCES departments (id) ON DELETE RESTRICT,
+    manager_id int REFERENCES employees (id) ON DELETE SET NULL,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE employee_assignments (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    employee_id int NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    department_id int REFERENCES departments (id) ON DELETE SET NULL,
+    manager_id int REFERENCES employees (id) ON DELETE SET NULL,
+    start_date date NOT NULL,
+    end_date date,
+    note text,
+    created_at timestamp with time zone DEFAULT now(),
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
 CREATE INDEX positions_tenant_id_idx ON positions (tenant_id);
 
 CREATE INDEX employees_tenant_id_idx ON employees (tenant_id);
@@ -66,3 +186,16 @@ CREATE INDEX employees_email_idx ON employees (email);
 
 CREATE INDEX employees_phone_idx ON employees (phone);
 
+CREATE INDEX leave_types_tenant_id_idx ON leave_types (tenant_id);
+
+CREATE INDEX leave_requests_tenant_id_idx ON leave_requests (tenant_id);
+
+CREATE INDEX leave_requests_employee_id_idx ON leave_requests (employee_id, start_date, end_date);
+
+CREATE INDEX attendances_tenant_id_date_idx ON attendances (tenant_id, date);
+
+CREATE INDEX payroll_rules_tenant_id_idx ON payroll_rules (tenant_id);
+
+CREATE INDEX departments_tenant_id_idx ON departments (tenant_id);
+
+CREATE INDEX employee_assignments_employee_id_idx ON employee_assignments (employee_id, start_date);
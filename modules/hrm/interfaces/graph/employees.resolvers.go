@@ -0,0 +1,195 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	model "github.com/iota-uz/iota-sdk/modules/hrm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/hrm/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// CreateEmployee is the resolver for the createEmployee field.
+func (r *mutationResolver) CreateEmployee(ctx context.Context, input model.CreateEmployee) (*model.Employee, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUser(ctx, permissions.EmployeeCreate); err != nil {
+		return nil, err
+	}
+	dto := &employee.CreateDTO{
+		FirstName:         input.FirstName,
+		LastName:          input.LastName,
+		MiddleName:        mapping.Value(input.MiddleName),
+		Email:             input.Email,
+		Phone:             input.Phone,
+		Salary:            input.Salary,
+		Tin:               mapping.Value(input.Tin),
+		Pin:               mapping.Value(input.Pin),
+		BirthDate:         shared.DateOnly(mapping.Value(input.BirthDate)),
+		HireDate:          shared.DateOnly(mapping.Value(input.HireDate)),
+		ResignationDate:   shared.DateOnly(mapping.Value(input.ResignationDate)),
+		PrimaryLanguage:   mapping.Value(input.PrimaryLanguage),
+		SecondaryLanguage: mapping.Value(input.SecondaryLanguage),
+		AvatarID:          uint(mapping.Value(input.AvatarID)),
+		Notes:             mapping.Value(input.Notes),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	created, err := r.employeeService.Create(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.EmployeeToGraphModel(created), nil
+}
+
+// UpdateEmployee is the resolver for the updateEmployee field.
+func (r *mutationResolver) UpdateEmployee(ctx context.Context, id int64, input model.UpdateEmployee) (*model.Employee, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUser(ctx, permissions.EmployeeUpdate); err != nil {
+		return nil, err
+	}
+	existing, err := r.employeeService.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	current := mappers.EmployeeToGraphModel(existing)
+	dto := &employee.UpdateDTO{
+		FirstName:         mapping.Or(mapping.Value(input.FirstName), current.FirstName),
+		LastName:          mapping.Or(mapping.Value(input.LastName), current.LastName),
+		MiddleName:        mapping.Or(mapping.Value(input.MiddleName), current.MiddleName),
+		Email:             mapping.Or(mapping.Value(input.Email), current.Email),
+		Phone:             mapping.Or(mapping.Value(input.Phone), current.Phone),
+		Salary:            mapping.Or(mapping.Value(input.Salary), current.Salary.Amount),
+		Tin:               mapping.Or(mapping.Value(input.Tin), current.Tin),
+		Pin:               mapping.Or(mapping.Value(input.Pin), current.Pin),
+		BirthDate:         shared.DateOnly(mapping.Or(mapping.Value(input.BirthDate), mapping.Value(current.BirthDate))),
+		HireDate:          shared.DateOnly(mapping.Or(mapping.Value(input.HireDate), current.HireDate)),
+		ResignationDate:   shared.DateOnly(mapping.Or(mapping.Value(input.ResignationDate), mapping.Value(current.ResignationDate))),
+		PrimaryLanguage:   mapping.Or(mapping.Value(input.PrimaryLanguage), current.PrimaryLanguage),
+		SecondaryLanguage: mapping.Or(mapping.Value(input.SecondaryLanguage), current.SecondaryLanguage),
+		AvatarID:          uint(mapping.Or(mapping.Value(input.AvatarID), mapping.Value(current.AvatarID))),
+		Notes:             mapping.Or(mapping.Value(input.Notes), current.Notes),
+	}
+	if errs, ok := dto.Ok(ctx); !ok {
+		graphql.AddError(ctx, serrors.ValidationGQLError(graphql.GetPath(ctx), errs))
+		return nil, nil
+	}
+	if err := r.employeeService.Update(ctx, uint(id), dto); err != nil {
+		return nil, err
+	}
+	updated, err := r.employeeService.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.EmployeeToGraphModel(updated), nil
+}
+
+// DeleteEmployee is the resolver for the deleteEmployee field.
+func (r *mutationResolver) DeleteEmployee(ctx context.Context, id int64) (*model.Employee, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUser(ctx, permissions.EmployeeDelete); err != nil {
+		return nil, err
+	}
+	deleted, err := r.employeeService.Delete(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.EmployeeToGraphModel(deleted), nil
+}
+
+// Employee is the resolver for the employee field.
+func (r *queryResolver) Employee(ctx context.Context, id int64) (*model.Employee, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUser(ctx, permissions.EmployeeRead); err != nil {
+		return nil, err
+	}
+	entity, err := r.employeeService.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.EmployeeToGraphModel(entity), nil
+}
+
+// Employees is the resolver for the employees field.
+func (r *queryResolver) Employees(ctx context.Context, offset int, limit int, filter *model.EmployeeFilter) (*model.PaginatedEmployees, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	if err := composables.CanUser(ctx, permissions.EmployeeRead); err != nil {
+		return nil, err
+	}
+	params := &employee.FindParams{
+		Offset: offset,
+		Limit:  limit,
+		SortBy: []string{"id"},
+	}
+	if filter != nil && filter.Query != nil {
+		params.Query = *filter.Query
+		params.Field = employeeSearchColumns[model.EmployeeSearchFieldFirstName]
+		if filter.Field != nil {
+			params.Field = employeeSearchColumns[*filter.Field]
+		}
+	}
+	entities, err := r.employeeService.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.employeeService.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PaginatedEmployees{
+		Data:  EmployeesToGraphModel(entities),
+		Total: total,
+	}, nil
+}
+
+// EmployeeCreated is the resolver for the employeeCreated field.
+func (r *subscriptionResolver) EmployeeCreated(ctx context.Context) (<-chan *model.Employee, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, serrors.UnauthorizedGQLError(graphql.GetPath(ctx))
+	}
+	if err := composables.CanUser(ctx, permissions.EmployeeRead); err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sdkgraphql.Subscribe(ctx, r.employeeCreated, func(e employee.Employee) (*model.Employee, bool) {
+		if e.TenantID() != tenantID {
+			return nil, false
+		}
+		return mappers.EmployeeToGraphModel(e), true
+	}), nil
+}
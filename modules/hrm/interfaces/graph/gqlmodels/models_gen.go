@@ -0,0 +1,136 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type CreateEmployee struct {
+	FirstName         string     `json:"firstName"`
+	LastName          string     `json:"lastName"`
+	MiddleName        *string    `json:"middleName,omitempty"`
+	Email             string     `json:"email"`
+	Phone             string     `json:"phone"`
+	Salary            float64    `json:"salary"`
+	Tin               *string    `json:"tin,omitempty"`
+	Pin               *string    `json:"pin,omitempty"`
+	PrimaryLanguage   *string    `json:"primaryLanguage,omitempty"`
+	SecondaryLanguage *string    `json:"secondaryLanguage,omitempty"`
+	AvatarID          *int64     `json:"avatarId,omitempty"`
+	HireDate          *time.Time `json:"hireDate,omitempty"`
+	BirthDate         *time.Time `json:"birthDate,omitempty"`
+	ResignationDate   *time.Time `json:"resignationDate,omitempty"`
+	Notes             *string    `json:"notes,omitempty"`
+}
+
+type Employee struct {
+	ID                int64      `json:"id"`
+	FirstName         string     `json:"firstName"`
+	LastName          string     `json:"lastName"`
+	MiddleName        string     `json:"middleName"`
+	Email             string     `json:"email"`
+	Phone             string     `json:"phone"`
+	Salary            *Money     `json:"salary"`
+	Tin               string     `json:"tin"`
+	Pin               string     `json:"pin"`
+	PrimaryLanguage   string     `json:"primaryLanguage"`
+	SecondaryLanguage string     `json:"secondaryLanguage"`
+	AvatarID          *int64     `json:"avatarId,omitempty"`
+	HireDate          time.Time  `json:"hireDate"`
+	BirthDate         *time.Time `json:"birthDate,omitempty"`
+	ResignationDate   *time.Time `json:"resignationDate,omitempty"`
+	Notes             string     `json:"notes"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+type EmployeeFilter struct {
+	Query *string              `json:"query,omitempty"`
+	Field *EmployeeSearchField `json:"field,omitempty"`
+}
+
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+type Mutation struct {
+}
+
+type PaginatedEmployees struct {
+	Data  []*Employee `json:"data"`
+	Total int64       `json:"total"`
+}
+
+type Query struct {
+}
+
+type Subscription struct {
+}
+
+type UpdateEmployee struct {
+	FirstName         *string    `json:"firstName,omitempty"`
+	LastName          *string    `json:"lastName,omitempty"`
+	MiddleName        *string    `json:"middleName,omitempty"`
+	Email             *string    `json:"email,omitempty"`
+	Phone             *string    `json:"phone,omitempty"`
+	Salary            *float64   `json:"salary,omitempty"`
+	Tin               *string    `json:"tin,omitempty"`
+	Pin               *string    `json:"pin,omitempty"`
+	PrimaryLanguage   *string    `json:"primaryLanguage,omitempty"`
+	SecondaryLanguage *string    `json:"secondaryLanguage,omitempty"`
+	AvatarID          *int64     `json:"avatarId,omitempty"`
+	HireDate          *time.Time `json:"hireDate,omitempty"`
+	BirthDate         *time.Time `json:"birthDate,omitempty"`
+	ResignationDate   *time.Time `json:"resignationDate,omitempty"`
+	Notes             *string    `json:"notes,omitempty"`
+}
+
+type EmployeeSearchField string
+
+const (
+	EmployeeSearchFieldFirstName EmployeeSearchField = "FIRST_NAME"
+	EmployeeSearchFieldLastName  EmployeeSearchField = "LAST_NAME"
+	EmployeeSearchFieldEmail     EmployeeSearchField = "EMAIL"
+	EmployeeSearchFieldPhone     EmployeeSearchField = "PHONE"
+)
+
+var AllEmployeeSearchField = []EmployeeSearchField{
+	EmployeeSearchFieldFirstName,
+	EmployeeSearchFieldLastName,
+	EmployeeSearchFieldEmail,
+	EmployeeSearchFieldPhone,
+}
+
+func (e EmployeeSearchField) IsValid() bool {
+	switch e {
+	case EmployeeSearchFieldFirstName, EmployeeSearchFieldLastName, EmployeeSearchFieldEmail, EmployeeSearchFieldPhone:
+		return true
+	}
+	return false
+}
+
+func (e EmployeeSearchField) String() string {
+	return string(e)
+}
+
+func (e *EmployeeSearchField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EmployeeSearchField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EmployeeSearchField", str)
+	}
+	return nil
+}
+
+func (e EmployeeSearchField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
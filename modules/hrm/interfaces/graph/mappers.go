@@ -0,0 +1,18 @@
+package graph
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	model "github.com/iota-uz/iota-sdk/modules/hrm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/hrm/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/pkg/fp"
+)
+
+var EmployeesToGraphModel = fp.Map[employee.Employee, *model.Employee](mappers.EmployeeToGraphModel)
+
+// employeeSearchColumns whitelists the columns a filter may search, FindParams.Field is spliced into SQL
+var employeeSearchColumns = map[model.EmployeeSearchField]string{
+	model.EmployeeSearchFieldFirstName: "first_name",
+	model.EmployeeSearchFieldLastName:  "last_name",
+	model.EmployeeSearchFieldEmail:     "email",
+	model.EmployeeSearchFieldPhone:     "phone",
+}
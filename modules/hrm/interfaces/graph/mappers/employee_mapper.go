@@ -0,0 +1,56 @@
+package mappers
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	model "github.com/iota-uz/iota-sdk/modules/hrm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func MoneyToGraphModel(m *money.Money) *model.Money {
+	if m == nil {
+		return &model.Money{}
+	}
+	return &model.Money{
+		Amount:   m.AsMajorUnits(),
+		Currency: m.Currency().Code,
+	}
+}
+
+func EmployeeToGraphModel(entity employee.Employee) *model.Employee {
+	var email string
+	if entity.Email() != nil {
+		email = entity.Email().Value()
+	}
+	var tin, pin string
+	if entity.Tin() != nil {
+		tin = entity.Tin().Value()
+	}
+	if entity.Pin() != nil {
+		pin = entity.Pin().Value()
+	}
+	var primaryLanguage, secondaryLanguage string
+	if lang := entity.Language(); lang != nil {
+		primaryLanguage, secondaryLanguage = lang.Primary(), lang.Secondary()
+	}
+	return &model.Employee{
+		ID:                int64(entity.ID()),
+		FirstName:         entity.FirstName(),
+		LastName:          entity.LastName(),
+		MiddleName:        entity.MiddleName(),
+		Email:             email,
+		Phone:             entity.Phone(),
+		Salary:            MoneyToGraphModel(entity.Salary()),
+		Tin:               tin,
+		Pin:               pin,
+		PrimaryLanguage:   primaryLanguage,
+		SecondaryLanguage: secondaryLanguage,
+		AvatarID:          mapping.Pointer(int64(entity.AvatarID())),
+		HireDate:          entity.HireDate(),
+		BirthDate:         mapping.Pointer(entity.BirthDate()),
+		ResignationDate:   entity.ResignationDate(),
+		Notes:             entity.Notes(),
+		CreatedAt:         entity.CreatedAt(),
+		UpdatedAt:         entity.UpdatedAt(),
+	}
+}
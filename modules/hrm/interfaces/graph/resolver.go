@@ -0,0 +1,30 @@
+package graph
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	sdkgraphql "github.com/iota-uz/iota-sdk/pkg/graphql"
+)
+
+// This file will not be regenerated automatically.
+//
+// It serves as dependency injection for your app, add any dependencies you require here.
+
+type Resolver struct {
+	app             application.Application
+	employeeService *services.EmployeeService
+	employeeCreated *sdkgraphql.Broker[employee.Employee]
+}
+
+func NewResolver(app application.Application) *Resolver {
+	r := &Resolver{
+		app:             app,
+		employeeService: app.Service(services.EmployeeService{}).(*services.EmployeeService),
+		employeeCreated: sdkgraphql.NewBroker[employee.Employee](),
+	}
+	app.EventPublisher().Subscribe(func(e *employee.CreatedEvent) {
+		r.employeeCreated.Publish(e.Result)
+	})
+	return r
+}
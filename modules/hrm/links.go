@@ -13,12 +13,60 @@ var EmployeesLink = types.NavigationItem{
 	Children: nil,
 }
 
+var LeavesLink = types.NavigationItem{
+	Name:     "NavigationLinks.Leaves",
+	Icon:     nil,
+	Href:     "/hrm/leaves",
+	Children: nil,
+}
+
+var AttendanceLink = types.NavigationItem{
+	Name:     "NavigationLinks.Attendance",
+	Icon:     nil,
+	Href:     "/hrm/attendance",
+	Children: nil,
+}
+
+var TeamCalendarLink = types.NavigationItem{
+	Name:     "NavigationLinks.TeamCalendar",
+	Icon:     nil,
+	Href:     "/hrm/attendance/calendar",
+	Children: nil,
+}
+
+var TimesheetsLink = types.NavigationItem{
+	Name:     "NavigationLinks.Timesheets",
+	Icon:     nil,
+	Href:     "/hrm/timesheets",
+	Children: nil,
+}
+
+var PayrollLink = types.NavigationItem{
+	Name:     "NavigationLinks.Payroll",
+	Icon:     nil,
+	Href:     "/hrm/payroll",
+	Children: nil,
+}
+
+var OrgChartLink = types.NavigationItem{
+	Name:     "NavigationLinks.OrgChart",
+	Icon:     nil,
+	Href:     "/hrm/org",
+	Children: nil,
+}
+
 var HRMLink = types.NavigationItem{
 	Name: "NavigationLinks.HRM",
 	Icon: icons.UsersThree(icons.Props{Size: "20"}),
 	Href: "/hrm",
 	Children: []types.NavigationItem{
 		EmployeesLink,
+		LeavesLink,
+		AttendanceLink,
+		TeamCalendarLink,
+		TimesheetsLink,
+		PayrollLink,
+		OrgChartLink,
 	},
 }
 
@@ -3,7 +3,9 @@ package hrm
 import (
 	"embed"
 
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/hrm/interfaces/graph"
 	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
 	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/hrm/services"
@@ -30,15 +32,57 @@ func (m *Module) Register(app application.Application) error {
 	)
 	app.Migrations().RegisterSchema(&MigrationFiles)
 	app.RegisterLocaleFiles(&LocaleFiles)
+	employeeRepo := persistence.NewEmployeeRepository()
+	leaveTypeRepo := persistence.NewLeaveTypeRepository()
+	leaveRequestRepo := persistence.NewLeaveRequestRepository()
+	attendanceRepo := persistence.NewAttendanceRepository()
+	orgService := services.NewOrgService(
+		persistence.NewDepartmentRepository(),
+		persistence.NewAssignmentRepository(),
+		employeeRepo,
+		corepersistence.NewUserRepository(corepersistence.NewUploadRepository()),
+		app.EventPublisher(),
+	)
+	timesheetService := services.NewTimesheetService(employeeRepo, attendanceRepo, leaveRequestRepo, leaveTypeRepo)
 	app.RegisterServices(
 		services.NewPositionService(persistence.NewPositionRepository(), app.EventPublisher()),
-		services.NewEmployeeService(persistence.NewEmployeeRepository(), app.EventPublisher()),
+		services.NewEmployeeService(employeeRepo, app.EventPublisher()),
+		services.NewLeaveService(leaveTypeRepo, leaveRequestRepo, employeeRepo, orgService, app.EventPublisher()),
+		services.NewAttendanceService(attendanceRepo, employeeRepo, app.EventPublisher()),
+		timesheetService,
+		services.NewPayrollService(
+			persistence.NewPayrollRunRepository(),
+			persistence.NewPayrollRuleRepository(),
+			employeeRepo,
+			timesheetService,
+			app,
+		),
+		orgService,
 	)
+	// Policies can refer to user.department_id and user.employee_id
+	app.RegisterUserAttributes(orgService.UserAttributes)
 	app.RegisterControllers(
 		controllers.NewEmployeeController(app),
+		controllers.NewLeaveController(app),
+		controllers.NewAttendanceController(app),
+		controllers.NewTimesheetController(app),
+		controllers.NewPayrollController(app),
+		controllers.NewOrgController(app),
 	)
+	app.RegisterGraphSchema(application.GraphSchema{
+		Value: graph.NewExecutableSchema(graph.Config{
+			Resolvers: graph.NewResolver(app),
+		}),
+		BasePath: "/hrm",
+	})
 	app.QuickLinks().Add(
 		spotlight.NewQuickLink(nil, EmployeesLink.Name, EmployeesLink.Href),
+		spotlight.NewQuickLink(nil, LeavesLink.Name, LeavesLink.Href),
+		spotlight.NewQuickLink(nil, AttendanceLink.Name, AttendanceLink.Href),
+		spotlight.NewQuickLink(nil, TeamCalendarLink.Name, TeamCalendarLink.Href),
+		spotlight.NewQuickLink(nil, TimesheetsLink.Name, TimesheetsLink.Href),
+		spotlight.NewQuickLink(nil, PayrollLink.Name, PayrollLink.Href),
+		spotlight.NewQuickLink(nil, OrgChartLink.Name, OrgChartLink.Href),
 	)
 	return nil
 }
@@ -7,7 +7,12 @@ import (
 )
 
 const (
-	ResourceEmployee permission.Resource = "employee"
+	ResourceEmployee      permission.Resource = "employee"
+	ResourceLeave         permission.Resource = "leave"
+	ResourceLeaveApproval permission.Resource = "leave_approval"
+	ResourceAttendance    permission.Resource = "attendance"
+	ResourcePayroll       permission.Resource = "payroll"
+	ResourceOrgStructure  permission.Resource = "org_structure"
 )
 
 var (
@@ -39,6 +44,90 @@ var (
 		Action:   permission.ActionDelete,
 		Modifier: permission.ModifierAll,
 	}
+	LeaveCreate = &permission.Permission{
+		ID:       uuid.MustParse("3c5e0f7a-1b1d-4f0e-9a57-6b8f0c2d4e11"),
+		Name:     "Leave.Create",
+		Resource: ResourceLeave,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	LeaveRead = &permission.Permission{
+		ID:       uuid.MustParse("9d2f4b61-7e3a-4c8b-b1f5-0a6e2d9c3b72"),
+		Name:     "Leave.Read",
+		Resource: ResourceLeave,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	LeaveUpdate = &permission.Permission{
+		ID:       uuid.MustParse("5a8c1e93-2f4d-4b6a-8e07-c3d9f1a2b584"),
+		Name:     "Leave.Update",
+		Resource: ResourceLeave,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	LeaveApprove = &permission.Permission{
+		ID:       uuid.MustParse("e7b3d5f2-6c1a-4e9d-a4b8-1f0c7e2d9a36"),
+		Name:     "Leave.Approve",
+		Resource: ResourceLeaveApproval,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	AttendanceRead = &permission.Permission{
+		ID:       uuid.MustParse("b4f8a2c6-0d3e-4a7b-9c15-e6d2f8b0a943"),
+		Name:     "Attendance.Read",
+		Resource: ResourceAttendance,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	AttendanceUpdate = &permission.Permission{
+		ID:       uuid.MustParse("1e6d9b3f-8a2c-4f5e-b7d0-4c9a1e3f6b28"),
+		Name:     "Attendance.Update",
+		Resource: ResourceAttendance,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	PayrollCreate = &permission.Permission{
+		ID:       uuid.MustParse("deae4040-936d-4f13-8e33-bd6b33e7744a"),
+		Name:     "Payroll.Create",
+		Resource: ResourcePayroll,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	PayrollRead = &permission.Permission{
+		ID:       uuid.MustParse("64398365-bb59-47d0-ba8f-d65795033437"),
+		Name:     "Payroll.Read",
+		Resource: ResourcePayroll,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	PayrollUpdate = &permission.Permission{
+		ID:       uuid.MustParse("e890ea91-a83e-4038-a536-11d92c863bce"),
+		Name:     "Payroll.Update",
+		Resource: ResourcePayroll,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	PayrollDelete = &permission.Permission{
+		ID:       uuid.MustParse("8a341cc9-cd6a-4514-9536-b76336f9f9e0"),
+		Name:     "Payroll.Delete",
+		Resource: ResourcePayroll,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	OrgStructureRead = &permission.Permission{
+		ID:       uuid.MustParse("75b81de1-41f2-4cb2-90e0-22865d50f878"),
+		Name:     "OrgStructure.Read",
+		Resource: ResourceOrgStructure,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	OrgStructureUpdate = &permission.Permission{
+		ID:       uuid.MustParse("31f06d0a-f41a-438d-9b80-a1dd676a8632"),
+		Name:     "OrgStructure.Update",
+		Resource: ResourceOrgStructure,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
 )
 
 var Permissions = []*permission.Permission{
@@ -46,4 +135,16 @@ var Permissions = []*permission.Permission{
 	EmployeeRead,
 	EmployeeUpdate,
 	EmployeeDelete,
+	LeaveCreate,
+	LeaveRead,
+	LeaveUpdate,
+	LeaveApprove,
+	AttendanceRead,
+	AttendanceUpdate,
+	PayrollCreate,
+	PayrollRead,
+	PayrollUpdate,
+	PayrollDelete,
+	OrgStructureRead,
+	OrgStructureUpdate,
 }
@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/mappers"
+	attendancepages "github.com/iota-uz/iota-sdk/modules/hrm/presentation/templates/pages/attendance"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/hrm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+var attendanceStatuses = []string{
+	string(attendance.StatusPresent),
+	string(attendance.StatusRemote),
+	string(attendance.StatusAbsent),
+}
+
+type AttendanceController struct {
+	app               application.Application
+	attendanceService *services.AttendanceService
+	employeeService   *services.EmployeeService
+	leaveService      *services.LeaveService
+	basePath          string
+}
+
+func NewAttendanceController(app application.Application) application.Controller {
+	return &AttendanceController{
+		app:               app,
+		attendanceService: app.Service(services.AttendanceService{}).(*services.AttendanceService),
+		employeeService:   app.Service(services.EmployeeService{}).(*services.EmployeeService),
+		leaveService:      app.Service(services.LeaveService{}).(*services.LeaveService),
+		basePath:          "/hrm/attendance",
+	}
+}
+
+func (c *AttendanceController) Key() string {
+	return c.basePath
+}
+
+func (c *AttendanceController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/calendar", c.Calendar).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Record).Methods(http.MethodPost)
+	setRouter.HandleFunc("/import", c.Import).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+}
+
+func (c *AttendanceController) List(w http.ResponseWriter, r *http.Request) {
+	params := composables.UsePaginated(r)
+	query := r.URL.Query()
+	findParams := &attendance.FindParams{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	if v, err := strconv.ParseUint(query.Get("employee_id"), 10, 64); err == nil {
+		findParams.EmployeeID = uint(v)
+	}
+	if v, err := time.Parse(time.DateOnly, query.Get("from")); err == nil {
+		findParams.From = v
+	}
+	if v, err := time.Parse(time.DateOnly, query.Get("to")); err == nil {
+		findParams.To = v
+	}
+	records, err := c.attendanceService.GetPaginated(r.Context(), findParams)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving attendance").Error(), http.StatusInternalServerError)
+		return
+	}
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving employees").Error(), http.StatusInternalServerError)
+		return
+	}
+	names := employeeNames(employees)
+	employeeViewModels := mapping.MapViewModels(employees, mappers.EmployeeToViewModel)
+	props := &attendancepages.IndexPageProps{
+		Records: mapping.MapViewModels(records, func(e attendance.Attendance) *viewmodels.Attendance {
+			return mappers.AttendanceToViewModel(e, names)
+		}),
+		Employees:  employeeViewModels,
+		EmployeeID: query.Get("employee_id"),
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+		Form: &attendancepages.RecordFormProps{
+			Attendance: &viewmodels.Attendance{
+				Date:   time.Now().Format(time.DateOnly),
+				Status: string(attendance.StatusPresent),
+			},
+			Employees: employeeViewModels,
+			Statuses:  attendanceStatuses,
+			Errors:    map[string]string{},
+		},
+		CalendarURL: fmt.Sprintf("%s/calendar", c.basePath),
+	}
+	templ.Handler(attendancepages.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *AttendanceController) Record(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&attendance.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		employees, err := c.employeeService.GetAll(r.Context())
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "Error retrieving employees").Error(), http.StatusInternalServerError)
+			return
+		}
+		templ.Handler(attendancepages.RecordForm(&attendancepages.RecordFormProps{
+			Attendance: &viewmodels.Attendance{
+				EmployeeID: strconv.FormatUint(uint64(dto.EmployeeID), 10),
+				Date:       time.Time(dto.Date).Format(time.DateOnly),
+				Status:     dto.Status,
+				CheckIn:    dto.CheckIn,
+				CheckOut:   dto.CheckOut,
+				Note:       dto.Note,
+			},
+			Employees: mapping.MapViewModels(employees, mappers.EmployeeToViewModel),
+			Statuses:  attendanceStatuses,
+			Errors:    errorsMap,
+		}), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.attendanceService.Record(r.Context(), dto); err != nil {
+		http.Error(w, err.Error(), attendanceErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *AttendanceController) Import(w http.ResponseWriter, r *http.Request) {
+	render := func(props *attendancepages.ImportResultProps) {
+		templ.Handler(attendancepages.ImportResult(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		render(&attendancepages.ImportResultProps{Error: err.Error()})
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		render(&attendancepages.ImportResultProps{Error: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	result, err := c.attendanceService.ImportCSV(r.Context(), file)
+	if errors.Is(err, services.ErrMissingEmployeeColumn) || errors.Is(err, services.ErrMissingDateColumn) {
+		render(&attendancepages.ImportResultProps{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), attendanceErrorStatus(err))
+		return
+	}
+	rowErrors := make([]string, 0, len(result.Errors))
+	for _, e := range result.Errors {
+		rowErrors = append(rowErrors, e.Error())
+	}
+	render(&attendancepages.ImportResultProps{
+		Imported: result.Imported,
+		Errors:   rowErrors,
+	})
+}
+
+func (c *AttendanceController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if err := c.attendanceService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), attendanceErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *AttendanceController) Calendar(w http.ResponseWriter, r *http.Request) {
+	month := parseMonth(r)
+	from, to := month, month.AddDate(0, 1, -1)
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving employees").Error(), http.StatusInternalServerError)
+		return
+	}
+	records, err := c.attendanceService.GetPaginated(r.Context(), &attendance.FindParams{From: from, To: to})
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving attendance").Error(), http.StatusInternalServerError)
+		return
+	}
+	// Leave is only marked on the calendar for those allowed to see it
+	approved, err := c.leaveService.GetPaginated(r.Context(), &leaverequest.FindParams{
+		Statuses: []leaverequest.Status{leaverequest.StatusApproved},
+		From:     from,
+		To:       to,
+	})
+	if err != nil && !errors.Is(err, composables.ErrForbidden) {
+		http.Error(w, errors.Wrap(err, "Error retrieving leave requests").Error(), http.StatusInternalServerError)
+		return
+	}
+	types, err := c.leaveService.GetTypes(r.Context())
+	if err != nil && !errors.Is(err, composables.ErrForbidden) {
+		http.Error(w, errors.Wrap(err, "Error retrieving leave types").Error(), http.StatusInternalServerError)
+		return
+	}
+	typeNames := make(map[uint]string, len(types))
+	for _, t := range types {
+		typeNames[t.ID()] = t.Name()
+	}
+	days, rows := mappers.TeamCalendar(month.Year(), month.Month(), employees, records, approved, typeNames, time.Now())
+	props := &attendancepages.CalendarPageProps{
+		Month:   month.Format("2006-01"),
+		PrevURL: monthURL(fmt.Sprintf("%s/calendar", c.basePath), month.AddDate(0, -1, 0)),
+		NextURL: monthURL(fmt.Sprintf("%s/calendar", c.basePath), month.AddDate(0, 1, 0)),
+		Days:    days,
+		Rows:    rows,
+	}
+	templ.Handler(attendancepages.Calendar(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func attendanceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, composables.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, attendance.ErrCheckOutBeforeCheckIn):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
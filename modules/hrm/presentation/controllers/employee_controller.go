@@ -50,6 +50,7 @@ func (c *EmployeeController) Register(r *mux.Router) {
 		middleware.Authorize(),
 		middleware.RedirectNotAuthenticated(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.ProvideDynamicLogo(c.app),
 		middleware.Tabs(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
@@ -164,7 +165,7 @@ func (c *EmployeeController) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.employeeService.Create(r.Context(), dto)
+	_, err = c.employeeService.Create(r.Context(), dto)
 	if err != nil {
 		l, ok := intl.UseLocalizer(r.Context())
 		if !ok {
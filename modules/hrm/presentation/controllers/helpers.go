@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/mappers"
+)
+
+// parseMonth reads a YYYY-MM month from the query, defaulting to the current month
+func parseMonth(r *http.Request) time.Time {
+	if v := r.URL.Query().Get("month"); v != "" {
+		if month, err := time.Parse("2006-01", v); err == nil {
+			return month
+		}
+	}
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func monthURL(basePath string, month time.Time) string {
+	return fmt.Sprintf("%s?month=%s", basePath, month.Format("2006-01"))
+}
+
+func employeeNames(employees []employee.Employee) map[uint]string {
+	names := make(map[uint]string, len(employees))
+	for _, e := range employees {
+		names[e.ID()] = mappers.EmployeeFullName(e)
+	}
+	return names
+}
@@ -0,0 +1,372 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/leavetype"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/templates/pages/leaves"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/hrm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+var leaveStatuses = []string{
+	string(leaverequest.StatusPending),
+	string(leaverequest.StatusApproved),
+	string(leaverequest.StatusRejected),
+	string(leaverequest.StatusCanceled),
+}
+
+var leaveAccruals = []string{
+	string(leavetype.AccrualYearly),
+	string(leavetype.AccrualMonthly),
+	string(leavetype.AccrualNone),
+}
+
+type LeaveController struct {
+	app             application.Application
+	leaveService    *services.LeaveService
+	employeeService *services.EmployeeService
+	basePath        string
+}
+
+func NewLeaveController(app application.Application) application.Controller {
+	return &LeaveController{
+		app:             app,
+		leaveService:    app.Service(services.LeaveService{}).(*services.LeaveService),
+		employeeService: app.Service(services.EmployeeService{}).(*services.EmployeeService),
+		basePath:        "/hrm/leaves",
+	}
+}
+
+func (c *LeaveController) Key() string {
+	return c.basePath
+}
+
+func (c *LeaveController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/types", c.GetTypes).Methods(http.MethodGet)
+	getRouter.HandleFunc("/balances", c.GetBalances).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/approve", c.Approve).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/reject", c.Reject).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/cancel", c.Cancel).Methods(http.MethodPost)
+	setRouter.HandleFunc("/types", c.CreateType).Methods(http.MethodPost)
+	setRouter.HandleFunc("/types/{id:[0-9]+}", c.DeleteType).Methods(http.MethodDelete)
+}
+
+func (c *LeaveController) leaveTypeNames(r *http.Request) (map[uint]string, []leavetype.LeaveType, error) {
+	types, err := c.leaveService.GetTypes(r.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make(map[uint]string, len(types))
+	for _, t := range types {
+		names[t.ID()] = t.Name()
+	}
+	return names, types, nil
+}
+
+func (c *LeaveController) List(w http.ResponseWriter, r *http.Request) {
+	params := composables.UsePaginated(r)
+	findParams := &leaverequest.FindParams{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	status := r.URL.Query().Get("status")
+	if status != "" {
+		findParams.Statuses = []leaverequest.Status{leaverequest.Status(status)}
+	}
+	requests, err := c.leaveService.GetPaginated(r.Context(), findParams)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving leave requests").Error(), http.StatusInternalServerError)
+		return
+	}
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving employees").Error(), http.StatusInternalServerError)
+		return
+	}
+	typeNames, _, err := c.leaveTypeNames(r)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving leave types").Error(), http.StatusInternalServerError)
+		return
+	}
+	names := employeeNames(employees)
+	props := &leaves.IndexPageProps{
+		Requests: mapping.MapViewModels(requests, func(e leaverequest.LeaveRequest) *viewmodels.LeaveRequest {
+			return mappers.LeaveRequestToViewModel(e, names, typeNames)
+		}),
+		Statuses:    leaveStatuses,
+		Status:      status,
+		NewURL:      fmt.Sprintf("%s/new", c.basePath),
+		TypesURL:    fmt.Sprintf("%s/types", c.basePath),
+		BalancesURL: fmt.Sprintf("%s/balances", c.basePath),
+	}
+	if len(r.Header.Get("Hx-Request")) > 0 {
+		templ.Handler(leaves.LeavesContent(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(leaves.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *LeaveController) createPageProps(
+	r *http.Request,
+	request *viewmodels.LeaveRequest,
+	errorsMap map[string]string,
+) (*leaves.CreatePageProps, error) {
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	types, err := c.leaveService.GetTypes(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &leaves.CreatePageProps{
+		Request:    request,
+		Employees:  mapping.MapViewModels(employees, mappers.EmployeeToViewModel),
+		LeaveTypes: mapping.MapViewModels(types, mappers.LeaveTypeToViewModel),
+		PostPath:   c.basePath,
+		Errors:     errorsMap,
+	}, nil
+}
+
+func (c *LeaveController) GetNew(w http.ResponseWriter, r *http.Request) {
+	props, err := c.createPageProps(r, &viewmodels.LeaveRequest{}, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(leaves.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *LeaveController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&leaverequest.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	errorsMap, ok := dto.Ok(r.Context())
+	if ok {
+		_, err = c.leaveService.Request(r.Context(), dto)
+		if err == nil {
+			shared.Redirect(w, r, c.basePath)
+			return
+		}
+		validationErrors := make(serrors.ValidationErrors)
+		switch {
+		case errors.Is(err, leaverequest.ErrOverlap):
+			validationErrors["StartDate"] = serrors.NewValidationError(
+				"StartDate", "LEAVE_OVERLAP", err.Error(), "ValidationErrors.leaveOverlap",
+			)
+		case errors.Is(err, leaverequest.ErrBalanceTooLow):
+			validationErrors["LeaveTypeID"] = serrors.NewValidationError(
+				"LeaveTypeID", "LEAVE_BALANCE", err.Error(), "ValidationErrors.leaveBalance",
+			)
+		default:
+			http.Error(w, err.Error(), leaveErrorStatus(err))
+			return
+		}
+		l, ok := intl.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		errorsMap = serrors.LocalizeValidationErrors(validationErrors, l)
+	}
+	props, err := c.createPageProps(r, &viewmodels.LeaveRequest{
+		EmployeeID:  strconv.FormatUint(uint64(dto.EmployeeID), 10),
+		LeaveTypeID: strconv.FormatUint(uint64(dto.LeaveTypeID), 10),
+		StartDate:   time.Time(dto.StartDate).Format(time.DateOnly),
+		EndDate:     time.Time(dto.EndDate).Format(time.DateOnly),
+		Reason:      dto.Reason,
+	}, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(leaves.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *LeaveController) Approve(w http.ResponseWriter, r *http.Request) {
+	c.transition(w, r, func(id uint) error {
+		_, err := c.leaveService.Approve(r.Context(), id, r.FormValue("Comment"))
+		return err
+	})
+}
+
+func (c *LeaveController) Reject(w http.ResponseWriter, r *http.Request) {
+	c.transition(w, r, func(id uint) error {
+		_, err := c.leaveService.Reject(r.Context(), id, r.FormValue("Comment"))
+		return err
+	})
+}
+
+func (c *LeaveController) Cancel(w http.ResponseWriter, r *http.Request) {
+	c.transition(w, r, func(id uint) error {
+		_, err := c.leaveService.Cancel(r.Context(), id)
+		return err
+	})
+}
+
+func (c *LeaveController) transition(w http.ResponseWriter, r *http.Request, fn func(id uint) error) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if err := fn(id); err != nil {
+		http.Error(w, err.Error(), leaveErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *LeaveController) typesPageProps(r *http.Request, form *leaves.TypeFormProps) (*leaves.TypesPageProps, error) {
+	types, err := c.leaveService.GetTypes(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &leaves.TypesPageProps{
+		LeaveTypes: mapping.MapViewModels(types, mappers.LeaveTypeToViewModel),
+		Form:       form,
+	}, nil
+}
+
+func (c *LeaveController) GetTypes(w http.ResponseWriter, r *http.Request) {
+	props, err := c.typesPageProps(r, &leaves.TypeFormProps{
+		LeaveType: &viewmodels.LeaveType{
+			Paid:    true,
+			Accrual: string(leavetype.AccrualYearly),
+		},
+		Accruals: leaveAccruals,
+		Errors:   map[string]string{},
+	})
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving leave types").Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(leaves.Types(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *LeaveController) CreateType(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&leavetype.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		templ.Handler(leaves.TypeForm(&leaves.TypeFormProps{
+			LeaveType: &viewmodels.LeaveType{
+				Name:            dto.Name,
+				Description:     dto.Description,
+				Paid:            dto.Paid,
+				AnnualAllowance: strconv.FormatFloat(dto.AnnualAllowance, 'f', -1, 64),
+				Accrual:         dto.Accrual,
+				CarryOverLimit:  strconv.FormatFloat(dto.CarryOverLimit, 'f', -1, 64),
+			},
+			Accruals: leaveAccruals,
+			Errors:   errorsMap,
+		}), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.leaveService.CreateType(r.Context(), dto); err != nil {
+		http.Error(w, err.Error(), leaveErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/types", c.basePath))
+}
+
+func (c *LeaveController) DeleteType(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if err := c.leaveService.DeleteType(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), leaveErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *LeaveController) GetBalances(w http.ResponseWriter, r *http.Request) {
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving employees").Error(), http.StatusInternalServerError)
+		return
+	}
+	year := time.Now().Year()
+	if v, err := strconv.Atoi(r.URL.Query().Get("year")); err == nil {
+		year = v
+	}
+	props := &leaves.BalancesPageProps{
+		Employees:  mapping.MapViewModels(employees, mappers.EmployeeToViewModel),
+		EmployeeID: r.URL.Query().Get("EmployeeID"),
+		Year:       strconv.Itoa(year),
+	}
+	if props.EmployeeID != "" {
+		employeeID, err := strconv.ParseUint(props.EmployeeID, 10, 64)
+		if err != nil {
+			http.Error(w, "Error parsing employee id", http.StatusBadRequest)
+			return
+		}
+		balances, err := c.leaveService.Balances(r.Context(), uint(employeeID), year)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "Error retrieving balances").Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Balances = mapping.MapViewModels(balances, mappers.LeaveBalanceToViewModel)
+	}
+	templ.Handler(leaves.Balances(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func leaveErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, composables.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, leaverequest.ErrNotPending),
+		errors.Is(err, leaverequest.ErrSelfApproval),
+		errors.Is(err, leaverequest.ErrCannotCancel),
+		errors.Is(err, leaverequest.ErrAlreadyStarted),
+		errors.Is(err, leaverequest.ErrInvalidPeriod),
+		errors.Is(err, leaverequest.ErrNoWorkingDays):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,361 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/department"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/assignment"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/mappers"
+	orgpages "github.com/iota-uz/iota-sdk/modules/hrm/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/hrm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type OrgController struct {
+	app             application.Application
+	orgService      *services.OrgService
+	employeeService *services.EmployeeService
+	basePath        string
+}
+
+func NewOrgController(app application.Application) application.Controller {
+	return &OrgController{
+		app:             app,
+		orgService:      app.Service(services.OrgService{}).(*services.OrgService),
+		employeeService: app.Service(services.EmployeeService{}).(*services.EmployeeService),
+		basePath:        "/hrm/org",
+	}
+}
+
+func (c *OrgController) Key() string {
+	return c.basePath
+}
+
+func (c *OrgController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.Chart).Methods(http.MethodGet)
+	getRouter.HandleFunc("/departments", c.Departments).Methods(http.MethodGet)
+	getRouter.HandleFunc("/departments/{id:[0-9]+}", c.GetDepartment).Methods(http.MethodGet)
+	getRouter.HandleFunc("/transfers", c.Transfers).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("/departments", c.CreateDepartment).Methods(http.MethodPost)
+	setRouter.HandleFunc("/departments/{id:[0-9]+}", c.UpdateDepartment).Methods(http.MethodPost)
+	setRouter.HandleFunc("/departments/{id:[0-9]+}", c.DeleteDepartment).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/transfers", c.Transfer).Methods(http.MethodPost)
+}
+
+func (c *OrgController) departmentsURL() string {
+	return fmt.Sprintf("%s/departments", c.basePath)
+}
+
+func (c *OrgController) transfersURL(employeeID uint) string {
+	return fmt.Sprintf("%s/transfers?employee=%d", c.basePath, employeeID)
+}
+
+func (c *OrgController) Chart(w http.ResponseWriter, r *http.Request) {
+	chart, err := c.orgService.Chart(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving org chart").Error(), orgErrorStatus(err))
+		return
+	}
+	employees := make([]employee.Employee, 0, len(chart.Employees))
+	for _, e := range chart.Employees {
+		employees = append(employees, e)
+	}
+	nodes, unassigned := mappers.OrgChartToViewModels(chart.Departments, chart.Members, chart.Managers, employeeNames(employees))
+	templ.Handler(orgpages.Chart(&orgpages.ChartPageProps{
+		Nodes:      nodes,
+		Unassigned: unassigned,
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// formData loads the departments in tree order and the employees offered in the selects of the forms
+func (c *OrgController) formData(r *http.Request) ([]*viewmodels.Department, []*viewmodels.Employee, error) {
+	departments, err := c.orgService.GetDepartments(r.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	return mappers.DepartmentTreeToViewModels(department.Tree(departments), employeeNames(employees)),
+		mapping.MapViewModels(employees, mappers.EmployeeToViewModel),
+		nil
+}
+
+func (c *OrgController) Departments(w http.ResponseWriter, r *http.Request) {
+	departments, employees, err := c.formData(r)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving departments").Error(), orgErrorStatus(err))
+		return
+	}
+	props := &orgpages.DepartmentsPageProps{
+		Departments: departments,
+		Form: &orgpages.DepartmentFormProps{
+			Department:  &viewmodels.Department{},
+			Departments: departments,
+			Employees:   employees,
+			SaveURL:     c.departmentsURL(),
+			Errors:      map[string]string{},
+		},
+	}
+	templ.Handler(orgpages.Departments(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *OrgController) GetDepartment(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	entity, err := c.orgService.GetDepartmentByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving department").Error(), orgErrorStatus(err))
+		return
+	}
+	departments, employees, err := c.formData(r)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving departments").Error(), orgErrorStatus(err))
+		return
+	}
+	templ.Handler(orgpages.Edit(&orgpages.DepartmentFormProps{
+		Department:  mappers.DepartmentToViewModel(entity, nil, nil),
+		Departments: departments,
+		Employees:   employees,
+		SaveURL:     fmt.Sprintf("%s/%d", c.departmentsURL(), id),
+		Errors:      map[string]string{},
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *OrgController) CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	c.saveDepartment(w, r, 0)
+}
+
+func (c *OrgController) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	c.saveDepartment(w, r, id)
+}
+
+// saveDepartment creates a department when id is zero and updates it otherwise
+func (c *OrgController) saveDepartment(w http.ResponseWriter, r *http.Request, id uint) {
+	dto, err := composables.UseForm(&department.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	renderForm := func(errorsMap map[string]string) {
+		departments, employees, err := c.formData(r)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "Error retrieving departments").Error(), orgErrorStatus(err))
+			return
+		}
+		saveURL := c.departmentsURL()
+		if id != 0 {
+			saveURL = fmt.Sprintf("%s/%d", saveURL, id)
+		}
+		templ.Handler(orgpages.DepartmentForm(&orgpages.DepartmentFormProps{
+			Department: &viewmodels.Department{
+				ID:          formatUint(id),
+				Name:        dto.Name,
+				Description: dto.Description,
+				ParentID:    formatUint(dto.ParentID),
+				ManagerID:   formatUint(dto.ManagerID),
+			},
+			Departments: departments,
+			Employees:   employees,
+			SaveURL:     saveURL,
+			Errors:      errorsMap,
+		}), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		renderForm(errorsMap)
+		return
+	}
+	if id == 0 {
+		_, err = c.orgService.CreateDepartment(r.Context(), dto)
+	} else {
+		_, err = c.orgService.UpdateDepartment(r.Context(), id, dto)
+	}
+	if errors.Is(err, department.ErrCycle) {
+		renderForm(map[string]string{"ParentID": localize(r, "ValidationErrors.departmentCycle")})
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), orgErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.departmentsURL())
+}
+
+func (c *OrgController) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if err := c.orgService.DeleteDepartment(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), orgErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *OrgController) Transfers(w http.ResponseWriter, r *http.Request) {
+	departments, err := c.orgService.GetDepartments(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving departments").Error(), orgErrorStatus(err))
+		return
+	}
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving employees").Error(), http.StatusInternalServerError)
+		return
+	}
+	names := employeeNames(employees)
+	props := &orgpages.TransfersPageProps{
+		Employees: mapping.MapViewModels(employees, mappers.EmployeeToViewModel),
+	}
+	employeeID, err := strconv.ParseUint(r.URL.Query().Get("employee"), 10, 64)
+	if err != nil || employeeID == 0 {
+		templ.Handler(orgpages.Transfers(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	props.EmployeeID = formatUint(uint(employeeID))
+	props.Form = &orgpages.TransferFormProps{
+		EmployeeID:  props.EmployeeID,
+		StartDate:   time.Now().Format(time.DateOnly),
+		Departments: mappers.DepartmentTreeToViewModels(department.Tree(departments), names),
+		Employees:   props.Employees,
+		Errors:      map[string]string{},
+	}
+	current, err := c.orgService.CurrentAssignment(r.Context(), uint(employeeID))
+	switch {
+	case err == nil:
+		props.Form.DepartmentID = formatUint(current.DepartmentID())
+		props.Form.ManagerID = formatUint(current.ManagerID())
+	case !errors.Is(err, services.ErrNoAssignment):
+		http.Error(w, errors.Wrap(err, "Error retrieving assignment").Error(), orgErrorStatus(err))
+		return
+	}
+	history, err := c.orgService.History(r.Context(), uint(employeeID))
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving transfer history").Error(), orgErrorStatus(err))
+		return
+	}
+	departmentNames := make(map[uint]string, len(departments))
+	for _, d := range departments {
+		departmentNames[d.ID()] = d.Name()
+	}
+	props.History = mapping.MapViewModels(history, func(a assignment.Assignment) *viewmodels.Assignment {
+		return mappers.AssignmentToViewModel(a, departmentNames, names)
+	})
+	templ.Handler(orgpages.Transfers(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *OrgController) Transfer(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&assignment.TransferDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	renderForm := func(errorsMap map[string]string) {
+		departments, employees, err := c.formData(r)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "Error retrieving departments").Error(), orgErrorStatus(err))
+			return
+		}
+		var startDate string
+		if t := time.Time(dto.StartDate); !t.IsZero() {
+			startDate = t.Format(time.DateOnly)
+		}
+		templ.Handler(orgpages.TransferForm(&orgpages.TransferFormProps{
+			EmployeeID:   formatUint(dto.EmployeeID),
+			DepartmentID: formatUint(dto.DepartmentID),
+			ManagerID:    formatUint(dto.ManagerID),
+			StartDate:    startDate,
+			Note:         dto.Note,
+			Departments:  departments,
+			Employees:    employees,
+			Errors:       errorsMap,
+		}), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		renderForm(errorsMap)
+		return
+	}
+	_, err = c.orgService.Transfer(r.Context(), dto)
+	switch {
+	case errors.Is(err, assignment.ErrManagerCycle):
+		renderForm(map[string]string{"ManagerID": localize(r, "ValidationErrors.managerCycle")})
+		return
+	case errors.Is(err, assignment.ErrSelfManager):
+		renderForm(map[string]string{"ManagerID": localize(r, "ValidationErrors.selfManager")})
+		return
+	case errors.Is(err, assignment.ErrStartTooEarly):
+		renderForm(map[string]string{"StartDate": localize(r, "ValidationErrors.transferTooEarly")})
+		return
+	case err != nil:
+		http.Error(w, err.Error(), orgErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.transfersURL(dto.EmployeeID))
+}
+
+func formatUint(v uint) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func orgErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, composables.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, persistence.ErrDepartmentNotFound),
+		errors.Is(err, persistence.ErrEmployeeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, department.ErrCycle),
+		errors.Is(err, department.ErrHasChildren),
+		errors.Is(err, assignment.ErrSelfManager),
+		errors.Is(err, assignment.ErrManagerCycle),
+		errors.Is(err, assignment.ErrStartTooEarly):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,439 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/payroll"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/payrollrule"
+	"github.com/iota-uz/iota-sdk/modules/hrm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/mappers"
+	payrollpages "github.com/iota-uz/iota-sdk/modules/hrm/presentation/templates/pages/payroll"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/hrm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/excel"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+var (
+	payrollRuleKinds = []string{
+		string(payrollrule.KindBonus),
+		string(payrollrule.KindDeduction),
+		string(payrollrule.KindTax),
+	}
+	payrollRuleMethods = []string{
+		string(payrollrule.MethodPercent),
+		string(payrollrule.MethodFixed),
+	}
+)
+
+type PayrollController struct {
+	app             application.Application
+	payrollService  *services.PayrollService
+	employeeService *services.EmployeeService
+	basePath        string
+}
+
+func NewPayrollController(app application.Application) application.Controller {
+	return &PayrollController{
+		app:             app,
+		payrollService:  app.Service(services.PayrollService{}).(*services.PayrollService),
+		employeeService: app.Service(services.EmployeeService{}).(*services.EmployeeService),
+		basePath:        "/hrm/payroll",
+	}
+}
+
+func (c *PayrollController) Key() string {
+	return c.basePath
+}
+
+func (c *PayrollController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/rules", c.GetRules).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetRun).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}/payslips/{payslipID:[0-9]+}/pdf", c.PayslipPDF).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/recalculate", c.Recalculate).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/post", c.Post).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/payslips/{payslipID:[0-9]+}/bonus", c.SetBonus).Methods(http.MethodPost)
+	setRouter.HandleFunc("/rules", c.CreateRule).Methods(http.MethodPost)
+	setRouter.HandleFunc("/rules/{id:[0-9]+}/toggle", c.ToggleRule).Methods(http.MethodPost)
+	setRouter.HandleFunc("/rules/{id:[0-9]+}", c.DeleteRule).Methods(http.MethodDelete)
+}
+
+func (c *PayrollController) runURL(id uint) string {
+	return fmt.Sprintf("%s/%d", c.basePath, id)
+}
+
+func (c *PayrollController) List(w http.ResponseWriter, r *http.Request) {
+	params := composables.UsePaginated(r)
+	runs, err := c.payrollService.GetPaginated(r.Context(), &payroll.FindParams{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving payroll runs").Error(), payrollErrorStatus(err))
+		return
+	}
+	props := &payrollpages.IndexPageProps{
+		Runs: mapping.MapViewModels(runs, func(e payroll.Run) *viewmodels.PayrollRun {
+			return mappers.PayrollRunToViewModel(e, nil)
+		}),
+		RulesURL: fmt.Sprintf("%s/rules", c.basePath),
+		Form: &payrollpages.RunFormProps{
+			Month:  parseMonth(r).Format("2006-01"),
+			Errors: map[string]string{},
+		},
+	}
+	templ.Handler(payrollpages.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *PayrollController) Create(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	value := r.FormValue("Month")
+	form := &payrollpages.RunFormProps{Month: value, Errors: map[string]string{}}
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		form.Errors["Month"] = localize(r, "ValidationErrors.payrollMonth")
+		templ.Handler(payrollpages.RunForm(form), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	run, err := c.payrollService.Run(r.Context(), month.Year(), month.Month())
+	if errors.Is(err, payroll.ErrRunExists) {
+		form.Errors["Month"] = localize(r, "ValidationErrors.payrollRunExists")
+		templ.Handler(payrollpages.RunForm(form), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), payrollErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.runURL(run.ID()))
+}
+
+func (c *PayrollController) GetRun(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	run, err := c.payrollService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving payroll run").Error(), payrollErrorStatus(err))
+		return
+	}
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving employees").Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &payrollpages.RunPageProps{
+		Run:      mappers.PayrollRunToViewModel(run, employeeNames(employees)),
+		BasePath: c.runURL(run.ID()),
+		Errors:   map[string]string{},
+	}
+	if run.Status() == payroll.StatusDraft {
+		accounts, err := c.payrollService.MoneyAccounts(r.Context())
+		if err != nil && !errors.Is(err, services.ErrFinanceUnavailable) {
+			http.Error(w, errors.Wrap(err, "Error retrieving money accounts").Error(), payrollErrorStatus(err))
+			return
+		}
+		for _, a := range accounts {
+			props.Accounts = append(props.Accounts, &payrollpages.AccountOption{
+				ID:   a.ID().String(),
+				Name: fmt.Sprintf("%s (%s)", a.Name(), a.Balance().Currency().Code),
+			})
+		}
+	}
+	templ.Handler(payrollpages.Run(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *PayrollController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if err := c.payrollService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), payrollErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *PayrollController) Recalculate(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.payrollService.Recalculate(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), payrollErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.runURL(id))
+}
+
+func (c *PayrollController) SetBonus(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	payslipID, err := strconv.ParseUint(mux.Vars(r)["payslipID"], 10, 64)
+	if err != nil {
+		http.Error(w, "Error parsing payslip id", http.StatusBadRequest)
+		return
+	}
+	bonus, err := strconv.ParseFloat(r.FormValue("Bonus"), 64)
+	if err != nil || bonus < 0 {
+		http.Error(w, "Error parsing bonus", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.payrollService.SetBonus(r.Context(), id, uint(payslipID), bonus); err != nil {
+		http.Error(w, err.Error(), payrollErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.runURL(id))
+}
+
+func (c *PayrollController) Post(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	accountID, err := uuid.Parse(r.FormValue("AccountID"))
+	if err != nil {
+		http.Error(w, "Error parsing account id", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.payrollService.Post(r.Context(), id, accountID); err != nil {
+		http.Error(w, err.Error(), payrollErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, c.runURL(id))
+}
+
+// PayslipPDF renders a payslip of a run as a PDF document
+func (c *PayrollController) PayslipPDF(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	payslipID, err := strconv.ParseUint(mux.Vars(r)["payslipID"], 10, 64)
+	if err != nil {
+		http.Error(w, "Error parsing payslip id", http.StatusBadRequest)
+		return
+	}
+	run, err := c.payrollService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving payroll run").Error(), payrollErrorStatus(err))
+		return
+	}
+	p, err := run.Payslip(uint(payslipID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	employee, err := c.employeeService.GetByID(r.Context(), p.EmployeeID)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving employee").Error(), http.StatusInternalServerError)
+		return
+	}
+	vm := mappers.PayslipToViewModel(p, map[uint]string{p.EmployeeID: mappers.EmployeeFullName(employee)})
+	period := run.Period().Format("2006-01")
+
+	pdfOpts := excel.DefaultPDFOptions()
+	pdfOpts.Orientation = "P"
+	pdfOpts.FontSize = 10
+	pdfOpts.Title = fmt.Sprintf("%s: %s, %s", localize(r, "Payroll.Payslip.Title"), vm.EmployeeName, period)
+	rows := payslipRows(r, vm)
+	datasource := excel.NewSliceDataSource(
+		[]string{localize(r, "Payroll.Payslip.Item"), localize(r, "Payroll.Payslip.Amount")},
+		rows,
+	)
+
+	w.Header().Set("Content-Type", excel.FormatPDF.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"payslip-%s-%d.pdf\"", period, p.EmployeeID))
+	if err := excel.NewPDFExporter(excel.DefaultOptions(), pdfOpts).ExportTo(r.Context(), w, datasource); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (c *PayrollController) rulesPageProps(r *http.Request, form *payrollpages.RuleFormProps) (*payrollpages.RulesPageProps, error) {
+	rules, err := c.payrollService.GetRules(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &payrollpages.RulesPageProps{
+		Rules: mapping.MapViewModels(rules, mappers.PayrollRuleToViewModel),
+		Form:  form,
+	}, nil
+}
+
+func (c *PayrollController) GetRules(w http.ResponseWriter, r *http.Request) {
+	props, err := c.rulesPageProps(r, &payrollpages.RuleFormProps{
+		Rule: &viewmodels.PayrollRule{
+			Kind:    string(payrollrule.KindDeduction),
+			Method:  string(payrollrule.MethodPercent),
+			Enabled: true,
+		},
+		Kinds:   payrollRuleKinds,
+		Methods: payrollRuleMethods,
+		Errors:  map[string]string{},
+	})
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving payroll rules").Error(), payrollErrorStatus(err))
+		return
+	}
+	templ.Handler(payrollpages.Rules(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *PayrollController) CreateRule(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&payrollrule.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		templ.Handler(payrollpages.RuleForm(&payrollpages.RuleFormProps{
+			Rule: &viewmodels.PayrollRule{
+				Name:     dto.Name,
+				Kind:     dto.Kind,
+				Method:   dto.Method,
+				Value:    strconv.FormatFloat(dto.Value, 'f', -1, 64),
+				PreTax:   dto.PreTax,
+				Enabled:  dto.Enabled,
+				Position: strconv.Itoa(dto.Position),
+			},
+			Kinds:   payrollRuleKinds,
+			Methods: payrollRuleMethods,
+			Errors:  errorsMap,
+		}), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.payrollService.CreateRule(r.Context(), dto); err != nil {
+		http.Error(w, err.Error(), payrollErrorStatus(err))
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/rules", c.basePath))
+}
+
+func (c *PayrollController) ToggleRule(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	rule, err := c.payrollService.ToggleRule(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), payrollErrorStatus(err))
+		return
+	}
+	templ.Handler(payrollpages.RuleRow(mappers.PayrollRuleToViewModel(rule)), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *PayrollController) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusBadRequest)
+		return
+	}
+	if err := c.payrollService.DeleteRule(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), payrollErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// payslipRows lists the earnings, deductions and taxes of a payslip for the PDF document
+func payslipRows(r *http.Request, p *viewmodels.Payslip) [][]interface{} {
+	rows := [][]interface{}{
+		{localize(r, "Payroll.Payslip.WorkingDays"), p.WorkingDays},
+		{localize(r, "Payroll.Payslip.PayableDays"), p.PayableDays},
+		{localize(r, "Payroll.Payslip.BaseSalary"), p.BaseSalary},
+		{localize(r, "Payroll.Payslip.Earned"), p.Earned},
+		{localize(r, "Payroll.Payslip.Bonus"), p.Bonus},
+	}
+	appendLines := func(kind payrollrule.Kind) {
+		for _, l := range p.Lines {
+			if l.Kind == string(kind) {
+				rows = append(rows, []interface{}{l.Name, l.Amount})
+			}
+		}
+	}
+	appendLines(payrollrule.KindBonus)
+	rows = append(rows, []interface{}{localize(r, "Payroll.Payslip.Gross"), p.Gross})
+	appendLines(payrollrule.KindDeduction)
+	rows = append(rows,
+		[]interface{}{localize(r, "Payroll.Payslip.Deductions"), p.Deductions},
+		[]interface{}{localize(r, "Payroll.Payslip.Taxable"), p.Taxable},
+	)
+	appendLines(payrollrule.KindTax)
+	return append(rows,
+		[]interface{}{localize(r, "Payroll.Payslip.Taxes"), p.Taxes},
+		[]interface{}{localize(r, "Payroll.Payslip.Net"), p.Net},
+	)
+}
+
+func localize(r *http.Request, messageID string) string {
+	l, ok := intl.UseLocalizer(r.Context())
+	if !ok {
+		return messageID
+	}
+	return l.MustLocalize(&i18n.LocalizeConfig{MessageID: messageID})
+}
+
+func payrollErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, composables.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, persistence.ErrPayrollRunNotFound),
+		errors.Is(err, persistence.ErrPayrollRuleNotFound),
+		errors.Is(err, payroll.ErrPayslipNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, payroll.ErrAlreadyPosted),
+		errors.Is(err, payroll.ErrEmptyRun),
+		errors.Is(err, payroll.ErrCurrencyMismatch),
+		errors.Is(err, services.ErrFinanceUnavailable):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
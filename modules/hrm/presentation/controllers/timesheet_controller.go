@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/timesheet"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/templates/pages/timesheets"
+	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/hrm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+)
+
+type TimesheetController struct {
+	app              application.Application
+	timesheetService *services.TimesheetService
+	employeeService  *services.EmployeeService
+	basePath         string
+}
+
+func NewTimesheetController(app application.Application) application.Controller {
+	return &TimesheetController{
+		app:              app,
+		timesheetService: app.Service(services.TimesheetService{}).(*services.TimesheetService),
+		employeeService:  app.Service(services.EmployeeService{}).(*services.EmployeeService),
+		basePath:         "/hrm/timesheets",
+	}
+}
+
+func (c *TimesheetController) Key() string {
+	return c.basePath
+}
+
+func (c *TimesheetController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", c.List).Methods(http.MethodGet)
+}
+
+func (c *TimesheetController) List(w http.ResponseWriter, r *http.Request) {
+	month := parseMonth(r)
+	entities, err := c.timesheetService.Monthly(r.Context(), month.Year(), month.Month())
+	if errors.Is(err, composables.ErrForbidden) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error building timesheets").Error(), http.StatusInternalServerError)
+		return
+	}
+	employees, err := c.employeeService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving employees").Error(), http.StatusInternalServerError)
+		return
+	}
+	names := employeeNames(employees)
+	props := &timesheets.IndexPageProps{
+		Month:   month.Format("2006-01"),
+		PrevURL: monthURL(c.basePath, month.AddDate(0, -1, 0)),
+		NextURL: monthURL(c.basePath, month.AddDate(0, 1, 0)),
+		Timesheets: mapping.MapViewModels(entities, func(t timesheet.Timesheet) *viewmodels.Timesheet {
+			return mappers.TimesheetToViewModel(t, names)
+		}),
+	}
+	templ.Handler(timesheets.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
@@ -1,10 +1,20 @@
 package mappers
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/department"
 	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/payroll"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/assignment"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/leavetype"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/payrollrule"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/timesheet"
 	"github.com/iota-uz/iota-sdk/modules/hrm/presentation/viewmodels"
 )
 
@@ -38,3 +48,336 @@ func EmployeeToViewModel(entity employee.Employee) *viewmodels.Employee {
 		CreatedAt:       entity.CreatedAt().Format(time.RFC3339),
 	}
 }
+
+func EmployeeFullName(entity employee.Employee) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s", entity.FirstName(), entity.LastName()))
+}
+
+func formatDays(days float64) string {
+	return strconv.FormatFloat(days, 'f', -1, 64)
+}
+
+func LeaveTypeToViewModel(entity leavetype.LeaveType) *viewmodels.LeaveType {
+	return &viewmodels.LeaveType{
+		ID:              strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:            entity.Name(),
+		Description:     entity.Description(),
+		Paid:            entity.Paid(),
+		AnnualAllowance: formatDays(entity.AnnualAllowance()),
+		Accrual:         string(entity.Accrual()),
+		CarryOverLimit:  formatDays(entity.CarryOverLimit()),
+	}
+}
+
+// LeaveRequestToViewModel maps a request, resolving names from the given employee and leave type names
+func LeaveRequestToViewModel(
+	entity leaverequest.LeaveRequest,
+	employeeNames map[uint]string,
+	leaveTypeNames map[uint]string,
+) *viewmodels.LeaveRequest {
+	status := entity.Status()
+	return &viewmodels.LeaveRequest{
+		ID:            strconv.FormatUint(uint64(entity.ID()), 10),
+		EmployeeID:    strconv.FormatUint(uint64(entity.EmployeeID()), 10),
+		EmployeeName:  employeeNames[entity.EmployeeID()],
+		LeaveTypeID:   strconv.FormatUint(uint64(entity.LeaveTypeID()), 10),
+		LeaveTypeName: leaveTypeNames[entity.LeaveTypeID()],
+		StartDate:     entity.StartDate().Format(time.DateOnly),
+		EndDate:       entity.EndDate().Format(time.DateOnly),
+		Days:          formatDays(entity.Days()),
+		Reason:        entity.Reason(),
+		Status:        string(status),
+		ReviewComment: entity.ReviewComment(),
+		CreatedAt:     entity.CreatedAt().Format(time.RFC3339),
+		CanReview:     status == leaverequest.StatusPending,
+		CanCancel: status == leaverequest.StatusPending ||
+			(status == leaverequest.StatusApproved && time.Now().Before(entity.StartDate())),
+	}
+}
+
+func LeaveBalanceToViewModel(balance leavetype.Balance) *viewmodels.LeaveBalance {
+	return &viewmodels.LeaveBalance{
+		LeaveType:   balance.LeaveType.Name(),
+		Limited:     balance.LeaveType.Limited(),
+		Accrued:     formatDays(balance.Accrued),
+		CarriedOver: formatDays(balance.CarriedOver),
+		Used:        formatDays(balance.Used),
+		Pending:     formatDays(balance.Pending),
+		Available:   formatDays(balance.Available()),
+	}
+}
+
+func AttendanceToViewModel(entity attendance.Attendance, employeeNames map[uint]string) *viewmodels.Attendance {
+	var checkIn, checkOut, workedHours string
+	if entity.CheckIn() != nil {
+		checkIn = entity.CheckIn().Format(attendance.TimeLayout)
+	}
+	if entity.CheckOut() != nil {
+		checkOut = entity.CheckOut().Format(attendance.TimeLayout)
+	}
+	if hours := entity.WorkedHours(); hours > 0 {
+		workedHours = strconv.FormatFloat(hours, 'f', 2, 64)
+	}
+	return &viewmodels.Attendance{
+		ID:           strconv.FormatUint(uint64(entity.ID()), 10),
+		EmployeeID:   strconv.FormatUint(uint64(entity.EmployeeID()), 10),
+		EmployeeName: employeeNames[entity.EmployeeID()],
+		Date:         entity.Date().Format(time.DateOnly),
+		Status:       string(entity.Status()),
+		CheckIn:      checkIn,
+		CheckOut:     checkOut,
+		WorkedHours:  workedHours,
+		Source:       string(entity.Source()),
+		Note:         entity.Note(),
+	}
+}
+
+func TimesheetToViewModel(entity timesheet.Timesheet, employeeNames map[uint]string) *viewmodels.Timesheet {
+	return &viewmodels.Timesheet{
+		EmployeeID:      strconv.FormatUint(uint64(entity.EmployeeID), 10),
+		EmployeeName:    employeeNames[entity.EmployeeID],
+		WorkingDays:     strconv.Itoa(entity.WorkingDays),
+		AttendedDays:    strconv.Itoa(entity.AttendedDays),
+		AbsentDays:      strconv.Itoa(entity.AbsentDays),
+		WorkedHours:     strconv.FormatFloat(entity.WorkedHours, 'f', 2, 64),
+		PaidLeaveDays:   formatDays(entity.PaidLeaveDays),
+		UnpaidLeaveDays: formatDays(entity.UnpaidLeaveDays),
+		PayableDays:     formatDays(entity.PayableDays()),
+	}
+}
+
+// TeamCalendar lays out a month of attendance and approved leaves as one row per employee
+func TeamCalendar(
+	year int,
+	month time.Month,
+	employees []employee.Employee,
+	records []attendance.Attendance,
+	leaves []leaverequest.LeaveRequest,
+	leaveTypeNames map[uint]string,
+	today time.Time,
+) ([]*viewmodels.CalendarDay, []*viewmodels.CalendarRow) {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	var days []*viewmodels.CalendarDay
+	for day := first; day.Month() == month; day = day.AddDate(0, 0, 1) {
+		days = append(days, &viewmodels.CalendarDay{
+			Date:    day.Format(time.DateOnly),
+			Day:     day.Day(),
+			Weekend: !leaverequest.IsWorkingDay(day),
+			Today:   day.Format(time.DateOnly) == today.Format(time.DateOnly),
+		})
+	}
+
+	type key struct {
+		employeeID uint
+		date       string
+	}
+	byDay := make(map[key]attendance.Attendance, len(records))
+	for _, r := range records {
+		byDay[key{r.EmployeeID(), r.Date().Format(time.DateOnly)}] = r
+	}
+
+	rows := make([]*viewmodels.CalendarRow, 0, len(employees))
+	for _, e := range employees {
+		row := &viewmodels.CalendarRow{
+			EmployeeName: EmployeeFullName(e),
+			Cells:        make([]viewmodels.CalendarCell, len(days)),
+		}
+		for i, day := range days {
+			date := first.AddDate(0, 0, i)
+			for _, l := range leaves {
+				if l.EmployeeID() == e.ID() && l.Status() == leaverequest.StatusApproved && l.Covers(date) {
+					row.Cells[i] = viewmodels.CalendarCell{Status: "leave", Title: leaveTypeNames[l.LeaveTypeID()]}
+					break
+				}
+			}
+			if row.Cells[i].Status != "" {
+				continue
+			}
+			if r, ok := byDay[key{e.ID(), day.Date}]; ok {
+				row.Cells[i] = viewmodels.CalendarCell{Status: string(r.Status()), Title: r.Note()}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return days, rows
+}
+
+func PayrollRuleToViewModel(entity payrollrule.PayrollRule) *viewmodels.PayrollRule {
+	return &viewmodels.PayrollRule{
+		ID:       strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:     entity.Name(),
+		Kind:     string(entity.Kind()),
+		Method:   string(entity.Method()),
+		Value:    strconv.FormatFloat(entity.Value(), 'f', -1, 64),
+		PreTax:   entity.PreTax(),
+		Enabled:  entity.Enabled(),
+		Position: strconv.Itoa(entity.Position()),
+	}
+}
+
+func PayslipToViewModel(p payroll.Payslip, employeeNames map[uint]string) *viewmodels.Payslip {
+	lines := make([]*viewmodels.PayslipLine, 0, len(p.Lines))
+	for _, l := range p.Lines {
+		lines = append(lines, &viewmodels.PayslipLine{
+			Kind:   string(l.Kind),
+			Name:   l.Name,
+			Amount: p.Money(l.Amount).Display(),
+		})
+	}
+	return &viewmodels.Payslip{
+		ID:           strconv.FormatUint(uint64(p.ID), 10),
+		EmployeeID:   strconv.FormatUint(uint64(p.EmployeeID), 10),
+		EmployeeName: employeeNames[p.EmployeeID],
+		Currency:     p.Currency,
+		BaseSalary:   p.Money(p.BaseSalary).Display(),
+		WorkingDays:  strconv.Itoa(p.WorkingDays),
+		PayableDays:  formatDays(p.PayableDays),
+		Earned:       p.Money(p.Earned).Display(),
+		Bonus:        p.Money(p.Bonus).Display(),
+		BonusValue:   strconv.FormatFloat(p.Money(p.Bonus).AsMajorUnits(), 'f', -1, 64),
+		Gross:        p.Money(p.Gross).Display(),
+		Taxable:      p.Money(p.Taxable).Display(),
+		Deductions:   p.Money(p.Deductions).Display(),
+		Taxes:        p.Money(p.Taxes).Display(),
+		Net:          p.Money(p.Net).Display(),
+		Lines:        lines,
+	}
+}
+
+func PayrollRunToViewModel(entity payroll.Run, employeeNames map[uint]string) *viewmodels.PayrollRun {
+	var gross, net []string
+	for _, t := range entity.Totals() {
+		gross = append(gross, t.Gross.Display())
+		net = append(net, t.Net.Display())
+	}
+	payslips := make([]*viewmodels.Payslip, 0, len(entity.Payslips()))
+	for _, p := range entity.Payslips() {
+		payslips = append(payslips, PayslipToViewModel(p, employeeNames))
+	}
+	var postedAt string
+	if entity.PostedAt() != nil {
+		postedAt = entity.PostedAt().Format(time.DateTime)
+	}
+	return &viewmodels.PayrollRun{
+		ID:        strconv.FormatUint(uint64(entity.ID()), 10),
+		Period:    entity.Period().Format("2006-01"),
+		Status:    string(entity.Status()),
+		Employees: strconv.Itoa(len(entity.Payslips())),
+		Gross:     strings.Join(gross, ", "),
+		Net:       strings.Join(net, ", "),
+		Payslips:  payslips,
+		PostedAt:  postedAt,
+		CreatedAt: entity.CreatedAt().Format(time.DateTime),
+	}
+}
+
+func formatID(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func DepartmentToViewModel(
+	entity department.Department,
+	departmentNames map[uint]string,
+	employeeNames map[uint]string,
+) *viewmodels.Department {
+	return &viewmodels.Department{
+		ID:          strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:        entity.Name(),
+		Description: entity.Description(),
+		ParentID:    formatID(entity.ParentID()),
+		ParentName:  departmentNames[entity.ParentID()],
+		ManagerID:   formatID(entity.ManagerID()),
+		ManagerName: employeeNames[entity.ManagerID()],
+	}
+}
+
+// DepartmentTreeToViewModels flattens a department tree in display order, setting the depth of each department
+func DepartmentTreeToViewModels(nodes []*department.Node, employeeNames map[uint]string) []*viewmodels.Department {
+	departmentNames := make(map[uint]string)
+	var collectNames func(nodes []*department.Node)
+	collectNames = func(nodes []*department.Node) {
+		for _, n := range nodes {
+			departmentNames[n.Department.ID()] = n.Department.Name()
+			collectNames(n.Children)
+		}
+	}
+	collectNames(nodes)
+
+	result := make([]*viewmodels.Department, 0, len(departmentNames))
+	var walk func(nodes []*department.Node, depth int)
+	walk = func(nodes []*department.Node, depth int) {
+		for _, n := range nodes {
+			vm := DepartmentToViewModel(n.Department, departmentNames, employeeNames)
+			vm.Depth = depth
+			vm.HasChildren = len(n.Children) > 0
+			result = append(result, vm)
+			walk(n.Children, depth+1)
+		}
+	}
+	walk(nodes, 0)
+	return result
+}
+
+func AssignmentToViewModel(
+	entity assignment.Assignment,
+	departmentNames map[uint]string,
+	employeeNames map[uint]string,
+) *viewmodels.Assignment {
+	var endDate string
+	if entity.EndDate() != nil {
+		endDate = entity.EndDate().Format(time.DateOnly)
+	}
+	return &viewmodels.Assignment{
+		ID:             strconv.FormatUint(uint64(entity.ID()), 10),
+		DepartmentName: departmentNames[entity.DepartmentID()],
+		ManagerName:    employeeNames[entity.ManagerID()],
+		StartDate:      entity.StartDate().Format(time.DateOnly),
+		EndDate:        endDate,
+		Note:           entity.Note(),
+		Current:        entity.Current(),
+	}
+}
+
+// OrgChartToViewModels converts the department tree into chart nodes holding the members of each department.
+// Employees outside of any department are returned as a separate list.
+func OrgChartToViewModels(
+	nodes []*department.Node,
+	members map[uint][]employee.Employee,
+	managers map[uint]uint,
+	employeeNames map[uint]string,
+) ([]*viewmodels.OrgNode, []*viewmodels.OrgMember) {
+	toMembers := func(employees []employee.Employee, managerID uint) []*viewmodels.OrgMember {
+		result := make([]*viewmodels.OrgMember, 0, len(employees))
+		for _, e := range employees {
+			m := &viewmodels.OrgMember{
+				ID:          strconv.FormatUint(uint64(e.ID()), 10),
+				Name:        employeeNames[e.ID()],
+				ManagerName: employeeNames[managers[e.ID()]],
+				IsManager:   managerID != 0 && e.ID() == managerID,
+			}
+			if m.IsManager {
+				result = append([]*viewmodels.OrgMember{m}, result...)
+			} else {
+				result = append(result, m)
+			}
+		}
+		return result
+	}
+	var convert func(nodes []*department.Node) []*viewmodels.OrgNode
+	convert = func(nodes []*department.Node) []*viewmodels.OrgNode {
+		result := make([]*viewmodels.OrgNode, 0, len(nodes))
+		for _, n := range nodes {
+			d := n.Department
+			result = append(result, &viewmodels.OrgNode{
+				Department: DepartmentToViewModel(d, nil, employeeNames),
+				Members:    toMembers(members[d.ID()], d.ManagerID()),
+				Children:   convert(n.Children),
+			})
+		}
+		return result
+	}
+	return convert(nodes), toMembers(members[0], 0)
+}
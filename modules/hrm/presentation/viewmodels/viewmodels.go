@@ -17,3 +17,182 @@ type Employee struct {
 	CreatedAt       string
 	UpdatedAt       string
 }
+
+type LeaveType struct {
+	ID              string
+	Name            string
+	Description     string
+	Paid            bool
+	AnnualAllowance string
+	Accrual         string
+	CarryOverLimit  string
+}
+
+type LeaveRequest struct {
+	ID            string
+	EmployeeID    string
+	EmployeeName  string
+	LeaveTypeID   string
+	LeaveTypeName string
+	StartDate     string
+	EndDate       string
+	Days          string
+	Reason        string
+	Status        string
+	ReviewComment string
+	CreatedAt     string
+	CanReview     bool
+	CanCancel     bool
+}
+
+type LeaveBalance struct {
+	LeaveType   string
+	Limited     bool
+	Accrued     string
+	CarriedOver string
+	Used        string
+	Pending     string
+	Available   string
+}
+
+type Attendance struct {
+	ID           string
+	EmployeeID   string
+	EmployeeName string
+	Date         string
+	Status       string
+	CheckIn      string
+	CheckOut     string
+	WorkedHours  string
+	Source       string
+	Note         string
+}
+
+type CalendarDay struct {
+	Date    string
+	Day     int
+	Weekend bool
+	Today   bool
+}
+
+type CalendarCell struct {
+	// Status is an attendance status, "leave" or empty when nothing is recorded
+	Status string
+	Title  string
+}
+
+type CalendarRow struct {
+	EmployeeName string
+	Cells        []CalendarCell
+}
+
+type Timesheet struct {
+	EmployeeID      string
+	EmployeeName    string
+	WorkingDays     string
+	AttendedDays    string
+	AbsentDays      string
+	WorkedHours     string
+	PaidLeaveDays   string
+	UnpaidLeaveDays string
+	PayableDays     string
+}
+
+type PayrollRule struct {
+	ID       string
+	Name     string
+	Kind     string
+	Method   string
+	Value    string
+	PreTax   bool
+	Enabled  bool
+	Position string
+}
+
+type PayrollRun struct {
+	ID        string
+	Period    string
+	Status    string
+	Employees string
+	// Gross and Net list the totals of every currency of the run
+	Gross     string
+	Net       string
+	Payslips  []*Payslip
+	PostedAt  string
+	CreatedAt string
+}
+
+func (r *PayrollRun) Posted() bool {
+	return r.Status == "posted"
+}
+
+type PayslipLine struct {
+	Kind   string
+	Name   string
+	Amount string
+}
+
+type Payslip struct {
+	ID           string
+	EmployeeID   string
+	EmployeeName string
+	Currency     string
+	BaseSalary   string
+	WorkingDays  string
+	PayableDays  string
+	Earned       string
+	Bonus        string
+	// BonusValue is the one-off bonus in major units for the bonus input
+	BonusValue string
+	Gross      string
+	Taxable    string
+	Deductions string
+	Taxes      string
+	Net        string
+	Lines      []*PayslipLine
+}
+
+type Department struct {
+	ID          string
+	Name        string
+	Description string
+	ParentID    string
+	ParentName  string
+	ManagerID   string
+	ManagerName string
+	// Depth is the level of the department in the tree, zero for top-level departments
+	Depth       int
+	HasChildren bool
+}
+
+type OrgMember struct {
+	ID          string
+	Name        string
+	ManagerName string
+	IsManager   bool
+}
+
+type OrgNode struct {
+	Department *Department
+	Members    []*OrgMember
+	Children   []*OrgNode
+}
+
+// Headcount is the number of employees in the department and its subdepartments
+func (n *OrgNode) Headcount() int {
+	count := len(n.Members)
+	for _, c := range n.Children {
+		count += c.Headcount()
+	}
+	return count
+}
+
+type Assignment struct {
+	ID             string
+	DepartmentName string
+	ManagerName    string
+	StartDate      string
+	EndDate        string
+	Note           string
+	Current        bool
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+)
+
+var (
+	ErrMissingEmployeeColumn = errors.New("csv has no employee column")
+	ErrMissingDateColumn     = errors.New("csv has neither a timestamp nor a date column")
+	ErrUnknownEmployee       = errors.New("unknown employee")
+)
+
+// timestampLayouts are the punch time formats exported by access-control devices
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+}
+
+// ImportRowError is a row of an attendance import that could not be read
+type ImportRowError struct {
+	Row int
+	Err error
+}
+
+func (e ImportRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// ImportResult summarizes an attendance import
+type ImportResult struct {
+	Imported int
+	Errors   []ImportRowError
+}
+
+// parseAttendanceCSV reads attendance records from a CSV file with a header row.
+// The employee column holds an employee ID or email, resolved with resolveEmployee.
+// Files with a timestamp column are punch logs: the first and last punch of a day become
+// the check-in and check-out. Otherwise every row is a day with date, check_in, check_out,
+// status and note columns.
+func parseAttendanceCSV(
+	r io.Reader,
+	resolveEmployee func(string) (uint, bool),
+) ([]attendance.Attendance, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[strings.ReplaceAll(name, " ", "_")] = i
+	}
+	if _, ok := columns["employee"]; !ok {
+		return nil, nil, ErrMissingEmployeeColumn
+	}
+	_, punchLog := columns["timestamp"]
+	if _, ok := columns["date"]; !ok && !punchLog {
+		return nil, nil, ErrMissingDateColumn
+	}
+	cell := func(row []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	type punchKey struct {
+		employeeID uint
+		date       string
+	}
+	punches := make(map[punchKey][]time.Time)
+	var records []attendance.Attendance
+	var rowErrors []ImportRowError
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: line, Err: err})
+			continue
+		}
+		employeeID, ok := resolveEmployee(cell(row, "employee"))
+		if !ok {
+			rowErrors = append(rowErrors, ImportRowError{
+				Row: line,
+				Err: fmt.Errorf("%w: %q", ErrUnknownEmployee, cell(row, "employee")),
+			})
+			continue
+		}
+		if punchLog {
+			ts, err := parseTimestamp(cell(row, "timestamp"))
+			if err != nil {
+				rowErrors = append(rowErrors, ImportRowError{Row: line, Err: err})
+				continue
+			}
+			key := punchKey{employeeID: employeeID, date: ts.Format(time.DateOnly)}
+			punches[key] = append(punches[key], ts)
+			continue
+		}
+		record, err := parseAttendanceRow(employeeID, cell(row, "date"), cell(row, "check_in"), cell(row, "check_out"), cell(row, "status"), cell(row, "note"))
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: line, Err: err})
+			continue
+		}
+		records = append(records, record)
+	}
+
+	for key, times := range punches {
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		opts := []attendance.Option{
+			attendance.WithSource(attendance.SourceImport),
+			attendance.WithCheckIn(times[0]),
+		}
+		if len(times) > 1 {
+			opts = append(opts, attendance.WithCheckOut(times[len(times)-1]))
+		}
+		record, err := attendance.New(key.employeeID, times[0], attendance.StatusPresent, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		records = append(records, record)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if !records[i].Date().Equal(records[j].Date()) {
+			return records[i].Date().Before(records[j].Date())
+		}
+		return records[i].EmployeeID() < records[j].EmployeeID()
+	})
+	return records, rowErrors, nil
+}
+
+func parseAttendanceRow(employeeID uint, date, checkIn, checkOut, status, note string) (attendance.Attendance, error) {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, err
+	}
+	if status == "" {
+		status = string(attendance.StatusPresent)
+	}
+	if !attendance.Status(status).IsValid() {
+		return nil, fmt.Errorf("invalid status %q", status)
+	}
+	opts := []attendance.Option{attendance.WithSource(attendance.SourceImport), attendance.WithNote(note)}
+	if checkIn != "" {
+		t, err := attendance.AtTime(day, checkIn)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, attendance.WithCheckIn(t))
+	}
+	if checkOut != "" {
+		t, err := attendance.AtTime(day, checkOut)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, attendance.WithCheckOut(t))
+	}
+	return attendance.New(employeeID, day, attendance.Status(status), opts...)
+}
+
+func parseTimestamp(v string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", v)
+}
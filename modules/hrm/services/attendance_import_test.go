@@ -0,0 +1,80 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+)
+
+func resolveTestEmployee(v string) (uint, bool) {
+	switch v {
+	case "1", "jane@example.com":
+		return 1, true
+	case "2":
+		return 2, true
+	}
+	return 0, false
+}
+
+func TestParseAttendanceCSV(t *testing.T) {
+	t.Run("punch log uses the first and last punch of a day", func(t *testing.T) {
+		records, rowErrors, err := parseAttendanceCSV(strings.NewReader(
+			"Employee,Timestamp\n"+
+				"jane@example.com,2025-03-03 18:05:00\n"+
+				"1,2025-03-03 08:55:00\n"+
+				"1,2025-03-03 13:00:00\n"+
+				"2,2025-03-04 09:00\n"+
+				"ghost,2025-03-04 09:00\n"+
+				"2,yesterday\n",
+		), resolveTestEmployee)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Len(t, rowErrors, 2)
+		assert.Equal(t, 6, rowErrors[0].Row)
+		assert.ErrorIs(t, rowErrors[0].Err, ErrUnknownEmployee)
+		assert.Equal(t, 7, rowErrors[1].Row)
+
+		first := records[0]
+		assert.Equal(t, uint(1), first.EmployeeID())
+		assert.Equal(t, "08:55", first.CheckIn().Format(attendance.TimeLayout))
+		assert.Equal(t, "18:05", first.CheckOut().Format(attendance.TimeLayout))
+		assert.Equal(t, attendance.SourceImport, first.Source())
+
+		second := records[1]
+		assert.Equal(t, uint(2), second.EmployeeID())
+		assert.Nil(t, second.CheckOut())
+	})
+
+	t.Run("daily rows", func(t *testing.T) {
+		records, rowErrors, err := parseAttendanceCSV(strings.NewReader(
+			"employee,date,check_in,check_out,status,note\n"+
+				"1,2025-03-03,09:00,17:30,,\n"+
+				"2,2025-03-03,,,absent,sick\n"+
+				"2,2025-03-04,18:00,09:00,,\n"+
+				"2,2025-03-05,,,vacation,\n",
+		), resolveTestEmployee)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Len(t, rowErrors, 2)
+		assert.ErrorIs(t, rowErrors[0].Err, attendance.ErrCheckOutBeforeCheckIn)
+
+		assert.Equal(t, attendance.StatusPresent, records[0].Status())
+		assert.InDelta(t, 8.5, records[0].WorkedHours(), 0.001)
+		assert.Equal(t, time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), records[0].Date())
+		assert.Equal(t, attendance.StatusAbsent, records[1].Status())
+		assert.Equal(t, "sick", records[1].Note())
+	})
+
+	t.Run("requires employee and date columns", func(t *testing.T) {
+		_, _, err := parseAttendanceCSV(strings.NewReader("date\n2025-03-03\n"), resolveTestEmployee)
+		require.ErrorIs(t, err, ErrMissingEmployeeColumn)
+
+		_, _, err = parseAttendanceCSV(strings.NewReader("employee,status\n1,present\n"), resolveTestEmployee)
+		require.ErrorIs(t, err, ErrMissingDateColumn)
+	})
+}
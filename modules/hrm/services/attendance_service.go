@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+type AttendanceService struct {
+	repo         attendance.Repository
+	employeeRepo employee.Repository
+	publisher    eventbus.EventBus
+}
+
+func NewAttendanceService(
+	repo attendance.Repository,
+	employeeRepo employee.Repository,
+	publisher eventbus.EventBus,
+) *AttendanceService {
+	return &AttendanceService{
+		repo:         repo,
+		employeeRepo: employeeRepo,
+		publisher:    publisher,
+	}
+}
+
+func (s *AttendanceService) Count(ctx context.Context, params *attendance.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *AttendanceService) GetPaginated(ctx context.Context, params *attendance.FindParams) ([]attendance.Attendance, error) {
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *AttendanceService) GetByID(ctx context.Context, id uint) (attendance.Attendance, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Record saves a manually entered day, replacing whatever was recorded for that employee and date
+func (s *AttendanceService) Record(ctx context.Context, data *attendance.CreateDTO) (attendance.Attendance, error) {
+	if err := composables.CanUser(ctx, permissions.AttendanceUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.repo.Save(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish("attendance.recorded", saved)
+	return saved, nil
+}
+
+// ImportCSV saves the attendance exported by an access-control device.
+// Rows that can not be read are skipped and reported in the result.
+func (s *AttendanceService) ImportCSV(ctx context.Context, r io.Reader) (*ImportResult, error) {
+	if err := composables.CanUser(ctx, permissions.AttendanceUpdate); err != nil {
+		return nil, err
+	}
+	employees, err := s.employeeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]uint, len(employees)*2)
+	for _, e := range employees {
+		byKey[strconv.FormatUint(uint64(e.ID()), 10)] = e.ID()
+		if e.Email() != nil {
+			byKey[strings.ToLower(e.Email().Value())] = e.ID()
+		}
+	}
+	records, rowErrors, err := parseAttendanceCSV(r, func(v string) (uint, bool) {
+		id, ok := byKey[strings.ToLower(v)]
+		return id, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if _, err := s.repo.Save(ctx, record); err != nil {
+			return nil, err
+		}
+	}
+	result := &ImportResult{Imported: len(records), Errors: rowErrors}
+	s.publisher.Publish("attendance.imported", result)
+	return result, nil
+}
+
+func (s *AttendanceService) Delete(ctx context.Context, id uint) error {
+	if err := composables.CanUser(ctx, permissions.AttendanceUpdate); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.publisher.Publish("attendance.deleted", id)
+	return nil
+}
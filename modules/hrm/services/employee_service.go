@@ -35,21 +35,21 @@ func (s *EmployeeService) GetPaginated(ctx context.Context, params *employee.Fin
 	return s.repo.GetPaginated(ctx, params)
 }
 
-func (s *EmployeeService) Create(ctx context.Context, data *employee.CreateDTO) error {
+func (s *EmployeeService) Create(ctx context.Context, data *employee.CreateDTO) (employee.Employee, error) {
 	entity, err := data.ToEntity()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	createdEntity, err := s.repo.Create(ctx, entity)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	ev, err := employee.NewCreatedEvent(ctx, *data, createdEntity)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	s.publisher.Publish(ev)
-	return nil
+	return createdEntity, nil
 }
 
 func (s *EmployeeService) Update(ctx context.Context, id uint, data *employee.UpdateDTO) error {
@@ -69,13 +69,13 @@ func (s *EmployeeService) Update(ctx context.Context, id uint, data *employee.Up
 }
 
 func (s *EmployeeService) Delete(ctx context.Context, id uint) (employee.Employee, error) {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return nil, err
-	}
 	entity, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
 	ev, err := employee.NewDeletedEvent(ctx, entity)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/leavetype"
+	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+type LeaveService struct {
+	typeRepo     leavetype.Repository
+	requestRepo  leaverequest.Repository
+	employeeRepo employee.Repository
+	orgService   *OrgService
+	publisher    eventbus.EventBus
+}
+
+func NewLeaveService(
+	typeRepo leavetype.Repository,
+	requestRepo leaverequest.Repository,
+	employeeRepo employee.Repository,
+	orgService *OrgService,
+	publisher eventbus.EventBus,
+) *LeaveService {
+	return &LeaveService{
+		typeRepo:     typeRepo,
+		requestRepo:  requestRepo,
+		employeeRepo: employeeRepo,
+		orgService:   orgService,
+		publisher:    publisher,
+	}
+}
+
+func (s *LeaveService) GetTypes(ctx context.Context) ([]leavetype.LeaveType, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveRead); err != nil {
+		return nil, err
+	}
+	return s.typeRepo.GetAll(ctx)
+}
+
+func (s *LeaveService) GetTypeByID(ctx context.Context, id uint) (leavetype.LeaveType, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveRead); err != nil {
+		return nil, err
+	}
+	return s.typeRepo.GetByID(ctx, id)
+}
+
+func (s *LeaveService) CreateType(ctx context.Context, data *leavetype.CreateDTO) (leavetype.LeaveType, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveUpdate); err != nil {
+		return nil, err
+	}
+	created, err := s.typeRepo.Create(ctx, data.ToEntity())
+	if err != nil {
+		return nil, err
+	}
+	ev, err := leavetype.NewCreatedEvent(ctx, *data, created)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(ev)
+	return created, nil
+}
+
+func (s *LeaveService) DeleteType(ctx context.Context, id uint) error {
+	if err := composables.CanUser(ctx, permissions.LeaveUpdate); err != nil {
+		return err
+	}
+	entity, err := s.typeRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.typeRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	ev, err := leavetype.NewDeletedEvent(ctx, entity)
+	if err != nil {
+		return err
+	}
+	s.publisher.Publish(ev)
+	return nil
+}
+
+func (s *LeaveService) Count(ctx context.Context, params *leaverequest.FindParams) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveRead); err != nil {
+		return 0, err
+	}
+	return s.requestRepo.Count(ctx, params)
+}
+
+func (s *LeaveService) GetPaginated(ctx context.Context, params *leaverequest.FindParams) ([]leaverequest.LeaveRequest, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveRead); err != nil {
+		return nil, err
+	}
+	return s.requestRepo.GetPaginated(ctx, params)
+}
+
+func (s *LeaveService) GetByID(ctx context.Context, id uint) (leaverequest.LeaveRequest, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveRead); err != nil {
+		return nil, err
+	}
+	return s.requestRepo.GetByID(ctx, id)
+}
+
+// Request files a leave request on behalf of the current user.
+// Users file for the employee linked to them, filing for someone else takes the permission to approve leave.
+// It is rejected when it overlaps another pending or approved request of the employee
+// or when a limited leave type has not enough days left.
+func (s *LeaveService) Request(ctx context.Context, data *leaverequest.CreateDTO) (leaverequest.LeaveRequest, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveCreate); err != nil {
+		return nil, err
+	}
+	var requestedBy uint
+	if u, err := composables.UseUser(ctx); err == nil {
+		requestedBy = u.ID()
+		if err := s.checkRequester(ctx, data.EmployeeID, u.Email().Value()); err != nil {
+			return nil, err
+		}
+	}
+	entity, err := data.ToEntity(requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	overlapping, err := s.requestRepo.Count(ctx, &leaverequest.FindParams{
+		EmployeeID: entity.EmployeeID(),
+		Statuses:   []leaverequest.Status{leaverequest.StatusPending, leaverequest.StatusApproved},
+		From:       entity.StartDate(),
+		To:         entity.EndDate(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if overlapping > 0 {
+		return nil, leaverequest.ErrOverlap
+	}
+	for year := entity.StartDate().Year(); year <= entity.EndDate().Year(); year++ {
+		balance, err := s.balance(ctx, entity.EmployeeID(), entity.LeaveTypeID(), year)
+		if err != nil {
+			return nil, err
+		}
+		if balance.LeaveType.Limited() && entity.DaysIn(year) > balance.Available() {
+			return nil, leaverequest.ErrBalanceTooLow
+		}
+	}
+	created, err := s.requestRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	ev, err := leaverequest.NewCreatedEvent(ctx, created)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(ev)
+	return created, nil
+}
+
+func (s *LeaveService) Approve(ctx context.Context, id uint, comment string) (leaverequest.LeaveRequest, error) {
+	return s.review(ctx, id, func(r leaverequest.LeaveRequest, reviewerID uint) (leaverequest.LeaveRequest, error) {
+		return r.Approve(reviewerID, comment)
+	})
+}
+
+func (s *LeaveService) Reject(ctx context.Context, id uint, comment string) (leaverequest.LeaveRequest, error) {
+	return s.review(ctx, id, func(r leaverequest.LeaveRequest, reviewerID uint) (leaverequest.LeaveRequest, error) {
+		return r.Reject(reviewerID, comment)
+	})
+}
+
+// Cancel withdraws a pending request or an approved one that has not started yet.
+// Requests filed by someone else can only be canceled by those allowed to approve leave.
+func (s *LeaveService) Cancel(ctx context.Context, id uint) (leaverequest.LeaveRequest, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveCreate); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := s.requestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.RequestedBy() != u.ID() {
+		if err := composables.CanUser(ctx, permissions.LeaveApprove); err != nil {
+			return nil, err
+		}
+	}
+	canceled, err := entity.Cancel(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return canceled, s.saveStatus(ctx, entity.Status(), canceled)
+}
+
+// Balance returns the allowance of a leave type an employee has in the given year
+func (s *LeaveService) Balance(ctx context.Context, employeeID, leaveTypeID uint, year int) (leavetype.Balance, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveRead); err != nil {
+		return leavetype.Balance{}, err
+	}
+	return s.balance(ctx, employeeID, leaveTypeID, year)
+}
+
+func (s *LeaveService) balance(ctx context.Context, employeeID, leaveTypeID uint, year int) (leavetype.Balance, error) {
+	t, err := s.typeRepo.GetByID(ctx, leaveTypeID)
+	if err != nil {
+		return leavetype.Balance{}, err
+	}
+	emp, err := s.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		return leavetype.Balance{}, err
+	}
+	requests, err := s.requestRepo.GetPaginated(ctx, &leaverequest.FindParams{
+		EmployeeID:  employeeID,
+		LeaveTypeID: leaveTypeID,
+		Statuses:    []leaverequest.Status{leaverequest.StatusPending, leaverequest.StatusApproved},
+		From:        time.Date(year-1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		return leavetype.Balance{}, err
+	}
+	var used, pending, previousUsed float64
+	for _, r := range requests {
+		switch r.Status() {
+		case leaverequest.StatusApproved:
+			used += r.DaysIn(year)
+			previousUsed += r.DaysIn(year - 1)
+		case leaverequest.StatusPending:
+			pending += r.DaysIn(year)
+		case leaverequest.StatusRejected, leaverequest.StatusCanceled:
+		}
+	}
+	return leavetype.NewBalance(t, emp.HireDate(), year, time.Now(), used, pending, previousUsed), nil
+}
+
+// Balances returns the balances of every leave type of an employee in the given year
+func (s *LeaveService) Balances(ctx context.Context, employeeID uint, year int) ([]leavetype.Balance, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveRead); err != nil {
+		return nil, err
+	}
+	types, err := s.typeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]leavetype.Balance, 0, len(types))
+	for _, t := range types {
+		b, err := s.balance(ctx, employeeID, t.ID(), year)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, b)
+	}
+	return balances, nil
+}
+
+func (s *LeaveService) review(
+	ctx context.Context,
+	id uint,
+	transition func(r leaverequest.LeaveRequest, reviewerID uint) (leaverequest.LeaveRequest, error),
+) (leaverequest.LeaveRequest, error) {
+	if err := composables.CanUser(ctx, permissions.LeaveApprove); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := s.requestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReviewer(ctx, entity, u.Email().Value()); err != nil {
+		return nil, err
+	}
+	reviewed, err := transition(entity, u.ID())
+	if err != nil {
+		return nil, err
+	}
+	return reviewed, s.saveStatus(ctx, entity.Status(), reviewed)
+}
+
+// checkRequester makes sure users file leave for themselves unless they may approve leave
+func (s *LeaveService) checkRequester(ctx context.Context, employeeID uint, requesterEmail string) error {
+	e, err := s.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		return err
+	}
+	// Users and employees are linked by their email
+	if strings.EqualFold(e.Email().Value(), requesterEmail) {
+		return nil
+	}
+	return composables.CanUser(ctx, permissions.LeaveApprove)
+}
+
+// checkReviewer makes sure the reviewer is the manager of the employee on leave.
+// Employees without a manager, like the head of the organization, can be reviewed by anyone allowed to approve leave.
+func (s *LeaveService) checkReviewer(ctx context.Context, entity leaverequest.LeaveRequest, reviewerEmail string) error {
+	manager, err := s.orgService.ManagerOf(ctx, entity.EmployeeID())
+	if errors.Is(err, ErrNoManager) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// Users and employees are linked by their email
+	if !strings.EqualFold(manager.Email().Value(), reviewerEmail) {
+		return composables.ErrForbidden
+	}
+	return nil
+}
+
+func (s *LeaveService) saveStatus(ctx context.Context, previous leaverequest.Status, entity leaverequest.LeaveRequest) error {
+	if err := s.requestRepo.Update(ctx, entity); err != nil {
+		return err
+	}
+	ev, err := leaverequest.NewStatusChangedEvent(ctx, previous, entity)
+	if err != nil {
+		return err
+	}
+	s.publisher.Publish(ev)
+	return nil
+}
@@ -0,0 +1,45 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
+	"github.com/iota-uz/iota-sdk/modules/hrm/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+func TestLeaveService_Request_ForAnotherEmployee(t *testing.T) {
+	t.Parallel()
+	u := itf.User(permissions.LeaveCreate)
+	env := itf.Setup(t, itf.WithModules(modules.BuiltInModules...), itf.WithUser(u))
+
+	employeeService := itf.GetService[services.EmployeeService](env)
+	leaveService := itf.GetService[services.LeaveService](env)
+
+	colleague, err := employeeService.Create(env.Ctx, &employee.CreateDTO{
+		FirstName: "Other",
+		LastName:  "Employee",
+		Email:     "colleague@example.com",
+		Phone:     "+998901234568",
+		Salary:    1000,
+		HireDate:  shared.DateOnly(time.Now()),
+	})
+	require.NoError(t, err)
+
+	start := time.Now().AddDate(0, 0, 7)
+	_, err = leaveService.Request(env.Ctx, &leaverequest.CreateDTO{
+		EmployeeID:  colleague.ID(),
+		LeaveTypeID: 1,
+		StartDate:   shared.DateOnly(start),
+		EndDate:     shared.DateOnly(start.AddDate(0, 0, 2)),
+	})
+	require.ErrorIs(t, err, composables.ErrForbidden)
+}
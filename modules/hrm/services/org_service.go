@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/department"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/assignment"
+	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+var (
+	ErrNoManager        = errors.New("employee has no manager")
+	ErrNoEmployeeRecord = errors.New("user has no employee record")
+	ErrNoAssignment     = errors.New("employee has no current assignment")
+)
+
+// OrgChart is the current structure of the organization
+type OrgChart struct {
+	Departments []*department.Node
+	Employees   map[uint]employee.Employee
+	// Members lists the active employees of each department, key zero holds the unassigned ones
+	Members map[uint][]employee.Employee
+	// Managers maps an employee to the one they report to
+	Managers map[uint]uint
+}
+
+type OrgService struct {
+	departmentRepo department.Repository
+	assignmentRepo assignment.Repository
+	employeeRepo   employee.Repository
+	userRepo       user.Repository
+	publisher      eventbus.EventBus
+}
+
+func NewOrgService(
+	departmentRepo department.Repository,
+	assignmentRepo assignment.Repository,
+	employeeRepo employee.Repository,
+	userRepo user.Repository,
+	publisher eventbus.EventBus,
+) *OrgService {
+	return &OrgService{
+		departmentRepo: departmentRepo,
+		assignmentRepo: assignmentRepo,
+		employeeRepo:   employeeRepo,
+		userRepo:       userRepo,
+		publisher:      publisher,
+	}
+}
+
+func (s *OrgService) GetDepartments(ctx context.Context) ([]department.Department, error) {
+	return s.departmentRepo.GetAll(ctx)
+}
+
+func (s *OrgService) GetDepartmentByID(ctx context.Context, id uint) (department.Department, error) {
+	return s.departmentRepo.GetByID(ctx, id)
+}
+
+func (s *OrgService) CreateDepartment(ctx context.Context, data *department.SaveDTO) (department.Department, error) {
+	if err := composables.CanUser(ctx, permissions.OrgStructureUpdate); err != nil {
+		return nil, err
+	}
+	if data.ParentID != 0 {
+		if _, err := s.departmentRepo.GetByID(ctx, data.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	created, err := s.departmentRepo.Create(ctx, data.ToEntity())
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish("department.created", created)
+	return created, nil
+}
+
+func (s *OrgService) UpdateDepartment(ctx context.Context, id uint, data *department.SaveDTO) (department.Department, error) {
+	if err := composables.CanUser(ctx, permissions.OrgStructureUpdate); err != nil {
+		return nil, err
+	}
+	departments, err := s.departmentRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := department.CheckParent(departments, id, data.ParentID); err != nil {
+		return nil, err
+	}
+	entity, err := s.departmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updated := data.Apply(entity)
+	if err := s.departmentRepo.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	s.publisher.Publish("department.updated", updated)
+	return updated, nil
+}
+
+func (s *OrgService) DeleteDepartment(ctx context.Context, id uint) error {
+	if err := composables.CanUser(ctx, permissions.OrgStructureUpdate); err != nil {
+		return err
+	}
+	departments, err := s.departmentRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, d := range departments {
+		if d.ParentID() == id {
+			return department.ErrHasChildren
+		}
+	}
+	if err := s.departmentRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.publisher.Publish("department.deleted", id)
+	return nil
+}
+
+// CurrentAssignment returns the open assignment of an employee, ErrNoAssignment when they have none
+func (s *OrgService) CurrentAssignment(ctx context.Context, employeeID uint) (assignment.Assignment, error) {
+	current, err := s.assignmentRepo.GetByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range current {
+		if a.Current() {
+			return a, nil
+		}
+	}
+	return nil, ErrNoAssignment
+}
+
+// History returns the assignments of an employee, latest first
+func (s *OrgService) History(ctx context.Context, employeeID uint) ([]assignment.Assignment, error) {
+	if err := composables.CanUser(ctx, permissions.OrgStructureRead); err != nil {
+		return nil, err
+	}
+	return s.assignmentRepo.GetByEmployee(ctx, employeeID)
+}
+
+// Transfer moves an employee to another department and/or manager starting from the given date.
+// The current assignment of the employee is closed on that date so the history stays continuous.
+func (s *OrgService) Transfer(ctx context.Context, data *assignment.TransferDTO) (assignment.Assignment, error) {
+	if err := composables.CanUser(ctx, permissions.OrgStructureUpdate); err != nil {
+		return nil, err
+	}
+	entity := data.ToEntity()
+	if _, err := s.employeeRepo.GetByID(ctx, entity.EmployeeID()); err != nil {
+		return nil, err
+	}
+	if entity.DepartmentID() != 0 {
+		if _, err := s.departmentRepo.GetByID(ctx, entity.DepartmentID()); err != nil {
+			return nil, err
+		}
+	}
+	current, err := s.assignmentRepo.GetCurrent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := assignment.CheckManager(current, entity.EmployeeID(), entity.ManagerID()); err != nil {
+		return nil, err
+	}
+
+	var created assignment.Assignment
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		for _, a := range current {
+			if a.EmployeeID() != entity.EmployeeID() {
+				continue
+			}
+			if entity.StartDate().Before(a.StartDate()) {
+				return assignment.ErrStartTooEarly
+			}
+			if err := s.assignmentRepo.Update(txCtx, a.End(entity.StartDate())); err != nil {
+				return err
+			}
+		}
+		var err error
+		created, err = s.assignmentRepo.Create(txCtx, entity)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish("employee.transferred", created)
+	return created, nil
+}
+
+// ManagerOf returns the employee the given one reports to. The manager set on the assignment
+// takes precedence, otherwise it is the manager of the employee's department or of the closest
+// parent department that has one.
+func (s *OrgService) ManagerOf(ctx context.Context, employeeID uint) (employee.Employee, error) {
+	managers, err := s.reportingLines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	managerID, ok := managers[employeeID]
+	if !ok {
+		return nil, ErrNoManager
+	}
+	return s.employeeRepo.GetByID(ctx, managerID)
+}
+
+// DirectReports returns the employees reporting to the given one
+func (s *OrgService) DirectReports(ctx context.Context, employeeID uint) ([]employee.Employee, error) {
+	managers, err := s.reportingLines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	employees, err := s.employeeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]employee.Employee, 0)
+	for _, e := range employees {
+		if managers[e.ID()] == employeeID {
+			reports = append(reports, e)
+		}
+	}
+	return reports, nil
+}
+
+// ApproverForUser returns the user account of the manager of the employee linked to the user.
+// Users and employees are linked by their email.
+func (s *OrgService) ApproverForUser(ctx context.Context, userID uint) (user.User, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.employeeOf(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := s.ManagerOf(ctx, e.ID())
+	if err != nil {
+		return nil, err
+	}
+	return s.userRepo.GetByEmail(ctx, manager.Email().Value())
+}
+
+// UserAttributes is the policy engine attribute provider of the module. It adds the employee_id
+// of the employee linked to the user and the department_id of their current assignment,
+// users without an employee record or assignment get none of them
+func (s *OrgService) UserAttributes(ctx context.Context, u user.User) (map[string]any, error) {
+	attrs := map[string]any{}
+	e, err := s.employeeOf(ctx, u)
+	if errors.Is(err, ErrNoEmployeeRecord) {
+		return attrs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	attrs["employee_id"] = e.ID()
+	current, err := s.CurrentAssignment(ctx, e.ID())
+	if errors.Is(err, ErrNoAssignment) {
+		return attrs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if current.DepartmentID() != 0 {
+		attrs["department_id"] = current.DepartmentID()
+	}
+	return attrs, nil
+}
+
+// employeeOf returns the employee linked to the user by email
+func (s *OrgService) employeeOf(ctx context.Context, u user.User) (employee.Employee, error) {
+	employees, err := s.employeeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range employees {
+		if strings.EqualFold(e.Email().Value(), u.Email().Value()) {
+			return e, nil
+		}
+	}
+	return nil, ErrNoEmployeeRecord
+}
+
+func (s *OrgService) Chart(ctx context.Context) (*OrgChart, error) {
+	if err := composables.CanUser(ctx, permissions.OrgStructureRead); err != nil {
+		return nil, err
+	}
+	departments, err := s.departmentRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.assignmentRepo.GetCurrent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	employees, err := s.employeeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chart := &OrgChart{
+		Departments: department.Tree(departments),
+		Employees:   make(map[uint]employee.Employee, len(employees)),
+		Members:     make(map[uint][]employee.Employee),
+		Managers:    reportingLines(current, departments),
+	}
+	memberOf := make(map[uint]uint, len(current))
+	for _, a := range current {
+		memberOf[a.EmployeeID()] = a.DepartmentID()
+	}
+	for _, d := range departments {
+		if _, ok := memberOf[d.ManagerID()]; d.ManagerID() != 0 && !ok {
+			memberOf[d.ManagerID()] = d.ID()
+		}
+	}
+	now := time.Now()
+	for _, e := range employees {
+		if e.ResignationDate() != nil && e.ResignationDate().Before(now) {
+			continue
+		}
+		chart.Employees[e.ID()] = e
+		chart.Members[memberOf[e.ID()]] = append(chart.Members[memberOf[e.ID()]], e)
+	}
+	return chart, nil
+}
+
+func (s *OrgService) reportingLines(ctx context.Context) (map[uint]uint, error) {
+	departments, err := s.departmentRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.assignmentRepo.GetCurrent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return reportingLines(current, departments), nil
+}
+
+// reportingLines resolves the manager of every employee that has one.
+// Department managers without an assignment are treated as members of the department they head.
+func reportingLines(current []assignment.Assignment, departments []department.Department) map[uint]uint {
+	byID := make(map[uint]department.Department, len(departments))
+	for _, d := range departments {
+		byID[d.ID()] = d
+	}
+	placements := make(map[uint]assignment.Assignment, len(current))
+	for _, a := range current {
+		placements[a.EmployeeID()] = a
+	}
+	for _, d := range departments {
+		if _, ok := placements[d.ManagerID()]; d.ManagerID() != 0 && !ok {
+			placements[d.ManagerID()] = assignment.New(d.ManagerID(), time.Time{}, assignment.WithDepartmentID(d.ID()))
+		}
+	}
+
+	managers := make(map[uint]uint, len(placements))
+	for employeeID, a := range placements {
+		if a.ManagerID() != 0 {
+			managers[employeeID] = a.ManagerID()
+			continue
+		}
+		d, ok := byID[a.DepartmentID()]
+		if !ok {
+			continue
+		}
+		chain := append([]department.Department{d}, department.Ancestors(departments, d.ID())...)
+		for _, c := range chain {
+			if c.ManagerID() != 0 && c.ManagerID() != employeeID {
+				managers[employeeID] = c.ManagerID()
+				break
+			}
+		}
+	}
+	return managers
+}
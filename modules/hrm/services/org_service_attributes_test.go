@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/department"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/assignment"
+	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
+	"github.com/iota-uz/iota-sdk/modules/hrm/services"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+func TestOrgService_UserAttributes(t *testing.T) {
+	t.Parallel()
+	u := itf.User(permissions.OrgStructureRead, permissions.OrgStructureUpdate)
+	env := itf.Setup(t, itf.WithModules(modules.BuiltInModules...), itf.WithUser(u))
+
+	engine := itf.GetService[rbac.Engine](env)
+	require.NotNil(t, engine)
+	orgService := itf.GetService[services.OrgService](env)
+	employeeService := itf.GetService[services.EmployeeService](env)
+
+	attrs, err := engine.Attributes(env.Ctx, u, nil, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, attrs["user"], "department_id", "the user has no employee record yet")
+
+	e, err := employeeService.Create(env.Ctx, &employee.CreateDTO{
+		FirstName: "Test",
+		LastName:  "User",
+		Email:     u.Email().Value(),
+		Phone:     "+998901234567",
+		Salary:    1000,
+		HireDate:  shared.DateOnly(time.Now()),
+	})
+	require.NoError(t, err)
+	d, err := orgService.CreateDepartment(env.Ctx, &department.SaveDTO{Name: "Sales"})
+	require.NoError(t, err)
+	_, err = orgService.Transfer(env.Ctx, &assignment.TransferDTO{
+		EmployeeID:   e.ID(),
+		DepartmentID: d.ID(),
+		StartDate:    shared.DateOnly(time.Now().AddDate(0, 0, -1)),
+	})
+	require.NoError(t, err)
+
+	attrs, err = engine.Attributes(env.Ctx, u, nil, nil)
+	require.NoError(t, err)
+	userAttrs := attrs["user"].(map[string]any)
+	assert.Equal(t, e.ID(), userAttrs["employee_id"])
+	assert.Equal(t, d.ID(), userAttrs["department_id"])
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/department"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/assignment"
+)
+
+func TestReportingLines(t *testing.T) {
+	departments := []department.Department{
+		department.New("Company", department.WithID(1), department.WithManagerID(1)),
+		department.New("Engineering", department.WithID(2), department.WithParentID(1), department.WithManagerID(2)),
+		department.New("Backend", department.WithID(3), department.WithParentID(2)),
+	}
+	now := time.Now()
+	current := []assignment.Assignment{
+		assignment.New(3, now, assignment.WithDepartmentID(3)),
+		assignment.New(4, now, assignment.WithDepartmentID(3), assignment.WithManagerID(3)),
+		assignment.New(5, now),
+	}
+
+	managers := reportingLines(current, departments)
+
+	assert.Equal(t, uint(2), managers[3], "falls back to the manager of the parent department")
+	assert.Equal(t, uint(3), managers[4], "direct manager takes precedence")
+	assert.Equal(t, uint(1), managers[2], "department head reports to the head of the parent department")
+	assert.NotContains(t, managers, uint(1))
+	assert.NotContains(t, managers, uint(5))
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	financeservices "github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/payroll"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/payrollrule"
+	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// PayrollExpenseCategory is the finance expense category salaries are posted under
+const PayrollExpenseCategory = "Payroll"
+
+var ErrFinanceUnavailable = errors.New("finance module is not loaded")
+
+type PayrollService struct {
+	runRepo          payroll.Repository
+	ruleRepo         payrollrule.Repository
+	employeeRepo     employee.Repository
+	timesheetService *TimesheetService
+	// app resolves finance services when a run is posted, the finance module is registered after hrm
+	app       application.Application
+	publisher eventbus.EventBus
+}
+
+func NewPayrollService(
+	runRepo payroll.Repository,
+	ruleRepo payrollrule.Repository,
+	employeeRepo employee.Repository,
+	timesheetService *TimesheetService,
+	app application.Application,
+) *PayrollService {
+	return &PayrollService{
+		runRepo:          runRepo,
+		ruleRepo:         ruleRepo,
+		employeeRepo:     employeeRepo,
+		timesheetService: timesheetService,
+		app:              app,
+		publisher:        app.EventPublisher(),
+	}
+}
+
+func (s *PayrollService) GetRules(ctx context.Context) ([]payrollrule.PayrollRule, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollRead); err != nil {
+		return nil, err
+	}
+	return s.ruleRepo.GetAll(ctx)
+}
+
+func (s *PayrollService) CreateRule(ctx context.Context, data *payrollrule.CreateDTO) (payrollrule.PayrollRule, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollUpdate); err != nil {
+		return nil, err
+	}
+	created, err := s.ruleRepo.Create(ctx, data.ToEntity())
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish("payroll_rule.created", created)
+	return created, nil
+}
+
+// ToggleRule enables a disabled rule and disables an enabled one
+func (s *PayrollService) ToggleRule(ctx context.Context, id uint) (payrollrule.PayrollRule, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollUpdate); err != nil {
+		return nil, err
+	}
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updated := rule.SetEnabled(!rule.Enabled())
+	if err := s.ruleRepo.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	s.publisher.Publish("payroll_rule.updated", updated)
+	return updated, nil
+}
+
+func (s *PayrollService) DeleteRule(ctx context.Context, id uint) error {
+	if err := composables.CanUser(ctx, permissions.PayrollUpdate); err != nil {
+		return err
+	}
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.publisher.Publish("payroll_rule.deleted", id)
+	return nil
+}
+
+func (s *PayrollService) Count(ctx context.Context) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollRead); err != nil {
+		return 0, err
+	}
+	return s.runRepo.Count(ctx)
+}
+
+func (s *PayrollService) GetPaginated(ctx context.Context, params *payroll.FindParams) ([]payroll.Run, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollRead); err != nil {
+		return nil, err
+	}
+	return s.runRepo.GetPaginated(ctx, params)
+}
+
+func (s *PayrollService) GetByID(ctx context.Context, id uint) (payroll.Run, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollRead); err != nil {
+		return nil, err
+	}
+	return s.runRepo.GetByID(ctx, id)
+}
+
+// Run calculates a draft payroll of every employee employed in the given month
+func (s *PayrollService) Run(ctx context.Context, year int, month time.Month) (payroll.Run, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollCreate); err != nil {
+		return nil, err
+	}
+	if _, err := s.runRepo.GetByPeriod(ctx, year, month); err == nil {
+		return nil, payroll.ErrRunExists
+	}
+	payslips, err := s.calculate(ctx, year, month, nil)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := []payroll.Option{payroll.WithTenantID(tenantID)}
+	if u, err := composables.UseUser(ctx); err == nil {
+		opts = append(opts, payroll.WithCreatedBy(u.ID()))
+	}
+	created, err := s.runRepo.Create(ctx, payroll.New(year, month, payslips, opts...))
+	if err != nil {
+		return nil, err
+	}
+	ev, err := payroll.NewCreatedEvent(ctx, created)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(ev)
+	return created, nil
+}
+
+// Recalculate refreshes the payslips of a draft run from the current salaries, timesheets and rules.
+// One-off bonuses of the run are kept.
+func (s *PayrollService) Recalculate(ctx context.Context, id uint) (payroll.Run, error) {
+	return s.updatePayslips(ctx, id, func(bonuses map[uint]int64) {})
+}
+
+// SetBonus sets the one-off bonus of a payslip in major units of its currency and recalculates the run
+func (s *PayrollService) SetBonus(ctx context.Context, runID, payslipID uint, amount float64) (payroll.Run, error) {
+	if amount < 0 {
+		return nil, fmt.Errorf("bonus must not be negative: %v", amount)
+	}
+	run, err := s.runRepo.GetByID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := run.Payslip(payslipID)
+	if err != nil {
+		return nil, err
+	}
+	return s.updatePayslips(ctx, runID, func(bonuses map[uint]int64) {
+		fraction := math.Pow10(p.Money(0).Currency().Fraction)
+		bonuses[p.EmployeeID] = int64(math.Round(amount * fraction))
+	})
+}
+
+func (s *PayrollService) Delete(ctx context.Context, id uint) error {
+	if err := composables.CanUser(ctx, permissions.PayrollDelete); err != nil {
+		return err
+	}
+	run, err := s.runRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if run.Status() == payroll.StatusPosted {
+		return payroll.ErrAlreadyPosted
+	}
+	if err := s.runRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.publisher.Publish("payroll_run.deleted", id)
+	return nil
+}
+
+// Post records the gross pay of every payslip as an expense of the given money account
+// under the payroll expense category and marks the run as posted
+func (s *PayrollService) Post(ctx context.Context, id uint, accountID uuid.UUID) (payroll.Run, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollUpdate); err != nil {
+		return nil, err
+	}
+	expenseService, categoryService, accountService, err := s.financeServices()
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.runRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	posted, err := run.Post(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	account, err := accountService.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range run.Payslips() {
+		if p.Currency != account.Balance().Currency().Code {
+			return nil, payroll.ErrCurrencyMismatch
+		}
+	}
+	employees, err := s.employeeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(employees))
+	for _, e := range employees {
+		names[e.ID()] = e.FirstName() + " " + e.LastName()
+	}
+
+	var saved payroll.Run
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		expenseCategory, err := s.payrollCategory(txCtx, categoryService, run.TenantID())
+		if err != nil {
+			return err
+		}
+		for _, p := range run.Payslips() {
+			if p.Gross == 0 {
+				continue
+			}
+			if _, err := expenseService.Create(txCtx, expense.New(
+				p.Money(p.Gross),
+				account,
+				expenseCategory,
+				*posted.PostedAt(),
+				expense.WithComment(fmt.Sprintf("Payroll %s: %s", run.Period().Format("2006-01"), names[p.EmployeeID])),
+				expense.WithAccountingPeriod(run.Period()),
+				expense.WithTenantID(run.TenantID()),
+			)); err != nil {
+				return err
+			}
+		}
+		saved, err = s.runRepo.Update(txCtx, posted)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ev, err := payroll.NewPostedEvent(ctx, saved)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(ev)
+	return saved, nil
+}
+
+// MoneyAccounts returns the finance accounts a run can be posted from
+func (s *PayrollService) MoneyAccounts(ctx context.Context) ([]moneyaccount.Account, error) {
+	_, _, accountService, err := s.financeServices()
+	if err != nil {
+		return nil, err
+	}
+	return accountService.GetAll(ctx)
+}
+
+func (s *PayrollService) updatePayslips(ctx context.Context, id uint, adjust func(bonuses map[uint]int64)) (payroll.Run, error) {
+	if err := composables.CanUser(ctx, permissions.PayrollCreate); err != nil {
+		return nil, err
+	}
+	run, err := s.runRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	bonuses := make(map[uint]int64, len(run.Payslips()))
+	for _, p := range run.Payslips() {
+		bonuses[p.EmployeeID] = p.Bonus
+	}
+	adjust(bonuses)
+	payslips, err := s.calculate(ctx, run.Year(), run.Month(), bonuses)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := run.SetPayslips(payslips)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.runRepo.Update(ctx, updated)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish("payroll_run.updated", saved)
+	return saved, nil
+}
+
+// calculate computes the payslips of every salaried employee with a timesheet in the month
+func (s *PayrollService) calculate(ctx context.Context, year int, month time.Month, bonuses map[uint]int64) ([]payroll.Payslip, error) {
+	timesheets, err := s.timesheetService.monthly(ctx, year, month)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.ruleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	employees, err := s.employeeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]employee.Employee, len(employees))
+	for _, e := range employees {
+		byID[e.ID()] = e
+	}
+
+	payslips := make([]payroll.Payslip, 0, len(timesheets))
+	for _, ts := range timesheets {
+		e, ok := byID[ts.EmployeeID]
+		if !ok || e.Salary() == nil || e.Salary().IsZero() {
+			continue
+		}
+		payslips = append(payslips, payroll.Calculate(e.Salary(), ts, rules, bonuses[ts.EmployeeID]))
+	}
+	return payslips, nil
+}
+
+func (s *PayrollService) payrollCategory(
+	ctx context.Context,
+	categoryService *financeservices.ExpenseCategoryService,
+	tenantID uuid.UUID,
+) (category.ExpenseCategory, error) {
+	categories, err := categoryService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range categories {
+		if c.Name() == PayrollExpenseCategory {
+			return c, nil
+		}
+	}
+	return categoryService.Create(ctx, category.New(
+		PayrollExpenseCategory,
+		category.WithDescription("Salaries posted from HRM payroll runs"),
+		category.WithTenantID(tenantID),
+	))
+}
+
+func (s *PayrollService) financeServices() (
+	*financeservices.ExpenseService,
+	*financeservices.ExpenseCategoryService,
+	*financeservices.MoneyAccountService,
+	error,
+) {
+	services := s.app.Services()
+	expenseService, ok := services[reflect.TypeOf(financeservices.ExpenseService{})].(*financeservices.ExpenseService)
+	if !ok {
+		return nil, nil, nil, ErrFinanceUnavailable
+	}
+	categoryService, ok := services[reflect.TypeOf(financeservices.ExpenseCategoryService{})].(*financeservices.ExpenseCategoryService)
+	if !ok {
+		return nil, nil, nil, ErrFinanceUnavailable
+	}
+	accountService, ok := services[reflect.TypeOf(financeservices.MoneyAccountService{})].(*financeservices.MoneyAccountService)
+	if !ok {
+		return nil, nil, nil, ErrFinanceUnavailable
+	}
+	return expenseService, categoryService, accountService, nil
+}
@@ -0,0 +1,14 @@
+package services_test
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	if err := os.Chdir("../../../"); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	os.Exit(code)
+}
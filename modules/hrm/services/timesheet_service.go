@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/attendance"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/leavetype"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/entities/timesheet"
+	"github.com/iota-uz/iota-sdk/modules/hrm/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type TimesheetService struct {
+	employeeRepo   employee.Repository
+	attendanceRepo attendance.Repository
+	leaveRepo      leaverequest.Repository
+	leaveTypeRepo  leavetype.Repository
+}
+
+func NewTimesheetService(
+	employeeRepo employee.Repository,
+	attendanceRepo attendance.Repository,
+	leaveRepo leaverequest.Repository,
+	leaveTypeRepo leavetype.Repository,
+) *TimesheetService {
+	return &TimesheetService{
+		employeeRepo:   employeeRepo,
+		attendanceRepo: attendanceRepo,
+		leaveRepo:      leaveRepo,
+		leaveTypeRepo:  leaveTypeRepo,
+	}
+}
+
+// Monthly returns the timesheets of every employee employed in the given month
+func (s *TimesheetService) Monthly(ctx context.Context, year int, month time.Month) ([]timesheet.Timesheet, error) {
+	if err := composables.CanUser(ctx, permissions.AttendanceRead); err != nil {
+		return nil, err
+	}
+	return s.monthly(ctx, year, month)
+}
+
+func (s *TimesheetService) monthly(ctx context.Context, year int, month time.Month) ([]timesheet.Timesheet, error) {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	employees, err := s.employeeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.attendanceRepo.GetPaginated(ctx, &attendance.FindParams{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := s.leaveRepo.GetPaginated(ctx, &leaverequest.FindParams{
+		Statuses: []leaverequest.Status{leaverequest.StatusApproved},
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.leaveTypeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paid := make(map[uint]bool, len(types))
+	for _, t := range types {
+		paid[t.ID()] = t.Paid()
+	}
+
+	timesheets := make([]timesheet.Timesheet, 0, len(employees))
+	for _, e := range employees {
+		if e.HireDate().After(to) {
+			continue
+		}
+		if resigned := e.ResignationDate(); resigned != nil && resigned.Before(from) {
+			continue
+		}
+		timesheets = append(timesheets, timesheet.Build(
+			e.ID(),
+			year,
+			month,
+			records,
+			leaves,
+			func(leaveTypeID uint) bool { return paid[leaveTypeID] },
+			time.Now(),
+		))
+	}
+	return timesheets, nil
+}
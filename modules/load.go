@@ -12,6 +12,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/hrm"
 	"github.com/iota-uz/iota-sdk/modules/logging"
 	"github.com/iota-uz/iota-sdk/modules/warehouse"
+	"github.com/iota-uz/iota-sdk/modules/webhooks"
 	"github.com/iota-uz/iota-sdk/modules/website"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 )
@@ -27,6 +28,7 @@ var (
 		crm.NewModule(),
 		website.NewModule(),
 		billing.NewModule(),
+		webhooks.NewModule(),
 	}
 
 	NavLinks = slices.Concat(
@@ -34,9 +36,11 @@ var (
 		bichat.NavItems,
 		hrm.NavItems,
 		finance.NavItems,
+		logging.NavItems,
 		warehouse.NavItems,
 		crm.NavItems,
 		website.NavItems,
+		webhooks.NavItems,
 	)
 )
 
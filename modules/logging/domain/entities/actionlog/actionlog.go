@@ -0,0 +1,56 @@
+package actionlog
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+func (a Action) IsValid() bool {
+	switch a {
+	case ActionCreate, ActionUpdate, ActionDelete:
+		return true
+	}
+	return false
+}
+
+// Snapshot is the state of an entity at the time of an action, keyed by field name
+type Snapshot map[string]any
+
+// ActionLog is an audit entry recording a change of an entity.
+// Before is empty for created entities and After is empty for deleted ones.
+type ActionLog struct {
+	ID        uint
+	TenantID  uuid.UUID
+	UserID    *uint
+	Action    Action
+	Entity    string
+	EntityID  string
+	Method    string
+	Path      string
+	Before    Snapshot
+	After     Snapshot
+	IP        string
+	UserAgent string
+	CreatedAt time.Time
+}
+
+// Changes lists the fields that differ between Before and After
+func (l *ActionLog) Changes() []Change {
+	return Diff(l.Before, l.After)
+}
+
+// Retention is how long the action logs of a tenant are kept.
+// Zero days keeps them forever.
+type Retention struct {
+	TenantID uuid.UUID
+	Days     int
+}
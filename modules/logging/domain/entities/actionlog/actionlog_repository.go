@@ -0,0 +1,49 @@
+package actionlog
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+type Field int
+
+const (
+	ID Field = iota
+	UserID
+	ActionField
+	Entity
+	EntityID
+	CreatedAt
+)
+
+type SortBy = repo.SortBy[Field]
+
+type Filter = repo.FieldFilter[Field]
+
+type FindParams struct {
+	Limit   int
+	Offset  int
+	SortBy  SortBy
+	Filters []Filter
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*ActionLog, error)
+	GetByID(ctx context.Context, id uint) (*ActionLog, error)
+	// Latest returns the most recent entry of the entity or nil if it has none
+	Latest(ctx context.Context, entity, entityID string) (*ActionLog, error)
+	// Entities lists the distinct entity names that have entries
+	Entities(ctx context.Context) ([]string, error)
+	Create(ctx context.Context, data *ActionLog) (*ActionLog, error)
+	// DeleteExpired removes the entries older than the retention of their tenant.
+	// defaultDays applies to tenants without a retention setting.
+	DeleteExpired(ctx context.Context, defaultDays int) (int64, error)
+}
+
+type RetentionRepository interface {
+	// Get returns the retention of the current tenant or nil if it is not configured
+	Get(ctx context.Context) (*Retention, error)
+	Save(ctx context.Context, data *Retention) error
+}
@@ -0,0 +1,51 @@
+package actionlog
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Change is the before and after value of a single field
+type Change struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// Added reports whether the field did not exist before
+func (c Change) Added() bool {
+	return c.Before == nil && c.After != nil
+}
+
+// Removed reports whether the field does not exist after
+func (c Change) Removed() bool {
+	return c.Before != nil && c.After == nil
+}
+
+// Diff returns the fields whose values differ between two snapshots, sorted by field name
+func Diff(before, after Snapshot) []Change {
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+
+	changes := make([]Change, 0, len(fields))
+	for field := range fields {
+		b, a := before[field], after[field]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, Change{
+			Field:  field,
+			Before: b,
+			After:  a,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
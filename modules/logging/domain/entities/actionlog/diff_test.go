@@ -0,0 +1,56 @@
+package actionlog_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iota-uz/iota-sdk/modules/logging/domain/entities/actionlog"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("returns changed fields sorted by name", func(t *testing.T) {
+		changes := actionlog.Diff(
+			actionlog.Snapshot{"Name": "Old", "Amount": 10.0, "Currency": "USD"},
+			actionlog.Snapshot{"Name": "New", "Amount": 12.5, "Currency": "USD"},
+		)
+
+		assert.Equal(t, []actionlog.Change{
+			{Field: "Amount", Before: 10.0, After: 12.5},
+			{Field: "Name", Before: "Old", After: "New"},
+		}, changes)
+	})
+
+	t.Run("reports added and removed fields", func(t *testing.T) {
+		changes := actionlog.Diff(
+			actionlog.Snapshot{"Phone": "+998"},
+			actionlog.Snapshot{"Email": "a@b.c"},
+		)
+
+		assert.Len(t, changes, 2)
+		assert.True(t, changes[0].Added())
+		assert.Equal(t, "Email", changes[0].Field)
+		assert.True(t, changes[1].Removed())
+		assert.Equal(t, "Phone", changes[1].Field)
+	})
+
+	t.Run("compares nested values deeply", func(t *testing.T) {
+		changes := actionlog.Diff(
+			actionlog.Snapshot{"Tags": []any{"a", "b"}},
+			actionlog.Snapshot{"Tags": []any{"a", "b"}},
+		)
+
+		assert.Empty(t, changes)
+	})
+
+	t.Run("treats every field of a created entity as added", func(t *testing.T) {
+		log := &actionlog.ActionLog{
+			Action: actionlog.ActionCreate,
+			After:  actionlog.Snapshot{"Name": "New"},
+		}
+
+		changes := log.Changes()
+		assert.Len(t, changes, 1)
+		assert.True(t, changes[0].Added())
+	})
+}
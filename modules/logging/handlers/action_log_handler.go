@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/logging/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// ActionLogHandler records every create, update and delete event published on the event bus
+type ActionLogHandler struct {
+	pool             *pgxpool.Pool
+	actionLogService *services.ActionLogService
+}
+
+func RegisterActionLogHandler(app application.Application) *ActionLogHandler {
+	handler := &ActionLogHandler{
+		pool:             app.DB(),
+		actionLogService: app.Service(services.ActionLogService{}).(*services.ActionLogService),
+	}
+	app.EventPublisher().Subscribe(handler.onEvent)
+	return handler
+}
+
+func (h *ActionLogHandler) onEvent(event any) {
+	entry, ok := services.NewEntryFromEvent(event)
+	if !ok {
+		return
+	}
+	ctx := composables.WithTenantID(composables.WithPool(context.Background(), h.pool), entry.TenantID)
+	if err := h.actionLogService.Record(ctx, entry); err != nil {
+		configuration.Use().Logger().WithFields(logrus.Fields{
+			"tenant_id": entry.TenantID,
+			"entity":    entry.Entity,
+			"entity_id": entry.EntityID,
+			"action":    entry.Action,
+		}).WithError(err).Error("failed to record action log")
+	}
+}
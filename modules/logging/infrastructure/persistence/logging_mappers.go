@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/logging/domain/entities/actionlog"
+	"github.com/iota-uz/iota-sdk/modules/logging/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+)
+
+func toDBActionLog(entity *actionlog.ActionLog) (*models.ActionLog, error) {
+	before, err := toDBSnapshot(entity.Before)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal before snapshot")
+	}
+	after, err := toDBSnapshot(entity.After)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal after snapshot")
+	}
+	userID := sql.NullInt64{}
+	if entity.UserID != nil {
+		userID = sql.NullInt64{Int64: int64(*entity.UserID), Valid: true}
+	}
+	return &models.ActionLog{
+		ID:        entity.ID,
+		TenantID:  entity.TenantID.String(),
+		UserID:    userID,
+		Action:    string(entity.Action),
+		Entity:    entity.Entity,
+		EntityID:  entity.EntityID,
+		Method:    mapping.ValueToSQLNullString(entity.Method),
+		Path:      mapping.ValueToSQLNullString(entity.Path),
+		Before:    before,
+		After:     after,
+		IP:        entity.IP,
+		UserAgent: entity.UserAgent,
+		CreatedAt: entity.CreatedAt,
+	}, nil
+}
+
+func toDomainActionLog(dbLog *models.ActionLog) (*actionlog.ActionLog, error) {
+	tenantID, err := uuid.Parse(dbLog.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant id")
+	}
+	before, err := toDomainSnapshot(dbLog.Before)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal before snapshot")
+	}
+	after, err := toDomainSnapshot(dbLog.After)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal after snapshot")
+	}
+	var userID *uint
+	if dbLog.UserID.Valid {
+		id := uint(dbLog.UserID.Int64)
+		userID = &id
+	}
+	return &actionlog.ActionLog{
+		ID:        dbLog.ID,
+		TenantID:  tenantID,
+		UserID:    userID,
+		Action:    actionlog.Action(dbLog.Action),
+		Entity:    dbLog.Entity,
+		EntityID:  dbLog.EntityID,
+		Method:    dbLog.Method.String,
+		Path:      dbLog.Path.String,
+		Before:    before,
+		After:     after,
+		IP:        dbLog.IP,
+		UserAgent: dbLog.UserAgent,
+		CreatedAt: dbLog.CreatedAt,
+	}, nil
+}
+
+func toDBSnapshot(snapshot actionlog.Snapshot) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	return json.Marshal(snapshot)
+}
+
+func toDomainSnapshot(data []byte) (actionlog.Snapshot, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var snapshot actionlog.Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
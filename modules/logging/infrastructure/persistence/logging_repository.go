@@ -1 +1,316 @@
 package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/logging/domain/entities/actionlog"
+	"github.com/iota-uz/iota-sdk/modules/logging/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrActionLogNotFound = errors.New("action log not found")
+)
+
+const (
+	actionLogSelectQuery = `
+		SELECT al.id, al.tenant_id, al.user_id, al.action, al.entity, al.entity_id, al.method, al.path,
+		al.before, al.after, al.ip, al.user_agent, al.created_at
+		FROM action_logs al`
+
+	actionLogCountQuery = `SELECT COUNT(al.id) FROM action_logs al`
+
+	actionLogInsertQuery = `
+		INSERT INTO action_logs (tenant_id, user_id, action, entity, entity_id, method, path, before, after, ip, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at`
+
+	actionLogEntitiesQuery = `SELECT DISTINCT entity FROM action_logs WHERE tenant_id = $1 AND entity <> '' ORDER BY entity`
+
+	actionLogDeleteExpiredQuery = `
+		DELETE FROM action_logs al
+		USING (
+			SELECT t.id AS tenant_id, COALESCE(s.retention_days, $1) AS days
+			FROM tenants t LEFT JOIN action_log_settings s ON s.tenant_id = t.id
+		) r
+		WHERE al.tenant_id = r.tenant_id
+		AND r.days > 0
+		AND al.created_at < now() - make_interval(days => r.days)`
+
+	retentionSelectQuery = `SELECT tenant_id, retention_days FROM action_log_settings WHERE tenant_id = $1`
+
+	retentionUpsertQuery = `
+		INSERT INTO action_log_settings (tenant_id, retention_days, updated_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (tenant_id) DO UPDATE SET retention_days = EXCLUDED.retention_days, updated_at = now()`
+)
+
+type ActionLogRepository struct {
+	fieldMap map[actionlog.Field]string
+}
+
+func NewActionLogRepository() actionlog.Repository {
+	return &ActionLogRepository{
+		fieldMap: map[actionlog.Field]string{
+			actionlog.ID:          "al.id",
+			actionlog.UserID:      "al.user_id",
+			actionlog.ActionField: "al.action",
+			actionlog.Entity:      "al.entity",
+			actionlog.EntityID:    "al.entity_id",
+			actionlog.CreatedAt:   "al.created_at",
+		},
+	}
+}
+
+func (r *ActionLogRepository) buildFilters(ctx context.Context, params *actionlog.FindParams) ([]string, []any, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	where := []string{"al.tenant_id = $1"}
+	args := []any{tenantID}
+
+	for _, filter := range params.Filters {
+		column, ok := r.fieldMap[filter.Column]
+		if !ok {
+			return nil, nil, errors.Wrap(fmt.Errorf("unknown filter field: %v", filter.Column), "invalid filter")
+		}
+		where = append(where, filter.Filter.String(column, len(args)+1))
+		args = append(args, filter.Filter.Value()...)
+	}
+
+	return where, args, nil
+}
+
+func (r *ActionLogRepository) Count(ctx context.Context, params *actionlog.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction")
+	}
+	where, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(actionLogCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count action logs")
+	}
+	return count, nil
+}
+
+func (r *ActionLogRepository) GetPaginated(ctx context.Context, params *actionlog.FindParams) ([]*actionlog.ActionLog, error) {
+	where, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	query := repo.Join(
+		actionLogSelectQuery,
+		repo.JoinWhere(where...),
+		params.SortBy.ToSQL(r.fieldMap),
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return r.queryActionLogs(ctx, query, args...)
+}
+
+func (r *ActionLogRepository) GetByID(ctx context.Context, id uint) (*actionlog.ActionLog, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+	logs, err := r.queryActionLogs(
+		ctx,
+		repo.Join(actionLogSelectQuery, "WHERE al.id = $1 AND al.tenant_id = $2"),
+		id, tenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return nil, errors.Wrapf(ErrActionLogNotFound, "id: %d", id)
+	}
+	return logs[0], nil
+}
+
+func (r *ActionLogRepository) Latest(ctx context.Context, entity, entityID string) (*actionlog.ActionLog, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+	logs, err := r.queryActionLogs(
+		ctx,
+		repo.Join(
+			actionLogSelectQuery,
+			"WHERE al.tenant_id = $1 AND al.entity = $2 AND al.entity_id = $3",
+			"ORDER BY al.created_at DESC, al.id DESC LIMIT 1",
+		),
+		tenantID, entity, entityID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return nil, nil
+	}
+	return logs[0], nil
+}
+
+func (r *ActionLogRepository) Entities(ctx context.Context) ([]string, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+	rows, err := tx.Query(ctx, actionLogEntitiesQuery, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query action log entities")
+	}
+	defer rows.Close()
+
+	entities := make([]string, 0)
+	for rows.Next() {
+		var entity string
+		if err := rows.Scan(&entity); err != nil {
+			return nil, errors.Wrap(err, "failed to scan action log entity")
+		}
+		entities = append(entities, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+	return entities, nil
+}
+
+func (r *ActionLogRepository) Create(ctx context.Context, data *actionlog.ActionLog) (*actionlog.ActionLog, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	dbLog, err := toDBActionLog(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.QueryRow(
+		ctx,
+		actionLogInsertQuery,
+		dbLog.TenantID,
+		dbLog.UserID,
+		dbLog.Action,
+		dbLog.Entity,
+		dbLog.EntityID,
+		dbLog.Method,
+		dbLog.Path,
+		dbLog.Before,
+		dbLog.After,
+		dbLog.IP,
+		dbLog.UserAgent,
+	).Scan(&dbLog.ID, &dbLog.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "failed to insert action log")
+	}
+	return toDomainActionLog(dbLog)
+}
+
+func (r *ActionLogRepository) DeleteExpired(ctx context.Context, defaultDays int) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction")
+	}
+	tag, err := tx.Exec(ctx, actionLogDeleteExpiredQuery, defaultDays)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete expired action logs")
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *ActionLogRepository) queryActionLogs(ctx context.Context, query string, args ...any) ([]*actionlog.ActionLog, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query action logs")
+	}
+	defer rows.Close()
+
+	logs := make([]*actionlog.ActionLog, 0)
+	for rows.Next() {
+		var dbLog models.ActionLog
+		if err := rows.Scan(
+			&dbLog.ID,
+			&dbLog.TenantID,
+			&dbLog.UserID,
+			&dbLog.Action,
+			&dbLog.Entity,
+			&dbLog.EntityID,
+			&dbLog.Method,
+			&dbLog.Path,
+			&dbLog.Before,
+			&dbLog.After,
+			&dbLog.IP,
+			&dbLog.UserAgent,
+			&dbLog.CreatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan action log")
+		}
+		entity, err := toDomainActionLog(&dbLog)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+	return logs, nil
+}
+
+type RetentionRepository struct{}
+
+func NewRetentionRepository() actionlog.RetentionRepository {
+	return &RetentionRepository{}
+}
+
+func (r *RetentionRepository) Get(ctx context.Context) (*actionlog.Retention, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+	var dbSettings models.ActionLogSettings
+	if err := tx.QueryRow(ctx, retentionSelectQuery, tenantID).Scan(
+		&dbSettings.TenantID,
+		&dbSettings.RetentionDays,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get action log retention")
+	}
+	return &actionlog.Retention{
+		TenantID: tenantID,
+		Days:     dbSettings.RetentionDays,
+	}, nil
+}
+
+func (r *RetentionRepository) Save(ctx context.Context, data *actionlog.Retention) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction")
+	}
+	if _, err := tx.Exec(ctx, retentionUpsertQuery, data.TenantID.String(), data.Days); err != nil {
+		return errors.Wrap(err, "failed to save action log retention")
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ActionLog struct {
+	ID        uint
+	TenantID  string
+	UserID    sql.NullInt64
+	Action    string
+	Entity    string
+	EntityID  string
+	Method    sql.NullString
+	Path      sql.NullString
+	Before    []byte
+	After     []byte
+	IP        string
+	UserAgent string
+	CreatedAt time.Time
+}
+
+type ActionLogSettings struct {
+	TenantID      string
+	RetentionDays int
+}
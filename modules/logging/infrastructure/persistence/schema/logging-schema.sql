@@ -10,8 +10,11 @@ CREATE TABLE authentication_logs (
 CREATE TABLE action_logs (
     id serial PRIMARY KEY,
     tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
-    method varchar(255) NOT NULL,
-    path varchar(255) NOT NULL,
+    action varchar(20) NOT NULL DEFAULT '',
+    entity varchar(255) NOT NULL DEFAULT '',
+    entity_id varchar(255) NOT NULL DEFAULT '',
+    method varchar(255),
+    path varchar(255),
     user_id int REFERENCES users (id) ON DELETE SET NULL,
     after JSON,
     before JSON,
@@ -20,10 +23,20 @@ CREATE TABLE action_logs (
     created_at timestamp with time zone DEFAULT now()
 );
 
+CREATE TABLE action_log_settings (
+    tenant_id uuid PRIMARY KEY REFERENCES tenants (id) ON DELETE CASCADE,
+    retention_days int NOT NULL DEFAULT 0 CHECK (retention_days >= 0),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
 CREATE INDEX action_logs_tenant_id_idx ON action_logs (tenant_id);
 
 CREATE INDEX action_log_user_id_idx ON action_logs (user_id);
 
+CREATE INDEX action_logs_entity_idx ON action_logs (tenant_id, entity, entity_id, created_at);
+
+CREATE INDEX action_logs_created_at_idx ON action_logs (created_at);
+
 CREATE INDEX authentication_logs_tenant_id_idx ON authentication_logs (tenant_id);
 
 CREATE INDEX authentication_logs_user_id_idx ON authentication_logs (user_id);
@@ -0,0 +1,21 @@
+package logging
+
+import (
+	icons "github.com/iota-uz/icons/phosphor"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/logging/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/types"
+)
+
+var ActionLogsLink = types.NavigationItem{
+	Name:        "NavigationLinks.ActionLogs",
+	Icon:        icons.ClockCounterClockwise(icons.Props{Size: "20"}),
+	Href:        "/logs",
+	Permissions: []*permission.Permission{permissions.ViewLogs},
+	Children:    nil,
+}
+
+var NavItems = []types.NavigationItem{
+	ActionLogsLink,
+}
@@ -3,7 +3,11 @@ package logging
 import (
 	"embed"
 
+	"github.com/iota-uz/iota-sdk/modules/logging/handlers"
+	"github.com/iota-uz/iota-sdk/modules/logging/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/logging/permissions"
+	"github.com/iota-uz/iota-sdk/modules/logging/presentation/controllers"
+	"github.com/iota-uz/iota-sdk/modules/logging/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 )
 
@@ -21,6 +25,17 @@ type Module struct {
 }
 
 func (m *Module) Register(app application.Application) error {
+	app.RegisterServices(
+		services.NewActionLogService(
+			persistence.NewActionLogRepository(),
+			persistence.NewRetentionRepository(),
+		),
+	)
+	app.RegisterControllers(
+		controllers.NewActionLogController(app),
+	)
+	handlers.RegisterActionLogHandler(app)
+
 	app.RBAC().Register(permissions.Permissions...)
 	app.RegisterLocaleFiles(&localeFiles)
 	app.Migrations().RegisterSchema(&migrationFiles)
@@ -28,5 +43,5 @@ func (m *Module) Register(app application.Application) error {
 }
 
 func (m *Module) Name() string {
-	return "crm"
+	return "logging"
 }
@@ -18,8 +18,16 @@ var (
 		Action:   permission.ActionRead,
 		Modifier: permission.ModifierAll,
 	}
+	ManageLogs = &permission.Permission{
+		ID:       uuid.MustParse("3f0b8d6e-5c0a-4f39-9d3e-7b1f2a6c84d1"),
+		Name:     "Logs.Manage",
+		Resource: ResourceLogs,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
 )
 
 var Permissions = []*permission.Permission{
 	ViewLogs,
+	ManageLogs,
 }
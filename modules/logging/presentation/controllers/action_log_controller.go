@@ -0,0 +1,305 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/logging/domain/entities/actionlog"
+	"github.com/iota-uz/iota-sdk/modules/logging/permissions"
+	"github.com/iota-uz/iota-sdk/modules/logging/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/logging/presentation/templates/pages/actionlogs"
+	"github.com/iota-uz/iota-sdk/modules/logging/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/logging/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+// filters kept when requesting the next page of the list
+var listFilters = []string{"UserID", "Entity", "Action", "CreatedAt.From", "CreatedAt.To"}
+
+type ActionLogController struct {
+	app      application.Application
+	basePath string
+}
+
+func NewActionLogController(app application.Application) application.Controller {
+	return &ActionLogController{
+		app:      app,
+		basePath: "/logs",
+	}
+}
+
+func (c *ActionLogController) Key() string {
+	return c.basePath
+}
+
+func (c *ActionLogController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", di.H(c.List)).Methods(http.MethodGet)
+	router.HandleFunc("/history/{entity}/{id}", di.H(c.History)).Methods(http.MethodGet)
+	router.HandleFunc("/retention", di.H(c.SaveRetention)).Methods(http.MethodPost)
+}
+
+func (c *ActionLogController) findParams(r *http.Request) *actionlog.FindParams {
+	paginationParams := composables.UsePaginated(r)
+	params := &actionlog.FindParams{
+		Limit:  paginationParams.Limit,
+		Offset: paginationParams.Offset,
+		SortBy: actionlog.SortBy{
+			Fields: []repo.SortByField[actionlog.Field]{
+				{Field: actionlog.CreatedAt, Ascending: false},
+				{Field: actionlog.ID, Ascending: false},
+			},
+		},
+	}
+	query := r.URL.Query()
+	if v, err := strconv.ParseUint(query.Get("UserID"), 10, 64); err == nil {
+		params.Filters = append(params.Filters, actionlog.Filter{
+			Column: actionlog.UserID,
+			Filter: repo.Eq(uint(v)),
+		})
+	}
+	if v := query.Get("Entity"); v != "" {
+		params.Filters = append(params.Filters, actionlog.Filter{
+			Column: actionlog.Entity,
+			Filter: repo.Eq(v),
+		})
+	}
+	if v := actionlog.Action(query.Get("Action")); v.IsValid() {
+		params.Filters = append(params.Filters, actionlog.Filter{
+			Column: actionlog.ActionField,
+			Filter: repo.Eq(string(v)),
+		})
+	}
+	if v, ok := parseDate(query.Get("CreatedAt.From")); ok {
+		params.Filters = append(params.Filters, actionlog.Filter{
+			Column: actionlog.CreatedAt,
+			Filter: repo.Gte(v),
+		})
+	}
+	if v, ok := parseDate(query.Get("CreatedAt.To")); ok {
+		params.Filters = append(params.Filters, actionlog.Filter{
+			Column: actionlog.CreatedAt,
+			Filter: repo.Lte(v),
+		})
+	}
+	return params
+}
+
+func (c *ActionLogController) List(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	actionLogService *services.ActionLogService,
+	userService *coreservices.UserService,
+) {
+	if !u.Can(permissions.ViewLogs) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	paginationParams := composables.UsePaginated(r)
+	params := c.findParams(r)
+
+	entities, err := actionLogService.GetPaginated(r.Context(), params)
+	if err != nil {
+		logger.Errorf("Error retrieving action logs: %v", err)
+		http.Error(w, "Error retrieving action logs", http.StatusInternalServerError)
+		return
+	}
+	total, err := actionLogService.Count(r.Context(), params)
+	if err != nil {
+		logger.Errorf("Error counting action logs: %v", err)
+		http.Error(w, "Error counting action logs", http.StatusInternalServerError)
+		return
+	}
+	users, err := userService.GetAll(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving users: %v", err)
+		http.Error(w, "Error retrieving users", http.StatusInternalServerError)
+		return
+	}
+
+	query := url.Values{}
+	for _, key := range listFilters {
+		if v := r.URL.Query().Get(key); v != "" {
+			query.Set(key, v)
+		}
+	}
+	props := &actionlogs.IndexPageProps{
+		Logs:    toViewModels(entities, users),
+		Query:   query,
+		Page:    paginationParams.Page,
+		PerPage: params.Limit,
+		HasMore: total > int64(paginationParams.Page*params.Limit),
+	}
+	if htmx.IsHxRequest(r) {
+		if paginationParams.Page > 1 {
+			templ.Handler(actionlogs.LogRows(props), templ.WithStreaming()).ServeHTTP(w, r)
+		} else {
+			templ.Handler(actionlogs.LogsTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+		}
+		return
+	}
+
+	entityNames, err := actionLogService.Entities(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving action log entities: %v", err)
+		http.Error(w, "Error retrieving action log entities", http.StatusInternalServerError)
+		return
+	}
+	defaultDays := configuration.Use().ActionLogs.RetentionDays
+	retention, err := actionLogService.Retention(r.Context(), defaultDays)
+	if err != nil {
+		logger.Errorf("Error retrieving action log retention: %v", err)
+		http.Error(w, "Error retrieving action log retention", http.StatusInternalServerError)
+		return
+	}
+	props.Users = make([]*viewmodels.ActionLogUser, 0, len(users))
+	for _, entity := range users {
+		props.Users = append(props.Users, mappers.UserToViewModel(entity))
+	}
+	props.Entities = entityNames
+	props.Retention = mappers.RetentionToViewModel(retention, defaultDays)
+	props.CanManage = u.Can(permissions.ManageLogs)
+	templ.Handler(actionlogs.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *ActionLogController) History(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	actionLogService *services.ActionLogService,
+	userService *coreservices.UserService,
+) {
+	if !u.Can(permissions.ViewLogs) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	vars := mux.Vars(r)
+	paginationParams := composables.UsePaginated(r)
+
+	// fetch one extra entry to know whether there is a next page
+	entities, err := actionLogService.History(
+		r.Context(),
+		vars["entity"],
+		vars["id"],
+		paginationParams.Limit+1,
+		paginationParams.Offset,
+	)
+	if err != nil {
+		logger.Errorf("Error retrieving action log history: %v", err)
+		http.Error(w, "Error retrieving action log history", http.StatusInternalServerError)
+		return
+	}
+	hasMore := len(entities) > paginationParams.Limit
+	if hasMore {
+		entities = entities[:paginationParams.Limit]
+	}
+	users, err := userService.GetAll(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving users: %v", err)
+		http.Error(w, "Error retrieving users", http.StatusInternalServerError)
+		return
+	}
+
+	props := &actionlogs.HistoryProps{
+		Logs:    toViewModels(entities, users),
+		BaseURL: fmt.Sprintf("%s/history/%s/%s", c.basePath, url.PathEscape(vars["entity"]), url.PathEscape(vars["id"])),
+		Page:    paginationParams.Page,
+		PerPage: paginationParams.Limit,
+		HasMore: hasMore,
+	}
+	if paginationParams.Page > 1 {
+		templ.Handler(actionlogs.HistoryRows(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(actionlogs.History(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *ActionLogController) SaveRetention(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	actionLogService *services.ActionLogService,
+) {
+	if !u.Can(permissions.ManageLogs) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defaultDays := configuration.Use().ActionLogs.RetentionDays
+	props := &viewmodels.Retention{
+		Days:        r.FormValue("Days"),
+		DefaultDays: strconv.Itoa(defaultDays),
+	}
+	days, err := strconv.Atoi(props.Days)
+	if err != nil || days < 0 {
+		props.Error = intl.MustT(r.Context(), "ActionLogs.Retention.Invalid")
+		templ.Handler(actionlogs.RetentionForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if err := actionLogService.SaveRetention(r.Context(), days); err != nil {
+		logger.Errorf("Error saving action log retention: %v", err)
+		http.Error(w, "Error saving action log retention", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(actionlogs.RetentionForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func toViewModels(entities []*actionlog.ActionLog, users []user.User) []*viewmodels.ActionLog {
+	userNames := make(map[uint]string, len(users))
+	for _, u := range users {
+		userNames[u.ID()] = mappers.UserToViewModel(u).Name
+	}
+	result := make([]*viewmodels.ActionLog, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, mappers.ActionLogToViewModel(entity, userNames))
+	}
+	return result
+}
+
+// parseDate accepts both timestamps from the date range filter and plain dates
+func parseDate(v string) (time.Time, bool) {
+	if v == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,80 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/logging/domain/entities/actionlog"
+	"github.com/iota-uz/iota-sdk/modules/logging/presentation/viewmodels"
+)
+
+// ActionLogToViewModel maps an entry using userNames to resolve the name of its author
+func ActionLogToViewModel(entity *actionlog.ActionLog, userNames map[uint]string) *viewmodels.ActionLog {
+	var userID, userName string
+	if entity.UserID != nil {
+		userID = strconv.FormatUint(uint64(*entity.UserID), 10)
+		userName = userNames[*entity.UserID]
+		if userName == "" {
+			userName = "#" + userID
+		}
+	}
+	changes := entity.Changes()
+	viewModelChanges := make([]viewmodels.ActionLogChange, 0, len(changes))
+	for _, change := range changes {
+		viewModelChanges = append(viewModelChanges, viewmodels.ActionLogChange{
+			Field:   change.Field,
+			Before:  formatSnapshotValue(change.Before),
+			After:   formatSnapshotValue(change.After),
+			Added:   change.Added(),
+			Removed: change.Removed(),
+		})
+	}
+	return &viewmodels.ActionLog{
+		ID:        strconv.FormatUint(uint64(entity.ID), 10),
+		Action:    string(entity.Action),
+		Entity:    entity.Entity,
+		EntityID:  entity.EntityID,
+		UserID:    userID,
+		UserName:  userName,
+		IP:        entity.IP,
+		UserAgent: entity.UserAgent,
+		CreatedAt: entity.CreatedAt.Format(time.RFC3339),
+		Changes:   viewModelChanges,
+	}
+}
+
+func UserToViewModel(entity user.User) *viewmodels.ActionLogUser {
+	return &viewmodels.ActionLogUser{
+		ID:   strconv.FormatUint(uint64(entity.ID()), 10),
+		Name: strings.TrimSpace(entity.FirstName() + " " + entity.LastName()),
+	}
+}
+
+func RetentionToViewModel(entity *actionlog.Retention, defaultDays int) *viewmodels.Retention {
+	return &viewmodels.Retention{
+		Days:        strconv.Itoa(entity.Days),
+		DefaultDays: strconv.Itoa(defaultDays),
+	}
+}
+
+func formatSnapshotValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
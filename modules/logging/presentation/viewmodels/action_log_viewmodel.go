@@ -0,0 +1,33 @@
+package viewmodels
+
+type ActionLogChange struct {
+	Field   string
+	Before  string
+	After   string
+	Added   bool
+	Removed bool
+}
+
+type ActionLog struct {
+	ID        string
+	Action    string
+	Entity    string
+	EntityID  string
+	UserID    string
+	UserName  string
+	IP        string
+	UserAgent string
+	CreatedAt string
+	Changes   []ActionLogChange
+}
+
+type ActionLogUser struct {
+	ID   string
+	Name string
+}
+
+type Retention struct {
+	Days        string
+	DefaultDays string
+	Error       string
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/modules/logging/domain/entities/actionlog"
+	"github.com/iota-uz/iota-sdk/modules/logging/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var ErrInvalidRetention = errors.New("retention must not be negative")
+
+var (
+	// event suffixes mapped to the action they record
+	eventActions = map[string]actionlog.Action{
+		"CreatedEvent": actionlog.ActionCreate,
+		"UpdatedEvent": actionlog.ActionUpdate,
+		"DeletedEvent": actionlog.ActionDelete,
+	}
+	// entities whose changes are not business data
+	ignoredEntities = map[string]struct{}{
+		"core.session": {},
+		"core.job":     {},
+	}
+	// event fields holding the changed entity, in order of preference
+	entityFields = []string{"Result", "Group", "Data"}
+	// event fields holding the user who made the change
+	actorFields = []string{"Sender", "Actor", "User"}
+)
+
+type ActionLogService struct {
+	repo          actionlog.Repository
+	retentionRepo actionlog.RetentionRepository
+}
+
+func NewActionLogService(repo actionlog.Repository, retentionRepo actionlog.RetentionRepository) *ActionLogService {
+	return &ActionLogService{
+		repo:          repo,
+		retentionRepo: retentionRepo,
+	}
+}
+
+func (s *ActionLogService) GetByID(ctx context.Context, id uint) (*actionlog.ActionLog, error) {
+	if err := composables.CanUser(ctx, permissions.ViewLogs); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ActionLogService) GetPaginated(ctx context.Context, params *actionlog.FindParams) ([]*actionlog.ActionLog, error) {
+	if err := composables.CanUser(ctx, permissions.ViewLogs); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *ActionLogService) Count(ctx context.Context, params *actionlog.FindParams) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.ViewLogs); err != nil {
+		return 0, err
+	}
+	return s.repo.Count(ctx, params)
+}
+
+func (s *ActionLogService) Entities(ctx context.Context) ([]string, error) {
+	if err := composables.CanUser(ctx, permissions.ViewLogs); err != nil {
+		return nil, err
+	}
+	return s.repo.Entities(ctx)
+}
+
+// History returns the entries of a single record, newest first
+func (s *ActionLogService) History(ctx context.Context, entity, entityID string, limit, offset int) ([]*actionlog.ActionLog, error) {
+	if err := composables.CanUser(ctx, permissions.ViewLogs); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, &actionlog.FindParams{
+		Limit:  limit,
+		Offset: offset,
+		SortBy: actionlog.SortBy{
+			Fields: []repo.SortByField[actionlog.Field]{
+				{Field: actionlog.CreatedAt, Ascending: false},
+				{Field: actionlog.ID, Ascending: false},
+			},
+		},
+		Filters: []actionlog.Filter{
+			{Column: actionlog.Entity, Filter: repo.Eq(entity)},
+			{Column: actionlog.EntityID, Filter: repo.Eq(entityID)},
+		},
+	})
+}
+
+// Retention returns the retention of the current tenant, falling back to defaultDays
+func (s *ActionLogService) Retention(ctx context.Context, defaultDays int) (*actionlog.Retention, error) {
+	if err := composables.CanUser(ctx, permissions.ViewLogs); err != nil {
+		return nil, err
+	}
+	retention, err := s.retentionRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if retention != nil {
+		return retention, nil
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &actionlog.Retention{TenantID: tenantID, Days: defaultDays}, nil
+}
+
+func (s *ActionLogService) SaveRetention(ctx context.Context, days int) error {
+	if err := composables.CanUser(ctx, permissions.ManageLogs); err != nil {
+		return err
+	}
+	if days < 0 {
+		return ErrInvalidRetention
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	return composables.InTx(ctx, func(txCtx context.Context) error {
+		return s.retentionRepo.Save(txCtx, &actionlog.Retention{TenantID: tenantID, Days: days})
+	})
+}
+
+// DeleteExpired purges the entries of all tenants that are older than their retention
+func (s *ActionLogService) DeleteExpired(ctx context.Context, defaultDays int) (int64, error) {
+	var deleted int64
+	err := composables.InTx(ctx, func(txCtx context.Context) error {
+		n, err := s.repo.DeleteExpired(txCtx, defaultDays)
+		deleted = n
+		return err
+	})
+	return deleted, err
+}
+
+// Record stores an entry built by NewEntryFromEvent. The state before an update
+// is taken from the latest entry of the same record.
+func (s *ActionLogService) Record(ctx context.Context, entry *actionlog.ActionLog) error {
+	return composables.InTx(ctx, func(txCtx context.Context) error {
+		if entry.Action != actionlog.ActionCreate && len(entry.Before) == 0 {
+			latest, err := s.repo.Latest(txCtx, entry.Entity, entry.EntityID)
+			if err != nil {
+				return err
+			}
+			if latest != nil {
+				entry.Before = latest.After
+			}
+		}
+		if entry.Action == actionlog.ActionUpdate && len(entry.Before) > 0 && len(entry.Changes()) == 0 {
+			return nil
+		}
+		_, err := s.repo.Create(txCtx, entry)
+		return err
+	})
+}
+
+// NewEntryFromEvent builds an audit entry out of a CreatedEvent, UpdatedEvent or
+// DeletedEvent published by any module. It returns false for other events.
+func NewEntryFromEvent(event any) (*actionlog.ActionLog, bool) {
+	v := reflect.ValueOf(event)
+	if !v.IsValid() {
+		return nil, false
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	action, ok := eventActions[v.Type().Name()]
+	if !ok {
+		return nil, false
+	}
+	name := entityName(v.Type().PkgPath())
+	if _, ok := ignoredEntities[name]; ok {
+		return nil, false
+	}
+	entity, ok := eventEntity(v)
+	if !ok {
+		return nil, false
+	}
+	entityID, _ := EntityID(entity)
+
+	entry := &actionlog.ActionLog{
+		Action:   action,
+		Entity:   name,
+		EntityID: entityID,
+	}
+	snapshot := NewSnapshot(entity)
+	if action == actionlog.ActionDelete {
+		entry.Before = snapshot
+	} else {
+		entry.After = snapshot
+	}
+	if old := v.FieldByName("Old" + entityFieldName(v)); old.IsValid() && action == actionlog.ActionUpdate {
+		entry.Before = NewSnapshot(old.Interface())
+	}
+
+	sess := eventSession(v)
+	if sess != nil {
+		entry.TenantID = sess.TenantID
+		entry.IP = sess.IP
+		entry.UserAgent = sess.UserAgent
+		if sess.UserID != 0 {
+			userID := sess.UserID
+			entry.UserID = &userID
+		}
+	}
+	if actor := eventActor(v); actor != nil {
+		userID := actor.ID()
+		entry.UserID = &userID
+		if entry.TenantID == uuid.Nil {
+			entry.TenantID = actor.TenantID()
+		}
+	}
+	if entry.TenantID == uuid.Nil {
+		entry.TenantID = entityTenantID(entity)
+	}
+	if entry.TenantID == uuid.Nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// entityName turns the package of an event into "<module>.<package>", e.g. "crm.client"
+func entityName(pkgPath string) string {
+	parts := strings.Split(pkgPath, "/")
+	last := parts[len(parts)-1]
+	for i, part := range parts {
+		if part == "modules" && i+1 < len(parts) {
+			return parts[i+1] + "." + last
+		}
+	}
+	return last
+}
+
+func entityFieldName(v reflect.Value) string {
+	for _, name := range entityFields {
+		if f := v.FieldByName(name); f.IsValid() {
+			return name
+		}
+	}
+	return ""
+}
+
+func eventEntity(v reflect.Value) (any, bool) {
+	for _, name := range entityFields {
+		f := v.FieldByName(name)
+		if !f.IsValid() || !f.CanInterface() || isNil(f) {
+			continue
+		}
+		if _, ok := EntityID(f.Interface()); ok {
+			return f.Interface(), true
+		}
+	}
+	return nil, false
+}
+
+func eventSession(v reflect.Value) *session.Session {
+	f := v.FieldByName("Session")
+	if !f.IsValid() || !f.CanInterface() || isNil(f) {
+		return nil
+	}
+	switch sess := f.Interface().(type) {
+	case session.Session:
+		return &sess
+	case *session.Session:
+		return sess
+	}
+	return nil
+}
+
+func eventActor(v reflect.Value) user.User {
+	for _, name := range actorFields {
+		f := v.FieldByName(name)
+		if !f.IsValid() || !f.CanInterface() || isNil(f) {
+			continue
+		}
+		if u, ok := f.Interface().(user.User); ok {
+			return u
+		}
+	}
+	return nil
+}
+
+func entityTenantID(entity any) uuid.UUID {
+	if e, ok := entity.(interface{ TenantID() uuid.UUID }); ok {
+		return e.TenantID()
+	}
+	return uuid.Nil
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// RetentionWorker periodically deletes action logs that outlived the retention of their tenant
+type RetentionWorker struct {
+	pool          *pgxpool.Pool
+	service       *ActionLogService
+	logger        *logrus.Logger
+	defaultDays   int
+	purgeInterval time.Duration
+}
+
+func NewRetentionWorker(app application.Application) *RetentionWorker {
+	conf := configuration.Use()
+	return &RetentionWorker{
+		pool:          app.DB(),
+		service:       app.Service(ActionLogService{}).(*ActionLogService),
+		logger:        conf.Logger(),
+		defaultDays:   conf.ActionLogs.RetentionDays,
+		purgeInterval: conf.ActionLogs.PurgeInterval,
+	}
+}
+
+// Run purges expired entries every purge interval until ctx is done
+func (w *RetentionWorker) Run(ctx context.Context) error {
+	if w.purgeInterval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(w.purgeInterval)
+	defer ticker.Stop()
+	for {
+		n, err := w.service.DeleteExpired(composables.WithoutTenantIsolation(composables.WithPool(ctx, w.pool)), w.defaultDays)
+		if err != nil && ctx.Err() == nil {
+			w.logger.WithError(err).Error("failed to delete expired action logs")
+		} else if n > 0 {
+			w.logger.WithField("count", n).Info("deleted expired action logs")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/logging/domain/entities/actionlog"
+)
+
+const redacted = "[redacted]"
+
+var (
+	// methods that do not describe the state of an entity
+	skippedMethods = map[string]struct{}{
+		"Events": {},
+		"Clone":  {},
+		"Copy":   {},
+		"String": {},
+		"Error":  {},
+	}
+	// fields whose values never end up in the audit trail
+	sensitiveFields = []string{"password", "secret", "token"}
+
+	timeType     = reflect.TypeOf(time.Time{})
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// NewSnapshot captures the state of an entity by calling its exported getters.
+// Nested entities are stored by ID and sensitive fields are redacted.
+// Values are normalized through JSON so that fresh snapshots compare equal to stored ones.
+func NewSnapshot(entity any) actionlog.Snapshot {
+	v := reflect.ValueOf(entity)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil
+	}
+
+	snapshot := actionlog.Snapshot{}
+	t := v.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		if _, ok := skippedMethods[method.Name]; ok {
+			continue
+		}
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) == errorType {
+			continue
+		}
+		if isSensitive(method.Name) {
+			snapshot[method.Name] = redacted
+			continue
+		}
+		value, ok := callGetter(v.Method(i))
+		if !ok {
+			continue
+		}
+		if formatted, ok := snapshotValue(value); ok {
+			snapshot[method.Name] = formatted
+		}
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, ok := snapshot[field.Name]; ok {
+				continue
+			}
+			if isSensitive(field.Name) {
+				snapshot[field.Name] = redacted
+				continue
+			}
+			if formatted, ok := snapshotValue(v.Field(i)); ok {
+				snapshot[field.Name] = formatted
+			}
+		}
+	}
+	return normalize(snapshot)
+}
+
+// EntityID returns the string form of the ID of an entity or false if it has none
+func EntityID(entity any) (string, bool) {
+	v := reflect.ValueOf(entity)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return "", false
+	}
+	method := v.MethodByName("ID")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return "", false
+	}
+	id, ok := callGetter(method)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprint(id.Interface()), true
+}
+
+func isSensitive(name string) bool {
+	lower := strings.ToLower(name)
+	for _, s := range sensitiveFields {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func callGetter(method reflect.Value) (result reflect.Value, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	return method.Call(nil)[0], true
+}
+
+func snapshotValue(v reflect.Value) (any, bool) {
+	if !v.IsValid() {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil, false
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, true
+		}
+	default:
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return nil, true
+		}
+		return t.Format(time.RFC3339), true
+	}
+	if v.Kind() == reflect.Ptr && v.Elem().Type() == timeType {
+		return snapshotValue(v.Elem())
+	}
+	// value types such as uuid.UUID have an ID method too, so they are stringified first
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Struct:
+	default:
+		if v.Type().Implements(stringerType) {
+			return v.Interface().(fmt.Stringer).String(), true
+		}
+	}
+	if id, ok := EntityID(v.Interface()); ok {
+		return id, true
+	}
+	if v.Type().Implements(stringerType) {
+		return v.Interface().(fmt.Stringer).String(), true
+	}
+
+	switch v.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return v.Interface(), true
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, true
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, false
+		}
+		items := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if item, ok := snapshotValue(v.Index(i)); ok {
+				items = append(items, item)
+			}
+		}
+		return items, true
+	default:
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil || string(data) == "{}" {
+		return nil, false
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
+
+func normalize(snapshot actionlog.Snapshot) actionlog.Snapshot {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return snapshot
+	}
+	var normalized actionlog.Snapshot
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return snapshot
+	}
+	return normalized
+}
@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/modules/logging/domain/entities/actionlog"
+	"github.com/iota-uz/iota-sdk/modules/logging/services"
+)
+
+type owner struct {
+	id uint
+}
+
+func (o *owner) ID() uint { return o.id }
+
+type status string
+
+func (s status) String() string { return "status:" + string(s) }
+
+type account struct {
+	id        uuid.UUID
+	tenantID  uuid.UUID
+	name      string
+	balance   float64
+	password  string
+	status    status
+	owner     *owner
+	members   []*owner
+	createdAt time.Time
+}
+
+func (a *account) ID() uuid.UUID        { return a.id }
+func (a *account) TenantID() uuid.UUID  { return a.tenantID }
+func (a *account) Name() string         { return a.name }
+func (a *account) Balance() float64     { return a.balance }
+func (a *account) Password() string     { return a.password }
+func (a *account) Status() status       { return a.status }
+func (a *account) Owner() *owner        { return a.owner }
+func (a *account) Members() []*owner    { return a.members }
+func (a *account) CreatedAt() time.Time { return a.createdAt }
+func (a *account) Validate() error      { return nil }
+func (a *account) Events() []any        { return nil }
+func (a *account) Broken() string       { panic("not loaded") }
+func (a *account) SetName(name string)  { a.name = name }
+
+type CreatedEvent struct {
+	Session session.Session
+	Result  *account
+}
+
+type UpdatedEvent struct {
+	Session session.Session
+	Result  *account
+}
+
+type RenamedEvent struct {
+	Result *account
+}
+
+func newAccount() *account {
+	return &account{
+		id:        uuid.MustParse("7a1c7b9e-4a1f-4f0a-9a44-3c1d6e1a2b3c"),
+		tenantID:  uuid.MustParse("0f3e0a6e-1111-4c0e-8d5a-5a7f7e0c9d21"),
+		name:      "Main",
+		balance:   12.5,
+		password:  "hunter2",
+		status:    "active",
+		owner:     &owner{id: 3},
+		members:   []*owner{{id: 4}, {id: 5}},
+		createdAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestNewSnapshot(t *testing.T) {
+	snapshot := services.NewSnapshot(newAccount())
+
+	assert.Equal(t, actionlog.Snapshot{
+		"ID":        "7a1c7b9e-4a1f-4f0a-9a44-3c1d6e1a2b3c",
+		"TenantID":  "0f3e0a6e-1111-4c0e-8d5a-5a7f7e0c9d21",
+		"Name":      "Main",
+		"Balance":   12.5,
+		"Password":  "[redacted]",
+		"Status":    "status:active",
+		"Owner":     "3",
+		"Members":   []any{"4", "5"},
+		"CreatedAt": "2024-05-01T10:00:00Z",
+	}, snapshot)
+}
+
+func TestNewSnapshot_Nil(t *testing.T) {
+	var a *account
+	assert.Nil(t, services.NewSnapshot(a))
+	assert.Nil(t, services.NewSnapshot(nil))
+}
+
+func TestNewEntryFromEvent(t *testing.T) {
+	sess := session.Session{
+		UserID:    9,
+		TenantID:  uuid.MustParse("0f3e0a6e-1111-4c0e-8d5a-5a7f7e0c9d21"),
+		IP:        "10.0.0.1",
+		UserAgent: "test",
+	}
+
+	t.Run("records created entities", func(t *testing.T) {
+		entry, ok := services.NewEntryFromEvent(&CreatedEvent{Session: sess, Result: newAccount()})
+		require.True(t, ok)
+
+		assert.Equal(t, actionlog.ActionCreate, entry.Action)
+		assert.Equal(t, "logging.services_test", entry.Entity)
+		assert.Equal(t, "7a1c7b9e-4a1f-4f0a-9a44-3c1d6e1a2b3c", entry.EntityID)
+		assert.Equal(t, sess.TenantID, entry.TenantID)
+		require.NotNil(t, entry.UserID)
+		assert.Equal(t, uint(9), *entry.UserID)
+		assert.Equal(t, "10.0.0.1", entry.IP)
+		assert.Nil(t, entry.Before)
+		assert.Equal(t, "Main", entry.After["Name"])
+	})
+
+	t.Run("falls back to the tenant of the entity", func(t *testing.T) {
+		entry, ok := services.NewEntryFromEvent(UpdatedEvent{Result: newAccount()})
+		require.True(t, ok)
+
+		assert.Equal(t, actionlog.ActionUpdate, entry.Action)
+		assert.Equal(t, sess.TenantID, entry.TenantID)
+		assert.Nil(t, entry.UserID)
+	})
+
+	t.Run("ignores other events", func(t *testing.T) {
+		_, ok := services.NewEntryFromEvent(&RenamedEvent{Result: newAccount()})
+		assert.False(t, ok)
+
+		_, ok = services.NewEntryFromEvent(&CreatedEvent{Session: sess})
+		assert.False(t, ok)
+
+		_, ok = services.NewEntryFromEvent("created")
+		assert.False(t, ok)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
 )
 
 type Option func(o *order)
@@ -42,6 +43,12 @@ func WithCreatedAt(createdAt time.Time) Option {
 	}
 }
 
+func WithCreatedBy(userID uint) Option {
+	return func(o *order) {
+		o.createdBy = userID
+	}
+}
+
 // --- Interfaces ---
 
 type Order interface {
@@ -51,10 +58,15 @@ type Order interface {
 	Status() Status
 	Items() []Item
 	CreatedAt() time.Time
+	CreatedBy() uint
+	Owner() rbac.Owner
+	// Attributes exposes the order to access policies
+	Attributes() map[string]any
 
 	Events() []interface{}
 
 	SetTenantID(tenantID uuid.UUID) Order
+	SetCreatedBy(userID uint) Order
 	AddItem(position position.Position, products ...product.Product) (Order, error)
 	Complete() (Order, error)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
 )
 
 // --- Implementation ---
@@ -33,6 +34,7 @@ type order struct {
 	status    Status
 	items     []Item
 	createdAt time.Time
+	createdBy uint
 	events    []interface{}
 }
 
@@ -60,6 +62,36 @@ func (o *order) CreatedAt() time.Time {
 	return o.createdAt
 }
 
+func (o *order) CreatedBy() uint {
+	return o.createdBy
+}
+
+func (o *order) Owner() rbac.Owner {
+	if o.createdBy == 0 {
+		return rbac.Owner{}
+	}
+	return rbac.Owner{UserIDs: []uint{o.createdBy}}
+}
+
+func (o *order) Attributes() map[string]any {
+	positionIDs := make([]uint, 0, len(o.items))
+	quantity := 0
+	for _, item := range o.items {
+		if item.Position() != nil {
+			positionIDs = append(positionIDs, item.Position().ID())
+		}
+		quantity += item.Quantity()
+	}
+	return map[string]any{
+		"id":           o.id,
+		"type":         string(o._type),
+		"status":       string(o.status),
+		"created_by":   o.createdBy,
+		"position_ids": positionIDs,
+		"quantity":     quantity,
+	}
+}
+
 func (o *order) Events() []interface{} {
 	return o.events
 }
@@ -70,6 +102,12 @@ func (o *order) SetTenantID(tenantID uuid.UUID) Order {
 	return &result
 }
 
+func (o *order) SetCreatedBy(userID uint) Order {
+	result := *o
+	result.createdBy = userID
+	return &result
+}
+
 func (o *order) AddItem(position position.Position, products ...product.Product) (Order, error) {
 	for _, p := range products {
 		if p.Status() == product.Shipped {
@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
 )
 
 func ToDBOrder(entity order.Order) (*models.WarehouseOrder, []*models.WarehouseProduct, error) {
@@ -23,6 +24,7 @@ func ToDBOrder(entity order.Order) (*models.WarehouseOrder, []*models.WarehouseP
 		TenantID:  entity.TenantID().String(),
 		Status:    string(entity.Status()),
 		Type:      string(entity.Type()),
+		CreatedBy: mapping.ValueToSQLNullInt64(int64(entity.CreatedBy())),
 		CreatedAt: entity.CreatedAt(),
 	}
 	return dbOrder, dbProducts, nil
@@ -46,6 +48,7 @@ func ToDomainOrder(dbOrder *models.WarehouseOrder) (order.Order, error) {
 		order.WithTenantID(tenantID),
 		order.WithStatus(status),
 		order.WithCreatedAt(dbOrder.CreatedAt),
+		order.WithCreatedBy(uint(dbOrder.CreatedBy.Int64)),
 	)
 	return orderEntity, nil
 }
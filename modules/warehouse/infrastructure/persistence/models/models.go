@@ -56,6 +56,7 @@ type WarehouseOrder struct {
 	TenantID  string
 	Type      string
 	Status    string
+	CreatedBy sql.NullInt64
 	CreatedAt time.Time
 }
 
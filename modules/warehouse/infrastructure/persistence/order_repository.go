@@ -9,6 +9,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/mappers"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 )
@@ -19,7 +20,7 @@ var (
 
 const (
 	orderFindQuery = `
-		SELECT id, tenant_id, type, status, created_at
+		SELECT id, tenant_id, type, status, created_by_id, created_at
 		FROM warehouse_orders wo`
 
 	orderCountQuery = `
@@ -27,8 +28,8 @@ const (
 		FROM warehouse_orders`
 
 	orderInsertQuery = `
-		INSERT INTO warehouse_orders (tenant_id, type, status, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO warehouse_orders (tenant_id, type, status, created_by_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id`
 
 	orderItemInsertQuery = `
@@ -92,6 +93,16 @@ type GormOrderRepository struct {
 	productRepo product.Repository
 }
 
+// ownerFilter narrows the query down to the orders created by the user
+// when they hold the "own" read permission only
+func ownerFilter(ctx context.Context, where []string, args []interface{}) ([]string, []interface{}) {
+	u, ok := composables.UseOwnScope(ctx, permissions.OrderRead)
+	if !ok {
+		return where, args
+	}
+	return append(where, fmt.Sprintf("created_by_id = $%d", len(args)+1)), append(args, u.ID())
+}
+
 func NewOrderRepository(productRepo product.Repository) order.Repository {
 	return &GormOrderRepository{
 		productRepo: productRepo,
@@ -104,7 +115,7 @@ func (g *GormOrderRepository) GetPaginated(ctx context.Context, params *order.Fi
 		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
 	}
 
-	where, args := []string{"wo.tenant_id = $1"}, []interface{}{tenantID}
+	where, args := ownerFilter(ctx, []string{"wo.tenant_id = $1"}, []interface{}{tenantID})
 	if params.CreatedAt.To != "" && params.CreatedAt.From != "" {
 		where, args = append(where, fmt.Sprintf("wo.created_at BETWEEN $%d and $%d", len(args)+1, len(args)+2)), append(args, params.CreatedAt.From, params.CreatedAt.To)
 	}
@@ -137,8 +148,9 @@ func (g *GormOrderRepository) Count(ctx context.Context) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	where, args := ownerFilter(ctx, []string{"tenant_id = $1"}, []interface{}{tenantID})
 	var count int64
-	if err := tx.QueryRow(ctx, orderCountQuery+" WHERE tenant_id = $1", tenantID).Scan(&count); err != nil {
+	if err := tx.QueryRow(ctx, repo.Join(orderCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -149,7 +161,8 @@ func (g *GormOrderRepository) GetAll(ctx context.Context) ([]order.Order, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
 	}
-	return g.queryOrders(ctx, orderFindQuery+" WHERE wo.tenant_id = $1", tenantID)
+	where, args := ownerFilter(ctx, []string{"wo.tenant_id = $1"}, []interface{}{tenantID})
+	return g.queryOrders(ctx, repo.Join(orderFindQuery, repo.JoinWhere(where...)), args...)
 }
 
 func (g *GormOrderRepository) GetByID(ctx context.Context, id uint) (order.Order, error) {
@@ -195,6 +208,7 @@ func (g *GormOrderRepository) Create(ctx context.Context, data order.Order) erro
 		dbOrder.TenantID,
 		dbOrder.Type,
 		dbOrder.Status,
+		dbOrder.CreatedBy,
 		dbOrder.CreatedAt,
 	).Scan(&dbOrder.ID); err != nil {
 		return err
@@ -393,6 +407,7 @@ func (g *GormOrderRepository) queryOrders(ctx context.Context, query string, arg
 			&o.TenantID,
 			&o.Type,
 			&o.Status,
+			&o.CreatedBy,
 			&o.CreatedAt,
 		); err != nil {
 			return nil, err
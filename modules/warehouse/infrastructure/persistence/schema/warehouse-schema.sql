@@ -43,6 +43,7 @@ CREATE TABLE warehouse_orders (
     tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
     type VARCHAR(255) NOT NULL,
     status varchar(255) NOT NULL,
+    created_by_id int REFERENCES users (id) ON DELETE SET NULL,
     created_at timestamp with time zone DEFAULT now()
 );
 
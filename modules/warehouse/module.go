@@ -74,6 +74,9 @@ func (m *Module) Register(app application.Application) error {
 		permissions.OrderRead,
 		permissions.OrderUpdate,
 		permissions.OrderDelete,
+		permissions.OrderReadOwn,
+		permissions.OrderUpdateOwn,
+		permissions.OrderDeleteOwn,
 		permissions.UnitCreate,
 		permissions.UnitRead,
 		permissions.UnitUpdate,
@@ -6,11 +6,12 @@ import (
 )
 
 const (
-	ResourceProduct   permission.Resource = "product"
-	ResourcePosition  permission.Resource = "position"
-	ResourceOrder     permission.Resource = "order"
-	ResourceUnit      permission.Resource = "unit"
-	ResourceInventory permission.Resource = "inventory"
+	ResourceProduct         permission.Resource = "product"
+	ResourcePosition        permission.Resource = "position"
+	ResourceOrder           permission.Resource = "order"
+	ResourceOrderCompletion permission.Resource = "order_completion"
+	ResourceUnit            permission.Resource = "unit"
+	ResourceInventory       permission.Resource = "inventory"
 )
 
 var (
@@ -98,6 +99,34 @@ var (
 		Action:   permission.ActionDelete,
 		Modifier: permission.ModifierAll,
 	}
+	OrderReadOwn = &permission.Permission{
+		ID:       uuid.MustParse("b8d1f3a5-6c9e-4e02-a7b4-1d5f9e3c8a60"),
+		Name:     "Order.ReadOwn",
+		Resource: ResourceOrder,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierOwn,
+	}
+	OrderUpdateOwn = &permission.Permission{
+		ID:       uuid.MustParse("c4e7a0b2-8f5d-4c1a-9b63-5e2a7d0f4c19"),
+		Name:     "Order.UpdateOwn",
+		Resource: ResourceOrder,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierOwn,
+	}
+	OrderDeleteOwn = &permission.Permission{
+		ID:       uuid.MustParse("d0f3c6e9-2a8b-4f75-8d16-9b4e1a7c3f82"),
+		Name:     "Order.DeleteOwn",
+		Resource: ResourceOrder,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierOwn,
+	}
+	OrderComplete = &permission.Permission{
+		ID:       uuid.MustParse("0ffce9a8-358c-41bb-b81e-8fd5f73a065c"),
+		Name:     "Order.Complete",
+		Resource: ResourceOrderCompletion,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
 	UnitCreate = &permission.Permission{
 		ID:       uuid.MustParse("1fd40255-8705-4c49-b60c-90ab66d3c344"),
 		Name:     "Unit.Create",
@@ -169,6 +198,10 @@ var Permissions = []*permission.Permission{
 	OrderRead,
 	OrderUpdate,
 	OrderDelete,
+	OrderReadOwn,
+	OrderUpdateOwn,
+	OrderDeleteOwn,
+	OrderComplete,
 	UnitCreate,
 	UnitRead,
 	UnitUpdate,
@@ -7,7 +7,9 @@ import (
 )
 
 type PositionsUploadDTO struct {
-	FileID uint `validate:"required"`
+	FileID  uint   `validate:"required"`
+	Mode    string `validate:"omitempty,oneof=insert upsert update_only"`
+	Mapping []string
 }
 
 func (d *PositionsUploadDTO) Ok(l ut.Translator) (map[string]string, bool) {
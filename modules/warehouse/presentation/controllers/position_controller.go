@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/iota-uz/go-i18n/v2/i18n"
@@ -65,6 +66,7 @@ func (c *PositionsController) Register(r *mux.Router) {
 		middleware.Authorize(),
 		middleware.RedirectNotAuthenticated(),
 		middleware.ProvideUser(),
+		middleware.RateLimit(),
 		middleware.ProvideDynamicLogo(c.app),
 		middleware.Tabs(),
 		middleware.ProvideLocalizer(c.app.Bundle()),
@@ -88,6 +90,7 @@ func (c *PositionsController) Register(r *mux.Router) {
 	setRouter.HandleFunc("", di.H(c.Create)).Methods(http.MethodPost)
 	setRouter.HandleFunc("/{id:[0-9]+}", di.H(c.Update)).Methods(http.MethodPost)
 	setRouter.HandleFunc("/{id:[0-9]+}", di.H(c.Delete)).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/import/preview", di.H(c.PreviewUpload)).Methods(http.MethodPost)
 	setRouter.HandleFunc("/import", di.H(c.HandleUpload)).Methods(http.MethodPost)
 }
 
@@ -125,6 +128,22 @@ func (c *PositionsController) GetUpload(
 	templ.Handler(layouts.Authenticated(layoutProps), templ.WithStreaming()).ServeHTTP(w, r.WithContext(ctx))
 }
 
+// PreviewUpload maps the columns of the uploaded file and shows what importing it would do without writing anything
+func (c *PositionsController) PreviewUpload(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	localizer *i18n.Localizer,
+	coreUploadService *coreservices.UploadService,
+	positionService *positionservice.PositionService,
+) {
+	dto, ok := c.parseUploadDTO(w, r, localizer)
+	if !ok {
+		return
+	}
+	c.renderImportPreview(w, r, logger, localizer, coreUploadService, positionService, dto, nil)
+}
+
 func (c *PositionsController) HandleUpload(
 	r *http.Request,
 	w http.ResponseWriter,
@@ -133,161 +152,182 @@ func (c *PositionsController) HandleUpload(
 	coreUploadService *coreservices.UploadService,
 	positionService *positionservice.PositionService,
 ) {
+	dto, ok := c.parseUploadDTO(w, r, localizer)
+	if !ok {
+		return
+	}
+	config := NewPositionImportConfigWithLocalizer(localizer)
+	importer := importpkg.NewImporter(NewUploadServiceAdapter(coreUploadService), importpkg.NewMultiFormatFileReader())
+	rowHandler := NewPositionRowHandler(positionService, importpkg.NewDefaultErrorFactory())
+
+	_, err := importer.Import(r.Context(), dto.FileID, rowHandler, c.importOptions(dto, config))
+	if err == nil {
+		shared.Redirect(w, r, c.basePath)
+		return
+	}
+
+	var validationErrors *importpkg.MultiValidationError
+	if errors.As(err, &validationErrors) {
+		c.renderImportPreview(w, r, logger, localizer, coreUploadService, positionService, dto, nil)
+		return
+	}
+	var vErr serrors.Base
+	if errors.As(err, &vErr) {
+		c.renderImportPreview(w, r, logger, localizer, coreUploadService, positionService, dto, map[string]string{
+			"validation": c.localizeImportError(r.Context(), localizer, err),
+		})
+		return
+	}
+	logger.WithError(err).Error("failed to import positions")
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func (c *PositionsController) parseUploadDTO(
+	w http.ResponseWriter,
+	r *http.Request,
+	localizer *i18n.Localizer,
+) (*dtos.PositionsUploadDTO, bool) {
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return nil, false
 	}
-	dto := dtos.PositionsUploadDTO{}
-	if err := shared.Decoder.Decode(&dto, r.Form); err != nil {
+	dto := &dtos.PositionsUploadDTO{}
+	if err := shared.Decoder.Decode(dto, r.Form); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return nil, false
 	}
 	uniLocalizer, err := intl.UseUniLocalizer(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return nil, false
 	}
-
-	config := NewPositionImportConfigWithLocalizer(localizer)
-
 	if errorsMap, ok := dto.Ok(uniLocalizer); !ok {
 		props := &importcomponents.ImportPageProps{
-			Config: config,
+			Config: NewPositionImportConfigWithLocalizer(localizer),
 			Errors: errorsMap,
 		}
-
-		// Create a namespaced component for the content
-		contentComponent := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
-			pageCtx := composables.UsePageCtx(ctx)
-			namespacedPageCtx := pageCtx.Namespace("WarehousePositions.Import")
-			namespacedCtx := composables.WithPageCtx(ctx, namespacedPageCtx)
-
-			return importcomponents.ImportContent(props).Render(namespacedCtx, w)
-		})
-
-		templ.Handler(contentComponent, templ.WithStreaming()).ServeHTTP(w, r)
-		return
+		c.renderImportComponent(w, r, importcomponents.ImportContent(props))
+		return nil, false
 	}
+	return dto, true
+}
 
-	// Create Excel processor and row handler
-	uploadServiceAdapter := NewUploadServiceAdapter(coreUploadService)
-	excelProcessor := importpkg.NewMultiErrorProcessor(
-		uploadServiceAdapter,
-		importpkg.NewExcelFileReader(),
-	)
-	rowHandler := NewPositionRowHandler(
-		positionService,
-		importpkg.NewDefaultErrorFactory(),
-	)
-
-	if err := excelProcessor.ProcessFileWithAllErrors(r.Context(), dto.FileID, rowHandler); err != nil {
-		// Check if it's a validation errors collection
-		if validationErrors, ok := err.(*importpkg.MultiValidationError); ok {
-			// Create namespaced context for error localization
-			pageCtx := composables.UsePageCtx(r.Context())
-			namespacedPageCtx := pageCtx.Namespace("WarehousePositions.Import")
-
-			// Localize all errors
-			var localizedErrors []string
-			for _, vErr := range validationErrors.Errors {
-				var localizedError string
-				switch e := vErr.(type) {
-				case *importpkg.InvalidCellError:
-					localizedError = namespacedPageCtx.T("Error.ERR_INVALID_CELL", map[string]interface{}{
-						"Row": e.Row,
-						"Col": e.Col,
-					})
-				case *importpkg.ValidationError:
-					localizedError = namespacedPageCtx.T("Error.ERR_VALIDATION", map[string]interface{}{
-						"Col":     e.Col,
-						"Value":   e.Value,
-						"RowNum":  e.RowNum,
-						"Message": e.Message,
-					})
-				default:
-					if baseErr, ok := vErr.(serrors.Base); ok {
-						localizedError = baseErr.Localize(localizer)
-					} else {
-						localizedError = vErr.Error()
-					}
-				}
-				localizedErrors = append(localizedErrors, localizedError)
-			}
-
-			// Join all errors into a single string with line breaks
-			errorsMap := make(map[string]string)
-			for i, err := range localizedErrors {
-				errorsMap[fmt.Sprintf("error_%d", i)] = err
-			}
-
-			props := &importcomponents.ImportPageProps{
-				Config: config,
-				Errors: errorsMap,
-			}
-
-			// Create a namespaced component for the content
-			contentComponent := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
-				pageCtx := composables.UsePageCtx(ctx)
-				namespacedPageCtx := pageCtx.Namespace("WarehousePositions.Import")
-				namespacedCtx := composables.WithPageCtx(ctx, namespacedPageCtx)
+func (c *PositionsController) importOptions(dto *dtos.PositionsUploadDTO, config *PositionImportConfig) importpkg.ImportOptions {
+	opts := importpkg.ImportOptions{
+		Mode:      importpkg.Mode(dto.Mode),
+		KeyColumn: config.GetKeyColumn(),
+		Mapping:   dto.Mapping,
+	}
+	if opts.Mode == "" {
+		opts.Mode = config.GetModes()[0]
+	}
+	return opts
+}
 
-				return importcomponents.ImportContent(props).Render(namespacedCtx, w)
-			})
+// renderImportPreview runs a dry-run of the import and renders the mapping and preview step.
+// Files uploaded without a mapping get one suggested from their headers.
+func (c *PositionsController) renderImportPreview(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	localizer *i18n.Localizer,
+	coreUploadService *coreservices.UploadService,
+	positionService *positionservice.PositionService,
+	dto *dtos.PositionsUploadDTO,
+	errorsMap map[string]string,
+) {
+	config := NewPositionImportConfigWithLocalizer(localizer)
+	importer := importpkg.NewImporter(NewUploadServiceAdapter(coreUploadService), importpkg.NewMultiFormatFileReader())
+	rowHandler := NewPositionRowHandler(positionService, importpkg.NewDefaultErrorFactory())
+	opts := c.importOptions(dto, config)
+	if errorsMap == nil {
+		errorsMap = map[string]string{}
+	}
 
-			templ.Handler(contentComponent, templ.WithStreaming()).ServeHTTP(w, r)
+	if len(opts.Mapping) == 0 {
+		headersOnly := opts
+		headersOnly.Mapping = nil
+		preview, err := importer.Preview(r.Context(), dto.FileID, rowHandler, headersOnly)
+		if err != nil {
+			logger.WithError(err).Error("failed to read import file")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		opts.Mapping = importpkg.AutoMapColumns(preview.Headers, config.Columns)
+	}
+	if err := opts.Mapping.Validate(config.Columns); err != nil {
+		errorsMap["mapping"] = c.localizeImportError(r.Context(), localizer, err)
+	}
 
-		// Handle single errors for backward compatibility
-		var vErr serrors.Base
-		if errors.As(err, &vErr) {
-			// Create namespaced context for error localization
-			pageCtx := composables.UsePageCtx(r.Context())
-			namespacedPageCtx := pageCtx.Namespace("WarehousePositions.Import")
-
-			// Localize the error with namespace prefix
-			var localizedError string
-			switch e := vErr.(type) {
-			case *importpkg.InvalidCellError:
-				localizedError = namespacedPageCtx.T("Error.ERR_INVALID_CELL", map[string]interface{}{
-					"Row": e.Row,
-					"Col": e.Col,
-				})
-			case *importpkg.ValidationError:
-				localizedError = namespacedPageCtx.T("Error.ERR_VALIDATION", map[string]interface{}{
-					"Col":     e.Col,
-					"Value":   e.Value,
-					"RowNum":  e.RowNum,
-					"Message": e.Message,
-				})
-			default:
-				localizedError = vErr.Localize(localizer)
-			}
+	preview, err := importer.Preview(r.Context(), dto.FileID, rowHandler, opts)
+	if err != nil {
+		logger.WithError(err).Error("failed to preview import")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rowErrors := make(map[int]string)
+	for i, row := range preview.Rows {
+		if row.Error != nil {
+			rowErrors[i] = c.localizeImportError(r.Context(), localizer, row.Error)
+		}
+	}
+	props := &importcomponents.ImportPreviewProps{
+		Config:    config,
+		FileID:    dto.FileID,
+		Options:   opts,
+		Preview:   preview,
+		RowErrors: rowErrors,
+		Errors:    errorsMap,
+	}
+	c.renderImportComponent(w, r, importcomponents.ImportPreview(props))
+}
 
-			props := &importcomponents.ImportPageProps{
-				Config: config,
-				Errors: map[string]string{
-					"validation": localizedError,
-				},
-			}
+// renderImportComponent renders import content with translations namespaced to the positions import page
+func (c *PositionsController) renderImportComponent(w http.ResponseWriter, r *http.Request, component templ.Component) {
+	contentComponent := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		pageCtx := composables.UsePageCtx(ctx)
+		namespacedPageCtx := pageCtx.Namespace("WarehousePositions.Import")
+		namespacedCtx := composables.WithPageCtx(ctx, namespacedPageCtx)
 
-			// Create a namespaced component for the content
-			contentComponent := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
-				pageCtx := composables.UsePageCtx(ctx)
-				namespacedPageCtx := pageCtx.Namespace("WarehousePositions.Import")
-				namespacedCtx := composables.WithPageCtx(ctx, namespacedPageCtx)
+		return component.Render(namespacedCtx, w)
+	})
+	templ.Handler(contentComponent, templ.WithStreaming()).ServeHTTP(w, r)
+}
 
-				return importcomponents.ImportContent(props).Render(namespacedCtx, w)
-			})
+func (c *PositionsController) localizeImportError(ctx context.Context, localizer *i18n.Localizer, err error) string {
+	namespacedPageCtx := composables.UsePageCtx(ctx).Namespace("WarehousePositions.Import")
 
-			templ.Handler(contentComponent, templ.WithStreaming()).ServeHTTP(w, r)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	var sheetErr *importpkg.SheetError
+	if errors.As(err, &sheetErr) {
+		return fmt.Sprintf("%s: %s", sheetErr.Sheet, c.localizeImportError(ctx, localizer, sheetErr.Err))
 	}
-
-	shared.Redirect(w, r, c.basePath)
+	var cellErr *importpkg.InvalidCellError
+	if errors.As(err, &cellErr) {
+		return namespacedPageCtx.T("Error.ERR_INVALID_CELL", map[string]interface{}{
+			"Row": cellErr.Row,
+			"Col": cellErr.Col,
+		})
+	}
+	var valErr *importpkg.ValidationError
+	if errors.As(err, &valErr) {
+		return namespacedPageCtx.T("Error.ERR_VALIDATION", map[string]interface{}{
+			"Col":     valErr.Col,
+			"Value":   valErr.Value,
+			"RowNum":  valErr.RowNum,
+			"Message": valErr.Message,
+		})
+	}
+	var columnErr *importpkg.MissingColumnError
+	if errors.As(err, &columnErr) {
+		return namespacedPageCtx.T("Error.ERR_MISSING_COLUMNS", map[string]interface{}{
+			"Columns": strings.Join(columnErr.Columns, ", "),
+		})
+	}
+	var baseErr serrors.Base
+	if errors.As(err, &baseErr) {
+		return baseErr.Localize(localizer)
+	}
+	return err.Error()
 }
 
 func (c *PositionsController) viewModelPositions(
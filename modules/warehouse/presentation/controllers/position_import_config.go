@@ -40,6 +40,7 @@ func newPositionImportConfig(localizer *i18n.Localizer) *PositionImportConfig {
 	config.Title = t("WarehousePositions.Import.Title")
 	config.Description = t("WarehousePositions.Import._Description")
 	config.SaveURL = "/warehouse/positions/import"
+	config.PreviewURL = "/warehouse/positions/import/preview"
 	config.LocalePrefix = "WarehousePositions.Import"
 	config.TemplateDownloadURL = "/warehouse/positions/template.xlsx"
 
@@ -67,6 +68,10 @@ func newPositionImportConfig(localizer *i18n.Localizer) *PositionImportConfig {
 		},
 	}
 
+	// Existing positions are matched by item code, updating them is the default as before
+	config.Modes = []importpkg.Mode{importpkg.ModeUpsert, importpkg.ModeInsert, importpkg.ModeUpdateOnly}
+	config.KeyColumn = 1
+
 	// Configure example rows
 	config.ExampleRows = [][]string{
 		{"Дрель Молоток N.C.V (900W)", "30232478", "шт", "1"},
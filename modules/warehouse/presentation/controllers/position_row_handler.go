@@ -2,9 +2,11 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"strings"
 
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
 	importpkg "github.com/iota-uz/iota-sdk/pkg/import"
 )
@@ -90,10 +92,39 @@ func (h *PositionRowHandler) ValidateRow(rowIndex int, row []string) error {
 
 // ProcessRow processes a validated row
 func (h *PositionRowHandler) ProcessRow(ctx context.Context, rowIndex int, row []string) error {
+	xlsRow, err := h.toXlsRow(rowIndex, row)
+	if err != nil {
+		return err
+	}
+
+	// Use the existing position service logic
+	return h.positionService.CreatePositionFromXlsRow(ctx, xlsRow)
+}
+
+// RecordExists reports whether a position with the given item code exists
+func (h *PositionRowHandler) RecordExists(ctx context.Context, key string) (bool, error) {
+	_, err := h.positionService.GetByBarcode(ctx, key)
+	if errors.Is(err, persistence.ErrPositionNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// UpdateRow updates the position matching the item code of the row
+func (h *PositionRowHandler) UpdateRow(ctx context.Context, rowIndex int, row []string) error {
+	xlsRow, err := h.toXlsRow(rowIndex, row)
+	if err != nil {
+		return err
+	}
+	return h.positionService.UpdatePositionFromXlsRow(ctx, xlsRow)
+}
+
+// toXlsRow converts a row to the XlsRow format that the existing service expects
+func (h *PositionRowHandler) toXlsRow(rowIndex int, row []string) (*positionservice.XlsRow, error) {
 	// Parse quantity to int
 	quantity, err := strconv.Atoi(strings.TrimSpace(row[3]))
 	if err != nil {
-		return h.errorFactory.NewValidationError(
+		return nil, h.errorFactory.NewValidationError(
 			"D",
 			row[3],
 			uint(rowIndex),
@@ -101,14 +132,10 @@ func (h *PositionRowHandler) ProcessRow(ctx context.Context, rowIndex int, row [
 		)
 	}
 
-	// Convert row to XlsRow format that the existing service expects
-	xlsRow := &positionservice.XlsRow{
+	return &positionservice.XlsRow{
 		Title:    strings.TrimSpace(row[0]),
 		Barcode:  strings.TrimSpace(row[1]),
 		Unit:     strings.ToLower(strings.TrimSpace(row[2])),
 		Quantity: quantity,
-	}
-
-	// Use the existing position service logic
-	return h.positionService.CreatePositionFromXlsRow(ctx, xlsRow)
+	}, nil
 }
@@ -29,21 +29,28 @@ func NewOrderService(
 }
 
 func (s *OrderService) GetByID(ctx context.Context, id uint) (order.Order, error) {
-	if err := composables.CanUser(ctx, permissions.OrderRead); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.OrderRead); err != nil {
 		return nil, err
 	}
-	return s.repo.GetByID(ctx, id)
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := composables.CanUserAccess(ctx, permissions.OrderRead, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
 }
 
 func (s *OrderService) GetAll(ctx context.Context) ([]order.Order, error) {
-	if err := composables.CanUser(ctx, permissions.OrderRead); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.OrderRead); err != nil {
 		return nil, err
 	}
 	return s.repo.GetAll(ctx)
 }
 
 func (s *OrderService) GetPaginated(ctx context.Context, params *order.FindParams) ([]order.Order, error) {
-	if err := composables.CanUser(ctx, permissions.OrderRead); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.OrderRead); err != nil {
 		return nil, err
 	}
 	return s.repo.GetPaginated(ctx, params)
@@ -61,6 +68,9 @@ func (s *OrderService) Create(ctx context.Context, data order.CreateDTO) error {
 	if err != nil {
 		return err
 	}
+	if u, err := composables.UseUser(ctx); err == nil {
+		entity = entity.SetCreatedBy(u.ID())
+	}
 	if err := s.repo.Create(ctx, entity); err != nil {
 		return err
 	}
@@ -68,13 +78,16 @@ func (s *OrderService) Create(ctx context.Context, data order.CreateDTO) error {
 }
 
 func (s *OrderService) Complete(ctx context.Context, id uint) (order.Order, error) {
-	//if err := composables.CanUser(ctx, permissions.OrderComplete); err != nil {
-	//	return err
-	//}
+	if err := composables.CanUser(ctx, permissions.OrderComplete); err != nil {
+		return nil, err
+	}
 	entity, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := composables.Authorize(ctx, permissions.OrderComplete, entity); err != nil {
+		return nil, err
+	}
 	completedEntity, err := entity.Complete()
 	if err != nil {
 		return nil, err
@@ -86,7 +99,14 @@ func (s *OrderService) Complete(ctx context.Context, id uint) (order.Order, erro
 }
 
 func (s *OrderService) Update(ctx context.Context, id uint, data order.UpdateDTO) error {
-	if err := composables.CanUser(ctx, permissions.OrderUpdate); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.OrderUpdate); err != nil {
+		return err
+	}
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := composables.Authorize(ctx, permissions.OrderUpdate, existing); err != nil {
 		return err
 	}
 	entity, err := data.ToEntity(id)
@@ -100,13 +120,16 @@ func (s *OrderService) Update(ctx context.Context, id uint, data order.UpdateDTO
 }
 
 func (s *OrderService) Delete(ctx context.Context, id uint) (order.Order, error) {
-	if err := composables.CanUser(ctx, permissions.OrderDelete); err != nil {
+	if err := composables.CanUserScoped(ctx, permissions.OrderDelete); err != nil {
 		return nil, err
 	}
 	entity, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := composables.Authorize(ctx, permissions.OrderDelete, entity); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return nil, err
 	}
@@ -46,6 +46,13 @@ func (s *PositionService) GetByID(ctx context.Context, id uint) (position.Positi
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *PositionService) GetByBarcode(ctx context.Context, barcode string) (position.Position, error) {
+	if err := composables.CanUser(ctx, permissions.PositionRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByBarcode(ctx, barcode)
+}
+
 func (s *PositionService) GetAll(ctx context.Context) ([]position.Position, error) {
 	if err := composables.CanUser(ctx, permissions.PositionRead); err != nil {
 		return nil, err
@@ -184,6 +191,23 @@ func (s *PositionService) CreatePositionFromXlsRow(ctx context.Context, xlsRow *
 	}
 }
 
+// UpdatePositionFromXlsRow updates the position with the barcode of the row. Quantities are not changed.
+func (s *PositionService) UpdatePositionFromXlsRow(ctx context.Context, xlsRow *XlsRow) error {
+	entity, err := s.repo.GetByBarcode(ctx, xlsRow.Barcode)
+	if err != nil {
+		return err
+	}
+	unitEntity, err := s.findOrCreateUnit(ctx, xlsRow.Unit)
+	if err != nil {
+		return err
+	}
+	return s.Update(ctx, entity.ID(), &position.UpdateDTO{
+		Title:   xlsRow.Title,
+		UnitID:  unitEntity.ID,
+		Barcode: xlsRow.Barcode,
+	})
+}
+
 func (s *PositionService) Create(ctx context.Context, data *position.CreateDTO) (position.Position, error) {
 	if err := composables.CanUser(ctx, permissions.PositionCreate); err != nil {
 		return nil, err
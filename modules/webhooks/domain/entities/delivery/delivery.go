@@ -0,0 +1,100 @@
+package delivery
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSending   Status = "sending"
+	StatusDelivered Status = "delivered"
+	StatusFailed    Status = "failed"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusSending, StatusDelivered, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// maxResponseBody limits how much of a response is kept in the delivery log
+const maxResponseBody = 4096
+
+// Delivery is a single event sent to an endpoint. Failed attempts are retried at NextAttemptAt
+// until the delivery succeeds or runs out of attempts.
+type Delivery struct {
+	ID         uint
+	TenantID   uuid.UUID
+	EndpointID uint
+	// EventID is shared by all deliveries of an event, including replays
+	EventID   uuid.UUID
+	EventType string
+	Payload   []byte
+	Status    Status
+	Attempts  int
+	// NextAttemptAt is when a pending delivery is due
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// New creates a delivery that is due right away
+func New(tenantID uuid.UUID, endpointID uint, eventID uuid.UUID, eventType string, payload []byte) *Delivery {
+	now := time.Now()
+	return &Delivery{
+		TenantID:      tenantID,
+		EndpointID:    endpointID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Replay creates a new delivery of the same event to the same endpoint
+func (d *Delivery) Replay() *Delivery {
+	return New(d.TenantID, d.EndpointID, d.EventID, d.EventType, d.Payload)
+}
+
+// Succeed records an attempt the endpoint accepted
+func (d *Delivery) Succeed(responseStatus int, responseBody string) {
+	now := time.Now()
+	d.record(responseStatus, responseBody, "")
+	d.Status = StatusDelivered
+	d.DeliveredAt = &now
+}
+
+// Fail records a rejected attempt and schedules the next one at retryAt.
+// A zero retryAt gives up on the delivery.
+func (d *Delivery) Fail(responseStatus int, responseBody, errMsg string, retryAt time.Time) {
+	d.record(responseStatus, responseBody, errMsg)
+	if retryAt.IsZero() {
+		d.Status = StatusFailed
+		return
+	}
+	d.Status = StatusPending
+	d.NextAttemptAt = retryAt
+}
+
+func (d *Delivery) record(responseStatus int, responseBody, errMsg string) {
+	if len(responseBody) > maxResponseBody {
+		responseBody = responseBody[:maxResponseBody]
+	}
+	d.ResponseStatus = responseStatus
+	d.ResponseBody = responseBody
+	d.Error = errMsg
+	d.UpdatedAt = time.Now()
+}
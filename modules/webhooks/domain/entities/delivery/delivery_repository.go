@@ -0,0 +1,41 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+type Field int
+
+const (
+	ID Field = iota
+	EndpointID
+	StatusField
+	EventType
+	CreatedAt
+)
+
+type SortBy = repo.SortBy[Field]
+
+type Filter = repo.FieldFilter[Field]
+
+type FindParams struct {
+	Limit   int
+	Offset  int
+	SortBy  SortBy
+	Filters []Filter
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Delivery, error)
+	GetByID(ctx context.Context, id uint) (*Delivery, error)
+	Create(ctx context.Context, data *Delivery) (*Delivery, error)
+	Update(ctx context.Context, data *Delivery) (*Delivery, error)
+	// Claim marks the next due delivery of any tenant as sending and returns it, nil when none is due
+	Claim(ctx context.Context) (*Delivery, error)
+	// RequeueStale puts deliveries that have been sending since before back into the queue
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+}
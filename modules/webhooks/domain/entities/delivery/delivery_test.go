@@ -0,0 +1,53 @@
+package delivery_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+)
+
+func TestDelivery_Fail(t *testing.T) {
+	d := delivery.New(uuid.New(), 1, uuid.New(), "crm.client.created", []byte(`{}`))
+	assert.Equal(t, delivery.StatusPending, d.Status)
+
+	retryAt := time.Now().Add(time.Minute)
+	d.Fail(500, strings.Repeat("x", 10000), "", retryAt)
+	assert.Equal(t, delivery.StatusPending, d.Status)
+	assert.Equal(t, retryAt, d.NextAttemptAt)
+	assert.Equal(t, 500, d.ResponseStatus)
+	assert.Len(t, d.ResponseBody, 4096)
+
+	d.Fail(0, "", "connection refused", time.Time{})
+	assert.Equal(t, delivery.StatusFailed, d.Status)
+	assert.Equal(t, "connection refused", d.Error)
+}
+
+func TestDelivery_Succeed(t *testing.T) {
+	d := delivery.New(uuid.New(), 1, uuid.New(), "crm.client.created", []byte(`{}`))
+	d.Fail(0, "", "timeout", time.Now())
+	d.Succeed(204, "")
+	assert.Equal(t, delivery.StatusDelivered, d.Status)
+	assert.Empty(t, d.Error)
+	require.NotNil(t, d.DeliveredAt)
+}
+
+func TestDelivery_Replay(t *testing.T) {
+	d := delivery.New(uuid.New(), 1, uuid.New(), "crm.client.created", []byte(`{"a":1}`))
+	d.ID = 5
+	d.Attempts = 8
+	d.Fail(500, "", "", time.Time{})
+
+	replay := d.Replay()
+	assert.Zero(t, replay.ID)
+	assert.Zero(t, replay.Attempts)
+	assert.Equal(t, delivery.StatusPending, replay.Status)
+	assert.Equal(t, d.EventID, replay.EventID)
+	assert.Equal(t, d.EndpointID, replay.EndpointID)
+	assert.Equal(t, d.Payload, replay.Payload)
+}
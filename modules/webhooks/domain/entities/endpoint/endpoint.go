@@ -0,0 +1,70 @@
+package endpoint
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AllEvents subscribes an endpoint to every event type
+const AllEvents = "*"
+
+// secretPrefix marks signing secrets so that they are recognizable in configuration files
+const secretPrefix = "whsec_"
+
+// Endpoint is a URL of a tenant that receives the events it subscribed to.
+// Event types are matched exactly, "*" matches every type and "crm.*" every type starting with "crm.".
+type Endpoint struct {
+	ID          uint
+	TenantID    uuid.UUID
+	URL         string
+	Description string
+	// Secret signs the payloads sent to the endpoint
+	Secret     string
+	EventTypes []string
+	Enabled    bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// New creates an enabled endpoint with a fresh signing secret
+func New(tenantID uuid.UUID, url, description string, eventTypes []string) (*Endpoint, error) {
+	secret, err := NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	return &Endpoint{
+		TenantID:    tenantID,
+		URL:         url,
+		Description: description,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Enabled:     true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+func NewSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Subscribes reports whether the endpoint receives events of the type
+func (e *Endpoint) Subscribes(eventType string) bool {
+	for _, pattern := range e.EventTypes {
+		if pattern == AllEvents || pattern == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
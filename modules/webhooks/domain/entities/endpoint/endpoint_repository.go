@@ -0,0 +1,13 @@
+package endpoint
+
+import (
+	"context"
+)
+
+type Repository interface {
+	GetByID(ctx context.Context, id uint) (*Endpoint, error)
+	GetAll(ctx context.Context) ([]*Endpoint, error)
+	Create(ctx context.Context, data *Endpoint) (*Endpoint, error)
+	Update(ctx context.Context, data *Endpoint) (*Endpoint, error)
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,55 @@
+package endpoint_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+)
+
+func TestEndpoint_Subscribes(t *testing.T) {
+	e := &endpoint.Endpoint{EventTypes: []string{"billing.billing.status_changed", "crm.*"}}
+	assert.True(t, e.Subscribes("billing.billing.status_changed"))
+	assert.True(t, e.Subscribes("crm.client.created"))
+	assert.False(t, e.Subscribes("billing.billing.created"))
+	assert.False(t, e.Subscribes("finance.expense.created"))
+
+	all := &endpoint.Endpoint{EventTypes: []string{endpoint.AllEvents}}
+	assert.True(t, all.Subscribes("finance.expense.created"))
+
+	none := &endpoint.Endpoint{}
+	assert.False(t, none.Subscribes("finance.expense.created"))
+}
+
+func TestNew(t *testing.T) {
+	e, err := endpoint.New(uuid.New(), "https://example.com/hook", "", []string{endpoint.AllEvents})
+	require.NoError(t, err)
+	assert.True(t, e.Enabled)
+	assert.True(t, strings.HasPrefix(e.Secret, "whsec_"))
+
+	other, err := endpoint.New(uuid.New(), "https://example.com/hook", "", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, e.Secret, other.Secret)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	payload := []byte(`{"type":"crm.client.created"}`)
+	now := time.Now()
+	header := endpoint.Sign("secret", now, payload)
+
+	require.NoError(t, endpoint.Verify("secret", header, payload, time.Minute, now))
+	require.ErrorIs(t, endpoint.Verify("other", header, payload, time.Minute, now), endpoint.ErrInvalidSignature)
+	require.ErrorIs(t, endpoint.Verify("secret", header, []byte(`{}`), time.Minute, now), endpoint.ErrInvalidSignature)
+	require.ErrorIs(t, endpoint.Verify("secret", "v1=abc", payload, time.Minute, now), endpoint.ErrInvalidSignature)
+	require.ErrorIs(
+		t,
+		endpoint.Verify("secret", header, payload, time.Minute, now.Add(10*time.Minute)),
+		endpoint.ErrSignatureExpired,
+	)
+	require.NoError(t, endpoint.Verify("secret", header, payload, 0, now.Add(10*time.Minute)))
+}
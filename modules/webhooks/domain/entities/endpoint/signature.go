@@ -0,0 +1,82 @@
+package endpoint
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the signature of a payload in the form "t=<unix time>,v1=<hex hmac>"
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader carries the type of the event
+	EventHeader = "X-Webhook-Event"
+	// IDHeader carries the ID of the event, retries and replays of an event share it
+	IDHeader = "X-Webhook-ID"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrSignatureExpired = errors.New("webhook signature timestamp is outside of the tolerance")
+)
+
+// Sign returns the value of SignatureHeader for the payload. The HMAC-SHA256 covers
+// "<unix time>.<payload>" so that a captured request can not be replayed later.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeSignature(secret, ts, payload))
+}
+
+// Verify checks a SignatureHeader value the way receivers should: the signature must match
+// the payload and the timestamp must be within tolerance of now. Zero tolerance skips the timestamp check.
+func Verify(secret, header string, payload []byte, tolerance time.Duration, now time.Time) error {
+	var ts string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	expected := computeSignature(secret, ts, payload)
+	valid := false
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 {
+		diff := now.Sub(time.Unix(unix, 0))
+		if diff > tolerance || diff < -tolerance {
+			return ErrSignatureExpired
+		}
+	}
+	return nil
+}
+
+func computeSignature(secret, ts string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
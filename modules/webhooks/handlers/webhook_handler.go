@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// WebhookHandler queues a delivery of every tenant event to the endpoints subscribed to it
+type WebhookHandler struct {
+	pool            *pgxpool.Pool
+	deliveryService *services.DeliveryService
+}
+
+func RegisterWebhookHandler(app application.Application) *WebhookHandler {
+	handler := &WebhookHandler{
+		pool:            app.DB(),
+		deliveryService: app.Service(services.DeliveryService{}).(*services.DeliveryService),
+	}
+	app.EventPublisher().Subscribe(handler.onEvent)
+	return handler
+}
+
+func (h *WebhookHandler) onEvent(event any) {
+	e, ok := services.NewEventFromEvent(event)
+	if !ok {
+		return
+	}
+	ctx := composables.WithTenantID(composables.WithPool(context.Background(), h.pool), e.TenantID)
+	if _, err := h.deliveryService.Enqueue(ctx, e); err != nil {
+		configuration.Use().Logger().WithFields(logrus.Fields{
+			"tenant_id":  e.TenantID,
+			"event_id":   e.ID,
+			"event_type": e.Type,
+		}).WithError(err).Error("failed to enqueue webhook deliveries")
+	}
+}
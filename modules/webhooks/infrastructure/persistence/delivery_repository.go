@@ -0,0 +1,278 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+const (
+	deliveryColumns = `
+		wd.id, wd.tenant_id, wd.endpoint_id, wd.event_id, wd.event_type, wd.payload, wd.status, wd.attempts,
+		wd.next_attempt_at, wd.response_status, wd.response_body, wd.error, wd.delivered_at, wd.created_at, wd.updated_at`
+
+	deliverySelectQuery = `SELECT` + deliveryColumns + ` FROM webhook_deliveries wd`
+
+	deliveryCountQuery = `SELECT COUNT(wd.id) FROM webhook_deliveries wd`
+
+	deliveryInsertQuery = `
+		INSERT INTO webhook_deliveries (
+			tenant_id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			response_status, response_body, error, delivered_at, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id`
+
+	deliveryUpdateQuery = `
+		UPDATE webhook_deliveries SET
+			status = $1,
+			attempts = $2,
+			next_attempt_at = $3,
+			response_status = $4,
+			response_body = $5,
+			error = $6,
+			delivered_at = $7,
+			updated_at = $8
+		WHERE id = $9 AND tenant_id = $10`
+
+	deliveryClaimQuery = `
+		UPDATE webhook_deliveries wd SET
+			status = 'sending',
+			attempts = wd.attempts + 1,
+			updated_at = NOW()
+		WHERE wd.id = (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + deliveryColumns
+
+	deliveryRequeueStaleQuery = `
+		UPDATE webhook_deliveries SET status = 'pending', next_attempt_at = NOW(), updated_at = NOW()
+		WHERE status = 'sending' AND updated_at < $1`
+)
+
+type DeliveryRepository struct {
+	fieldMap map[delivery.Field]string
+}
+
+func NewDeliveryRepository() delivery.Repository {
+	return &DeliveryRepository{
+		fieldMap: map[delivery.Field]string{
+			delivery.ID:          "wd.id",
+			delivery.EndpointID:  "wd.endpoint_id",
+			delivery.StatusField: "wd.status",
+			delivery.EventType:   "wd.event_type",
+			delivery.CreatedAt:   "wd.created_at",
+		},
+	}
+}
+
+func (r *DeliveryRepository) buildFilters(ctx context.Context, params *delivery.FindParams) ([]string, []any, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+
+	where := []string{"wd.tenant_id = $1"}
+	args := []any{tenantID}
+
+	for _, filter := range params.Filters {
+		column, ok := r.fieldMap[filter.Column]
+		if !ok {
+			return nil, nil, errors.Wrap(fmt.Errorf("unknown filter field: %v", filter.Column), "invalid filter")
+		}
+		where = append(where, filter.Filter.String(column, len(args)+1))
+		args = append(args, filter.Filter.Value()...)
+	}
+
+	return where, args, nil
+}
+
+func (r *DeliveryRepository) Count(ctx context.Context, params *delivery.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction")
+	}
+	where, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(deliveryCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count webhook deliveries")
+	}
+	return count, nil
+}
+
+func (r *DeliveryRepository) GetPaginated(ctx context.Context, params *delivery.FindParams) ([]*delivery.Delivery, error) {
+	where, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	query := repo.Join(
+		deliverySelectQuery,
+		repo.JoinWhere(where...),
+		params.SortBy.ToSQL(r.fieldMap),
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return r.queryDeliveries(ctx, query, args...)
+}
+
+func (r *DeliveryRepository) GetByID(ctx context.Context, id uint) (*delivery.Delivery, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+	deliveries, err := r.queryDeliveries(
+		ctx,
+		repo.Join(deliverySelectQuery, "WHERE wd.id = $1 AND wd.tenant_id = $2"),
+		id, tenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, errors.Wrapf(ErrDeliveryNotFound, "id: %d", id)
+	}
+	return deliveries[0], nil
+}
+
+func (r *DeliveryRepository) Create(ctx context.Context, data *delivery.Delivery) (*delivery.Delivery, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	dbDelivery := toDBDelivery(data)
+	if err := tx.QueryRow(
+		ctx,
+		deliveryInsertQuery,
+		dbDelivery.TenantID,
+		dbDelivery.EndpointID,
+		dbDelivery.EventID,
+		dbDelivery.EventType,
+		dbDelivery.Payload,
+		dbDelivery.Status,
+		dbDelivery.Attempts,
+		dbDelivery.NextAttemptAt,
+		dbDelivery.ResponseStatus,
+		dbDelivery.ResponseBody,
+		dbDelivery.Error,
+		dbDelivery.DeliveredAt,
+		dbDelivery.CreatedAt,
+		dbDelivery.UpdatedAt,
+	).Scan(&dbDelivery.ID); err != nil {
+		return nil, errors.Wrap(err, "failed to insert webhook delivery")
+	}
+	return toDomainDelivery(dbDelivery)
+}
+
+func (r *DeliveryRepository) Update(ctx context.Context, data *delivery.Delivery) (*delivery.Delivery, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	dbDelivery := toDBDelivery(data)
+	tag, err := tx.Exec(
+		ctx,
+		deliveryUpdateQuery,
+		dbDelivery.Status,
+		dbDelivery.Attempts,
+		dbDelivery.NextAttemptAt,
+		dbDelivery.ResponseStatus,
+		dbDelivery.ResponseBody,
+		dbDelivery.Error,
+		dbDelivery.DeliveredAt,
+		dbDelivery.UpdatedAt,
+		dbDelivery.ID,
+		dbDelivery.TenantID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update webhook delivery")
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errors.Wrapf(ErrDeliveryNotFound, "id: %d", data.ID)
+	}
+	return data, nil
+}
+
+func (r *DeliveryRepository) Claim(ctx context.Context) (*delivery.Delivery, error) {
+	deliveries, err := r.queryDeliveries(ctx, deliveryClaimQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to claim webhook delivery")
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	return deliveries[0], nil
+}
+
+func (r *DeliveryRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction")
+	}
+	tag, err := tx.Exec(ctx, deliveryRequeueStaleQuery, before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to requeue stale webhook deliveries")
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *DeliveryRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]*delivery.Delivery, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query webhook deliveries")
+	}
+	defer rows.Close()
+
+	deliveries := make([]*delivery.Delivery, 0)
+	for rows.Next() {
+		var dbDelivery models.WebhookDelivery
+		if err := rows.Scan(
+			&dbDelivery.ID,
+			&dbDelivery.TenantID,
+			&dbDelivery.EndpointID,
+			&dbDelivery.EventID,
+			&dbDelivery.EventType,
+			&dbDelivery.Payload,
+			&dbDelivery.Status,
+			&dbDelivery.Attempts,
+			&dbDelivery.NextAttemptAt,
+			&dbDelivery.ResponseStatus,
+			&dbDelivery.ResponseBody,
+			&dbDelivery.Error,
+			&dbDelivery.DeliveredAt,
+			&dbDelivery.CreatedAt,
+			&dbDelivery.UpdatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan webhook delivery")
+		}
+		entity, err := toDomainDelivery(&dbDelivery)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,184 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrEndpointNotFound = errors.New("webhook endpoint not found")
+)
+
+const (
+	endpointSelectQuery = `
+		SELECT we.id, we.tenant_id, we.url, we.description, we.secret, we.event_types, we.enabled, we.created_at, we.updated_at
+		FROM webhook_endpoints we`
+
+	endpointInsertQuery = `
+		INSERT INTO webhook_endpoints (tenant_id, url, description, secret, event_types, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	endpointUpdateQuery = `
+		UPDATE webhook_endpoints SET url = $1, description = $2, secret = $3, event_types = $4, enabled = $5, updated_at = $6
+		WHERE id = $7 AND tenant_id = $8`
+
+	endpointDeleteQuery = `DELETE FROM webhook_endpoints WHERE id = $1 AND tenant_id = $2`
+)
+
+type EndpointRepository struct{}
+
+func NewEndpointRepository() endpoint.Repository {
+	return &EndpointRepository{}
+}
+
+func (r *EndpointRepository) GetByID(ctx context.Context, id uint) (*endpoint.Endpoint, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+	endpoints, err := r.queryEndpoints(
+		ctx,
+		repo.Join(endpointSelectQuery, "WHERE we.id = $1 AND we.tenant_id = $2"),
+		id, tenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.Wrapf(ErrEndpointNotFound, "id: %d", id)
+	}
+	return endpoints[0], nil
+}
+
+func (r *EndpointRepository) GetAll(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenant from context")
+	}
+	return r.queryEndpoints(
+		ctx,
+		repo.Join(endpointSelectQuery, "WHERE we.tenant_id = $1", "ORDER BY we.created_at, we.id"),
+		tenantID,
+	)
+}
+
+func (r *EndpointRepository) Create(ctx context.Context, data *endpoint.Endpoint) (*endpoint.Endpoint, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	dbEndpoint, err := toDBEndpoint(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.QueryRow(
+		ctx,
+		endpointInsertQuery,
+		dbEndpoint.TenantID,
+		dbEndpoint.URL,
+		dbEndpoint.Description,
+		dbEndpoint.Secret,
+		dbEndpoint.EventTypes,
+		dbEndpoint.Enabled,
+		dbEndpoint.CreatedAt,
+		dbEndpoint.UpdatedAt,
+	).Scan(&dbEndpoint.ID); err != nil {
+		return nil, errors.Wrap(err, "failed to insert webhook endpoint")
+	}
+	return toDomainEndpoint(dbEndpoint)
+}
+
+func (r *EndpointRepository) Update(ctx context.Context, data *endpoint.Endpoint) (*endpoint.Endpoint, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	dbEndpoint, err := toDBEndpoint(data)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := tx.Exec(
+		ctx,
+		endpointUpdateQuery,
+		dbEndpoint.URL,
+		dbEndpoint.Description,
+		dbEndpoint.Secret,
+		dbEndpoint.EventTypes,
+		dbEndpoint.Enabled,
+		dbEndpoint.UpdatedAt,
+		dbEndpoint.ID,
+		dbEndpoint.TenantID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update webhook endpoint")
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errors.Wrapf(ErrEndpointNotFound, "id: %d", data.ID)
+	}
+	return data, nil
+}
+
+func (r *EndpointRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction")
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tenant from context")
+	}
+	tag, err := tx.Exec(ctx, endpointDeleteQuery, id, tenantID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete webhook endpoint")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(ErrEndpointNotFound, "id: %d", id)
+	}
+	return nil
+}
+
+func (r *EndpointRepository) queryEndpoints(ctx context.Context, query string, args ...any) ([]*endpoint.Endpoint, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query webhook endpoints")
+	}
+	defer rows.Close()
+
+	endpoints := make([]*endpoint.Endpoint, 0)
+	for rows.Next() {
+		var dbEndpoint models.WebhookEndpoint
+		if err := rows.Scan(
+			&dbEndpoint.ID,
+			&dbEndpoint.TenantID,
+			&dbEndpoint.URL,
+			&dbEndpoint.Description,
+			&dbEndpoint.Secret,
+			&dbEndpoint.EventTypes,
+			&dbEndpoint.Enabled,
+			&dbEndpoint.CreatedAt,
+			&dbEndpoint.UpdatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan webhook endpoint")
+		}
+		entity, err := toDomainEndpoint(&dbEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+	return endpoints, nil
+}
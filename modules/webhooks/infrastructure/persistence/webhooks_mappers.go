@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+)
+
+func toDBEndpoint(entity *endpoint.Endpoint) (*models.WebhookEndpoint, error) {
+	eventTypes := entity.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	data, err := json.Marshal(eventTypes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal event types")
+	}
+	return &models.WebhookEndpoint{
+		ID:          entity.ID,
+		TenantID:    entity.TenantID.String(),
+		URL:         entity.URL,
+		Description: entity.Description,
+		Secret:      entity.Secret,
+		EventTypes:  data,
+		Enabled:     entity.Enabled,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}, nil
+}
+
+func toDomainEndpoint(dbEndpoint *models.WebhookEndpoint) (*endpoint.Endpoint, error) {
+	tenantID, err := uuid.Parse(dbEndpoint.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant id")
+	}
+	var eventTypes []string
+	if len(dbEndpoint.EventTypes) > 0 {
+		if err := json.Unmarshal(dbEndpoint.EventTypes, &eventTypes); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal event types")
+		}
+	}
+	return &endpoint.Endpoint{
+		ID:          dbEndpoint.ID,
+		TenantID:    tenantID,
+		URL:         dbEndpoint.URL,
+		Description: dbEndpoint.Description,
+		Secret:      dbEndpoint.Secret,
+		EventTypes:  eventTypes,
+		Enabled:     dbEndpoint.Enabled,
+		CreatedAt:   dbEndpoint.CreatedAt,
+		UpdatedAt:   dbEndpoint.UpdatedAt,
+	}, nil
+}
+
+func toDBDelivery(entity *delivery.Delivery) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:             entity.ID,
+		TenantID:       entity.TenantID.String(),
+		EndpointID:     entity.EndpointID,
+		EventID:        entity.EventID.String(),
+		EventType:      entity.EventType,
+		Payload:        entity.Payload,
+		Status:         string(entity.Status),
+		Attempts:       entity.Attempts,
+		NextAttemptAt:  entity.NextAttemptAt,
+		ResponseStatus: entity.ResponseStatus,
+		ResponseBody:   entity.ResponseBody,
+		Error:          entity.Error,
+		DeliveredAt:    mapping.PointerToSQLNullTime(entity.DeliveredAt),
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+	}
+}
+
+func toDomainDelivery(dbDelivery *models.WebhookDelivery) (*delivery.Delivery, error) {
+	tenantID, err := uuid.Parse(dbDelivery.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse tenant id")
+	}
+	eventID, err := uuid.Parse(dbDelivery.EventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse event id")
+	}
+	return &delivery.Delivery{
+		ID:             dbDelivery.ID,
+		TenantID:       tenantID,
+		EndpointID:     dbDelivery.EndpointID,
+		EventID:        eventID,
+		EventType:      dbDelivery.EventType,
+		Payload:        dbDelivery.Payload,
+		Status:         delivery.Status(dbDelivery.Status),
+		Attempts:       dbDelivery.Attempts,
+		NextAttemptAt:  dbDelivery.NextAttemptAt,
+		ResponseStatus: dbDelivery.ResponseStatus,
+		ResponseBody:   dbDelivery.ResponseBody,
+		Error:          dbDelivery.Error,
+		DeliveredAt:    mapping.SQLNullTimeToPointer(dbDelivery.DeliveredAt),
+		CreatedAt:      dbDelivery.CreatedAt,
+		UpdatedAt:      dbDelivery.UpdatedAt,
+	}, nil
+}
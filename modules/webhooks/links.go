@@ -0,0 +1,21 @@
+package webhooks
+
+import (
+	icons "github.com/iota-uz/icons/phosphor"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/types"
+)
+
+var WebhooksLink = types.NavigationItem{
+	Name:        "NavigationLinks.Webhooks",
+	Icon:        icons.WebhooksLogo(icons.Props{Size: "20"}),
+	Href:        "/webhooks",
+	Permissions: []*permission.Permission{permissions.ViewWebhooks},
+	Children:    nil,
+}
+
+var NavItems = []types.NavigationItem{
+	WebhooksLink,
+}
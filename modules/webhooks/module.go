@@ -0,0 +1,46 @@
+package webhooks
+
+import (
+	"embed"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/handlers"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/permissions"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/presentation/controllers"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+)
+
+//go:embed presentation/locales/*.json
+var localeFiles embed.FS
+
+//go:embed infrastructure/persistence/schema/webhooks-schema.sql
+var migrationFiles embed.FS
+
+func NewModule() application.Module {
+	return &Module{}
+}
+
+type Module struct {
+}
+
+func (m *Module) Register(app application.Application) error {
+	endpointRepo := persistence.NewEndpointRepository()
+	app.RegisterServices(
+		services.NewEndpointService(endpointRepo, services.DefaultTargetGuard()),
+		services.NewDeliveryService(persistence.NewDeliveryRepository(), endpointRepo),
+	)
+	app.RegisterControllers(
+		controllers.NewWebhooksController(app),
+	)
+	handlers.RegisterWebhookHandler(app)
+
+	app.RBAC().Register(permissions.Permissions...)
+	app.RegisterLocaleFiles(&localeFiles)
+	app.Migrations().RegisterSchema(&migrationFiles)
+	return nil
+}
+
+func (m *Module) Name() string {
+	return "webhooks"
+}
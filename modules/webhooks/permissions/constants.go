@@ -0,0 +1,33 @@
+package permissions
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+)
+
+const (
+	ResourceWebhooks permission.Resource = "webhooks"
+)
+
+var (
+	ViewWebhooks = &permission.Permission{
+		ID:       uuid.MustParse("ade12d71-e5f3-4f8f-9b84-5b4ca4931903"),
+		Name:     "Webhooks.View",
+		Resource: ResourceWebhooks,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	ManageWebhooks = &permission.Permission{
+		ID:       uuid.MustParse("c3691578-c38b-4158-9499-e103c0fc041e"),
+		Name:     "Webhooks.Manage",
+		Resource: ResourceWebhooks,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+)
+
+var Permissions = []*permission.Permission{
+	ViewWebhooks,
+	ManageWebhooks,
+}
@@ -0,0 +1,76 @@
+package dtos
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/services"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/validators"
+)
+
+type SaveEndpointDTO struct {
+	URL         string   `validate:"required,http_url,max=2048"`
+	Description string   `validate:"max=255" label:"_Description"`
+	EventTypes  []string // types picked from the catalog
+	// CustomEventTypes lists other types separated by commas, "crm.*" subscribes to a whole module
+	CustomEventTypes string `validate:"max=1024"`
+	Enabled          bool
+}
+
+func (dto *SaveEndpointDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		panic(intl.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	if len(dto.Types()) == 0 {
+		errorMessages["EventTypes"] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ValidationErrors.required",
+		})
+	}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages, len(errorMessages) == 0
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("Webhooks.Single.%s", validators.FieldLabel(dto, err)),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+// Types merges the picked and the custom event types, dropping blanks and duplicates
+func (dto *SaveEndpointDTO) Types() []string {
+	types := make([]string, 0, len(dto.EventTypes))
+	candidates := append(slices.Clone(dto.EventTypes), strings.Split(dto.CustomEventTypes, ",")...)
+	for _, t := range candidates {
+		t = strings.TrimSpace(t)
+		if t != "" && !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func (dto *SaveEndpointDTO) ToParams() *services.SaveEndpointParams {
+	return &services.SaveEndpointParams{
+		URL:         strings.TrimSpace(dto.URL),
+		Description: dto.Description,
+		EventTypes:  dto.Types(),
+		Enabled:     dto.Enabled,
+	}
+}
@@ -0,0 +1,429 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/permissions"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/presentation/templates/pages/webhooks"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type WebhooksController struct {
+	app      application.Application
+	basePath string
+}
+
+func NewWebhooksController(app application.Application) application.Controller {
+	return &WebhooksController{
+		app:      app,
+		basePath: "/webhooks",
+	}
+}
+
+func (c *WebhooksController) Key() string {
+	return c.basePath
+}
+
+func (c *WebhooksController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.RateLimit(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.Tabs(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", di.H(c.List)).Methods(http.MethodGet)
+	router.HandleFunc("", di.H(c.Create)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}", di.H(c.GetEdit)).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}", di.H(c.Update)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}", di.H(c.Delete)).Methods(http.MethodDelete)
+	router.HandleFunc("/{id:[0-9]+}/secret", di.H(c.RotateSecret)).Methods(http.MethodPost)
+	router.HandleFunc("/deliveries/{id:[0-9]+}/replay", di.H(c.Replay)).Methods(http.MethodPost)
+}
+
+func (c *WebhooksController) endpointForm(
+	u user.User,
+	entity *endpoint.Endpoint,
+	dto *dtos.SaveEndpointDTO,
+	errorsMap map[string]string,
+) *webhooks.EndpointFormProps {
+	props := &webhooks.EndpointFormProps{
+		Catalog:   services.EventTypes,
+		Enabled:   true,
+		CanManage: u.Can(permissions.ManageWebhooks),
+		Errors:    errorsMap,
+	}
+	if entity != nil {
+		props.Endpoint = mappers.EndpointToViewModel(entity)
+		props.URL = entity.URL
+		props.Description = entity.Description
+		props.Enabled = entity.Enabled
+		props.EventTypes, props.CustomEventTypes = splitEventTypes(entity.EventTypes)
+	}
+	if dto != nil {
+		props.URL = dto.URL
+		props.Description = dto.Description
+		props.EventTypes = dto.EventTypes
+		props.CustomEventTypes = dto.CustomEventTypes
+		props.Enabled = dto.Enabled
+	}
+	if props.Errors == nil {
+		props.Errors = map[string]string{}
+	}
+	return props
+}
+
+func (c *WebhooksController) deliveriesProps(r *http.Request, u user.User, endpointID uint) *webhooks.DeliveriesProps {
+	paginationParams := composables.UsePaginated(r)
+	props := &webhooks.DeliveriesProps{
+		BaseURL:   fmt.Sprintf("%s/%d", c.basePath, endpointID),
+		CanManage: u.Can(permissions.ManageWebhooks),
+		Page:      paginationParams.Page,
+		PerPage:   paginationParams.Limit,
+	}
+	if status := delivery.Status(r.URL.Query().Get("Status")); status.IsValid() {
+		props.Status = string(status)
+	}
+	return props
+}
+
+func (c *WebhooksController) findParams(endpointID uint, props *webhooks.DeliveriesProps) *delivery.FindParams {
+	params := &delivery.FindParams{
+		Limit:  props.PerPage,
+		Offset: (props.Page - 1) * props.PerPage,
+		SortBy: delivery.SortBy{
+			Fields: []repo.SortByField[delivery.Field]{
+				{Field: delivery.CreatedAt, Ascending: false},
+				{Field: delivery.ID, Ascending: false},
+			},
+		},
+		Filters: []delivery.Filter{
+			{Column: delivery.EndpointID, Filter: repo.Eq(endpointID)},
+		},
+	}
+	if props.Status != "" {
+		params.Filters = append(params.Filters, delivery.Filter{
+			Column: delivery.StatusField,
+			Filter: repo.Eq(props.Status),
+		})
+	}
+	return params
+}
+
+func (c *WebhooksController) List(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	endpointService *services.EndpointService,
+) {
+	if !u.Can(permissions.ViewWebhooks) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	entities, err := endpointService.GetAll(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving webhook endpoints: %v", err)
+		http.Error(w, "Error retrieving webhook endpoints", http.StatusInternalServerError)
+		return
+	}
+	props := &webhooks.IndexPageProps{
+		Endpoints: toEndpointViewModels(entities),
+	}
+	if u.Can(permissions.ManageWebhooks) {
+		props.Form = c.endpointForm(u, nil, nil, nil)
+	}
+	templ.Handler(webhooks.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *WebhooksController) Create(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	endpointService *services.EndpointService,
+) {
+	if !u.Can(permissions.ManageWebhooks) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	dto, err := composables.UseForm(&dtos.SaveEndpointDTO{}, r)
+	if err != nil {
+		logger.Errorf("Error parsing form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		templ.Handler(webhooks.EndpointForm(c.endpointForm(u, nil, dto, errorsMap)), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	entity, err := endpointService.Create(r.Context(), dto.ToParams())
+	if errors.Is(err, services.ErrTargetNotAllowed) {
+		templ.Handler(webhooks.EndpointForm(c.endpointForm(u, nil, dto, targetErrors(r))), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error creating webhook endpoint: %v", err)
+		http.Error(w, "Error creating webhook endpoint", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(webhooks.EndpointForm(c.endpointForm(u, nil, nil, nil))).ServeHTTP(w, r)
+	templ.Handler(webhooks.EndpointCreated(mappers.EndpointToViewModel(entity))).ServeHTTP(w, r)
+}
+
+func (c *WebhooksController) GetEdit(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	endpointService *services.EndpointService,
+	deliveryService *services.DeliveryService,
+) {
+	if !u.Can(permissions.ViewWebhooks) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		logger.Errorf("Error parsing webhook endpoint ID: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := endpointService.GetByID(r.Context(), id)
+	if errors.Is(err, persistence.ErrEndpointNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error retrieving webhook endpoint: %v", err)
+		http.Error(w, "Error retrieving webhook endpoint", http.StatusInternalServerError)
+		return
+	}
+
+	deliveriesProps := c.deliveriesProps(r, u, id)
+	params := c.findParams(id, deliveriesProps)
+	deliveries, err := deliveryService.GetPaginated(r.Context(), params)
+	if err != nil {
+		logger.Errorf("Error retrieving webhook deliveries: %v", err)
+		http.Error(w, "Error retrieving webhook deliveries", http.StatusInternalServerError)
+		return
+	}
+	total, err := deliveryService.Count(r.Context(), params)
+	if err != nil {
+		logger.Errorf("Error counting webhook deliveries: %v", err)
+		http.Error(w, "Error counting webhook deliveries", http.StatusInternalServerError)
+		return
+	}
+	deliveriesProps.Deliveries = toDeliveryViewModels(deliveries)
+	deliveriesProps.HasMore = total > int64(deliveriesProps.Page*deliveriesProps.PerPage)
+
+	if htmx.IsHxRequest(r) {
+		if deliveriesProps.Page > 1 {
+			templ.Handler(webhooks.DeliveryRows(deliveriesProps), templ.WithStreaming()).ServeHTTP(w, r)
+		} else {
+			templ.Handler(webhooks.DeliveriesTable(deliveriesProps), templ.WithStreaming()).ServeHTTP(w, r)
+		}
+		return
+	}
+	props := &webhooks.EndpointPageProps{
+		Endpoint:   mappers.EndpointToViewModel(entity),
+		Form:       c.endpointForm(u, entity, nil, nil),
+		Deliveries: deliveriesProps,
+	}
+	templ.Handler(webhooks.Endpoint(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *WebhooksController) Update(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	endpointService *services.EndpointService,
+) {
+	if !u.Can(permissions.ManageWebhooks) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		logger.Errorf("Error parsing webhook endpoint ID: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&dtos.SaveEndpointDTO{}, r)
+	if err != nil {
+		logger.Errorf("Error parsing form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := endpointService.GetByID(r.Context(), id)
+	if errors.Is(err, persistence.ErrEndpointNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error retrieving webhook endpoint: %v", err)
+		http.Error(w, "Error retrieving webhook endpoint", http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		templ.Handler(webhooks.EndpointForm(c.endpointForm(u, entity, dto, errorsMap)), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	_, err = endpointService.Update(r.Context(), id, dto.ToParams())
+	if errors.Is(err, services.ErrTargetNotAllowed) {
+		templ.Handler(webhooks.EndpointForm(c.endpointForm(u, entity, dto, targetErrors(r))), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error updating webhook endpoint: %v", err)
+		http.Error(w, "Error updating webhook endpoint", http.StatusInternalServerError)
+		return
+	}
+	htmx.Refresh(w)
+}
+
+func (c *WebhooksController) Delete(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	endpointService *services.EndpointService,
+) {
+	if !u.Can(permissions.ManageWebhooks) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		logger.Errorf("Error parsing webhook endpoint ID: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := endpointService.Delete(r.Context(), id); err != nil {
+		logger.Errorf("Error deleting webhook endpoint: %v", err)
+		http.Error(w, "Error deleting webhook endpoint", http.StatusInternalServerError)
+		return
+	}
+	htmx.Redirect(w, c.basePath)
+}
+
+func (c *WebhooksController) RotateSecret(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	endpointService *services.EndpointService,
+) {
+	if !u.Can(permissions.ManageWebhooks) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		logger.Errorf("Error parsing webhook endpoint ID: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := endpointService.RotateSecret(r.Context(), id)
+	if err != nil {
+		logger.Errorf("Error rotating webhook endpoint secret: %v", err)
+		http.Error(w, "Error rotating webhook endpoint secret", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(webhooks.Secret(mappers.EndpointToViewModel(entity), true), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *WebhooksController) Replay(
+	r *http.Request,
+	w http.ResponseWriter,
+	u user.User,
+	logger *logrus.Entry,
+	deliveryService *services.DeliveryService,
+) {
+	if !u.Can(permissions.ManageWebhooks) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		logger.Errorf("Error parsing webhook delivery ID: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := deliveryService.Replay(r.Context(), id)
+	if errors.Is(err, persistence.ErrDeliveryNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error replaying webhook delivery: %v", err)
+		http.Error(w, "Error replaying webhook delivery", http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(webhooks.DeliveryReplayed(mappers.DeliveryToViewModel(entity), true), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// splitEventTypes separates the types of the catalog from the custom ones shown in the text field
+func splitEventTypes(eventTypes []string) ([]string, string) {
+	var picked, custom []string
+	for _, t := range eventTypes {
+		if slices.Contains(services.EventTypes, t) {
+			picked = append(picked, t)
+		} else {
+			custom = append(custom, t)
+		}
+	}
+	return picked, strings.Join(custom, ", ")
+}
+
+func toEndpointViewModels(entities []*endpoint.Endpoint) []*viewmodels.Endpoint {
+	result := make([]*viewmodels.Endpoint, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, mappers.EndpointToViewModel(entity))
+	}
+	return result
+}
+
+func toDeliveryViewModels(entities []*delivery.Delivery) []*viewmodels.Delivery {
+	result := make([]*viewmodels.Delivery, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, mappers.DeliveryToViewModel(entity))
+	}
+	return result
+}
+
+// targetErrors explains why an endpoint URL pointing to an internal address was rejected
+func targetErrors(r *http.Request) map[string]string {
+	return map[string]string{
+		"URL": intl.MustT(r.Context(), "Webhooks.Single.TargetNotAllowed"),
+	}
+}
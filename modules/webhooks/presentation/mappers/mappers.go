@@ -0,0 +1,61 @@
+package mappers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/presentation/viewmodels"
+)
+
+func EndpointToViewModel(entity *endpoint.Endpoint) *viewmodels.Endpoint {
+	return &viewmodels.Endpoint{
+		ID:          strconv.FormatUint(uint64(entity.ID), 10),
+		URL:         entity.URL,
+		Description: entity.Description,
+		Secret:      entity.Secret,
+		EventTypes:  entity.EventTypes,
+		Enabled:     entity.Enabled,
+		CreatedAt:   entity.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   entity.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func DeliveryToViewModel(entity *delivery.Delivery) *viewmodels.Delivery {
+	var responseStatus, nextAttemptAt, deliveredAt string
+	if entity.ResponseStatus != 0 {
+		responseStatus = strconv.Itoa(entity.ResponseStatus)
+	}
+	if entity.Status == delivery.StatusPending {
+		nextAttemptAt = entity.NextAttemptAt.Format(time.RFC3339)
+	}
+	if entity.DeliveredAt != nil {
+		deliveredAt = entity.DeliveredAt.Format(time.RFC3339)
+	}
+	return &viewmodels.Delivery{
+		ID:             strconv.FormatUint(uint64(entity.ID), 10),
+		EndpointID:     strconv.FormatUint(uint64(entity.EndpointID), 10),
+		EventID:        entity.EventID.String(),
+		EventType:      entity.EventType,
+		Status:         string(entity.Status),
+		Attempts:       strconv.Itoa(entity.Attempts),
+		ResponseStatus: responseStatus,
+		ResponseBody:   entity.ResponseBody,
+		Error:          entity.Error,
+		Payload:        formatPayload(entity.Payload),
+		NextAttemptAt:  nextAttemptAt,
+		DeliveredAt:    deliveredAt,
+		CreatedAt:      entity.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatPayload(payload []byte) string {
+	var b bytes.Buffer
+	if err := json.Indent(&b, payload, "", "  "); err != nil {
+		return string(payload)
+	}
+	return b.String()
+}
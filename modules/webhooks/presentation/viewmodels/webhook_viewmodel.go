@@ -0,0 +1,33 @@
+package viewmodels
+
+type Endpoint struct {
+	ID          string
+	URL         string
+	Description string
+	Secret      string
+	EventTypes  []string
+	Enabled     bool
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+type Delivery struct {
+	ID             string
+	EndpointID     string
+	EventID        string
+	EventType      string
+	Status         string
+	Attempts       string
+	ResponseStatus string
+	ResponseBody   string
+	Error          string
+	Payload        string
+	NextAttemptAt  string
+	DeliveredAt    string
+	CreatedAt      string
+}
+
+// CanReplay reports whether the delivery finished, pending deliveries are sent anyway
+func (d *Delivery) CanReplay() bool {
+	return d.Status == "delivered" || d.Status == "failed"
+}
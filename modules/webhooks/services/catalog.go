@@ -0,0 +1,59 @@
+package services
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/billing/domain/aggregates/billing"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/employee"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/leaverequest"
+	"github.com/iota-uz/iota-sdk/modules/hrm/domain/aggregates/payroll"
+)
+
+// EventTypes are the event types of the built-in modules offered when subscribing an endpoint.
+// Endpoints may subscribe to any other type published on the event bus as well.
+var EventTypes = eventTypes(
+	billing.CreatedEvent{},
+	billing.UpdatedEvent{},
+	billing.DeletedEvent{},
+	billing.StatusChangedEvent{},
+	billing.AmountChangedEvent{},
+	payment.Created{},
+	payment.Updated{},
+	payment.Deleted{},
+	expense.CreatedEvent{},
+	expense.UpdatedEvent{},
+	expense.DeletedEvent{},
+	client.CreatedEvent{},
+	client.UpdatedEvent{},
+	client.DeletedEvent{},
+	deal.CreatedEvent{},
+	deal.UpdatedEvent{},
+	deal.StageChangedEvent{},
+	deal.DeletedEvent{},
+	chat.CreatedEvent{},
+	chat.MessagedAddedEvent{},
+	employee.CreatedEvent{},
+	employee.UpdatedEvent{},
+	employee.DeletedEvent{},
+	leaverequest.CreatedEvent{},
+	leaverequest.StatusChangedEvent{},
+	payroll.CreatedEvent{},
+	payroll.PostedEvent{},
+	user.CreatedEvent{},
+	user.UpdatedEvent{},
+	user.DeletedEvent{},
+)
+
+func eventTypes(events ...any) []string {
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		if eventType, ok := EventType(event); ok {
+			types = append(types, eventType)
+		}
+	}
+	return types
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// DeliveryService keeps the log of events sent to webhook endpoints
+type DeliveryService struct {
+	repo         delivery.Repository
+	endpointRepo endpoint.Repository
+}
+
+func NewDeliveryService(repo delivery.Repository, endpointRepo endpoint.Repository) *DeliveryService {
+	return &DeliveryService{
+		repo:         repo,
+		endpointRepo: endpointRepo,
+	}
+}
+
+func (s *DeliveryService) GetByID(ctx context.Context, id uint) (*delivery.Delivery, error) {
+	if err := composables.CanUser(ctx, permissions.ViewWebhooks); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *DeliveryService) GetPaginated(ctx context.Context, params *delivery.FindParams) ([]*delivery.Delivery, error) {
+	if err := composables.CanUser(ctx, permissions.ViewWebhooks); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *DeliveryService) Count(ctx context.Context, params *delivery.FindParams) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.ViewWebhooks); err != nil {
+		return 0, err
+	}
+	return s.repo.Count(ctx, params)
+}
+
+// Replay sends the event of a delivery again as a new delivery with the same event ID
+func (s *DeliveryService) Replay(ctx context.Context, id uint) (*delivery.Delivery, error) {
+	if err := composables.CanUser(ctx, permissions.ManageWebhooks); err != nil {
+		return nil, err
+	}
+	var created *delivery.Delivery
+	err := composables.InTx(ctx, func(txCtx context.Context) error {
+		d, err := s.repo.GetByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		created, err = s.repo.Create(txCtx, d.Replay())
+		return err
+	})
+	return created, err
+}
+
+// Enqueue schedules a delivery of the event to every enabled endpoint of its tenant subscribed to it.
+// The context must carry the tenant of the event.
+func (s *DeliveryService) Enqueue(ctx context.Context, event *Event) (int, error) {
+	payload, err := event.Payload()
+	if err != nil {
+		return 0, err
+	}
+	enqueued := 0
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		endpoints, err := s.endpointRepo.GetAll(txCtx)
+		if err != nil {
+			return err
+		}
+		for _, e := range endpoints {
+			if !e.Enabled || !e.Subscribes(event.Type) {
+				continue
+			}
+			if _, err := s.repo.Create(txCtx, delivery.New(event.TenantID, e.ID, event.ID, event.Type, payload)); err != nil {
+				return err
+			}
+			enqueued++
+		}
+		return nil
+	})
+	return enqueued, err
+}
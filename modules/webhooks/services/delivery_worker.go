@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+const maxBackoff = 12 * time.Hour
+
+type DeliveryWorkerOptions struct {
+	// Concurrency is the number of deliveries sent in parallel
+	Concurrency int
+	// PollInterval is how long an idle worker waits before looking for due deliveries
+	PollInterval time.Duration
+	// MaxAttempts is the number of attempts before a delivery is marked as failed
+	MaxAttempts int
+	// StaleAfter is how long a delivery may stay in sending before it is put back into the queue
+	StaleAfter time.Duration
+	// Backoff returns the delay before the next attempt of a failed delivery
+	Backoff func(attempt int) time.Duration
+	// Client sends the requests, its timeout bounds every attempt.
+	// It has to keep requests away from internal addresses, see NewClient
+	Client *http.Client
+}
+
+func DefaultDeliveryWorkerOptions() *DeliveryWorkerOptions {
+	conf := configuration.Use()
+	return &DeliveryWorkerOptions{
+		Concurrency:  conf.Webhooks.Workers,
+		PollInterval: conf.Webhooks.PollInterval,
+		MaxAttempts:  conf.Webhooks.MaxAttempts,
+		StaleAfter:   conf.Webhooks.Timeout + time.Minute,
+		Backoff:      ExponentialBackoff,
+		Client:       NewClient(conf.Webhooks.Timeout, DefaultTargetGuard()),
+	}
+}
+
+// ExponentialBackoff waits 30s after the first attempt and doubles the delay after each next one, up to 12h
+func ExponentialBackoff(attempt int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+
+// DeliveryWorker sends the pending deliveries of all tenants and schedules retries of failed attempts
+type DeliveryWorker struct {
+	pool            *pgxpool.Pool
+	deliveryService *DeliveryService
+	sender          *Sender
+	logger          *logrus.Logger
+	opts            *DeliveryWorkerOptions
+}
+
+func NewDeliveryWorker(app application.Application, opts *DeliveryWorkerOptions) *DeliveryWorker {
+	if opts == nil {
+		opts = DefaultDeliveryWorkerOptions()
+	}
+	return &DeliveryWorker{
+		pool:            app.DB(),
+		deliveryService: app.Service(DeliveryService{}).(*DeliveryService),
+		sender:          NewSender(opts.Client),
+		logger:          configuration.Use().Logger(),
+		opts:            opts,
+	}
+}
+
+// Run sends deliveries until ctx is done. Attempts interrupted by shutdown are retried later.
+func (w *DeliveryWorker) Run(ctx context.Context) error {
+	if w.opts.Concurrency <= 0 {
+		return nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < w.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.requeueStale(ctx)
+	}()
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (w *DeliveryWorker) loop(ctx context.Context) {
+	for {
+		processed, err := w.ProcessNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.logger.WithError(err).Error("failed to send webhook delivery")
+		}
+		if processed {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.opts.PollInterval):
+		}
+	}
+}
+
+func (w *DeliveryWorker) requeueStale(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.StaleAfter / 2)
+	defer ticker.Stop()
+	for {
+		n, err := w.deliveryService.repo.RequeueStale(composables.WithoutTenantIsolation(composables.WithPool(ctx, w.pool)), time.Now().Add(-w.opts.StaleAfter))
+		if err != nil && ctx.Err() == nil {
+			w.logger.WithError(err).Error("failed to requeue stale webhook deliveries")
+		} else if n > 0 {
+			w.logger.WithField("count", n).Warn("requeued stale webhook deliveries")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessNext claims and sends a single delivery. It reports false when no delivery was due.
+func (w *DeliveryWorker) ProcessNext(ctx context.Context) (bool, error) {
+	// The queue spans tenants, the endpoint is looked up in the tenant of the delivery
+	poolCtx := composables.WithoutTenantIsolation(composables.WithPool(ctx, w.pool))
+	d, err := w.deliveryService.repo.Claim(poolCtx)
+	if err != nil {
+		return false, err
+	}
+	if d == nil {
+		return false, nil
+	}
+	tenantCtx := composables.WithTenantID(poolCtx, d.TenantID)
+	e, err := w.deliveryService.endpointRepo.GetByID(tenantCtx, d.EndpointID)
+	if err != nil {
+		return true, err
+	}
+
+	if !e.Enabled {
+		d.Fail(0, "", "endpoint is disabled", time.Time{})
+	} else {
+		result := w.sender.Send(ctx, e, d)
+		if result.Succeeded() {
+			d.Succeed(result.StatusCode, result.Body)
+		} else {
+			var retryAt time.Time
+			if d.Attempts < w.opts.MaxAttempts {
+				retryAt = time.Now().Add(w.opts.Backoff(d.Attempts))
+			}
+			d.Fail(result.StatusCode, result.Body, result.ErrorMessage(), retryAt)
+		}
+		w.logger.WithFields(logrus.Fields{
+			"delivery_id": d.ID,
+			"endpoint_id": e.ID,
+			"event_type":  d.EventType,
+			"attempt":     d.Attempts,
+			"status":      d.Status,
+			"duration":    result.Duration,
+		}).Debug("sent webhook delivery")
+	}
+
+	// the outcome is recorded even when the worker is shutting down
+	saveCtx := context.WithoutCancel(tenantCtx)
+	return true, composables.InTx(saveCtx, func(txCtx context.Context) error {
+		_, err := w.deliveryService.repo.Update(txCtx, d)
+		return err
+	})
+}
@@ -0,0 +1,17 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/services"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, services.ExponentialBackoff(1))
+	assert.Equal(t, time.Minute, services.ExponentialBackoff(2))
+	assert.Equal(t, 4*time.Minute, services.ExponentialBackoff(4))
+	assert.Equal(t, 12*time.Hour, services.ExponentialBackoff(20))
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type SaveEndpointParams struct {
+	URL         string
+	Description string
+	EventTypes  []string
+	Enabled     bool
+}
+
+// EndpointService manages the URLs a tenant receives its events at
+type EndpointService struct {
+	repo  endpoint.Repository
+	guard *TargetGuard
+}
+
+func NewEndpointService(repo endpoint.Repository, guard *TargetGuard) *EndpointService {
+	return &EndpointService{
+		repo:  repo,
+		guard: guard,
+	}
+}
+
+func (s *EndpointService) GetByID(ctx context.Context, id uint) (*endpoint.Endpoint, error) {
+	if err := composables.CanUser(ctx, permissions.ViewWebhooks); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *EndpointService) GetAll(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	if err := composables.CanUser(ctx, permissions.ViewWebhooks); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAll(ctx)
+}
+
+// Create registers an endpoint of the current tenant with a freshly generated signing secret.
+// The URL has to lead to a public address, see TargetGuard
+func (s *EndpointService) Create(ctx context.Context, params *SaveEndpointParams) (*endpoint.Endpoint, error) {
+	if err := composables.CanUser(ctx, permissions.ManageWebhooks); err != nil {
+		return nil, err
+	}
+	if err := s.guard.CheckURL(ctx, params.URL); err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := endpoint.New(tenantID, params.URL, params.Description, params.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	entity.Enabled = params.Enabled
+	var created *endpoint.Endpoint
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		created, err = s.repo.Create(txCtx, entity)
+		return err
+	})
+	return created, err
+}
+
+func (s *EndpointService) Update(ctx context.Context, id uint, params *SaveEndpointParams) (*endpoint.Endpoint, error) {
+	if err := composables.CanUser(ctx, permissions.ManageWebhooks); err != nil {
+		return nil, err
+	}
+	if err := s.guard.CheckURL(ctx, params.URL); err != nil {
+		return nil, err
+	}
+	var updated *endpoint.Endpoint
+	err := composables.InTx(ctx, func(txCtx context.Context) error {
+		entity, err := s.repo.GetByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		entity.URL = params.URL
+		entity.Description = params.Description
+		entity.EventTypes = params.EventTypes
+		entity.Enabled = params.Enabled
+		entity.UpdatedAt = time.Now()
+		updated, err = s.repo.Update(txCtx, entity)
+		return err
+	})
+	return updated, err
+}
+
+// RotateSecret replaces the signing secret, receivers must switch to the new one right away
+func (s *EndpointService) RotateSecret(ctx context.Context, id uint) (*endpoint.Endpoint, error) {
+	if err := composables.CanUser(ctx, permissions.ManageWebhooks); err != nil {
+		return nil, err
+	}
+	var updated *endpoint.Endpoint
+	err := composables.InTx(ctx, func(txCtx context.Context) error {
+		entity, err := s.repo.GetByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		secret, err := endpoint.NewSecret()
+		if err != nil {
+			return err
+		}
+		entity.Secret = secret
+		entity.UpdatedAt = time.Now()
+		updated, err = s.repo.Update(txCtx, entity)
+		return err
+	})
+	return updated, err
+}
+
+// Delete removes the endpoint together with its delivery log
+func (s *EndpointService) Delete(ctx context.Context, id uint) error {
+	if err := composables.CanUser(ctx, permissions.ManageWebhooks); err != nil {
+		return err
+	}
+	return composables.InTx(ctx, func(txCtx context.Context) error {
+		return s.repo.Delete(txCtx, id)
+	})
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	loggingservices "github.com/iota-uz/iota-sdk/modules/logging/services"
+)
+
+var (
+	// event types that are not business data
+	ignoredEventPrefixes = []string{"core.session.", "core.job."}
+	// events named without the "Event" suffix, e.g. payment.Created
+	bareEventNames = []string{"Created", "Updated", "Deleted"}
+	// event fields holding the entity the event is about, in order of preference
+	entityFields = []string{"Result", "Group", "Data"}
+	// event fields describing who caused the event, they never end up in payloads
+	metaFields = []string{"Session", "Sender", "Actor", "User", "TenantID"}
+)
+
+// Event is the payload posted to webhook endpoints
+type Event struct {
+	ID        uuid.UUID      `json:"id"`
+	Type      string         `json:"type"`
+	TenantID  uuid.UUID      `json:"tenant_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	Data      map[string]any `json:"data"`
+}
+
+func (e *Event) Payload() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// EventType names an event published on the event bus "<module>.<package>.<event>",
+// e.g. "billing.billing.status_changed" for billing.StatusChangedEvent. Generic events
+// of pkg/crud are named after the package of their entity, e.g. "crm.client.created".
+func EventType(event any) (string, bool) {
+	v, ok := eventStruct(event)
+	if !ok {
+		return "", false
+	}
+	t := v.Type()
+	name, typeArgs, generic := strings.Cut(t.Name(), "[")
+	if trimmed, ok := strings.CutSuffix(name, "Event"); ok {
+		name = trimmed
+	} else if !slices.Contains(bareEventNames, name) {
+		return "", false
+	}
+	if name == "" {
+		return "", false
+	}
+	pkgPath := t.PkgPath()
+	if generic && typeArgs != "" {
+		entityType, ok := entityFieldType(t)
+		if !ok {
+			return "", false
+		}
+		pkgPath = entityType.PkgPath()
+	}
+	if pkgPath == "" {
+		return "", false
+	}
+	return packageName(pkgPath) + "." + snakeCase(name), true
+}
+
+// NewEventFromEvent builds the webhook payload of an event published on the event bus.
+// It returns false for events that are not tied to a tenant or are not business data.
+func NewEventFromEvent(event any) (*Event, bool) {
+	eventType, ok := EventType(event)
+	if !ok {
+		return nil, false
+	}
+	for _, prefix := range ignoredEventPrefixes {
+		if strings.HasPrefix(eventType, prefix) {
+			return nil, false
+		}
+	}
+	v, _ := eventStruct(event)
+	entity := eventEntity(v)
+	tenantID := eventTenantID(v, entity)
+	if tenantID == uuid.Nil {
+		return nil, false
+	}
+
+	var data map[string]any
+	if entity != nil {
+		data = loggingservices.NewSnapshot(entity)
+	} else {
+		data = loggingservices.NewSnapshot(v.Interface())
+		for _, name := range metaFields {
+			delete(data, name)
+		}
+	}
+	if data == nil {
+		data = map[string]any{}
+	}
+	return &Event{
+		ID:        uuid.New(),
+		Type:      eventType,
+		TenantID:  tenantID,
+		CreatedAt: time.Now(),
+		Data:      data,
+	}, true
+}
+
+func eventStruct(event any) (reflect.Value, bool) {
+	v := reflect.ValueOf(event)
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Struct
+}
+
+func entityFieldType(t reflect.Type) (reflect.Type, bool) {
+	for _, name := range entityFields {
+		f, ok := t.FieldByName(name)
+		if !ok {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		return ft, true
+	}
+	return nil, false
+}
+
+func eventEntity(v reflect.Value) any {
+	for _, name := range entityFields {
+		f := v.FieldByName(name)
+		if !f.IsValid() || !f.CanInterface() || isNil(f) {
+			continue
+		}
+		if _, ok := loggingservices.EntityID(f.Interface()); ok {
+			return f.Interface()
+		}
+	}
+	return nil
+}
+
+func eventTenantID(v reflect.Value, entity any) uuid.UUID {
+	if f := v.FieldByName("TenantID"); f.IsValid() && f.CanInterface() {
+		if id, ok := f.Interface().(uuid.UUID); ok && id != uuid.Nil {
+			return id
+		}
+	}
+	if f := v.FieldByName("Session"); f.IsValid() && f.CanInterface() && !isNil(f) {
+		switch sess := f.Interface().(type) {
+		case session.Session:
+			if sess.TenantID != uuid.Nil {
+				return sess.TenantID
+			}
+		case *session.Session:
+			if sess.TenantID != uuid.Nil {
+				return sess.TenantID
+			}
+		}
+	}
+	if entity != nil {
+		if e, ok := entity.(interface{ TenantID() uuid.UUID }); ok {
+			return e.TenantID()
+		}
+	}
+	for _, name := range metaFields {
+		f := v.FieldByName(name)
+		if !f.IsValid() || !f.CanInterface() || isNil(f) {
+			continue
+		}
+		if e, ok := f.Interface().(interface{ TenantID() uuid.UUID }); ok && e.TenantID() != uuid.Nil {
+			return e.TenantID()
+		}
+	}
+	return uuid.Nil
+}
+
+// packageName turns a package path into "<module>.<package>", e.g. "crm.client"
+func packageName(pkgPath string) string {
+	parts := strings.Split(pkgPath, "/")
+	last := parts[len(parts)-1]
+	for i, part := range parts {
+		if part == "modules" && i+1 < len(parts) {
+			return parts[i+1] + "." + last
+		}
+	}
+	return last
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
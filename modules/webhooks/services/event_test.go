@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/billing/domain/aggregates/billing"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/services"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+)
+
+func TestEventType(t *testing.T) {
+	cases := []struct {
+		name     string
+		event    any
+		expected string
+		ok       bool
+	}{
+		{"aggregate event", &billing.StatusChangedEvent{}, "billing.billing.status_changed", true},
+		{"bare event name", &payment.Created{}, "finance.payment.created", true},
+		{"generic crud event", &crud.CreatedEvent[billing.Transaction]{}, "billing.billing.created", true},
+		{"not an event", &struct{ ID int }{}, "", false},
+		{"nil", nil, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventType, ok := services.EventType(tc.event)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, eventType)
+		})
+	}
+}
+
+func TestEventTypes(t *testing.T) {
+	assert.Contains(t, services.EventTypes, "crm.client.created")
+	assert.Contains(t, services.EventTypes, "hrm.payroll.posted")
+	assert.Contains(t, services.EventTypes, "billing.billing.status_changed")
+}
+
+func TestNewEventFromEvent(t *testing.T) {
+	tenantID := uuid.New()
+	transactionID := uuid.New()
+
+	event, ok := services.NewEventFromEvent(&billing.StatusChangedEvent{
+		TenantID:      tenantID,
+		TransactionID: transactionID,
+		Data:          billing.Pending,
+		Result:        billing.Completed,
+	})
+	require.True(t, ok)
+	assert.Equal(t, "billing.billing.status_changed", event.Type)
+	assert.Equal(t, tenantID, event.TenantID)
+	assert.NotEqual(t, uuid.Nil, event.ID)
+	assert.Contains(t, event.Data, "TransactionID")
+	assert.NotContains(t, event.Data, "TenantID")
+
+	payload, err := event.Payload()
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), `"type":"billing.billing.status_changed"`)
+
+	_, ok = services.NewEventFromEvent(&billing.StatusChangedEvent{TransactionID: transactionID})
+	assert.False(t, ok, "events without a tenant are not delivered")
+
+	_, ok = services.NewEventFromEvent(&session.CreatedEvent{Result: session.Session{TenantID: tenantID}})
+	assert.False(t, ok, "session events are not business data")
+}
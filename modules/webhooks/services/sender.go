@@ -0,0 +1,81 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+)
+
+const (
+	userAgent = "iota-sdk-webhooks/1.0"
+	// responses are truncated by the delivery anyway, there is no point in reading more
+	maxResponseRead = 64 << 10
+)
+
+// SendResult is the outcome of a single delivery attempt
+type SendResult struct {
+	StatusCode int
+	Body       string
+	Err        error
+	Duration   time.Duration
+}
+
+// Succeeded reports whether the endpoint accepted the event with a 2xx response
+func (r SendResult) Succeeded() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// ErrorMessage describes why the attempt failed, empty for successful attempts
+func (r SendResult) ErrorMessage() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	if r.Succeeded() {
+		return ""
+	}
+	return http.StatusText(r.StatusCode)
+}
+
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(client *http.Client) *Sender {
+	if client == nil {
+		client = NewClient(0, DefaultTargetGuard())
+	}
+	return &Sender{client: client}
+}
+
+// Send posts the payload of the delivery to the endpoint, signed with the endpoint secret
+func (s *Sender) Send(ctx context.Context, e *endpoint.Endpoint, d *delivery.Delivery) SendResult {
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return SendResult{Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(endpoint.IDHeader, d.EventID.String())
+	req.Header.Set(endpoint.EventHeader, d.EventType)
+	req.Header.Set(endpoint.SignatureHeader, endpoint.Sign(e.Secret, start, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return SendResult{Err: err, Duration: time.Since(start)}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseRead))
+	return SendResult{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Err:        err,
+		Duration:   time.Since(start),
+	}
+}
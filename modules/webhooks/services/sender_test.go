@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/endpoint"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/services"
+)
+
+func newEndpoint(t *testing.T, url string) *endpoint.Endpoint {
+	t.Helper()
+	e, err := endpoint.New(uuid.New(), url, "", []string{endpoint.AllEvents})
+	require.NoError(t, err)
+	return e
+}
+
+func TestSender_Send(t *testing.T) {
+	payload := []byte(`{"type":"crm.client.created","data":{"ID":1}}`)
+	var received http.Header
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, payload, body)
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer receiver.Close()
+
+	e := newEndpoint(t, receiver.URL)
+	d := delivery.New(e.TenantID, 1, uuid.New(), "crm.client.created", payload)
+
+	result := services.NewSender(receiver.Client()).Send(context.Background(), e, d)
+	require.NoError(t, result.Err)
+	assert.True(t, result.Succeeded())
+	assert.Equal(t, http.StatusAccepted, result.StatusCode)
+	assert.Equal(t, "ok", result.Body)
+
+	require.NotNil(t, received)
+	assert.Equal(t, "application/json", received.Get("Content-Type"))
+	assert.Equal(t, d.EventID.String(), received.Get(endpoint.IDHeader))
+	assert.Equal(t, "crm.client.created", received.Get(endpoint.EventHeader))
+	assert.NoError(t, endpoint.Verify(e.Secret, received.Get(endpoint.SignatureHeader), payload, time.Minute, time.Now()))
+	assert.ErrorIs(t, endpoint.Verify("whsec_other", received.Get(endpoint.SignatureHeader), payload, time.Minute, time.Now()), endpoint.ErrInvalidSignature)
+}
+
+func TestSender_Send_Failures(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	e := newEndpoint(t, receiver.URL)
+	d := delivery.New(e.TenantID, 1, uuid.New(), "crm.client.created", []byte(`{}`))
+	sender := services.NewSender(receiver.Client())
+
+	result := sender.Send(context.Background(), e, d)
+	assert.False(t, result.Succeeded())
+	assert.Equal(t, http.StatusInternalServerError, result.StatusCode)
+	assert.Equal(t, "Internal Server Error", result.ErrorMessage())
+
+	receiver.Close()
+	result = sender.Send(context.Background(), e, d)
+	assert.False(t, result.Succeeded())
+	require.Error(t, result.Err)
+	assert.NotEmpty(t, result.ErrorMessage())
+}
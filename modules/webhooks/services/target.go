@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// ErrTargetNotAllowed is returned for endpoint URLs that don't lead to a public internet address
+var ErrTargetNotAllowed = errors.New("webhook target is not a public address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which clouds use for internal services as well
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// TargetGuard keeps webhook requests away from the internal network of the platform:
+// loopback, private, link-local (cloud metadata), unspecified and multicast addresses
+// are rejected unless they are in one of the allowed networks
+type TargetGuard struct {
+	allowed []netip.Prefix
+}
+
+// NewTargetGuard creates a guard that lets through the allowed networks in addition to public addresses.
+// Allowing private networks is meant for on-premise installations only
+func NewTargetGuard(allowed []netip.Prefix) *TargetGuard {
+	return &TargetGuard{allowed: allowed}
+}
+
+// DefaultTargetGuard lets through the networks allowed by WEBHOOK_ALLOWED_NETWORKS
+func DefaultTargetGuard() *TargetGuard {
+	return NewTargetGuard(configuration.Use().Webhooks.AllowedNetworks)
+}
+
+// CheckAddr reports whether requests may be sent to addr
+func (g *TargetGuard) CheckAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	for _, prefix := range g.allowed {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s", ErrTargetNotAllowed, addr)
+	}
+	return nil
+}
+
+// CheckURL resolves the host of an endpoint URL and checks every address it resolves to.
+// It rejects obviously internal endpoints when they are saved, the dialer of NewClient
+// checks the address actually connected to on every attempt
+func (g *TargetGuard) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return g.CheckAddr(addr)
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrTargetNotAllowed, err)
+	}
+	for _, addr := range addrs {
+		if err := g.CheckAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// control runs right before connecting, after DNS resolution, so a host that
+// resolves to another address by the time of the delivery (DNS rebinding) is still rejected
+func (g *TargetGuard) control(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	return g.CheckAddr(addrPort.Addr())
+}
+
+// NewClient creates the client deliveries are sent with. It only connects to addresses
+// the guard lets through, ignores proxy settings and doesn't follow redirects,
+// a redirect response counts as a failed attempt
+func NewClient(timeout time.Duration, guard *TargetGuard) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   guard.control,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/webhooks/domain/entities/delivery"
+	"github.com/iota-uz/iota-sdk/modules/webhooks/services"
+)
+
+func TestTargetGuard_CheckURL(t *testing.T) {
+	guard := services.NewTargetGuard(nil)
+	ctx := context.Background()
+
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://[::1]/hook",
+		"http://10.1.2.3/hook",
+		"http://192.168.0.10/hook",
+		"http://172.16.5.4/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://100.100.100.200/hook",
+		"http://0.0.0.0/hook",
+		"http://224.0.0.1/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://[fd00::1]/hook",
+	} {
+		assert.ErrorIs(t, guard.CheckURL(ctx, url), services.ErrTargetNotAllowed, url)
+	}
+	assert.NoError(t, guard.CheckURL(ctx, "https://93.184.216.34/hook"))
+
+	onPremise := services.NewTargetGuard([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+	require.NoError(t, onPremise.CheckURL(ctx, "http://10.1.2.3/hook"))
+	assert.ErrorIs(t, onPremise.CheckURL(ctx, "http://192.168.0.10/hook"), services.ErrTargetNotAllowed)
+}
+
+func TestNewClient(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/internal", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	e := newEndpoint(t, receiver.URL)
+	d := delivery.New(e.TenantID, 1, uuid.New(), "crm.client.created", []byte(`{}`))
+
+	// The receiver listens on loopback, which is only reachable when allowed explicitly
+	blocked := services.NewSender(services.NewClient(time.Second, services.NewTargetGuard(nil)))
+	result := blocked.Send(context.Background(), e, d)
+	require.ErrorIs(t, result.Err, services.ErrTargetNotAllowed)
+
+	loopback := services.NewTargetGuard([]netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")})
+	allowed := services.NewSender(services.NewClient(time.Second, loopback))
+	result = allowed.Send(context.Background(), e, d)
+	require.NoError(t, result.Err)
+	assert.True(t, result.Succeeded())
+
+	e.URL = receiver.URL + "/redirect"
+	result = allowed.Send(context.Background(), e, d)
+	require.NoError(t, result.Err)
+	assert.Equal(t, http.StatusFound, result.StatusCode, "redirects are not followed")
+	assert.False(t, result.Succeeded())
+}
@@ -136,6 +136,8 @@ type application struct {
 	quickLinks     *spotlight.QuickLinks
 	migrations     MigrationManager
 	navItems       []types.NavigationItem
+	tenantSeeds    []SeedFunc
+	userAttributes []rbac.AttributeProvider
 }
 
 func (app *application) Spotlight() spotlight.Spotlight {
@@ -158,6 +160,22 @@ func (app *application) RegisterNavItems(items ...types.NavigationItem) {
 	app.navItems = append(app.navItems, items...)
 }
 
+func (app *application) RegisterTenantSeeds(funcs ...SeedFunc) {
+	app.tenantSeeds = append(app.tenantSeeds, funcs...)
+}
+
+func (app *application) TenantSeeds() []SeedFunc {
+	return app.tenantSeeds
+}
+
+func (app *application) RegisterUserAttributes(providers ...rbac.AttributeProvider) {
+	app.userAttributes = append(app.userAttributes, providers...)
+}
+
+func (app *application) UserAttributes() []rbac.AttributeProvider {
+	return app.userAttributes
+}
+
 func (app *application) RBAC() rbac.RBAC {
 	return app.rbac
 }
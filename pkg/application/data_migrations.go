@@ -0,0 +1,302 @@
+package application
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// MigrationPhase tells when a migration runs relative to the rollout of a new application version
+type MigrationPhase string
+
+const (
+	// PhaseExpand migrations are compatible with both application versions (new tables,
+	// nullable columns, backfills) and run before the rollout. Migrations are expand by default
+	PhaseExpand MigrationPhase = "expand"
+	// PhaseContract migrations remove what only the old version uses (old columns, compatibility
+	// views) and run after the rollout. SQL files opt in with a "-- +phase contract" line
+	PhaseContract MigrationPhase = "contract"
+)
+
+const (
+	dataMigrationsTable      = "data_migrations"
+	defaultBackfillBatchSize = 1000
+	phaseDirective           = "-- +phase "
+)
+
+var (
+	ErrExpandPending        = errors.New("expand migrations have to be applied before contract migrations")
+	ErrInvalidDataMigration = errors.New("invalid data migration")
+)
+
+// DataMigration is a migration written in Go, e.g. filling a new column after a column split.
+// It is ordered together with the SQL migrations by Version, the unix timestamp that
+// changes-<timestamp>.sql files are named with. On equal versions the SQL migration runs first
+type DataMigration struct {
+	Version int64
+	Name    string
+	// Phase defaults to PhaseExpand
+	Phase MigrationPhase
+	// Up migrates the data in a single transaction. Set either Up or Backfill
+	Up func(ctx context.Context, tx pgx.Tx) error
+	// Backfill migrates the data in batches, for tables too large for one transaction
+	Backfill *Backfill
+	// Down reverts the migration on rollback. Without it the rollback only forgets the migration
+	Down func(ctx context.Context, tx pgx.Tx) error
+}
+
+// ID is the key of the migration in the data_migrations table
+func (d DataMigration) ID() string {
+	return fmt.Sprintf("data-%d-%s", d.Version, d.Name)
+}
+
+func (d DataMigration) phase() MigrationPhase {
+	if d.Phase == "" {
+		return PhaseExpand
+	}
+	return d.Phase
+}
+
+func (d DataMigration) validate() error {
+	if d.Version <= 0 || d.Name == "" {
+		return fmt.Errorf("%w: version and name are required", ErrInvalidDataMigration)
+	}
+	if (d.Up == nil) == (d.Backfill == nil) {
+		return fmt.Errorf("%w %s: exactly one of Up and Backfill has to be set", ErrInvalidDataMigration, d.ID())
+	}
+	if d.Backfill != nil && d.Backfill.Batch == nil {
+		return fmt.Errorf("%w %s: backfill has no batch function", ErrInvalidDataMigration, d.ID())
+	}
+	if p := d.phase(); p != PhaseExpand && p != PhaseContract {
+		return fmt.Errorf("%w %s: unknown phase %q", ErrInvalidDataMigration, d.ID(), p)
+	}
+	return nil
+}
+
+// Backfill processes rows batch by batch, every batch in its own transaction. The cursor a batch
+// ends at is committed together with it, so a failed or interrupted backfill resumes after the
+// last committed batch on the next run instead of starting over
+type Backfill struct {
+	// Batch processes up to limit rows after the cursor, which is empty for the first batch, and
+	// returns the cursor of the last processed row (e.g. its id) and the number of processed rows.
+	// A batch with fewer rows than the limit completes the backfill
+	Batch func(ctx context.Context, tx pgx.Tx, cursor string, limit int) (next string, processed int, err error)
+	// BatchSize defaults to 1000
+	BatchSize int
+	// Total optionally counts the rows to process, so the progress is reported in percent
+	Total func(ctx context.Context, tx pgx.Tx) (int64, error)
+}
+
+// migrationStep is a pending SQL or data migration
+type migrationStep struct {
+	id      string
+	version int64
+	phase   MigrationPhase
+	sql     *migrate.PlannedMigration
+	data    *DataMigration
+}
+
+// planSteps orders SQL and data migrations by version. SQL migrations keep the order
+// sql-migrate planned them in and go first on equal versions, so a data migration can rely
+// on the schema change released with it
+func planSteps(sqlSteps, dataSteps []migrationStep) []migrationStep {
+	steps := make([]migrationStep, 0, len(sqlSteps)+len(dataSteps))
+	steps = append(steps, sqlSteps...)
+	steps = append(steps, dataSteps...)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].version < steps[j].version
+	})
+	return steps
+}
+
+// selectPhase keeps the steps of one phase. A contract step can't overtake a pending
+// expand step ordered before it: the expand step and the rollout have to come first
+func selectPhase(steps []migrationStep, phase MigrationPhase) ([]migrationStep, error) {
+	selected := make([]migrationStep, 0, len(steps))
+	var pendingExpand string
+	for _, step := range steps {
+		switch {
+		case step.phase == phase && phase == PhaseContract && pendingExpand != "":
+			return nil, fmt.Errorf("%w: %s is pending before %s", ErrExpandPending, pendingExpand, step.id)
+		case step.phase == phase:
+			selected = append(selected, step)
+		case step.phase == PhaseExpand && pendingExpand == "":
+			pendingExpand = step.id
+		}
+	}
+	return selected, nil
+}
+
+// readPhase looks for the "-- +phase" directive of a SQL migration file
+func readPhase(path string) (MigrationPhase, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, phaseDirective) {
+			continue
+		}
+		phase := MigrationPhase(strings.TrimSpace(strings.TrimPrefix(line, phaseDirective)))
+		if phase != PhaseExpand && phase != PhaseContract {
+			return "", fmt.Errorf("unknown migration phase %q in %s", phase, path)
+		}
+		return phase, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return PhaseExpand, nil
+}
+
+// migrationVersion extracts the timestamp from a changes-<timestamp>.sql file name
+func migrationVersion(id string) int64 {
+	version, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(id, "changes-"), ".sql"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// ensureDataMigrationsTable creates the table tracking data migrations, like sql-migrate does for its own
+func ensureDataMigrationsTable(ctx context.Context, conn *pgx.Conn) error {
+	_, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS `+dataMigrationsTable+` (
+		id varchar(255) PRIMARY KEY,
+		cursor text NOT NULL DEFAULT '',
+		processed bigint NOT NULL DEFAULT 0,
+		started_at timestamp with time zone NOT NULL DEFAULT now(),
+		applied_at timestamp with time zone
+	)`)
+	return err
+}
+
+// dataMigrationRecords returns the recorded data migrations and whether they are complete
+func dataMigrationRecords(ctx context.Context, conn *pgx.Conn) (map[string]bool, error) {
+	rows, err := conn.Query(ctx, `SELECT id, applied_at IS NOT NULL FROM `+dataMigrationsTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	records := make(map[string]bool)
+	for rows.Next() {
+		var (
+			id      string
+			applied bool
+		)
+		if err := rows.Scan(&id, &applied); err != nil {
+			return nil, err
+		}
+		records[id] = applied
+	}
+	return records, rows.Err()
+}
+
+func saveDataMigration(ctx context.Context, tx pgx.Tx, id, cursor string, processed int64, done bool) error {
+	var appliedAt *time.Time
+	if done {
+		now := time.Now()
+		appliedAt = &now
+	}
+	_, err := tx.Exec(ctx, `INSERT INTO `+dataMigrationsTable+` (id, cursor, processed, applied_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET cursor = EXCLUDED.cursor, processed = EXCLUDED.processed, applied_at = EXCLUDED.applied_at`,
+		id, cursor, processed, appliedAt,
+	)
+	return err
+}
+
+func (m *migrationManager) applyDataMigration(ctx context.Context, conn *pgx.Conn, migration *DataMigration) error {
+	if migration.Backfill != nil {
+		return m.runBackfill(ctx, conn, migration)
+	}
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if err := migration.Up(ctx, tx); err != nil {
+			return err
+		}
+		return saveDataMigration(ctx, tx, migration.ID(), "", 0, true)
+	})
+}
+
+func (m *migrationManager) runBackfill(ctx context.Context, conn *pgx.Conn, migration *DataMigration) error {
+	id := migration.ID()
+	backfill := migration.Backfill
+	batchSize := backfill.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBackfillBatchSize
+	}
+
+	var (
+		cursor    string
+		processed int64
+	)
+	err := conn.QueryRow(ctx, `SELECT cursor, processed FROM `+dataMigrationsTable+` WHERE id = $1`, id).Scan(&cursor, &processed)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if processed > 0 {
+		m.logger.Infof("Resuming backfill %s after %d rows", id, processed)
+	}
+
+	var total int64
+	if backfill.Total != nil {
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			var err error
+			total, err = backfill.Total(ctx, tx)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to count rows: %w", err)
+		}
+	}
+
+	for done := false; !done; {
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			next, count, err := backfill.Batch(ctx, tx, cursor, batchSize)
+			if err != nil {
+				return err
+			}
+			done = count < batchSize
+			if err := saveDataMigration(ctx, tx, id, next, processed+int64(count), done); err != nil {
+				return err
+			}
+			cursor = next
+			processed += int64(count)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("batch after %d rows failed: %w", processed, err)
+		}
+		if total > 0 {
+			m.logger.Infof("Backfill %s: %d/%d rows (%.1f%%)", id, processed, total, float64(processed)*100/float64(total))
+		} else {
+			m.logger.Infof("Backfill %s: %d rows", id, processed)
+		}
+	}
+	return nil
+}
+
+func (m *migrationManager) rollbackDataMigration(ctx context.Context, conn *pgx.Conn, migration *DataMigration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if migration.Down != nil {
+			if err := migration.Down(ctx, tx); err != nil {
+				return err
+			}
+		} else {
+			m.logger.Warnf("Data migration %s has no down function, only its record is removed", migration.ID())
+		}
+		_, err := tx.Exec(ctx, `DELETE FROM `+dataMigrationsTable+` WHERE id = $1`, migration.ID())
+		return err
+	})
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stepIDs(steps []migrationStep) []string {
+	ids := make([]string, 0, len(steps))
+	for _, step := range steps {
+		ids = append(ids, step.id)
+	}
+	return ids
+}
+
+func TestPlanSteps(t *testing.T) {
+	sqlSteps := []migrationStep{
+		{id: "changes-100.sql", version: 100, phase: PhaseExpand},
+		{id: "changes-200.sql", version: 200, phase: PhaseExpand},
+		{id: "changes-300.sql", version: 300, phase: PhaseContract},
+	}
+	dataSteps := []migrationStep{
+		dataStep(&DataMigration{Version: 200, Name: "split_names"}),
+		dataStep(&DataMigration{Version: 150, Name: "fill_defaults"}),
+	}
+
+	steps := planSteps(sqlSteps, dataSteps)
+	assert.Equal(t, []string{
+		"changes-100.sql",
+		"data-150-fill_defaults",
+		"changes-200.sql",
+		"data-200-split_names",
+		"changes-300.sql",
+	}, stepIDs(steps), "data migrations run after the SQL migration of the same version")
+}
+
+func TestSelectPhase(t *testing.T) {
+	steps := []migrationStep{
+		{id: "changes-100.sql", version: 100, phase: PhaseExpand},
+		{id: "changes-200.sql", version: 200, phase: PhaseContract},
+		{id: "changes-300.sql", version: 300, phase: PhaseExpand},
+	}
+
+	expand, err := selectPhase(steps, PhaseExpand)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"changes-100.sql", "changes-300.sql"}, stepIDs(expand))
+
+	_, err = selectPhase(steps, PhaseContract)
+	require.ErrorIs(t, err, ErrExpandPending)
+
+	contract, err := selectPhase(steps[1:], PhaseContract)
+	require.NoError(t, err, "expand steps after the contract step don't block it")
+	assert.Equal(t, []string{"changes-200.sql"}, stepIDs(contract))
+}
+
+func TestReadPhase(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	phase, err := readPhase(write("expand.sql", "-- +migrate Up\nALTER TABLE users ADD COLUMN first_name varchar(255);\n"))
+	require.NoError(t, err)
+	assert.Equal(t, PhaseExpand, phase)
+
+	phase, err = readPhase(write("contract.sql", "-- +phase contract\n-- +migrate Up\nALTER TABLE users DROP COLUMN name;\n"))
+	require.NoError(t, err)
+	assert.Equal(t, PhaseContract, phase)
+
+	_, err = readPhase(write("unknown.sql", "-- +phase later\n"))
+	require.Error(t, err)
+}
+
+func TestDataMigration_validate(t *testing.T) {
+	valid := DataMigration{Version: 1, Name: "noop", Up: func(_ context.Context, _ pgx.Tx) error { return nil }}
+	require.NoError(t, valid.validate())
+
+	noop := valid
+	noop.Up = nil
+	require.ErrorIs(t, noop.validate(), ErrInvalidDataMigration, "either Up or Backfill is required")
+
+	unknownPhase := valid
+	unknownPhase.Phase = "later"
+	require.ErrorIs(t, unknownPhase.validate(), ErrInvalidDataMigration)
+
+	assert.Equal(t, int64(1719475200), migrationVersion("changes-1719475200.sql"))
+}
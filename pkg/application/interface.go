@@ -22,6 +22,9 @@ type GraphSchema struct {
 	Value      graphql.ExecutableSchema
 	BasePath   string
 	ExecutorCb func(*executor.Executor)
+	// MaxComplexity and MaxDepth override GRAPHQL_MAX_COMPLEXITY and GRAPHQL_MAX_DEPTH for the schema, -1 disables the limit
+	MaxComplexity int
+	MaxDepth      int
 }
 
 // Application with a dynamically extendable service registry
@@ -47,6 +50,15 @@ type Application interface {
 	GraphSchemas() []GraphSchema
 	RegisterServices(services ...interface{})
 	RegisterMiddleware(middleware ...mux.MiddlewareFunc)
+	// RegisterTenantSeeds registers seed functions run for every newly provisioned tenant,
+	// they are called with the new tenant in the context
+	RegisterTenantSeeds(funcs ...SeedFunc)
+	TenantSeeds() []SeedFunc
+	// RegisterUserAttributes registers providers of the user attributes policy conditions are evaluated against,
+	// e.g. the department of the user's employee record. Attributes only an application knows, like the
+	// warehouse location of its staff, have to be registered by the application the same way
+	RegisterUserAttributes(providers ...rbac.AttributeProvider)
+	UserAttributes() []rbac.AttributeProvider
 	Service(service interface{}) interface{}
 	Services() map[reflect.Type]interface{}
 	Bundle() *i18n.Bundle
@@ -5,16 +5,20 @@ import (
 	"embed"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-gorp/gorp/v3"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/sirupsen/logrus"
 
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/rls"
 	"github.com/iota-uz/iota-sdk/pkg/schema/collector"
 )
 
@@ -22,10 +26,15 @@ import (
 type MigrationManager interface {
 	// CollectSchema collects schema changes from embedded modules and generates SQL migration
 	CollectSchema(ctx context.Context) error
-	// Run applies pending migrations to the database
+	// Run applies pending SQL and data migrations of both phases to the database
 	Run() error
+	// RunPhase applies pending migrations of a single phase: expand before rolling out
+	// a new application version, contract after it
+	RunPhase(phase MigrationPhase) error
 	// Rollback rolls back the last applied migration
 	Rollback() error
+	// RegisterDataMigration registers migrations written in Go, which are applied in order with the SQL migrations
+	RegisterDataMigration(migrations ...DataMigration)
 	// RegisterSchema registers an embedded filesystem containing schema definitions
 	RegisterSchema(fs ...*embed.FS)
 	// SchemaFSs returns all registered schema embedded filesystems
@@ -38,6 +47,7 @@ func NewMigrationManager(pool *pgxpool.Pool) MigrationManager {
 		migrationsDir:  conf.MigrationsDir,
 		logger:         conf.Logger(),
 		pool:           pool,
+		rls:            conf.Database.RLS,
 		schemaEmbedFSs: make([]*embed.FS, 0),
 	}
 }
@@ -47,7 +57,9 @@ type migrationManager struct {
 	migrationsDir  string
 	logger         logrus.FieldLogger
 	pool           *pgxpool.Pool
-	schemaEmbedFSs []*embed.FS // For schema definitions in embed.FS
+	rls            bool            // Re-apply row level security policies after migrations
+	schemaEmbedFSs []*embed.FS     // For schema definitions in embed.FS
+	dataMigrations []DataMigration // Go migrations ordered together with the SQL files
 }
 
 func (m *migrationManager) SchemaFSs() []*embed.FS {
@@ -58,6 +70,10 @@ func (m *migrationManager) RegisterSchema(fs ...*embed.FS) {
 	m.schemaEmbedFSs = append(m.schemaEmbedFSs, fs...)
 }
 
+func (m *migrationManager) RegisterDataMigration(migrations ...DataMigration) {
+	m.dataMigrations = append(m.dataMigrations, migrations...)
+}
+
 // CollectSchema collects schema changes from embedded module.FS and generates SQL migration
 func (m *migrationManager) CollectSchema(ctx context.Context) error {
 	if err := os.MkdirAll(m.migrationsDir, 0755); err != nil {
@@ -155,8 +171,24 @@ func (m *migrationManager) applyMigrations(
 	return applied, nil
 }
 
+// Run applies the pending migrations of both phases
 func (m *migrationManager) Run() error {
-	db := stdlib.OpenDB(*m.pool.Config().ConnConfig)
+	return m.run(context.Background(), "")
+}
+
+// RunPhase applies the pending migrations of one phase
+func (m *migrationManager) RunPhase(phase MigrationPhase) error {
+	if phase != PhaseExpand && phase != PhaseContract {
+		return fmt.Errorf("unknown migration phase %q", phase)
+	}
+	return m.run(context.Background(), phase)
+}
+
+func (m *migrationManager) run(ctx context.Context, phase MigrationPhase) error {
+	if err := m.validateDataMigrations(); err != nil {
+		return err
+	}
+	db := stdlib.OpenDB(*m.connConfig())
 	migrationSource := &migrate.FileMigrationSource{
 		Dir: m.migrationsDir,
 	}
@@ -166,16 +198,145 @@ func (m *migrationManager) Run() error {
 		return err
 	}
 
-	applied, err := m.applyMigrations(context.Background(), migrate.Up, plannedMigrations, dbMap)
+	conn, err := pgx.ConnectConfig(ctx, m.connConfig())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	if err := ensureDataMigrationsTable(ctx, conn); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", dataMigrationsTable, err)
+	}
+	records, err := dataMigrationRecords(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	sqlSteps, err := m.sqlSteps(plannedMigrations)
+	if err != nil {
+		return err
+	}
+	dataSteps := make([]migrationStep, 0, len(m.dataMigrations))
+	for i := range m.dataMigrations {
+		migration := &m.dataMigrations[i]
+		if !records[migration.ID()] {
+			dataSteps = append(dataSteps, dataStep(migration))
+		}
+	}
+	steps := planSteps(sqlSteps, dataSteps)
+	if phase != "" {
+		if steps, err = selectPhase(steps, phase); err != nil {
+			return err
+		}
+	}
+
+	applied, err := m.applySteps(ctx, migrate.Up, steps, dbMap, conn)
 	if err != nil {
 		return err
 	}
 	m.logger.Infof("Applied %d migrations", applied)
+	if m.rls {
+		// New tenant tables have to be covered by the policies as well
+		if err := m.enableRLS(ctx); err != nil {
+			return fmt.Errorf("failed to enable row level security: %w", err)
+		}
+		m.logger.Info("Row level security policies applied")
+	}
+	return nil
+}
+
+func (m *migrationManager) validateDataMigrations() error {
+	seen := make(map[string]bool, len(m.dataMigrations))
+	for _, migration := range m.dataMigrations {
+		if err := migration.validate(); err != nil {
+			return err
+		}
+		if seen[migration.ID()] {
+			return fmt.Errorf("%w: %s is registered twice", ErrInvalidDataMigration, migration.ID())
+		}
+		seen[migration.ID()] = true
+	}
 	return nil
 }
 
+func (m *migrationManager) sqlSteps(migrations []*migrate.PlannedMigration) ([]migrationStep, error) {
+	steps := make([]migrationStep, 0, len(migrations))
+	for _, migration := range migrations {
+		phase, err := readPhase(filepath.Join(m.migrationsDir, migration.Id))
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, migrationStep{
+			id:      migration.Id,
+			version: migrationVersion(migration.Id),
+			phase:   phase,
+			sql:     migration,
+		})
+	}
+	return steps, nil
+}
+
+func dataStep(migration *DataMigration) migrationStep {
+	return migrationStep{
+		id:      migration.ID(),
+		version: migration.Version,
+		phase:   migration.phase(),
+		data:    migration,
+	}
+}
+
+// applySteps runs SQL migrations through sql-migrate's bookkeeping and data migrations over conn
+func (m *migrationManager) applySteps(
+	ctx context.Context,
+	dir migrate.MigrationDirection,
+	steps []migrationStep,
+	dbMap *gorp.DbMap,
+	conn *pgx.Conn,
+) (int, error) {
+	applied := 0
+	for _, step := range steps {
+		if step.sql != nil {
+			n, err := m.applyMigrations(ctx, dir, []*migrate.PlannedMigration{step.sql}, dbMap)
+			applied += n
+			if err != nil {
+				return applied, err
+			}
+			continue
+		}
+
+		var err error
+		if dir == migrate.Up {
+			err = m.applyDataMigration(ctx, conn, step.data)
+		} else {
+			err = m.rollbackDataMigration(ctx, conn, step.data)
+		}
+		if err != nil {
+			return applied, fmt.Errorf("data migration %s failed: %w", step.id, err)
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// connConfig is the config of the dedicated migration connections. They get through the tenant
+// policies, which hide every tenant's rows from connections without a tenant
+func (m *migrationManager) connConfig() *pgx.ConnConfig {
+	return rls.BypassConfig(m.pool.Config().ConnConfig)
+}
+
+// enableRLS applies the policies over a dedicated connection, just like the migrations themselves,
+// so that pool hooks (tenant scoping, role switching) don't interfere with the DDL
+func (m *migrationManager) enableRLS(ctx context.Context) error {
+	conn, err := pgx.ConnectConfig(ctx, m.connConfig())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	return rls.Enable(ctx, conn)
+}
+
 func (m *migrationManager) Rollback() error {
-	db := stdlib.OpenDB(*m.pool.Config().ConnConfig)
+	ctx := context.Background()
+	db := stdlib.OpenDB(*m.connConfig())
 	migrationSource := &migrate.FileMigrationSource{
 		Dir: m.migrationsDir,
 	}
@@ -185,7 +346,38 @@ func (m *migrationManager) Rollback() error {
 		return err
 	}
 
-	applied, err := m.applyMigrations(context.Background(), migrate.Down, plannedMigrations, dbMap)
+	conn, err := pgx.ConnectConfig(ctx, m.connConfig())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	if err := ensureDataMigrationsTable(ctx, conn); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", dataMigrationsTable, err)
+	}
+	records, err := dataMigrationRecords(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	// Steps are planned in the order they were applied and undone in reverse,
+	// so data migrations are rolled back before the schema they depend on
+	sqlSteps, err := m.sqlSteps(plannedMigrations)
+	if err != nil {
+		return err
+	}
+	slices.Reverse(sqlSteps)
+	dataSteps := make([]migrationStep, 0, len(m.dataMigrations))
+	for i := range m.dataMigrations {
+		migration := &m.dataMigrations[i]
+		// Interrupted backfills are rolled back as well
+		if _, ok := records[migration.ID()]; ok {
+			dataSteps = append(dataSteps, dataStep(migration))
+		}
+	}
+	steps := planSteps(sqlSteps, dataSteps)
+	slices.Reverse(steps)
+
+	applied, err := m.applySteps(ctx, migrate.Down, steps, dbMap, conn)
 	if err != nil {
 		return err
 	}
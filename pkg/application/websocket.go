@@ -128,13 +128,14 @@ func (h *huber) ForEach(channel string, f WsCallback) error {
 			h.logger.Error("connection meta not found")
 			continue
 		}
-		usr, err := h.userRepo.GetByID(ctx, meta.UserID)
+		tenantCtx := composables.WithTenantID(ctx, meta.TenantID)
+		usr, err := h.userRepo.GetByID(tenantCtx, meta.UserID)
 		if err != nil {
 			h.logger.WithError(err).Error("failed to get user by ID")
 			continue
 		}
 		localizer := i18n.NewLocalizer(h.bundle, string(usr.UILanguage()))
-		connCtx := intl.WithLocalizer(ctx, localizer)
+		connCtx := intl.WithLocalizer(tenantCtx, localizer)
 		connCtx = composables.WithPageCtx(connCtx, &types.PageContext{
 			URL:       MustParseURL("/"),
 			Locale:    language.English,
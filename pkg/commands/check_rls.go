@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/rls"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// CheckRLS lists the tables with a tenant_id column that are not protected by a row level security policy
+func CheckRLS() error {
+	conf := configuration.Use()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	conn, err := pgx.Connect(ctx, conf.Database.Opts)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	tables, err := rls.Tables(ctx, conn)
+	if err != nil {
+		return err
+	}
+	missing, err := rls.Missing(ctx, conn)
+	if err != nil {
+		return err
+	}
+	for _, table := range missing {
+		conf.Logger().WithField("table", table).Error("Table is missing row level security policy")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"%d of %d tenant tables are missing row level security policies: %s",
+			len(missing), len(tables), strings.Join(missing, ", "),
+		)
+	}
+
+	conf.Logger().WithFields(logrus.Fields{
+		"table_count": len(tables),
+	}).Info("All tenant tables are protected by row level security policies")
+	return nil
+}
@@ -5,20 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/iota-uz/iota-sdk/modules"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/rls"
 	"github.com/iota-uz/iota-sdk/pkg/schema/collector"
 	"github.com/iota-uz/utils/env"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
 
+// allowDestructiveFlag lets 'collect' generate changes that drop data or narrow column types
+const allowDestructiveFlag = "--allow-destructive"
+
 var (
-	ErrNoCommand = errors.New("expected 'up', 'down', 'redo', or 'collect' subcommands")
+	ErrNoCommand = errors.New("expected 'up', 'down', 'redo', 'expand', 'contract', 'collect' or 'check' subcommands")
 )
 
 // ensureDirectories creates necessary directories if they don't exist
@@ -47,7 +52,7 @@ func Migrate(mods ...application.Module) error {
 
 	command := os.Args[1]
 
-	pool, err := pgxpool.New(ctx, conf.Database.Opts)
+	pool, err := rls.NewSystemPool(ctx, conf.Database.Opts)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -65,8 +70,8 @@ func Migrate(mods ...application.Module) error {
 	}
 
 	switch command {
-	case "collect":
-		return handleSchemaCommands(ctx, command, app, conf.Logger().Level)
+	case "collect", "check":
+		return handleSchemaCommands(ctx, command, os.Args[2:], app, pool, conf.Logger().Level)
 	default:
 		return handleMigrationCommands(ctx, command, app.Migrations())
 	}
@@ -75,23 +80,43 @@ func Migrate(mods ...application.Module) error {
 func handleSchemaCommands(
 	ctx context.Context,
 	command string,
+	args []string,
 	app application.Application,
+	pool *pgxpool.Pool,
 	logLevel logrus.Level,
 ) error {
 	migrationsPath := env.GetEnv("MIGRATIONS_DIR", "migrations")
 
+	schemaCollector := collector.New(collector.Config{
+		MigrationsPath:   migrationsPath,
+		LogLevel:         logLevel,
+		EmbedFSs:         app.Migrations().SchemaFSs(),
+		AllowDestructive: slices.Contains(args, allowDestructiveFlag),
+	})
+
 	switch command {
 	case "collect":
-		collector := collector.New(collector.Config{
-			MigrationsPath: migrationsPath,
-			LogLevel:       logLevel,
-			EmbedFSs:       app.Migrations().SchemaFSs(),
-		})
-		upChanges, downChanges, err := collector.CollectMigrations(ctx)
+		upChanges, downChanges, err := schemaCollector.CollectMigrations(ctx)
+		if errors.Is(err, collector.ErrDestructiveChanges) {
+			return fmt.Errorf("%w\nReview the changes and run 'collect %s' to generate them anyway", err, allowDestructiveFlag)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to collect migrations: %w", err)
 		}
-		return collector.StoreMigrations(upChanges, downChanges)
+		return schemaCollector.StoreMigrations(upChanges, downChanges)
+
+	case "check":
+		drift, err := schemaCollector.CheckDrift(ctx, pool)
+		if err != nil {
+			return fmt.Errorf("failed to check schema drift: %w", err)
+		}
+		if len(drift) == 0 {
+			return nil
+		}
+		for _, d := range drift {
+			fmt.Println(d)
+		}
+		return fmt.Errorf("%w: %d differences", collector.ErrSchemaDrift, len(drift))
 
 	default:
 		return fmt.Errorf("unknown schema command: %s", command)
@@ -112,6 +137,10 @@ func handleMigrationCommands(
 		if err := migrationManager.Rollback(); err != nil {
 			return fmt.Errorf("failed to rollback migrations: %w", err)
 		}
+	case "expand", "contract":
+		if err := migrationManager.RunPhase(application.MigrationPhase(command)); err != nil {
+			return fmt.Errorf("failed to run %s migrations: %w", command, err)
+		}
 	case "redo":
 		if err := migrationManager.Rollback(); err != nil {
 			return errors.Join(err, errors.New("failed to rollback migrations"))
@@ -121,7 +150,7 @@ func handleMigrationCommands(
 		}
 
 	default:
-		return fmt.Errorf("unsupported command: %s\nSupported commands: 'up', 'down', 'redo', 'collect'", command)
+		return fmt.Errorf("unsupported command: %s\nSupported commands: 'up', 'down', 'redo', 'expand', 'contract', 'collect', 'check'", command)
 	}
 
 	return nil
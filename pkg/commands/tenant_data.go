@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/rls"
+)
+
+// ExportTenant writes the archive of the tenant to the file at path
+func ExportTenant(tenantID string, path string, mods ...application.Module) error {
+	id, err := uuid.Parse(tenantID)
+	if err != nil {
+		return fmt.Errorf("invalid tenant id: %w", err)
+	}
+	return withTenantDataService(mods, func(ctx context.Context, service *services.TenantDataService) error {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		manifest, err := service.Export(ctx, id, f)
+		if err != nil {
+			return err
+		}
+		rows := 0
+		for _, t := range manifest.Tables {
+			rows += t.Rows
+		}
+		composables.UseLogger(ctx).WithFields(logrus.Fields{
+			"tenant": manifest.TenantName,
+			"tables": len(manifest.Tables),
+			"rows":   rows,
+			"files":  manifest.Files,
+			"path":   path,
+		}).Info("Tenant exported")
+		return nil
+	})
+}
+
+// ImportTenant creates a new tenant from the archive at path, name and subdomain are optional
+func ImportTenant(path string, name string, subdomain string, mods ...application.Module) error {
+	return withTenantDataService(mods, func(ctx context.Context, service *services.TenantDataService) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		created, result, err := service.Import(ctx, f, info.Size(), &services.ImportTenantParams{
+			Name:      name,
+			Subdomain: subdomain,
+		})
+		if err != nil {
+			return err
+		}
+		rows := 0
+		for _, count := range result.Rows {
+			rows += count
+		}
+		composables.UseLogger(ctx).WithFields(logrus.Fields{
+			"tenant_id": created.ID(),
+			"tenant":    created.Name(),
+			"domain":    created.Domain(),
+			"rows":      rows,
+			"files":     result.Files,
+		}).Info("Tenant imported")
+		return nil
+	})
+}
+
+func withTenantDataService(mods []application.Module, fn func(ctx context.Context, service *services.TenantDataService) error) error {
+	conf := configuration.Use()
+	ctx := context.Background()
+
+	pool, err := rls.NewSystemPool(ctx, conf.Database.Opts)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	app := application.New(&application.ApplicationOptions{
+		Pool:     pool,
+		Bundle:   application.LoadBundle(),
+		EventBus: eventbus.NewEventPublisher(conf.Logger()),
+		Logger:   conf.Logger(),
+	})
+	if err := modules.Load(app, mods...); err != nil {
+		return err
+	}
+	ctx = composables.WithPool(ctx, pool)
+	ctx = context.WithValue(ctx, constants.LoggerKey, logrus.NewEntry(conf.Logger()))
+	return fn(ctx, app.Service(services.TenantDataService{}).(*services.TenantDataService))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
 	"github.com/iota-uz/iota-sdk/pkg/constants"
@@ -12,8 +13,9 @@ import (
 )
 
 var (
-	ErrNoSessionFound = errors.New("no session found")
-	ErrNoUserFound    = errors.New("no user found")
+	ErrNoSessionFound  = errors.New("no session found")
+	ErrNoUserFound     = errors.New("no user found")
+	ErrNoAPITokenFound = errors.New("no api token found")
 )
 
 // UseUser returns the user from the context.
@@ -73,6 +75,48 @@ func CanUserAny(ctx context.Context, perms ...rbac.Permission) error {
 	return nil
 }
 
+// UseScope returns the part of the resource of permission the user can access.
+// Contexts without a user, e.g. event handlers and background jobs, can access everything.
+func UseScope(ctx context.Context, permission *permission.Permission) rbac.Scope {
+	u, _ := UseUser(ctx)
+	if u == nil {
+		return rbac.ScopeAll
+	}
+	return rbac.ScopeOf(u, permission)
+}
+
+// UseOwnScope returns the user when they hold permission only with ModifierOwn,
+// meaning that queries have to be narrowed down to the rows they own.
+func UseOwnScope(ctx context.Context, permission *permission.Permission) (user.User, bool) {
+	u, _ := UseUser(ctx)
+	if u == nil || rbac.ScopeOf(u, permission) != rbac.ScopeOwn {
+		return nil, false
+	}
+	return u, true
+}
+
+// CanUserScoped checks that the user holds permission with any modifier.
+// Use it in front of queries that are scoped with UseOwnScope.
+func CanUserScoped(ctx context.Context, permission *permission.Permission) error {
+	if UseScope(ctx, permission) == rbac.ScopeNone {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// CanUserAccess checks that the user can apply permission to resource,
+// either by holding it with ModifierAll or by owning the resource.
+func CanUserAccess(ctx context.Context, permission *permission.Permission, resource rbac.Owned) error {
+	u, _ := UseUser(ctx)
+	if u == nil {
+		return nil
+	}
+	if !rbac.Perm(permission).CanAccess(u, resource) {
+		return ErrForbidden
+	}
+	return nil
+}
+
 // UseSession returns the session from the context.
 func UseSession(ctx context.Context) (*session.Session, error) {
 	sess, ok := ctx.Value(constants.SessionKey).(*session.Session)
@@ -86,3 +130,17 @@ func UseSession(ctx context.Context) (*session.Session, error) {
 func WithSession(ctx context.Context, sess *session.Session) context.Context {
 	return context.WithValue(ctx, constants.SessionKey, sess)
 }
+
+// UseAPIToken returns the API token the request was authorized with, requests authorized with a session have none.
+func UseAPIToken(ctx context.Context) (*apitoken.Token, error) {
+	token, ok := ctx.Value(constants.APITokenKey).(*apitoken.Token)
+	if !ok {
+		return nil, ErrNoAPITokenFound
+	}
+	return token, nil
+}
+
+// WithAPIToken returns a new context with the API token.
+func WithAPIToken(ctx context.Context, token *apitoken.Token) context.Context {
+	return context.WithValue(ctx, constants.APITokenKey, token)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/pkg/constants"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 	"github.com/jackc/pgx/v5"
@@ -15,6 +16,26 @@ var (
 	ErrNoPool = errors.New("no database pool found in context")
 )
 
+// TenantSetting is the run-time parameter the row level security policies compare tenant_id with
+const TenantSetting = "app.tenant_id"
+
+// TenantIsolationBypassSetting is the run-time parameter that lets a connection without a tenant
+// through the row level security policies, which otherwise hide every tenant's rows from it
+const TenantIsolationBypassSetting = "app.rls_bypass"
+
+// WithoutTenantIsolation marks ctx as system work spanning tenants, such as polling the job queue
+// or looking up a session before its tenant is known. Transactions and pool connections of the
+// context get through the row level security policies as long as it has no tenant.
+func WithoutTenantIsolation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, constants.TenantIsolationBypassKey, true)
+}
+
+// BypassesTenantIsolation reports whether ctx was marked by WithoutTenantIsolation
+func BypassesTenantIsolation(ctx context.Context) bool {
+	bypass, _ := ctx.Value(constants.TenantIsolationBypassKey).(bool)
+	return bypass
+}
+
 func WithTx(ctx context.Context, tx pgx.Tx) context.Context {
 	return context.WithValue(ctx, constants.TxKey, tx)
 }
@@ -40,15 +61,38 @@ func UsePool(ctx context.Context) (*pgxpool.Pool, error) {
 }
 
 func BeginTx(ctx context.Context) (pgx.Tx, error) {
-	tx := ctx.Value(constants.TxKey)
-	if tx != nil {
+	if tx := ctx.Value(constants.TxKey); tx != nil {
 		return tx.(pgx.Tx), nil
 	}
 	pool, err := UsePool(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return pool.Begin(ctx)
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ApplyTenant(ctx, tx); err != nil {
+		return nil, errors.Join(err, tx.Rollback(ctx))
+	}
+	return tx, nil
+}
+
+// ApplyTenant scopes the transaction to the tenant in ctx, same as SET LOCAL app.tenant_id,
+// so that the row level security policies hide the rows of other tenants.
+// Transactions of a context without a tenant see no tenant rows unless the context
+// was marked by WithoutTenantIsolation.
+func ApplyTenant(ctx context.Context, tx repo.Tx) error {
+	tenantID, err := UseTenantID(ctx)
+	if err == nil && tenantID != uuid.Nil {
+		_, err = tx.Exec(ctx, "SELECT set_config($1, $2, true)", TenantSetting, tenantID.String())
+		return err
+	}
+	if BypassesTenantIsolation(ctx) {
+		_, err = tx.Exec(ctx, "SELECT set_config($1, 'on', true)", TenantIsolationBypassSetting)
+		return err
+	}
+	return nil
 }
 
 func InTx(ctx context.Context, fn func(context.Context) error) error {
@@ -61,6 +105,9 @@ func InTx(ctx context.Context, fn func(context.Context) error) error {
 	if err != nil {
 		return err
 	}
+	if err := ApplyTenant(ctx, tx); err != nil {
+		return errors.Join(err, tx.Rollback(ctx))
+	}
 
 	if err := fn(WithTx(ctx, tx)); err != nil {
 		if rErr := tx.Rollback(ctx); rErr != nil {
@@ -0,0 +1,53 @@
+package composables
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+)
+
+// UsePolicyEngine returns the policy engine from the context.
+// If the engine is not found, the second return value will be false.
+func UsePolicyEngine(ctx context.Context) (*rbac.Engine, bool) {
+	engine, ok := ctx.Value(constants.PolicyEngineKey).(*rbac.Engine)
+	return engine, ok
+}
+
+// WithPolicyEngine returns a new context with the policy engine.
+func WithPolicyEngine(ctx context.Context, engine *rbac.Engine) context.Context {
+	return context.WithValue(ctx, constants.PolicyEngineKey, engine)
+}
+
+// Decide evaluates permission and the tenant's policies for resource on behalf of the user.
+// resource may be nil, an rbac.Owned or rbac.Attributed entity, or a map of attributes.
+// Without a policy engine in the context only permissions and ownership are checked,
+// without a user (e.g. background jobs) everything is allowed.
+func Decide(ctx context.Context, permission *permission.Permission, resource any) (rbac.Decision, error) {
+	u, _ := UseUser(ctx)
+	if u == nil {
+		return rbac.Decision{Allowed: true, Reason: rbac.ReasonAllowed}, nil
+	}
+	request := map[string]any{}
+	if params, ok := UseParams(ctx); ok && params.Request != nil {
+		request = rbac.RequestAttributes(params.Request)
+	}
+	engine, ok := UsePolicyEngine(ctx)
+	if !ok {
+		return rbac.EvaluatePolicies(u, permission, nil, rbac.NewAttributes(u, resource, request)), nil
+	}
+	return engine.Evaluate(ctx, u, permission, resource, request)
+}
+
+// Authorize returns ErrForbidden unless Decide allows the user to apply permission to resource
+func Authorize(ctx context.Context, permission *permission.Permission, resource any) error {
+	decision, err := Decide(ctx, permission, resource)
+	if err != nil {
+		return err
+	}
+	if !decision.Allowed {
+		return ErrForbidden
+	}
+	return nil
+}
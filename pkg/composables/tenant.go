@@ -5,11 +5,13 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tenant"
 	"github.com/iota-uz/iota-sdk/pkg/constants"
 )
 
 var (
-	ErrNoTenantIDFound = errors.New("no tenant id found in context")
+	ErrNoTenantIDFound   = errors.New("no tenant id found in context")
+	ErrNoHostTenantFound = errors.New("no host tenant found in context")
 )
 
 type Tenant struct {
@@ -29,3 +31,17 @@ func UseTenantID(ctx context.Context) (uuid.UUID, error) {
 func WithTenantID(ctx context.Context, tenantID uuid.UUID) context.Context {
 	return context.WithValue(ctx, constants.TenantIDKey, tenantID)
 }
+
+// UseHostTenant returns the tenant served at the host of the request
+func UseHostTenant(ctx context.Context) (*tenant.Tenant, error) {
+	t, ok := ctx.Value(constants.HostTenantKey).(*tenant.Tenant)
+	if !ok {
+		return nil, ErrNoHostTenantFound
+	}
+	return t, nil
+}
+
+// WithHostTenant stores the tenant served at the host of the request and scopes the context to it
+func WithHostTenant(ctx context.Context, t *tenant.Tenant) context.Context {
+	return WithTenantID(context.WithValue(ctx, constants.HostTenantKey, t), t.ID())
+}
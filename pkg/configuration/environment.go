@@ -3,6 +3,7 @@ package configuration
 import (
 	"fmt"
 	"log"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"sync"
@@ -56,6 +57,8 @@ type DatabaseOptions struct {
 	Port     string `env:"DB_PORT" envDefault:"5432"`
 	User     string `env:"DB_USER" envDefault:"postgres"`
 	Password string `env:"DB_PASSWORD" envDefault:"postgres"`
+	// RLS enables the row level security policies on tenant tables, see pkg/rls
+	RLS bool `env:"DB_RLS" envDefault:"false"`
 }
 
 func (d *DatabaseOptions) ConnectionString() string {
@@ -78,6 +81,33 @@ type TwilioOptions struct {
 	PhoneNumber string `env:"TWILIO_PHONE_NUMBER"`
 }
 
+type EmailOptions struct {
+	// SMTP relay used to send chat messages by email, the email transport is disabled when empty
+	SMTPAddr     string `env:"EMAIL_SMTP_ADDR"`
+	SMTPUsername string `env:"EMAIL_SMTP_USERNAME"`
+	SMTPPassword string `env:"EMAIL_SMTP_PASSWORD"`
+	From         string `env:"EMAIL_FROM"`
+	Subject      string `env:"EMAIL_SUBJECT" envDefault:"New message"`
+	// IMAP mailbox polled for client replies, polling is disabled when empty
+	IMAPAddr     string        `env:"EMAIL_IMAP_ADDR"`
+	IMAPUsername string        `env:"EMAIL_IMAP_USERNAME"`
+	IMAPPassword string        `env:"EMAIL_IMAP_PASSWORD"`
+	IMAPFolder   string        `env:"EMAIL_IMAP_FOLDER" envDefault:"INBOX"`
+	IMAPTLS      bool          `env:"EMAIL_IMAP_TLS" envDefault:"true"`
+	PollInterval time.Duration `env:"EMAIL_POLL_INTERVAL" envDefault:"1m"`
+	// Tenant whose clients write to the mailbox
+	TenantID string `env:"EMAIL_TENANT_ID"`
+}
+
+type TelegramOptions struct {
+	// Tenant whose clients write to the bot of TELEGRAM_BOT_TOKEN, the bot only sends messages when empty
+	TenantID string `env:"TELEGRAM_TENANT_ID"`
+	// Public URL of the /telegram endpoint, the bot long polls for updates when empty
+	WebhookURL    string        `env:"TELEGRAM_WEBHOOK_URL"`
+	WebhookSecret string        `env:"TELEGRAM_WEBHOOK_SECRET"`
+	PollTimeout   time.Duration `env:"TELEGRAM_POLL_TIMEOUT" envDefault:"30s"`
+}
+
 type LokiOptions struct {
 	URL     string `env:"LOKI_URL"`
 	AppName string `env:"LOKI_APP_NAME" envDefault:"sdk"`
@@ -117,16 +147,78 @@ type StripeOptions struct {
 	SigningSecret string `env:"STRIPE_SIGNING_SECRET"`
 }
 
+type JobsOptions struct {
+	// Number of jobs processed concurrently by a single server, 0 disables background workers
+	Workers      int           `env:"JOB_WORKERS" envDefault:"2"`
+	PollInterval time.Duration `env:"JOB_POLL_INTERVAL" envDefault:"2s"`
+}
+
+type ActionLogsOptions struct {
+	// Days the audit trail is kept for tenants without their own setting, 0 keeps it forever
+	RetentionDays int           `env:"ACTION_LOG_RETENTION_DAYS" envDefault:"0"`
+	PurgeInterval time.Duration `env:"ACTION_LOG_PURGE_INTERVAL" envDefault:"1h"`
+}
+
+type TenantsOptions struct {
+	// Domain tenant subdomains are created under, a tenant with subdomain "acme" is served at acme.<BaseDomain>
+	BaseDomain string `env:"TENANT_BASE_DOMAIN"`
+	// Tenant whose users listed in SUPER_ADMIN_EMAILS manage the other tenants
+	PlatformTenantID string   `env:"PLATFORM_TENANT_ID" envDefault:"00000000-0000-0000-0000-000000000001"`
+	SuperAdminEmails []string `env:"SUPER_ADMIN_EMAILS" envSeparator:","`
+	// How long the admin invite of a new tenant stays valid
+	InviteTTL time.Duration `env:"TENANT_INVITE_TTL" envDefault:"168h"`
+}
+
+type WebhooksOptions struct {
+	// Number of deliveries sent concurrently by a single server, 0 disables outbound webhooks
+	Workers      int           `env:"WEBHOOK_WORKERS" envDefault:"1"`
+	PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" envDefault:"2s"`
+	// How long an endpoint has to respond before the attempt counts as failed
+	Timeout time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	// Attempts of a delivery before it is marked as failed for good
+	MaxAttempts int `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	// Private networks endpoints may point to, e.g. "10.0.0.0/8,192.168.1.10/32". Loopback, private,
+	// link-local and multicast addresses are rejected otherwise. Only meant for on-premise installations
+	AllowedNetworks []netip.Prefix `env:"WEBHOOK_ALLOWED_NETWORKS" envSeparator:","`
+}
+
+type GraphQLOptions struct {
+	// Limits of the schemas that don't set their own, 0 disables a limit
+	MaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY" envDefault:"1000"`
+	MaxDepth      int `env:"GRAPHQL_MAX_DEPTH" envDefault:"12"`
+	// Store of automatic persisted queries: "memory", "postgres" or empty to disable them
+	APQStore string `env:"GRAPHQL_APQ_STORE" envDefault:"memory"`
+	// Number of persisted queries kept in memory, the postgres store caches as many in front of the table
+	APQCacheSize int `env:"GRAPHQL_APQ_CACHE_SIZE" envDefault:"1000"`
+}
+
+type RateLimitOptions struct {
+	// Requests per second a signed in user can make across HTTP controllers and GraphQL, 0 disables the limit
+	UserRate  float64 `env:"RATE_LIMIT_USER_RPS" envDefault:"0"`
+	UserBurst int     `env:"RATE_LIMIT_USER_BURST" envDefault:"60"`
+	// Requests per second made with a single API token, 0 disables the limit
+	TokenRate  float64 `env:"RATE_LIMIT_TOKEN_RPS" envDefault:"0"`
+	TokenBurst int     `env:"RATE_LIMIT_TOKEN_BURST" envDefault:"30"`
+}
+
 type Configuration struct {
 	Database      DatabaseOptions
 	Google        GoogleOptions
 	Twilio        TwilioOptions
+	Email         EmailOptions
+	Telegram      TelegramOptions
 	Loki          LokiOptions
 	OpenTelemetry OpenTelemetryOptions
 	Click         ClickOptions
 	Payme         PaymeOptions
 	Octo          OctoOptions
 	Stripe        StripeOptions
+	Jobs          JobsOptions
+	ActionLogs    ActionLogsOptions
+	Tenants       TenantsOptions
+	Webhooks      WebhooksOptions
+	GraphQL       GraphQLOptions
+	RateLimit     RateLimitOptions
 
 	MigrationsDir    string        `env:"MIGRATIONS_DIR" envDefault:"migrations"`
 	ServerPort       int           `env:"PORT" envDefault:"3200"`
@@ -5,8 +5,10 @@ import "github.com/go-playground/validator/v10"
 type ContextKey string
 
 const (
-	UserKey         ContextKey = "user"
-	SessionKey      ContextKey = "session"
+	UserKey    ContextKey = "user"
+	SessionKey ContextKey = "session"
+	// APITokenKey holds the API token a request was authorized with
+	APITokenKey     ContextKey = "apiToken"
 	NavItemsKey     ContextKey = "navItems"
 	AllNavItemsKey  ContextKey = "allNavItems"
 	TxKey           ContextKey = "poolTx"
@@ -22,6 +24,12 @@ const (
 
 	PageContext ContextKey = "pageContext"
 	TenantIDKey ContextKey = "tenant"
+	// HostTenantKey holds the tenant resolved from the request host
+	HostTenantKey ContextKey = "hostTenant"
+	// TenantIsolationBypassKey marks system work that spans tenants, see composables.WithoutTenantIsolation
+	TenantIsolationBypassKey ContextKey = "tenantIsolationBypass"
+
+	PolicyEngineKey ContextKey = "policyEngine"
 )
 
 var Validate = validator.New(validator.WithRequiredStructEnabled())
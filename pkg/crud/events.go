@@ -1,30 +1,50 @@
 package crud
 
-import "context"
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
 
 type Event[TEntity any] interface {
 	SetResult(result TEntity)
 }
 
-func NewCreatedEvent[TEntity any](_ context.Context, data TEntity) (*CreatedEvent[TEntity], error) {
+func NewCreatedEvent[TEntity any](ctx context.Context, data TEntity) (*CreatedEvent[TEntity], error) {
 	return &CreatedEvent[TEntity]{
-		Data: data,
+		TenantID: eventTenantID(ctx),
+		Data:     data,
 	}, nil
 }
 
-func NewUpdatedEvent[TEntity any](_ context.Context, data TEntity) (*UpdatedEvent[TEntity], error) {
+func NewUpdatedEvent[TEntity any](ctx context.Context, data TEntity) (*UpdatedEvent[TEntity], error) {
 	return &UpdatedEvent[TEntity]{
-		Data: data,
+		TenantID: eventTenantID(ctx),
+		Data:     data,
+	}, nil
+}
+
+func NewDeletedEvent[TEntity any](ctx context.Context) (*DeletedEvent[TEntity], error) {
+	return &DeletedEvent[TEntity]{
+		TenantID: eventTenantID(ctx),
 	}, nil
 }
 
-func NewDeletedEvent[TEntity any](_ context.Context) (*DeletedEvent[TEntity], error) {
-	return &DeletedEvent[TEntity]{}, nil
+// eventTenantID returns the tenant of the request, uuid.Nil outside of one
+func eventTenantID(ctx context.Context) uuid.UUID {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return uuid.Nil
+	}
+	return tenantID
 }
 
 type CreatedEvent[TEntity any] struct {
-	Data   TEntity
-	Result TEntity
+	TenantID uuid.UUID
+	Data     TEntity
+	Result   TEntity
 }
 
 func (e *CreatedEvent[TEntity]) SetResult(result TEntity) {
@@ -32,8 +52,9 @@ func (e *CreatedEvent[TEntity]) SetResult(result TEntity) {
 }
 
 type UpdatedEvent[TEntity any] struct {
-	Data   TEntity
-	Result TEntity
+	TenantID uuid.UUID
+	Data     TEntity
+	Result   TEntity
 }
 
 func (e *UpdatedEvent[TEntity]) SetResult(result TEntity) {
@@ -41,5 +62,6 @@ func (e *UpdatedEvent[TEntity]) SetResult(result TEntity) {
 }
 
 type DeletedEvent[TEntity any] struct {
-	Data TEntity
+	TenantID uuid.UUID
+	Data     TEntity
 }
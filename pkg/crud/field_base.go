@@ -27,6 +27,8 @@ const (
 	DateTimeFieldType  FieldType = "datetime"
 	TimestampFieldType FieldType = "timestamp"
 	UUIDFieldType      FieldType = "uuid"
+	// CollectionFieldType holds the child rows of a one-to-many relation as [][]FieldValue
+	CollectionFieldType FieldType = "collection"
 )
 
 const (
@@ -52,6 +54,7 @@ const (
 	DefaultValue string = "defaultValue"
 	TrueLabel    string = "trueLabel"
 	FalseLabel   string = "falseLabel"
+	Accept       string = "accept"
 )
 
 type Field interface {
@@ -255,6 +258,10 @@ func isValidType(fieldType FieldType, value any) bool {
 		_, ok := value.(uuid.UUID)
 		return ok
 
+	case CollectionFieldType:
+		_, ok := value.([][]FieldValue)
+		return ok
+
 	default:
 		return false
 	}
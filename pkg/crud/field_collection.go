@@ -0,0 +1,103 @@
+package crud
+
+import (
+	"fmt"
+)
+
+// CollectionField holds the child rows of a one-to-many relation.
+// Its value is [][]FieldValue, one slice of child field values per row. The repository loads
+// the rows together with the parent and replaces them within the transaction saving the parent.
+type CollectionField interface {
+	Field
+
+	// Child is the schema of the child rows
+	Child() RelatedSchema
+	// ForeignKey is the child field referencing the key of the parent
+	ForeignKey() Field
+	// EditableFields are the child fields edited inline: everything except the child key and the foreign key
+	EditableFields() []Field
+	// Rows returns a copy of the rows of the value with the foreign key set to the given parent key
+	Rows(value FieldValue, parentKey any) [][]FieldValue
+}
+
+// NewOneToManyField creates a collection of rows of the child schema referencing the parent through foreignKey
+func NewOneToManyField(
+	name string,
+	child RelatedSchema,
+	foreignKey string,
+	opts ...FieldOption,
+) CollectionField {
+	fk, err := child.Fields().Field(foreignKey)
+	if err != nil {
+		panic(fmt.Sprintf("one-to-many field %q: %v in schema %q", name, err, child.Name()))
+	}
+
+	f := newField(
+		name,
+		CollectionFieldType,
+		opts...,
+	).(*field)
+
+	return &collectionField{
+		field:      f,
+		child:      child,
+		foreignKey: fk,
+	}
+}
+
+type collectionField struct {
+	*field
+	child      RelatedSchema
+	foreignKey Field
+}
+
+func (f *collectionField) Child() RelatedSchema {
+	return f.child
+}
+
+func (f *collectionField) ForeignKey() Field {
+	return f.foreignKey
+}
+
+func (f *collectionField) EditableFields() []Field {
+	result := make([]Field, 0, len(f.child.Fields().Fields()))
+	for _, cf := range f.child.Fields().Fields() {
+		if cf.Key() || cf.Name() == f.foreignKey.Name() {
+			continue
+		}
+		result = append(result, cf)
+	}
+	return result
+}
+
+func (f *collectionField) Rows(value FieldValue, parentKey any) [][]FieldValue {
+	if value == nil {
+		return nil
+	}
+	rows, ok := value.Value().([][]FieldValue)
+	if !ok {
+		return nil
+	}
+
+	result := make([][]FieldValue, len(rows))
+	for i, row := range rows {
+		values := make([]FieldValue, 0, len(row)+1)
+		for _, fv := range row {
+			if fv.Field().Name() != f.foreignKey.Name() {
+				values = append(values, fv)
+			}
+		}
+		result[i] = append(values, f.foreignKey.Value(parentKey))
+	}
+	return result
+}
+
+// Value accepts nil as an empty collection
+func (f *collectionField) Value(value any) FieldValue {
+	if value == nil {
+		value = [][]FieldValue{}
+	}
+	fv := f.field.Value(value).(*fieldValue)
+	fv.field = f
+	return fv
+}
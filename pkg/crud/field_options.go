@@ -226,6 +226,13 @@ func WithFalseLabel(label string) FieldOption {
 	}
 }
 
+// WithAccept limits the file types an upload field accepts, e.g. "image/*" or ".pdf,.docx"
+func WithAccept(accept string) FieldOption {
+	return func(field *field) {
+		field.attrs[Accept] = accept
+	}
+}
+
 func WithURL() FieldOption {
 	return func(field *field) {
 		field.rules = append(field.rules, URLRule())
@@ -0,0 +1,148 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// RelatedSchema is the part of a schema relation fields need to reach its table.
+// Every Schema[TEntity] implements it.
+type RelatedSchema interface {
+	Name() string
+	Fields() Fields
+}
+
+// ReferenceField stores the key of a record of another schema and renders as a searchable select
+type ReferenceField interface {
+	SelectField
+
+	// Target is the referenced schema
+	Target() RelatedSchema
+	// LabelField is the field of the target schema shown instead of the key
+	LabelField() Field
+	// LabelAlias is the name the referenced label is known under in filters and sorting, e.g. "currency_id__label"
+	LabelAlias() string
+
+	// Search returns up to limit options of the target whose label contains the query.
+	// Option values are the target keys formatted as text.
+	Search(ctx context.Context, query string, limit int) ([]SelectOption, error)
+	// Labels resolves the labels of the given target keys. Keys are formatted with fmt.Sprint.
+	Labels(ctx context.Context, keys ...any) (map[string]string, error)
+}
+
+// NewReferenceField creates a field pointing to the key of the target schema.
+// The value type follows the type of the target key and labelField names the target field displayed to users.
+func NewReferenceField(
+	name string,
+	target RelatedSchema,
+	labelField string,
+	opts ...FieldOption,
+) ReferenceField {
+	label, err := target.Fields().Field(labelField)
+	if err != nil {
+		panic(fmt.Sprintf("reference field %q: %v in schema %q", name, err, target.Name()))
+	}
+
+	sf := NewSelectField(name, opts...).(*selectField)
+	sf.SetValueType(target.Fields().KeyField().Type())
+	sf.selectType = SelectTypeSearchable
+	sf.attrs["isReferenceField"] = true
+
+	return &referenceField{
+		selectField: sf,
+		target:      target,
+		labelField:  label,
+	}
+}
+
+type referenceField struct {
+	*selectField
+	target     RelatedSchema
+	labelField Field
+}
+
+func (f *referenceField) Target() RelatedSchema {
+	return f.target
+}
+
+func (f *referenceField) LabelField() Field {
+	return f.labelField
+}
+
+func (f *referenceField) LabelAlias() string {
+	return f.name + "__label"
+}
+
+func (f *referenceField) Search(ctx context.Context, query string, limit int) ([]SelectOption, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+
+	keyField := f.target.Fields().KeyField()
+	sql := fmt.Sprintf(
+		"SELECT %s::text, COALESCE(%s::text, '') FROM %s WHERE LOWER(%s::text) LIKE $1 ORDER BY %s LIMIT $2",
+		keyField.Name(), f.labelField.Name(), f.target.Name(), f.labelField.Name(), f.labelField.Name(),
+	)
+	rows, err := tx.Query(ctx, sql, "%"+strings.ToLower(query)+"%", limit)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to search %s", f.target.Name()))
+	}
+	defer rows.Close()
+
+	options := make([]SelectOption, 0, limit)
+	for rows.Next() {
+		var key, label string
+		if err := rows.Scan(&key, &label); err != nil {
+			return nil, errors.Wrap(err, "failed to scan reference option")
+		}
+		options = append(options, SelectOption{Value: key, Label: label})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+	return options, nil
+}
+
+func (f *referenceField) Labels(ctx context.Context, keys ...any) (map[string]string, error) {
+	labels := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return labels, nil
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+
+	keyField := f.target.Fields().KeyField()
+	sql := fmt.Sprintf(
+		"SELECT %s::text, COALESCE(%s::text, '') FROM %s WHERE %s::text = ANY($1)",
+		keyField.Name(), f.labelField.Name(), f.target.Name(), keyField.Name(),
+	)
+	textKeys := make([]string, len(keys))
+	for i, key := range keys {
+		textKeys[i] = fmt.Sprint(key)
+	}
+	rows, err := tx.Query(ctx, sql, textKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to load %s labels", f.target.Name()))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, label string
+		if err := rows.Scan(&key, &label); err != nil {
+			return nil, errors.Wrap(err, "failed to scan reference label")
+		}
+		labels[key] = label
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+	return labels, nil
+}
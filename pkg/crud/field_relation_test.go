@@ -0,0 +1,151 @@
+package crud_test
+
+import (
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type relatedSchema struct {
+	name   string
+	fields crud.Fields
+}
+
+func (s relatedSchema) Name() string        { return s.name }
+func (s relatedSchema) Fields() crud.Fields { return s.fields }
+
+func currenciesSchema() relatedSchema {
+	return relatedSchema{
+		name: "currencies",
+		fields: crud.NewFields([]crud.Field{
+			crud.NewUUIDField("id", crud.WithKey()),
+			crud.NewStringField("code"),
+		}),
+	}
+}
+
+func orderLinesSchema() relatedSchema {
+	return relatedSchema{
+		name: "order_lines",
+		fields: crud.NewFields([]crud.Field{
+			crud.NewIntField("id", crud.WithKey()),
+			crud.NewIntField("order_id"),
+			crud.NewStringField("product"),
+			crud.NewIntField("quantity"),
+		}),
+	}
+}
+
+func TestReferenceField(t *testing.T) {
+	t.Run("takes the type of the target key", func(t *testing.T) {
+		field := crud.NewReferenceField("currency_id", currenciesSchema(), "code")
+
+		assert.Equal(t, crud.UUIDFieldType, field.Type())
+		assert.Equal(t, crud.UUIDFieldType, field.ValueType())
+		assert.Equal(t, crud.SelectTypeSearchable, field.SelectType())
+		assert.Equal(t, "currencies", field.Target().Name())
+		assert.Equal(t, "code", field.LabelField().Name())
+		assert.Equal(t, "currency_id__label", field.LabelAlias())
+
+		id := uuid.New()
+		assert.Equal(t, id, field.Value(id).Value())
+	})
+
+	t.Run("keeps field options", func(t *testing.T) {
+		field := crud.NewReferenceField(
+			"currency_id",
+			currenciesSchema(),
+			"code",
+			crud.WithReadonly(),
+			crud.WithRules(crud.RequiredRule()),
+		)
+
+		assert.True(t, field.Readonly())
+		assert.Len(t, field.Rules(), 1)
+	})
+
+	t.Run("panics on unknown label field", func(t *testing.T) {
+		require.Panics(t, func() {
+			crud.NewReferenceField("currency_id", currenciesSchema(), "symbol")
+		})
+	})
+}
+
+func TestOneToManyField(t *testing.T) {
+	t.Run("excludes the key and the foreign key from editable fields", func(t *testing.T) {
+		field := crud.NewOneToManyField("lines", orderLinesSchema(), "order_id")
+
+		assert.Equal(t, crud.CollectionFieldType, field.Type())
+		assert.Equal(t, "order_id", field.ForeignKey().Name())
+
+		names := make([]string, 0)
+		for _, f := range field.EditableFields() {
+			names = append(names, f.Name())
+		}
+		assert.Equal(t, []string{"product", "quantity"}, names)
+	})
+
+	t.Run("treats nil as an empty collection", func(t *testing.T) {
+		field := crud.NewOneToManyField("lines", orderLinesSchema(), "order_id")
+
+		fv := field.Value(nil)
+		assert.Equal(t, [][]crud.FieldValue{}, fv.Value())
+		assert.Equal(t, field, fv.Field())
+	})
+
+	t.Run("sets the foreign key of every row", func(t *testing.T) {
+		child := orderLinesSchema()
+		field := crud.NewOneToManyField("lines", child, "order_id")
+		product, err := child.Fields().Field("product")
+		require.NoError(t, err)
+		orderID, err := child.Fields().Field("order_id")
+		require.NoError(t, err)
+
+		value := field.Value([][]crud.FieldValue{
+			{product.Value("apple"), orderID.Value(1)},
+			{product.Value("pear")},
+		})
+		rows := field.Rows(value, 42)
+
+		require.Len(t, rows, 2)
+		for _, row := range rows {
+			require.Len(t, row, 2)
+			assert.Equal(t, "order_id", row[1].Field().Name())
+			assert.Equal(t, 42, row[1].Value())
+		}
+	})
+
+	t.Run("panics on unknown foreign key", func(t *testing.T) {
+		require.Panics(t, func() {
+			crud.NewOneToManyField("lines", orderLinesSchema(), "parent_id")
+		})
+	})
+
+	t.Run("rejects values that are not rows", func(t *testing.T) {
+		field := crud.NewOneToManyField("lines", orderLinesSchema(), "order_id")
+
+		require.Panics(t, func() {
+			field.Value("apple")
+		})
+	})
+}
+
+func TestUploadField(t *testing.T) {
+	t.Run("stores the upload ID", func(t *testing.T) {
+		field := crud.NewUploadField("avatar_id")
+
+		assert.Equal(t, crud.IntFieldType, field.Type())
+		assert.Equal(t, "image/*", field.Accept())
+		assert.Equal(t, 7, field.Value(7).Value())
+	})
+
+	t.Run("accepts custom file types", func(t *testing.T) {
+		field := crud.NewUploadField("document_id", crud.WithAccept("application/pdf"))
+
+		assert.Equal(t, "application/pdf", field.Accept())
+	})
+}
@@ -0,0 +1,35 @@
+package crud
+
+// UploadField stores the ID of a file uploaded through the upload service.
+// It is an int field rendered as a file input.
+type UploadField interface {
+	Field
+
+	// Accept lists the accepted file types in the format of the HTML accept attribute
+	Accept() string
+}
+
+func NewUploadField(
+	name string,
+	opts ...FieldOption,
+) UploadField {
+	f := newField(
+		name,
+		IntFieldType,
+		opts...,
+	).(*field)
+	f.attrs["isUploadField"] = true
+
+	return &uploadField{field: f}
+}
+
+type uploadField struct {
+	*field
+}
+
+func (f *uploadField) Accept() string {
+	if val, ok := f.attrs[Accept].(string); ok {
+		return val
+	}
+	return "image/*"
+}
@@ -230,7 +230,7 @@ func (fv *fieldValue) AsTime() (time.Time, error) {
 			return time.Time{}, fv.valueCastError("time.Time")
 		}
 		return t, nil
-	case StringFieldType, IntFieldType, BoolFieldType, FloatFieldType, DecimalFieldType, UUIDFieldType:
+	case StringFieldType, IntFieldType, BoolFieldType, FloatFieldType, DecimalFieldType, UUIDFieldType, CollectionFieldType:
 		return time.Time{}, fv.typeMismatch("time.Time")
 	}
 	return time.Time{}, fv.typeMismatch("time.Time")
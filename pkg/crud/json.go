@@ -0,0 +1,225 @@
+package crud
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownField = errors.New("unknown field")
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// Layouts of the text representation of date and time values
+const (
+	DateLayout = "2006-01-02"
+	TimeLayout = "15:04:05"
+)
+
+// ValuesToJSON converts field values to a JSON object keyed by field name.
+// Hidden fields are left out.
+func ValuesToJSON(values []FieldValue) map[string]any {
+	result := make(map[string]any, len(values))
+	for _, fv := range values {
+		if fv.Field().Hidden() {
+			continue
+		}
+		result[fv.Field().Name()] = ValueToJSON(fv)
+	}
+	return result
+}
+
+// ValueToJSON converts a field value to a value encoding/json renders in the format ValueFromJSON accepts
+func ValueToJSON(fv FieldValue) any {
+	value := fv.Value()
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case [][]FieldValue:
+		rows := make([]map[string]any, len(v))
+		for i, row := range v {
+			rows[i] = ValuesToJSON(row)
+		}
+		return rows
+	case time.Time:
+		switch fv.Field().Type() {
+		case DateFieldType:
+			return v.Format(DateLayout)
+		case TimeFieldType:
+			return v.Format(TimeLayout)
+		default:
+			return v.Format(time.RFC3339)
+		}
+	case uuid.UUID:
+		return v.String()
+	}
+
+	if fv.Field().Type() == DecimalFieldType {
+		return fmt.Sprint(value)
+	}
+	return value
+}
+
+// ValuesFromJSON converts a JSON object to the values of the given fields.
+// Readonly and hidden fields are skipped since clients cannot set them, unknown keys are rejected.
+func ValuesFromJSON(fields Fields, object map[string]json.RawMessage) ([]FieldValue, error) {
+	values := make([]FieldValue, 0, len(object))
+	for name, raw := range object {
+		f, err := fields.Field(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownField, name)
+		}
+		if f.Readonly() || f.Hidden() {
+			continue
+		}
+		fv, err := ValueFromJSON(f, raw)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, fv)
+	}
+	return values, nil
+}
+
+// ValueFromJSON converts a JSON value to a value of the field.
+// Strings are parsed with ValueFromText, so numbers, dates and UUIDs may also be sent as text.
+func ValueFromJSON(f Field, raw json.RawMessage) (FieldValue, error) {
+	if len(raw) == 0 || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return f.Value(nil), nil
+	}
+
+	if cf, ok := f.(CollectionField); ok {
+		return collectionFromJSON(cf, raw)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("%w for field %q: %v", ErrInvalidValue, f.Name(), err)
+	}
+
+	switch v := decoded.(type) {
+	case string:
+		return ValueFromText(f, v)
+	case json.Number:
+		switch f.Type() {
+		case IntFieldType, FloatFieldType, DecimalFieldType:
+			return ValueFromText(f, v.String())
+		}
+	case bool:
+		if f.Type() == BoolFieldType {
+			return f.Value(v), nil
+		}
+	}
+	return nil, fmt.Errorf("%w for field %q: expected %s", ErrInvalidValue, f.Name(), f.Type())
+}
+
+// ValueFromText parses the text representation of a value of the field, e.g. a query parameter
+func ValueFromText(f Field, text string) (FieldValue, error) {
+	value, err := parseText(f.Type(), text)
+	if err != nil {
+		return nil, fmt.Errorf("%w for field %q: %v", ErrInvalidValue, f.Name(), err)
+	}
+	return f.Value(value), nil
+}
+
+func parseText(fieldType FieldType, text string) (any, error) {
+	switch fieldType {
+	case StringFieldType:
+		return text, nil
+	case IntFieldType:
+		v, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		// Matches the values forms produce: int unless it does not fit in 32 bits
+		if v >= math.MinInt32 && v <= math.MaxInt32 {
+			return int(v), nil
+		}
+		return v, nil
+	case BoolFieldType:
+		return strconv.ParseBool(text)
+	case FloatFieldType:
+		return strconv.ParseFloat(text, 64)
+	case DecimalFieldType:
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return nil, err
+		}
+		return text, nil
+	case DateFieldType:
+		return parseTime(text, DateLayout, time.RFC3339)
+	case TimeFieldType:
+		return parseTime(text, TimeLayout, "15:04", time.RFC3339)
+	case DateTimeFieldType, TimestampFieldType:
+		return parseTime(text, time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04")
+	case UUIDFieldType:
+		return uuid.Parse(text)
+	case CollectionFieldType:
+		return nil, errors.New("collections have no text representation")
+	}
+	return nil, fmt.Errorf("unsupported field type %q", fieldType)
+}
+
+func parseTime(text string, layouts ...string) (time.Time, error) {
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if t, err = time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// collectionFromJSON reads the rows of a collection from an array of objects.
+// Rows may carry the child key to keep the identity of existing rows.
+func collectionFromJSON(cf CollectionField, raw json.RawMessage) (FieldValue, error) {
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &objects); err != nil {
+		return nil, fmt.Errorf("%w for field %q: expected an array of objects", ErrInvalidValue, cf.Name())
+	}
+
+	keyField := cf.Child().Fields().KeyField()
+	editable := make(map[string]Field, len(cf.EditableFields())+1)
+	editable[keyField.Name()] = keyField
+	for _, child := range cf.EditableFields() {
+		if !child.Hidden() && !child.Readonly() {
+			editable[child.Name()] = child
+		}
+	}
+
+	rows := make([][]FieldValue, len(objects))
+	for i, object := range objects {
+		row := make([]FieldValue, 0, len(object))
+		for name, value := range object {
+			child, ok := editable[name]
+			if !ok {
+				if _, err := cf.Child().Fields().Field(name); err == nil {
+					continue
+				}
+				return nil, fmt.Errorf("%w: %q in row %d of %q", ErrUnknownField, name, i, cf.Name())
+			}
+			fv, err := ValueFromJSON(child, value)
+			if err != nil {
+				return nil, err
+			}
+			// New rows come without a key, the database assigns it
+			if child.Key() && fv.Value() == nil {
+				continue
+			}
+			row = append(row, fv)
+		}
+		rows[i] = row
+	}
+	return cf.Value(rows), nil
+}
@@ -0,0 +1,113 @@
+package crud_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueFromJSON(t *testing.T) {
+	t.Run("converts JSON scalars to field types", func(t *testing.T) {
+		fv, err := crud.ValueFromJSON(crud.NewIntField("quantity"), json.RawMessage(`42`))
+		require.NoError(t, err)
+		assert.Equal(t, 42, fv.Value())
+
+		fv, err = crud.ValueFromJSON(crud.NewFloatField("amount"), json.RawMessage(`12.5`))
+		require.NoError(t, err)
+		assert.InDelta(t, 12.5, fv.Value(), 0.0001)
+
+		fv, err = crud.ValueFromJSON(crud.NewBoolField("active"), json.RawMessage(`true`))
+		require.NoError(t, err)
+		assert.Equal(t, true, fv.Value())
+
+		fv, err = crud.ValueFromJSON(crud.NewDecimalField("price"), json.RawMessage(`19.99`))
+		require.NoError(t, err)
+		assert.Equal(t, "19.99", fv.Value())
+	})
+
+	t.Run("parses text representations", func(t *testing.T) {
+		id := uuid.New()
+		fv, err := crud.ValueFromJSON(crud.NewUUIDField("id"), json.RawMessage(`"`+id.String()+`"`))
+		require.NoError(t, err)
+		assert.Equal(t, id, fv.Value())
+
+		fv, err = crud.ValueFromJSON(crud.NewDateField("due"), json.RawMessage(`"2024-03-01"`))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), fv.Value())
+	})
+
+	t.Run("accepts null", func(t *testing.T) {
+		fv, err := crud.ValueFromJSON(crud.NewStringField("name"), json.RawMessage(`null`))
+		require.NoError(t, err)
+		assert.Nil(t, fv.Value())
+	})
+
+	t.Run("rejects mismatching types", func(t *testing.T) {
+		_, err := crud.ValueFromJSON(crud.NewStringField("name"), json.RawMessage(`1`))
+		require.ErrorIs(t, err, crud.ErrInvalidValue)
+
+		_, err = crud.ValueFromJSON(crud.NewIntField("quantity"), json.RawMessage(`"many"`))
+		require.ErrorIs(t, err, crud.ErrInvalidValue)
+	})
+
+	t.Run("reads collection rows without keys of new rows", func(t *testing.T) {
+		field := crud.NewOneToManyField("lines", orderLinesSchema(), "order_id")
+
+		fv, err := crud.ValueFromJSON(field, json.RawMessage(`[{"id": 7, "product": "Tea", "quantity": 2}, {"id": null, "product": "Milk"}]`))
+		require.NoError(t, err)
+
+		rows := fv.Value().([][]crud.FieldValue)
+		require.Len(t, rows, 2)
+		assert.Len(t, rows[0], 3)
+		require.Len(t, rows[1], 1)
+		assert.Equal(t, "product", rows[1][0].Field().Name())
+	})
+}
+
+func TestValuesFromJSON(t *testing.T) {
+	fields := crud.NewFields([]crud.Field{
+		crud.NewIntField("id", crud.WithKey(), crud.WithReadonly()),
+		crud.NewStringField("name"),
+		crud.NewIntField("tenant_id", crud.WithHidden()),
+	})
+
+	t.Run("skips readonly and hidden fields", func(t *testing.T) {
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal([]byte(`{"id": 1, "name": "Tea", "tenant_id": 5}`), &body))
+
+		values, err := crud.ValuesFromJSON(fields, body)
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		assert.Equal(t, "Tea", values[0].Value())
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := crud.ValuesFromJSON(fields, map[string]json.RawMessage{"color": json.RawMessage(`"red"`)})
+		require.ErrorIs(t, err, crud.ErrUnknownField)
+	})
+}
+
+func TestValuesToJSON(t *testing.T) {
+	id := uuid.New()
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	values := []crud.FieldValue{
+		crud.NewUUIDField("id", crud.WithKey()).Value(id),
+		crud.NewDateField("due").Value(due),
+		crud.NewTimestampField("created_at").Value(due),
+		crud.NewStringField("secret", crud.WithHidden()).Value("hidden"),
+	}
+
+	result := crud.ValuesToJSON(values)
+
+	assert.Equal(t, map[string]any{
+		"id":         id.String(),
+		"due":        "2024-03-01",
+		"created_at": "2024-03-01T00:00:00Z",
+	}, result)
+}
@@ -0,0 +1,443 @@
+package crud
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const OpenAPIVersion = "3.0.3"
+
+// OpenAPIDocument is the subset of an OpenAPI 3 document needed to describe the REST API of crud schemas
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components"`
+	Security   []map[string][]string      `json:"security,omitempty"`
+}
+
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPIComponents struct {
+	Schemas         map[string]*OpenAPISchema         `json:"schemas"`
+	SecuritySchemes map[string]*OpenAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type OpenAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	In     string `json:"in,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// OpenAPIPathItem maps lowercase HTTP methods to operations
+type OpenAPIPathItem map[string]*OpenAPIOperation
+
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPISchema struct {
+	Ref         string                    `json:"$ref,omitempty"`
+	Type        string                    `json:"type,omitempty"`
+	Format      string                    `json:"format,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Nullable    bool                      `json:"nullable,omitempty"`
+	ReadOnly    bool                      `json:"readOnly,omitempty"`
+	Enum        []any                     `json:"enum,omitempty"`
+	MinLength   *int                      `json:"minLength,omitempty"`
+	MaxLength   *int                      `json:"maxLength,omitempty"`
+	Pattern     string                    `json:"pattern,omitempty"`
+	Minimum     *float64                  `json:"minimum,omitempty"`
+	Maximum     *float64                  `json:"maximum,omitempty"`
+	MultipleOf  *float64                  `json:"multipleOf,omitempty"`
+	Items       *OpenAPISchema            `json:"items,omitempty"`
+	Properties  map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required    []string                  `json:"required,omitempty"`
+}
+
+// APIOperations selects the operations of a resource that are exposed
+type APIOperations struct {
+	Create bool
+	Update bool
+	Delete bool
+}
+
+// Filter operators of list endpoints, a filter is passed as "<field>__<operator>=<value>"
+// and "<field>=<value>" is a shorthand for "eq"
+const (
+	FilterEq    = "eq"
+	FilterNotEq = "ne"
+	FilterGt    = "gt"
+	FilterGte   = "gte"
+	FilterLt    = "lt"
+	FilterLte   = "lte"
+	FilterIn    = "in"
+	FilterLike  = "like"
+)
+
+// NewOpenAPIDocument creates an empty document
+func NewOpenAPIDocument(title, version string) *OpenAPIDocument {
+	return &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info: OpenAPIInfo{
+			Title:   title,
+			Version: version,
+		},
+		Paths: make(map[string]OpenAPIPathItem),
+		Components: OpenAPIComponents{
+			Schemas: map[string]*OpenAPISchema{
+				"Error": errorSchema(),
+			},
+		},
+	}
+}
+
+// AddResource describes the REST endpoints of the schema served under basePath.
+// Entities are published as the "<Name>" component and request bodies as "<Name>Input".
+func (d *OpenAPIDocument) AddResource(basePath string, schema RelatedSchema, ops APIOperations) {
+	name := componentName(schema.Name())
+	keyField := schema.Fields().KeyField()
+
+	d.Components.Schemas[name] = EntitySchema(schema.Fields())
+	if ops.Create || ops.Update {
+		d.Components.Schemas[name+"Input"] = InputSchema(schema.Fields())
+	}
+	d.Components.Schemas[name+"Page"] = &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"items":  {Type: "array", Items: componentRef(name)},
+			"total":  {Type: "integer", Format: "int64"},
+			"limit":  {Type: "integer"},
+			"offset": {Type: "integer"},
+		},
+		Required: []string{"items", "total", "limit", "offset"},
+	}
+
+	tags := []string{schema.Name()}
+	idParam := OpenAPIParameter{
+		Name:     "id",
+		In:       "path",
+		Required: true,
+		Schema:   FieldSchema(keyField),
+	}
+	entityResponse := jsonResponse("The "+schema.Name()+" record", componentRef(name))
+	inputBody := &OpenAPIRequestBody{
+		Required: true,
+		Content:  map[string]OpenAPIMediaType{"application/json": {Schema: componentRef(name + "Input")}},
+	}
+
+	collection := OpenAPIPathItem{
+		strings.ToLower(http.MethodGet): {
+			OperationID: "list" + name,
+			Summary:     "List " + schema.Name(),
+			Tags:        tags,
+			Parameters:  listParameters(schema),
+			Responses: map[string]OpenAPIResponse{
+				"200": jsonResponse("A page of "+schema.Name(), componentRef(name+"Page")),
+				"400": errorResponse("Invalid query parameters"),
+				"401": errorResponse("Not authenticated"),
+			},
+		},
+	}
+	item := OpenAPIPathItem{
+		strings.ToLower(http.MethodGet): {
+			OperationID: "get" + name,
+			Summary:     "Get a " + schema.Name() + " record",
+			Tags:        tags,
+			Parameters:  []OpenAPIParameter{idParam},
+			Responses: map[string]OpenAPIResponse{
+				"200": entityResponse,
+				"401": errorResponse("Not authenticated"),
+				"404": errorResponse("Record not found"),
+			},
+		},
+	}
+
+	if ops.Create {
+		collection[strings.ToLower(http.MethodPost)] = &OpenAPIOperation{
+			OperationID: "create" + name,
+			Summary:     "Create a " + schema.Name() + " record",
+			Tags:        tags,
+			RequestBody: inputBody,
+			Responses: map[string]OpenAPIResponse{
+				"201": entityResponse,
+				"400": errorResponse("Malformed body"),
+				"401": errorResponse("Not authenticated"),
+				"422": errorResponse("Validation failed"),
+			},
+		}
+	}
+	if ops.Update {
+		item[strings.ToLower(http.MethodPatch)] = &OpenAPIOperation{
+			OperationID: "update" + name,
+			Summary:     "Update a " + schema.Name() + " record, omitted fields keep their values",
+			Tags:        tags,
+			Parameters:  []OpenAPIParameter{idParam},
+			RequestBody: inputBody,
+			Responses: map[string]OpenAPIResponse{
+				"200": entityResponse,
+				"400": errorResponse("Malformed body"),
+				"401": errorResponse("Not authenticated"),
+				"404": errorResponse("Record not found"),
+				"422": errorResponse("Validation failed"),
+			},
+		}
+	}
+	if ops.Delete {
+		item[strings.ToLower(http.MethodDelete)] = &OpenAPIOperation{
+			OperationID: "delete" + name,
+			Summary:     "Delete a " + schema.Name() + " record",
+			Tags:        tags,
+			Parameters:  []OpenAPIParameter{idParam},
+			Responses: map[string]OpenAPIResponse{
+				"200": entityResponse,
+				"401": errorResponse("Not authenticated"),
+				"404": errorResponse("Record not found"),
+			},
+		}
+	}
+
+	basePath = strings.TrimSuffix(basePath, "/")
+	d.Paths[basePath] = collection
+	d.Paths[basePath+"/{id}"] = item
+}
+
+// EntitySchema describes the JSON object of a record as ValuesToJSON renders it
+func EntitySchema(fields Fields) *OpenAPISchema {
+	s := &OpenAPISchema{
+		Type:       "object",
+		Properties: make(map[string]*OpenAPISchema),
+	}
+	for _, f := range fields.Fields() {
+		if f.Hidden() {
+			continue
+		}
+		s.Properties[f.Name()] = FieldSchema(f)
+	}
+	return s
+}
+
+// InputSchema describes the JSON object ValuesFromJSON accepts
+func InputSchema(fields Fields) *OpenAPISchema {
+	s := &OpenAPISchema{
+		Type:       "object",
+		Properties: make(map[string]*OpenAPISchema),
+	}
+	for _, f := range fields.Fields() {
+		if f.Hidden() || f.Readonly() {
+			continue
+		}
+		s.Properties[f.Name()] = FieldSchema(f)
+	}
+	return s
+}
+
+// FieldSchema describes the JSON value of a field
+func FieldSchema(f Field) *OpenAPISchema {
+	s := &OpenAPISchema{
+		Nullable: !f.Key(),
+		ReadOnly: f.Readonly(),
+	}
+	attrs := f.Attrs()
+
+	switch f.Type() {
+	case StringFieldType:
+		s.Type = "string"
+		if v, ok := attrs[MinLen].(int); ok && v > 0 {
+			s.MinLength = &v
+		}
+		if v, ok := attrs[MaxLen].(int); ok && v > 0 {
+			s.MaxLength = &v
+		}
+		if v, ok := attrs[Pattern].(string); ok {
+			s.Pattern = v
+		}
+	case IntFieldType:
+		s.Type = "integer"
+		s.Format = "int64"
+		if v, ok := attrs[Min].(int64); ok {
+			s.Minimum = floatPtr(float64(v))
+		}
+		if v, ok := attrs[Max].(int64); ok {
+			s.Maximum = floatPtr(float64(v))
+		}
+		if v, ok := attrs[MultipleOf].(int64); ok && v > 0 {
+			s.MultipleOf = floatPtr(float64(v))
+		}
+	case BoolFieldType:
+		s.Type = "boolean"
+	case FloatFieldType:
+		s.Type = "number"
+		s.Format = "double"
+		if v, ok := attrs[Min].(float64); ok {
+			s.Minimum = floatPtr(v)
+		}
+		if v, ok := attrs[Max].(float64); ok {
+			s.Maximum = floatPtr(v)
+		}
+	case DecimalFieldType:
+		s.Type = "string"
+		s.Format = "decimal"
+	case DateFieldType:
+		s.Type = "string"
+		s.Format = "date"
+	case TimeFieldType:
+		s.Type = "string"
+		s.Format = "time"
+	case DateTimeFieldType, TimestampFieldType:
+		s.Type = "string"
+		s.Format = "date-time"
+	case UUIDFieldType:
+		s.Type = "string"
+		s.Format = "uuid"
+	case CollectionFieldType:
+		s.Type = "array"
+		s.Nullable = false
+		if cf, ok := f.(CollectionField); ok {
+			row := &OpenAPISchema{
+				Type:       "object",
+				Properties: make(map[string]*OpenAPISchema),
+			}
+			keyField := cf.Child().Fields().KeyField()
+			row.Properties[keyField.Name()] = FieldSchema(keyField)
+			for _, child := range cf.EditableFields() {
+				if !child.Hidden() {
+					row.Properties[child.Name()] = FieldSchema(child)
+				}
+			}
+			s.Items = row
+		}
+	}
+
+	switch tf := f.(type) {
+	case ReferenceField:
+		s.Description = "Key of a " + tf.Target().Name() + " record"
+	case SelectField:
+		for _, option := range tf.Options() {
+			s.Enum = append(s.Enum, option.Value)
+		}
+	}
+
+	return s
+}
+
+func listParameters(schema RelatedSchema) []OpenAPIParameter {
+	params := []OpenAPIParameter{
+		{Name: "search", In: "query", Description: "Text searched in the searchable fields", Schema: &OpenAPISchema{Type: "string"}},
+		{Name: "sort", In: "query", Description: "Comma separated fields to sort by, a leading '-' sorts descending", Schema: &OpenAPISchema{Type: "string"}},
+		{Name: "limit", In: "query", Schema: &OpenAPISchema{Type: "integer", Minimum: floatPtr(1)}},
+		{Name: "offset", In: "query", Schema: &OpenAPISchema{Type: "integer", Minimum: floatPtr(0)}},
+	}
+
+	filters := make([]OpenAPIParameter, 0)
+	for _, f := range schema.Fields().Fields() {
+		if f.Hidden() || f.Type() == CollectionFieldType {
+			continue
+		}
+		value := FieldSchema(f)
+		value.Nullable = false
+		value.ReadOnly = false
+		filters = append(filters, OpenAPIParameter{
+			Name:        f.Name(),
+			In:          "query",
+			Description: "Equal to, other operators are passed as " + f.Name() + "__<operator>: " + strings.Join(FilterOperators(f.Type()), ", "),
+			Schema:      value,
+		})
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		return filters[i].Name < filters[j].Name
+	})
+	return append(params, filters...)
+}
+
+// FilterOperators lists the filter operators applicable to fields of the type
+func FilterOperators(fieldType FieldType) []string {
+	switch fieldType {
+	case StringFieldType:
+		return []string{FilterEq, FilterNotEq, FilterIn, FilterLike}
+	case BoolFieldType, UUIDFieldType:
+		return []string{FilterEq, FilterNotEq, FilterIn}
+	case CollectionFieldType:
+		return nil
+	case IntFieldType, FloatFieldType, DecimalFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType:
+		return []string{FilterEq, FilterNotEq, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn}
+	}
+	return nil
+}
+
+func errorSchema() *OpenAPISchema {
+	return &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"code":    {Type: "string"},
+			"message": {Type: "string"},
+			"errors": {
+				Type:        "object",
+				Description: "Validation messages keyed by field name",
+			},
+		},
+		Required: []string{"code", "message"},
+	}
+}
+
+func jsonResponse(description string, schema *OpenAPISchema) OpenAPIResponse {
+	return OpenAPIResponse{
+		Description: description,
+		Content:     map[string]OpenAPIMediaType{"application/json": {Schema: schema}},
+	}
+}
+
+func errorResponse(description string) OpenAPIResponse {
+	return jsonResponse(description, componentRef("Error"))
+}
+
+func componentRef(name string) *OpenAPISchema {
+	return &OpenAPISchema{Ref: "#/components/schemas/" + name}
+}
+
+// componentName turns a table name like "warehouse_positions" into "WarehousePositions"
+func componentName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '.' || r == '-'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
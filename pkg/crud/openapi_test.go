@@ -0,0 +1,71 @@
+package crud_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIDocument_AddResource(t *testing.T) {
+	schema := relatedSchema{
+		name: "order_items",
+		fields: crud.NewFields([]crud.Field{
+			crud.NewIntField("id", crud.WithKey(), crud.WithReadonly()),
+			crud.NewStringField("title", crud.WithMaxLen(64)),
+			crud.NewIntField("quantity", crud.WithMin(1)),
+			crud.NewIntField("tenant_id", crud.WithHidden()),
+			crud.NewOneToManyField("lines", orderLinesSchema(), "order_id"),
+		}),
+	}
+
+	t.Run("describes the paths of enabled operations", func(t *testing.T) {
+		doc := crud.NewOpenAPIDocument("API", "1.0.0")
+		doc.AddResource("/api/v1/order-items", schema, crud.APIOperations{Create: true})
+
+		collection := doc.Paths["/api/v1/order-items"]
+		require.NotNil(t, collection)
+		assert.Equal(t, "listOrderItems", collection["get"].OperationID)
+		assert.Equal(t, "createOrderItems", collection["post"].OperationID)
+
+		item := doc.Paths["/api/v1/order-items/{id}"]
+		require.NotNil(t, item)
+		assert.Contains(t, item, "get")
+		assert.NotContains(t, item, "patch")
+		assert.NotContains(t, item, "delete")
+	})
+
+	t.Run("builds component schemas from the fields", func(t *testing.T) {
+		doc := crud.NewOpenAPIDocument("API", "1.0.0")
+		doc.AddResource("/api/v1/order-items", schema, crud.APIOperations{Create: true, Update: true, Delete: true})
+
+		entity := doc.Components.Schemas["OrderItems"]
+		require.NotNil(t, entity)
+		assert.NotContains(t, entity.Properties, "tenant_id")
+		assert.True(t, entity.Properties["id"].ReadOnly)
+		assert.Equal(t, 64, *entity.Properties["title"].MaxLength)
+		assert.InDelta(t, 1, *entity.Properties["quantity"].Minimum, 0)
+		assert.Equal(t, "array", entity.Properties["lines"].Type)
+		assert.Contains(t, entity.Properties["lines"].Items.Properties, "product")
+
+		input := doc.Components.Schemas["OrderItemsInput"]
+		require.NotNil(t, input)
+		assert.NotContains(t, input.Properties, "id")
+		assert.Contains(t, input.Properties, "title")
+	})
+
+	t.Run("renders valid JSON", func(t *testing.T) {
+		doc := crud.NewOpenAPIDocument("API", "1.0.0")
+		doc.AddResource("/api/v1/order-items", schema, crud.APIOperations{Update: true})
+
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, crud.OpenAPIVersion, decoded["openapi"])
+	})
+}
@@ -35,20 +35,34 @@ type Repository[TEntity any] interface {
 func DefaultRepository[TEntity any](
 	schema Schema[TEntity],
 ) Repository[TEntity] {
-	// Initialize fieldMap correctly for generic repository
-	fieldMap := make(map[string]string)
-	for _, f := range schema.Fields().Fields() {
-		fieldMap[f.Name()] = f.Name() // Map field name to itself for generic columns
-	}
-	return &repository[TEntity]{
+	r := &repository[TEntity]{
 		schema:   schema,
-		fieldMap: fieldMap,
+		fieldMap: make(map[string]string),
+	}
+	for _, f := range schema.Fields().Fields() {
+		switch tf := f.(type) {
+		case CollectionField:
+			r.collections = append(r.collections, tf)
+			continue
+		case ReferenceField:
+			r.references = append(r.references, tf)
+			// Referenced labels can be filtered and sorted by through the join
+			r.fieldMap[tf.LabelAlias()] = fmt.Sprintf("%s.%s", joinAlias(tf), tf.LabelField().Name())
+		}
+		r.columns = append(r.columns, f.Name())
+		// Columns are qualified with the table name as referenced tables are joined
+		r.fieldMap[f.Name()] = fmt.Sprintf("%s.%s", schema.Name(), f.Name())
 	}
+	return r
 }
 
 type repository[TEntity any] struct {
 	schema   Schema[TEntity]
 	fieldMap map[string]string
+	// columns are the names of the fields stored in the table of the schema
+	columns     []string
+	references  []ReferenceField
+	collections []CollectionField
 }
 
 func (r *repository[TEntity]) GetAll(ctx context.Context) ([]TEntity, error) {
@@ -107,7 +121,7 @@ func (r *repository[TEntity]) Count(ctx context.Context, params *FindParams) (in
 		return 0, errors.Wrap(err, "failed to build filters for count")
 	}
 
-	baseQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", r.schema.Name())
+	baseQuery := repo.Join("SELECT COUNT(*)", r.from())
 
 	query := baseQuery
 	if len(whereClauses) > 0 {
@@ -129,7 +143,7 @@ func (r *repository[TEntity]) List(ctx context.Context, params *FindParams) ([]T
 		return nil, errors.Wrap(err, "failed to build filters for list")
 	}
 
-	baseQuery := fmt.Sprintf("SELECT * FROM %s", r.schema.Name())
+	baseQuery := repo.Join(fmt.Sprintf("SELECT %s.*", r.schema.Name()), r.from())
 	query := baseQuery
 	if len(whereClauses) > 0 {
 		query = repo.Join(query, repo.JoinWhere(whereClauses...))
@@ -160,6 +174,9 @@ func (r *repository[TEntity]) Create(ctx context.Context, values []FieldValue) (
 		if field.Key() && fv.IsZero() {
 			continue
 		}
+		if field.Type() == CollectionFieldType {
+			continue
+		}
 		columns = append(columns, field.Name())
 		args = append(args, value)
 	}
@@ -168,15 +185,22 @@ func (r *repository[TEntity]) Create(ctx context.Context, values []FieldValue) (
 		return zero, errors.New("no fields to create for entity")
 	}
 
-	query := repo.Insert(r.schema.Name(), columns, r.schema.Fields().Names()...)
-	entities, err := r.queryEntities(ctx, query, args...)
+	query := repo.Insert(r.schema.Name(), columns, r.columns...)
+	rows, err := r.queryFieldValues(ctx, query, args...)
 	if err != nil {
 		return zero, errors.Wrap(err, "failed to create entity")
 	}
-	if len(entities) != 1 {
-		return zero, errors.Errorf("unexpected insert result count: %d", len(entities))
+	if len(rows) != 1 {
+		return zero, errors.Errorf("unexpected insert result count: %d", len(rows))
 	}
-	return entities[0], nil
+
+	if len(r.collections) > 0 {
+		rows, err = r.saveCollections(ctx, rows[0], values, true)
+		if err != nil {
+			return zero, errors.Wrap(err, "failed to create child rows")
+		}
+	}
+	return r.toEntity(ctx, rows)
 }
 
 func (r *repository[TEntity]) Update(ctx context.Context, values []FieldValue) (TEntity, error) {
@@ -195,6 +219,9 @@ func (r *repository[TEntity]) Update(ctx context.Context, values []FieldValue) (
 			fieldKeyValue = fv
 			continue
 		}
+		if field.Type() == CollectionFieldType {
+			continue
+		}
 		updates = append(updates, field.Name())
 		args = append(args, val)
 	}
@@ -207,36 +234,68 @@ func (r *repository[TEntity]) Update(ctx context.Context, values []FieldValue) (
 	args = append(args, fieldKeyValue.Value())
 
 	query := repo.Update(r.schema.Name(), updates, whereClause) + " RETURNING *"
-	entities, err := r.queryEntities(ctx, query, args...)
+	if len(updates) == 0 {
+		// Only child rows changed
+		query = fmt.Sprintf("SELECT * FROM %s WHERE %s", r.schema.Name(), whereClause)
+	}
+	rows, err := r.queryFieldValues(ctx, query, args...)
 	if err != nil {
 		return zero, errors.Wrap(err, "failed to update entity")
 	}
-	if len(entities) != 1 {
-		return zero, errors.Errorf("unexpected update result count: %d", len(entities))
+	if len(rows) != 1 {
+		return zero, errors.Errorf("unexpected update result count: %d", len(rows))
 	}
-	return entities[0], nil
+
+	if len(r.collections) > 0 {
+		rows, err = r.saveCollections(ctx, rows[0], values, false)
+		if err != nil {
+			return zero, errors.Wrap(err, "failed to update child rows")
+		}
+	}
+	return r.toEntity(ctx, rows)
 }
 
 func (r *repository[TEntity]) Delete(ctx context.Context, value FieldValue) (TEntity, error) {
 	var zero TEntity
+
+	var existing [][]FieldValue
+	if len(r.collections) > 0 {
+		// Child rows are deleted first and loaded beforehand to return the entity as it was
+		var err error
+		existing, err = r.queryFieldValues(ctx, fmt.Sprintf(
+			"SELECT * FROM %s WHERE %s = $1",
+			r.schema.Name(),
+			value.Field().Name(),
+		), value.Value())
+		if err != nil {
+			return zero, errors.Wrap(err, "failed to load entity child rows")
+		}
+		if err := r.deleteCollections(ctx, value); err != nil {
+			return zero, errors.Wrap(err, "failed to delete child rows")
+		}
+	}
+
 	query := fmt.Sprintf(
 		"DELETE FROM %s WHERE %s = $1 RETURNING *",
 		r.schema.Name(),
 		value.Field().Name(),
 	)
 
-	entities, err := r.queryEntities(ctx, query, value.Value())
+	rows, err := r.queryFieldValues(ctx, query, value.Value())
 	if err != nil {
 		return zero, errors.Wrap(err, "failed to delete entity")
 	}
-	if len(entities) == 0 {
+	if len(rows) == 0 {
 		return zero, errors.New("entity not found")
 	}
-	if len(entities) > 1 {
+	if len(rows) > 1 {
 		return zero, errors.New("multiple entities deleted")
 	}
+	if len(existing) == 1 {
+		rows = existing
+	}
 
-	return entities[0], nil
+	return r.toEntity(ctx, rows)
 }
 
 func (r *repository[TEntity]) buildFilters(params *FindParams) ([]string, []any, error) {
@@ -261,7 +320,14 @@ func (r *repository[TEntity]) buildFilters(params *FindParams) ([]string, []any,
 		for _, sf := range r.schema.Fields().Searchable() {
 			searchClauses = append(
 				searchClauses,
-				fmt.Sprintf("LOWER(%s) LIKE $%d", sf.Name(), currentArgIdx),
+				fmt.Sprintf("LOWER(%s) LIKE $%d", r.fieldMap[sf.Name()], currentArgIdx),
+			)
+		}
+		// Records are also found by the labels of the records they reference
+		for _, rf := range r.references {
+			searchClauses = append(
+				searchClauses,
+				fmt.Sprintf("LOWER(%s::text) LIKE $%d", r.fieldMap[rf.LabelAlias()], currentArgIdx),
 			)
 		}
 		if len(searchClauses) > 0 {
@@ -274,6 +340,206 @@ func (r *repository[TEntity]) buildFilters(params *FindParams) ([]string, []any,
 }
 
 func (r *repository[TEntity]) queryEntities(ctx context.Context, query string, args ...any) ([]TEntity, error) {
+	fvs, err := r.queryFieldValues(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := r.schema.Mapper().ToEntities(ctx, fvs...)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert entities")
+	}
+
+	return entities, nil
+}
+
+func (r *repository[TEntity]) toEntity(ctx context.Context, fvs [][]FieldValue) (TEntity, error) {
+	var zero TEntity
+	entities, err := r.schema.Mapper().ToEntities(ctx, fvs...)
+	if err != nil {
+		return zero, errors.Wrap(err, "failed to convert entities")
+	}
+	if len(entities) != 1 {
+		return zero, errors.Errorf("unexpected entity count: %d", len(entities))
+	}
+	return entities[0], nil
+}
+
+// queryFieldValues scans the rows of the schema table and attaches the child rows of one-to-many fields
+func (r *repository[TEntity]) queryFieldValues(ctx context.Context, query string, args ...any) ([][]FieldValue, error) {
+	fvs, err := scanFieldValues(ctx, r.schema.Fields(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.collections) > 0 && len(fvs) > 0 {
+		if err := r.loadCollections(ctx, fvs); err != nil {
+			return nil, err
+		}
+	}
+	return fvs, nil
+}
+
+// from returns the FROM clause joining the tables of reference fields
+func (r *repository[TEntity]) from() string {
+	parts := []string{fmt.Sprintf("FROM %s", r.schema.Name())}
+	for _, rf := range r.references {
+		parts = append(parts, fmt.Sprintf(
+			"LEFT JOIN %s %s ON %s.%s = %s.%s",
+			rf.Target().Name(),
+			joinAlias(rf),
+			joinAlias(rf),
+			rf.Target().Fields().KeyField().Name(),
+			r.schema.Name(),
+			rf.Name(),
+		))
+	}
+	return repo.Join(parts...)
+}
+
+// loadCollections fetches the child rows of all parents with a single query per one-to-many field
+func (r *repository[TEntity]) loadCollections(ctx context.Context, parents [][]FieldValue) error {
+	keyName := r.schema.Fields().KeyField().Name()
+	keys := make([]any, 0, len(parents))
+	for _, row := range parents {
+		if key := findValue(row, keyName); key != nil {
+			keys = append(keys, key.Value())
+		}
+	}
+
+	for _, cf := range r.collections {
+		child := cf.Child()
+		query := fmt.Sprintf(
+			"SELECT * FROM %s WHERE %s = ANY($1) ORDER BY %s",
+			child.Name(),
+			cf.ForeignKey().Name(),
+			child.Fields().KeyField().Name(),
+		)
+		childRows, err := scanFieldValues(ctx, child.Fields(), query, keys)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load %s", child.Name())
+		}
+
+		byParent := make(map[string][][]FieldValue, len(parents))
+		for _, childRow := range childRows {
+			if fk := findValue(childRow, cf.ForeignKey().Name()); fk != nil {
+				parentKey := fmt.Sprint(fk.Value())
+				byParent[parentKey] = append(byParent[parentKey], childRow)
+			}
+		}
+		for i, row := range parents {
+			var rows [][]FieldValue
+			if key := findValue(row, keyName); key != nil {
+				rows = byParent[fmt.Sprint(key.Value())]
+			}
+			parents[i] = append(row, cf.Value(rows))
+		}
+	}
+	return nil
+}
+
+// saveCollections writes the child rows of the one-to-many fields present in values and returns the parent
+// with the stored child rows. Rows without a key are inserted, rows with a key updated, and on update
+// stored rows missing from the value are deleted.
+func (r *repository[TEntity]) saveCollections(ctx context.Context, parent []FieldValue, values []FieldValue, isCreate bool) ([][]FieldValue, error) {
+	keyField := r.schema.Fields().KeyField()
+	key := findValue(parent, keyField.Name())
+	if key == nil {
+		return nil, errors.New("missing primary key of saved entity")
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+
+	for _, cf := range r.collections {
+		value := findValue(values, cf.Name())
+		if value == nil {
+			// The collection was not submitted, keep the stored rows
+			continue
+		}
+		child := cf.Child()
+		childKey := child.Fields().KeyField()
+		rows := cf.Rows(value, key.Value())
+
+		if !isCreate {
+			kept := make([]any, 0, len(rows))
+			for _, row := range rows {
+				if ck := findValue(row, childKey.Name()); ck != nil && !ck.IsZero() {
+					kept = append(kept, ck.Value())
+				}
+			}
+			query := fmt.Sprintf(
+				"DELETE FROM %s WHERE %s = $1 AND NOT (%s = ANY($2))",
+				child.Name(), cf.ForeignKey().Name(), childKey.Name(),
+			)
+			if _, err := tx.Exec(ctx, query, key.Value(), kept); err != nil {
+				return nil, errors.Wrapf(err, "failed to delete removed %s", child.Name())
+			}
+		}
+
+		for _, row := range rows {
+			columns := make([]string, 0, len(row))
+			args := make([]any, 0, len(row)+1)
+			var rowKey FieldValue
+			for _, fv := range row {
+				if fv.Field().Name() == childKey.Name() {
+					rowKey = fv
+					continue
+				}
+				columns = append(columns, fv.Field().Name())
+				args = append(args, fv.Value())
+			}
+
+			var query string
+			if rowKey == nil || rowKey.IsZero() {
+				query = repo.Insert(child.Name(), columns)
+			} else {
+				query = repo.Update(
+					child.Name(),
+					columns,
+					fmt.Sprintf("%s = $%d", childKey.Name(), len(args)+1),
+					fmt.Sprintf("%s = $%d", cf.ForeignKey().Name(), len(args)+2),
+				)
+				args = append(args, rowKey.Value(), key.Value())
+			}
+			if _, err := tx.Exec(ctx, query, args...); err != nil {
+				return nil, errors.Wrapf(err, "failed to save %s row", child.Name())
+			}
+		}
+	}
+
+	return r.queryFieldValues(ctx, fmt.Sprintf(
+		"SELECT * FROM %s WHERE %s = $1",
+		r.schema.Name(),
+		keyField.Name(),
+	), key.Value())
+}
+
+func (r *repository[TEntity]) deleteCollections(ctx context.Context, value FieldValue) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction")
+	}
+	for _, cf := range r.collections {
+		query := fmt.Sprintf(
+			"DELETE FROM %s WHERE %s IN (SELECT %s FROM %s WHERE %s = $1)",
+			cf.Child().Name(),
+			cf.ForeignKey().Name(),
+			r.schema.Fields().KeyField().Name(),
+			r.schema.Name(),
+			value.Field().Name(),
+		)
+		if _, err := tx.Exec(ctx, query, value.Value()); err != nil {
+			return errors.Wrapf(err, "failed to delete %s", cf.Child().Name())
+		}
+	}
+	return nil
+}
+
+// scanFieldValues runs the query and converts every row into field values of the given fields
+func scanFieldValues(ctx context.Context, fields Fields, query string, args ...any) ([][]FieldValue, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get transaction")
@@ -288,7 +554,7 @@ func (r *repository[TEntity]) queryEntities(ctx context.Context, query string, a
 	columnDescriptions := rows.FieldDescriptions()
 	columnOrder := make([]Field, len(columnDescriptions))
 	for i, col := range columnDescriptions {
-		f, err := r.schema.Fields().Field(col.Name)
+		f, err := fields.Field(col.Name)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get field %q", col.Name)
 		}
@@ -317,11 +583,18 @@ func (r *repository[TEntity]) queryEntities(ctx context.Context, query string, a
 		return nil, errors.Wrap(err, "row iteration error")
 	}
 
-	entities, err := r.schema.Mapper().ToEntities(ctx, fvs...)
+	return fvs, nil
+}
 
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert entities")
+func findValue(values []FieldValue, name string) FieldValue {
+	for _, fv := range values {
+		if fv.Field().Name() == name {
+			return fv
+		}
 	}
+	return nil
+}
 
-	return entities, nil
+func joinAlias(rf ReferenceField) string {
+	return rf.Name() + "_ref"
 }
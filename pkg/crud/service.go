@@ -218,7 +218,7 @@ func (s *service[TEntity]) validation(ctx context.Context, entity TEntity) error
 			keyFieldVal = fv
 		}
 
-		if fv.Field().Readonly() {
+		if fv.Field().Readonly() && fv.Field().Type() != CollectionFieldType {
 			readonlyFieldValues = append(readonlyFieldValues, fv)
 		}
 		for _, rule := range fv.Field().Rules() {
@@ -226,6 +226,9 @@ func (s *service[TEntity]) validation(ctx context.Context, entity TEntity) error
 				errs = append(errs, errors.Wrap(ruleErr, fmt.Sprintf("validation rule failed for field %q", fv.Field().Name())))
 			}
 		}
+		if cf, ok := fv.Field().(CollectionField); ok {
+			errs = append(errs, validateRows(cf, fv)...)
+		}
 	}
 	if keyFieldVal == nil {
 		errs = append(errs, errors.New("missing primary key for validation"))
@@ -283,3 +286,22 @@ func (s *service[TEntity]) validation(ctx context.Context, entity TEntity) error
 
 	return errors.Join(errs...)
 }
+
+// validateRows applies the rules of the child fields to every row of a one-to-many field
+func validateRows(cf CollectionField, value FieldValue) []error {
+	rows, ok := value.Value().([][]FieldValue)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	for i, row := range rows {
+		for _, fv := range row {
+			for _, rule := range fv.Field().Rules() {
+				if ruleErr := rule(fv); ruleErr != nil {
+					errs = append(errs, errors.Wrap(ruleErr, fmt.Sprintf("validation rule failed for field %q of %s row %d", fv.Field().Name(), cf.Name(), i+1)))
+				}
+			}
+		}
+	}
+	return errs
+}
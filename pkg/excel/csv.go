@@ -0,0 +1,126 @@
+package excel
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// CSVExporter exports data to CSV format
+type CSVExporter struct {
+	options    *ExportOptions
+	csvOptions *CSVOptions
+}
+
+// NewCSVExporter creates a new CSV exporter
+func NewCSVExporter(opts *ExportOptions, csvOpts *CSVOptions) *CSVExporter {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if csvOpts == nil {
+		csvOpts = DefaultCSVOptions()
+	}
+	return &CSVExporter{
+		options:    opts,
+		csvOptions: csvOpts,
+	}
+}
+
+// Format returns the file format produced by the exporter
+func (e *CSVExporter) Format() Format {
+	return FormatCSV
+}
+
+// Export exports data from the datasource to CSV format
+func (e *CSVExporter) Export(ctx context.Context, datasource DataSource) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := e.ExportTo(ctx, &buf, datasource); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportTo streams data from the datasource to w in CSV format
+func (e *CSVExporter) ExportTo(ctx context.Context, w io.Writer, datasource DataSource) error {
+	headers := datasource.GetHeaders()
+	if len(headers) == 0 {
+		return fmt.Errorf("no columns found in data source")
+	}
+
+	bw := bufio.NewWriter(w)
+	out, err := e.encode(bw)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(out)
+	if e.csvOptions.Delimiter != 0 {
+		cw.Comma = e.csvOptions.Delimiter
+	}
+	cw.UseCRLF = e.csvOptions.UseCRLF
+
+	if e.options.IncludeHeaders {
+		if err := cw.Write(headers); err != nil {
+			return fmt.Errorf("failed to write headers: %w", err)
+		}
+	}
+
+	record := make([]string, len(headers))
+	err = eachRow(ctx, datasource, e.options, func(row []interface{}) error {
+		for i := range record {
+			record[i] = ""
+			if i < len(row) {
+				record[i] = stringValue(row[i], e.options)
+			}
+		}
+		return cw.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to encode csv: %w", err)
+	}
+	return bw.Flush()
+}
+
+// nopWriteCloser is the encoding writer of encodings that need no conversion
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// encode wraps w with the configured character encoding. The returned writer has to be
+// closed before w is flushed, it holds back the bytes of a character that isn't complete yet
+func (e *CSVExporter) encode(w io.Writer) (io.WriteCloser, error) {
+	switch e.csvOptions.Encoding {
+	case "", CSVEncodingUTF8:
+		return nopWriteCloser{w}, nil
+	case CSVEncodingUTF8BOM:
+		if _, err := w.Write(utf8BOM); err != nil {
+			return nil, err
+		}
+		return nopWriteCloser{w}, nil
+	case CSVEncodingWindows1251:
+		return transform.NewWriter(w, encoding.ReplaceUnsupported(charmap.Windows1251.NewEncoder())), nil
+	default:
+		return nil, fmt.Errorf("unsupported csv encoding %q", e.csvOptions.Encoding)
+	}
+}
@@ -0,0 +1,77 @@
+package excel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/iota-uz/iota-sdk/pkg/excel"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func TestCSVExporter_Export(t *testing.T) {
+	ds := NewMockDataSource(
+		[]string{"ID", "Name", "Amount", "Note"},
+		[][]interface{}{
+			{1, "John", money.New(150050, money.USD), "a;b"},
+			{2, "Jane", 12.5, nil},
+		},
+	)
+
+	exporter := excel.NewCSVExporter(nil, &excel.CSVOptions{
+		Delimiter: ';',
+		Encoding:  excel.CSVEncodingUTF8,
+	})
+	data, err := exporter.Export(context.Background(), ds)
+	require.NoError(t, err)
+
+	expected := "ID;Name;Amount;Note\n1;John;1500.50;\"a;b\"\n2;Jane;12.5;\n"
+	assert.Equal(t, expected, string(data))
+	assert.Equal(t, excel.FormatCSV, exporter.Format())
+}
+
+func TestCSVExporter_Encodings(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding excel.CSVEncoding
+		expected func() []byte
+	}{
+		{
+			name:     "utf-8 with BOM",
+			encoding: excel.CSVEncodingUTF8BOM,
+			expected: func() []byte {
+				return append([]byte{0xEF, 0xBB, 0xBF}, []byte("Имя\nИван\n")...)
+			},
+		},
+		{
+			name:     "windows-1251",
+			encoding: excel.CSVEncodingWindows1251,
+			expected: func() []byte {
+				b, _ := charmap.Windows1251.NewEncoder().Bytes([]byte("Имя\nИван\n"))
+				return b
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := NewMockDataSource([]string{"Имя"}, [][]interface{}{{"Иван"}})
+			exporter := excel.NewCSVExporter(nil, &excel.CSVOptions{Encoding: tt.encoding})
+
+			data, err := exporter.Export(context.Background(), ds)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected(), data)
+		})
+	}
+}
+
+func TestCSVExporter_UnsupportedEncoding(t *testing.T) {
+	ds := NewMockDataSource([]string{"ID"}, [][]interface{}{{1}})
+	exporter := excel.NewCSVExporter(nil, &excel.CSVOptions{Encoding: "koi8"})
+
+	_, err := exporter.Export(context.Background(), ds)
+	require.Error(t, err)
+}
@@ -356,3 +356,38 @@ func (s *SliceDataSource) GetRows(ctx context.Context) (func() ([]interface{}, e
 func (s *SliceDataSource) GetSheetName() string {
 	return s.sheetName
 }
+
+// ProgressDataSource wraps a DataSource and reports how many rows have been read
+type ProgressDataSource struct {
+	DataSource
+	total      int
+	onProgress func(processed, total int)
+}
+
+// NewProgressDataSource creates a data source that calls onProgress after every row read from ds.
+// total is the expected number of rows and is passed through to onProgress as is.
+func NewProgressDataSource(ds DataSource, total int, onProgress func(processed, total int)) *ProgressDataSource {
+	return &ProgressDataSource{
+		DataSource: ds,
+		total:      total,
+		onProgress: onProgress,
+	}
+}
+
+// GetRows returns an iterator that reports progress for every fetched row
+func (p *ProgressDataSource) GetRows(ctx context.Context) (func() ([]interface{}, error), error) {
+	getRow, err := p.DataSource.GetRows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	processed := 0
+	return func() ([]interface{}, error) {
+		row, err := getRow()
+		if err != nil || row == nil {
+			return row, err
+		}
+		processed++
+		p.onProgress(processed, p.total)
+		return row, nil
+	}, nil
+}
@@ -146,3 +146,30 @@ func TestPostgresDataSource_ConvertSQLValues(t *testing.T) {
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestProgressDataSource_GetRows(t *testing.T) {
+	ds := excel.NewSliceDataSource(
+		[]string{"ID", "Name"},
+		[][]interface{}{{1, "a"}, {2, "b"}, {3, "c"}},
+	).WithSheetName("Items")
+
+	var reported [][2]int
+	progress := excel.NewProgressDataSource(ds, 3, func(processed, total int) {
+		reported = append(reported, [2]int{processed, total})
+	})
+
+	assert.Equal(t, []string{"ID", "Name"}, progress.GetHeaders())
+	assert.Equal(t, "Items", progress.GetSheetName())
+
+	getRow, err := progress.GetRows(context.Background())
+	require.NoError(t, err)
+	for {
+		row, err := getRow()
+		require.NoError(t, err)
+		if row == nil {
+			break
+		}
+	}
+
+	assert.Equal(t, [][2]int{{1, 3}, {2, 3}, {3, 3}}, reported)
+}
@@ -3,6 +3,7 @@ package excel
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -136,6 +137,11 @@ func (e *ExcelExporter) Export(ctx context.Context, datasource DataSource) ([]by
 	return buffer.Bytes(), nil
 }
 
+// ExportTo streams data from the datasource to w in Excel format
+func (e *ExcelExporter) ExportTo(ctx context.Context, w io.Writer, datasource DataSource) error {
+	return NewWorkbookExporter(e.options, e.styleOptions).ExportTo(ctx, w, Sheet{DataSource: datasource})
+}
+
 // writeHeaders writes header row to the Excel file
 func (e *ExcelExporter) writeHeaders(f *excelize.File, sheet string, headers []string) error {
 	for i, header := range headers {
@@ -247,7 +253,15 @@ func (e *ExcelExporter) applyStyles(f *excelize.File, sheet string, colCount, ro
 
 // createStyle creates an excelize style from CellStyle
 func (e *ExcelExporter) createStyle(f *excelize.File, cellStyle *CellStyle) (int, error) {
+	return f.NewStyle(toExcelizeStyle(cellStyle))
+}
+
+// toExcelizeStyle converts CellStyle to an excelize style
+func toExcelizeStyle(cellStyle *CellStyle) *excelize.Style {
 	style := &excelize.Style{}
+	if cellStyle == nil {
+		return style
+	}
 
 	if cellStyle.Font != nil {
 		style.Font = &excelize.Font{
@@ -274,5 +288,5 @@ func (e *ExcelExporter) createStyle(f *excelize.File, cellStyle *CellStyle) (int
 		}
 	}
 
-	return f.NewStyle(style)
+	return style
 }
@@ -0,0 +1,136 @@
+package excel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+// Format identifies an export file format
+type Format string
+
+const (
+	FormatXLSX Format = "xlsx"
+	FormatCSV  Format = "csv"
+	FormatODS  Format = "ods"
+	FormatPDF  Format = "pdf"
+)
+
+var formatNames = map[Format]string{
+	FormatXLSX: "Excel",
+	FormatCSV:  "CSV",
+	FormatODS:  "ODS",
+	FormatPDF:  "PDF",
+}
+
+var formatContentTypes = map[Format]string{
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatCSV:  "text/csv",
+	FormatODS:  "application/vnd.oasis.opendocument.spreadsheet",
+	FormatPDF:  "application/pdf",
+}
+
+// Name returns a human readable name of the format
+func (f Format) Name() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+	return string(f)
+}
+
+// Extension returns the file extension including the leading dot
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	if ct, ok := formatContentTypes[f]; ok {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// IsValid reports whether the format is supported
+func (f Format) IsValid() bool {
+	_, ok := formatContentTypes[f]
+	return ok
+}
+
+// FormatExporter exports a data source to a specific file format
+type FormatExporter interface {
+	Exporter
+	ExportTo(ctx context.Context, w io.Writer, datasource DataSource) error
+	Format() Format
+}
+
+// Format returns the file format produced by the exporter
+func (e *ExcelExporter) Format() Format {
+	return FormatXLSX
+}
+
+// eachRow iterates over data source rows honouring context cancellation and MaxRows
+func eachRow(ctx context.Context, datasource DataSource, opts *ExportOptions, fn func(row []interface{}) error) error {
+	getRow, err := datasource.GetRows(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	rowCount := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		row, err := getRow()
+		if err != nil {
+			return fmt.Errorf("failed to get row: %w", err)
+		}
+		if row == nil {
+			return nil
+		}
+
+		if opts.MaxRows > 0 && rowCount >= opts.MaxRows {
+			return nil
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+		rowCount++
+	}
+}
+
+// stringValue converts a cell value to its plain text representation
+func stringValue(val interface{}, opts *ExportOptions) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case *money.Money:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(v.AsMajorUnits(), 'f', v.Currency().Fraction, 64)
+	case money.Money:
+		return strconv.FormatFloat(v.AsMajorUnits(), 'f', v.Currency().Fraction, 64)
+	case time.Time, *time.Time:
+		if formatted, ok := formatValue(v, opts).(string); ok {
+			return formatted
+		}
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,23 @@
+package excel
+
+import (
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+// CurrencyFormat returns an Excel number format for the given currency,
+// honouring its fraction digits and symbol placement
+func CurrencyFormat(c *money.Currency) string {
+	number := "#,##0"
+	if c.Fraction > 0 {
+		number += "." + strings.Repeat("0", c.Fraction)
+	}
+	template := c.Template
+	if template == "" {
+		template = "1 $"
+	}
+	grapheme := strings.ReplaceAll(c.Grapheme, `"`, `""`)
+	format := strings.Replace(template, "1", number, 1)
+	return strings.Replace(format, "$", `"`+grapheme+`"`, 1)
+}
@@ -0,0 +1,195 @@
+package excel
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+const (
+	odsMimetype = "application/vnd.oasis.opendocument.spreadsheet"
+
+	odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimetype + `"/>
+<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>`
+
+	odsContentHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content` +
+		` xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"` +
+		` xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"` +
+		` xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"` +
+		` xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"` +
+		` xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"` +
+		` office:version="1.2">` +
+		`<office:automatic-styles>` +
+		`<style:style style:name="header" style:family="table-cell">` +
+		`<style:text-properties fo:font-weight="bold"/>` +
+		`</style:style>` +
+		`</office:automatic-styles>` +
+		`<office:body><office:spreadsheet>`
+
+	odsContentFooter = `</office:spreadsheet></office:body></office:document-content>`
+)
+
+// ODSExporter exports data to OpenDocument spreadsheet format
+type ODSExporter struct {
+	options *ExportOptions
+}
+
+// NewODSExporter creates a new OpenDocument spreadsheet exporter
+func NewODSExporter(opts *ExportOptions) *ODSExporter {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	return &ODSExporter{
+		options: opts,
+	}
+}
+
+// Format returns the file format produced by the exporter
+func (e *ODSExporter) Format() Format {
+	return FormatODS
+}
+
+// Export exports data from the datasource to OpenDocument format
+func (e *ODSExporter) Export(ctx context.Context, datasource DataSource) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := e.ExportTo(ctx, &buf, datasource); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportTo streams data from the datasource to w in OpenDocument format
+func (e *ODSExporter) ExportTo(ctx context.Context, w io.Writer, datasource DataSource) error {
+	headers := datasource.GetHeaders()
+	if len(headers) == 0 {
+		return fmt.Errorf("no columns found in data source")
+	}
+
+	zw := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+	if _, err := io.WriteString(mw, odsMimetype); err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+
+	manifest, err := zw.Create("META-INF/manifest.xml")
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if _, err := io.WriteString(manifest, odsManifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	cw, err := zw.Create("content.xml")
+	if err != nil {
+		return fmt.Errorf("failed to write content: %w", err)
+	}
+	content := bufio.NewWriter(cw)
+
+	_, _ = content.WriteString(odsContentHeader)
+	_, _ = content.WriteString(`<table:table table:name="`)
+	_ = xml.EscapeText(content, []byte(datasource.GetSheetName()))
+	_, _ = content.WriteString(`">`)
+	_, _ = fmt.Fprintf(content, `<table:table-column table:number-columns-repeated="%d"/>`, len(headers))
+
+	if e.options.IncludeHeaders {
+		_, _ = content.WriteString(`<table:table-row>`)
+		for _, header := range headers {
+			writeODSTextCell(content, header, `table:style-name="header" `)
+		}
+		_, _ = content.WriteString(`</table:table-row>`)
+	}
+
+	err = eachRow(ctx, datasource, e.options, func(row []interface{}) error {
+		_, _ = content.WriteString(`<table:table-row>`)
+		for _, value := range row {
+			e.writeCell(content, value)
+		}
+		_, _ = content.WriteString(`</table:table-row>`)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _ = content.WriteString(`</table:table>`)
+	_, _ = content.WriteString(odsContentFooter)
+	if err := content.Flush(); err != nil {
+		return fmt.Errorf("failed to write content: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize document: %w", err)
+	}
+	return nil
+}
+
+// writeCell writes a typed table cell
+func (e *ODSExporter) writeCell(w *bufio.Writer, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		_, _ = w.WriteString(`<table:table-cell/>`)
+	case *money.Money:
+		if v == nil {
+			_, _ = w.WriteString(`<table:table-cell/>`)
+			return
+		}
+		writeODSCurrencyCell(w, v)
+	case money.Money:
+		writeODSCurrencyCell(w, &v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		text := stringValue(v, e.options)
+		_, _ = fmt.Fprintf(w, `<table:table-cell office:value-type="float" office:value="%s"><text:p>%s</text:p></table:table-cell>`, text, text)
+	case bool:
+		_, _ = fmt.Fprintf(w, `<table:table-cell office:value-type="boolean" office:boolean-value="%t"><text:p>%t</text:p></table:table-cell>`, v, v)
+	case time.Time:
+		writeODSDateCell(w, v, e.options)
+	case *time.Time:
+		if v == nil {
+			_, _ = w.WriteString(`<table:table-cell/>`)
+			return
+		}
+		writeODSDateCell(w, *v, e.options)
+	default:
+		writeODSTextCell(w, stringValue(v, e.options), "")
+	}
+}
+
+func writeODSTextCell(w *bufio.Writer, text string, attrs string) {
+	_, _ = w.WriteString(`<table:table-cell ` + attrs + `office:value-type="string"><text:p>`)
+	_ = xml.EscapeText(w, []byte(text))
+	_, _ = w.WriteString(`</text:p></table:table-cell>`)
+}
+
+func writeODSCurrencyCell(w *bufio.Writer, m *money.Money) {
+	currency := m.Currency()
+	_, _ = fmt.Fprintf(w,
+		`<table:table-cell office:value-type="currency" office:currency="%s" office:value="%s"><text:p>`,
+		currency.Code,
+		strconv.FormatFloat(m.AsMajorUnits(), 'f', currency.Fraction, 64),
+	)
+	_ = xml.EscapeText(w, []byte(m.Display()))
+	_, _ = w.WriteString(`</text:p></table:table-cell>`)
+}
+
+func writeODSDateCell(w *bufio.Writer, t time.Time, opts *ExportOptions) {
+	_, _ = fmt.Fprintf(w, `<table:table-cell office:value-type="date" office:date-value="%s"><text:p>`, t.Format("2006-01-02T15:04:05"))
+	_ = xml.EscapeText(w, []byte(t.Format(opts.DateTimeFormat)))
+	_, _ = w.WriteString(`</text:p></table:table-cell>`)
+}
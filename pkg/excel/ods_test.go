@@ -0,0 +1,56 @@
+package excel_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/excel"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func TestODSExporter_Export(t *testing.T) {
+	ds := NewMockDataSource(
+		[]string{"ID", "Name", "Amount"},
+		[][]interface{}{
+			{1, "Tom & Jerry", money.New(1000, money.USD)},
+			{2, "<Jane>", nil},
+		},
+	)
+
+	data, err := excel.NewODSExporter(nil).Export(context.Background(), ds)
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	require.NotEmpty(t, zr.File)
+	assert.Equal(t, "mimetype", zr.File[0].Name)
+	assert.Equal(t, zip.Store, zr.File[0].Method)
+
+	files := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		_ = rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	assert.Equal(t, "application/vnd.oasis.opendocument.spreadsheet", files["mimetype"])
+	require.Contains(t, files, "META-INF/manifest.xml")
+
+	content := files["content.xml"]
+	require.NoError(t, xml.Unmarshal([]byte(content), new(struct{})))
+	assert.Contains(t, content, `table:name="TestSheet"`)
+	assert.Contains(t, content, `<text:p>Tom &amp; Jerry</text:p>`)
+	assert.Contains(t, content, `<text:p>&lt;Jane&gt;</text:p>`)
+	assert.Contains(t, content, `office:value-type="float" office:value="1"`)
+	assert.Contains(t, content, `office:value-type="currency" office:currency="USD" office:value="10.00"`)
+}
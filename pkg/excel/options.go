@@ -1,6 +1,10 @@
 package excel
 
-import "time"
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
 
 // ExportOptions configures the Excel export behavior
 type ExportOptions struct {
@@ -109,9 +113,82 @@ func DefaultStyleOptions() *StyleOptions {
 
 // ColumnOptions defines column-specific options
 type ColumnOptions struct {
-	Width    float64
-	Format   string
+	// Width overrides the default column width (0 = default)
+	Width float64
+	// Format is a custom Excel number format, e.g. "#,##0.00"
+	Format string
+	// DataType is an optional hint for consumers of the column definition
 	DataType string
+	// Currency applies the currency number format to numeric cells in the column
+	Currency *money.Currency
+}
+
+// HeaderBlock defines a merged header cell spanning several columns above the column headers
+type HeaderBlock struct {
+	Title string
+	// FromColumn and ToColumn are zero-based inclusive column indexes
+	FromColumn int
+	ToColumn   int
+}
+
+// TotalFunction is an aggregate function used in a totals row
+type TotalFunction string
+
+const (
+	TotalSum     TotalFunction = "SUM"
+	TotalAverage TotalFunction = "AVERAGE"
+	TotalCount   TotalFunction = "COUNT"
+	TotalMin     TotalFunction = "MIN"
+	TotalMax     TotalFunction = "MAX"
+)
+
+// TotalsOptions configures a formula totals row written after the data rows
+type TotalsOptions struct {
+	// Label is written to the first column unless it holds a total
+	Label string
+	// Columns maps zero-based column indexes to aggregate functions
+	Columns map[int]TotalFunction
+}
+
+// ChartType defines the kind of chart added to a sheet
+type ChartType string
+
+const (
+	ChartColumn ChartType = "col"
+	ChartBar    ChartType = "bar"
+	ChartLine   ChartType = "line"
+	ChartPie    ChartType = "pie"
+)
+
+// ChartOptions defines a chart built from the data rows of a sheet
+type ChartOptions struct {
+	Type  ChartType
+	Title string
+	// Cell is the top-left anchor of the chart, e.g. "H2"
+	Cell string
+	// CategoryColumn is the zero-based column used for category labels
+	CategoryColumn int
+	// ValueColumns are zero-based columns plotted as series
+	ValueColumns []int
+	Width        uint
+	Height       uint
+}
+
+// SheetOptions configures layout of a single sheet within a workbook
+type SheetOptions struct {
+	// Columns holds per-column options indexed by zero-based column position
+	Columns      []ColumnOptions
+	HeaderBlocks []HeaderBlock
+	Totals       *TotalsOptions
+	Charts       []ChartOptions
+}
+
+// column returns options for the given zero-based column index
+func (o *SheetOptions) column(index int) ColumnOptions {
+	if o == nil || index >= len(o.Columns) {
+		return ColumnOptions{}
+	}
+	return o.Columns[index]
 }
 
 // formatValue formats a value based on its type
@@ -128,3 +205,58 @@ func formatValue(val interface{}, opts *ExportOptions) interface{} {
 		return val
 	}
 }
+
+// CSVEncoding defines the character encoding of CSV output
+type CSVEncoding string
+
+const (
+	CSVEncodingUTF8        CSVEncoding = "utf-8"
+	CSVEncodingUTF8BOM     CSVEncoding = "utf-8-bom"
+	CSVEncodingWindows1251 CSVEncoding = "windows-1251"
+)
+
+// CSVOptions configures CSV output
+type CSVOptions struct {
+	// Delimiter separates fields, defaults to comma
+	Delimiter rune
+	// Encoding of the output, defaults to UTF-8 with BOM so that Excel detects it
+	Encoding CSVEncoding
+	// UseCRLF terminates lines with \r\n instead of \n
+	UseCRLF bool
+}
+
+// DefaultCSVOptions returns default CSV options
+func DefaultCSVOptions() *CSVOptions {
+	return &CSVOptions{
+		Delimiter: ',',
+		Encoding:  CSVEncodingUTF8BOM,
+		UseCRLF:   true,
+	}
+}
+
+// PDFOptions configures paginated PDF table output
+type PDFOptions struct {
+	// Title is printed on every page, defaults to the sheet name
+	Title string
+	// Orientation is "P" (portrait) or "L" (landscape)
+	Orientation string
+	// PageSize is a standard page size such as "A4" or "Letter"
+	PageSize string
+	// Logo is a PNG or JPEG image printed in the page header
+	Logo []byte
+	// Font is a TrueType font used for all text, defaults to the embedded
+	// DejaVu Sans which covers Latin and Cyrillic
+	Font []byte
+	// BoldFont is used for the title and headers, defaults to Font
+	BoldFont []byte
+	FontSize float64
+}
+
+// DefaultPDFOptions returns default PDF options
+func DefaultPDFOptions() *PDFOptions {
+	return &PDFOptions{
+		Orientation: "L",
+		PageSize:    "A4",
+		FontSize:    9,
+	}
+}
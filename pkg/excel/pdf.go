@@ -0,0 +1,231 @@
+package excel
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+const (
+	pdfFontFamily = "export"
+	pdfLogoName   = "logo"
+	pdfLogoHeight = 12.0
+	pdfMargin     = 10.0
+)
+
+// DejaVu Sans covers Latin, Cyrillic and the Uzbek Latin letters (oʻ, gʻ),
+// so exports render without a font configured by the caller
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	defaultPDFFont []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	defaultPDFBoldFont []byte
+)
+
+// PDFExporter exports data to a paginated PDF table
+type PDFExporter struct {
+	options    *ExportOptions
+	pdfOptions *PDFOptions
+}
+
+// NewPDFExporter creates a new PDF exporter
+func NewPDFExporter(opts *ExportOptions, pdfOpts *PDFOptions) *PDFExporter {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if pdfOpts == nil {
+		pdfOpts = DefaultPDFOptions()
+	}
+	return &PDFExporter{
+		options:    opts,
+		pdfOptions: pdfOpts,
+	}
+}
+
+// Format returns the file format produced by the exporter
+func (e *PDFExporter) Format() Format {
+	return FormatPDF
+}
+
+// Export exports data from the datasource to PDF format
+func (e *PDFExporter) Export(ctx context.Context, datasource DataSource) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := e.ExportTo(ctx, &buf, datasource); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportTo renders data from the datasource as a PDF table and writes it to w.
+// The title, logo and column headers are repeated on every page.
+func (e *PDFExporter) ExportTo(ctx context.Context, w io.Writer, datasource DataSource) error {
+	headers := datasource.GetHeaders()
+	if len(headers) == 0 {
+		return fmt.Errorf("no columns found in data source")
+	}
+
+	opts := e.pdfOptions
+	orientation := opts.Orientation
+	if orientation == "" {
+		orientation = "L"
+	}
+	pageSize := opts.PageSize
+	if pageSize == "" {
+		pageSize = "A4"
+	}
+	fontSize := opts.FontSize
+	if fontSize <= 0 {
+		fontSize = 9
+	}
+	title := opts.Title
+	if title == "" {
+		title = datasource.GetSheetName()
+	}
+
+	pdf := fpdf.New(orientation, "mm", pageSize, "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+5)
+	pdf.AliasNbPages("")
+
+	font, boldFont := defaultPDFFont, defaultPDFBoldFont
+	if len(opts.Font) > 0 {
+		font, boldFont = opts.Font, opts.Font
+		if len(opts.BoldFont) > 0 {
+			boldFont = opts.BoldFont
+		}
+	}
+	family := pdfFontFamily
+	pdf.AddUTF8FontFromBytes(family, "", font)
+	pdf.AddUTF8FontFromBytes(family, "B", boldFont)
+
+	hasLogo := false
+	if len(opts.Logo) > 0 {
+		imageType, err := pdfImageType(opts.Logo)
+		if err != nil {
+			return err
+		}
+		pdf.RegisterImageOptionsReader(pdfLogoName, fpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(opts.Logo))
+		hasLogo = true
+	}
+	if pdf.Err() {
+		return fmt.Errorf("failed to prepare pdf: %w", pdf.Error())
+	}
+
+	pageWidth, _ := pdf.GetPageSize()
+	colWidth := (pageWidth - 2*pdfMargin) / float64(len(headers))
+	rowHeight := fontSize * 0.6
+
+	pdf.SetHeaderFunc(func() {
+		titleX := pdfMargin
+		if hasLogo {
+			pdf.ImageOptions(pdfLogoName, pdfMargin, pdfMargin, 0, pdfLogoHeight, false, fpdf.ImageOptions{}, 0, "")
+			titleX += pdfLogoHeight*3 + 4
+		}
+		pdf.SetXY(titleX, pdfMargin)
+		pdf.SetFont(family, "B", fontSize+4)
+		pdf.CellFormat(0, pdfLogoHeight, title, "", 1, "LM", false, 0, "")
+		pdf.Ln(2)
+
+		if e.options.IncludeHeaders {
+			pdf.SetFont(family, "B", fontSize)
+			pdf.SetFillColor(224, 224, 224)
+			for _, header := range headers {
+				pdf.CellFormat(colWidth, rowHeight+1, fitText(pdf, header, colWidth), "1", 0, "C", true, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+		pdf.SetFont(family, "", fontSize)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin - 2)
+		pdf.SetFont(family, "", fontSize-1)
+		pdf.CellFormat(0, 4, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	rowNum := 0
+	err := eachRow(ctx, datasource, e.options, func(row []interface{}) error {
+		fill := rowNum%2 == 1
+		pdf.SetFillColor(245, 245, 245)
+		for i := range headers {
+			var value interface{}
+			if i < len(row) {
+				value = row[i]
+			}
+			text, align := e.cellText(value)
+			pdf.CellFormat(colWidth, rowHeight, fitText(pdf, text, colWidth), "1", 0, align, fill, 0, "")
+		}
+		pdf.Ln(-1)
+		rowNum++
+		if pdf.Err() {
+			return pdf.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to write pdf: %w", err)
+	}
+	return nil
+}
+
+// cellText returns the display text and alignment for a value
+func (e *PDFExporter) cellText(value interface{}) (string, string) {
+	switch v := value.(type) {
+	case *money.Money:
+		if v == nil {
+			return "", "L"
+		}
+		return v.Display(), "R"
+	case money.Money:
+		return v.Display(), "R"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return stringValue(v, e.options), "R"
+	default:
+		return stringValue(v, e.options), "L"
+	}
+}
+
+// fitText truncates text so it fits into a cell
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	const padding = 2.0
+	if pdf.GetStringWidth(text) <= width-padding {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width-padding {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// IsPDFImage reports whether data is an image format the PDF exporter can print as a logo
+func IsPDFImage(data []byte) bool {
+	_, err := pdfImageType(data)
+	return err == nil
+}
+
+// pdfImageType detects the fpdf image type of a logo
+func pdfImageType(data []byte) (string, error) {
+	switch http.DetectContentType(data) {
+	case "image/png":
+		return "PNG", nil
+	case "image/jpeg":
+		return "JPG", nil
+	case "image/gif":
+		return "GIF", nil
+	default:
+		return "", fmt.Errorf("unsupported logo image type")
+	}
+}
@@ -0,0 +1,60 @@
+package excel_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/excel"
+)
+
+func TestPDFExporter_Export(t *testing.T) {
+	rows := make([][]interface{}, 0, 200)
+	for i := 0; i < 200; i++ {
+		rows = append(rows, []interface{}{i, fmt.Sprintf("Name %d", i), float64(i) * 1.5})
+	}
+	ds := NewMockDataSource([]string{"ID", "Name", "Amount"}, rows)
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 1, color.Black)
+	var logo bytes.Buffer
+	require.NoError(t, png.Encode(&logo, img))
+
+	exporter := excel.NewPDFExporter(nil, &excel.PDFOptions{
+		Title: "Expenses",
+		Logo:  logo.Bytes(),
+	})
+	data, err := exporter.Export(context.Background(), ds)
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	assert.Greater(t, bytes.Count(data, []byte("/Type /Page\n")), 1)
+}
+
+func TestPDFExporter_InvalidLogo(t *testing.T) {
+	ds := NewMockDataSource([]string{"ID"}, [][]interface{}{{1}})
+	exporter := excel.NewPDFExporter(nil, &excel.PDFOptions{Logo: []byte("not an image")})
+
+	_, err := exporter.Export(context.Background(), ds)
+	require.Error(t, err)
+}
+
+func TestPDFExporter_UnicodeWithoutFont(t *testing.T) {
+	ds := NewMockDataSource(
+		[]string{"Имя", "Manzil"},
+		[][]interface{}{{"Алишер Навоий", "Oʻzbekiston, Gʻijduvon"}},
+	)
+	exporter := excel.NewPDFExporter(nil, &excel.PDFOptions{Title: "Сотрудники"})
+
+	data, err := exporter.Export(context.Background(), ds)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "/FontFile2", "the embedded UTF-8 font is used by default")
+	assert.NotContains(t, string(data), "/Helvetica")
+}
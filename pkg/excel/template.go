@@ -0,0 +1,193 @@
+package excel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// TemplateFiller fills named ranges of an existing .xlsx template.
+// Styles, formulas and charts of the template are preserved.
+type TemplateFiller struct {
+	file    *excelize.File
+	options *ExportOptions
+}
+
+// NewTemplateFiller opens an .xlsx template from the reader
+func NewTemplateFiller(r io.Reader, opts *ExportOptions) (*TemplateFiller, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template: %w", err)
+	}
+	return &TemplateFiller{
+		file:    f,
+		options: opts,
+	}, nil
+}
+
+// Names returns the named ranges defined in the template
+func (t *TemplateFiller) Names() []string {
+	definedNames := t.file.GetDefinedName()
+	names := make([]string, 0, len(definedNames))
+	for _, dn := range definedNames {
+		names = append(names, dn.Name)
+	}
+	return names
+}
+
+// SetValue writes a single value to the top-left cell of the named range
+func (t *TemplateFiller) SetValue(name string, value interface{}) error {
+	sheet, col, row, err := t.resolve(name)
+	if err != nil {
+		return err
+	}
+	cell, _ := excelize.CoordinatesToCellName(col, row)
+	value, _, _ = cellValue(value, ColumnOptions{}, t.options)
+	return t.file.SetCellValue(sheet, cell, value)
+}
+
+// FillRange writes the data source rows starting at the top-left cell of the named range.
+// Rows after the first are inserted below it, so cells located under the range are shifted
+// down, and inserted cells reuse the styles of the first row. The named range is resized to
+// the filled rows, so template formulas should aggregate over the name, e.g.
+// SUM(INDEX(items,0,2)).
+func (t *TemplateFiller) FillRange(ctx context.Context, name string, datasource DataSource) error {
+	dn, err := t.definedName(name)
+	if err != nil {
+		return err
+	}
+	sheet, startCol, startRow, endCol, err := parseRange(dn)
+	if err != nil {
+		return err
+	}
+
+	getRow, err := datasource.GetRows(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	var rows [][]interface{}
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		row, err := getRow()
+		if err != nil {
+			return fmt.Errorf("failed to get row: %w", err)
+		}
+		if row == nil {
+			break
+		}
+
+		if t.options.MaxRows > 0 && len(rows) >= t.options.MaxRows {
+			break
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	styles := make([]int, width)
+	for i := range styles {
+		cell, _ := excelize.CoordinatesToCellName(startCol+i, startRow)
+		if styles[i], err = t.file.GetCellStyle(sheet, cell); err != nil {
+			return err
+		}
+	}
+
+	if len(rows) > 1 {
+		if err := t.file.InsertRows(sheet, startRow+1, len(rows)-1); err != nil {
+			return fmt.Errorf("failed to insert rows: %w", err)
+		}
+	}
+
+	for r, row := range rows {
+		for i, value := range row {
+			cell, _ := excelize.CoordinatesToCellName(startCol+i, startRow+r)
+			value, _, _ = cellValue(value, ColumnOptions{}, t.options)
+			if err := t.file.SetCellValue(sheet, cell, value); err != nil {
+				return fmt.Errorf("failed to write cell %s: %w", cell, err)
+			}
+			if r > 0 {
+				if err := t.file.SetCellStyle(sheet, cell, cell, styles[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	endCol = max(endCol, startCol+width-1)
+	from, _ := excelize.CoordinatesToCellName(startCol, startRow, true)
+	to, _ := excelize.CoordinatesToCellName(endCol, startRow+len(rows)-1, true)
+	if err := t.file.DeleteDefinedName(&excelize.DefinedName{Name: dn.Name, Scope: dn.Scope}); err != nil {
+		return fmt.Errorf("failed to resize named range %q: %w", name, err)
+	}
+	dn.RefersTo = fmt.Sprintf("%s!%s:%s", quoteSheetName(sheet), from, to)
+	if err := t.file.SetDefinedName(&dn); err != nil {
+		return fmt.Errorf("failed to resize named range %q: %w", name, err)
+	}
+	return nil
+}
+
+// WriteTo writes the filled workbook to w
+func (t *TemplateFiller) WriteTo(w io.Writer) (int64, error) {
+	return t.file.WriteTo(w)
+}
+
+// Close releases resources held by the template
+func (t *TemplateFiller) Close() error {
+	return t.file.Close()
+}
+
+// resolve returns the sheet and top-left coordinates of a named range
+func (t *TemplateFiller) resolve(name string) (string, int, int, error) {
+	dn, err := t.definedName(name)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	sheet, col, row, _, err := parseRange(dn)
+	return sheet, col, row, err
+}
+
+func (t *TemplateFiller) definedName(name string) (excelize.DefinedName, error) {
+	for _, dn := range t.file.GetDefinedName() {
+		if dn.Name == name {
+			return dn, nil
+		}
+	}
+	return excelize.DefinedName{}, fmt.Errorf("named range %q not found", name)
+}
+
+// parseRange returns the sheet, top-left coordinates and last column of a named range
+func parseRange(dn excelize.DefinedName) (string, int, int, int, error) {
+	ref := strings.TrimPrefix(dn.RefersTo, "=")
+	sep := strings.LastIndex(ref, "!")
+	if sep == -1 {
+		return "", 0, 0, 0, fmt.Errorf("named range %q has no sheet reference", dn.Name)
+	}
+	sheet := strings.ReplaceAll(strings.Trim(ref[:sep], "'"), "''", "'")
+	cells := strings.SplitN(strings.ReplaceAll(ref[sep+1:], "$", ""), ":", 2)
+	col, row, err := excelize.CellNameToCoordinates(cells[0])
+	if err != nil {
+		return "", 0, 0, 0, fmt.Errorf("invalid reference for named range %q: %w", dn.Name, err)
+	}
+	endCol := col
+	if len(cells) == 2 {
+		if endCol, _, err = excelize.CellNameToCoordinates(cells[1]); err != nil {
+			return "", 0, 0, 0, fmt.Errorf("invalid reference for named range %q: %w", dn.Name, err)
+		}
+	}
+	return sheet, col, row, endCol, nil
+}
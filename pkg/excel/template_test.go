@@ -0,0 +1,89 @@
+package excel_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/iota-uz/iota-sdk/pkg/excel"
+)
+
+func newTemplate(t *testing.T) []byte {
+	t.Helper()
+
+	f := excelize.NewFile()
+	require.NoError(t, f.SetSheetName("Sheet1", "Invoice"))
+	require.NoError(t, f.SetCellValue("Invoice", "A1", "Customer:"))
+	require.NoError(t, f.SetCellValue("Invoice", "A3", "Item"))
+	require.NoError(t, f.SetCellValue("Invoice", "B3", "Amount"))
+	require.NoError(t, f.SetCellValue("Invoice", "A5", "Total"))
+	require.NoError(t, f.SetCellFormula("Invoice", "B5", "SUM(INDEX(items,0,2))"))
+	require.NoError(t, f.SetDefinedName(&excelize.DefinedName{Name: "customer", RefersTo: "Invoice!$B$1"}))
+	require.NoError(t, f.SetDefinedName(&excelize.DefinedName{Name: "items", RefersTo: "Invoice!$A$4:$B$4"}))
+
+	buf, err := f.WriteToBuffer()
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestTemplateFiller_FillRange(t *testing.T) {
+	filler, err := excel.NewTemplateFiller(bytes.NewReader(newTemplate(t)), nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = filler.Close()
+	}()
+
+	assert.ElementsMatch(t, []string{"customer", "items"}, filler.Names())
+	require.NoError(t, filler.SetValue("customer", "ACME"))
+
+	items := excel.NewSliceDataSource([]string{"Item", "Amount"}, [][]interface{}{
+		{"Consulting", 100.0},
+		{"Support", 50.0},
+		{"Hosting", 25.0},
+	})
+	require.NoError(t, filler.FillRange(context.Background(), "items", items))
+
+	var buf bytes.Buffer
+	_, err = filler.WriteTo(&buf)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+
+	customer, err := f.GetCellValue("Invoice", "B1")
+	require.NoError(t, err)
+	assert.Equal(t, "ACME", customer)
+
+	last, err := f.GetCellValue("Invoice", "A6")
+	require.NoError(t, err)
+	assert.Equal(t, "Hosting", last)
+
+	label, err := f.GetCellValue("Invoice", "A7")
+	require.NoError(t, err)
+	assert.Equal(t, "Total", label)
+
+	formula, err := f.GetCellFormula("Invoice", "B7")
+	require.NoError(t, err)
+	assert.Equal(t, "SUM(INDEX(items,0,2))", formula)
+
+	for _, dn := range f.GetDefinedName() {
+		if dn.Name == "items" {
+			assert.Equal(t, "'Invoice'!$A$4:$B$6", dn.RefersTo)
+		}
+	}
+}
+
+func TestTemplateFiller_UnknownName(t *testing.T) {
+	filler, err := excel.NewTemplateFiller(bytes.NewReader(newTemplate(t)), nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = filler.Close()
+	}()
+
+	err = filler.SetValue("missing", "value")
+	require.Error(t, err)
+}
@@ -0,0 +1,519 @@
+package excel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+const maxSheetNameLength = 31
+
+// Sheet pairs a data source with its sheet layout options
+type Sheet struct {
+	DataSource DataSource
+	Options    *SheetOptions
+}
+
+// WorkbookExporter exports several data sources into one workbook, one sheet each.
+// Rows are written with the excelize stream writer, so large data sources are not
+// held in memory cell by cell.
+type WorkbookExporter struct {
+	options      *ExportOptions
+	styleOptions *StyleOptions
+}
+
+// NewWorkbookExporter creates a new workbook exporter
+func NewWorkbookExporter(opts *ExportOptions, styleOpts *StyleOptions) *WorkbookExporter {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if styleOpts == nil {
+		styleOpts = DefaultStyleOptions()
+	}
+	return &WorkbookExporter{
+		options:      opts,
+		styleOptions: styleOpts,
+	}
+}
+
+// Export exports all sheets into a workbook and returns its bytes
+func (e *WorkbookExporter) Export(ctx context.Context, sheets ...Sheet) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := e.ExportTo(ctx, &buf, sheets...); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportTo exports all sheets into a workbook and writes it to w
+func (e *WorkbookExporter) ExportTo(ctx context.Context, w io.Writer, sheets ...Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("no sheets to export")
+	}
+
+	f := excelize.NewFile()
+	defer func() {
+		_ = f.Close()
+	}()
+
+	styles := newStyleCache(f, e.styleOptions)
+	used := make(map[string]bool, len(sheets))
+	for i, sheet := range sheets {
+		name := uniqueSheetName(sheet.DataSource.GetSheetName(), used)
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), name); err != nil {
+				return fmt.Errorf("failed to rename sheet: %w", err)
+			}
+		} else if _, err := f.NewSheet(name); err != nil {
+			return fmt.Errorf("failed to create sheet: %w", err)
+		}
+
+		if err := e.writeSheet(ctx, f, styles, name, sheet); err != nil {
+			return fmt.Errorf("failed to write sheet %q: %w", name, err)
+		}
+	}
+	f.SetActiveSheet(0)
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
+
+// writeSheet streams a single data source into the named sheet
+func (e *WorkbookExporter) writeSheet(ctx context.Context, f *excelize.File, styles *styleCache, name string, sheet Sheet) error {
+	headers := sheet.DataSource.GetHeaders()
+	if len(headers) == 0 {
+		return fmt.Errorf("no columns found in data source")
+	}
+	opts := sheet.Options
+
+	sw, err := f.NewStreamWriter(name)
+	if err != nil {
+		return fmt.Errorf("failed to create stream writer: %w", err)
+	}
+
+	for i := range headers {
+		width := 15.0
+		if w := opts.column(i).Width; w > 0 {
+			width = w
+		}
+		if err := sw.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
+	}
+
+	rowNum := 1
+	blockRow := 0
+	if opts != nil && len(opts.HeaderBlocks) > 0 {
+		blockRow = rowNum
+		rowNum++
+	}
+	headerRow := 0
+	if e.options.IncludeHeaders {
+		headerRow = rowNum
+		rowNum++
+	}
+
+	if headerRow > 0 && e.options.FreezeHeader {
+		topLeft, _ := excelize.CoordinatesToCellName(1, headerRow+1)
+		if err := sw.SetPanes(&excelize.Panes{
+			Freeze:      true,
+			YSplit:      headerRow,
+			TopLeftCell: topLeft,
+			ActivePane:  "bottomLeft",
+		}); err != nil {
+			return err
+		}
+	}
+
+	if blockRow > 0 {
+		if err := e.writeHeaderBlocks(sw, styles, blockRow, len(headers), opts.HeaderBlocks); err != nil {
+			return fmt.Errorf("failed to write header blocks: %w", err)
+		}
+	}
+
+	if headerRow > 0 {
+		styleID, err := styles.get(styleHeader, 0, "")
+		if err != nil {
+			return err
+		}
+		cells := make([]interface{}, len(headers))
+		for i, header := range headers {
+			cells[i] = excelize.Cell{StyleID: styleID, Value: header}
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, headerRow)
+		if err := sw.SetRow(cell, cells); err != nil {
+			return fmt.Errorf("failed to write headers: %w", err)
+		}
+	}
+
+	getRow, err := sheet.DataSource.GetRows(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rows: %w", err)
+	}
+
+	firstDataRow := rowNum
+	rowCount := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		row, err := getRow()
+		if err != nil {
+			return fmt.Errorf("failed to get row: %w", err)
+		}
+		if row == nil {
+			break
+		}
+
+		if e.options.MaxRows > 0 && rowCount >= e.options.MaxRows {
+			break
+		}
+
+		cells, err := e.rowCells(styles, opts, rowNum, row)
+		if err != nil {
+			return fmt.Errorf("failed to prepare row %d: %w", rowNum, err)
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+		if err := sw.SetRow(cell, cells); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", rowNum, err)
+		}
+
+		rowNum++
+		rowCount++
+	}
+	lastDataRow := rowNum - 1
+
+	if opts != nil && opts.Totals != nil && rowCount > 0 {
+		if err := e.writeTotals(sw, styles, opts, rowNum, len(headers), firstDataRow, lastDataRow); err != nil {
+			return fmt.Errorf("failed to write totals: %w", err)
+		}
+	}
+
+	if err := sw.Flush(); err != nil {
+		return fmt.Errorf("failed to flush sheet: %w", err)
+	}
+
+	if headerRow > 0 && e.options.AutoFilter {
+		endCol, _ := excelize.ColumnNumberToName(len(headers))
+		if err := f.AutoFilter(name, fmt.Sprintf("A%d:%s%d", headerRow, endCol, headerRow), nil); err != nil {
+			return fmt.Errorf("failed to add auto filter: %w", err)
+		}
+	}
+
+	if opts != nil && rowCount > 0 {
+		for _, chart := range opts.Charts {
+			if err := addChart(f, name, chart, headerRow, firstDataRow, lastDataRow); err != nil {
+				return fmt.Errorf("failed to add chart: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeHeaderBlocks writes merged header cells above the column headers
+func (e *WorkbookExporter) writeHeaderBlocks(sw *excelize.StreamWriter, styles *styleCache, rowNum, colCount int, blocks []HeaderBlock) error {
+	styleID, err := styles.get(styleHeader, 0, "")
+	if err != nil {
+		return err
+	}
+	cells := make([]interface{}, colCount)
+	for i := range cells {
+		cells[i] = excelize.Cell{StyleID: styleID}
+	}
+	for _, block := range blocks {
+		if block.FromColumn < 0 || block.ToColumn >= colCount || block.FromColumn > block.ToColumn {
+			return fmt.Errorf("header block %q is out of column range", block.Title)
+		}
+		cells[block.FromColumn] = excelize.Cell{StyleID: styleID, Value: block.Title}
+	}
+	cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+	if err := sw.SetRow(cell, cells); err != nil {
+		return err
+	}
+	for _, block := range blocks {
+		if block.FromColumn == block.ToColumn {
+			continue
+		}
+		from, _ := excelize.CoordinatesToCellName(block.FromColumn+1, rowNum)
+		to, _ := excelize.CoordinatesToCellName(block.ToColumn+1, rowNum)
+		if err := sw.MergeCell(from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowCells converts a data row into styled stream cells
+func (e *WorkbookExporter) rowCells(styles *styleCache, opts *SheetOptions, rowNum int, row []interface{}) ([]interface{}, error) {
+	role := styleData
+	if e.styleOptions.AlternateRow && rowNum%2 == 0 {
+		role = styleAlternate
+	}
+
+	cells := make([]interface{}, len(row))
+	for i, value := range row {
+		column := opts.column(i)
+		value, numFmt, customFmt := cellValue(value, column, e.options)
+		styleID, err := styles.get(role, numFmt, customFmt)
+		if err != nil {
+			return nil, err
+		}
+		cells[i] = excelize.Cell{StyleID: styleID, Value: value}
+	}
+	return cells, nil
+}
+
+// writeTotals writes the formula totals row
+func (e *WorkbookExporter) writeTotals(sw *excelize.StreamWriter, styles *styleCache, opts *SheetOptions, rowNum, colCount, firstRow, lastRow int) error {
+	labelStyle, err := styles.get(styleTotal, 0, "")
+	if err != nil {
+		return err
+	}
+	cells := make([]interface{}, colCount)
+	for i := range cells {
+		cells[i] = excelize.Cell{StyleID: labelStyle}
+	}
+	if _, ok := opts.Totals.Columns[0]; !ok && opts.Totals.Label != "" {
+		cells[0] = excelize.Cell{StyleID: labelStyle, Value: opts.Totals.Label}
+	}
+
+	for index, fn := range opts.Totals.Columns {
+		if index < 0 || index >= colCount {
+			return fmt.Errorf("totals column %d is out of range", index)
+		}
+		column := opts.column(index)
+		customFmt := column.Format
+		if customFmt == "" && column.Currency != nil {
+			customFmt = CurrencyFormat(column.Currency)
+		}
+		numFmt := 0
+		if customFmt == "" && fn != TotalCount {
+			numFmt = 2
+		}
+		styleID, err := styles.get(styleTotal, numFmt, customFmt)
+		if err != nil {
+			return err
+		}
+		col, _ := excelize.ColumnNumberToName(index + 1)
+		cells[index] = excelize.Cell{
+			StyleID: styleID,
+			Formula: fmt.Sprintf("%s(%s%d:%s%d)", fn, col, firstRow, col, lastRow),
+		}
+	}
+
+	cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+	return sw.SetRow(cell, cells)
+}
+
+// cellValue normalizes a value and resolves its number format
+func cellValue(value interface{}, column ColumnOptions, opts *ExportOptions) (interface{}, int, string) {
+	numFmt := 0
+	customFmt := column.Format
+
+	switch v := value.(type) {
+	case *money.Money:
+		if v == nil {
+			return nil, 0, customFmt
+		}
+		if customFmt == "" {
+			customFmt = CurrencyFormat(v.Currency())
+		}
+		return v.AsMajorUnits(), 0, customFmt
+	case money.Money:
+		if customFmt == "" {
+			customFmt = CurrencyFormat(v.Currency())
+		}
+		return v.AsMajorUnits(), 0, customFmt
+	case time.Time, *time.Time:
+		return formatValue(value, opts), 0, customFmt
+	case float64, float32:
+		numFmt = 2
+	case int, int64, int32:
+		numFmt = 1
+	}
+
+	if customFmt == "" && column.Currency != nil && numFmt != 0 {
+		customFmt = CurrencyFormat(column.Currency)
+	}
+	if customFmt != "" {
+		numFmt = 0
+	}
+	return value, numFmt, customFmt
+}
+
+// addChart adds a chart referencing the data rows of a sheet
+func addChart(f *excelize.File, sheet string, opts ChartOptions, headerRow, firstRow, lastRow int) error {
+	chartType, ok := chartTypes[opts.Type]
+	if !ok {
+		return fmt.Errorf("unsupported chart type %q", opts.Type)
+	}
+	if len(opts.ValueColumns) == 0 {
+		return fmt.Errorf("chart has no value columns")
+	}
+
+	ref := quoteSheetName(sheet)
+	catCol, _ := excelize.ColumnNumberToName(opts.CategoryColumn + 1)
+	categories := fmt.Sprintf("%s!$%s$%d:$%s$%d", ref, catCol, firstRow, catCol, lastRow)
+
+	series := make([]excelize.ChartSeries, 0, len(opts.ValueColumns))
+	for _, index := range opts.ValueColumns {
+		col, _ := excelize.ColumnNumberToName(index + 1)
+		s := excelize.ChartSeries{
+			Categories: categories,
+			Values:     fmt.Sprintf("%s!$%s$%d:$%s$%d", ref, col, firstRow, col, lastRow),
+		}
+		if headerRow > 0 {
+			s.Name = fmt.Sprintf("%s!$%s$%d", ref, col, headerRow)
+		}
+		series = append(series, s)
+	}
+
+	chart := &excelize.Chart{
+		Type:   chartType,
+		Series: series,
+	}
+	if opts.Title != "" {
+		chart.Title = []excelize.RichTextRun{{Text: opts.Title}}
+	}
+	if opts.Width > 0 && opts.Height > 0 {
+		chart.Dimension = excelize.ChartDimension{Width: opts.Width, Height: opts.Height}
+	}
+
+	cell := opts.Cell
+	if cell == "" {
+		cell = "A" + fmt.Sprint(lastRow+3)
+	}
+	return f.AddChart(sheet, cell, chart)
+}
+
+var chartTypes = map[ChartType]excelize.ChartType{
+	ChartColumn: excelize.Col,
+	ChartBar:    excelize.Bar,
+	ChartLine:   excelize.Line,
+	ChartPie:    excelize.Pie,
+}
+
+// quoteSheetName quotes a sheet name for use in cell references
+func quoteSheetName(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// uniqueSheetName sanitizes a sheet name and makes it unique within the workbook
+func uniqueSheetName(name string, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, strings.Trim(name, "'"))
+	if name == "" {
+		name = "Sheet"
+	}
+	name = truncateRunes(name, maxSheetNameLength)
+
+	candidate := name
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		candidate = truncateRunes(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+type styleRole int
+
+const (
+	styleHeader styleRole = iota
+	styleData
+	styleAlternate
+	styleTotal
+)
+
+type styleKey struct {
+	role      styleRole
+	numFmt    int
+	customFmt string
+}
+
+// styleCache creates each distinct cell style once per workbook
+type styleCache struct {
+	f    *excelize.File
+	opts *StyleOptions
+	ids  map[styleKey]int
+}
+
+func newStyleCache(f *excelize.File, opts *StyleOptions) *styleCache {
+	return &styleCache{
+		f:    f,
+		opts: opts,
+		ids:  make(map[styleKey]int),
+	}
+}
+
+func (c *styleCache) get(role styleRole, numFmt int, customFmt string) (int, error) {
+	key := styleKey{role: role, numFmt: numFmt, customFmt: customFmt}
+	if id, ok := c.ids[key]; ok {
+		return id, nil
+	}
+
+	style := toExcelizeStyle(c.cellStyle(role))
+	style.NumFmt = numFmt
+	if customFmt != "" {
+		style.CustomNumFmt = &customFmt
+	}
+	id, err := c.f.NewStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	c.ids[key] = id
+	return id, nil
+}
+
+func (c *styleCache) cellStyle(role styleRole) *CellStyle {
+	switch role {
+	case styleHeader:
+		return c.opts.HeaderStyle
+	case styleAlternate:
+		alt := &CellStyle{
+			Fill: &FillStyle{
+				Type:    "pattern",
+				Pattern: 1,
+				Color:   "#F5F5F5",
+			},
+		}
+		if c.opts.DataStyle != nil {
+			alt.Font = c.opts.DataStyle.Font
+			alt.Alignment = c.opts.DataStyle.Alignment
+		}
+		return alt
+	case styleTotal:
+		total := &CellStyle{Font: &FontStyle{Bold: true}}
+		if c.opts.DataStyle != nil && c.opts.DataStyle.Font != nil {
+			font := *c.opts.DataStyle.Font
+			font.Bold = true
+			total.Font = &font
+		}
+		return total
+	default:
+		return c.opts.DataStyle
+	}
+}
@@ -0,0 +1,174 @@
+package excel_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/iota-uz/iota-sdk/pkg/excel"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+func TestWorkbookExporter_MultipleSheets(t *testing.T) {
+	summary := excel.NewSliceDataSource(
+		[]string{"Category", "Total"},
+		[][]interface{}{
+			{"Rent", money.New(150000, money.USD)},
+			{"Salaries", money.New(420000, money.USD)},
+		},
+	).WithSheetName("Summary")
+	details := excel.NewSliceDataSource(
+		[]string{"ID", "Category", "Amount"},
+		[][]interface{}{
+			{1, "Rent", 1500.0},
+			{2, "Salaries", 2100.0},
+			{3, "Salaries", 2100.0},
+		},
+	).WithSheetName("Details")
+
+	exporter := excel.NewWorkbookExporter(nil, nil)
+	data, err := exporter.Export(context.Background(),
+		excel.Sheet{
+			DataSource: summary,
+			Options: &excel.SheetOptions{
+				Totals: &excel.TotalsOptions{
+					Label:   "Total",
+					Columns: map[int]excel.TotalFunction{1: excel.TotalSum},
+				},
+			},
+		},
+		excel.Sheet{DataSource: details},
+	)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Summary", "Details"}, f.GetSheetList())
+
+	value, err := f.GetCellValue("Summary", "B2")
+	require.NoError(t, err)
+	assert.Equal(t, "$1,500.00", value)
+
+	label, err := f.GetCellValue("Summary", "A4")
+	require.NoError(t, err)
+	assert.Equal(t, "Total", label)
+
+	formula, err := f.GetCellFormula("Summary", "B4")
+	require.NoError(t, err)
+	assert.Equal(t, "SUM(B2:B3)", formula)
+
+	rows, err := f.GetRows("Details")
+	require.NoError(t, err)
+	assert.Len(t, rows, 4)
+}
+
+func TestWorkbookExporter_HeaderBlocksAndCharts(t *testing.T) {
+	ds := excel.NewSliceDataSource(
+		[]string{"Month", "Income", "Expense"},
+		[][]interface{}{
+			{"Jan", 100, 80},
+			{"Feb", 120, 90},
+		},
+	).WithSheetName("Report")
+
+	exporter := excel.NewWorkbookExporter(nil, nil)
+	data, err := exporter.Export(context.Background(), excel.Sheet{
+		DataSource: ds,
+		Options: &excel.SheetOptions{
+			HeaderBlocks: []excel.HeaderBlock{{Title: "Cash flow", FromColumn: 1, ToColumn: 2}},
+			Charts: []excel.ChartOptions{{
+				Type:         excel.ChartColumn,
+				Title:        "Cash flow",
+				Cell:         "E2",
+				ValueColumns: []int{1, 2},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	merged, err := f.GetMergeCells("Report")
+	require.NoError(t, err)
+	require.Len(t, merged, 1)
+	assert.Equal(t, "B1", merged[0].GetStartAxis())
+	assert.Equal(t, "C1", merged[0].GetEndAxis())
+	assert.Equal(t, "Cash flow", merged[0].GetCellValue())
+
+	header, err := f.GetCellValue("Report", "A2")
+	require.NoError(t, err)
+	assert.Equal(t, "Month", header)
+
+	first, err := f.GetCellValue("Report", "A3")
+	require.NoError(t, err)
+	assert.Equal(t, "Jan", first)
+}
+
+func TestWorkbookExporter_DuplicateSheetNames(t *testing.T) {
+	first := excel.NewSliceDataSource([]string{"A"}, [][]interface{}{{1}}).WithSheetName("Data")
+	second := excel.NewSliceDataSource([]string{"A"}, [][]interface{}{{2}}).WithSheetName("Data")
+
+	data, err := excel.NewWorkbookExporter(nil, nil).Export(context.Background(),
+		excel.Sheet{DataSource: first},
+		excel.Sheet{DataSource: second},
+	)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Data", "Data (2)"}, f.GetSheetList())
+}
+
+func TestWorkbookExporter_NoSheets(t *testing.T) {
+	_, err := excel.NewWorkbookExporter(nil, nil).Export(context.Background())
+	require.Error(t, err)
+}
+
+func TestExcelExporter_ExportTo(t *testing.T) {
+	ds := NewMockDataSource([]string{"ID", "Name"}, [][]interface{}{{1, "John"}, {2, "Jane"}})
+
+	var buf bytes.Buffer
+	err := excel.NewExcelExporter(nil, nil).ExportTo(context.Background(), &buf, ds)
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	value, err := f.GetCellValue("TestSheet", "B3")
+	require.NoError(t, err)
+	assert.Equal(t, "Jane", value)
+}
+
+func TestCurrencyFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency *money.Currency
+		expected string
+	}{
+		{
+			name:     "symbol before amount",
+			currency: money.GetCurrency(money.USD),
+			expected: `"$"#,##0.00`,
+		},
+		{
+			name:     "symbol after amount",
+			currency: &money.Currency{Fraction: 2, Grapheme: "сўм", Template: "1 $"},
+			expected: `#,##0.00 "сўм"`,
+		},
+		{
+			name:     "no fraction digits",
+			currency: &money.Currency{Fraction: 0, Grapheme: "¥", Template: "$1"},
+			expected: `"¥"#,##0`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, excel.CurrencyFormat(tt.currency))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+const subscriptionBufferSize = 16
+
+// Broker fans values out to the subscriptions that are open at the time they are published.
+// Subscribe it to the event bus once and let every subscription resolver listen to it.
+type Broker[T any] struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers map[int]func(T)
+}
+
+func NewBroker[T any]() *Broker[T] {
+	return &Broker[T]{
+		subscribers: make(map[int]func(T)),
+	}
+}
+
+// Publish hands v to every open subscription, it never blocks on slow subscribers
+func (b *Broker[T]) Publish(v T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, fn := range b.subscribers {
+		fn(v)
+	}
+}
+
+// Count returns the number of open subscriptions
+func (b *Broker[T]) Count() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+func (b *Broker[T]) add(fn func(T)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = fn
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, id)
+	}
+}
+
+// Subscribe streams the values published on b until ctx is done, then closes the channel.
+// fn maps a value to what the subscription returns, values it rejects are skipped.
+// Values are dropped for a subscriber whose buffer is full.
+func Subscribe[T any, M any](ctx context.Context, b *Broker[T], fn func(T) (M, bool)) <-chan M {
+	ch := make(chan M, subscriptionBufferSize)
+	remove := b.add(func(v T) {
+		m, ok := fn(v)
+		if !ok {
+			return
+		}
+		select {
+		case ch <- m:
+		default:
+		}
+	})
+	go func() {
+		<-ctx.Done()
+		// remove waits for in-flight publishes, so nothing is sent after close
+		remove()
+		close(ch)
+	}()
+	return ch
+}
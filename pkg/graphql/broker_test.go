@@ -0,0 +1,51 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker_Subscribe(t *testing.T) {
+	broker := NewBroker[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	evens := Subscribe(ctx, broker, func(v int) (string, bool) {
+		return string(rune('0' + v)), v%2 == 0
+	})
+	require.Equal(t, 1, broker.Count())
+
+	for i := 1; i <= 4; i++ {
+		broker.Publish(i)
+	}
+	assert.Equal(t, "2", <-evens)
+	assert.Equal(t, "4", <-evens)
+
+	cancel()
+	select {
+	case _, ok := <-evens:
+		assert.False(t, ok, "channel must be closed once the context is done")
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed")
+	}
+	assert.Equal(t, 0, broker.Count())
+
+	// publishing without subscribers is a no-op
+	broker.Publish(6)
+}
+
+func TestBroker_DropsForSlowSubscribers(t *testing.T) {
+	broker := NewBroker[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	values := Subscribe(ctx, broker, func(v int) (int, bool) { return v, true })
+	for i := 0; i < subscriptionBufferSize*2; i++ {
+		broker.Publish(i)
+	}
+	assert.Len(t, values, subscriptionBufferSize)
+	assert.Equal(t, 0, <-values)
+}
@@ -0,0 +1,157 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLoaderWait     = 2 * time.Millisecond
+	defaultLoaderMaxBatch = 100
+)
+
+// BatchFunc fetches the values of keys in a single round trip.
+// Keys missing from the returned map resolve to the zero value of V.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects the keys requested while resolving sibling fields and fetches them
+// with one BatchFunc call, so relation fields don't issue a query per parent.
+// Loaded values are cached for the lifetime of the loader, create one per operation.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*loaderResult[V]
+	batch *loaderBatch[K, V]
+}
+
+type loaderResult[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type loaderBatch[K comparable, V any] struct {
+	keys    []K
+	results map[K]*loaderResult[V]
+}
+
+type LoaderOption[K comparable, V any] func(l *Loader[K, V])
+
+// WithWait sets how long the loader waits for more keys before fetching a batch
+func WithWait[K comparable, V any](wait time.Duration) LoaderOption[K, V] {
+	return func(l *Loader[K, V]) {
+		l.wait = wait
+	}
+}
+
+// WithMaxBatch sets the number of keys that triggers a fetch without waiting
+func WithMaxBatch[K comparable, V any](size int) LoaderOption[K, V] {
+	return func(l *Loader[K, V]) {
+		l.maxBatch = size
+	}
+}
+
+func NewLoader[K comparable, V any](fetch BatchFunc[K, V], opts ...LoaderOption[K, V]) *Loader[K, V] {
+	l := &Loader[K, V]{
+		fetch:    fetch,
+		wait:     defaultLoaderWait,
+		maxBatch: defaultLoaderMaxBatch,
+		cache:    make(map[K]*loaderResult[V]),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Load returns the value of key, fetching it together with the other keys requested meanwhile
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res, ok := l.cache[key]
+	if !ok {
+		res = &loaderResult[V]{done: make(chan struct{})}
+		l.cache[key] = res
+		if l.batch == nil {
+			l.batch = &loaderBatch[K, V]{results: make(map[K]*loaderResult[V])}
+			batch := l.batch
+			time.AfterFunc(l.wait, func() {
+				l.dispatch(ctx, batch)
+			})
+		}
+		l.batch.keys = append(l.batch.keys, key)
+		l.batch.results[key] = res
+		if len(l.batch.keys) >= l.maxBatch {
+			batch := l.batch
+			l.batch = nil
+			l.mu.Unlock()
+			go l.run(ctx, batch)
+			l.mu.Lock()
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// LoadMany returns the values of keys in the same order
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		v, err := l.Load(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// Prime stores value for key so that later loads don't fetch it
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.cache[key]; ok {
+		return
+	}
+	res := &loaderResult[V]{done: make(chan struct{}), value: value}
+	close(res.done)
+	l.cache[key] = res
+}
+
+// dispatch runs batch unless it was already flushed for reaching the max size
+func (l *Loader[K, V]) dispatch(ctx context.Context, batch *loaderBatch[K, V]) {
+	l.mu.Lock()
+	if l.batch != batch {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+	l.run(ctx, batch)
+}
+
+func (l *Loader[K, V]) run(ctx context.Context, batch *loaderBatch[K, V]) {
+	values, err := l.fetch(ctx, batch.keys)
+	if err != nil {
+		// drop the failed keys so that a later load retries them
+		l.mu.Lock()
+		for _, key := range batch.keys {
+			delete(l.cache, key)
+		}
+		l.mu.Unlock()
+	}
+	for key, res := range batch.results {
+		res.value, res.err = values[key], err
+		close(res.done)
+	}
+}
@@ -0,0 +1,111 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_BatchesConcurrentLoads(t *testing.T) {
+	var calls atomic.Int32
+	var batchSize atomic.Int32
+	loader := NewLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		calls.Add(1)
+		batchSize.Store(int32(len(keys)))
+		result := make(map[int]string, len(keys))
+		for _, k := range keys {
+			if k != 3 {
+				result[k] = string(rune('a' + k))
+			}
+		}
+		return result, nil
+	})
+
+	keys := []int{0, 1, 2, 3, 1}
+	values := make([]string, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := loader.Load(context.Background(), key)
+			assert.NoError(t, err)
+			values[i] = v
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, int32(4), batchSize.Load())
+	assert.Equal(t, []string{"a", "b", "c", "", "b"}, values)
+
+	v, err := loader.Load(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "c", v)
+	assert.Equal(t, int32(1), calls.Load(), "cached keys must not be fetched again")
+}
+
+func TestLoader_MaxBatch(t *testing.T) {
+	var calls atomic.Int32
+	loader := NewLoader(func(ctx context.Context, keys []int) (map[int]int, error) {
+		calls.Add(1)
+		assert.LessOrEqual(t, len(keys), 2)
+		result := make(map[int]int, len(keys))
+		for _, k := range keys {
+			result[k] = k * 10
+		}
+		return result, nil
+	}, WithMaxBatch[int, int](2))
+
+	values, err := loader.LoadMany(context.Background(), []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 20}, values)
+
+	var wg sync.WaitGroup
+	for _, key := range []int{3, 4, 5} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := loader.Load(context.Background(), key)
+			assert.NoError(t, err)
+			assert.Equal(t, key*10, v)
+		}()
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, calls.Load(), int32(3))
+}
+
+func TestLoader_ErrorsAreNotCached(t *testing.T) {
+	fail := true
+	loader := NewLoader(func(ctx context.Context, keys []string) (map[string]int, error) {
+		if fail {
+			return nil, errors.New("boom")
+		}
+		return map[string]int{"x": 1}, nil
+	})
+
+	_, err := loader.Load(context.Background(), "x")
+	require.Error(t, err)
+
+	fail = false
+	v, err := loader.Load(context.Background(), "x")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestLoader_Prime(t *testing.T) {
+	loader := NewLoader(func(ctx context.Context, keys []int) (map[int]int, error) {
+		t.Fatal("primed keys must not be fetched")
+		return nil, nil
+	})
+	loader.Prime(1, 42)
+
+	v, err := loader.Load(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+}
@@ -84,11 +84,11 @@ func (h MyPOST) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExe
 		return
 	}
 
-	execsLen := len(execs)
 	for i, ex := range execs {
 		rc, opErr := ex.CreateOperationContext(ctx, params)
 		if opErr != nil {
-			if i < execsLen && rc.Operation == nil {
+			// The query may belong to the schema of another executor, the last one reports why it was rejected
+			if i < len(execs)-1 && rc.Operation == nil && !isPersistedQueryNotFound(opErr) {
 				continue
 			}
 			w.WriteHeader(statusFor(opErr))
@@ -104,6 +104,16 @@ func (h MyPOST) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExe
 	}
 }
 
+// isPersistedQueryNotFound tells the client to send the full query, no executor knows the hash as they share the store
+func isPersistedQueryNotFound(errs gqlerror.List) bool {
+	for _, err := range errs {
+		if code, ok := err.Extensions["code"].(string); ok && code == "PERSISTED_QUERY_NOT_FOUND" {
+			return true
+		}
+	}
+	return false
+}
+
 type MyPOST struct {
 	// Map of all headers that are added to graphql response. If not
 	// set, only one header: Content-Type: application/json will be set.
@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/iota-uz/iota-sdk/pkg/ratelimit"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// CostLimit rejects operations over the complexity or depth limit before they are executed, a limit of 0 or less is disabled.
+// Introspection fields don't count towards the depth so that tools can always load the schema.
+type CostLimit struct {
+	MaxComplexity int
+	MaxDepth      int
+
+	es graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = &CostLimit{}
+
+func (c *CostLimit) ExtensionName() string {
+	return "CostLimit"
+}
+
+func (c *CostLimit) Validate(schema graphql.ExecutableSchema) error {
+	c.es = schema
+	return nil
+}
+
+func (c *CostLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if c.MaxDepth > 0 {
+		if depth := selectionDepth(opCtx.Operation.SelectionSet, 0); depth > c.MaxDepth {
+			return serrors.DepthLimitGQLError(depth, c.MaxDepth)
+		}
+	}
+	if c.MaxComplexity > 0 {
+		if cost := complexity.Calculate(c.es, opCtx.Operation, opCtx.Variables); cost > c.MaxComplexity {
+			return serrors.ComplexityLimitGQLError(cost, c.MaxComplexity)
+		}
+	}
+	return nil
+}
+
+// selectionDepth returns the deepest level of fields in set, fragments don't add a level of their own
+func selectionDepth(set ast.SelectionSet, depth int) int {
+	deepest := depth
+	for _, selection := range set {
+		var d int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			d = selectionDepth(s.SelectionSet, depth+1)
+		case *ast.InlineFragment:
+			d = selectionDepth(s.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				d = selectionDepth(s.Definition.SelectionSet, depth)
+			}
+		}
+		deepest = max(deepest, d)
+	}
+	return deepest
+}
+
+// RateLimit takes every operation from the budget the user or API token shares with the HTTP controllers
+type RateLimit struct {
+	Limits *ratelimit.Limits
+}
+
+var _ interface {
+	graphql.OperationInterceptor
+	graphql.HandlerExtension
+} = RateLimit{}
+
+func (r RateLimit) ExtensionName() string {
+	return "RateLimit"
+}
+
+func (r RateLimit) Validate(schema graphql.ExecutableSchema) error {
+	if r.Limits == nil {
+		return errors.New("RateLimit.Limits can not be nil")
+	}
+	return nil
+}
+
+func (r RateLimit) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if ok, retryAfter := r.Limits.Allow(ctx); !ok {
+		return graphql.OneShot(&graphql.Response{
+			Errors: gqlerror.List{serrors.RateLimitGQLError(retryAfter)},
+		})
+	}
+	return next(ctx)
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type testSchema struct {
+	schema *ast.Schema
+}
+
+func (s testSchema) Schema() *ast.Schema {
+	return s.schema
+}
+
+func (s testSchema) Complexity(typeName, fieldName string, childComplexity int, args map[string]any) (int, bool) {
+	return 0, false
+}
+
+func (s testSchema) Exec(ctx context.Context) graphql.ResponseHandler {
+	return nil
+}
+
+func newTestOperation(t *testing.T, query string) (testSchema, *graphql.OperationContext) {
+	t.Helper()
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+		type User { id: ID!, name: String!, manager: User }
+		type Query { user: User }
+	`})
+	doc, errs := gqlparser.LoadQuery(schema, query)
+	require.Empty(t, errs)
+	return testSchema{schema: schema}, &graphql.OperationContext{Doc: doc, Operation: doc.Operations[0]}
+}
+
+func TestCostLimit_Depth(t *testing.T) {
+	es, opCtx := newTestOperation(t, `
+		query {
+			user { ...withManager }
+			__schema { types { fields { type { ofType { ofType { name } } } } } }
+		}
+		fragment withManager on User { name manager { ... on User { manager { id } } } }
+	`)
+	assert.Equal(t, 4, selectionDepth(opCtx.Operation.SelectionSet, 0))
+
+	limit := &CostLimit{MaxDepth: 4}
+	require.NoError(t, limit.Validate(es))
+	assert.Nil(t, limit.MutateOperationContext(context.Background(), opCtx))
+
+	limit.MaxDepth = 3
+	err := limit.MutateOperationContext(context.Background(), opCtx)
+	require.NotNil(t, err)
+	assert.Equal(t, "DEPTH_LIMIT_EXCEEDED", err.Extensions["code"])
+}
+
+func TestCostLimit_Complexity(t *testing.T) {
+	es, opCtx := newTestOperation(t, `query { user { id name manager { id } } }`)
+
+	limit := &CostLimit{MaxComplexity: 5}
+	require.NoError(t, limit.Validate(es))
+	assert.Nil(t, limit.MutateOperationContext(context.Background(), opCtx))
+
+	limit.MaxComplexity = 4
+	err := limit.MutateOperationContext(context.Background(), opCtx)
+	require.NotNil(t, err)
+	assert.Equal(t, "COMPLEXITY_LIMIT_EXCEEDED", err.Extensions["code"])
+}
@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+const (
+	persistedQueryFindQuery   = `SELECT query FROM graphql_persisted_queries WHERE hash = $1`
+	persistedQueryInsertQuery = `INSERT INTO graphql_persisted_queries (hash, query) VALUES ($1, $2) ON CONFLICT (hash) DO NOTHING`
+)
+
+// NewQueryStore returns the store of automatic persisted queries picked by GRAPHQL_APQ_STORE,
+// nil when persisted queries are disabled
+func NewQueryStore(opts configuration.GraphQLOptions, pool *pgxpool.Pool) (graphql.Cache[string], error) {
+	switch opts.APQStore {
+	case "":
+		return nil, nil
+	case "memory":
+		return lru.New[string](opts.APQCacheSize), nil
+	case "postgres":
+		return NewPostgresQueryStore(pool, opts.APQCacheSize), nil
+	default:
+		return nil, fmt.Errorf("unknown persisted query store %q", opts.APQStore)
+	}
+}
+
+// PostgresQueryStore keeps persisted queries in the graphql_persisted_queries table so that every server knows
+// the queries registered on any of them, recently used queries are cached in memory
+type PostgresQueryStore struct {
+	pool  *pgxpool.Pool
+	cache *lru.LRU[string]
+}
+
+var _ graphql.Cache[string] = &PostgresQueryStore{}
+
+func NewPostgresQueryStore(pool *pgxpool.Pool, cacheSize int) *PostgresQueryStore {
+	return &PostgresQueryStore{
+		pool:  pool,
+		cache: lru.New[string](cacheSize),
+	}
+}
+
+func (s *PostgresQueryStore) Get(ctx context.Context, hash string) (string, bool) {
+	if query, ok := s.cache.Get(ctx, hash); ok {
+		return query, true
+	}
+	var query string
+	if err := s.pool.QueryRow(ctx, persistedQueryFindQuery, hash).Scan(&query); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			composables.UseLogger(ctx).WithError(err).Error("failed to load persisted query")
+		}
+		return "", false
+	}
+	s.cache.Add(ctx, hash, query)
+	return query, true
+}
+
+// Add stores query under its hash, a failed insert only costs the client a retry with the full query
+func (s *PostgresQueryStore) Add(ctx context.Context, hash string, query string) {
+	s.cache.Add(ctx, hash, query)
+	if _, err := s.pool.Exec(ctx, persistedQueryInsertQuery, hash, query); err != nil {
+		composables.UseLogger(ctx).WithError(err).Error("failed to save persisted query")
+	}
+}
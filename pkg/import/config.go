@@ -11,6 +11,12 @@ type BaseImportPageConfig struct {
 	LocalePrefix        string
 	TemplateDownloadURL string
 	HTMXConfig          HTMXConfig
+	// PreviewURL enables the mapping and dry-run step when set
+	PreviewURL string
+	// Modes are the import modes users can choose from, the first one is the default
+	Modes []Mode
+	// KeyColumn is the index of the column identifying existing records in upsert and update-only modes
+	KeyColumn int
 }
 
 // Implement ImportPageConfig interface
@@ -24,10 +30,21 @@ func (c *BaseImportPageConfig) GetLocalePrefix() string        { return c.Locale
 func (c *BaseImportPageConfig) GetTemplateDownloadURL() string { return c.TemplateDownloadURL }
 func (c *BaseImportPageConfig) GetHTMXConfig() HTMXConfig      { return c.HTMXConfig }
 
+// Implement PreviewPageConfig interface
+func (c *BaseImportPageConfig) GetPreviewURL() string { return c.PreviewURL }
+func (c *BaseImportPageConfig) GetKeyColumn() int     { return c.KeyColumn }
+
+func (c *BaseImportPageConfig) GetModes() []Mode {
+	if len(c.Modes) == 0 {
+		return []Mode{ModeInsert}
+	}
+	return c.Modes
+}
+
 // NewBaseImportPageConfig creates a new import page configuration with sensible defaults
 func NewBaseImportPageConfig() *BaseImportPageConfig {
 	return &BaseImportPageConfig{
-		AcceptedFileTypes: "text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		AcceptedFileTypes: ".csv,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
 		HTMXConfig: HTMXConfig{
 			Target:    "#import-content",
 			Swap:      "innerHTML",
@@ -1,6 +1,8 @@
 package importpkg
 
 import (
+	"strings"
+
 	"github.com/iota-uz/go-i18n/v2/i18n"
 	"github.com/iota-uz/iota-sdk/pkg/serrors"
 )
@@ -76,3 +78,31 @@ func (e *ValidationError) Localize(l *i18n.Localizer) string {
 		},
 	})
 }
+
+// NewMissingColumnError reports columns that are required or mapped but not present in the file
+func NewMissingColumnError(columns []string) error {
+	return &MissingColumnError{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_MISSING_COLUMNS",
+			Message: "Missing columns: " + strings.Join(columns, ", "),
+		},
+		Columns: columns,
+	}
+}
+
+// MissingColumnError represents columns that could not be found in the file
+type MissingColumnError struct {
+	serrors.BaseError
+	Columns []string
+}
+
+func (e *MissingColumnError) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID: "Error.ERR_MISSING_COLUMNS",
+		},
+		TemplateData: map[string]interface{}{
+			"Columns": strings.Join(e.Columns, ", "),
+		},
+	})
+}
@@ -0,0 +1,315 @@
+package importpkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrModeNotSupported is returned when the row handler lacks what the import mode needs
+var ErrModeNotSupported = errors.New("import mode is not supported by the row handler")
+
+// Mode decides what happens to rows matching records that already exist
+type Mode string
+
+const (
+	// ModeInsert creates new records and skips rows matching existing ones
+	ModeInsert Mode = "insert"
+	// ModeUpsert creates new records and updates existing ones
+	ModeUpsert Mode = "upsert"
+	// ModeUpdateOnly updates existing records and skips rows without a match
+	ModeUpdateOnly Mode = "update_only"
+)
+
+func (m Mode) IsValid() bool {
+	return m == ModeInsert || m == ModeUpsert || m == ModeUpdateOnly
+}
+
+// Action is what an import does with a single row
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionSkip   Action = "skip"
+	ActionError  Action = "error"
+)
+
+// RecordMatcher is implemented by row handlers that can tell whether a record with the given key exists.
+// Handlers implementing it skip existing records in insert mode.
+type RecordMatcher interface {
+	RecordExists(ctx context.Context, key string) (bool, error)
+}
+
+// RowUpdater is implemented by row handlers that can update an existing record from a row.
+// Upsert and update-only modes need both RecordMatcher and RowUpdater.
+type RowUpdater interface {
+	UpdateRow(ctx context.Context, rowIndex int, row []string) error
+}
+
+// ImportOptions controls how a file is matched against the row handler
+type ImportOptions struct {
+	// Mode defaults to ModeInsert
+	Mode Mode `json:"mode,omitempty"`
+	// KeyColumn is the index of the handler column identifying existing records
+	KeyColumn int `json:"keyColumn"`
+	// Mapping assigns file columns to handler columns. Columns are taken by position when it is empty.
+	Mapping ColumnMapping `json:"mapping,omitempty"`
+	// Sheets limits the import to the named sheets. All sheets with data are imported when it is empty.
+	Sheets []string `json:"sheets,omitempty"`
+}
+
+// RowPreview describes what importing a single row will do
+type RowPreview struct {
+	Sheet    string
+	RowIndex int
+	// Values are the row cells in handler column order
+	Values []string
+	Key    string
+	Action Action
+	Error  error
+}
+
+// Preview is the outcome of a dry-run: the decision taken for every row of the file
+type Preview struct {
+	// Sheets are the names of the imported sheets
+	Sheets []string
+	// Headers are the distinct column headers found in the imported sheets, used to build a mapping
+	Headers []string
+	Rows    []RowPreview
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+}
+
+// HasErrors returns true if any row failed validation
+func (p *Preview) HasErrors() bool {
+	return p.Failed > 0
+}
+
+// Errors returns the errors of all failed rows
+func (p *Preview) Errors() *MultiValidationError {
+	result := &MultiValidationError{}
+	for _, row := range p.Rows {
+		if row.Error != nil {
+			result.Add(row.Error)
+		}
+	}
+	return result
+}
+
+func (p *Preview) add(row RowPreview) {
+	switch row.Action {
+	case ActionCreate:
+		p.Created++
+	case ActionUpdate:
+		p.Updated++
+	case ActionSkip:
+		p.Skipped++
+	case ActionError:
+		p.Failed++
+	}
+	p.Rows = append(p.Rows, row)
+}
+
+// SheetError ties a row error to the sheet it was found in when a workbook has several sheets
+type SheetError struct {
+	Sheet string
+	Err   error
+}
+
+func (e *SheetError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Sheet, e.Err.Error())
+}
+
+func (e *SheetError) Unwrap() error {
+	return e.Err
+}
+
+// Importer previews and imports CSV and Excel files with column mapping and insert, upsert or update-only modes
+type Importer struct {
+	uploadService UploadService
+	fileReader    FileReader
+}
+
+// NewImporter creates a new importer. fileReader should implement SheetReader to import every sheet of a workbook.
+func NewImporter(uploadService UploadService, fileReader FileReader) *Importer {
+	return &Importer{
+		uploadService: uploadService,
+		fileReader:    fileReader,
+	}
+}
+
+// Preview validates the file and decides per row whether it creates, updates or skips a record without writing anything
+func (i *Importer) Preview(ctx context.Context, fileID uint, handler ExcelRowHandler, opts ImportOptions) (*Preview, error) {
+	if opts.Mode == "" {
+		opts.Mode = ModeInsert
+	}
+	if !opts.Mode.IsValid() {
+		return nil, fmt.Errorf("unknown import mode %q", opts.Mode)
+	}
+	matcher, canMatch := handler.(RecordMatcher)
+	_, canUpdate := handler.(RowUpdater)
+	if opts.Mode != ModeInsert && (!canMatch || !canUpdate) {
+		return nil, fmt.Errorf("%w: %s", ErrModeNotSupported, opts.Mode)
+	}
+	columnCount := handler.ExpectedColumnCount()
+	if opts.KeyColumn < 0 || opts.KeyColumn >= columnCount {
+		return nil, fmt.Errorf("key column %d is out of range", opts.KeyColumn)
+	}
+
+	uploadedFile, err := i.uploadService.GetByID(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get uploaded file: %w", err)
+	}
+	sheets, err := readSheets(i.fileReader, uploadedFile.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	sheets = selectSheets(sheets, opts.Sheets)
+
+	preview := &Preview{}
+	// Keys created or updated by earlier rows, so duplicates within the file are not created twice
+	seen := make(map[string]bool)
+	for _, sheet := range sheets {
+		preview.Sheets = append(preview.Sheets, sheet.Name)
+		for _, h := range sheet.Header() {
+			if h = strings.TrimSpace(h); h != "" && !slices.Contains(preview.Headers, h) {
+				preview.Headers = append(preview.Headers, h)
+			}
+		}
+		wrap := func(err error) error {
+			if len(sheets) > 1 {
+				return &SheetError{Sheet: sheet.Name, Err: err}
+			}
+			return err
+		}
+
+		indices := identityIndices(columnCount)
+		if len(opts.Mapping) > 0 {
+			var missing []string
+			indices, missing = opts.Mapping.resolve(sheet.Header(), columnCount)
+			if len(missing) > 0 {
+				preview.add(RowPreview{
+					Sheet:  sheet.Name,
+					Action: ActionError,
+					Error:  wrap(NewMissingColumnError(missing)),
+				})
+				continue
+			}
+		}
+
+		for rowIndex := 1; rowIndex < len(sheet.Rows); rowIndex++ {
+			if isBlankRow(sheet.Rows[rowIndex]) {
+				continue
+			}
+			values := applyMapping(sheet.Rows[rowIndex], indices)
+			row := RowPreview{
+				Sheet:    sheet.Name,
+				RowIndex: rowIndex,
+				Values:   values,
+				Key:      strings.TrimSpace(values[opts.KeyColumn]),
+			}
+			if err := handler.ValidateRow(rowIndex, values); err != nil {
+				row.Action, row.Error = ActionError, wrap(err)
+				preview.add(row)
+				continue
+			}
+
+			exists := row.Key != "" && seen[row.Key]
+			if !exists && row.Key != "" && canMatch {
+				exists, err = matcher.RecordExists(ctx, row.Key)
+				if err != nil {
+					return nil, fmt.Errorf("failed to look up record %q: %w", row.Key, err)
+				}
+			}
+			row.Action = decideAction(opts.Mode, exists)
+			if row.Action != ActionSkip && row.Key != "" {
+				seen[row.Key] = true
+			}
+			preview.add(row)
+		}
+	}
+	return preview, nil
+}
+
+// Import previews the file and, when no row failed validation, creates and updates records as previewed.
+// Validation failures are returned as *MultiValidationError and nothing is written.
+func (i *Importer) Import(ctx context.Context, fileID uint, handler ExcelRowHandler, opts ImportOptions) (*Preview, error) {
+	preview, err := i.Preview(ctx, fileID, handler, opts)
+	if err != nil {
+		return nil, err
+	}
+	if preview.HasErrors() {
+		return preview, preview.Errors()
+	}
+
+	total := preview.Created + preview.Updated
+	processed := 0
+	for _, row := range preview.Rows {
+		switch row.Action {
+		case ActionCreate:
+			err = handler.ProcessRow(ctx, row.RowIndex, row.Values)
+		case ActionUpdate:
+			err = handler.(RowUpdater).UpdateRow(ctx, row.RowIndex, row.Values)
+		default:
+			continue
+		}
+		if err != nil {
+			return preview, fmt.Errorf("failed to process row %d: %w", row.RowIndex, err)
+		}
+		processed++
+		reportProgress(handler, processed, total)
+	}
+	return preview, nil
+}
+
+func decideAction(mode Mode, exists bool) Action {
+	switch {
+	case exists && mode == ModeInsert:
+		return ActionSkip
+	case exists:
+		return ActionUpdate
+	case mode == ModeUpdateOnly:
+		return ActionSkip
+	default:
+		return ActionCreate
+	}
+}
+
+// selectSheets keeps the named sheets, or every sheet having data rows when no names are given
+func selectSheets(sheets []Sheet, names []string) []Sheet {
+	result := make([]Sheet, 0, len(sheets))
+	for _, sheet := range sheets {
+		if len(names) > 0 {
+			if slices.Contains(names, sheet.Name) {
+				result = append(result, sheet)
+			}
+			continue
+		}
+		if len(sheet.Rows) > 1 {
+			result = append(result, sheet)
+		}
+	}
+	return result
+}
+
+func identityIndices(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
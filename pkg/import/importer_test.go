@@ -0,0 +1,242 @@
+package importpkg
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type fakeSheetReader struct {
+	sheets []Sheet
+}
+
+func (f fakeSheetReader) ReadExcelRows(filePath string) ([][]string, error) {
+	return f.sheets[0].Rows, nil
+}
+
+func (f fakeSheetReader) ReadSheets(filePath string) ([]Sheet, error) {
+	return f.sheets, nil
+}
+
+// productHandler expects rows of code and name, codes identify existing records
+type productHandler struct {
+	existing map[string]bool
+	created  [][]string
+	updated  [][]string
+}
+
+func (h *productHandler) ExpectedColumnCount() int       { return 2 }
+func (h *productHandler) GetColumnName(index int) string { return []string{"Code", "Name"}[index] }
+
+func (h *productHandler) ValidateRow(rowIndex int, row []string) error {
+	if strings.TrimSpace(row[1]) == "" {
+		return NewDefaultErrorFactory().NewInvalidCellError("B", uint(rowIndex))
+	}
+	return nil
+}
+
+func (h *productHandler) ProcessRow(ctx context.Context, rowIndex int, row []string) error {
+	h.created = append(h.created, row)
+	return nil
+}
+
+func (h *productHandler) UpdateRow(ctx context.Context, rowIndex int, row []string) error {
+	h.updated = append(h.updated, row)
+	return nil
+}
+
+func (h *productHandler) RecordExists(ctx context.Context, key string) (bool, error) {
+	return h.existing[key], nil
+}
+
+func actions(p *Preview) []Action {
+	result := make([]Action, 0, len(p.Rows))
+	for _, row := range p.Rows {
+		result = append(result, row.Action)
+	}
+	return result
+}
+
+func TestImporter_Modes(t *testing.T) {
+	reader := fakeSheetReader{sheets: []Sheet{{
+		Name: "Products",
+		Rows: [][]string{
+			{"Code", "Name"},
+			{"A1", "Existing"},
+			{"B2", "New"},
+			{"B2", "Duplicate"},
+			{"", ""},
+		},
+	}}}
+
+	tests := []struct {
+		mode     Mode
+		expected []Action
+		created  int
+		updated  int
+	}{
+		{ModeInsert, []Action{ActionSkip, ActionCreate, ActionSkip}, 1, 0},
+		{ModeUpsert, []Action{ActionUpdate, ActionCreate, ActionUpdate}, 1, 2},
+		{ModeUpdateOnly, []Action{ActionUpdate, ActionSkip, ActionSkip}, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			handler := &productHandler{existing: map[string]bool{"A1": true}}
+			importer := NewImporter(fakeUploadService{}, reader)
+
+			preview, err := importer.Preview(context.Background(), 1, handler, ImportOptions{Mode: tt.mode})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actions(preview))
+			assert.Empty(t, handler.created, "preview must not write")
+			assert.Empty(t, handler.updated, "preview must not write")
+
+			_, err = importer.Import(context.Background(), 1, handler, ImportOptions{Mode: tt.mode})
+			require.NoError(t, err)
+			assert.Len(t, handler.created, tt.created)
+			assert.Len(t, handler.updated, tt.updated)
+		})
+	}
+}
+
+func TestImporter_ModeRequiresMatcher(t *testing.T) {
+	importer := NewImporter(fakeUploadService{}, fakeReader{rows: [][]string{{"Name", "Qty"}}})
+	_, err := importer.Preview(context.Background(), 1, &progressHandler{}, ImportOptions{Mode: ModeUpsert})
+	require.ErrorIs(t, err, ErrModeNotSupported)
+}
+
+func TestImporter_ValidationErrorsPreventImport(t *testing.T) {
+	reader := fakeSheetReader{sheets: []Sheet{{
+		Rows: [][]string{
+			{"Code", "Name"},
+			{"A1", "Valid"},
+			{"B2", ""},
+		},
+	}}}
+	handler := &productHandler{}
+	importer := NewImporter(fakeUploadService{}, reader)
+
+	preview, err := importer.Import(context.Background(), 1, handler, ImportOptions{})
+	var validationErrors *MultiValidationError
+	require.ErrorAs(t, err, &validationErrors)
+	assert.Len(t, validationErrors.Errors, 1)
+	assert.Equal(t, 1, preview.Failed)
+	assert.Empty(t, handler.created)
+}
+
+func TestImporter_MappingAcrossSheets(t *testing.T) {
+	reader := fakeSheetReader{sheets: []Sheet{
+		{
+			Name: "January",
+			Rows: [][]string{
+				{"Title", "Extra", "SKU"},
+				{"Drill", "x", "A1"},
+			},
+		},
+		{
+			Name: "February",
+			Rows: [][]string{
+				{"sku", "title"},
+				{"B2", "Hammer"},
+			},
+		},
+		{
+			Name: "Notes",
+			Rows: [][]string{{"Nothing to import"}},
+		},
+	}}
+	handler := &productHandler{}
+	importer := NewImporter(fakeUploadService{}, reader)
+
+	preview, err := importer.Import(context.Background(), 1, handler, ImportOptions{
+		Mapping: ColumnMapping{"SKU", "Title"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"January", "February"}, preview.Sheets)
+	assert.Equal(t, [][]string{{"A1", "Drill"}, {"B2", "Hammer"}}, handler.created)
+
+	t.Run("missing mapped column", func(t *testing.T) {
+		preview, err := importer.Preview(context.Background(), 1, &productHandler{}, ImportOptions{
+			Mapping: ColumnMapping{"SKU", "Extra"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, preview.Failed)
+		var sheetErr *SheetError
+		require.ErrorAs(t, preview.Rows[1].Error, &sheetErr)
+		assert.Equal(t, "February", sheetErr.Sheet)
+		var missingErr *MissingColumnError
+		require.True(t, errors.As(sheetErr, &missingErr))
+		assert.Equal(t, []string{"Extra"}, missingErr.Columns)
+	})
+}
+
+func TestAutoMapColumns(t *testing.T) {
+	columns := []ImportColumn{
+		{Header: "Item Name", Required: true},
+		{Header: "Item Code", Required: true},
+		{Header: "Comment"},
+	}
+	mapping := AutoMapColumns([]string{" item  code", "ITEM NAME", "Unit"}, columns)
+	assert.Equal(t, ColumnMapping{"ITEM NAME", "item  code", ""}, mapping)
+	require.NoError(t, mapping.Validate(columns))
+
+	mapping = AutoMapColumns([]string{"Название", "Код"}, columns)
+	assert.Equal(t, ColumnMapping{"Название", "Код", ""}, mapping, "unknown headers are mapped by position")
+
+	var missingErr *MissingColumnError
+	require.ErrorAs(t, ColumnMapping{"Item Name"}.Validate(columns), &missingErr)
+	assert.Equal(t, []string{"Item Code"}, missingErr.Columns)
+}
+
+func TestCSVFileReader_ReadRows(t *testing.T) {
+	t.Run("detects semicolons and skips BOM", func(t *testing.T) {
+		rows, err := NewCSVFileReader().ReadRows(strings.NewReader("\ufeffCode;Name\nA1;\"Drill; cordless\"\n"))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"Code", "Name"}, {"A1", "Drill; cordless"}}, rows)
+	})
+
+	t.Run("explicit delimiter", func(t *testing.T) {
+		rows, err := NewCSVFileReader().WithDelimiter('|').ReadRows(strings.NewReader("a|b,c\n"))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"a", "b,c"}}, rows)
+	})
+}
+
+func TestMultiFormatFileReader_ReadSheets(t *testing.T) {
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "products.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("Code,Name\nA1,Drill\n"), 0o600))
+
+	xlsxPath := filepath.Join(dir, "products.xlsx")
+	f := excelize.NewFile()
+	require.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Code", "Name"}))
+	_, err := f.NewSheet("Second")
+	require.NoError(t, err)
+	require.NoError(t, f.SetSheetRow("Second", "A1", &[]string{"Code"}))
+	require.NoError(t, f.SaveAs(xlsxPath))
+	require.NoError(t, f.Close())
+
+	reader := NewMultiFormatFileReader()
+
+	sheets, err := reader.ReadSheets(csvPath)
+	require.NoError(t, err)
+	require.Len(t, sheets, 1)
+	assert.Equal(t, "products", sheets[0].Name)
+	assert.Equal(t, []string{"Code", "Name"}, sheets[0].Header())
+
+	sheets, err = reader.ReadSheets(xlsxPath)
+	require.NoError(t, err)
+	require.Len(t, sheets, 2)
+	assert.Equal(t, "Second", sheets[1].Name)
+
+	rows, err := reader.ReadExcelRows(xlsxPath)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Code", "Name"}}, rows)
+}
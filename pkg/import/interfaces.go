@@ -18,6 +18,14 @@ type ImportPageConfig interface {
 	GetHTMXConfig() HTMXConfig
 }
 
+// PreviewPageConfig is optionally implemented by import pages that let users map columns,
+// choose an import mode and review a dry-run before anything is written
+type PreviewPageConfig interface {
+	GetPreviewURL() string
+	GetModes() []Mode
+	GetKeyColumn() int
+}
+
 // ImportColumn represents a column in the import template
 type ImportColumn struct {
 	Header      string // Column header text
@@ -56,6 +64,12 @@ type ExcelRowHandler interface {
 	RowProcessor
 }
 
+// ProgressReporter is optionally implemented by row handlers that want to track import progress.
+// Processors call ReportProgress after every processed data row.
+type ProgressReporter interface {
+	ReportProgress(processed, total int)
+}
+
 // FileReader abstracts file reading (Dependency Inversion)
 type FileReader interface {
 	ReadExcelRows(filePath string) ([][]string, error)
@@ -0,0 +1,95 @@
+package importpkg
+
+import (
+	"strings"
+)
+
+// ColumnMapping matches file columns to the columns a row handler expects.
+// Element i holds the header of the file column feeding handler column i; an empty string leaves it unmapped.
+// Headers are matched case-insensitively, so the same mapping applies to every sheet of a workbook.
+type ColumnMapping []string
+
+// AutoMapColumns suggests a mapping by matching file headers to the headers of the import columns.
+// When no header matches, e.g. the file was filled in another language, columns are mapped by position.
+func AutoMapColumns(header []string, columns []ImportColumn) ColumnMapping {
+	mapping := make(ColumnMapping, len(columns))
+	used := make(map[int]bool, len(header))
+	for i, column := range columns {
+		want := normalizeHeader(column.Header)
+		for j, h := range header {
+			if !used[j] && want != "" && normalizeHeader(h) == want {
+				mapping[i] = strings.TrimSpace(h)
+				used[j] = true
+				break
+			}
+		}
+	}
+	if len(used) > 0 {
+		return mapping
+	}
+	for i := range mapping {
+		if i < len(header) {
+			mapping[i] = strings.TrimSpace(header[i])
+		}
+	}
+	return mapping
+}
+
+// IsMapped reports whether the handler column at the given index has a file column assigned
+func (m ColumnMapping) IsMapped(index int) bool {
+	return index < len(m) && strings.TrimSpace(m[index]) != ""
+}
+
+// Validate makes sure every required column has a file column assigned
+func (m ColumnMapping) Validate(columns []ImportColumn) error {
+	var missing []string
+	for i, column := range columns {
+		if column.Required && !m.IsMapped(i) {
+			missing = append(missing, column.Header)
+		}
+	}
+	if len(missing) > 0 {
+		return NewMissingColumnError(missing)
+	}
+	return nil
+}
+
+// resolve returns the file column index for each handler column or -1 when unmapped.
+// Headers the sheet does not have are returned as missing.
+func (m ColumnMapping) resolve(header []string, columnCount int) (indices []int, missing []string) {
+	positions := make(map[string]int, len(header))
+	for i, h := range header {
+		key := normalizeHeader(h)
+		if _, ok := positions[key]; !ok {
+			positions[key] = i
+		}
+	}
+	indices = make([]int, columnCount)
+	for i := range indices {
+		indices[i] = -1
+		if !m.IsMapped(i) {
+			continue
+		}
+		if pos, ok := positions[normalizeHeader(m[i])]; ok {
+			indices[i] = pos
+		} else {
+			missing = append(missing, m[i])
+		}
+	}
+	return indices, missing
+}
+
+// applyMapping reorders a file row into the column order of the handler
+func applyMapping(row []string, indices []int) []string {
+	result := make([]string, len(indices))
+	for i, idx := range indices {
+		if idx >= 0 && idx < len(row) {
+			result[i] = row[idx]
+		}
+	}
+	return result
+}
+
+func normalizeHeader(h string) string {
+	return strings.ToLower(strings.Join(strings.Fields(h), " "))
+}
@@ -77,10 +77,13 @@ func (p *MultiErrorProcessor) ProcessFileWithAllErrors(ctx context.Context, file
 	}
 
 	// Process all valid rows
+	processed := 0
 	for rowIndex, row := range validRows {
 		if err := handler.ProcessRow(ctx, rowIndex, row); err != nil {
 			return fmt.Errorf("failed to process row %d: %w", rowIndex, err)
 		}
+		processed++
+		reportProgress(handler, processed, len(validRows))
 	}
 
 	return nil
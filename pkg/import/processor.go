@@ -34,6 +34,7 @@ func (p *DefaultExcelProcessor) ProcessFile(ctx context.Context, fileID uint, ha
 	}
 
 	// Process each row (skip header)
+	total := max(len(rows)-1, 0)
 	for i, row := range rows {
 		if i == 0 {
 			continue // Skip header row
@@ -53,7 +54,15 @@ func (p *DefaultExcelProcessor) ProcessFile(ctx context.Context, fileID uint, ha
 		if err := handler.ProcessRow(ctx, i, row); err != nil {
 			return err
 		}
+		reportProgress(handler, i, total)
 	}
 
 	return nil
 }
+
+// reportProgress notifies the handler about progress if it implements ProgressReporter
+func reportProgress(handler ExcelRowHandler, processed, total int) {
+	if reporter, ok := handler.(ProgressReporter); ok {
+		reporter.ReportProgress(processed, total)
+	}
+}
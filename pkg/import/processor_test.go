@@ -0,0 +1,71 @@
+package importpkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUpload struct{}
+
+func (f fakeUpload) Path() string { return "file.xlsx" }
+
+type fakeUploadService struct{}
+
+func (f fakeUploadService) GetByID(ctx context.Context, id uint) (UploadFile, error) {
+	return fakeUpload{}, nil
+}
+
+type fakeReader struct {
+	rows [][]string
+}
+
+func (f fakeReader) ReadExcelRows(filePath string) ([][]string, error) {
+	return f.rows, nil
+}
+
+type progressHandler struct {
+	processed [][]string
+	progress  [][2]int
+}
+
+func (h *progressHandler) ExpectedColumnCount() int                     { return 2 }
+func (h *progressHandler) GetColumnName(index int) string               { return "" }
+func (h *progressHandler) ValidateRow(rowIndex int, row []string) error { return nil }
+
+func (h *progressHandler) ProcessRow(ctx context.Context, rowIndex int, row []string) error {
+	h.processed = append(h.processed, row)
+	return nil
+}
+
+func (h *progressHandler) ReportProgress(processed, total int) {
+	h.progress = append(h.progress, [2]int{processed, total})
+}
+
+func TestProcessorsReportProgress(t *testing.T) {
+	rows := [][]string{
+		{"Name", "Qty"},
+		{"a", "1"},
+		{"b", "2"},
+	}
+
+	t.Run("DefaultExcelProcessor", func(t *testing.T) {
+		handler := &progressHandler{}
+		processor := NewDefaultExcelProcessor(fakeUploadService{}, fakeReader{rows: rows}, NewDefaultErrorFactory())
+
+		require.NoError(t, processor.ProcessFile(context.Background(), 1, handler))
+		assert.Len(t, handler.processed, 2)
+		assert.Equal(t, [][2]int{{1, 2}, {2, 2}}, handler.progress)
+	})
+
+	t.Run("MultiErrorProcessor", func(t *testing.T) {
+		handler := &progressHandler{}
+		processor := NewMultiErrorProcessor(fakeUploadService{}, fakeReader{rows: rows})
+
+		require.NoError(t, processor.ProcessFileWithAllErrors(context.Background(), 1, handler))
+		assert.Len(t, handler.processed, 2)
+		assert.Equal(t, [][2]int{{1, 2}, {2, 2}}, handler.progress)
+	})
+}
@@ -1,11 +1,41 @@
 package importpkg
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/xuri/excelize/v2"
 )
 
+// ErrNoSheets is returned when a workbook has no sheets to read
+var ErrNoSheets = errors.New("no sheets found")
+
+// Sheet holds the rows of a single worksheet, the first row being the header
+type Sheet struct {
+	Name string
+	Rows [][]string
+}
+
+// Header returns the first row of the sheet
+func (s Sheet) Header() []string {
+	if len(s.Rows) == 0 {
+		return nil
+	}
+	return s.Rows[0]
+}
+
+// SheetReader is implemented by readers that can return every sheet of a file
+type SheetReader interface {
+	ReadSheets(filePath string) ([]Sheet, error)
+}
+
 // ExcelFileReader is the excelize-based implementation
 type ExcelFileReader struct{}
 
@@ -14,7 +44,21 @@ func NewExcelFileReader() *ExcelFileReader {
 	return &ExcelFileReader{}
 }
 
+// ReadExcelRows returns the rows of the first sheet
 func (r *ExcelFileReader) ReadExcelRows(filePath string) ([][]string, error) {
+	sheets, err := r.read(filePath, true)
+	if err != nil {
+		return nil, err
+	}
+	return sheets[0].Rows, nil
+}
+
+// ReadSheets returns the rows of every sheet in workbook order
+func (r *ExcelFileReader) ReadSheets(filePath string) ([]Sheet, error) {
+	return r.read(filePath, false)
+}
+
+func (r *ExcelFileReader) read(filePath string, firstOnly bool) ([]Sheet, error) {
 	file, err := excelize.OpenFile(filePath)
 	if err != nil {
 		return nil, err
@@ -26,10 +70,149 @@ func (r *ExcelFileReader) ReadExcelRows(filePath string) ([][]string, error) {
 		}
 	}()
 
-	sheets := file.GetSheetList()
-	if len(sheets) == 0 {
-		return nil, fmt.Errorf("no sheets found")
+	names := file.GetSheetList()
+	if len(names) == 0 {
+		return nil, ErrNoSheets
+	}
+	if firstOnly {
+		names = names[:1]
+	}
+
+	sheets := make([]Sheet, 0, len(names))
+	for _, name := range names {
+		rows, err := file.GetRows(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %q: %w", name, err)
+		}
+		sheets = append(sheets, Sheet{Name: name, Rows: rows})
+	}
+	return sheets, nil
+}
+
+// CSVFileReader reads comma, semicolon or tab separated files.
+// The delimiter is detected from the header line unless set explicitly.
+type CSVFileReader struct {
+	delimiter rune
+}
+
+// NewCSVFileReader creates a new CSV file reader detecting the delimiter
+func NewCSVFileReader() *CSVFileReader {
+	return &CSVFileReader{}
+}
+
+// WithDelimiter disables delimiter detection and uses the given one
+func (r *CSVFileReader) WithDelimiter(delimiter rune) *CSVFileReader {
+	r.delimiter = delimiter
+	return r
+}
+
+func (r *CSVFileReader) ReadExcelRows(filePath string) ([][]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return r.ReadRows(file)
+}
+
+// ReadSheets returns the file as a single sheet named after it
+func (r *CSVFileReader) ReadSheets(filePath string) ([]Sheet, error) {
+	rows, err := r.ReadExcelRows(filePath)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	return []Sheet{{Name: name, Rows: rows}}, nil
+}
+
+// ReadRows parses CSV data from the reader
+func (r *CSVFileReader) ReadRows(in io.Reader) ([][]string, error) {
+	br := bufio.NewReader(in)
+	// Skip the UTF-8 byte order mark spreadsheet applications like to add
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = br.Discard(3)
+	}
+
+	delimiter := r.delimiter
+	if delimiter == 0 {
+		line, err := br.Peek(br.Size())
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+			return nil, err
+		}
+		delimiter = detectDelimiter(line)
+	}
+
+	reader := csv.NewReader(br)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
 	}
+	return rows, nil
+}
 
-	return file.GetRows(sheets[0])
+// detectDelimiter picks the most frequent candidate delimiter in the first line
+func detectDelimiter(data []byte) rune {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data = data[:i]
+	}
+	best, bestCount := ',', 0
+	for _, candidate := range []rune{',', ';', '\t'} {
+		if count := bytes.Count(data, []byte(string(candidate))); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best
+}
+
+// MultiFormatFileReader reads CSV and Excel files choosing the reader by file extension
+type MultiFormatFileReader struct {
+	excel *ExcelFileReader
+	csv   *CSVFileReader
+}
+
+// NewMultiFormatFileReader creates a reader accepting CSV and Excel files
+func NewMultiFormatFileReader() *MultiFormatFileReader {
+	return &MultiFormatFileReader{
+		excel: NewExcelFileReader(),
+		csv:   NewCSVFileReader(),
+	}
+}
+
+func (r *MultiFormatFileReader) ReadExcelRows(filePath string) ([][]string, error) {
+	if isCSV(filePath) {
+		return r.csv.ReadExcelRows(filePath)
+	}
+	return r.excel.ReadExcelRows(filePath)
+}
+
+func (r *MultiFormatFileReader) ReadSheets(filePath string) ([]Sheet, error) {
+	if isCSV(filePath) {
+		return r.csv.ReadSheets(filePath)
+	}
+	return r.excel.ReadSheets(filePath)
+}
+
+func isCSV(filePath string) bool {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csv", ".tsv", ".txt":
+		return true
+	}
+	return false
+}
+
+// readSheets reads every sheet when the reader supports it and falls back to a single sheet otherwise
+func readSheets(reader FileReader, filePath string) ([]Sheet, error) {
+	if sr, ok := reader.(SheetReader); ok {
+		return sr.ReadSheets(filePath)
+	}
+	rows, err := reader.ReadExcelRows(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return []Sheet{{Rows: rows}}, nil
 }
@@ -23,6 +23,7 @@ type TestContext struct {
 	user    user.User
 	modules []application.Module
 	dbName  string
+	rls     bool
 }
 
 // New creates a new TestContext builder
@@ -30,6 +31,7 @@ func NewTestContext() *TestContext {
 	return &TestContext{
 		ctx:     context.Background(),
 		modules: []application.Module{},
+		rls:     true,
 	}
 }
 
@@ -54,6 +56,12 @@ func (tc *TestContext) WithDBName(tb testing.TB, name string) *TestContext {
 	return tc
 }
 
+// WithoutRLS runs the test without row level security policies
+func (tc *TestContext) WithoutRLS() *TestContext {
+	tc.rls = false
+	return tc
+}
+
 // Build creates the test context with all dependencies
 func (tc *TestContext) Build(tb testing.TB) *TestEnvironment {
 	tb.Helper()
@@ -65,7 +73,20 @@ func (tc *TestContext) Build(tb testing.TB) *TestEnvironment {
 
 	// Create test database
 	CreateDB(tc.dbName)
-	tc.pool = NewPool(DbOpts(tc.dbName))
+	dbOpts := DbOpts(tc.dbName)
+	var (
+		configure func(*pgxpool.Config)
+		rlsRole   string
+	)
+	if tc.rls {
+		role, err := prepareRLSRole(tc.ctx, dbOpts)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		rlsRole = role
+		configure = configureRLS(role)
+	}
+	tc.pool = newPool(dbOpts, configure)
 
 	// Setup application
 	app, err := SetupApplication(tc.pool, tc.modules...)
@@ -74,6 +95,12 @@ func (tc *TestContext) Build(tb testing.TB) *TestEnvironment {
 	}
 	tc.app = app
 
+	if tc.rls {
+		if err := enableRLS(tc.ctx, dbOpts, rlsRole); err != nil {
+			tb.Fatal(err)
+		}
+	}
+
 	// Create tenant
 	tenant, err := CreateTestTenant(tc.ctx, tc.pool)
 	if err != nil {
@@ -90,6 +117,9 @@ func (tc *TestContext) Build(tb testing.TB) *TestEnvironment {
 
 	// Build context with all composables
 	tc.ctx = tc.buildContext()
+	if err := composables.ApplyTenant(tc.ctx, tx); err != nil {
+		tb.Fatal(err)
+	}
 
 	// Setup cleanup
 	tb.Cleanup(func() {
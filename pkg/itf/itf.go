@@ -97,6 +97,13 @@ func WithUser(u user.User) Option {
 	}
 }
 
+// WithoutRLS disables the row level security policies that are enforced by default
+func WithoutRLS() Option {
+	return func(tc *TestContext) {
+		tc.rls = false
+	}
+}
+
 // applyOptions applies all options to the test context
 func (tc *TestContext) applyOptions(opts ...Option) *TestContext {
 	for _, opt := range opts {
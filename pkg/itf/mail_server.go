@@ -0,0 +1,153 @@
+package itf
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/backend/memory"
+	imapserver "github.com/emersion/go-imap/server"
+	"github.com/emersion/go-smtp"
+	"github.com/stretchr/testify/require"
+)
+
+// SentMail is a message accepted by the SMTP side of a MailServer
+type SentMail struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// MailServer is an in-process IMAP and SMTP server for testing email transports.
+// Messages put into the IMAP inbox with Deliver are unseen, messages sent over SMTP are kept in Sent.
+type MailServer struct {
+	IMAPAddr string
+	SMTPAddr string
+	// Credentials of the only IMAP account, SMTP accepts mail without authentication
+	Username string
+	Password string
+
+	backend *memory.Backend
+	mu      sync.Mutex
+	sent    []SentMail
+}
+
+// NewMailServer starts a mail server listening on random local ports and stops it when the test ends
+func NewMailServer(t testing.TB) *MailServer {
+	t.Helper()
+	s := &MailServer{
+		Username: "username",
+		Password: "password",
+		backend:  memory.New(),
+	}
+
+	imapListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	imapSrv := imapserver.New(s.backend)
+	imapSrv.AllowInsecureAuth = true
+	go func() { _ = imapSrv.Serve(imapListener) }()
+	s.IMAPAddr = imapListener.Addr().String()
+
+	smtpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	smtpSrv := smtp.NewServer(smtp.BackendFunc(func(*smtp.Conn) (smtp.Session, error) {
+		return &smtpSession{server: s}, nil
+	}))
+	smtpSrv.Domain = "localhost"
+	smtpSrv.AllowInsecureAuth = true
+	go func() { _ = smtpSrv.Serve(smtpListener) }()
+	s.SMTPAddr = smtpListener.Addr().String()
+
+	t.Cleanup(func() {
+		_ = imapSrv.Close()
+		_ = smtpSrv.Close()
+	})
+	return s
+}
+
+// Deliver puts a raw RFC 5322 message into the inbox as unseen
+func (s *MailServer) Deliver(t testing.TB, raw string) {
+	t.Helper()
+	require.NoError(t, s.inbox(t).CreateMessage(nil, time.Now(), bytes.NewBufferString(raw)))
+}
+
+// Unseen returns the number of inbox messages without the \Seen flag
+func (s *MailServer) Unseen(t testing.TB) int {
+	t.Helper()
+	count := 0
+	for _, msg := range s.inbox(t).Messages {
+		seen := false
+		for _, flag := range msg.Flags {
+			if flag == imap.SeenFlag {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			count++
+		}
+	}
+	return count
+}
+
+// Sent returns the messages accepted over SMTP
+func (s *MailServer) Sent() []SentMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sent := make([]SentMail, len(s.sent))
+	copy(sent, s.sent)
+	return sent
+}
+
+func (s *MailServer) inbox(t testing.TB) *memory.Mailbox {
+	t.Helper()
+	user, err := s.backend.Login(nil, s.Username, s.Password)
+	require.NoError(t, err)
+	mbox, err := user.GetMailbox("INBOX")
+	require.NoError(t, err)
+	return mbox.(*memory.Mailbox)
+}
+
+type smtpSession struct {
+	server *MailServer
+	from   string
+	to     []string
+}
+
+func (s *smtpSession) Mail(from string, _ *smtp.MailOptions) error {
+	s.from = from
+	return nil
+}
+
+func (s *smtpSession) Rcpt(to string, _ *smtp.RcptOptions) error {
+	s.to = append(s.to, to)
+	return nil
+}
+
+func (s *smtpSession) Data(r io.Reader) error {
+	if s.from == "" || len(s.to) == 0 {
+		return errors.New("missing envelope")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+	s.server.sent = append(s.server.sent, SentMail{From: s.from, To: s.to, Data: data})
+	return nil
+}
+
+func (s *smtpSession) Reset() {
+	s.from = ""
+	s.to = nil
+}
+
+func (s *smtpSession) Logout() error {
+	return nil
+}
@@ -0,0 +1,92 @@
+package itf
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/pkg/rls"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rlsRole is the role test connections switch to when the database user is a superuser,
+// as superusers bypass row level security
+const rlsRole = "itf_rls"
+
+const (
+	isSuperuserQuery = `SELECT rolsuper FROM pg_roles WHERE rolname = current_user`
+	createRoleQuery  = `
+		DO $$
+		BEGIN
+			CREATE ROLE itf_rls NOLOGIN NOSUPERUSER NOBYPASSRLS;
+		EXCEPTION WHEN duplicate_object OR unique_violation THEN
+			NULL;
+		END
+		$$`
+)
+
+// prepareRLSRole returns the role the pool has to switch to for the policies to apply,
+// or an empty string when the database user is already subject to them
+func prepareRLSRole(ctx context.Context, dbOpts string) (string, error) {
+	conn, err := pgx.Connect(ctx, dbOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	var superuser bool
+	if err := conn.QueryRow(ctx, isSuperuserQuery).Scan(&superuser); err != nil {
+		return "", err
+	}
+	if !superuser {
+		return "", nil
+	}
+	if _, err := conn.Exec(ctx, createRoleQuery); err != nil {
+		return "", fmt.Errorf("failed to create %s role: %w", rlsRole, err)
+	}
+	return rlsRole, nil
+}
+
+// enableRLS creates the tenant policies once migrations are applied and grants role access to the schema
+func enableRLS(ctx context.Context, dbOpts, role string) error {
+	conn, err := pgx.Connect(ctx, dbOpts)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	if err := rls.Enable(ctx, conn); err != nil {
+		return err
+	}
+	if role == "" {
+		return nil
+	}
+	var schema string
+	if err := conn.QueryRow(ctx, "SELECT current_schema()").Scan(&schema); err != nil {
+		return err
+	}
+	schemaIdent, roleIdent := pgx.Identifier{schema}.Sanitize(), pgx.Identifier{role}.Sanitize()
+	for _, stmt := range []string{
+		fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s", schemaIdent, roleIdent),
+		fmt.Sprintf("GRANT ALL ON ALL TABLES IN SCHEMA %s TO %s", schemaIdent, roleIdent),
+		fmt.Sprintf("GRANT ALL ON ALL SEQUENCES IN SCHEMA %s TO %s", schemaIdent, roleIdent),
+	} {
+		if _, err := conn.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configureRLS makes pool connections switch to role and scope themselves to the tenant of the acquiring context
+func configureRLS(role string) func(*pgxpool.Config) {
+	return func(config *pgxpool.Config) {
+		if role != "" {
+			config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+				_, err := conn.Exec(ctx, "SET ROLE "+pgx.Identifier{role}.Sanitize())
+				return err
+			}
+		}
+		rls.Configure(config)
+	}
+}
@@ -109,6 +109,10 @@ func (s *Suite) PUT(path string) *Request {
 	return s.newRequest(http.MethodPut, path)
 }
 
+func (s *Suite) PATCH(path string) *Request {
+	return s.newRequest(http.MethodPatch, path)
+}
+
 func (s *Suite) DELETE(path string) *Request {
 	return s.newRequest(http.MethodDelete, path)
 }
@@ -43,6 +43,10 @@ func MockSession() *session.Session {
 }
 
 func NewPool(dbOpts string) *pgxpool.Pool {
+	return newPool(dbOpts, nil)
+}
+
+func newPool(dbOpts string, configure func(*pgxpool.Config)) *pgxpool.Pool {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
@@ -56,6 +60,9 @@ func NewPool(dbOpts string) *pgxpool.Pool {
 	config.MinConns = 1
 	config.MaxConnLifetime = time.Minute * 5
 	config.MaxConnIdleTime = time.Second * 30
+	if configure != nil {
+		configure(config)
+	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
 	"github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
@@ -19,7 +22,7 @@ func getToken(r *http.Request) (string, error) {
 	conf := configuration.Use()
 	token, err := r.Cookie(conf.SidCookieKey)
 	if errors.Is(err, http.ErrNoCookie) {
-		v := r.Header.Get("Authorization")
+		v := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
 		if v == "" {
 			return "", errors.New("no token found")
 		}
@@ -45,13 +48,24 @@ func Authorize() mux.MiddlewareFunc {
 				if err != nil {
 					panic(err)
 				}
-				authService := app.Service(services.AuthService{}).(*services.AuthService)
-				sess, err := authService.Authorize(ctx, token)
+				var sess *session.Session
+				if apitoken.IsToken(token) {
+					sess, ctx, err = authorizeAPIToken(ctx, app, token)
+				} else {
+					authService := app.Service(services.AuthService{}).(*services.AuthService)
+					sess, err = authService.Authorize(lookupContext(ctx), token)
+				}
 				if err != nil {
 					next.ServeHTTP(w, r)
 					return
 				}
 
+				// Sessions are only valid at the host of their own tenant
+				if hostTenant, err := composables.UseHostTenant(ctx); err == nil && hostTenant.ID() != sess.TenantID {
+					next.ServeHTTP(w, r)
+					return
+				}
+
 				if _, err := composables.UseTenantID(ctx); err != nil {
 					ctx = composables.WithTenantID(ctx, sess.TenantID)
 				}
@@ -67,6 +81,34 @@ func Authorize() mux.MiddlewareFunc {
 	}
 }
 
+// authorizeAPIToken authorizes a request made with an API token as a session of the token's user
+// that ends with the token, the token is kept in the context to scope the user's permissions
+func authorizeAPIToken(ctx context.Context, app application.Application, secret string) (*session.Session, context.Context, error) {
+	apiTokenService := app.Service(services.APITokenService{}).(*services.APITokenService)
+	token, err := apiTokenService.Authenticate(lookupContext(ctx), secret)
+	if err != nil {
+		return nil, ctx, err
+	}
+	sess := &session.Session{
+		UserID:    token.UserID(),
+		TenantID:  token.TenantID(),
+		CreatedAt: token.CreatedAt(),
+	}
+	if token.ExpiresAt() != nil {
+		sess.ExpiresAt = *token.ExpiresAt()
+	}
+	return sess, composables.WithAPIToken(ctx, token), nil
+}
+
+// lookupContext is the context sessions and API tokens are looked up with. Without a host tenant
+// the tenant is only known from the session itself, so the lookup has to span tenants
+func lookupContext(ctx context.Context) context.Context {
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return composables.WithoutTenantIsolation(ctx)
+	}
+	return ctx
+}
+
 func ProvideUser() mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(
@@ -87,6 +129,10 @@ func ProvideUser() mux.MiddlewareFunc {
 					next.ServeHTTP(w, r)
 					return
 				}
+				// Requests made with an API token only get the permissions the token is scoped to
+				if token, err := composables.UseAPIToken(ctx); err == nil {
+					u = services.ScopeUser(u, token)
+				}
 				// Set the user in context
 				ctx = context.WithValue(ctx, constants.UserKey, u)
 
@@ -27,6 +27,10 @@ func WithTransaction() mux.MiddlewareFunc {
 					logger.WithError(err).Error("failed to rollback transaction")
 				}
 			}()
+			if err := composables.ApplyTenant(r.Context(), tx); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
 			r = r.WithContext(composables.WithTx(r.Context(), tx))
 			next.ServeHTTP(w, r)
 			if err := tx.Commit(r.Context()); err != nil {
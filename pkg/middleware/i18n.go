@@ -24,11 +24,23 @@ func useLocaleFromUser(ctx context.Context) (language.Tag, error) {
 	return tag, nil
 }
 
+func useLocaleFromHostTenant(ctx context.Context) (language.Tag, error) {
+	t, err := composables.UseHostTenant(ctx)
+	if err != nil {
+		return language.Und, err
+	}
+	return language.Parse(t.UILanguage())
+}
+
 func useLocale(r *http.Request, defaultLocale language.Tag) language.Tag {
 	tag, err := useLocaleFromUser(r.Context())
 	if err == nil {
 		return tag
 	}
+	tag, err = useLocaleFromHostTenant(r.Context())
+	if err == nil {
+		return tag
+	}
 	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
 	if err != nil {
 		return defaultLocale
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/iota-uz/iota-sdk/internal/assets"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
@@ -21,9 +22,13 @@ func ProvideDynamicLogo(app application.Application) mux.MiddlewareFunc {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			// Try to get user from context
-			user, err := composables.UseUser(ctx)
-			if err != nil {
+			// Use the tenant of the user, before login the tenant served at the request host
+			var tenantID uuid.UUID
+			if user, err := composables.UseUser(ctx); err == nil {
+				tenantID = user.TenantID()
+			} else if hostTenant, err := composables.UseHostTenant(ctx); err == nil {
+				tenantID = hostTenant.ID()
+			} else {
 				// If no user, provide default logo
 				ctx = context.WithValue(ctx, constants.LogoKey, assets.DefaultLogo())
 				next.ServeHTTP(w, r.WithContext(ctx))
@@ -31,7 +36,7 @@ func ProvideDynamicLogo(app application.Application) mux.MiddlewareFunc {
 			}
 
 			// Get tenant
-			tenant, err := tenantService.GetByID(ctx, user.TenantID())
+			tenant, err := tenantService.GetByID(ctx, tenantID)
 			if err != nil {
 				// If tenant error, provide default logo
 				ctx = context.WithValue(ctx, constants.LogoKey, assets.DefaultLogo())
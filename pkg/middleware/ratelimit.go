@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/ratelimit"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// RateLimit rejects the requests of users and API tokens that ran out of their budget in ratelimit.Use,
+// it expects ProvideUser up the chain
+func RateLimit() mux.MiddlewareFunc {
+	limits := ratelimit.Use()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ok, retryAfter := limits.Allow(r.Context())
+			if ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			err := serrors.NewRateLimitError(retryAfter)
+			msg := err.Message
+			if l, ok := intl.UseLocalizer(r.Context()); ok {
+				msg = err.Localize(l)
+			}
+			w.Header().Set("Retry-After", err.TemplateData["RetryAfter"])
+			http.Error(w, msg, http.StatusTooManyRequests)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// ResolveTenant scopes requests to the tenant whose domain matches the request host,
+// so that pages rendered before login use the tenant's users, logo and language.
+// Requests to hosts without a tenant are passed on unscoped.
+func ResolveTenant(app application.Application) mux.MiddlewareFunc {
+	tenantService := app.Service(services.TenantService{}).(*services.TenantService)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			t, err := tenantService.GetByHost(ctx, r.Host)
+			if err != nil {
+				if !errors.Is(err, persistence.ErrTenantNotFound) {
+					composables.UseLogger(ctx).WithError(err).Error("failed to resolve tenant from host")
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(composables.WithHostTenant(ctx, t)))
+		})
+	}
+}
+
+// RequireSuperAdmin lets through the super admins of the platform only, it expects ProvideUser up the chain
+func RequireSuperAdmin() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, err := composables.UseUser(r.Context())
+			if err != nil || !services.IsSuperAdmin(u) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(2, 3)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("a")
+		assert.True(t, ok, "request %d must fit in the burst", i)
+	}
+	ok, wait := l.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	ok, _ = l.Allow("b")
+	assert.True(t, ok, "keys must not share a bucket")
+
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = l.Allow("a")
+	assert.True(t, ok, "a token must be refilled after 1/rate seconds")
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(1, 1)
+	l.now = func() time.Time { return now }
+
+	l.Allow("a")
+	now = now.Add(2 * sweepInterval)
+	l.Allow("b")
+	assert.Len(t, l.buckets, 1)
+
+	ok, _ := l.Allow("a")
+	assert.True(t, ok)
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	l := NewLimiter(0, 10)
+	assert.Nil(t, l)
+	ok, _ := l.Allow("a")
+	assert.True(t, ok)
+}
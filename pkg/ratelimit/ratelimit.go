@@ -0,0 +1,45 @@
+// Package ratelimit limits the requests of signed in users and API tokens.
+// HTTP controllers and the GraphQL executors share the limits returned by Use,
+// so a user spends a single budget whichever API they call.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// Limits applies the limit of API tokens to requests made with a token and the limit of users to the other signed in requests.
+// Anonymous requests are not limited.
+type Limits struct {
+	User  *Limiter
+	Token *Limiter
+}
+
+var singleton = sync.OnceValue(func() *Limits {
+	conf := configuration.Use().RateLimit
+	return &Limits{
+		User:  NewLimiter(conf.UserRate, conf.UserBurst),
+		Token: NewLimiter(conf.TokenRate, conf.TokenBurst),
+	}
+})
+
+// Use returns the limits configured by the RATE_LIMIT_* variables
+func Use() *Limits {
+	return singleton()
+}
+
+// Allow takes a request from the budget of the token or user in ctx
+func (l *Limits) Allow(ctx context.Context) (bool, time.Duration) {
+	if token, err := composables.UseAPIToken(ctx); err == nil {
+		return l.Token.Allow(fmt.Sprintf("token:%d", token.ID()))
+	}
+	if u, err := composables.UseUser(ctx); err == nil {
+		return l.User.Allow(fmt.Sprintf("user:%d", u.ID()))
+	}
+	return true, 0
+}
@@ -0,0 +1,75 @@
+package rbac
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+)
+
+// Owner lists the users and groups a resource belongs to,
+// e.g. its creator, the user it is assigned to or the group it is shared with.
+type Owner struct {
+	UserIDs  []uint
+	GroupIDs []uuid.UUID
+}
+
+// Owned is implemented by entities that can be accessed with ModifierOwn permissions
+type Owned interface {
+	Owner() Owner
+}
+
+// IsOwnedBy reports whether u is one of the owners or belongs to one of the owning groups
+func (o Owner) IsOwnedBy(u user.User) bool {
+	if slices.Contains(o.UserIDs, u.ID()) {
+		return true
+	}
+	for _, groupID := range u.GroupIDs() {
+		if slices.Contains(o.GroupIDs, groupID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scope is the part of a resource a user can access with a permission
+type Scope int
+
+const (
+	// ScopeNone grants no access
+	ScopeNone Scope = iota
+	// ScopeOwn grants access to the rows the user owns
+	ScopeOwn
+	// ScopeAll grants access to every row
+	ScopeAll
+)
+
+// ScopeOf returns the widest scope u holds for the resource and action of perm,
+// regardless of the modifier of perm itself.
+func ScopeOf(u user.User, perm *permission.Permission) Scope {
+	scope := ScopeNone
+	held := u.Permissions()
+	for _, r := range u.Roles() {
+		held = append(held[:len(held):len(held)], r.Permissions()...)
+	}
+	for _, p := range held {
+		if p.Resource != perm.Resource || p.Action != perm.Action {
+			continue
+		}
+		switch p.Modifier {
+		case permission.ModifierAll:
+			return ScopeAll
+		case permission.ModifierOwn:
+			scope = ScopeOwn
+		}
+	}
+	return scope
+}
+
+// CanScoped reports whether u holds perm with any modifier,
+// i.e. can access at least the rows they own.
+func CanScoped(u user.User, perm *permission.Permission) bool {
+	return ScopeOf(u, perm) != ScopeNone
+}
@@ -0,0 +1,133 @@
+package rbac_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+)
+
+var (
+	readAll = &permission.Permission{
+		ID:       uuid.New(),
+		Name:     "Document.Read",
+		Resource: "document",
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	readOwn = &permission.Permission{
+		ID:       uuid.New(),
+		Name:     "Document.ReadOwn",
+		Resource: "document",
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierOwn,
+	}
+	deleteAll = &permission.Permission{
+		ID:       uuid.New(),
+		Name:     "Document.Delete",
+		Resource: "document",
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+)
+
+type document struct {
+	owner rbac.Owner
+}
+
+func (d document) Owner() rbac.Owner {
+	return d.owner
+}
+
+func newUser(id uint, opts ...user.Option) user.User {
+	opts = append([]user.Option{user.WithID(id)}, opts...)
+	return user.New("Test", "User", internet.MustParseEmail("test@example.com"), user.UILanguageEN, opts...)
+}
+
+func TestScopeOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		user user.User
+		want rbac.Scope
+	}{
+		{
+			name: "no permissions",
+			user: newUser(1),
+			want: rbac.ScopeNone,
+		},
+		{
+			name: "all modifier",
+			user: newUser(1, user.WithPermissions([]*permission.Permission{readAll})),
+			want: rbac.ScopeAll,
+		},
+		{
+			name: "own modifier",
+			user: newUser(1, user.WithPermissions([]*permission.Permission{readOwn})),
+			want: rbac.ScopeOwn,
+		},
+		{
+			name: "all wins over own",
+			user: newUser(1, user.WithPermissions([]*permission.Permission{readOwn, readAll})),
+			want: rbac.ScopeAll,
+		},
+		{
+			name: "own modifier from role",
+			user: newUser(1, user.WithRoles([]role.Role{
+				role.New("Clerk", role.WithPermissions([]*permission.Permission{readOwn})),
+			})),
+			want: rbac.ScopeOwn,
+		},
+		{
+			name: "other action",
+			user: newUser(1, user.WithPermissions([]*permission.Permission{deleteAll})),
+			want: rbac.ScopeNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, rbac.ScopeOf(tt.user, readAll))
+			assert.Equal(t, tt.want, rbac.ScopeOf(tt.user, readOwn))
+		})
+	}
+}
+
+func TestPermission_CanAccess(t *testing.T) {
+	t.Parallel()
+
+	groupID := uuid.New()
+	owned := document{owner: rbac.Owner{UserIDs: []uint{1}}}
+	shared := document{owner: rbac.Owner{GroupIDs: []uuid.UUID{groupID}}}
+	foreign := document{owner: rbac.Owner{UserIDs: []uint{2}}}
+
+	allUser := newUser(1, user.WithPermissions([]*permission.Permission{readAll}))
+	ownUser := newUser(1, user.WithPermissions([]*permission.Permission{readOwn}))
+	groupUser := newUser(3,
+		user.WithPermissions([]*permission.Permission{readOwn}),
+		user.WithGroupIDs([]uuid.UUID{groupID}),
+	)
+
+	perm := rbac.Perm(readAll)
+	assert.True(t, perm.CanAccess(allUser, foreign))
+	assert.True(t, perm.CanAccess(allUser, nil))
+
+	assert.True(t, perm.CanAccess(ownUser, owned))
+	assert.False(t, perm.CanAccess(ownUser, foreign))
+	assert.False(t, perm.CanAccess(ownUser, shared))
+	assert.False(t, perm.CanAccess(ownUser, nil))
+	assert.False(t, perm.Can(ownUser))
+
+	assert.True(t, perm.CanAccess(groupUser, shared))
+	assert.False(t, perm.CanAccess(groupUser, owned))
+
+	assert.True(t, rbac.Or(rbac.Perm(deleteAll), perm).CanAccess(ownUser, owned))
+	assert.False(t, rbac.And(rbac.Perm(deleteAll), perm).CanAccess(ownUser, owned))
+}
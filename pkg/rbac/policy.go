@@ -0,0 +1,274 @@
+package rbac
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+)
+
+// Effect is what a policy does when its condition matches
+type Effect string
+
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+func (e Effect) IsValid() bool {
+	return e == EffectAllow || e == EffectDeny
+}
+
+// Attributes are the inputs of a policy condition, grouped under "user", "resource" and "request"
+type Attributes map[string]any
+
+// Attributed is implemented by entities that expose attributes to policy conditions
+type Attributed interface {
+	Attributes() map[string]any
+}
+
+// Policy refines a permission with a condition on user, resource and request attributes.
+//
+// A request is allowed when the user holds the permission, no deny policy matches and,
+// if there are allow policies for the resource and action, at least one of them matches.
+type Policy struct {
+	Name      string
+	Resource  permission.Resource
+	Action    permission.Action
+	Effect    Effect
+	Condition *Expr
+}
+
+// PolicySource provides the policies of the current tenant
+type PolicySource interface {
+	Policies(ctx context.Context, resource permission.Resource, action permission.Action) ([]Policy, error)
+}
+
+// Reason explains a Decision
+type Reason string
+
+const (
+	ReasonAllowed       Reason = "allowed"
+	ReasonNoPermission  Reason = "no_permission"
+	ReasonNotOwner      Reason = "not_owner"
+	ReasonDenied        Reason = "denied_by_policy"
+	ReasonNoAllowPolicy Reason = "no_allow_policy"
+)
+
+// PolicyResult is the outcome of a single policy, collected for the simulator
+type PolicyResult struct {
+	Policy  Policy
+	Matched bool
+	Err     error
+}
+
+// Decision is the outcome of Evaluate
+type Decision struct {
+	Allowed bool
+	Reason  Reason
+	// Policy is the name of the policy that decided, if any
+	Policy     string
+	Attributes Attributes
+	Results    []PolicyResult
+}
+
+// AttributeProvider adds attributes to the user, e.g. the departments they belong to
+type AttributeProvider func(ctx context.Context, u user.User) (map[string]any, error)
+
+type EngineOption func(e *Engine)
+
+// WithUserAttributes registers a provider of extra user attributes
+func WithUserAttributes(provider AttributeProvider) EngineOption {
+	return func(e *Engine) {
+		e.userAttributes = append(e.userAttributes, provider)
+	}
+}
+
+// Engine evaluates permissions together with the policies of a PolicySource
+type Engine struct {
+	source         PolicySource
+	userAttributes []AttributeProvider
+}
+
+func NewEngine(source PolicySource, opts ...EngineOption) *Engine {
+	e := &Engine{source: source}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Attributes collects the attributes a condition is evaluated against
+func (e *Engine) Attributes(ctx context.Context, u user.User, resource any, request map[string]any) (Attributes, error) {
+	attrs := NewAttributes(u, resource, request)
+	userAttrs := attrs["user"].(map[string]any)
+	for _, provider := range e.userAttributes {
+		extra, err := provider(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range extra {
+			userAttrs[k] = v
+		}
+	}
+	return attrs, nil
+}
+
+// Evaluate decides whether u can apply perm to resource. resource may be nil,
+// an Owned or Attributed entity, or a plain map of attributes.
+func (e *Engine) Evaluate(
+	ctx context.Context,
+	u user.User,
+	perm *permission.Permission,
+	resource any,
+	request map[string]any,
+) (Decision, error) {
+	policies, err := e.source.Policies(ctx, perm.Resource, perm.Action)
+	if err != nil {
+		return Decision{}, err
+	}
+	attrs, err := e.Attributes(ctx, u, resource, request)
+	if err != nil {
+		return Decision{}, err
+	}
+	return EvaluatePolicies(u, perm, policies, attrs), nil
+}
+
+// EvaluatePolicies is the policy decision algorithm, deny policies override allow policies.
+// Conditions that fail to evaluate count as matching for deny and as not matching for allow policies.
+func EvaluatePolicies(u user.User, perm *permission.Permission, policies []Policy, attrs Attributes) Decision {
+	decision := Decision{Attributes: attrs}
+
+	switch ScopeOf(u, perm) {
+	case ScopeNone:
+		decision.Reason = ReasonNoPermission
+		return decision
+	case ScopeOwn:
+		resourceAttrs, _ := attrs["resource"].(map[string]any)
+		if owned, _ := resourceAttrs["owned"].(bool); !owned {
+			decision.Reason = ReasonNotOwner
+			return decision
+		}
+	case ScopeAll:
+	}
+
+	var allowPolicies int
+	var allowedBy, deniedBy string
+	for _, p := range policies {
+		if p.Resource != perm.Resource || p.Action != perm.Action {
+			continue
+		}
+		result := PolicyResult{Policy: p}
+		result.Matched, result.Err = p.Condition.Eval(attrs)
+		decision.Results = append(decision.Results, result)
+
+		switch p.Effect {
+		case EffectDeny:
+			if (result.Matched || result.Err != nil) && deniedBy == "" {
+				deniedBy = p.Name
+			}
+		case EffectAllow:
+			allowPolicies++
+			if result.Matched && result.Err == nil && allowedBy == "" {
+				allowedBy = p.Name
+			}
+		}
+	}
+
+	switch {
+	case deniedBy != "":
+		decision.Reason = ReasonDenied
+		decision.Policy = deniedBy
+	case allowPolicies > 0 && allowedBy == "":
+		decision.Reason = ReasonNoAllowPolicy
+	default:
+		decision.Allowed = true
+		decision.Reason = ReasonAllowed
+		decision.Policy = allowedBy
+	}
+	return decision
+}
+
+// NewAttributes collects the attributes of u, resource and request
+func NewAttributes(u user.User, resource any, request map[string]any) Attributes {
+	if request == nil {
+		request = map[string]any{}
+	}
+	return Attributes{
+		"user":     UserAttributes(u),
+		"resource": ResourceAttributes(u, resource),
+		"request":  request,
+	}
+}
+
+// UserAttributes exposes the user to policy conditions
+func UserAttributes(u user.User) map[string]any {
+	roles := make([]string, 0, len(u.Roles()))
+	for _, r := range u.Roles() {
+		roles = append(roles, r.Name())
+	}
+	groups := make([]string, 0, len(u.GroupIDs()))
+	for _, id := range u.GroupIDs() {
+		groups = append(groups, id.String())
+	}
+	attrs := map[string]any{
+		"id":         u.ID(),
+		"tenant_id":  u.TenantID().String(),
+		"first_name": u.FirstName(),
+		"last_name":  u.LastName(),
+		"roles":      roles,
+		"groups":     groups,
+	}
+	if u.Email() != nil {
+		attrs["email"] = u.Email().Value()
+	}
+	return attrs
+}
+
+// ResourceAttributes exposes the resource to policy conditions.
+// "owned" tells whether u owns an Owned resource.
+func ResourceAttributes(u user.User, resource any) map[string]any {
+	attrs := map[string]any{}
+	switch r := resource.(type) {
+	case nil:
+	case Attributed:
+		for k, v := range r.Attributes() {
+			attrs[k] = v
+		}
+	case map[string]any:
+		for k, v := range r {
+			attrs[k] = v
+		}
+	}
+	if owned, ok := resource.(Owned); ok {
+		owner := owned.Owner()
+		attrs["owner_ids"] = owner.UserIDs
+		attrs["owned"] = owner.IsOwnedBy(u)
+	}
+	return attrs
+}
+
+// RequestAttributes exposes the HTTP request to policy conditions
+func RequestAttributes(r *http.Request) map[string]any {
+	now := time.Now()
+	ip := r.Header.Get("X-Real-IP")
+	if ip == "" {
+		ip = strings.Split(r.Header.Get("X-Forwarded-For"), ",")[0]
+	}
+	if ip == "" {
+		ip = r.RemoteAddr
+		if i := strings.LastIndex(ip, ":"); i > 0 {
+			ip = ip[:i]
+		}
+	}
+	return map[string]any{
+		"method":  r.Method,
+		"path":    r.URL.Path,
+		"ip":      strings.TrimSpace(ip),
+		"hour":    now.Hour(),
+		"weekday": strings.ToLower(now.Weekday().String()),
+	}
+}
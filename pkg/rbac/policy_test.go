@@ -0,0 +1,176 @@
+package rbac_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+)
+
+func TestExpr_Eval(t *testing.T) {
+	t.Parallel()
+
+	attrs := rbac.Attributes{
+		"user": map[string]any{
+			"id":          uint(7),
+			"roles":       []string{"Accountant"},
+			"departments": []any{"finance", "ops"},
+		},
+		"resource": map[string]any{
+			"amount":        9_500_000.0,
+			"currency":      "UZS",
+			"department_id": "finance",
+			"approved":      false,
+		},
+		"request": map[string]any{"method": "POST", "hour": 14},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "true", want: true},
+		{expr: "resource.amount < 10_000_000", want: true},
+		{expr: "resource.amount >= 10000000", want: false},
+		{expr: "resource.currency == 'UZS' && resource.amount < 10000000", want: true},
+		{expr: `resource.currency != "UZS" || user.id == 7`, want: true},
+		{expr: "resource.department_id in user.departments", want: true},
+		{expr: "resource.department_id not in user.departments", want: false},
+		{expr: "'Accountant' in user.roles", want: true},
+		{expr: "request.method in ['GET', 'HEAD']", want: false},
+		{expr: "!resource.approved", want: true},
+		{expr: "not (request.hour >= 9 and request.hour < 18)", want: false},
+		{expr: "resource.missing == null", want: true},
+		{expr: "resource.missing > 1", want: false},
+		{expr: "user.id > -1", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			got, err := rbac.MustParseExpr(tt.expr).Eval(attrs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExpr_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, src := range []string{"", "resource.amount <", "(true", "'unterminated", "a == b c", "a in"} {
+		_, err := rbac.ParseExpr(src)
+		require.ErrorIs(t, err, rbac.ErrInvalidExpression, src)
+	}
+}
+
+func TestExpr_EvalErrors(t *testing.T) {
+	t.Parallel()
+
+	attrs := rbac.Attributes{"resource": map[string]any{"amount": 10.0, "name": "x"}}
+	for _, src := range []string{"resource.amount", "resource.amount < 'a'", "resource.name in 'x'", "!resource.amount"} {
+		_, err := rbac.MustParseExpr(src).Eval(attrs)
+		require.ErrorIs(t, err, rbac.ErrEvaluation, src)
+	}
+}
+
+type staticSource []rbac.Policy
+
+func (s staticSource) Policies(context.Context, permission.Resource, permission.Action) ([]rbac.Policy, error) {
+	return s, nil
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	underLimit := rbac.Policy{
+		Name:      "under limit",
+		Resource:  "document",
+		Action:    permission.ActionRead,
+		Effect:    rbac.EffectAllow,
+		Condition: rbac.MustParseExpr("resource.amount < 100"),
+	}
+	sameDepartment := rbac.Policy{
+		Name:      "other department",
+		Resource:  "document",
+		Action:    permission.ActionRead,
+		Effect:    rbac.EffectDeny,
+		Condition: rbac.MustParseExpr("resource.department not in user.departments"),
+	}
+	departments := rbac.WithUserAttributes(func(context.Context, user.User) (map[string]any, error) {
+		return map[string]any{"departments": []string{"finance"}}, nil
+	})
+	engine := rbac.NewEngine(staticSource{underLimit, sameDepartment}, departments)
+	ctx := context.Background()
+
+	allUser := newUser(1, user.WithPermissions([]*permission.Permission{readAll}))
+	ownUser := newUser(1, user.WithPermissions([]*permission.Permission{readOwn}))
+
+	tests := []struct {
+		name     string
+		user     user.User
+		resource any
+		allowed  bool
+		reason   rbac.Reason
+		policy   string
+	}{
+		{
+			name:     "no permission",
+			user:     newUser(1),
+			resource: map[string]any{"amount": 1, "department": "finance"},
+			reason:   rbac.ReasonNoPermission,
+		},
+		{
+			name:     "allowed by policy",
+			user:     allUser,
+			resource: map[string]any{"amount": 1, "department": "finance"},
+			allowed:  true,
+			reason:   rbac.ReasonAllowed,
+			policy:   "under limit",
+		},
+		{
+			name:     "no allow policy matches",
+			user:     allUser,
+			resource: map[string]any{"amount": 500, "department": "finance"},
+			reason:   rbac.ReasonNoAllowPolicy,
+		},
+		{
+			name:     "deny overrides allow",
+			user:     allUser,
+			resource: map[string]any{"amount": 1, "department": "sales"},
+			reason:   rbac.ReasonDenied,
+			policy:   "other department",
+		},
+		{
+			name:     "own scope requires ownership",
+			user:     ownUser,
+			resource: map[string]any{"amount": 1, "department": "finance"},
+			reason:   rbac.ReasonNotOwner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			decision, err := engine.Evaluate(ctx, tt.user, readAll, tt.resource, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, decision.Allowed)
+			assert.Equal(t, tt.reason, decision.Reason)
+			assert.Equal(t, tt.policy, decision.Policy)
+		})
+	}
+}
+
+func TestEvaluatePolicies_NoPolicies(t *testing.T) {
+	t.Parallel()
+
+	u := newUser(1, user.WithPermissions([]*permission.Permission{readOwn}))
+	owned := document{owner: rbac.Owner{UserIDs: []uint{1}}}
+
+	decision := rbac.EvaluatePolicies(u, readAll, nil, rbac.NewAttributes(u, owned, nil))
+	assert.True(t, decision.Allowed)
+	assert.Empty(t, decision.Results)
+}
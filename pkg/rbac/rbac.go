@@ -15,6 +15,9 @@ var (
 
 type Permission interface {
 	Can(u user.User) bool
+	// CanAccess is the resource-aware variant of Can. Holding the permission with
+	// ModifierOwn is enough when u owns the resource.
+	CanAccess(u user.User, resource Owned) bool
 }
 
 type rbacPermission struct {
@@ -27,6 +30,18 @@ func (p rbacPermission) Can(u user.User) bool {
 	return u.Can(p.Permission)
 }
 
+func (p rbacPermission) CanAccess(u user.User, resource Owned) bool {
+	switch ScopeOf(u, p.Permission) {
+	case ScopeAll:
+		return true
+	case ScopeOwn:
+		return resource != nil && resource.Owner().IsOwnedBy(u)
+	case ScopeNone:
+		return false
+	}
+	return false
+}
+
 type or struct {
 	permissions []Permission
 }
@@ -42,6 +57,15 @@ func (o or) Can(u user.User) bool {
 	return false
 }
 
+func (o or) CanAccess(u user.User, resource Owned) bool {
+	for _, p := range o.permissions {
+		if p.CanAccess(u, resource) {
+			return true
+		}
+	}
+	return false
+}
+
 type and struct {
 	permissions []Permission
 }
@@ -57,6 +81,15 @@ func (a and) Can(u user.User) bool {
 	return true
 }
 
+func (a and) CanAccess(u user.User, resource Owned) bool {
+	for _, p := range a.permissions {
+		if !p.CanAccess(u, resource) {
+			return false
+		}
+	}
+	return true
+}
+
 func Or(perms ...Permission) Permission {
 	return or{permissions: perms}
 }
@@ -0,0 +1,188 @@
+// Package rls manages Postgres row level security policies that act as a
+// second line of tenant isolation behind the tenant_id filters of the repositories.
+//
+// Every table of the current schema with a uuid tenant_id column gets a policy
+// that only lets through the rows of the tenant stored in the app.tenant_id
+// run-time parameter. Transactions started through composables.InTx and
+// composables.BeginTx set the parameter with SET LOCAL semantics, connections
+// acquired from a pool prepared with Configure set it for the session.
+//
+// The policies fail closed: a connection without a tenant sees no tenant rows.
+// System work that spans tenants has to ask for it explicitly, either with
+// composables.WithoutTenantIsolation (background jobs, session lookups) or with
+// connections made through BypassConfig (migrations, seeders). Both set the
+// app.rls_bypass parameter. Rows with a NULL tenant_id are shared and not
+// restricted. Superusers and roles with BYPASSRLS ignore the policies altogether.
+package rls
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PolicyName is the name of the policy created on every tenant table
+const PolicyName = "tenant_isolation"
+
+const (
+	tablesQuery = `
+		SELECT c.table_name
+		FROM information_schema.columns c
+		JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE c.table_schema = current_schema()
+		  AND c.column_name = 'tenant_id'
+		  AND c.data_type = 'uuid'
+		  AND t.table_type = 'BASE TABLE'
+		ORDER BY c.table_name`
+
+	missingQuery = `
+		SELECT c.table_name
+		FROM information_schema.columns c
+		JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		JOIN pg_class pc ON pc.relname = c.table_name AND pc.relnamespace = current_schema()::regnamespace
+		WHERE c.table_schema = current_schema()
+		  AND c.column_name = 'tenant_id'
+		  AND c.data_type = 'uuid'
+		  AND t.table_type = 'BASE TABLE'
+		  AND (
+		    NOT pc.relrowsecurity
+		    OR NOT pc.relforcerowsecurity
+		    OR NOT EXISTS (
+		      SELECT 1 FROM pg_policies p
+		      WHERE p.schemaname = c.table_schema AND p.tablename = c.table_name AND p.policyname = $1
+		    )
+		  )
+		ORDER BY c.table_name`
+
+	setSessionTenantQuery = `SELECT set_config($1, $2, false), set_config($3, $4, false)`
+)
+
+// Tables returns the tables of the current schema that have a tenant_id column
+func Tables(ctx context.Context, db repo.Tx) ([]string, error) {
+	return queryTables(ctx, db, tablesQuery)
+}
+
+// Missing returns the tenant tables that have row level security disabled or lack the tenant policy
+func Missing(ctx context.Context, db repo.Tx) ([]string, error) {
+	return queryTables(ctx, db, missingQuery, PolicyName)
+}
+
+// PolicySQL returns the statements that enable row level security on table and (re)create the tenant policy
+func PolicySQL(table string) []string {
+	ident := pgx.Identifier{table}.Sanitize()
+	condition := fmt.Sprintf(
+		"tenant_id IS NULL OR tenant_id = NULLIF(current_setting('%s', true), '')::uuid OR current_setting('%s', true) = 'on'",
+		composables.TenantSetting,
+		composables.TenantIsolationBypassSetting,
+	)
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", ident),
+		fmt.Sprintf("ALTER TABLE %s FORCE ROW LEVEL SECURITY", ident),
+		fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", PolicyName, ident),
+		fmt.Sprintf("CREATE POLICY %s ON %s USING (%s) WITH CHECK (%s)", PolicyName, ident, condition, condition),
+	}
+}
+
+// DisableSQL returns the statements that drop the tenant policy and disable row level security on table
+func DisableSQL(table string) []string {
+	ident := pgx.Identifier{table}.Sanitize()
+	return []string{
+		fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", PolicyName, ident),
+		fmt.Sprintf("ALTER TABLE %s NO FORCE ROW LEVEL SECURITY", ident),
+		fmt.Sprintf("ALTER TABLE %s DISABLE ROW LEVEL SECURITY", ident),
+	}
+}
+
+// Enable creates the tenant policy on every tenant table. It is idempotent and
+// is meant to run after migrations so that new tables are covered as well.
+func Enable(ctx context.Context, db repo.Tx) error {
+	return apply(ctx, db, PolicySQL)
+}
+
+// Disable drops the tenant policies created by Enable
+func Disable(ctx context.Context, db repo.Tx) error {
+	return apply(ctx, db, DisableSQL)
+}
+
+// Configure makes the pool set app.tenant_id on every acquired connection from the tenant in the
+// acquiring context, so that queries issued outside of a transaction are scoped as well.
+// Contexts marked by composables.WithoutTenantIsolation get app.rls_bypass instead.
+func Configure(config *pgxpool.Config) {
+	beforeAcquire := config.BeforeAcquire
+	config.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+		if beforeAcquire != nil && !beforeAcquire(ctx, conn) {
+			return false
+		}
+		tenant, bypass := "", ""
+		if tenantID, err := composables.UseTenantID(ctx); err == nil && tenantID != uuid.Nil {
+			tenant = tenantID.String()
+		} else if composables.BypassesTenantIsolation(ctx) {
+			bypass = "on"
+		}
+		// a connection that can't be scoped is destroyed rather than handed out with a stale tenant
+		_, err := conn.Exec(ctx, setSessionTenantQuery,
+			composables.TenantSetting, tenant,
+			composables.TenantIsolationBypassSetting, bypass,
+		)
+		return err == nil
+	}
+}
+
+// BypassConfig returns a copy of config whose connections get through the tenant policies.
+// It is meant for operator tools such as migrations and seeders, never for pools serving requests.
+func BypassConfig(config *pgx.ConnConfig) *pgx.ConnConfig {
+	bypass := config.Copy()
+	if bypass.RuntimeParams == nil {
+		bypass.RuntimeParams = make(map[string]string)
+	}
+	bypass.RuntimeParams[composables.TenantIsolationBypassSetting] = "on"
+	return bypass
+}
+
+// NewSystemPool connects a pool whose connections get through the tenant policies, see BypassConfig
+func NewSystemPool(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	config.ConnConfig = BypassConfig(config.ConnConfig)
+	return pgxpool.NewWithConfig(ctx, config)
+}
+
+func apply(ctx context.Context, db repo.Tx, statements func(string) []string) error {
+	tables, err := Tables(ctx, db)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		for _, stmt := range statements(table) {
+			if _, err := db.Exec(ctx, stmt); err != nil {
+				return errors.Wrapf(err, "rls: table %s", table)
+			}
+		}
+	}
+	return nil
+}
+
+func queryTables(ctx context.Context, db repo.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "rls: failed to list tenant tables")
+	}
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
@@ -2,6 +2,10 @@ package collector
 
 import (
 	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -147,7 +151,7 @@ func CompareTables(oldTable, newTable *tree.CreateTable) ([]interface{}, []inter
 			downChanges = append(downChanges, dropColumn)
 		} else {
 			// Column exists in both - compare types
-			if oldCol.Type.String() != newCol.Type.String() {
+			if oldCol.Type.SQLString() != newCol.Type.SQLString() {
 				// Column type was changed (up operation)
 				upTableName, _ := tree.NewUnresolvedObjectName(
 					1, /* number of parts */
@@ -230,7 +234,9 @@ func CompareTables(oldTable, newTable *tree.CreateTable) ([]interface{}, []inter
 	return upChanges, downChanges, nil
 }
 
-// CollectSchemaChanges compares two schemas and generates both up and down change sets
+// CollectSchemaChanges compares two schemas and generates both up and down change sets.
+// Up changes are ordered so that dependencies exist before their dependents: enums, tables,
+// functions, views and indexes are created first, then removed objects are dropped in reverse
 func CollectSchemaChanges(oldSchema, newSchema *common.Schema) (*common.ChangeSet, *common.ChangeSet, error) {
 	timestamp := time.Now().Unix()
 
@@ -244,78 +250,125 @@ func CollectSchemaChanges(oldSchema, newSchema *common.Schema) (*common.ChangeSe
 		Timestamp: timestamp,
 	}
 
-	newSchemaTables := make([]*tree.CreateTable, 0, len(newSchema.Tables))
-	for _, t := range newSchema.Tables {
-		newSchemaTables = append(newSchemaTables, t)
+	// Every up change gets its down counterpart, the down list is reversed at the end
+	add := func(up, down interface{}) {
+		upChanges.Changes = append(upChanges.Changes, up)
+		if down != nil {
+			downChanges.Changes = append(downChanges.Changes, down)
+		}
+	}
+
+	compareEnums(oldSchema, newSchema, add)
+
+	// Only the created tables need ordering, the existing ones may well reference each other in cycles
+	existingTables := make(map[string]bool, len(oldSchema.Tables)+len(oldSchema.RawTables))
+	for name := range oldSchema.Tables {
+		existingTables[name] = true
+	}
+	for name := range oldSchema.RawTables {
+		existingTables[name] = true
+	}
+	createdTables := make([]*tree.CreateTable, 0)
+	for _, name := range sortedKeys(newSchema.Tables) {
+		if !existingTables[name] {
+			createdTables = append(createdTables, newSchema.Tables[name])
+		}
 	}
-	var err error
-	newSchemaTables, err = common.SortTableDefs(newSchemaTables)
+	createdTables, err := common.SortTableDefsAfter(createdTables, existingTables)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Check for tables in new schema
-	for _, newTable := range newSchemaTables {
-		tableName := newTable.Table.Table()
-		if oldTable, exists := oldSchema.Tables[tableName]; !exists {
-			// New table was added (up operation)
-			createTable := &tree.CreateTable{
-				IfNotExists: false,
-				Table:       newTable.Table,
-				Defs:        newTable.Defs,
-			}
-			upChanges.Changes = append(upChanges.Changes, createTable)
+	for _, newTable := range createdTables {
+		// New table was added (up operation)
+		createTable := &tree.CreateTable{
+			IfNotExists: false,
+			Table:       newTable.Table,
+			Defs:        newTable.Defs,
+		}
+		// Corresponding down operation: drop the table
+		dropTable := &tree.DropTable{
+			Names:        tree.TableNames{newTable.Table},
+			IfExists:     true,
+			DropBehavior: tree.DropCascade,
+		}
+		add(createTable, dropTable)
+	}
 
-			// Corresponding down operation: drop the table
-			dropTable := &tree.DropTable{
-				Names:        tree.TableNames{newTable.Table},
-				IfExists:     true,
-				DropBehavior: tree.DropCascade,
-			}
-			downChanges.Changes = append(downChanges.Changes, dropTable)
-		} else {
-			// Table exists in both - compare columns
-			tableUpChanges, tableDownChanges, err := CompareTables(oldTable, newTable)
-			if err != nil {
-				return nil, nil, err
-			}
-			upChanges.Changes = append(upChanges.Changes, tableUpChanges...)
-			downChanges.Changes = append(downChanges.Changes, tableDownChanges...)
+	// Tables that exist in both - compare columns
+	for _, tableName := range sortedKeys(newSchema.Tables) {
+		if _, raw := oldSchema.RawTables[tableName]; raw {
+			log.Printf("WARNING: Table '%s' was stored as unparsed SQL before, its changes can't be diffed.", tableName)
+			continue
+		}
+		oldTable, exists := oldSchema.Tables[tableName]
+		if !exists {
+			continue
+		}
+		tableUpChanges, tableDownChanges, err := CompareTables(oldTable, newSchema.Tables[tableName])
+		if err != nil {
+			return nil, nil, err
 		}
+		upChanges.Changes = append(upChanges.Changes, tableUpChanges...)
+		downChanges.Changes = append(downChanges.Changes, tableDownChanges...)
 	}
 
-	// Check for new indexes
-	for indexName, newIndex := range newSchema.Indexes {
-		if _, exists := oldSchema.Indexes[indexName]; !exists {
-			upChanges.Changes = append(upChanges.Changes, newIndex)
+	compareRawTables(oldSchema, newSchema, add)
+	compareFunctions(oldSchema, newSchema, add)
+	compareViews(oldSchema, newSchema, add)
+	compareIndexes(oldSchema, newSchema, add)
 
-			downChanges.Changes = append(downChanges.Changes, &tree.DropIndex{
-				IndexList: tree.TableIndexNames{
-					&tree.TableIndexName{
-						Table: newIndex.Table,
-						Index: tree.UnrestrictedName(newIndex.Name),
-					},
-				},
-			})
+	// Check for removed indexes, views and functions
+	for _, name := range sortedKeys(oldSchema.Indexes) {
+		if _, exists := newSchema.Indexes[name]; !exists {
+			add(dropIndexChange(oldSchema.Indexes[name]), createIndexChange(oldSchema.Indexes[name], "DROP_INDEX"))
+		}
+	}
+	for _, name := range sortedKeys(oldSchema.Views) {
+		if _, exists := newSchema.Views[name]; !exists {
+			add(dropViewChange(oldSchema.Views[name]), createViewChange(oldSchema.Views[name], "DROP_VIEW"))
+		}
+	}
+	for _, key := range sortedKeys(oldSchema.Functions) {
+		if _, exists := newSchema.Functions[key]; !exists {
+			add(dropFunctionChange(oldSchema.Functions[key]), createFunctionChange(oldSchema.Functions[key], "DROP_FUNCTION"))
 		}
 	}
 
 	// Check for removed tables
-	for tableName, oldTable := range oldSchema.Tables {
-		if _, exists := newSchema.Tables[tableName]; !exists {
-			upChanges.Changes = append(upChanges.Changes, &tree.DropTable{
+	for _, tableName := range sortedKeys(oldSchema.Tables) {
+		oldTable := oldSchema.Tables[tableName]
+		_, exists := newSchema.Tables[tableName]
+		_, raw := newSchema.RawTables[tableName]
+		if !exists && !raw {
+			add(&tree.DropTable{
 				Names:        tree.TableNames{oldTable.Table},
 				IfExists:     true,
 				DropBehavior: tree.DropCascade,
-			})
-
-			downChanges.Changes = append(downChanges.Changes, &tree.CreateTable{
+			}, &tree.CreateTable{
 				IfNotExists: false,
 				Table:       oldTable.Table,
 				Defs:        oldTable.Defs,
 			})
 		}
 	}
+	for _, name := range sortedKeys(oldSchema.RawTables) {
+		_, exists := newSchema.Tables[name]
+		_, raw := newSchema.RawTables[name]
+		if !exists && !raw {
+			add(
+				&common.RawChange{Kind: "DROP_TABLE", Object: name, SQL: fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", name)},
+				&common.RawChange{Kind: "DROP_TABLE", Object: name, SQL: oldSchema.RawTables[name]},
+			)
+		}
+	}
+
+	// Enums go last, tables that used them are gone by now
+	for _, name := range sortedKeys(oldSchema.Enums) {
+		if _, exists := newSchema.Enums[name]; !exists {
+			add(dropEnumChange(oldSchema.Enums[name]), createEnumChange(oldSchema.Enums[name], "DROP_TYPE"))
+		}
+	}
 
 	// Ensure the down migrations are in the reverse order of the up migrations
 	reversedDownChanges := make([]interface{}, len(downChanges.Changes))
@@ -324,9 +377,229 @@ func CollectSchemaChanges(oldSchema, newSchema *common.Schema) (*common.ChangeSe
 	}
 	downChanges.Changes = reversedDownChanges
 
+	upChanges.Destructive = ClassifyDestructive(oldSchema, upChanges.Changes)
+
 	return upChanges, downChanges, nil
 }
 
+// compareEnums creates new enums and extends existing ones. Postgres can only add enum values,
+// so removed or reordered values recreate the type, which is destructive
+func compareEnums(oldSchema, newSchema *common.Schema, add func(up, down interface{})) {
+	for _, name := range sortedKeys(newSchema.Enums) {
+		newEnum := newSchema.Enums[name]
+		oldEnum, exists := oldSchema.Enums[name]
+		if !exists {
+			add(createEnumChange(newEnum, "CREATE_TYPE"), dropEnumChange(newEnum))
+			continue
+		}
+		if slices.Equal(oldEnum.Values, newEnum.Values) {
+			continue
+		}
+		if !extendsEnum(oldEnum.Values, newEnum.Values) {
+			add(dropEnumChange(oldEnum), createEnumChange(oldEnum, "DROP_TYPE"))
+			add(createEnumChange(newEnum, "CREATE_TYPE"), dropEnumChange(newEnum))
+			continue
+		}
+		// Postgres can't remove enum values, so added values stay when the migration is rolled back
+		for i, value := range newEnum.Values {
+			if slices.Contains(oldEnum.Values, value) {
+				continue
+			}
+			position := ""
+			switch {
+			case i > 0:
+				position = " AFTER " + quoteLiteral(newEnum.Values[i-1])
+			case len(oldEnum.Values) > 0:
+				position = " BEFORE " + quoteLiteral(oldEnum.Values[0])
+			}
+			add(&common.RawChange{
+				Kind:   "ALTER_TYPE",
+				Object: name,
+				SQL:    fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s%s", name, quoteLiteral(value), position),
+			}, nil)
+		}
+	}
+}
+
+// extendsEnum reports whether the new values only add labels to the old ones, keeping their order
+func extendsEnum(oldValues, newValues []string) bool {
+	next := 0
+	for _, value := range newValues {
+		if next < len(oldValues) && value == oldValues[next] {
+			next++
+		} else if slices.Contains(oldValues, value) {
+			return false
+		}
+	}
+	return next == len(oldValues)
+}
+
+// compareRawTables creates the unparsed tables of the new schema. Existing ones can't be diffed,
+// a changed definition is reported so the migration can be written by hand
+func compareRawTables(oldSchema, newSchema *common.Schema, add func(up, down interface{})) {
+	for _, name := range sortedKeys(newSchema.RawTables) {
+		newSQL := newSchema.RawTables[name]
+		if oldSQL, exists := oldSchema.RawTables[name]; exists {
+			if definitionOf(oldSQL) != definitionOf(newSQL) {
+				log.Printf("WARNING: Table '%s' changed but can't be parsed, write its migration by hand.", name)
+			}
+			continue
+		}
+		if _, exists := oldSchema.Tables[name]; exists {
+			log.Printf("WARNING: Table '%s' can't be parsed anymore, its changes can't be diffed.", name)
+			continue
+		}
+		add(
+			&common.RawChange{Kind: "CREATE_TABLE", Object: name, SQL: newSQL},
+			&common.RawChange{Kind: "CREATE_TABLE", Object: name, SQL: fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", name)},
+		)
+	}
+}
+
+// compareFunctions creates new functions and replaces changed ones in place,
+// so triggers and views depending on them are kept
+func compareFunctions(oldSchema, newSchema *common.Schema, add func(up, down interface{})) {
+	for _, key := range sortedKeys(newSchema.Functions) {
+		newFn := newSchema.Functions[key]
+		oldFn, exists := oldSchema.Functions[key]
+		switch {
+		case !exists:
+			add(createFunctionChange(newFn, "CREATE_FUNCTION"), dropFunctionChange(newFn))
+		case definitionOf(oldFn.SQL) != definitionOf(newFn.SQL):
+			add(createFunctionChange(newFn, "REPLACE_FUNCTION"), createFunctionChange(oldFn, "REPLACE_FUNCTION"))
+		}
+	}
+}
+
+// compareViews creates new views and recreates changed ones, since CREATE OR REPLACE VIEW
+// can't drop or retype columns
+func compareViews(oldSchema, newSchema *common.Schema, add func(up, down interface{})) {
+	for _, name := range sortedKeys(newSchema.Views) {
+		newView := newSchema.Views[name]
+		oldView, exists := oldSchema.Views[name]
+		if exists && definitionOf(oldView.SQL) == definitionOf(newView.SQL) {
+			continue
+		}
+		if exists {
+			add(dropViewChange(oldView), createViewChange(oldView, "DROP_VIEW"))
+		}
+		add(createViewChange(newView, "CREATE_VIEW"), dropViewChange(newView))
+	}
+}
+
+// compareIndexes creates new indexes and recreates changed ones
+func compareIndexes(oldSchema, newSchema *common.Schema, add func(up, down interface{})) {
+	for _, name := range sortedKeys(newSchema.Indexes) {
+		newIndex := newSchema.Indexes[name]
+		oldIndex, exists := oldSchema.Indexes[name]
+		if exists && definitionOf(oldIndex.SQL) == definitionOf(newIndex.SQL) {
+			continue
+		}
+		if exists {
+			add(dropIndexChange(oldIndex), createIndexChange(oldIndex, "DROP_INDEX"))
+		}
+		add(createIndexChange(newIndex, "CREATE_INDEX"), dropIndexChange(newIndex))
+	}
+}
+
+func createEnumChange(enum *common.Enum, kind string) *common.RawChange {
+	values := make([]string, 0, len(enum.Values))
+	for _, value := range enum.Values {
+		values = append(values, quoteLiteral(value))
+	}
+	return &common.RawChange{
+		Kind:   kind,
+		Object: enum.Name,
+		SQL:    fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", enum.Name, strings.Join(values, ", ")),
+	}
+}
+
+func dropEnumChange(enum *common.Enum) *common.RawChange {
+	return &common.RawChange{Kind: "DROP_TYPE", Object: enum.Name, SQL: fmt.Sprintf("DROP TYPE IF EXISTS %s", enum.Name)}
+}
+
+func createIndexChange(index *common.Index, kind string) *common.RawChange {
+	return &common.RawChange{Kind: kind, Object: index.Name, SQL: index.SQL}
+}
+
+func dropIndexChange(index *common.Index) *common.RawChange {
+	return &common.RawChange{Kind: "DROP_INDEX", Object: index.Name, SQL: fmt.Sprintf("DROP INDEX IF EXISTS %s", index.Name)}
+}
+
+func createViewChange(view *common.View, kind string) *common.RawChange {
+	return &common.RawChange{Kind: kind, Object: view.Name, SQL: view.SQL}
+}
+
+func dropViewChange(view *common.View) *common.RawChange {
+	keyword := "VIEW"
+	if view.Materialized {
+		keyword = "MATERIALIZED VIEW"
+	}
+	return &common.RawChange{Kind: "DROP_VIEW", Object: view.Name, SQL: fmt.Sprintf("DROP %s IF EXISTS %s", keyword, view.Name)}
+}
+
+var createFunctionPrefixRe = regexp.MustCompile(`(?is)^create\s+(?:or\s+replace\s+)?function`)
+
+// createFunctionChange always renders CREATE OR REPLACE, so the same statement serves
+// creating, replacing and restoring a function
+func createFunctionChange(fn *common.Function, kind string) *common.RawChange {
+	return &common.RawChange{
+		Kind:   kind,
+		Object: fn.Name,
+		SQL:    createFunctionPrefixRe.ReplaceAllString(fn.SQL, "CREATE OR REPLACE FUNCTION"),
+	}
+}
+
+var argDefaultRe = regexp.MustCompile(`(?is)\s*(?:\bdefault\b|=).*$`)
+
+// dropFunctionChange addresses the overload by its arguments without their defaults,
+// which DROP FUNCTION doesn't accept
+func dropFunctionChange(fn *common.Function) *common.RawChange {
+	args := make([]string, 0)
+	for _, arg := range splitArgs(fn.Args) {
+		if arg = strings.TrimSpace(argDefaultRe.ReplaceAllString(arg, "")); arg != "" {
+			args = append(args, arg)
+		}
+	}
+	return &common.RawChange{
+		Kind:   "DROP_FUNCTION",
+		Object: fn.Name,
+		SQL:    fmt.Sprintf("DROP FUNCTION IF EXISTS %s(%s)", fn.Name, strings.Join(args, ", ")),
+	}
+}
+
+// splitArgs splits an argument list on the commas outside of parentheses
+func splitArgs(args string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, c := range args {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, args[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, args[start:])
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 type constraintInfo struct {
 	Name string
 	Def  tree.ConstraintTableDef
@@ -505,3 +505,92 @@ func TestCompareTables_UniqueConstraintChange(t *testing.T) {
 	assert.Equal(t, "passports_passport_number_series_key", downDropConstraint1.Constraint.String(), "First down constraint name should be 'passports_passport_number_series_key'")
 	assert.True(t, downDropConstraint1.IfExists, "IfExists should be true")
 }
+
+func TestCollectSchemaChanges_Indexes(t *testing.T) {
+	oldSchema := common.NewSchema()
+	oldSchema.Indexes["users_email_idx"] = &common.Index{Name: "users_email_idx", Table: "users", SQL: "CREATE INDEX users_email_idx ON users (email)"}
+	oldSchema.Indexes["users_name_idx"] = &common.Index{Name: "users_name_idx", Table: "users", SQL: "CREATE INDEX users_name_idx ON users (name)"}
+	oldSchema.Indexes["users_phone_idx"] = &common.Index{Name: "users_phone_idx", Table: "users", SQL: "CREATE INDEX users_phone_idx ON users (phone)"}
+
+	newSchema := common.NewSchema()
+	newSchema.Indexes["users_email_idx"] = &common.Index{Name: "users_email_idx", Table: "users", SQL: "CREATE INDEX IF NOT EXISTS users_email_idx ON users(email)"}
+	newSchema.Indexes["users_name_idx"] = &common.Index{Name: "users_name_idx", Table: "users", SQL: "CREATE INDEX users_name_idx ON users (name) WHERE name <> ''"}
+	newSchema.Indexes["users_tenant_idx"] = &common.Index{Name: "users_tenant_idx", Table: "users", SQL: "CREATE INDEX users_tenant_idx ON users (tenant_id)"}
+
+	upChanges, downChanges, err := CollectSchemaChanges(oldSchema, newSchema)
+	require.NoError(t, err)
+
+	var up []string
+	for _, change := range upChanges.Changes {
+		raw, ok := change.(*common.RawChange)
+		require.True(t, ok)
+		up = append(up, raw.SQL)
+	}
+	assert.Equal(t, []string{
+		"DROP INDEX IF EXISTS users_name_idx",
+		"CREATE INDEX users_name_idx ON users (name) WHERE name <> ''",
+		"CREATE INDEX users_tenant_idx ON users (tenant_id)",
+		"DROP INDEX IF EXISTS users_phone_idx",
+	}, up)
+	assert.Len(t, downChanges.Changes, 4)
+	assert.Empty(t, upChanges.Destructive)
+}
+
+func TestCollectSchemaChanges_Enums(t *testing.T) {
+	oldSchema := common.NewSchema()
+	oldSchema.Enums["mood"] = &common.Enum{Name: "mood", Values: []string{"sad", "happy"}}
+	oldSchema.Enums["size"] = &common.Enum{Name: "size", Values: []string{"s", "m", "l"}}
+
+	newSchema := common.NewSchema()
+	newSchema.Enums["mood"] = &common.Enum{Name: "mood", Values: []string{"very sad", "sad", "ok", "happy"}}
+	newSchema.Enums["size"] = &common.Enum{Name: "size", Values: []string{"s", "l"}}
+	newSchema.Enums["color"] = &common.Enum{Name: "color", Values: []string{"red"}}
+
+	upChanges, _, err := CollectSchemaChanges(oldSchema, newSchema)
+	require.NoError(t, err)
+
+	var up []string
+	for _, change := range upChanges.Changes {
+		up = append(up, change.(*common.RawChange).SQL)
+	}
+	assert.Equal(t, []string{
+		"CREATE TYPE color AS ENUM ('red')",
+		"ALTER TYPE mood ADD VALUE IF NOT EXISTS 'very sad' BEFORE 'sad'",
+		"ALTER TYPE mood ADD VALUE IF NOT EXISTS 'ok' AFTER 'sad'",
+		"DROP TYPE IF EXISTS size",
+		"CREATE TYPE size AS ENUM ('s', 'l')",
+	}, up)
+	require.Len(t, upChanges.Destructive, 1)
+	assert.Equal(t, "size", upChanges.Destructive[0].Object)
+}
+
+func TestCollectSchemaChanges_FunctionsAndViews(t *testing.T) {
+	oldSchema := common.NewSchema()
+	oldSchema.Functions["touch()"] = &common.Function{Name: "touch", SQL: "CREATE FUNCTION touch() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql"}
+	oldSchema.Views["active_users"] = &common.View{Name: "active_users", SQL: "CREATE VIEW active_users AS SELECT id FROM users"}
+
+	newSchema := common.NewSchema()
+	newSchema.Functions["touch()"] = &common.Function{Name: "touch", SQL: "CREATE FUNCTION touch() RETURNS int AS $$ SELECT 2; $$ LANGUAGE sql"}
+	newSchema.Functions["add(a int,b int default 1)"] = &common.Function{Name: "add", Args: "a int, b int DEFAULT 1", SQL: "CREATE FUNCTION add(a int, b int DEFAULT 1) RETURNS int AS $$ SELECT a + b; $$ LANGUAGE sql"}
+
+	upChanges, downChanges, err := CollectSchemaChanges(oldSchema, newSchema)
+	require.NoError(t, err)
+
+	var up, down []string
+	for _, change := range upChanges.Changes {
+		up = append(up, change.(*common.RawChange).SQL)
+	}
+	for _, change := range downChanges.Changes {
+		down = append(down, change.(*common.RawChange).SQL)
+	}
+	assert.Equal(t, []string{
+		"CREATE OR REPLACE FUNCTION add(a int, b int DEFAULT 1) RETURNS int AS $$ SELECT a + b; $$ LANGUAGE sql",
+		"CREATE OR REPLACE FUNCTION touch() RETURNS int AS $$ SELECT 2; $$ LANGUAGE sql",
+		"DROP VIEW IF EXISTS active_users",
+	}, up)
+	assert.Equal(t, []string{
+		"CREATE VIEW active_users AS SELECT id FROM users",
+		"CREATE OR REPLACE FUNCTION touch() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql",
+		"DROP FUNCTION IF EXISTS add(a int, b int)",
+	}, down)
+}
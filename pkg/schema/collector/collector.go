@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/iota-uz/iota-sdk/pkg/schema/common"
@@ -15,9 +16,10 @@ import (
 )
 
 type Collector struct {
-	loader  *FileLoader
-	logger  *logrus.Logger
-	baseDir string
+	loader           *FileLoader
+	logger           *logrus.Logger
+	baseDir          string
+	allowDestructive bool
 }
 
 type Config struct {
@@ -25,6 +27,9 @@ type Config struct {
 	Logger         *logrus.Logger
 	LogLevel       logrus.Level
 	EmbedFSs       []*embed.FS
+	// AllowDestructive lets CollectMigrations return changes that drop data or narrow column types.
+	// Without it such changes fail the collection with ErrDestructiveChanges
+	AllowDestructive bool
 }
 
 func New(cfg Config) *Collector {
@@ -44,9 +49,10 @@ func New(cfg Config) *Collector {
 	})
 
 	return &Collector{
-		loader:  fileLoader,
-		logger:  logger,
-		baseDir: cfg.MigrationsPath,
+		loader:           fileLoader,
+		logger:           logger,
+		baseDir:          cfg.MigrationsPath,
+		allowDestructive: cfg.AllowDestructive,
 	}
 }
 
@@ -73,20 +79,41 @@ func (c *Collector) CollectMigrations(ctx context.Context) (*common.ChangeSet, *
 	c.logger.Infof("Found %d up changes and %d down changes",
 		len(upChanges.Changes), len(downChanges.Changes))
 
+	if len(upChanges.Destructive) > 0 {
+		descriptions := make([]string, 0, len(upChanges.Destructive))
+		for _, d := range upChanges.Destructive {
+			c.logger.Warnf("Destructive change on %s: %s", d.Object, d.Reason)
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s)", d.Object, d.Reason))
+		}
+		if !c.allowDestructive {
+			return nil, nil, fmt.Errorf("%w: %s", ErrDestructiveChanges, strings.Join(descriptions, ", "))
+		}
+	}
+
 	return upChanges, downChanges, nil
 }
 
+// CheckDrift compares the module schema with the live database
+func (c *Collector) CheckDrift(ctx context.Context, db Querier) ([]Drift, error) {
+	expected, err := c.loader.LoadModuleSchema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load module schema: %w", err)
+	}
+	actual, err := LoadDatabaseSchema(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database schema: %w", err)
+	}
+	drift := CompareDatabase(expected, actual)
+	c.logger.Infof("Found %d differences between the module schema and the database", len(drift))
+	return drift, nil
+}
+
 func (c *Collector) StoreMigrations(upChanges, downChanges *common.ChangeSet) error {
 	if upChanges == nil || len(upChanges.Changes) == 0 {
 		c.logger.Info("No up changes to store")
 		return nil
 	}
 
-	if downChanges == nil || len(downChanges.Changes) == 0 {
-		c.logger.Info("No down changes to store")
-		return nil
-	}
-
 	c.logger.Info("Storing migrations")
 
 	// Generate timestamp for the filename
@@ -117,6 +144,9 @@ func (c *Collector) StoreMigrations(upChanges, downChanges *common.ChangeSet) er
 
 	// Process each up change and convert to SQL
 	for i, change := range upChanges.Changes {
+		if reason, ok := upChanges.DestructiveReason(i); ok {
+			buffer.WriteString(fmt.Sprintf("-- DESTRUCTIVE: %s\n", reason))
+		}
 		switch node := change.(type) {
 		case *tree.CreateTable:
 			buffer.WriteString(fmt.Sprintf("-- Change CREATE_TABLE: %s\n", node.Table.TableName))
@@ -135,15 +165,22 @@ func (c *Collector) StoreMigrations(upChanges, downChanges *common.ChangeSet) er
 					buffer.WriteString(fmt.Sprintf("-- Change ALTER_COLUMN_TYPE: %s\n", altCmd.Column))
 					buffer.WriteString(pPrinter.Pretty(node))
 					buffer.WriteString(";\n\n")
+				case *tree.AlterTableDropColumn:
+					buffer.WriteString(fmt.Sprintf("-- Change DROP_COLUMN: %s\n", altCmd.Column))
+					buffer.WriteString(pPrinter.Pretty(node))
+					buffer.WriteString(";\n\n")
 				default:
 					buffer.WriteString(pPrinter.Pretty(node))
 					buffer.WriteString(";\n\n")
 				}
 			}
-		case *tree.CreateIndex:
-			buffer.WriteString(fmt.Sprintf("-- Change CREATE_INDEX: %s\n", node.Name))
-			buffer.WriteString(pPrinter.Pretty(node))
+		case *tree.DropTable:
+			buffer.WriteString(fmt.Sprintf("-- Change DROP_TABLE: %s\n", node.Names[0].TableName))
+			buffer.WriteString(node.String())
 			buffer.WriteString(";\n\n")
+		case *common.RawChange:
+			buffer.WriteString(fmt.Sprintf("-- Change %s: %s\n", node.Kind, node.Object))
+			writeRawStatement(buffer, node.SQL)
 		default:
 			c.logger.Warnf("Unknown up change type at index %d: %T", i, change)
 			buffer.WriteString(fmt.Sprintf("-- Unknown change type: %T\n", change))
@@ -185,11 +222,15 @@ func (c *Collector) StoreMigrations(upChanges, downChanges *common.ChangeSet) er
 					}
 				}
 
-			case *tree.DropIndex:
-				buffer.WriteString(fmt.Sprintf("-- Undo CREATE_INDEX: %s\n", node.IndexList[0]))
-				buffer.WriteString(node.String())
+			case *tree.CreateTable:
+				buffer.WriteString(fmt.Sprintf("-- Undo DROP_TABLE: %s\n", node.Table.TableName))
+				buffer.WriteString(pPrinter.Pretty(node))
 				buffer.WriteString(";\n\n")
 
+			case *common.RawChange:
+				buffer.WriteString(fmt.Sprintf("-- Undo %s: %s\n", node.Kind, node.Object))
+				writeRawStatement(buffer, node.SQL)
+
 			default:
 				c.logger.Warnf("Unknown down change type at index %d: %T", i, change)
 				buffer.WriteString(fmt.Sprintf("-- Unknown down change type: %T\n", change))
@@ -211,3 +252,17 @@ func (c *Collector) StoreMigrations(upChanges, downChanges *common.ChangeSet) er
 	c.logger.Infof("Successfully stored migrations to %s", filepath)
 	return nil
 }
+
+// writeRawStatement writes a statement kept as SQL. Statements with semicolons inside,
+// such as function bodies, are wrapped so sql-migrate doesn't split them
+func writeRawStatement(buffer *bytes.Buffer, sql string) {
+	sql = strings.TrimSuffix(strings.TrimSpace(sql), ";")
+	if strings.Contains(sql, ";") {
+		buffer.WriteString("-- +migrate StatementBegin\n")
+		buffer.WriteString(sql)
+		buffer.WriteString(";\n-- +migrate StatementEnd\n\n")
+		return
+	}
+	buffer.WriteString(sql)
+	buffer.WriteString(";\n\n")
+}
@@ -0,0 +1,120 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/iota-uz/iota-sdk/pkg/schema/common"
+	"github.com/iota-uz/psql-parser/sql/sem/tree"
+	"github.com/iota-uz/psql-parser/sql/types"
+)
+
+var ErrDestructiveChanges = errors.New("schema changes drop data or narrow column types")
+
+// lossless lists the type families a column can be converted to without losing values,
+// besides its own family
+var lossless = map[types.Family][]types.Family{
+	types.IntFamily:         {types.FloatFamily, types.DecimalFamily, types.StringFamily},
+	types.FloatFamily:       {types.DecimalFamily, types.StringFamily},
+	types.DecimalFamily:     {types.StringFamily},
+	types.DateFamily:        {types.TimestampFamily, types.TimestampTZFamily, types.StringFamily},
+	types.TimestampFamily:   {types.TimestampTZFamily, types.StringFamily},
+	types.TimestampTZFamily: {types.TimestampFamily, types.StringFamily},
+	types.BoolFamily:        {types.StringFamily},
+	types.UuidFamily:        {types.StringFamily},
+	types.StringFamily:      {types.JsonFamily},
+}
+
+// ClassifyDestructive returns the up changes that can lose data: dropped tables, columns and
+// enums, and column types that can't hold every value of the old type. Dropped indexes, views
+// and functions are not destructive, the down migration recreates them as they were
+func ClassifyDestructive(oldSchema *common.Schema, changes []interface{}) []common.DestructiveChange {
+	var result []common.DestructiveChange
+	for i, change := range changes {
+		switch c := change.(type) {
+		case *tree.DropTable:
+			for _, name := range c.Names {
+				result = append(result, common.DestructiveChange{
+					Index:  i,
+					Object: name.TableName.Normalize(),
+					Reason: "drops the table with its data",
+				})
+			}
+		case *tree.AlterTable:
+			table := c.Table.ToTableName().TableName.Normalize()
+			for _, cmd := range c.Cmds {
+				switch altCmd := cmd.(type) {
+				case *tree.AlterTableDropColumn:
+					result = append(result, common.DestructiveChange{
+						Index:  i,
+						Object: table + "." + altCmd.Column.String(),
+						Reason: "drops the column with its data",
+					})
+				case *tree.AlterTableAlterColumnType:
+					from := columnType(oldSchema, table, altCmd.Column.Normalize())
+					if from != nil && IsNarrowing(from, altCmd.ToType) {
+						result = append(result, common.DestructiveChange{
+							Index:  i,
+							Object: table + "." + altCmd.Column.String(),
+							Reason: fmt.Sprintf("narrows the type from %s to %s", from.SQLString(), altCmd.ToType.SQLString()),
+						})
+					}
+				}
+			}
+		case *common.RawChange:
+			switch c.Kind {
+			case "DROP_TABLE":
+				result = append(result, common.DestructiveChange{Index: i, Object: c.Object, Reason: "drops the table with its data"})
+			case "DROP_TYPE":
+				result = append(result, common.DestructiveChange{Index: i, Object: c.Object, Reason: "drops the enum type, columns using it must be converted first"})
+			}
+		}
+	}
+	return result
+}
+
+// IsNarrowing reports whether converting a column from one type to another can fail or lose values
+func IsNarrowing(from, to *types.T) bool {
+	if from.Family() == types.ArrayFamily || to.Family() == types.ArrayFamily {
+		if from.Family() != to.Family() {
+			return true
+		}
+		return IsNarrowing(from.ArrayContents(), to.ArrayContents())
+	}
+	if from.Family() != to.Family() {
+		if slices.Contains(lossless[from.Family()], to.Family()) {
+			// Converting to a bounded string may still truncate
+			return to.Family() == types.StringFamily && to.Width() > 0
+		}
+		return true
+	}
+
+	switch from.Family() {
+	case types.IntFamily, types.FloatFamily:
+		return to.Width() < from.Width()
+	case types.StringFamily, types.CollatedStringFamily, types.BitFamily:
+		return to.Width() > 0 && (from.Width() == 0 || to.Width() < from.Width())
+	case types.DecimalFamily:
+		if to.Precision() == 0 {
+			return false
+		}
+		if from.Precision() == 0 {
+			return true
+		}
+		return to.Scale() < from.Scale() || to.Precision()-to.Scale() < from.Precision()-from.Scale()
+	}
+	return false
+}
+
+func columnType(schema *common.Schema, table, column string) *types.T {
+	t, ok := schema.Tables[table]
+	if !ok {
+		return nil
+	}
+	idx := findColumnIndex(t.Defs, column)
+	if idx == -1 {
+		return nil
+	}
+	return t.Defs[idx].(*tree.ColumnTableDef).Type
+}
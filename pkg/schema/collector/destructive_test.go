@@ -0,0 +1,75 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/pkg/schema/common"
+	"github.com/iota-uz/psql-parser/sql/sem/tree"
+	"github.com/iota-uz/psql-parser/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNarrowing(t *testing.T) {
+	tests := []struct {
+		name string
+		from *types.T
+		to   *types.T
+		want bool
+	}{
+		{name: "longer varchar", from: types.MakeVarChar(50), to: types.MakeVarChar(255), want: false},
+		{name: "shorter varchar", from: types.MakeVarChar(255), to: types.MakeVarChar(50), want: true},
+		{name: "text to varchar", from: types.String, to: types.MakeVarChar(255), want: true},
+		{name: "varchar to text", from: types.MakeVarChar(255), to: types.String, want: false},
+		{name: "int to bigint", from: types.Int4, to: types.Int, want: false},
+		{name: "bigint to int", from: types.Int, to: types.Int4, want: true},
+		{name: "int to numeric", from: types.Int, to: types.Decimal, want: false},
+		{name: "numeric to int", from: types.Decimal, to: types.Int, want: true},
+		{name: "smaller scale", from: types.MakeDecimal(10, 4), to: types.MakeDecimal(10, 2), want: true},
+		{name: "larger precision", from: types.MakeDecimal(10, 2), to: types.MakeDecimal(12, 2), want: false},
+		{name: "date to timestamp", from: types.Date, to: types.TimestampTZ, want: false},
+		{name: "text to uuid", from: types.String, to: types.Uuid, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsNarrowing(tt.from, tt.to))
+		})
+	}
+}
+
+func TestClassifyDestructive(t *testing.T) {
+	oldSchema := common.NewSchema()
+	oldSchema.Tables["users"] = &tree.CreateTable{
+		Table: tree.MakeTableName("", "users"),
+		Defs: tree.TableDefs{
+			&tree.ColumnTableDef{Name: "name", Type: types.MakeVarChar(255)},
+			&tree.ColumnTableDef{Name: "email", Type: types.MakeVarChar(100)},
+			&tree.ColumnTableDef{Name: "age", Type: types.Int},
+		},
+	}
+	oldSchema.Tables["sessions"] = &tree.CreateTable{Table: tree.MakeTableName("", "sessions")}
+	oldSchema.Enums["mood"] = &common.Enum{Name: "mood", Values: []string{"sad", "happy"}}
+
+	newSchema := common.NewSchema()
+	newSchema.Tables["users"] = &tree.CreateTable{
+		Table: tree.MakeTableName("", "users"),
+		Defs: tree.TableDefs{
+			&tree.ColumnTableDef{Name: "name", Type: types.MakeVarChar(50)},
+			&tree.ColumnTableDef{Name: "email", Type: types.MakeVarChar(255)},
+		},
+	}
+	newSchema.Enums["mood"] = &common.Enum{Name: "mood", Values: []string{"sad", "happy", "ok"}}
+
+	upChanges, _, err := CollectSchemaChanges(oldSchema, newSchema)
+	require.NoError(t, err)
+
+	objects := make([]string, 0, len(upChanges.Destructive))
+	for _, d := range upChanges.Destructive {
+		objects = append(objects, d.Object)
+		assert.NotEmpty(t, d.Reason)
+		assert.Less(t, d.Index, len(upChanges.Changes))
+	}
+	assert.ElementsMatch(t, []string{"users.name", "users.age", "sessions"}, objects,
+		"widened columns and added enum values are not destructive")
+}
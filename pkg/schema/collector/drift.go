@@ -0,0 +1,315 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/pkg/schema/common"
+	"github.com/iota-uz/psql-parser/sql/sem/tree"
+	"github.com/iota-uz/psql-parser/sql/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq/oid"
+)
+
+var ErrSchemaDrift = errors.New("database schema differs from the module schema")
+
+// ignoredTables are managed by the migration tooling, not by module schemas
+var ignoredTables = []string{"gorp_migrations", "data_migrations"}
+
+// Querier is the part of pgx.Conn and pgxpool.Pool the drift check needs
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// DatabaseSchema is the live schema of the public namespace
+type DatabaseSchema struct {
+	// Tables maps a table to its columns and their types as format_type prints them
+	Tables map[string]map[string]string
+	// Indexes maps an index to its table. Indexes backing constraints are left out,
+	// the constraints are part of the table definitions
+	Indexes   map[string]string
+	Enums     map[string][]string
+	Views     map[string]bool
+	Functions map[string]bool
+}
+
+// Drift is a difference between the module schema and the database
+type Drift struct {
+	Object  string
+	Problem string
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s: %s", d.Object, d.Problem)
+}
+
+const (
+	columnsQuery = `SELECT c.relname, a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped`
+	indexesQuery = `SELECT i.relname, t.relname
+		FROM pg_index x
+		JOIN pg_class i ON i.oid = x.indexrelid
+		JOIN pg_class t ON t.oid = x.indrelid
+		JOIN pg_namespace n ON n.oid = i.relnamespace
+		WHERE n.nspname = 'public' AND NOT EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = x.indexrelid)`
+	enumsQuery = `SELECT t.typname, e.enumlabel
+		FROM pg_type t
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE n.nspname = 'public'
+		ORDER BY t.typname, e.enumsortorder`
+	viewsQuery = `SELECT c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'public' AND c.relkind IN ('v', 'm')`
+	functionsQuery = `SELECT p.proname
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE n.nspname = 'public' AND p.prokind = 'f'
+		AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = p.oid AND d.deptype = 'e')`
+)
+
+// LoadDatabaseSchema reads tables, indexes, enums, views and functions of the public schema.
+// Functions owned by extensions are skipped
+func LoadDatabaseSchema(ctx context.Context, db Querier) (*DatabaseSchema, error) {
+	schema := &DatabaseSchema{
+		Tables:    make(map[string]map[string]string),
+		Indexes:   make(map[string]string),
+		Enums:     make(map[string][]string),
+		Views:     make(map[string]bool),
+		Functions: make(map[string]bool),
+	}
+
+	err := queryRows(ctx, db, columnsQuery, func(rows pgx.Rows) error {
+		var table, column, typ string
+		if err := rows.Scan(&table, &column, &typ); err != nil {
+			return err
+		}
+		if schema.Tables[table] == nil {
+			schema.Tables[table] = make(map[string]string)
+		}
+		schema.Tables[table][column] = typ
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load columns: %w", err)
+	}
+
+	err = queryRows(ctx, db, indexesQuery, func(rows pgx.Rows) error {
+		var index, table string
+		if err := rows.Scan(&index, &table); err != nil {
+			return err
+		}
+		schema.Indexes[index] = table
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load indexes: %w", err)
+	}
+
+	err = queryRows(ctx, db, enumsQuery, func(rows pgx.Rows) error {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return err
+		}
+		schema.Enums[name] = append(schema.Enums[name], value)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load enums: %w", err)
+	}
+
+	err = queryRows(ctx, db, viewsQuery, func(rows pgx.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		schema.Views[name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load views: %w", err)
+	}
+
+	err = queryRows(ctx, db, functionsQuery, func(rows pgx.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		schema.Functions[name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load functions: %w", err)
+	}
+
+	return schema, nil
+}
+
+func queryRows(ctx context.Context, db Querier, query string, scan func(pgx.Rows) error) error {
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// CompareDatabase lists the differences between the expected schema and the database.
+// Tables kept as raw SQL are only checked for existence, and functions are compared by name
+func CompareDatabase(expected *common.Schema, actual *DatabaseSchema) []Drift {
+	var drift []Drift
+	report := func(object, problem string, args ...any) {
+		drift = append(drift, Drift{Object: object, Problem: fmt.Sprintf(problem, args...)})
+	}
+
+	for name, table := range expected.Tables {
+		columns, exists := actual.Tables[name]
+		if !exists {
+			report(name, "table is missing in the database")
+			continue
+		}
+		for _, def := range table.Defs {
+			col, ok := def.(*tree.ColumnTableDef)
+			if !ok {
+				continue
+			}
+			colName := col.Name.Normalize()
+			actualType, exists := columns[colName]
+			if !exists {
+				report(name+"."+colName, "column is missing in the database")
+				continue
+			}
+			if expectedType := pgTypeName(col.Type); !sameType(expectedType, actualType) {
+				report(name+"."+colName, "column is %s in the database, %s in the schema", actualType, expectedType)
+			}
+		}
+		for colName := range columns {
+			if findColumnIndex(table.Defs, colName) == -1 {
+				report(name+"."+colName, "column is not in the schema")
+			}
+		}
+	}
+	for name := range expected.RawTables {
+		if _, exists := actual.Tables[name]; !exists {
+			report(name, "table is missing in the database")
+		}
+	}
+	for name := range actual.Tables {
+		_, parsed := expected.Tables[name]
+		_, raw := expected.RawTables[name]
+		if !parsed && !raw && !slices.Contains(ignoredTables, name) {
+			report(name, "table is not in the schema")
+		}
+	}
+
+	for name, index := range expected.Indexes {
+		table, exists := actual.Indexes[name]
+		switch {
+		case !exists:
+			report(name, "index is missing in the database")
+		case table != index.Table:
+			report(name, "index is on %s in the database, on %s in the schema", table, index.Table)
+		}
+	}
+	for name := range actual.Indexes {
+		if _, exists := expected.Indexes[name]; !exists {
+			report(name, "index is not in the schema")
+		}
+	}
+
+	for name, enum := range expected.Enums {
+		values, exists := actual.Enums[name]
+		switch {
+		case !exists:
+			report(name, "enum is missing in the database")
+		case !slices.Equal(values, enum.Values):
+			report(name, "enum values are (%s) in the database, (%s) in the schema", strings.Join(values, ", "), strings.Join(enum.Values, ", "))
+		}
+	}
+	for name := range actual.Enums {
+		if _, exists := expected.Enums[name]; !exists {
+			report(name, "enum is not in the schema")
+		}
+	}
+
+	for name := range expected.Views {
+		if !actual.Views[name] {
+			report(name, "view is missing in the database")
+		}
+	}
+	for name := range actual.Views {
+		if _, exists := expected.Views[name]; !exists {
+			report(name, "view is not in the schema")
+		}
+	}
+
+	functions := make(map[string]bool, len(expected.Functions))
+	for _, fn := range expected.Functions {
+		functions[fn.Name] = true
+		if !actual.Functions[fn.Name] {
+			report(fn.Name, "function is missing in the database")
+		}
+	}
+	for name := range actual.Functions {
+		if !functions[name] {
+			report(name, "function is not in the schema")
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Object != drift[j].Object {
+			return drift[i].Object < drift[j].Object
+		}
+		return drift[i].Problem < drift[j].Problem
+	})
+	return drift
+}
+
+// pgTypeName renders a parsed column type the way format_type prints it
+func pgTypeName(t *types.T) string {
+	switch t.Family() {
+	case types.ArrayFamily:
+		return pgTypeName(t.ArrayContents()) + "[]"
+	case types.StringFamily, types.CollatedStringFamily:
+		if t.Width() > 0 && t.Oid() != oid.T_text {
+			// format_type expects the typmod, which is the length plus the header size
+			return t.SQLStandardNameWithTypmod(true, int(t.Width())+4)
+		}
+	case types.DecimalFamily:
+		if t.Precision() > 0 {
+			return fmt.Sprintf("numeric(%d,%d)", t.Precision(), t.Scale())
+		}
+	}
+	return t.SQLStandardName()
+}
+
+// sameType compares type names leniently where the parser loses information:
+// it reads INT, INTEGER and SERIAL as INT8 and JSON as JSONB
+func sameType(expected, actual string) bool {
+	if expected == actual {
+		return true
+	}
+	if strings.HasSuffix(expected, "[]") && strings.HasSuffix(actual, "[]") {
+		return sameType(strings.TrimSuffix(expected, "[]"), strings.TrimSuffix(actual, "[]"))
+	}
+	switch expected {
+	case "bigint":
+		return actual == "integer"
+	case "jsonb":
+		return actual == "json"
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/pkg/schema/common"
+	"github.com/iota-uz/psql-parser/sql/sem/tree"
+	"github.com/iota-uz/psql-parser/sql/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareDatabase(t *testing.T) {
+	expected := common.NewSchema()
+	expected.Tables["users"] = &tree.CreateTable{
+		Table: tree.MakeTableName("", "users"),
+		Defs: tree.TableDefs{
+			&tree.ColumnTableDef{Name: "id", Type: types.Int, IsSerial: true},
+			&tree.ColumnTableDef{Name: "email", Type: types.MakeVarChar(255)},
+			&tree.ColumnTableDef{Name: "balance", Type: types.MakeDecimal(10, 2)},
+			&tree.ColumnTableDef{Name: "tags", Type: types.MakeArray(types.String)},
+			&tree.ColumnTableDef{Name: "created_at", Type: types.TimestampTZ},
+		},
+	}
+	expected.RawTables["moods"] = "CREATE TABLE moods (value mood)"
+	expected.Indexes["users_email_idx"] = &common.Index{Name: "users_email_idx", Table: "users"}
+	expected.Enums["mood"] = &common.Enum{Name: "mood", Values: []string{"sad", "happy"}}
+	expected.Views["active_users"] = &common.View{Name: "active_users"}
+	expected.Functions["touch()"] = &common.Function{Name: "touch"}
+
+	actual := &DatabaseSchema{
+		Tables: map[string]map[string]string{
+			"users": {
+				"id":         "integer",
+				"email":      "character varying(100)",
+				"balance":    "numeric(10,2)",
+				"tags":       "text[]",
+				"created_at": "timestamp with time zone",
+				"legacy":     "text",
+			},
+			"moods":           {"value": "mood"},
+			"gorp_migrations": {"id": "text"},
+			"audit":           {"id": "integer"},
+		},
+		Indexes:   map[string]string{"users_email_idx": "users", "audit_id_idx": "audit"},
+		Enums:     map[string][]string{"mood": {"sad", "ok", "happy"}},
+		Views:     map[string]bool{},
+		Functions: map[string]bool{"touch": true},
+	}
+
+	assert.Equal(t, []Drift{
+		{Object: "active_users", Problem: "view is missing in the database"},
+		{Object: "audit", Problem: "table is not in the schema"},
+		{Object: "audit_id_idx", Problem: "index is not in the schema"},
+		{Object: "mood", Problem: "enum values are (sad, ok, happy) in the database, (sad, happy) in the schema"},
+		{Object: "users.email", Problem: "column is character varying(100) in the database, character varying(255) in the schema"},
+		{Object: "users.legacy", Problem: "column is not in the schema"},
+	}, CompareDatabase(expected, actual))
+}
@@ -13,7 +13,6 @@ import (
 	"strings"
 
 	"github.com/iota-uz/iota-sdk/pkg/schema/common"
-	"github.com/iota-uz/psql-parser/sql/parser"
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/sirupsen/logrus"
 )
@@ -81,13 +80,13 @@ func (l *FileLoader) LoadExistingSchema(ctx context.Context) (*common.Schema, er
 			return nil, fmt.Errorf("failed to parse migration file %s: %w", path, err)
 		}
 
-		stmts, err := parser.Parse(strings.Join(migration.Up, "\n"))
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse file %s: %w", file, err)
+		var stmts []string
+		for _, stmt := range migration.Up {
+			stmts = append(stmts, splitStatements(stmt)...)
 		}
 
 		timestamp := l.extractTimestamp(file)
-		schemaState.update(stmts, timestamp, file)
+		schemaState.apply(stmts, timestamp, file)
 		l.logger.Debugf("Updating schema state from file: %s with timestamp: %d", file, timestamp)
 	}
 
@@ -127,14 +126,8 @@ func (l *FileLoader) LoadModuleSchema(ctx context.Context) (*common.Schema, erro
 					return nil
 				}
 
-				parsed, err := parser.Parse(string(content))
-				if err != nil {
-					l.logger.Warnf("Error parsing embedded file %s: %v", path, err)
-					return nil
-				}
-
 				l.logger.Debugf("Processing file: %s", path)
-				schemaState.update(parsed, currentTimestamp, path)
+				schemaState.apply(splitStatements(string(content)), currentTimestamp, path)
 			}
 			return nil
 		})
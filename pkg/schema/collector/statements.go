@@ -0,0 +1,232 @@
+package collector
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Statements the parser can't read (partial and expression indexes, enums, functions, ...)
+// are recognized by these patterns and tracked as SQL text
+var (
+	createIndexRe    = regexp.MustCompile(`(?is)^create\s+(unique\s+)?index\s+(?:concurrently\s+)?(?:if\s+not\s+exists\s+)?([\w."]+)\s+on\s+(?:only\s+)?([\w."]+)`)
+	dropIndexRe      = regexp.MustCompile(`(?is)^drop\s+index\s+(?:concurrently\s+)?(?:if\s+exists\s+)?(.+?)(?:\s+(?:cascade|restrict))?$`)
+	createEnumRe     = regexp.MustCompile(`(?is)^create\s+type\s+([\w."]+)\s+as\s+enum\s*\((.*)\)$`)
+	alterEnumAddRe   = regexp.MustCompile(`(?is)^alter\s+type\s+([\w."]+)\s+add\s+value\s+(?:if\s+not\s+exists\s+)?'((?:[^']|'')*)'(?:\s+(before|after)\s+'((?:[^']|'')*)')?$`)
+	dropTypeRe       = regexp.MustCompile(`(?is)^drop\s+type\s+(?:if\s+exists\s+)?(.+?)(?:\s+(?:cascade|restrict))?$`)
+	createViewRe     = regexp.MustCompile(`(?is)^create\s+(?:or\s+replace\s+)?(materialized\s+)?view\s+(?:if\s+not\s+exists\s+)?([\w."]+)`)
+	dropViewRe       = regexp.MustCompile(`(?is)^drop\s+(materialized\s+)?view\s+(?:if\s+exists\s+)?(.+?)(?:\s+(?:cascade|restrict))?$`)
+	createFunctionRe = regexp.MustCompile(`(?is)^create\s+(?:or\s+replace\s+)?function\s+([\w."]+)\s*\(`)
+	dropFunctionRe   = regexp.MustCompile(`(?is)^drop\s+function\s+(?:if\s+exists\s+)?([\w."]+)\s*(?:\((.*)\))?(?:\s+(?:cascade|restrict))?$`)
+	createTableRe    = regexp.MustCompile(`(?is)^create\s+table\s+(?:if\s+not\s+exists\s+)?([\w."]+)`)
+	enumValueRe      = regexp.MustCompile(`'((?:[^']|'')*)'`)
+)
+
+// splitStatements splits SQL into statements on semicolons that are outside of quotes,
+// dollar-quoted bodies and comments. Comments outside of dollar-quoted bodies are dropped
+func splitStatements(sql string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+	)
+	flush := func() {
+		if stmt := strings.TrimSpace(current.String()); stmt != "" {
+			statements = append(statements, stmt)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			end := strings.IndexByte(sql[i:], '\n')
+			if end == -1 {
+				i = len(sql)
+			} else {
+				i += end
+			}
+			current.WriteByte('\n')
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := strings.Index(sql[i+2:], "*/")
+			if end == -1 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+			current.WriteByte(' ')
+		case c == '\'' || c == '"':
+			end := closingQuote(sql, i)
+			current.WriteString(sql[i:end])
+			i = end - 1
+		case c == '$':
+			tag := dollarTag(sql[i:])
+			if tag == "" {
+				current.WriteByte(c)
+				continue
+			}
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end == -1 {
+				current.WriteString(sql[i:])
+				i = len(sql)
+				continue
+			}
+			end += i + 2*len(tag)
+			current.WriteString(sql[i:end])
+			i = end - 1
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return statements
+}
+
+// closingQuote returns the position right after the quoted literal that starts at start.
+// Doubled quotes inside the literal are escapes
+func closingQuote(sql string, start int) int {
+	quote := sql[start]
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != quote {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(sql)
+}
+
+// dollarTag returns the $tag$ the string starts with, or an empty string
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if c == '$' {
+			return s[:i+1]
+		}
+		isLetter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+		if !isLetter && (i == 1 || c < '0' || c > '9') {
+			return ""
+		}
+	}
+	return ""
+}
+
+// normalizeSQL makes statements comparable: keywords and identifiers are lower-cased and
+// whitespace is collapsed, while string literals are kept as is
+func normalizeSQL(sql string) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'':
+			end := closingQuote(sql, i)
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteString(sql[i:end])
+			i = end - 1
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+		case c == '(' || c == ')' || c == ',':
+			space = false
+			b.WriteByte(c)
+			if i+1 < len(sql) && strings.IndexByte(" \t\n\r", sql[i+1]) != -1 {
+				for i+1 < len(sql) && strings.IndexByte(" \t\n\r", sql[i+1]) != -1 {
+					i++
+				}
+			}
+		default:
+			if space && b.Len() > 0 {
+				last := b.String()[b.Len()-1]
+				if last != '(' && last != ',' {
+					b.WriteByte(' ')
+				}
+			}
+			space = false
+			if c >= 'A' && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			b.WriteByte(c)
+		}
+	}
+	return strings.TrimSuffix(b.String(), ";")
+}
+
+// definitionOf normalizes a CREATE statement and drops the clauses that don't change the
+// resulting object, so "CREATE INDEX IF NOT EXISTS" and "CREATE INDEX" compare equal
+func definitionOf(sql string) string {
+	def := normalizeSQL(sql)
+	for _, clause := range []string{" if not exists", " or replace", " concurrently"} {
+		def = strings.Replace(def, clause, "", 1)
+	}
+	return def
+}
+
+// objectName turns an identifier as written in SQL into the name Postgres stores:
+// unquoted names are folded to lower case and the public schema is dropped
+func objectName(ident string) string {
+	ident = strings.TrimSpace(ident)
+	var name string
+	if strings.Contains(ident, `"`) {
+		name = strings.ReplaceAll(ident, `"`, "")
+	} else {
+		name = strings.ToLower(ident)
+	}
+	return strings.TrimPrefix(name, "public.")
+}
+
+// objectNames splits the comma-separated list of a DROP statement
+func objectNames(list string) []string {
+	parts := strings.Split(list, ",")
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if name := objectName(part); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// enumValues extracts the quoted labels of an enum
+func enumValues(list string) []string {
+	matches := enumValueRe.FindAllStringSubmatch(list, -1)
+	values := make([]string, 0, len(matches))
+	for _, m := range matches {
+		values = append(values, strings.ReplaceAll(m[1], "''", "'"))
+	}
+	return values
+}
+
+// functionArgs returns the argument list of a CREATE FUNCTION statement, starting right
+// after the opening parenthesis and honoring nested ones such as numeric(10,2)
+func functionArgs(sql string, open int) string {
+	depth := 1
+	for i := open; i < len(sql); i++ {
+		switch sql[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return sql[open:i]
+			}
+		}
+	}
+	return sql[open:]
+}
+
+// functionKey identifies a function overload
+func functionKey(name, args string) string {
+	return name + "(" + normalizeSQL(args) + ")"
+}
+
+// quoteLiteral renders a string as a SQL literal
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
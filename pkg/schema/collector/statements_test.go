@@ -0,0 +1,99 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "splits on semicolons and drops comments",
+			sql: `-- users
+CREATE TABLE users (id int); /* block ; comment */
+CREATE INDEX users_id_idx ON users (id);`,
+			want: []string{"CREATE TABLE users (id int)", "CREATE INDEX users_id_idx ON users (id)"},
+		},
+		{
+			name: "keeps semicolons inside quotes",
+			sql:  `INSERT INTO notes (body) VALUES ('a;b'), ('it''s;');`,
+			want: []string{`INSERT INTO notes (body) VALUES ('a;b'), ('it''s;')`},
+		},
+		{
+			name: "keeps dollar-quoted bodies whole",
+			sql: `CREATE FUNCTION touch() RETURNS trigger AS $body$
+BEGIN
+    NEW.updated_at = now(); -- stamp
+    RETURN NEW;
+END;
+$body$ LANGUAGE plpgsql;
+DROP VIEW report;`,
+			want: []string{
+				"CREATE FUNCTION touch() RETURNS trigger AS $body$\nBEGIN\n    NEW.updated_at = now(); -- stamp\n    RETURN NEW;\nEND;\n$body$ LANGUAGE plpgsql",
+				"DROP VIEW report",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitStatements(tt.sql))
+		})
+	}
+}
+
+func TestDefinitionOf(t *testing.T) {
+	assert.Equal(t,
+		definitionOf("CREATE INDEX IF NOT EXISTS tenants_domain_key ON tenants (domain) WHERE domain <> ''"),
+		definitionOf("create index tenants_domain_key on tenants(domain)\n    where domain <> ''"),
+	)
+	assert.NotEqual(t,
+		definitionOf("CREATE INDEX notes_body_idx ON notes (body) WHERE body <> 'A'"),
+		definitionOf("CREATE INDEX notes_body_idx ON notes (body) WHERE body <> 'a'"),
+	)
+}
+
+func TestSchemaState_apply(t *testing.T) {
+	state := newSchemaState()
+	state.apply(splitStatements(`
+CREATE TYPE mood AS ENUM ('sad', 'happy');
+ALTER TYPE mood ADD VALUE 'ok' BEFORE 'happy';
+CREATE TABLE tenants (id uuid PRIMARY KEY, domain varchar(255));
+CREATE TABLE people (id serial PRIMARY KEY, current_mood mood);
+CREATE UNIQUE INDEX tenants_domain_key ON tenants (domain) WHERE domain <> '';
+CREATE INDEX tenants_id_idx ON tenants (id);
+DROP INDEX tenants_id_idx;
+CREATE MATERIALIZED VIEW tenant_domains AS SELECT domain FROM tenants;
+CREATE OR REPLACE FUNCTION add(a integer, b integer DEFAULT 1) RETURNS integer AS $$ SELECT a + b; $$ LANGUAGE sql;
+CREATE FUNCTION noop() RETURNS void AS $$ $$ LANGUAGE sql;
+DROP FUNCTION noop();
+`), 1, "schema.sql")
+
+	schema := state.buildSchema()
+
+	require.Contains(t, schema.Tables, "tenants")
+	assert.Contains(t, schema.RawTables, "people", "tables with enum columns can't be parsed and are kept as SQL")
+
+	require.Contains(t, schema.Enums, "mood")
+	assert.Equal(t, []string{"sad", "ok", "happy"}, schema.Enums["mood"].Values)
+
+	require.Contains(t, schema.Indexes, "tenants_domain_key")
+	assert.True(t, schema.Indexes["tenants_domain_key"].Unique)
+	assert.Equal(t, "tenants", schema.Indexes["tenants_domain_key"].Table)
+	assert.NotContains(t, schema.Indexes, "tenants_id_idx")
+
+	require.Contains(t, schema.Views, "tenant_domains")
+	assert.True(t, schema.Views["tenant_domains"].Materialized)
+
+	require.Len(t, schema.Functions, 1)
+	for _, fn := range schema.Functions {
+		assert.Equal(t, "add", fn.Name)
+		assert.Equal(t, "a integer, b integer DEFAULT 1", fn.Args)
+	}
+}
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"log"
+	"slices"
 	"strings"
 
 	"github.com/iota-uz/iota-sdk/pkg/schema/common"
@@ -12,22 +13,49 @@ import (
 )
 
 type schemaState struct {
-	tables  map[string]*tree.CreateTable // table -> column -> state
-	indexes map[string]*indexState
-	drops   map[string]bool
-}
-
-type indexState struct {
-	node      *tree.CreateIndex
-	timestamp int64
-	lastFile  string
+	tables    map[string]*tree.CreateTable // table -> column -> state
+	rawTables map[string]string
+	indexes   map[string]*common.Index
+	enums     map[string]*common.Enum
+	views     map[string]*common.View
+	functions map[string]*common.Function
+	drops     map[string]bool
 }
 
 func newSchemaState() *schemaState {
 	return &schemaState{
-		tables:  make(map[string]*tree.CreateTable),
-		indexes: make(map[string]*indexState),
-		drops:   make(map[string]bool),
+		tables:    make(map[string]*tree.CreateTable),
+		rawTables: make(map[string]string),
+		indexes:   make(map[string]*common.Index),
+		enums:     make(map[string]*common.Enum),
+		views:     make(map[string]*common.View),
+		functions: make(map[string]*common.Function),
+		drops:     make(map[string]bool),
+	}
+}
+
+// apply updates the state statement by statement, so a statement the parser can't read
+// doesn't hide the rest of the file. Indexes, enums, views and functions are tracked as SQL,
+// tables that fail to parse are kept as raw SQL and anything else unreadable is skipped
+func (s *schemaState) apply(statements []string, timestamp int64, fileName string) {
+	for _, stmt := range statements {
+		if s.applyRaw(stmt, fileName) {
+			continue
+		}
+		parsed, err := parser.Parse(stmt)
+		if err != nil {
+			if m := createTableRe.FindStringSubmatch(stmt); m != nil {
+				name := objectName(m[1])
+				log.Printf("WARNING: Table '%s' from %s can't be parsed (%v). It is kept as SQL and won't be diffed column by column.", name, fileName, err)
+				delete(s.tables, name)
+				delete(s.drops, name)
+				s.rawTables[name] = stmt
+				continue
+			}
+			log.Printf("WARNING: Skipping statement from %s that can't be parsed: %v", fileName, err)
+			continue
+		}
+		s.update(parsed, timestamp, fileName)
 	}
 }
 
@@ -38,15 +66,16 @@ func (s *schemaState) update(stmts parser.Statements, timestamp int64, fileName
 			case *tree.CreateTable:
 				name := n.Table.TableName.Normalize()
 				s.tables[name] = n
+				delete(s.rawTables, name)
+				delete(s.drops, name)
 			case *tree.AlterTable:
 				s.applyAlterTable(n, timestamp, fileName)
-			case *tree.CreateIndex:
-				s.updateIndexState(n, timestamp, fileName)
 			case *tree.DropTable:
 				for _, name := range n.Names {
 					tableName := name.TableName.Normalize()
 					s.drops[tableName] = true
 					delete(s.tables, tableName)
+					delete(s.rawTables, tableName)
 				}
 			}
 			return true
@@ -55,6 +84,103 @@ func (s *schemaState) update(stmts parser.Statements, timestamp int64, fileName
 	_, _ = w.Walk(stmts, nil)
 }
 
+// applyRaw tracks the statements matched by the raw patterns and reports whether it did
+func (s *schemaState) applyRaw(stmt string, fileName string) bool {
+	if m := createIndexRe.FindStringSubmatch(stmt); m != nil {
+		name := objectName(m[2])
+		s.indexes[name] = &common.Index{
+			Name:   name,
+			Table:  objectName(m[3]),
+			Unique: m[1] != "",
+			SQL:    stmt,
+		}
+		return true
+	}
+	if m := dropIndexRe.FindStringSubmatch(stmt); m != nil {
+		for _, name := range objectNames(m[1]) {
+			delete(s.indexes, name)
+		}
+		return true
+	}
+	if m := createEnumRe.FindStringSubmatch(stmt); m != nil {
+		name := objectName(m[1])
+		s.enums[name] = &common.Enum{Name: name, Values: enumValues(m[2])}
+		return true
+	}
+	if m := alterEnumAddRe.FindStringSubmatch(stmt); m != nil {
+		s.addEnumValue(objectName(m[1]), strings.ReplaceAll(m[2], "''", "'"), strings.ToLower(m[3]), strings.ReplaceAll(m[4], "''", "'"), fileName)
+		return true
+	}
+	if m := dropTypeRe.FindStringSubmatch(stmt); m != nil {
+		for _, name := range objectNames(m[1]) {
+			delete(s.enums, name)
+		}
+		return true
+	}
+	if m := createViewRe.FindStringSubmatch(stmt); m != nil {
+		name := objectName(m[2])
+		s.views[name] = &common.View{Name: name, Materialized: m[1] != "", SQL: stmt}
+		return true
+	}
+	if m := dropViewRe.FindStringSubmatch(stmt); m != nil {
+		for _, name := range objectNames(m[2]) {
+			delete(s.views, name)
+		}
+		return true
+	}
+	if loc := createFunctionRe.FindStringSubmatchIndex(stmt); loc != nil {
+		name := objectName(stmt[loc[2]:loc[3]])
+		args := strings.TrimSpace(functionArgs(stmt, loc[1]))
+		s.functions[functionKey(name, args)] = &common.Function{Name: name, Args: args, SQL: stmt}
+		return true
+	}
+	if m := dropFunctionRe.FindStringSubmatch(stmt); m != nil {
+		s.dropFunction(objectName(m[1]), m[2])
+		return true
+	}
+	return false
+}
+
+func (s *schemaState) addEnumValue(name, value, position, neighbour, fileName string) {
+	enum, ok := s.enums[name]
+	if !ok {
+		log.Printf("WARNING: Enum '%s' not found in schema state while adding value '%s' from %s.", name, value, fileName)
+		return
+	}
+	if slices.Contains(enum.Values, value) {
+		return
+	}
+	idx := slices.Index(enum.Values, neighbour)
+	switch {
+	case position == "before" && idx != -1:
+		enum.Values = slices.Insert(enum.Values, idx, value)
+	case position == "after" && idx != -1:
+		enum.Values = slices.Insert(enum.Values, idx+1, value)
+	default:
+		enum.Values = append(enum.Values, value)
+	}
+}
+
+// dropFunction removes the overload with the given arguments. DROP FUNCTION may list only
+// the argument types, so a sole overload of the name is dropped even if the lists differ
+func (s *schemaState) dropFunction(name, args string) {
+	if _, ok := s.functions[functionKey(name, args)]; ok {
+		delete(s.functions, functionKey(name, args))
+		return
+	}
+	var overloads []string
+	for key, fn := range s.functions {
+		if fn.Name == name {
+			overloads = append(overloads, key)
+		}
+	}
+	if strings.TrimSpace(args) == "" || len(overloads) == 1 {
+		for _, key := range overloads {
+			delete(s.functions, key)
+		}
+	}
+}
+
 func getConstraintName(def tree.TableDef) (string, bool) {
 	switch d := def.(type) {
 	case *tree.UniqueConstraintTableDef:
@@ -189,15 +315,6 @@ func (s *schemaState) updateColumnState(table *tree.CreateTable, cmd interface{}
 	}
 }
 
-func (s *schemaState) updateIndexState(node *tree.CreateIndex, timestamp int64, fileName string) {
-	indexName := strings.ToLower(node.Name.String())
-	s.indexes[indexName] = &indexState{
-		node:      node,
-		timestamp: timestamp,
-		lastFile:  fileName,
-	}
-}
-
 func (s *schemaState) buildSchema() *common.Schema {
 	schema := common.NewSchema()
 
@@ -211,9 +328,20 @@ func (s *schemaState) buildSchema() *common.Schema {
 		schema.Tables[tableName] = t
 	}
 
+	for name, table := range s.rawTables {
+		schema.RawTables[name] = table
+	}
 	for name, idx := range s.indexes {
-		// For now, assume indexes persist unless explicitly dropped (DROP INDEX not handled yet)
-		schema.Indexes[name] = idx.node
+		schema.Indexes[name] = idx
+	}
+	for name, enum := range s.enums {
+		schema.Enums[name] = enum
+	}
+	for name, view := range s.views {
+		schema.Views[name] = view
+	}
+	for key, fn := range s.functions {
+		schema.Functions[key] = fn
 	}
 
 	return schema
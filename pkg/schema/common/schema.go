@@ -13,25 +13,94 @@ type SchemaObject interface{}
 // Schema represents a database schema containing all objects
 type Schema struct {
 	Tables  map[string]*tree.CreateTable
-	Indexes map[string]*tree.CreateIndex
+	Indexes map[string]*Index
 	Columns map[string]map[string]*tree.ColumnTableDef
+	Enums   map[string]*Enum
+	Views   map[string]*View
+	// Functions are keyed by name and argument list, so overloads are distinct objects
+	Functions map[string]*Function
+	// RawTables holds the CREATE TABLE statements the parser cannot read (e.g. columns of enum types).
+	// They can be created and kept, but not diffed column by column
+	RawTables map[string]string
 }
 
 // NewSchema creates a new empty schema
 func NewSchema() *Schema {
 	return &Schema{
-		Tables:  make(map[string]*tree.CreateTable),
-		Indexes: make(map[string]*tree.CreateIndex),
-		Columns: make(map[string]map[string]*tree.ColumnTableDef),
+		Tables:    make(map[string]*tree.CreateTable),
+		Indexes:   make(map[string]*Index),
+		Columns:   make(map[string]map[string]*tree.ColumnTableDef),
+		Enums:     make(map[string]*Enum),
+		Views:     make(map[string]*View),
+		Functions: make(map[string]*Function),
+		RawTables: make(map[string]string),
 	}
 }
 
+// Index is a CREATE INDEX statement. It is kept as SQL because partial and expression
+// indexes are beyond the parser
+type Index struct {
+	Name   string
+	Table  string
+	Unique bool
+	SQL    string
+}
+
+// Enum is a type created with CREATE TYPE ... AS ENUM
+type Enum struct {
+	Name   string
+	Values []string
+}
+
+// View is a plain or materialized view kept as its CREATE statement
+type View struct {
+	Name         string
+	Materialized bool
+	SQL          string
+}
+
+// Function is a CREATE FUNCTION statement. Args is the argument list as written,
+// which is enough to address the function in DROP FUNCTION
+type Function struct {
+	Name string
+	Args string
+	SQL  string
+}
+
+// RawChange is a change that is rendered from SQL text instead of a parser node
+type RawChange struct {
+	// Kind names the change in the migration file, e.g. CREATE_INDEX or DROP_VIEW
+	Kind   string
+	Object string
+	SQL    string
+}
+
+// DestructiveChange describes an up change that can lose data when applied
+type DestructiveChange struct {
+	// Index is the position of the change in ChangeSet.Changes
+	Index  int
+	Object string
+	Reason string
+}
+
 // ChangeSet represents a collection of related schema changes
 type ChangeSet struct {
 	Changes   []interface{}
 	Timestamp int64
 	Version   string
 	Hash      string
+	// Destructive lists the changes that drop data or narrow column types
+	Destructive []DestructiveChange
+}
+
+// DestructiveReason returns the reason the change at the given index is destructive
+func (c *ChangeSet) DestructiveReason(index int) (string, bool) {
+	for _, d := range c.Destructive {
+		if d.Index == index {
+			return d.Reason, true
+		}
+	}
+	return "", false
 }
 
 // NewChangeSet creates a new empty change set
@@ -53,6 +122,10 @@ func HasReferences(table *tree.CreateTable) bool {
 }
 
 func AllReferencesSatisfied(t *tree.CreateTable, tables []*tree.CreateTable) bool {
+	return allReferencesSatisfied(t, tables, nil)
+}
+
+func allReferencesSatisfied(t *tree.CreateTable, tables []*tree.CreateTable, existing map[string]bool) bool {
 	for _, def := range t.Defs {
 		colDef, ok := def.(*tree.ColumnTableDef)
 		if !ok {
@@ -65,6 +138,9 @@ func AllReferencesSatisfied(t *tree.CreateTable, tables []*tree.CreateTable) boo
 		if colDef.References.Table.String() == t.Table.String() {
 			continue
 		}
+		if existing[colDef.References.Table.String()] {
+			continue
+		}
 
 		found := false
 		for _, table := range tables {
@@ -81,6 +157,12 @@ func AllReferencesSatisfied(t *tree.CreateTable, tables []*tree.CreateTable) boo
 }
 
 func SortTableDefs(tables []*tree.CreateTable) ([]*tree.CreateTable, error) {
+	return SortTableDefsAfter(tables, nil)
+}
+
+// SortTableDefsAfter orders tables so that referenced tables come first. References to existing
+// tables are satisfied already, which lets the new tables of a schema be sorted on their own
+func SortTableDefsAfter(tables []*tree.CreateTable, existing map[string]bool) ([]*tree.CreateTable, error) {
 	var result []*tree.CreateTable
 	processed := make(map[string]bool)
 
@@ -102,7 +184,7 @@ func SortTableDefs(tables []*tree.CreateTable) ([]*tree.CreateTable, error) {
 			if processed[refTable.Table.String()] {
 				continue
 			}
-			if AllReferencesSatisfied(refTable, result) {
+			if allReferencesSatisfied(refTable, result, existing) {
 				result = append(result, refTable)
 				processed[refTable.Table.String()] = true
 				progress = true
@@ -127,7 +209,7 @@ func SortTableDefs(tables []*tree.CreateTable) ([]*tree.CreateTable, error) {
 						continue
 					}
 					refName := colDef.References.Table.String()
-					if !knownTables[refName] {
+					if !knownTables[refName] && !existing[refName] {
 						missing = append(missing, refName)
 					}
 				}
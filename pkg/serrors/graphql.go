@@ -1,6 +1,9 @@
 package serrors
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
@@ -14,3 +17,51 @@ func UnauthorizedGQLError(path ast.Path) *gqlerror.Error {
 		},
 	}
 }
+
+// ValidationGQLError reports the localized messages of the fields that failed validation
+func ValidationGQLError(path ast.Path, fields map[string]string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Path:    path,
+		Message: "validation failed",
+		Extensions: map[string]interface{}{
+			"code":   "VALIDATION_FAILED",
+			"fields": fields,
+		},
+	}
+}
+
+// ComplexityLimitGQLError rejects an operation whose cost is over the limit of the schema
+func ComplexityLimitGQLError(complexity, limit int) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: fmt.Sprintf("operation has complexity %d, which exceeds the limit of %d", complexity, limit),
+		Extensions: map[string]interface{}{
+			"code":       "COMPLEXITY_LIMIT_EXCEEDED",
+			"complexity": complexity,
+			"limit":      limit,
+		},
+	}
+}
+
+// DepthLimitGQLError rejects an operation that nests selections deeper than the limit of the schema
+func DepthLimitGQLError(depth, limit int) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: fmt.Sprintf("operation has depth %d, which exceeds the limit of %d", depth, limit),
+		Extensions: map[string]interface{}{
+			"code":  "DEPTH_LIMIT_EXCEEDED",
+			"depth": depth,
+			"limit": limit,
+		},
+	}
+}
+
+// RateLimitGQLError rejects an operation of a user or token that ran out of requests
+func RateLimitGQLError(retryAfter time.Duration) *gqlerror.Error {
+	err := NewRateLimitError(retryAfter)
+	return &gqlerror.Error{
+		Message: err.Message,
+		Extensions: map[string]interface{}{
+			"code":       err.Code,
+			"retryAfter": retryAfterSeconds(retryAfter),
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package serrors
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// NewRateLimitError is returned to users and API tokens that made too many requests
+func NewRateLimitError(retryAfter time.Duration) *BaseError {
+	seconds := strconv.Itoa(retryAfterSeconds(retryAfter))
+	return NewError(
+		"RATE_LIMITED",
+		"too many requests, retry in "+seconds+"s",
+		"Errors.TooManyRequests",
+	).WithTemplateData(map[string]string{"RetryAfter": seconds})
+}
+
+// retryAfterSeconds rounds up so that clients honoring Retry-After don't come back too early
+func retryAfterSeconds(d time.Duration) int {
+	return int(math.Max(1, math.Ceil(d.Seconds())))
+}
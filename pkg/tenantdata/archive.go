@@ -0,0 +1,89 @@
+package tenantdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+// ArchiveVersion is bumped whenever the layout of the archive changes
+const ArchiveVersion = 1
+
+// The archive is a zip file with the manifest, one JSON lines file per table and the referenced files
+const (
+	manifestEntry = "manifest.json"
+	tablesDir     = "tables/"
+	filesDir      = "files/"
+)
+
+var (
+	ErrNoManifest         = errors.New("tenantdata: archive has no manifest")
+	ErrUnsupportedVersion = errors.New("tenantdata: unsupported archive version")
+)
+
+type Manifest struct {
+	Version    int             `json:"version"`
+	TenantID   string          `json:"tenant_id"`
+	TenantName string          `json:"tenant_name"`
+	Domain     string          `json:"domain"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Tables     []ManifestTable `json:"tables"`
+	Files      int             `json:"files"`
+}
+
+type ManifestTable struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+func tableEntry(table string) string {
+	return tablesDir + table + ".jsonl"
+}
+
+func fileEntry(filePath string) string {
+	return filesDir + strings.TrimPrefix(path.Clean("/"+filePath), "/")
+}
+
+type columnInfo struct {
+	dataType  string
+	serial    bool
+	generated bool
+}
+
+const columnsQuery = `
+	SELECT table_name, column_name, data_type, COALESCE(column_default, ''), is_identity = 'YES', is_generated = 'ALWAYS'
+	FROM information_schema.columns
+	WHERE table_schema = current_schema()`
+
+// loadColumns reads the columns of the tables of the database, they may differ from the schema files
+// when the database is behind or ahead of the code
+func loadColumns(ctx context.Context, db repo.Tx) (map[string]map[string]columnInfo, error) {
+	rows, err := db.Query(ctx, columnsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("tenantdata: failed to read columns: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]map[string]columnInfo)
+	for rows.Next() {
+		var table, column, dataType, def string
+		var identity, generated bool
+		if err := rows.Scan(&table, &column, &dataType, &def, &identity, &generated); err != nil {
+			return nil, err
+		}
+		if result[table] == nil {
+			result[table] = make(map[string]columnInfo)
+		}
+		result[table][column] = columnInfo{
+			dataType:  dataType,
+			serial:    identity || strings.HasPrefix(def, "nextval("),
+			generated: generated,
+		}
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,162 @@
+package tenantdata
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+type ExportOptions struct {
+	// FileColumns maps tables to the column holding the path of a file, the files are put into the archive
+	FileColumns map[string]string
+	ReadFile    func(ctx context.Context, path string) ([]byte, error)
+	// SkipTables are left out of the archive, e.g. tables of short lived tokens
+	SkipTables []string
+	Logger     logrus.FieldLogger
+}
+
+// Export writes the rows of the tenant and the files they reference to w as a zip archive
+func Export(ctx context.Context, db repo.Tx, g *Graph, tenantID uuid.UUID, w io.Writer, opts ExportOptions) (*Manifest, error) {
+	columns, err := loadColumns(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	zw := zip.NewWriter(w)
+	manifest := &Manifest{
+		Version:    ArchiveVersion,
+		TenantID:   tenantID.String(),
+		ExportedAt: time.Now(),
+	}
+	var files []string
+	seen := make(map[string]bool)
+
+	for _, t := range g.Tables() {
+		if slices.Contains(opts.SkipTables, t.Name) {
+			continue
+		}
+		if _, ok := columns[t.Name]; !ok {
+			useLogger(opts.Logger).WithField("table", t.Name).Warn("tenantdata: table is not in the database, skipping")
+			continue
+		}
+		entry, err := zw.Create(tableEntry(t.Name))
+		if err != nil {
+			return nil, err
+		}
+		fileColumn := opts.FileColumns[t.Name]
+		count := 0
+		err = exportRows(ctx, db, g, t, tenantID, func(row []byte) error {
+			if _, err := entry.Write(append(row, '\n')); err != nil {
+				return err
+			}
+			count++
+			if t.Name == TenantsTable {
+				var tenantRow struct {
+					Name   string `json:"name"`
+					Domain string `json:"domain"`
+				}
+				if err := json.Unmarshal(row, &tenantRow); err == nil {
+					manifest.TenantName = tenantRow.Name
+					manifest.Domain = tenantRow.Domain
+				}
+			}
+			if fileColumn == "" {
+				return nil
+			}
+			var values map[string]interface{}
+			if err := json.Unmarshal(row, &values); err != nil {
+				return err
+			}
+			if filePath, ok := values[fileColumn].(string); ok && filePath != "" && !seen[filePath] {
+				seen[filePath] = true
+				files = append(files, filePath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("tenantdata: failed to export %s: %w", t.Name, err)
+		}
+		manifest.Tables = append(manifest.Tables, ManifestTable{Name: t.Name, Rows: count})
+	}
+
+	if opts.ReadFile != nil {
+		for _, filePath := range files {
+			data, err := opts.ReadFile(ctx, filePath)
+			if err != nil {
+				// A missing file should not make the rest of the data unrecoverable
+				useLogger(opts.Logger).WithError(err).WithField("path", filePath).Warn("tenantdata: failed to read file, skipping")
+				continue
+			}
+			entry, err := zw.Create(fileEntry(filePath))
+			if err != nil {
+				return nil, err
+			}
+			if _, err := entry.Write(data); err != nil {
+				return nil, err
+			}
+			manifest.Files++
+		}
+	}
+
+	entry, err := zw.Create(manifestEntry)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewEncoder(entry).Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func exportRows(ctx context.Context, db repo.Tx, g *Graph, t *Table, tenantID uuid.UUID, fn func(row []byte) error) error {
+	query := fmt.Sprintf(
+		"SELECT row_to_json(t)::text FROM %s t WHERE %s",
+		pgx.Identifier{t.Name}.Sanitize(),
+		g.Predicate(t, "t"),
+	)
+	// Ordered by primary key so that parents usually come before the rows referencing them
+	if len(t.PrimaryKey) > 0 {
+		keys := make([]string, 0, len(t.PrimaryKey))
+		for _, k := range t.PrimaryKey {
+			keys = append(keys, "t."+pgx.Identifier{k}.Sanitize())
+		}
+		query += " ORDER BY " + strings.Join(keys, ", ")
+	}
+	rows, err := db.Query(ctx, query, tenantID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return err
+		}
+		if err := fn([]byte(row)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// useLogger falls back to the standard logger when the options have none
+func useLogger(l logrus.FieldLogger) logrus.FieldLogger {
+	if l == nil {
+		return logrus.StandardLogger()
+	}
+	return l
+}
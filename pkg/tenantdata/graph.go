@@ -0,0 +1,294 @@
+// Package tenantdata moves the data of a single tenant between databases.
+//
+// The tables of a tenant are derived from the schema files registered by the
+// modules: the tenants table itself, every table with a tenant_id column and
+// every table without one that references the rows of a tenant table (join
+// tables such as role_permissions). Export writes their rows together with the
+// referenced files into a zip archive, Import inserts them into another
+// database in foreign key order, giving serial and uuid primary keys new
+// values and rewriting the foreign keys that point at them.
+package tenantdata
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iota-uz/psql-parser/sql/sem/tree"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/pkg/schema/collector"
+	"github.com/iota-uz/iota-sdk/pkg/schema/common"
+)
+
+const (
+	// TenantsTable is the root of the graph, its row is matched by id
+	TenantsTable = "tenants"
+	tenantColumn = "tenant_id"
+)
+
+// Reference is a single column foreign key
+type Reference struct {
+	Column    string
+	Table     string
+	RefColumn string
+	NotNull   bool
+}
+
+// Table is a table holding tenant rows
+type Table struct {
+	Name       string
+	Columns    []string
+	PrimaryKey []string
+	References []Reference
+	// Parent is the reference rows are matched to the tenant through when the table has no tenant_id column
+	Parent *Reference
+}
+
+func (t *Table) HasColumn(name string) bool {
+	for _, c := range t.Columns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Graph holds the tenant tables in the order their rows can be inserted in
+type Graph struct {
+	tables []*Table
+	byName map[string]*Table
+}
+
+// LoadGraph builds the graph from the schema files of the modules
+func LoadGraph(ctx context.Context, schemaFSs []*embed.FS, logger logrus.FieldLogger) (*Graph, error) {
+	loader := collector.NewFileLoader(collector.LoaderConfig{
+		EmbedFSs: schemaFSs,
+		Logger:   logger,
+	})
+	schema, err := loader.LoadModuleSchema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tenantdata: failed to load module schema: %w", err)
+	}
+	return NewGraph(schema)
+}
+
+// NewGraph picks the tenant tables of schema and sorts them by their foreign keys.
+// Tables that reference each other are ordered by name, the references that point
+// forward are filled in by Import once the referenced rows exist.
+func NewGraph(schema *common.Schema) (*Graph, error) {
+	all := make(map[string]*Table, len(schema.Tables))
+	for _, ct := range schema.Tables {
+		t := tableFromDef(ct)
+		all[t.Name] = t
+	}
+	if _, ok := all[TenantsTable]; !ok {
+		return nil, fmt.Errorf("tenantdata: schema has no %s table", TenantsTable)
+	}
+	// REFERENCES without a column point at the primary key
+	for _, t := range all {
+		for i := range t.References {
+			ref := &t.References[i]
+			if ref.RefColumn != "" {
+				continue
+			}
+			ref.RefColumn = "id"
+			if target, ok := all[ref.Table]; ok && len(target.PrimaryKey) == 1 {
+				ref.RefColumn = target.PrimaryKey[0]
+			}
+		}
+	}
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// A table belongs to the tenant if it has a tenant_id column, or references a table that does.
+	// Resolving parents repeats until nothing changes so that chains of join tables are picked up.
+	scoped := map[string]bool{TenantsTable: true}
+	for _, name := range names {
+		if all[name].HasColumn(tenantColumn) {
+			scoped[name] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			t := all[name]
+			if scoped[name] {
+				continue
+			}
+			if parent := pickParent(t, scoped); parent != nil {
+				t.Parent = parent
+				scoped[name] = true
+				changed = true
+			}
+		}
+	}
+
+	g := &Graph{byName: make(map[string]*Table, len(scoped))}
+	for name := range scoped {
+		g.byName[name] = all[name]
+	}
+	g.tables = sortTables(g.byName)
+	return g, nil
+}
+
+// Tables returns the tenant tables in insert order, the tenants table comes first
+func (g *Graph) Tables() []*Table {
+	return g.tables
+}
+
+func (g *Graph) Table(name string) (*Table, bool) {
+	t, ok := g.byName[name]
+	return t, ok
+}
+
+// Predicate returns the condition that matches the rows of the tenant passed as $1, alias is the alias of table
+func (g *Graph) Predicate(table *Table, alias string) string {
+	return g.predicate(table, alias, 0)
+}
+
+func (g *Graph) predicate(table *Table, alias string, depth int) string {
+	if table.Name == TenantsTable {
+		return fmt.Sprintf("%s.id = $1", alias)
+	}
+	if table.Parent == nil {
+		return fmt.Sprintf("%s.%s = $1", alias, tenantColumn)
+	}
+	parent := g.byName[table.Parent.Table]
+	parentAlias := fmt.Sprintf("p%d", depth)
+	return fmt.Sprintf(
+		"%s.%s IN (SELECT %s.%s FROM %s %s WHERE %s)",
+		alias,
+		pgx.Identifier{table.Parent.Column}.Sanitize(),
+		parentAlias,
+		pgx.Identifier{table.Parent.RefColumn}.Sanitize(),
+		pgx.Identifier{parent.Name}.Sanitize(),
+		parentAlias,
+		g.predicate(parent, parentAlias, depth+1),
+	)
+}
+
+// pickParent prefers NOT NULL references so that rows with an empty reference are not lost
+func pickParent(t *Table, scoped map[string]bool) *Reference {
+	var nullable *Reference
+	for i := range t.References {
+		ref := &t.References[i]
+		if ref.Table == t.Name || !scoped[ref.Table] {
+			continue
+		}
+		if ref.NotNull {
+			return ref
+		}
+		if nullable == nil {
+			nullable = ref
+		}
+	}
+	return nullable
+}
+
+func sortTables(tables map[string]*Table) []*Table {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	done := make(map[string]bool, len(names))
+	result := make([]*Table, 0, len(names))
+	ready := func(t *Table) bool {
+		for _, ref := range t.References {
+			if ref.Table == t.Name {
+				continue
+			}
+			if _, ok := tables[ref.Table]; ok && !done[ref.Table] {
+				return false
+			}
+		}
+		return true
+	}
+	for len(result) < len(names) {
+		progress := false
+		for _, name := range names {
+			if done[name] || !ready(tables[name]) {
+				continue
+			}
+			done[name] = true
+			result = append(result, tables[name])
+			progress = true
+		}
+		if progress {
+			continue
+		}
+		// A cycle, the first table left is inserted with its forward references deferred
+		for _, name := range names {
+			if !done[name] {
+				done[name] = true
+				result = append(result, tables[name])
+				break
+			}
+		}
+	}
+	return result
+}
+
+func tableFromDef(ct *tree.CreateTable) *Table {
+	t := &Table{Name: normalizeName(ct.Table.Table())}
+	for _, def := range ct.Defs {
+		switch d := def.(type) {
+		case *tree.ColumnTableDef:
+			name := normalizeName(string(d.Name))
+			t.Columns = append(t.Columns, name)
+			if d.PrimaryKey.IsPrimaryKey {
+				t.PrimaryKey = []string{name}
+			}
+			if d.References.Table != nil {
+				t.References = append(t.References, Reference{
+					Column:    name,
+					Table:     normalizeName(d.References.Table.Table()),
+					RefColumn: normalizeName(string(d.References.Col)),
+					NotNull:   d.Nullable.Nullability == tree.NotNull || d.PrimaryKey.IsPrimaryKey,
+				})
+			}
+		case *tree.UniqueConstraintTableDef:
+			if d.PrimaryKey {
+				t.PrimaryKey = nil
+				for _, col := range d.Columns {
+					t.PrimaryKey = append(t.PrimaryKey, normalizeName(string(col.Column)))
+				}
+			}
+		case *tree.ForeignKeyConstraintTableDef:
+			// Composite foreign keys keep their values, they point at natural keys
+			if len(d.FromCols) != 1 {
+				continue
+			}
+			ref := Reference{
+				Column: normalizeName(string(d.FromCols[0])),
+				Table:  normalizeName(d.Table.Table()),
+			}
+			if len(d.ToCols) == 1 {
+				ref.RefColumn = normalizeName(string(d.ToCols[0]))
+			}
+			t.References = append(t.References, ref)
+		}
+	}
+	// The NOT NULL of table level foreign keys is only known once all columns are read
+	for i := range t.References {
+		for _, def := range ct.Defs {
+			if d, ok := def.(*tree.ColumnTableDef); ok && normalizeName(string(d.Name)) == t.References[i].Column {
+				t.References[i].NotNull = t.References[i].NotNull || d.Nullable.Nullability == tree.NotNull
+			}
+		}
+	}
+	return t
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Trim(name, `"`))
+}
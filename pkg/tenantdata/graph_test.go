@@ -0,0 +1,159 @@
+package tenantdata
+
+import (
+	"testing"
+
+	"github.com/iota-uz/psql-parser/sql/parser"
+	"github.com/iota-uz/psql-parser/sql/sem/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/schema/common"
+)
+
+const testSchema = `
+CREATE TABLE tenants (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name varchar(255) NOT NULL UNIQUE
+);
+
+CREATE TABLE uploads (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    path varchar(1024) NOT NULL
+);
+
+CREATE TABLE users (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    avatar_id int REFERENCES uploads (id) ON DELETE SET NULL,
+    manager_id int REFERENCES users (id)
+);
+
+CREATE TABLE roles (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE
+);
+
+CREATE TABLE user_roles (
+    user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role_id int NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE TABLE role_notes (
+    id serial PRIMARY KEY,
+    user_role_user_id int,
+    role_id int,
+    CONSTRAINT role_notes_role_fk FOREIGN KEY (role_id) REFERENCES roles (id)
+);
+
+CREATE TABLE currencies (
+    code varchar(3) PRIMARY KEY,
+    name varchar(255) NOT NULL
+);
+`
+
+func parseSchema(t *testing.T, sql string) *common.Schema {
+	t.Helper()
+	stmts, err := parser.Parse(sql)
+	require.NoError(t, err)
+	schema := common.NewSchema()
+	for _, stmt := range stmts {
+		if ct, ok := stmt.AST.(*tree.CreateTable); ok {
+			schema.Tables[ct.Table.Table()] = ct
+		}
+	}
+	return schema
+}
+
+func tableNames(tables []*Table) []string {
+	names := make([]string, 0, len(tables))
+	for _, t := range tables {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// assertInsertOrder checks that every table comes after the tables it references
+func assertInsertOrder(t *testing.T, g *Graph) {
+	t.Helper()
+	position := make(map[string]int)
+	for i, table := range g.Tables() {
+		position[table.Name] = i
+	}
+	for _, table := range g.Tables() {
+		for _, ref := range table.References {
+			if target, ok := position[ref.Table]; ok && ref.Table != table.Name {
+				assert.Less(t, target, position[table.Name], "%s references %s", table.Name, ref.Table)
+			}
+		}
+	}
+}
+
+func TestNewGraph(t *testing.T) {
+	g, err := NewGraph(parseSchema(t, testSchema))
+	require.NoError(t, err)
+
+	names := tableNames(g.Tables())
+	assert.ElementsMatch(t, []string{"tenants", "uploads", "users", "roles", "user_roles", "role_notes"}, names)
+	assert.Equal(t, "tenants", names[0])
+	assertInsertOrder(t, g)
+
+	_, ok := g.Table("currencies")
+	assert.False(t, ok, "tables without tenant rows are not exported")
+
+	users, ok := g.Table("users")
+	require.True(t, ok)
+	assert.Equal(t, []string{"id"}, users.PrimaryKey)
+	assert.Nil(t, users.Parent)
+	assert.Contains(t, users.References, Reference{Column: "manager_id", Table: "users", RefColumn: "id"})
+
+	userRoles, ok := g.Table("user_roles")
+	require.True(t, ok)
+	assert.Equal(t, []string{"user_id", "role_id"}, userRoles.PrimaryKey)
+	require.NotNil(t, userRoles.Parent)
+	assert.Equal(t, "users", userRoles.Parent.Table, "the first NOT NULL reference is the parent")
+
+	roleNotes, ok := g.Table("role_notes")
+	require.True(t, ok)
+	require.NotNil(t, roleNotes.Parent)
+	assert.Equal(t, Reference{Column: "role_id", Table: "roles", RefColumn: "id"}, *roleNotes.Parent)
+}
+
+func TestNewGraph_NoTenants(t *testing.T) {
+	_, err := NewGraph(parseSchema(t, `CREATE TABLE currencies (code varchar(3) PRIMARY KEY);`))
+	require.Error(t, err)
+}
+
+func TestGraph_Predicate(t *testing.T) {
+	g, err := NewGraph(parseSchema(t, testSchema))
+	require.NoError(t, err)
+
+	tenants, _ := g.Table("tenants")
+	assert.Equal(t, "t.id = $1", g.Predicate(tenants, "t"))
+
+	users, _ := g.Table("users")
+	assert.Equal(t, "t.tenant_id = $1", g.Predicate(users, "t"))
+
+	userRoles, _ := g.Table("user_roles")
+	assert.Equal(t, `t."user_id" IN (SELECT p0."id" FROM "users" p0 WHERE p0.tenant_id = $1)`, g.Predicate(userRoles, "t"))
+}
+
+func TestNewGraph_Cycle(t *testing.T) {
+	g, err := NewGraph(parseSchema(t, `
+CREATE TABLE tenants (id uuid PRIMARY KEY);
+CREATE TABLE departments (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id),
+    head_id int REFERENCES employees (id)
+);
+CREATE TABLE employees (
+    id serial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id),
+    department_id int NOT NULL REFERENCES departments (id)
+);
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tenants", "departments", "employees"}, tableNames(g.Tables()))
+}
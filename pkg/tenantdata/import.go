@@ -0,0 +1,348 @@
+package tenantdata
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+// maxRowSize limits a single JSON line of a table file
+const maxRowSize = 64 << 20
+
+type ImportOptions struct {
+	// TenantID is the id the tenant is created with, the transaction is expected to be scoped to it
+	TenantID uuid.UUID
+	// Name and Domain replace the ones of the exported tenant when set
+	Name   string
+	Domain string
+	// WriteFile stores a file of the archive at the path the rows refer to it by
+	WriteFile func(ctx context.Context, path string, data []byte) error
+	Logger    logrus.FieldLogger
+}
+
+type ImportResult struct {
+	Manifest *Manifest
+	Rows     map[string]int
+	Files    int
+}
+
+// deferredRef is a reference to a row that was not inserted yet when the referencing row was
+type deferredRef struct {
+	table  *Table
+	column string
+	target string
+	oldRef interface{}
+	pk     interface{}
+}
+
+type importer struct {
+	db       repo.Tx
+	graph    *Graph
+	opts     ImportOptions
+	columns  map[string]map[string]columnInfo
+	ids      map[string]map[string]interface{}
+	done     map[string]bool
+	deferred []deferredRef
+}
+
+// Import inserts the rows of an archive written by Export as a new tenant. Serial and uuid primary keys
+// get new values and the foreign keys pointing at them are rewritten, other values are kept as they are.
+func Import(ctx context.Context, db repo.Tx, g *Graph, r io.ReaderAt, size int64, opts ImportOptions) (*ImportResult, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("tenantdata: failed to open archive: %w", err)
+	}
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+	manifest, err := readManifest(entries)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := loadColumns(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &importer{
+		db:      db,
+		graph:   g,
+		opts:    opts,
+		columns: columns,
+		ids:     make(map[string]map[string]interface{}),
+		done:    make(map[string]bool),
+	}
+	result := &ImportResult{
+		Manifest: manifest,
+		Rows:     make(map[string]int),
+	}
+
+	for _, t := range g.Tables() {
+		entry, ok := entries[tableEntry(t.Name)]
+		if !ok {
+			continue
+		}
+		if _, ok := columns[t.Name]; !ok {
+			useLogger(opts.Logger).WithField("table", t.Name).Warn("tenantdata: table is not in the database, skipping")
+			continue
+		}
+		count, err := imp.importTable(ctx, t, entry)
+		if err != nil {
+			return nil, fmt.Errorf("tenantdata: failed to import %s: %w", t.Name, err)
+		}
+		imp.done[t.Name] = true
+		result.Rows[t.Name] = count
+	}
+	for name := range entries {
+		if strings.HasPrefix(name, tablesDir) {
+			table := strings.TrimSuffix(strings.TrimPrefix(name, tablesDir), ".jsonl")
+			if _, ok := g.Table(table); !ok {
+				useLogger(opts.Logger).WithField("table", table).Warn("tenantdata: table is not in the module schema, skipping")
+			}
+		}
+	}
+	if err := imp.resolveDeferred(ctx); err != nil {
+		return nil, err
+	}
+
+	if opts.WriteFile != nil {
+		for _, f := range zr.File {
+			if !strings.HasPrefix(f.Name, filesDir) || strings.HasSuffix(f.Name, "/") {
+				continue
+			}
+			data, err := readEntry(f)
+			if err != nil {
+				return nil, err
+			}
+			// Cleaned so that entries can not be written outside of the storage
+			filePath := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(f.Name, filesDir)), "/")
+			if err := opts.WriteFile(ctx, filePath, data); err != nil {
+				return nil, fmt.Errorf("tenantdata: failed to write file %s: %w", f.Name, err)
+			}
+			result.Files++
+		}
+	}
+	return result, nil
+}
+
+func (imp *importer) importTable(ctx context.Context, t *Table, entry *zip.File) (int, error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRowSize)
+	count := 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		var row map[string]interface{}
+		if err := decoder.Decode(&row); err != nil {
+			return count, err
+		}
+		if err := imp.importRow(ctx, t, row); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, scanner.Err()
+}
+
+func (imp *importer) importRow(ctx context.Context, t *Table, row map[string]interface{}) error {
+	dbColumns := imp.columns[t.Name]
+	if t.Name == TenantsTable {
+		if imp.opts.Name != "" {
+			row["name"] = imp.opts.Name
+		}
+		if imp.opts.Domain != "" {
+			row["domain"] = imp.opts.Domain
+		}
+	}
+	if _, ok := row[tenantColumn]; ok && row[tenantColumn] != nil {
+		row[tenantColumn] = imp.opts.TenantID.String()
+	}
+
+	var pending []deferredRef
+	for _, ref := range t.References {
+		value := row[ref.Column]
+		if value == nil || !imp.remapped(ref) {
+			continue
+		}
+		if mapped, ok := imp.ids[ref.Table][key(value)]; ok {
+			row[ref.Column] = mapped
+			continue
+		}
+		if imp.done[ref.Table] {
+			// The referenced row was not exported, e.g. it belongs to another tenant
+			continue
+		}
+		if ref.NotNull {
+			return fmt.Errorf("reference %s.%s to %s can not be deferred", t.Name, ref.Column, ref.Table)
+		}
+		row[ref.Column] = nil
+		pending = append(pending, deferredRef{table: t, column: ref.Column, target: ref.Table, oldRef: value})
+	}
+
+	var pk string
+	var oldPK, newPK interface{}
+	if len(t.PrimaryKey) == 1 {
+		pk = t.PrimaryKey[0]
+		oldPK = row[pk]
+		switch info := dbColumns[pk]; {
+		case t.Name == TenantsTable:
+			newPK = imp.opts.TenantID.String()
+			row[pk] = newPK
+		case info.serial:
+			delete(row, pk)
+		case info.dataType == "uuid" && oldPK != nil:
+			newPK = uuid.New().String()
+			row[pk] = newPK
+		default:
+			newPK = oldPK
+		}
+	}
+
+	cols := make([]string, 0, len(row))
+	for col := range row {
+		if info, ok := dbColumns[col]; ok && !info.generated {
+			cols = append(cols, pgx.Identifier{col}.Sanitize())
+		}
+	}
+	sort.Strings(cols)
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf(
+		"INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM json_populate_record(NULL::%[1]s, $1::json)",
+		pgx.Identifier{t.Name}.Sanitize(),
+		strings.Join(cols, ", "),
+	)
+	if pk != "" && newPK == nil {
+		query += " RETURNING " + pgx.Identifier{pk}.Sanitize()
+		if err := imp.db.QueryRow(ctx, query, string(data)).Scan(&newPK); err != nil {
+			return err
+		}
+	} else if _, err := imp.db.Exec(ctx, query, string(data)); err != nil {
+		return err
+	}
+
+	if pk != "" && oldPK != nil {
+		if imp.ids[t.Name] == nil {
+			imp.ids[t.Name] = make(map[string]interface{})
+		}
+		imp.ids[t.Name][key(oldPK)] = newPK
+	}
+	for _, d := range pending {
+		if pk == "" {
+			return fmt.Errorf("reference %s.%s to %s can not be deferred without a primary key", t.Name, d.column, d.target)
+		}
+		d.pk = newPK
+		imp.deferred = append(imp.deferred, d)
+	}
+	return nil
+}
+
+// resolveDeferred fills in the references that pointed at rows inserted later
+func (imp *importer) resolveDeferred(ctx context.Context) error {
+	for _, d := range imp.deferred {
+		mapped, ok := imp.ids[d.target][key(d.oldRef)]
+		if !ok {
+			continue
+		}
+		pk := d.table.PrimaryKey[0]
+		data, err := json.Marshal(map[string]interface{}{pk: d.pk, d.column: mapped})
+		if err != nil {
+			return err
+		}
+		query := fmt.Sprintf(
+			"UPDATE %[1]s AS t SET %[2]s = r.%[2]s FROM json_populate_record(NULL::%[1]s, $1::json) r WHERE t.%[3]s = r.%[3]s",
+			pgx.Identifier{d.table.Name}.Sanitize(),
+			pgx.Identifier{d.column}.Sanitize(),
+			pgx.Identifier{pk}.Sanitize(),
+		)
+		if _, err := imp.db.Exec(ctx, query, string(data)); err != nil {
+			return fmt.Errorf("tenantdata: failed to update %s.%s: %w", d.table.Name, d.column, err)
+		}
+	}
+	return nil
+}
+
+// remapped reports whether the reference points at a primary key that gets new values on import
+func (imp *importer) remapped(ref Reference) bool {
+	target, ok := imp.graph.Table(ref.Table)
+	if !ok || len(target.PrimaryKey) != 1 || target.PrimaryKey[0] != ref.RefColumn {
+		return false
+	}
+	if target.Name == TenantsTable {
+		return true
+	}
+	info := imp.columns[target.Name][ref.RefColumn]
+	return info.serial || info.dataType == "uuid"
+}
+
+// ReadManifest returns the manifest of an archive without importing it
+func ReadManifest(r io.ReaderAt, size int64) (*Manifest, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("tenantdata: failed to open archive: %w", err)
+	}
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+	return readManifest(entries)
+}
+
+func readManifest(entries map[string]*zip.File) (*Manifest, error) {
+	f, ok := entries[manifestEntry]
+	if !ok {
+		return nil, ErrNoManifest
+	}
+	data, err := readEntry(f)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("tenantdata: invalid manifest: %w", err)
+	}
+	if manifest.Version != ArchiveVersion {
+		return nil, errors.Join(ErrUnsupportedVersion, fmt.Errorf("got version %d", manifest.Version))
+	}
+	return &manifest, nil
+}
+
+func readEntry(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func key(v interface{}) string {
+	return fmt.Sprint(v)
+}
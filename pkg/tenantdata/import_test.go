@@ -0,0 +1,56 @@
+package tenantdata
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArchive(t *testing.T, entries map[string]interface{}) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, v := range entries {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+	require.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadManifest(t *testing.T) {
+	r := writeArchive(t, map[string]interface{}{
+		manifestEntry: Manifest{Version: ArchiveVersion, TenantName: "Acme", Domain: "acme.example.com"},
+	})
+	manifest, err := ReadManifest(r, r.Size())
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", manifest.TenantName)
+	assert.Equal(t, "acme.example.com", manifest.Domain)
+}
+
+func TestReadManifest_Errors(t *testing.T) {
+	r := writeArchive(t, map[string]interface{}{
+		tableEntry("tenants"): map[string]string{"name": "Acme"},
+	})
+	_, err := ReadManifest(r, r.Size())
+	require.ErrorIs(t, err, ErrNoManifest)
+
+	r = writeArchive(t, map[string]interface{}{
+		manifestEntry: Manifest{Version: ArchiveVersion + 1},
+	})
+	_, err = ReadManifest(r, r.Size())
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = ReadManifest(bytes.NewReader([]byte("not a zip")), 9)
+	require.Error(t, err)
+}
+
+func TestFileEntry(t *testing.T) {
+	assert.Equal(t, "files/static/uploads/a.png", fileEntry("static/uploads/a.png"))
+	assert.Equal(t, "files/etc/passwd", fileEntry("../../etc/passwd"))
+}
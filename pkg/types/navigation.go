@@ -4,6 +4,7 @@ import (
 	"github.com/a-h/templ"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
 )
 
 type NavigationItem struct {
@@ -14,12 +15,14 @@ type NavigationItem struct {
 	Permissions []*permission.Permission
 }
 
+// HasPermission reports whether user holds every permission of the item,
+// holding a permission with ModifierOwn is enough as the pages scope their rows.
 func (n NavigationItem) HasPermission(user user.User) bool {
 	if n.Permissions == nil {
 		return true
 	}
 	for _, perm := range n.Permissions {
-		if !user.Can(perm) {
+		if !rbac.CanScoped(user, perm) {
 			return false
 		}
 	}